@@ -57,10 +57,10 @@ Examples:
 
 // runAck executes the ack command.
 func runAck(cmd *cobra.Command, storage *ledger.Storage, shaArg, reason string, dryRun bool) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 
 	if storage == nil && !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return err
 	}
@@ -142,13 +142,12 @@ func resolveAcker() ledger.Acker {
 // outputAckDryRun reports what would be written without writing.
 func outputAckDryRun(printer *output.Printer, ack *ledger.Ack) error {
 	if printer.IsJSON() {
-		return printer.Success(map[string]any{
-			"status":     "dry_run",
+		return printer.Success(withPlan(map[string]any{
 			"ack_id":     ack.ID,
 			"target_sha": ack.TargetSHA,
 			"reason":     ack.Reason,
 			"acker":      ack.Acker,
-		})
+		}, []plannedAction{{Action: planCreate, Target: ack.ID, Detail: "ack for " + ack.TargetSHA}}))
 	}
 	printer.Println("Would write ack " + ack.ID)
 	printer.KeyValue("Target", ack.TargetSHA)
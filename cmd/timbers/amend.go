@@ -2,11 +2,13 @@
 package main
 
 import (
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/gorewood/timbers/internal/age"
 	"github.com/gorewood/timbers/internal/git"
 	"github.com/gorewood/timbers/internal/ledger"
 	"github.com/gorewood/timbers/internal/output"
@@ -25,6 +27,10 @@ type amendFlags struct {
 	tags         []string
 	who          []string
 	contributors []ledger.Contributor
+	metaPairs    []string
+	meta         map[string]string
+	notes        *string // set by --edit, which can also change notes
+	edit         bool
 	dryRun       bool
 }
 
@@ -36,16 +42,21 @@ func newAmendCmdInternal(storage *ledger.Storage) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "amend <entry-id>",
 		Short: "Modify an existing ledger entry",
-		Long: `Modify an existing ledger entry's summary fields or tags.
+		Long: `Modify an existing ledger entry's summary fields, tags, or meta fields.
 
 The amend command allows you to update what/why/how fields and tags on existing entries.
 Only the fields you specify will be updated; unspecified fields retain their current values.
+--meta key=value sets one custom field and leaves the others alone; --meta key= removes it.
+--edit opens what, why, how, and notes in the editor git uses, after applying
+any --what, --why, or --how; the saved text replaces them.
 The updated_at timestamp will be set to the current time when amending.
 
 Examples:
   timbers amend tb_2026-01-15T15:04:05Z_8f2c1a --what "Fixed critical auth bug"
   timbers amend tb_2026-01-15T15:04:05Z_8f2c1a --why "Updated reasoning" --how "Better approach"
   timbers amend tb_2026-01-15T15:04:05Z_8f2c1a --tag security --tag auth
+  timbers amend tb_2026-01-15T15:04:05Z_8f2c1a --meta risk=high --meta reviewer=
+  timbers amend tb_2026-01-15T15:04:05Z_8f2c1a --edit
   timbers amend tb_2026-01-15T15:04:05Z_8f2c1a --dry-run`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -58,6 +69,8 @@ Examples:
 	cmd.Flags().StringVar(&flags.how, "how", "", "Update the 'how' summary field")
 	cmd.Flags().StringSliceVar(&flags.tags, "tag", nil, "Replace tags (repeatable)")
 	cmd.Flags().StringArrayVar(&flags.who, "who", nil, "Replace contributors with Name <email> (repeatable)")
+	cmd.Flags().StringArrayVar(&flags.metaPairs, "meta", nil, "Set a custom field as key=value; key= removes it (repeatable)")
+	cmd.Flags().BoolVar(&flags.edit, "edit", false, "Edit what/why/how/notes in $EDITOR")
 	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Preview changes without writing")
 
 	return cmd
@@ -65,18 +78,30 @@ Examples:
 
 // runAmend executes the amend command.
 func runAmend(cmd *cobra.Command, storage *ledger.Storage, entryID string, flags amendFlags) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 
 	if err := validateAmendFlags(flags, printer); err != nil {
 		return err
 	}
+	meta, err := parseMetaFlags(flags.metaPairs, true)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	flags.meta = meta
 
-	storage, err := initAmendStorage(storage, printer)
+	storage, err = initAmendStorage(storage, printer)
 	if err != nil {
 		return err
 	}
 
 	entry, err := storage.GetEntryByID(entryID)
+	if err == nil {
+		err = checkAmendable(entry, flags)
+	}
+	if err == nil && flags.edit {
+		err = editAmendSummary(entry, &flags)
+	}
 	if err != nil {
 		printer.Error(err)
 		return err
@@ -106,18 +131,65 @@ func runAmend(cmd *cobra.Command, storage *ledger.Storage, entryID string, flags
 
 // validateAmendFlags checks that at least one field is being updated.
 func validateAmendFlags(flags amendFlags, printer *output.Printer) error {
-	if flags.what == "" && flags.why == "" && flags.how == "" && len(flags.tags) == 0 && len(flags.who) == 0 {
-		err := output.NewUserError("at least one field must be specified for amendment (--what, --why, --how, --tag, or --who)")
+	if flags.edit && printer.IsJSON() {
+		err := output.NewUserError("--edit opens an editor and cannot be combined with --json")
 		printer.Error(err)
 		return err
 	}
+	if !flags.edit && flags.what == "" && flags.why == "" && flags.how == "" &&
+		len(flags.tags) == 0 && len(flags.who) == 0 && len(flags.metaPairs) == 0 {
+		err := output.NewUserError("at least one field must be specified for amendment (--what, --why, --how, --tag, --who, --meta, or --edit)")
+		printer.Error(err)
+		return err
+	}
+	return nil
+}
+
+// editAmendSummary opens the entry's summary and notes in the editor, with
+// any --what, --why, or --how applied, and sets flags from the saved text.
+// The edited summary must still satisfy the entry's kind.
+func editAmendSummary(entry *ledger.Entry, flags *amendFlags) error {
+	summary := entry.Summary
+	for _, override := range []struct{ value, field *string }{
+		{&flags.what, &summary.What}, {&flags.why, &summary.Why}, {&flags.how, &summary.How},
+	} {
+		if *override.value != "" {
+			*override.field = *override.value
+		}
+	}
+	edited, err := editText(formatSummaryTemplate("Amending "+entry.ID+".", summary, entry.Notes))
+	if err != nil {
+		return err
+	}
+	summary, notes, err := parseSummaryTemplate(edited)
+	if err != nil {
+		return err
+	}
+	kind := entry.KindOrDefault()
+	switch {
+	case summary.What == "":
+		return output.NewUserError("amend aborted: the edited entry has no what")
+	case summary.Why == "" && ledger.RequiresWhy(kind):
+		return output.NewUserError("amend aborted: a " + kind + " needs a why")
+	case summary.How == "" && ledger.RequiresHow(kind):
+		return output.NewUserError("amend aborted: a " + kind + " needs a how")
+	}
+	flags.what, flags.why, flags.how, flags.notes = summary.What, summary.Why, summary.How, &notes
+	return nil
+}
+
+// checkAmendable rejects summary edits to an encrypted entry it cannot decrypt.
+func checkAmendable(entry *ledger.Entry, flags amendFlags) error {
+	if entry.IsSealed() && (flags.what != "" || flags.why != "" || flags.how != "" || flags.edit) {
+		return output.NewUserError("entry " + entry.ID + " is encrypted; set " + age.IdentityEnv + " to amend its summary")
+	}
 	return nil
 }
 
 // initAmendStorage initializes the storage, checking for git repo if needed.
 func initAmendStorage(storage *ledger.Storage, printer *output.Printer) (*ledger.Storage, error) {
 	if storage == nil && !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return nil, err
 	}
@@ -132,11 +204,10 @@ func initAmendStorage(storage *ledger.Storage, printer *output.Printer) (*ledger
 	return storage, nil
 }
 
-// amendEntry applies the amendments to the entry and returns the modified entry.
+// amendEntry applies the amendments to a copy of entry, recording a revision.
 func amendEntry(entry *ledger.Entry, flags amendFlags) *ledger.Entry {
 	// Create a copy to avoid modifying the original
 	amended := *entry
-
 	// Update summary fields if specified
 	if flags.what != "" {
 		amended.Summary.What = flags.what
@@ -147,6 +218,9 @@ func amendEntry(entry *ledger.Entry, flags amendFlags) *ledger.Entry {
 	if flags.how != "" {
 		amended.Summary.How = flags.how
 	}
+	if flags.notes != nil {
+		amended.Notes = *flags.notes
+	}
 
 	// Replace tags if specified (empty slice means clear tags)
 	if flags.tags != nil {
@@ -155,9 +229,13 @@ func amendEntry(entry *ledger.Entry, flags amendFlags) *ledger.Entry {
 	if flags.who != nil {
 		amended.Contributors = flags.contributors
 	}
+	if flags.meta != nil {
+		amended.Meta = ledger.MergeMeta(entry.Meta, flags.meta)
+	}
 
-	// Update timestamp
+	// Update timestamp and record what changed
 	amended.UpdatedAt = time.Now().UTC()
+	amended.RecordRevision(entry, resolveAcker(), amended.UpdatedAt)
 
 	return &amended
 }
@@ -165,11 +243,12 @@ func amendEntry(entry *ledger.Entry, flags amendFlags) *ledger.Entry {
 // outputAmendDryRun outputs a preview of the changes.
 func outputAmendDryRun(printer *output.Printer, original, amended *ledger.Entry, flags amendFlags) error {
 	if printer.IsJSON() {
-		return printer.WriteJSON(map[string]any{
+		changes := buildChangesMap(original, amended, flags)
+		return printer.WriteJSON(withPlan(map[string]any{
 			"dry_run": true,
 			"entry":   amended,
-			"changes": buildChangesMap(original, amended, flags),
-		})
+			"changes": changes,
+		}, []plannedAction{{Action: planModify, Target: amended.ID, Detail: "amend " + changedFieldNames(changes)}}))
 	}
 
 	printer.Println("Dry run - changes that would be made:")
@@ -197,6 +276,13 @@ func outputAmendDryRun(printer *output.Printer, original, amended *ledger.Entry,
 		printer.Println("  After:  " + amended.Summary.How)
 	}
 
+	if flags.notes != nil {
+		printer.Println()
+		printer.Section("Notes")
+		printer.Println("  Before: " + original.Notes)
+		printer.Println("  After:  " + amended.Notes)
+	}
+
 	if flags.tags != nil {
 		printer.Println()
 		printer.Section("Tags")
@@ -209,6 +295,12 @@ func outputAmendDryRun(printer *output.Printer, original, amended *ledger.Entry,
 		printer.Println("  Before: " + formatContributors(original.Contributors))
 		printer.Println("  After:  " + formatContributors(amended.Contributors))
 	}
+	if flags.meta != nil {
+		printer.Println()
+		printer.Section("Meta")
+		printer.Println("  Before: " + formatMetaOrNone(original.Meta))
+		printer.Println("  After:  " + formatMetaOrNone(amended.Meta))
+	}
 
 	return nil
 }
@@ -250,6 +342,12 @@ func buildChangesMap(original, amended *ledger.Entry, flags amendFlags) map[stri
 			"after":  amended.Contributors,
 		}
 	}
+	if flags.meta != nil {
+		changes["meta"] = map[string]map[string]string{
+			"before": original.Meta,
+			"after":  amended.Meta,
+		}
+	}
 
 	return changes
 }
@@ -262,6 +360,14 @@ func formatTags(tags []string) string {
 	return strings.Join(tags, ", ")
 }
 
+// formatMetaOrNone formats meta fields as key=value pairs, or "(none)".
+func formatMetaOrNone(meta map[string]string) string {
+	if len(meta) == 0 {
+		return "(none)"
+	}
+	return ledger.FormatMeta(meta)
+}
+
 func formatContributors(contributors []ledger.Contributor) string {
 	if len(contributors) == 0 {
 		return "(none)"
@@ -290,3 +396,13 @@ func outputAmendSuccess(printer *output.Printer, entry *ledger.Entry) error {
 
 	return nil
 }
+
+// changedFieldNames lists the amended fields, sorted, for the dry-run plan.
+func changedFieldNames(changes map[string]any) string {
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
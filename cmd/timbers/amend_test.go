@@ -3,6 +3,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -22,44 +23,58 @@ func newMockGitOpsForAmend() *mockGitOpsForAmend {
 	return &mockGitOpsForAmend{}
 }
 
-func (m *mockGitOpsForAmend) HEAD() (string, error) {
+func (m *mockGitOpsForAmend) HEAD(_ context.Context) (string, error) {
 	return "abc123", nil
 }
 
-func (m *mockGitOpsForAmend) Log(_, _ string) ([]git.Commit, error) {
+func (m *mockGitOpsForAmend) Log(_ context.Context, _, _ string) ([]git.Commit, error) {
 	return nil, nil
 }
 
-func (m *mockGitOpsForAmend) ResolveCommit(ref string) (string, error) {
+func (m *mockGitOpsForAmend) ResolveCommit(_ context.Context, ref string) (string, error) {
 	return ref, nil
 }
 
-func (m *mockGitOpsForAmend) LogFirstParent(_, _ string) ([]git.Commit, error) {
+func (m *mockGitOpsForAmend) LogFirstParent(_ context.Context, _, _ string) ([]git.Commit, error) {
 	return nil, nil
 }
 
-func (m *mockGitOpsForAmend) CommitsReachableFrom(_ string) ([]git.Commit, error) {
+func (m *mockGitOpsForAmend) CommitsReachableFrom(_ context.Context, _ string) ([]git.Commit, error) {
 	return nil, nil
 }
 
-func (m *mockGitOpsForAmend) IsAncestorOf(ancestor, descendant string) bool {
+func (m *mockGitOpsForAmend) CommitsReachableFromFirstParent(ctx context.Context, sha string) ([]git.Commit, error) {
+	return m.CommitsReachableFrom(ctx, sha)
+}
+
+func (m *mockGitOpsForAmend) IsAncestorOf(_ context.Context, ancestor, descendant string) bool {
 	return true
 }
 
-func (m *mockGitOpsForAmend) IsOnFirstParentLine(sha, head string) bool {
+func (m *mockGitOpsForAmend) IsOnFirstParentLine(_ context.Context, sha, head string) bool {
 	return true
 }
 
-func (m *mockGitOpsForAmend) GetDiffstat(_, _ string) (git.Diffstat, error) {
+func (m *mockGitOpsForAmend) IsShallow(_ context.Context) bool {
+	return false
+}
+
+func (m *mockGitOpsForAmend) GetDiffstat(_ context.Context, _, _ string) (git.Diffstat, error) {
 	return git.Diffstat{}, nil
 }
 
-func (m *mockGitOpsForAmend) CommitFiles(sha string) ([]string, error) { return nil, nil }
-func (m *mockGitOpsForAmend) CommitFilesMulti(shas []string) (map[string][]string, error) {
+func (m *mockGitOpsForAmend) CommitFiles(_ context.Context, sha string) ([]string, error) {
+	return nil, nil
+}
+func (m *mockGitOpsForAmend) CommitFilesMulti(_ context.Context, shas []string) (map[string][]string, error) {
 	return make(map[string][]string), nil
 }
 
-func (m *mockGitOpsForAmend) DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
+func (m *mockGitOpsForAmend) CommitFilesMultiFirstParent(ctx context.Context, shas []string) (map[string][]string, error) {
+	return m.CommitFilesMulti(ctx, shas)
+}
+
+func (m *mockGitOpsForAmend) DiffNameOnly(_ context.Context, fromRef, toRef, pathPrefix string) ([]string, error) {
 	return nil, nil
 }
 
@@ -537,6 +552,14 @@ func TestAmendCommandJSON(t *testing.T) {
 				if dryRun, ok := result["dry_run"].(bool); !ok || !dryRun {
 					t.Error("expected dry_run=true in JSON output")
 				}
+				plan, _ := result["planned_actions"].([]any)
+				var first map[string]any
+				if len(plan) == 1 {
+					first, _ = plan[0].(map[string]any)
+				}
+				if first["action"] != "modify" {
+					t.Errorf("expected one modify planned action, got %v", result["planned_actions"])
+				}
 				changes, ok := result["changes"].(map[string]any)
 				if !ok {
 					t.Error("expected changes object in JSON output")
@@ -617,3 +640,87 @@ func TestAmendWhoRepairsEntryWithoutCommitObjects(t *testing.T) {
 		t.Fatalf("Contributors = %#v, want retroactive explicit identity", got)
 	}
 }
+
+func TestAmendMetaMergesAndRemoves(t *testing.T) {
+	baseTime := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	entry := withMeta(createQueryTestEntryStruct("abc123def456", "Original what", baseTime), "service", "api")
+	entry.Meta["reviewer"] = "ada"
+	storage, dir := setupAmendTestStorage(t, newMockGitOpsForAmend(), entry)
+
+	cmd := newAmendCmdInternal(storage)
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{entry.ID, "--meta", "risk=high", "--meta", "reviewer="})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("amend --meta: %v\n%s", err, buf.String())
+	}
+
+	got := readEntryFromDir(t, dir, entry.ID).Meta
+	if len(got) != 2 || got["service"] != "api" || got["risk"] != "high" {
+		t.Errorf("meta = %v, want service=api and risk=high", got)
+	}
+}
+
+func TestAmendRecordsRevisionShownByHistory(t *testing.T) {
+	baseTime := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	entry := createQueryTestEntryStruct("abc123def456", "Original what", baseTime)
+	storage, dir := setupAmendTestStorage(t, newMockGitOpsForAmend(), entry)
+
+	cmd := newAmendCmdInternal(storage)
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{entry.ID, "--what", "Corrected what"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("amend: %v\n%s", err, buf.String())
+	}
+
+	revisions := readEntryFromDir(t, dir, entry.ID).Revisions
+	if len(revisions) != 1 || len(revisions[0].Changes) != 1 {
+		t.Fatalf("revisions = %+v, want one revision with one change", revisions)
+	}
+	want := ledger.FieldChange{Field: "summary.what", Before: "Original what", After: "Corrected what"}
+	if change := revisions[0].Changes[0]; change != want {
+		t.Errorf("change = %+v", change)
+	}
+
+	show := newShowCmdInternal(storage)
+	buf.Reset()
+	show.SetOut(buf)
+	show.SetErr(buf)
+	show.SetArgs([]string{entry.ID, "--history"})
+	if err := show.Execute(); err != nil {
+		t.Fatalf("show --history: %v\n%s", err, buf.String())
+	}
+	if out := buf.String(); !strings.Contains(out, "History") || !strings.Contains(out, `summary.what: "Original what" → "Corrected what"`) {
+		t.Errorf("show --history output missing the revision:\n%s", out)
+	}
+}
+
+func TestAmendEditReplacesSummaryAndNotes(t *testing.T) {
+	baseTime := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	entry := createQueryTestEntryStruct("abc123def456", "Original what", baseTime)
+	storage, dir := setupAmendTestStorage(t, newMockGitOpsForAmend(), entry)
+	given := useFakeEditor(t, "## What\nEdited what\n## Why\nEdited why\n## How\nEdited how\n## Notes\nnew notes\n")
+
+	cmd := newAmendCmdInternal(storage)
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{entry.ID, "--edit", "--how", "Flag how"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("amend --edit: %v\n%s", err, buf.String())
+	}
+
+	if template, err := os.ReadFile(given); err != nil || !strings.Contains(string(template), "## How\nFlag how\n") { //nolint:gosec // test temp file
+		t.Errorf("template should start from --how; got %q (%v)", template, err)
+	}
+	got := readEntryFromDir(t, dir, entry.ID)
+	if got.Summary.What != "Edited what" || got.Summary.Why != "Edited why" || got.Summary.How != "Edited how" {
+		t.Errorf("summary = %+v", got.Summary)
+	}
+	if got.Notes != "new notes" {
+		t.Errorf("notes = %q, want new notes", got.Notes)
+	}
+}
@@ -0,0 +1,115 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// newArchiveCmd creates the archive command.
+func newArchiveCmd() *cobra.Command {
+	return newArchiveCmdInternal(nil)
+}
+
+// newArchiveCmdInternal creates the archive command with optional storage
+// injection. If storage is nil, a real storage is created when the command
+// runs.
+func newArchiveCmdInternal(storage *ledger.Storage) *cobra.Command {
+	var beforeFlag string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "archive --before <date>",
+		Short: "Move old entries out of the default reads",
+		Long: `Move entries created before a date into .timbers/archive/, keeping the
+layout's directories underneath, and commit the moves.
+
+Archived entries are left out of query, export, prime, and show unless
+--include-archived is passed, so a long-lived ledger stays fast to read. They
+remain in the repository and fsck still checks them. Acks and tombstones stay
+where they are. The newest entry is never archived: pending detection starts
+from it.
+
+--before takes a date (2025-01-01) or an age (90d, 12w, 6m).
+
+Examples:
+  timbers archive --before 2025-01-01 --dry-run
+  timbers archive --before 6m
+  timbers query --since 2024-06-01 --until 2024-12-31 --include-archived`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runArchive(cmd, storage, beforeFlag, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVar(&beforeFlag, "before", "", "Archive entries created before this date (2025-01-01) or age (90d) (required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be archived without moving anything")
+	_ = cmd.MarkFlagRequired("before")
+
+	return cmd
+}
+
+// runArchive executes the archive command.
+func runArchive(cmd *cobra.Command, storage *ledger.Storage, beforeFlag string, dryRun bool) error {
+	printer := newPrinter(cmd)
+
+	cutoff, err := parseTimeValue(beforeFlag)
+	if err != nil {
+		err = output.NewUserError("invalid --before value " + beforeFlag + "; use a date (2025-01-01) or an age (90d, 12w, 6m)")
+		printer.Error(err)
+		return err
+	}
+	storage, err = initAmendStorage(storage, printer)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	if dryRun {
+		ids, err = storage.PlanArchive(cutoff)
+	} else {
+		ids, err = storage.ArchiveBefore(cutoff)
+	}
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	return printArchiveResult(printer, ids, cutoff, dryRun)
+}
+
+// printArchiveResult reports the archived entries, with a plan on a dry run.
+func printArchiveResult(printer *output.Printer, ids []string, cutoff time.Time, dryRun bool) error {
+	if printer.IsJSON() {
+		if ids == nil {
+			ids = []string{}
+		}
+		fields := map[string]any{
+			"status":   "archived",
+			"before":   cutoff.Format(time.RFC3339),
+			"archived": ids,
+		}
+		if !dryRun {
+			return printer.Success(fields)
+		}
+		fields["dry_run"] = true
+		plan := make([]plannedAction, 0, len(ids))
+		for _, id := range ids {
+			plan = append(plan, plannedAction{Action: planModify, Target: id, Detail: "move into " + ledger.ArchiveDir + "/"})
+		}
+		return printer.WriteJSON(withPlan(fields, plan))
+	}
+
+	verb := "Archived"
+	if dryRun {
+		verb = "Would archive"
+	}
+	printer.Print("%s %d entries created before %s\n", verb, len(ids), cutoff.Format(time.DateOnly))
+	for _, id := range ids {
+		printer.Print("  %s\n", id)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// runArchiveTest runs the archive command against storage and returns its
+// output.
+func runArchiveTest(t *testing.T, storage *ledger.Storage, args ...string) (string, error) {
+	t.Helper()
+	cmd := newArchiveCmdInternal(storage)
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestArchiveHidesEntryFromQuery(t *testing.T) {
+	storage, _, earlier, later := setupLinkTestStorage(t)
+
+	out, err := runArchiveTest(t, storage, "--before", "2026-01-18", "--dry-run")
+	if err != nil || !strings.Contains(out, "Would archive 1 entries") {
+		t.Fatalf("archive --dry-run: %v\n%s", err, out)
+	}
+	if ids := queryIDs(t, storage); len(ids) != 2 {
+		t.Fatalf("query after dry run = %v, want both entries", ids)
+	}
+
+	out, err = runArchiveTest(t, storage, "--before", "2026-01-18")
+	if err != nil || !strings.Contains(out, earlier) {
+		t.Fatalf("archive: %v\n%s", err, out)
+	}
+	if ids := queryIDs(t, storage); !slices.Equal(ids, []string{later}) {
+		t.Errorf("query = %v, want only %s", ids, later)
+	}
+	if ids := queryIDs(t, storage, "--include-archived"); len(ids) != 2 {
+		t.Errorf("query --include-archived = %v, want both entries", ids)
+	}
+}
+
+func TestArchiveRejectsBadBefore(t *testing.T) {
+	storage, _ := setupAmendTestStorage(t, newMockGitOpsForAmend(), nil)
+
+	if _, err := runArchiveTest(t, storage, "--before", "last year"); err == nil {
+		t.Error("archive with an unparsable --before should fail")
+	}
+}
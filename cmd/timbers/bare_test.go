@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBareRepoReadCommands runs status, query, and export in a bare clone,
+// where the ledger is read from HEAD's tree, and checks that log is refused.
+func TestBareRepoReadCommands(t *testing.T) {
+	src := newLogAnchorRepo(t)
+	if out, err := runLogCmd(t, src, "Mirror work", "--why", "Report from CI", "--how", "Bare clone"); err != nil {
+		t.Fatalf("log failed: %v\n%s", err, out)
+	}
+	bare := filepath.Join(t.TempDir(), "mirror.git")
+	runGit(t, "", "clone", "--bare", src, bare)
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		var out strings.Builder
+		var execErr error
+		runInDir(t, bare, func() {
+			cmd := newRootCmd()
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			cmd.SetArgs(args)
+			execErr = cmd.Execute()
+		})
+		return out.String(), execErr
+	}
+
+	out, err := run("status", "--json")
+	if err != nil {
+		t.Fatalf("status failed: %v\n%s", err, out)
+	}
+	var status map[string]any
+	if err = json.Unmarshal([]byte(out), &status); err != nil {
+		t.Fatalf("status output is not JSON: %v\n%s", err, out)
+	}
+	if status["repo"] != "mirror.git" || status["timbers_dir"] != "HEAD:.timbers" ||
+		status["dir_exists"] != true || status["entry_count"] != float64(1) {
+		t.Errorf("status = %v, want mirror.git with one entry read from HEAD:.timbers", status)
+	}
+
+	for _, args := range [][]string{{"query", "--last", "5", "--json"}, {"export", "--last", "5", "--json"}} {
+		out, err = run(args...)
+		if err != nil || !strings.Contains(out, "Mirror work") {
+			t.Errorf("%s: err %v, output %s; want the logged entry", args[0], err, out)
+		}
+	}
+
+	out, err = run("log", "More work", "--why", "w", "--how", "h", "--anchor", "HEAD")
+	if err == nil || !strings.Contains(out, "read-only") {
+		t.Errorf("log in a bare repo: err %v, output %s; want a read-only refusal", err, out)
+	}
+}
@@ -0,0 +1,149 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// decideFlags holds flag values for the decide command.
+type decideFlags struct {
+	context      string
+	consequences string
+	status       string
+	alternatives []string
+	supersedes   string
+	notes        string
+	tags         []string
+	workItems    []string
+	rangeStr     string
+	anchor       string
+	dryRun       bool
+	force        bool
+}
+
+// newDecideCmd creates the decide command.
+func newDecideCmd() *cobra.Command {
+	return newDecideCmdInternal(nil, nil)
+}
+
+// newDecideCmdInternal creates the decide command with optional storage and
+// dirty checker injection, as newLogCmdInternal does.
+func newDecideCmdInternal(storage *ledger.Storage, isDirty dirtyChecker) *cobra.Command {
+	var flags decideFlags
+
+	cmd := &cobra.Command{
+		Use:   "decide <decision>",
+		Short: "Record an architecture decision (ADR) as a ledger entry",
+		Long: `Record an architecture decision as a kind "decision" ledger entry.
+
+A decision is anchored to commits exactly like 'timbers log', so the ADR links
+to the code changes that carry it out. --context becomes the entry's why and
+--consequences its how; export them as numbered ADR files with
+'timbers export --format adr --out docs/adr/'.
+
+Examples:
+  timbers decide "Use Postgres" --context "We need transactions across services" \
+    --consequences "Ops runs a managed instance" --alternative "SQLite" --alternative "DynamoDB"
+  timbers decide "Adopt ULIDs" --context "..." --alternative "UUIDv7" --status proposed
+  timbers decide "Use MySQL 8" --context "..." --alternative "Postgres" --supersedes tb_2026-01-15T15:04:05Z_8f2c1a`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDecide(cmd, storage, isDirty, args, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.context, "context", "", "Forces and constraints that led to the decision (required)")
+	cmd.Flags().StringVar(&flags.consequences, "consequences", "", "What becomes easier or harder as a result")
+	cmd.Flags().StringVar(&flags.status, "status", ledger.DecisionAccepted, "ADR status: proposed, accepted, deprecated, or superseded")
+	cmd.Flags().StringArrayVar(&flags.alternatives, "alternative", nil, "Option considered and rejected (required, repeatable)")
+	cmd.Flags().StringVar(&flags.supersedes, "supersedes", "", "ID of the decision entry this one replaces")
+	cmd.Flags().StringVar(&flags.notes, "notes", "", "Deliberation notes capturing the journey to the decision")
+	cmd.Flags().StringArrayVar(&flags.tags, "tag", nil, "Tags for categorization (repeatable)")
+	cmd.Flags().StringArrayVar(&flags.workItems, "work-item", nil, "Work item reference as system:id (repeatable)")
+	cmd.Flags().StringVar(&flags.rangeStr, "range", "", "Explicit commit range (e.g., abc123..def456)")
+	cmd.Flags().StringVar(&flags.anchor, "anchor", "", "Override anchor commit (default: HEAD)")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Show what would be written without writing")
+	cmd.Flags().BoolVar(&flags.force, "force", false, "Write even if another decision already covers these commits")
+
+	return cmd
+}
+
+// runDecide validates the ADR fields and records the decision through the
+// log pipeline with kind "decision".
+func runDecide(cmd *cobra.Command, storage *ledger.Storage, isDirty dirtyChecker, args []string, flags decideFlags) error {
+	printer := newPrinter(cmd)
+
+	if strings.TrimSpace(flags.context) == "" {
+		err := output.NewUserError("--context is required: record the forces that led to the decision")
+		printer.Error(err)
+		return err
+	}
+	if len(flags.alternatives) == 0 {
+		err := output.NewUserError("--alternative is required: name the options considered and rejected")
+		printer.Error(err)
+		return err
+	}
+	status, err := ledger.ParseDecisionStatus(flags.status)
+	if err != nil {
+		err = output.NewUserError("--status: " + err.Error())
+		printer.Error(err)
+		return err
+	}
+
+	storage, err = initLogStorage(storage, printer)
+	if err != nil {
+		return err
+	}
+	if err := checkSupersedes(storage, flags.supersedes); err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	return runLog(cmd, storage, isDirty, args, logFlags{
+		why:       flags.context,
+		how:       flags.consequences,
+		notes:     flags.notes,
+		tags:      flags.tags,
+		workItems: flags.workItems,
+		rangeStr:  flags.rangeStr,
+		anchor:    flags.anchor,
+		dryRun:    flags.dryRun,
+		force:     flags.force,
+		kind:      ledger.KindDecision,
+		decision: &ledger.Decision{
+			Status:       status,
+			Alternatives: flags.alternatives,
+		},
+		relations: supersedesRelations(flags.supersedes),
+	})
+}
+
+// supersedesRelations returns the relation --supersedes records: since
+// timbers.devlog/v2 a replaced decision is a supersedes relation rather
+// than a decision field.
+func supersedesRelations(id string) []ledger.Relation {
+	if id == "" {
+		return nil
+	}
+	return []ledger.Relation{{Type: ledger.RelationSupersedes, ID: id}}
+}
+
+// checkSupersedes verifies that --supersedes names an existing decision.
+func checkSupersedes(storage *ledger.Storage, id string) error {
+	if id == "" {
+		return nil
+	}
+	entry, err := storage.GetEntryByID(id)
+	if err != nil {
+		return err
+	}
+	if entry.KindOrDefault() != ledger.KindDecision {
+		return output.NewUserError("--supersedes: " + id + " is a " + entry.KindOrDefault() + ", not a decision")
+	}
+	return nil
+}
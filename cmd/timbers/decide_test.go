@@ -0,0 +1,96 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// runTimbersCmd runs `timbers <args...>` in dir through the root command.
+func runTimbersCmd(t *testing.T, dir string, args ...string) (string, error) {
+	t.Helper()
+	var out strings.Builder
+	var execErr error
+	runInDir(t, dir, func() {
+		cmd := newRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(args)
+		execErr = cmd.Execute()
+	})
+	return out.String(), execErr
+}
+
+func TestDecideRecordsDecisionAndExportsADR(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+
+	out, err := runTimbersCmd(t, dir, "decide", "Use Postgres",
+		"--context", "We need transactions", "--consequences", "Ops runs a managed instance",
+		"--alternative", "SQLite", "--alternative", "DynamoDB")
+	if err != nil {
+		t.Fatalf("timbers decide errored: %v\noutput: %s", err, out)
+	}
+
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if entry.Kind != ledger.KindDecision {
+		t.Errorf("kind = %q, want %q", entry.Kind, ledger.KindDecision)
+	}
+	if entry.Summary.Why != "We need transactions" || entry.Summary.How != "Ops runs a managed instance" {
+		t.Errorf("summary = %+v, want context as why and consequences as how", entry.Summary)
+	}
+	if entry.Decision == nil || entry.Decision.Status != ledger.DecisionAccepted || len(entry.Decision.Alternatives) != 2 {
+		t.Fatalf("decision = %+v, want accepted with 2 alternatives", entry.Decision)
+	}
+
+	adrDir := filepath.Join(t.TempDir(), "adr")
+	if out, err = runTimbersCmd(t, dir, "export", "--last", "5", "--format", "adr", "--out", adrDir); err != nil {
+		t.Fatalf("export --format adr errored: %v\noutput: %s", err, out)
+	}
+	data, err := os.ReadFile(filepath.Join(adrDir, "0001-use-postgres.md"))
+	if err != nil {
+		t.Fatalf("ADR file not written: %v", err)
+	}
+	for _, want := range []string{"# 1. Use Postgres", "## Status\n\nAccepted", "## Context\n\nWe need transactions", "- SQLite"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("ADR missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestDecideValidation(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing context", args: []string{"decide", "Use Postgres"}, want: "--context is required"},
+		{name: "missing alternative", args: []string{"decide", "Use Postgres", "--context", "c"}, want: "--alternative is required"},
+		{
+			name: "bad status",
+			args: []string{"decide", "Use Postgres", "--context", "c", "--alternative", "a", "--status", "maybe"},
+			want: "unknown decision status",
+		},
+		{
+			name: "unknown superseded entry",
+			args: []string{"decide", "Use Postgres", "--context", "c", "--alternative", "a", "--supersedes", "tb_nope"},
+			want: "not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runTimbersCmd(t, dir, tt.args...)
+			if err == nil || !strings.Contains(out, tt.want) {
+				t.Errorf("want error containing %q, got err=%v output: %s", tt.want, err, out)
+			}
+		})
+	}
+	if n := countJSONFilesInDir(filepath.Join(dir, ".timbers")); n != 0 {
+		t.Errorf("%d entries written by failed decide runs, want 0", n)
+	}
+}
@@ -87,11 +87,11 @@ Examples:
 
 // runDoctor executes the doctor command.
 func runDoctor(cmd *cobra.Command, flags *doctorFlags) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 
 	// Check if we're in a git repo
 	if !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return err
 	}
@@ -163,25 +163,23 @@ type doctorStyleSet struct {
 	dim     lipgloss.Style
 }
 
-// doctorStyles returns a TTY-aware style set.
-func doctorStyles(isTTY bool) doctorStyleSet {
-	if !isTTY {
-		return doctorStyleSet{}
-	}
+// doctorStyles returns the doctor style set in the printer's palette
+// (plain when the printer has no color).
+func doctorStyles(styles *output.Styles) doctorStyleSet {
 	return doctorStyleSet{
-		heading: lipgloss.NewStyle().Bold(true),
-		section: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.AdaptiveColor{Light: "12", Dark: "12"}),
-		pass:    lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "10", Dark: "10"}),
-		warn:    lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "11", Dark: "11"}),
-		fail:    lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "9", Dark: "9"}),
-		hint:    lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "8", Dark: "7"}),
-		dim:     lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "8", Dark: "7"}),
+		heading: styles.Bold,
+		section: styles.Title,
+		pass:    styles.Success,
+		warn:    styles.Warning,
+		fail:    styles.Error.UnsetBold(),
+		hint:    styles.Dim,
+		dim:     styles.Dim,
 	}
 }
 
 // outputDoctorHuman outputs the doctor result in human-readable format.
 func outputDoctorHuman(printer *output.Printer, result *doctorResult, quiet bool) {
-	styles := doctorStyles(printer.IsTTY())
+	styles := doctorStyles(printer.Styles())
 
 	// Header
 	printer.Println()
@@ -199,6 +197,11 @@ func outputDoctorHuman(printer *output.Printer, result *doctorResult, quiet bool
 
 	// Summary
 	printer.Println()
+	if printer.IsAccessible() {
+		printer.Print("%d passed, %d warnings, %d failed.\n",
+			result.Summary.Passed, result.Summary.Warnings, result.Summary.Failed)
+		return
+	}
 	printer.Print("%s %d passed  %s %d warnings  %s %d failed\n",
 		styles.pass.Render("ok"), result.Summary.Passed,
 		styles.warn.Render("!!"), result.Summary.Warnings,
@@ -209,17 +212,8 @@ func outputDoctorHuman(printer *output.Printer, result *doctorResult, quiet bool
 // printCheckSection prints a section of checks.
 func printCheckSection(printer *output.Printer, styles doctorStyleSet, title string, checks []checkResult, quiet bool) {
 	// In quiet mode, skip sections with only passing checks
-	if quiet {
-		hasNonPass := false
-		for _, check := range checks {
-			if check.Status != checkPass {
-				hasNonPass = true
-				break
-			}
-		}
-		if !hasNonPass {
-			return
-		}
+	if quiet && allChecksPass(checks) {
+		return
 	}
 
 	printer.Println()
@@ -231,6 +225,10 @@ func printCheckSection(printer *output.Printer, styles doctorStyleSet, title str
 			continue
 		}
 
+		if printer.IsAccessible() {
+			printAccessibleCheck(printer, check)
+			continue
+		}
 		icon := styledIcon(styles, check.Status)
 		printer.Print("  %s  %s %s\n", icon, check.Name, styles.dim.Render(check.Message))
 		if check.Hint != "" {
@@ -239,6 +237,29 @@ func printCheckSection(printer *output.Printer, styles doctorStyleSet, title str
 	}
 }
 
+// allChecksPass reports whether every check in checks passed.
+func allChecksPass(checks []checkResult) bool {
+	for _, check := range checks {
+		if check.Status != checkPass {
+			return false
+		}
+	}
+	return true
+}
+
+// printAccessibleCheck prints a check as a sentence, with its status as a
+// word ("Passed", "Warning", "Failed") instead of an icon.
+func printAccessibleCheck(printer *output.Printer, check checkResult) {
+	status := map[checkStatus]string{checkPass: "Passed", checkWarn: "Warning", checkFail: "Failed"}[check.Status]
+	if status == "" {
+		status = "Unknown"
+	}
+	printer.Print("%s: %s. %s\n", status, check.Name, check.Message)
+	if check.Hint != "" {
+		printer.Print("Hint: %s\n", check.Hint)
+	}
+}
+
 // styledIcon returns the styled icon for a check status.
 func styledIcon(styles doctorStyleSet, status checkStatus) string {
 	switch status {
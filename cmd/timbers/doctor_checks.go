@@ -14,13 +14,14 @@ import (
 
 // runCoreChecks performs core infrastructure checks.
 func runCoreChecks(flags *doctorFlags) []checkResult {
-	checks := make([]checkResult, 0, 6)
+	checks := make([]checkResult, 0, 7)
 	checks = append(checks, checkTimbersDirExists())
 	checks = append(checks, checkBinaryInPath())
 	checks = append(checks, checkShadowingBinary())
 	checks = append(checks, checkVersion())
 	checks = append(checks, checkGitattributes())
 	checks = append(checks, checkLegacyFilenames(flags))
+	checks = append(checks, checkStorageLayout(flags))
 	return checks
 }
 
@@ -82,10 +83,12 @@ func checkBinaryInPath() checkResult {
 
 // runWorkflowChecks performs workflow-related checks.
 func runWorkflowChecks() []checkResult {
-	checks := make([]checkResult, 0, 4)
+	checks := make([]checkResult, 0, 6)
+	checks = append(checks, checkShallowClone())
 	checks = append(checks, checkPendingCommits())
 	checks = append(checks, checkLatestAnchorTopology())
 	checks = append(checks, checkRecentEntries())
+	checks = append(checks, checkEntryOverlaps())
 	checks = append(checks, checkMergeStrategy())
 	return checks
 }
@@ -1,10 +1,7 @@
 package main
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -14,6 +11,7 @@ import (
 	"github.com/gorewood/timbers/internal/config"
 	"github.com/gorewood/timbers/internal/draft"
 	"github.com/gorewood/timbers/internal/llm"
+	"github.com/gorewood/timbers/internal/selfupdate"
 )
 
 // checkVersion compares installed version against latest GitHub release.
@@ -36,10 +34,7 @@ func checkVersion() checkResult {
 		}
 	}
 
-	installed := strings.TrimPrefix(version, "v")
-	latestClean := strings.TrimPrefix(latest, "v")
-
-	if installed == latestClean {
+	if !selfupdate.IsNewer(latest, version) {
 		return checkResult{
 			Name:    "Version",
 			Status:  checkPass,
@@ -51,44 +46,20 @@ func checkVersion() checkResult {
 		Name:    "Version",
 		Status:  checkWarn,
 		Message: fmt.Sprintf("%s (latest: %s)", version, latest),
-		Hint:    "Update: curl -fsSL https://raw.githubusercontent.com/gorewood/timbers/main/install.sh | bash",
+		Hint:    "Run 'timbers upgrade'",
 	}
 }
 
 // fetchLatestVersion queries GitHub for the latest release tag.
 func fetchLatestVersion() (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(5 * time.Second)
 	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-		"https://api.github.com/repos/gorewood/timbers/releases/latest", nil)
-	if err != nil {
-		return "", fmt.Errorf("creating request: %w", err)
-	}
-	req.Header.Set("Accept", "application/vnd.github+json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close() //nolint:errcheck
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("GitHub API returned %d", resp.StatusCode)
-	}
-
-	var release struct {
-		TagName string `json:"tag_name"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return "", fmt.Errorf("parsing response: %w", err)
-	}
-	return release.TagName, nil
+	return selfupdate.NewClient().Latest(ctx)
 }
 
 // runConfigChecks performs configuration-related checks.
 func runConfigChecks(flags *doctorFlags) []checkResult {
-	checks := make([]checkResult, 0, 7)
+	checks := make([]checkResult, 0, 10)
 	checks = append(checks, checkConfigDir(flags))
 	checks = append(checks, checkEnvFiles())
 	checks = append(checks, checkTemplates())
@@ -96,6 +67,9 @@ func runConfigChecks(flags *doctorFlags) []checkResult {
 	checks = append(checks, checkTimbersignoreGlobs())
 	checks = append(checks, checkSessionIdentity())
 	checks = append(checks, checkSessionWindow())
+	checks = append(checks, checkTheme())
+	checks = append(checks, checkGitBackend())
+	checks = append(checks, checkGitTimeout())
 	return checks
 }
 
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+)
+
+// checkGitBackend validates the [git] backend setting. An unknown backend
+// falls back to exec, so it is a warning.
+func checkGitBackend() checkResult {
+	if name := os.Getenv(gitBackendEnv); name != "" {
+		return checkResult{Name: "Git backend", Status: checkPass, Message: name + " (from " + gitBackendEnv + ")"}
+	}
+	name := configuredGit().Backend
+	if _, err := git.NewBackend(name); err != nil {
+		return checkResult{
+			Name:    "Git backend",
+			Status:  checkWarn,
+			Message: err.Error() + " — using exec",
+			Hint:    "Fix [git] backend in " + config.ProjectFile,
+		}
+	}
+	if name == "" {
+		name = git.BackendExec
+	}
+	return checkResult{Name: "Git backend", Status: checkPass, Message: name}
+}
+
+// checkGitTimeout validates the [git] timeout setting. An invalid timeout
+// is ignored, so it is a warning.
+func checkGitTimeout() checkResult {
+	if value := os.Getenv(gitTimeoutEnv); value != "" {
+		return checkResult{Name: "Git timeout", Status: checkPass, Message: value + " (from " + gitTimeoutEnv + ")"}
+	}
+	value := configuredGit().Timeout
+	timeout, err := parseGitTimeout(value)
+	if err != nil {
+		return checkResult{
+			Name:    "Git timeout",
+			Status:  checkWarn,
+			Message: err.Error() + " — git commands are not time-limited",
+			Hint:    "Fix [git] timeout in " + config.ProjectFile,
+		}
+	}
+	if timeout == 0 {
+		return checkResult{Name: "Git timeout", Status: checkPass, Message: "none (only --timeout applies)"}
+	}
+	return checkResult{Name: "Git timeout", Status: checkPass, Message: timeout.String() + " per git command"}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"path/filepath"
 
+	"github.com/gorewood/timbers/internal/git"
 	"github.com/gorewood/timbers/internal/setup"
 )
 
@@ -20,6 +21,9 @@ func checkGitHooks(flags *doctorFlags) checkResult {
 
 	agentActive := len(setup.DetectedAgentEnvs()) > 0
 	preCommitPath := filepath.Join(env.HooksDir, "pre-commit")
+	if env.JJ {
+		return checkGitHooksJJ(preCommitPath)
+	}
 
 	// If timbers section is present, report active regardless of tier.
 	if setup.HasTimbersSection(preCommitPath) {
@@ -38,6 +42,28 @@ func checkGitHooks(flags *doctorFlags) checkResult {
 	return checkGitHooksNotInstalled(env, agentActive)
 }
 
+// checkGitHooksJJ reports on git hooks in a jj colocated repository, where
+// jj never runs them and --fix does not install them. Rewrites by jj do not
+// fire post-rewrite either, so entries anchored to rewritten commits need
+// timbers remap.
+func checkGitHooksJJ(preCommitPath string) checkResult {
+	if setup.HasTimbersSection(preCommitPath) {
+		return checkResult{
+			Name:   "Git Hooks",
+			Status: checkWarn,
+			Message: "installed, but this is a jj colocated repository:" +
+				" jj never runs git hooks, so they fire only for plain git commands",
+			Hint: "Check `timbers pending` before pushing; after jj rewrites, run `timbers remap`",
+		}
+	}
+	return checkResult{
+		Name:   "Git Hooks",
+		Status: checkPass,
+		Message: "jj colocated repository: git hooks skipped (jj never runs them)." +
+			" Check `timbers pending` before pushing; after jj rewrites, run `timbers remap`.",
+	}
+}
+
 // checkGitHooksActive returns the check result when hooks are installed.
 func checkGitHooksActive(env setup.HookEnvInfo, agentActive bool) checkResult {
 	var msg string
@@ -203,7 +229,7 @@ func checkPostCommitHook(flags *doctorFlags) checkResult {
 		}
 	}
 
-	if flags.fix {
+	if flags.fix && !git.IsJJColocated() {
 		// Use AppendTimbersSection for consistency.
 		appendErr := setup.AppendTimbersSection(hookPath, postCommitSectionContent)
 		if appendErr == nil {
@@ -223,11 +249,11 @@ func checkPostCommitHook(flags *doctorFlags) checkResult {
 }
 
 // checkPostRewriteHookDrift checks the post-rewrite hook (SHA relink after rebase).
-// Unlike the pre/post-commit hooks, which are thin shims to the timbers binary,
-// the post-rewrite hook carries self-contained shell logic — so generator fixes
-// only reach a repo if the installed section is refreshed. This check detects
-// drift between the installed section and the current generator output and, on
-// --fix, regenerates it.
+// The section is now a thin shim to `timbers hook run post-rewrite`, but older
+// installs carry self-contained find/sed logic that breaks under Git for
+// Windows — and generator changes only reach a repo if the installed section
+// is refreshed. This check detects drift between the installed section and the
+// current generator output and, on --fix, regenerates it.
 func checkPostRewriteHookDrift(flags *doctorFlags) checkResult {
 	hooksDir, err := setup.GetHooksDir()
 	if err != nil {
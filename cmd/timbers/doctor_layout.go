@@ -0,0 +1,63 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strconv"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// checkStorageLayout verifies the [storage] layout setting is valid and that
+// entry and tombstone files sit where it puts them. Misplaced files still read fine, but
+// new and old entries end up in different trees. Auto-fixable: --fix moves
+// them into the configured layout.
+func checkStorageLayout(flags *doctorFlags) checkResult {
+	const name = "Storage Layout"
+	root, err := git.RepoRoot()
+	if err != nil {
+		return checkResult{Name: name, Status: checkWarn, Message: "could not determine repo root: " + err.Error()}
+	}
+	cfg, err := config.LoadProject(root)
+	if err != nil {
+		return checkResult{Name: name, Status: checkWarn, Message: err.Error()}
+	}
+	layout, err := ledger.ParseLayout(cfg.Storage.Layout)
+	if err != nil {
+		return checkResult{
+			Name:    name,
+			Status:  checkFail,
+			Message: err.Error() + "; using \"day\"",
+			Hint:    "Set [storage] layout in " + config.ProjectFile,
+		}
+	}
+
+	store, err := ledger.NewDefaultStorage()
+	if err != nil {
+		return checkResult{Name: name, Status: checkWarn, Message: err.Error()}
+	}
+	misplaced, err := store.MisplacedEntries()
+	if err != nil {
+		return checkResult{Name: name, Status: checkWarn, Message: "scan failed: " + err.Error()}
+	}
+	if misplaced == 0 {
+		return checkResult{Name: name, Status: checkPass, Message: string(layout) + " layout"}
+	}
+	if flags != nil && flags.fix {
+		if moved, migErr := store.MigrateLayout(); migErr == nil {
+			return checkResult{
+				Name:    name,
+				Status:  checkPass,
+				Message: "moved " + strconv.Itoa(len(moved)) + " ledger file(s) into the " + string(layout) + " layout",
+				Hint:    "Commit the moves: git add -A .timbers && git commit",
+			}
+		}
+	}
+	return checkResult{
+		Name:    name,
+		Status:  checkWarn,
+		Message: strconv.Itoa(misplaced) + " ledger file(s) outside the " + string(layout) + " layout",
+		Hint:    "Run 'timbers doctor --fix' to move them, then commit",
+	}
+}
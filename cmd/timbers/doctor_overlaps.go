@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// checkEntryOverlaps warns when two entries document the same commits,
+// which a cherry-pick or rebase can cause by leaving a logged range pending
+// again. fsck lists the entry IDs.
+func checkEntryOverlaps() checkResult {
+	storage, err := ledger.NewDefaultStorage()
+	if err != nil {
+		return checkResult{Name: "Entry Overlaps", Status: checkPass, Message: "skipped: " + err.Error()}
+	}
+	entries, err := storage.ListEntries()
+	if err != nil {
+		return checkResult{Name: "Entry Overlaps", Status: checkWarn, Message: "could not list entries: " + err.Error()}
+	}
+	overlaps := ledger.FindOverlaps(ledger.FilterDeleted(entries, storage.DeletedSet()))
+	if len(overlaps) == 0 {
+		return checkResult{Name: "Entry Overlaps", Status: checkPass, Message: "no commit is documented twice"}
+	}
+	return checkResult{
+		Name:    "Entry Overlaps",
+		Status:  checkWarn,
+		Message: strconv.Itoa(len(overlaps)) + " set(s) of entries document the same commits",
+		Hint:    "Run 'timbers fsck' to list them, then 'timbers rm <id>' to drop a duplicate",
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+// checkShallowClone warns about a shallow clone. pending and --range then
+// see only the fetched history, so an anchor older than the fetch depth
+// reads as every fetched commit being undocumented.
+func checkShallowClone() checkResult {
+	if !git.IsShallow() {
+		return checkResult{Name: "Clone Depth", Status: checkPass, Message: "full history"}
+	}
+	message := "shallow clone — pending and --range see only the fetched history"
+	if count, err := git.FetchedCommitCount(); err == nil {
+		message = "shallow clone (" + strconv.Itoa(count) + " commits fetched) — pending and --range see only the fetched history"
+	}
+	return checkResult{
+		Name:    "Clone Depth",
+		Status:  checkWarn,
+		Message: message,
+		Hint:    "Run " + git.UnshallowHint,
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// checkTheme validates the [theme] section of the project config. An
+// invalid theme does not break anything — output falls back to the default
+// palette — so it is a warning.
+func checkTheme() checkResult {
+	root, err := git.RepoRoot()
+	if err != nil {
+		return checkResult{Name: "Theme", Status: checkPass, Message: "default (not in a git repo)"}
+	}
+	cfg, err := config.LoadProject(root)
+	if err != nil {
+		return checkResult{Name: "Theme", Status: checkPass, Message: "default (config does not parse)"}
+	}
+	theme := output.Theme{Background: cfg.Theme.Background, Colors: cfg.Theme.Colors}
+	if err := theme.Validate(); err != nil {
+		return checkResult{
+			Name:    "Theme",
+			Status:  checkWarn,
+			Message: err.Error() + " — using the default palette",
+			Hint:    fmt.Sprintf("Fix [theme] in %s; see the comments 'timbers init' writes there", config.ProjectFile),
+		}
+	}
+	message := theme.Background
+	if message == "" {
+		message = output.ThemeAuto
+	}
+	if len(theme.Colors) > 0 {
+		message += fmt.Sprintf(" (%d custom color(s))", len(theme.Colors))
+	}
+	if output.NoColorEnv() {
+		message += "; color off via NO_COLOR/CLICOLOR"
+	}
+	return checkResult{Name: "Theme", Status: checkPass, Message: message}
+}
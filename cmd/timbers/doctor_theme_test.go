@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckTheme(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     string
+		wantStatus checkStatus
+		wantMsg    string
+	}{
+		{name: "no config", wantStatus: checkPass, wantMsg: "auto"},
+		{name: "custom colors", config: "[theme]\nbackground = \"light\"\n[theme.colors]\ntitle = \"33\"\n",
+			wantStatus: checkPass, wantMsg: "light (1 custom color(s))"},
+		{name: "bad role", config: "[theme.colors]\nheading = \"33\"\n", wantStatus: checkWarn, wantMsg: "unknown role"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", "")
+			t.Setenv("CLICOLOR", "")
+			dir := newLogAnchorRepo(t)
+			if tt.config != "" {
+				path := filepath.Join(dir, ".timbers", "config.toml")
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(tt.config), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var result checkResult
+			runInDir(t, dir, func() { result = checkTheme() })
+			if result.Status != tt.wantStatus || !strings.Contains(result.Message, tt.wantMsg) {
+				t.Errorf("checkTheme() = %s %q, want %s containing %q", result.Status, result.Message, tt.wantStatus, tt.wantMsg)
+			}
+		})
+	}
+}
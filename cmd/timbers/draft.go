@@ -1,9 +1,7 @@
 package main
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 
@@ -41,6 +39,7 @@ Examples:
   timbers draft changelog --last 10 --model opus       # Generate with built-in LLM
   timbers draft devblog --since 7d --model opus --with-frontmatter
   timbers draft decision-digest --last 20              # Retrospective decision report
+  timbers draft changelog --since 7d --package api     # Only one monorepo package
   timbers draft --list                                 # List available templates
   timbers draft release-notes --last 5 --append "Focus on security changes"`,
 		Args: cobra.MaximumNArgs(1),
@@ -67,14 +66,14 @@ Examples:
 	cmd.Flags().StringVarP(&providerFlag, "provider", "p", "", "Provider (anthropic, openai, google, local) - inferred if omitted")
 	cmd.Flags().BoolVar(&withFrontmatterFlag, "with-frontmatter", false, "Include generation metadata as TOML frontmatter (requires --model)")
 	cmd.Flags().StringArrayVar(&varsFlag, "var", nil, "Template variable as key=value, substituted as {{vars.key}} (repeatable)")
+	registerPackageFlag(cmd)
 
 	return cmd
 }
 
 // runDraft executes the draft command.
 func runDraft(cmd *cobra.Command, args []string, flags draftFlags) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd)).
-		WithStderr(cmd.ErrOrStderr())
+	printer := newPrinter(cmd)
 
 	// Handle --list
 	if flags.list {
@@ -118,7 +117,7 @@ func prepareRender(printer *output.Printer, flags draftFlags) ([]*ledger.Entry,
 		return nil, nil, err
 	}
 
-	entries, err := getDraftEntries(printer, flags.last, flags.since, flags.until, flags.rng)
+	entries, err := getDraftEntries(printer, flags.last, flags.since, flags.until, flags.rng, flags.pkgFilter)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -135,9 +134,14 @@ func prepareRender(printer *output.Printer, flags draftFlags) ([]*ledger.Entry,
 
 // runDraftRender renders the template with entries and outputs the result.
 func runDraftRender(
-	_ *cobra.Command, printer *output.Printer,
+	cmd *cobra.Command, printer *output.Printer,
 	tmpl *draft.Template, templateName string, flags draftFlags,
 ) error {
+	var err error
+	if flags.pkgFilter, err = packagePathFilter(cmd); err != nil {
+		printer.Error(err)
+		return err
+	}
 	entries, renderCtx, err := prepareRender(printer, flags)
 	if err != nil {
 		return err
@@ -203,10 +207,10 @@ func runDraftWithLLM(
 	}
 
 	// Execute with timeout (2 minutes default, same as generate command)
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	ctx, cancel := llmContext()
 	defer cancel()
 
-	resp, err := client.Complete(ctx, req)
+	resp, err := completeWithProgress(ctx, printer, client, req)
 	if err != nil {
 		sysErr := output.NewSystemErrorWithCause("LLM request failed", err)
 		printer.Error(sysErr)
@@ -126,12 +126,14 @@ func parseTimeCutoffs(printer *output.Printer, sinceFlag, untilFlag string) (tim
 	return sinceCutoff, untilCutoff, nil
 }
 
-// getDraftEntries retrieves and validates the complete ledger before selecting entries.
+// getDraftEntries retrieves and validates the complete ledger before selecting
+// entries. A pathFilter keeps only entries that changed a matching file,
+// before --last counts them.
 func getDraftEntries(
-	printer *output.Printer, lastFlag, sinceFlag, untilFlag, rangeFlag string,
+	printer *output.Printer, lastFlag, sinceFlag, untilFlag, rangeFlag, pathFilter string,
 ) ([]*ledger.Entry, error) {
 	if !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return nil, err
 	}
@@ -149,6 +151,9 @@ func getDraftEntries(
 		printer.Error(integrityErr)
 		return nil, integrityErr
 	}
+	if pathFilter != "" {
+		allEntries = ledger.FilterEntriesByPaths(allEntries, []string{pathFilter})
+	}
 	return selectDraftEntries(printer, storage, allEntries, lastFlag, sinceFlag, untilFlag, rangeFlag)
 }
 
@@ -23,6 +23,7 @@ type draftFlags struct {
 	provider        string
 	withFrontmatter bool
 	vars            []string // "key=value" pairs from --var
+	pkgFilter       string   // --package directory as a path filter; "" keeps all
 }
 
 // parseVars converts "key=value" strings into a map.
@@ -0,0 +1,45 @@
+package main
+
+// Planned action verbs. Every --dry-run reports its changes with these, so
+// an agent can read any preview without knowing the command.
+const (
+	planCreate  = "create"  // a new file, entry, or ack
+	planModify  = "modify"  // an existing file changes (a section appended or removed, an entry amended)
+	planRemove  = "remove"  // a file or directory is deleted
+	planReplace = "replace" // a file is swapped for a new version
+	planSkip    = "skip"    // nothing happens; detail says why
+	planRun     = "run"     // a setup step; detail describes its effect
+)
+
+// plannedAction is one change a dry run would make.
+type plannedAction struct {
+	Action string `json:"action"`
+	Target string `json:"target"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// withPlan marks a dry-run JSON result: status "dry_run" and the planned
+// actions under "planned_actions" (an array even when empty), next to the
+// command's own fields.
+func withPlan(fields map[string]any, actions []plannedAction) map[string]any {
+	if actions == nil {
+		actions = []plannedAction{}
+	}
+	fields["status"] = "dry_run"
+	fields["planned_actions"] = actions
+	return fields
+}
+
+// planInitSteps turns init's dry-run steps into planned actions: steps that
+// would run become "run", the rest "skip".
+func planInitSteps(steps []initStepResult) []plannedAction {
+	plan := make([]plannedAction, 0, len(steps))
+	for _, step := range steps {
+		action := planSkip
+		if step.Status == "dry_run" {
+			action = planRun
+		}
+		plan = append(plan, plannedAction{Action: action, Target: step.Name, Detail: step.Message})
+	}
+	return plan
+}
@@ -0,0 +1,111 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// canPromptEditor reports whether an editor can be opened: stdin is a
+// terminal, so a user is there to close it.
+func canPromptEditor(stdin io.Reader) bool {
+	file, ok := stdin.(*os.File)
+	return ok && output.IsTTY(file)
+}
+
+// editText opens text in the editor git would use (GIT_EDITOR,
+// core.editor, VISUAL, EDITOR) and returns what the user saved.
+func editText(text string) (string, error) {
+	editor, err := git.Run("var", "GIT_EDITOR")
+	if err != nil || strings.TrimSpace(editor) == "" {
+		return "", output.NewUserError("no editor configured: set GIT_EDITOR, core.editor, VISUAL, or EDITOR")
+	}
+
+	file, err := os.CreateTemp("", "timbers-*.md")
+	if err != nil {
+		return "", output.NewSystemErrorWithCause("failed to create editor file", err)
+	}
+	path := file.Name()
+	defer func() { _ = os.Remove(path) }()
+	_, writeErr := file.WriteString(text)
+	if closeErr := file.Close(); writeErr != nil || closeErr != nil {
+		return "", output.NewSystemError("failed to write editor file " + path)
+	}
+
+	// Run through the shell like git does, so an editor with arguments
+	// ("code --wait") works.
+	//nolint:gosec // the user's own editor setting
+	run := exec.CommandContext(context.Background(), "sh", "-c", strings.TrimSpace(editor)+` "$@"`, "editor", path)
+	run.Stdin, run.Stdout, run.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if runErr := run.Run(); runErr != nil {
+		return "", output.NewUserError("editor exited with an error: " + runErr.Error())
+	}
+	edited, err := os.ReadFile(path) //nolint:gosec // the temp file created above
+	if err != nil {
+		return "", output.NewSystemErrorWithCause("failed to read editor file", err)
+	}
+	return string(edited), nil
+}
+
+// summarySections are the headings of a summary template, in order.
+var summarySections = []string{"What", "Why", "How", "Notes"}
+
+// formatSummaryTemplate renders a summary and notes for editing, under a
+// comment header.
+func formatSummaryTemplate(header string, summary ledger.Summary, notes string) string {
+	var builder strings.Builder
+	for line := range strings.SplitSeq(header, "\n") {
+		builder.WriteString("# " + line + "\n")
+	}
+	builder.WriteString("# Lines starting with '#' are ignored. An empty What aborts.\n")
+	writeSummarySections(&builder, summary, notes)
+	return builder.String()
+}
+
+// writeSummarySections writes the "## What" through "## Notes" sections of
+// a summary template.
+func writeSummarySections(builder *strings.Builder, summary ledger.Summary, notes string) {
+	for i, value := range []string{summary.What, summary.Why, summary.How, notes} {
+		builder.WriteString("\n## " + summarySections[i] + "\n" + value + "\n")
+	}
+}
+
+// parseSummaryTemplate reads back a template written by
+// formatSummaryTemplate. Lines before the first section are ignored.
+func parseSummaryTemplate(text string) (ledger.Summary, string, error) {
+	values := make(map[string][]string)
+	section := ""
+	for line := range strings.SplitSeq(text, "\n") {
+		if heading, ok := strings.CutPrefix(line, "## "); ok {
+			section = strings.TrimSpace(heading)
+			if !containsFold(summarySections, section) {
+				return ledger.Summary{}, "", output.NewUserError("unknown section \"## " + section + "\"; use What, Why, How, or Notes")
+			}
+			section = strings.ToLower(section)
+			continue
+		}
+		if strings.HasPrefix(line, "#") || section == "" {
+			continue
+		}
+		values[section] = append(values[section], line)
+	}
+	field := func(name string) string { return strings.TrimSpace(strings.Join(values[name], "\n")) }
+	return ledger.Summary{What: field("what"), Why: field("why"), How: field("how")}, field("notes"), nil
+}
+
+// containsFold reports whether values holds value, ignoring case.
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/spf13/cobra"
+
 	"github.com/gorewood/timbers/internal/ledger"
 	"github.com/gorewood/timbers/internal/output"
 )
@@ -32,48 +34,150 @@ func entryHasAnyTag(entry *ledger.Entry, tags []string) bool {
 	return ledger.EntryHasAnyTag(entry, tags)
 }
 
+// parseKindFlags validates --kind filter values. cobra's StringSliceVar has
+// already split repeated and comma-separated values.
+func parseKindFlags(kindFlags []string) ([]string, error) {
+	for _, kind := range kindFlags {
+		if _, err := ledger.ParseKind(kind); err != nil {
+			return nil, output.NewUserError("--kind: " + err.Error())
+		}
+	}
+	return kindFlags, nil
+}
+
+// entryMatch holds the content filters query and export share: --kind
+// (any of), --meta (all of), --path (any of), and the deleted entries to
+// hide; and query's --package, --branch, and --reachable-from.
+type entryMatch struct {
+	kinds     []string
+	meta      map[string]string
+	paths     []string
+	pkg       string // --package directory filter; "" keeps all
+	deleted   map[string]bool
+	branch    string
+	reachable map[string]bool // anchors to keep; nil keeps all
+}
+
+// parseEntryMatch validates --kind and --meta filter values.
+func parseEntryMatch(kindFlags, metaFlags []string) (entryMatch, error) {
+	kinds, err := parseKindFlags(kindFlags)
+	if err != nil {
+		return entryMatch{}, err
+	}
+	meta, err := ledger.ParseMetaFilter(metaFlags)
+	if err != nil {
+		return entryMatch{}, output.NewUserError("--meta: " + err.Error())
+	}
+	return entryMatch{kinds: kinds, meta: meta}, nil
+}
+
+// hidingDeleted returns m set to also drop entries with a tombstone, unless
+// the command's --include-deleted flag is set.
+func (m entryMatch) hidingDeleted(cmd *cobra.Command, storage *ledger.Storage) entryMatch {
+	if include, _ := cmd.Flags().GetBool("include-deleted"); !include {
+		m.deleted = storage.DeletedSet()
+	}
+	return m
+}
+
+// scopedToHistory returns m set to also keep only entries logged on the
+// command's --branch, and whose anchors are reachable from its
+// --reachable-from ref.
+func (m entryMatch) scopedToHistory(cmd *cobra.Command, storage *ledger.Storage) (entryMatch, error) {
+	m.branch, _ = cmd.Flags().GetString("branch")
+	ref, _ := cmd.Flags().GetString("reachable-from")
+	if ref == "" {
+		return m, nil
+	}
+	sha, err := storage.ResolveCommit(ref)
+	if err != nil {
+		return m, output.NewUserError("cannot resolve --reachable-from " + ref + ": " + err.Error())
+	}
+	if m.reachable, err = storage.ReachableCommits(sha); err != nil {
+		return m, output.NewSystemErrorWithCause("failed to list commits reachable from "+ref, err)
+	}
+	return m, nil
+}
+
+// includeArchived makes storage read archived entries too when the
+// command's --include-archived flag is set.
+func includeArchived(cmd *cobra.Command, storage *ledger.Storage) {
+	if include, _ := cmd.Flags().GetBool("include-archived"); include {
+		storage.SetIncludeArchived(true)
+	}
+}
+
+// active reports whether any filter is set.
+func (m entryMatch) active() bool {
+	return len(m.kinds) > 0 || len(m.meta) > 0 || len(m.paths) > 0 || len(m.deleted) > 0 ||
+		m.pkg != "" || m.branch != "" || m.reachable != nil
+}
+
+// pageLimit returns the List limit that still leaves count entries once m
+// is applied, or 0 when m can drop any number of entries and the whole range
+// must be read. Only deleted entries can be over-fetched: each tombstone
+// hides at most one.
+func (m entryMatch) pageLimit(count int) int {
+	if count <= 0 || len(m.kinds) > 0 || len(m.meta) > 0 || len(m.paths) > 0 || m.pkg != "" ||
+		m.branch != "" || m.reachable != nil {
+		return 0
+	}
+	return count + len(m.deleted)
+}
+
+// apply keeps the entries that pass every filter.
+func (m entryMatch) apply(entries []*ledger.Entry) []*ledger.Entry {
+	entries = ledger.FilterEntriesByPaths(ledger.FilterDeleted(entries, m.deleted), m.paths)
+	if m.pkg != "" {
+		entries = ledger.FilterEntriesByPaths(entries, []string{m.pkg})
+	}
+	entries = ledger.FilterEntriesByAnchors(ledger.FilterEntriesByBranch(entries, m.branch), m.reachable)
+	return ledger.FilterEntriesByMeta(ledger.FilterEntriesByKinds(entries, m.kinds), m.meta)
+}
+
 // sortEntriesByCreatedAt sorts entries by created_at descending (most recent first).
 func sortEntriesByCreatedAt(entries []*ledger.Entry) {
 	ledger.SortEntriesByCreatedAt(entries)
 }
 
-// getEntriesByTimeRange retrieves entries within the time range, with optional limit and tag filtering.
+// getEntriesByTimeRange retrieves entries within the time range, newest first, with optional limit and
+// tag/kind/meta filtering. Without tag, kind, or meta filters only the entries the limit needs are read.
 //
 //nolint:unparam // tagFlags will be used by callers beyond export
 func getEntriesByTimeRange(
 	printer *output.Printer, storage *ledger.Storage,
-	sinceCutoff, untilCutoff time.Time, lastFlag string, tagFlags []string,
+	sinceCutoff, untilCutoff time.Time, lastFlag string, tagFlags []string, match entryMatch,
 ) ([]*ledger.Entry, error) {
-	entries, err := storage.ListEntries()
+	count, parseErr := strconv.Atoi(lastFlag)
+	if parseErr != nil {
+		count = 0
+	}
+	limit := 0
+	if len(tagFlags) == 0 {
+		limit = match.pageLimit(count)
+	}
+	entries, err := storage.List(ledger.ListOptions{Since: sinceCutoff, Until: untilCutoff, Limit: limit})
 	if err != nil {
 		printer.Error(err)
 		return nil, err
 	}
 
-	if !sinceCutoff.IsZero() {
-		entries = ledger.FilterEntriesSince(entries, sinceCutoff)
-	}
-	if !untilCutoff.IsZero() {
-		entries = ledger.FilterEntriesUntil(entries, untilCutoff)
-	}
 	if len(tagFlags) > 0 {
 		entries = ledger.FilterEntriesByTags(entries, tagFlags)
 	}
+	entries = match.apply(entries)
 
-	ledger.SortEntriesByCreatedAt(entries)
-
-	if lastFlag != "" {
-		count, parseErr := strconv.Atoi(lastFlag)
-		if parseErr == nil && count > 0 && len(entries) > count {
-			entries = entries[:count]
-		}
+	if count > 0 && len(entries) > count {
+		entries = entries[:count]
 	}
 
 	return entries, nil
 }
 
-// getEntriesByLast retrieves the last N entries with optional tag filtering.
-func getEntriesByLast(printer *output.Printer, storage *ledger.Storage, lastFlag string, tagFlags []string) ([]*ledger.Entry, error) {
+// getEntriesByLast retrieves the last N entries with optional tag, kind, and meta filtering.
+func getEntriesByLast(
+	printer *output.Printer, storage *ledger.Storage, lastFlag string, tagFlags []string, match entryMatch,
+) ([]*ledger.Entry, error) {
 	count, parseErr := strconv.Atoi(lastFlag)
 	if parseErr != nil || count <= 0 {
 		err := output.NewUserError("--last must be a positive integer")
@@ -81,28 +185,7 @@ func getEntriesByLast(printer *output.Printer, storage *ledger.Storage, lastFlag
 		return nil, err
 	}
 
-	// If tag filtering is needed, we can't use the optimized path
-	if len(tagFlags) > 0 {
-		entries, err := storage.ListEntries()
-		if err != nil {
-			printer.Error(err)
-			return nil, err
-		}
-		entries = ledger.FilterEntriesByTags(entries, tagFlags)
-		ledger.SortEntriesByCreatedAt(entries)
-		if len(entries) > count {
-			entries = entries[:count]
-		}
-		return entries, nil
-	}
-
-	// Optimized path when no tag filtering
-	entries, err := storage.GetLastNEntries(count)
-	if err != nil {
-		printer.Error(err)
-		return nil, err
-	}
-	return entries, nil
+	return getEntriesByTimeRange(printer, storage, time.Time{}, time.Time{}, lastFlag, tagFlags, match)
 }
 
 // getEntriesByRange retrieves entries whose commits fall within the given range.
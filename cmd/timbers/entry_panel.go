@@ -5,18 +5,27 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/gorewood/timbers/internal/age"
 	"github.com/gorewood/timbers/internal/ledger"
 	"github.com/gorewood/timbers/internal/output"
 )
 
-// substanceFields builds the shared What/Why/How(/Notes/Tags/Work) rows that
-// lead both the show and dry-run panels. What and Why are emphasized so the
-// substance of the entry reads first; optional rows appear only when set.
+// substanceFields builds the shared (Kind/)What/Why/How(/Notes/Tags/Work/Meta) rows
+// that lead both the show and dry-run panels. What and Why are emphasized so
+// the substance of the entry reads first; optional rows appear only when set.
+// Kind is shown only for non-entry records, and Why/How only when the kind
+// carried them.
 func substanceFields(entry *ledger.Entry) []output.Field {
-	fields := []output.Field{
-		{Key: "What", Value: entry.Summary.What, Emphasis: true},
-		{Key: "Why", Value: entry.Summary.Why, Emphasis: true},
-		{Key: "How", Value: entry.Summary.How},
+	var fields []output.Field
+	if kind := entry.KindOrDefault(); kind != ledger.KindEntry {
+		fields = append(fields, output.Field{Key: "Kind", Value: kind})
+	}
+	fields = append(fields, output.Field{Key: "What", Value: entry.Summary.What, Emphasis: true})
+	if entry.Summary.Why != "" || ledger.RequiresWhy(entry.KindOrDefault()) {
+		fields = append(fields, output.Field{Key: "Why", Value: entry.Summary.Why, Emphasis: true})
+	}
+	if entry.Summary.How != "" || ledger.RequiresHow(entry.KindOrDefault()) {
+		fields = append(fields, output.Field{Key: "How", Value: entry.Summary.How})
 	}
 	if entry.Notes != "" {
 		fields = append(fields, output.Field{Key: "Notes", Value: entry.Notes})
@@ -27,6 +36,9 @@ func substanceFields(entry *ledger.Entry) []output.Field {
 	if work := formatWorkItems(entry.WorkItems); work != "" {
 		fields = append(fields, output.Field{Key: "Work", Value: work})
 	}
+	if len(entry.Meta) > 0 {
+		fields = append(fields, output.Field{Key: "Meta", Value: ledger.FormatMeta(entry.Meta)})
+	}
 	return fields
 }
 
@@ -47,10 +59,12 @@ func dryRunFields(entry *ledger.Entry) []output.Field {
 // showFields builds the field rows for `timbers show`: substance first, a
 // separator, then the workset bookkeeping. The entry ID is the panel title
 // (it is the thing you copy), so it is not repeated in the body.
-func showFields(entry *ledger.Entry) []output.Field {
+func showFields(entry *ledger.Entry, linker *links) []output.Field {
 	fields := substanceFields(entry)
+	fields = append(fields, relationFields(entry)...)
+	fields = append(fields, encryptionFields(entry)...)
 	fields = append(fields, output.Separator())
-	fields = append(fields, output.Field{Key: "Anchor", Value: anchorDisplay(entry.Workset.AnchorCommit)})
+	fields = append(fields, output.Field{Key: "Anchor", Value: linker.anchor(entry.Workset.AnchorCommit)})
 	if len(entry.Workset.Commits) > 0 {
 		commits := strconv.Itoa(len(entry.Workset.Commits))
 		if entry.Workset.Range != "" {
@@ -61,10 +75,32 @@ func showFields(entry *ledger.Entry) []output.Field {
 	if entry.Workset.Diffstat != nil {
 		fields = append(fields, output.Field{Key: "Files", Value: formatDiffstat(entry.Workset.Diffstat)})
 	}
+	if signatures := formatSignatures(&entry.Workset); signatures != "" {
+		fields = append(fields, output.Field{Key: "Signed", Value: signatures})
+	}
 	fields = append(fields, output.Field{Key: "Created", Value: entry.CreatedAt.Format("2006-01-02 15:04:05 UTC")})
 	return fields
 }
 
+// relationLabels are the panel row keys for each relation type.
+var relationLabels = map[string]string{
+	ledger.RelationSupersedes: "Supersedes",
+	ledger.RelationFixes:      "Fixes",
+	ledger.RelationRelatesTo:  "Related",
+}
+
+// relationFields builds one row per relation type the entry carries, listing
+// the related entry IDs.
+func relationFields(entry *ledger.Entry) []output.Field {
+	var fields []output.Field
+	for _, relType := range ledger.RelationTypes {
+		if ids := entry.RelationIDs(relType); len(ids) > 0 {
+			fields = append(fields, output.Field{Key: relationLabels[relType], Value: strings.Join(ids, ", ")})
+		}
+	}
+	return fields
+}
+
 // formatWorkItems renders work items as "system:id, system:id".
 func formatWorkItems(items []ledger.WorkItem) string {
 	if len(items) == 0 {
@@ -76,3 +112,16 @@ func formatWorkItems(items []ledger.WorkItem) string {
 	}
 	return strings.Join(parts, ", ")
 }
+
+// encryptionFields returns an Encrypted row for entries stored encrypted,
+// noting when no identity was available to decrypt them.
+func encryptionFields(entry *ledger.Entry) []output.Field {
+	if !entry.IsEncrypted() {
+		return nil
+	}
+	value := "yes"
+	if entry.IsSealed() {
+		value = "yes (set " + age.IdentityEnv + " to decrypt)"
+	}
+	return []output.Field{{Key: "Encrypted", Value: value}}
+}
@@ -89,7 +89,7 @@ func TestDryRunFieldsOrder(t *testing.T) {
 // TestShowFieldsTitleNotInBody verifies the ID is not duplicated in the body
 // (it is the panel title), and substance leads with bookkeeping trailing.
 func TestShowFieldsTitleNotInBody(t *testing.T) {
-	fields := showFields(sampleEntry())
+	fields := showFields(sampleEntry(), nil)
 	if hasKey(fields, "ID") {
 		t.Error("show body must not repeat the ID (it is the title)")
 	}
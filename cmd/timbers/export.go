@@ -29,6 +29,8 @@ func newExportCmdInternal(storage *ledger.Storage) *cobra.Command {
 	var formatFlag string
 	var outFlag string
 	var tagFlags []string
+	var kindFlags []string
+	var metaFlags []string
 
 	cmd := &cobra.Command{
 		Use:   "export",
@@ -44,9 +46,19 @@ Examples:
   timbers export --range v1.0.0..v1.1.0 --json      # Export range as JSON
   timbers export --last 10 --format md --out ./notes/ # Export last 10 as markdown files
   timbers export --last 10 --tag security           # Export last 10 security-tagged entries
-  timbers export --since 7d --tag feature,bugfix    # Export feature or bugfix entries from last 7 days`,
+  timbers export --since 7d --tag feature,bugfix    # Export feature or bugfix entries from last 7 days
+  timbers export --since 30d --meta service=api     # Export entries whose meta service is api
+  timbers export --last 100 --format adr --out docs/adr/  # Write decisions as numbered ADR files
+
+--query and --ndjson shape the JSON written to stdout, so they cannot be
+combined with --out or a non-JSON --format. Example:
+  timbers export --since 7d --query 'map(select(.tags | index("security")))'
+
+--format adr writes only decision entries (timbers decide), numbered in the
+order they were recorded across the whole ledger.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runExport(cmd, storage, lastFlag, sinceFlag, untilFlag, rangeFlag, formatFlag, outFlag, tagFlags)
+			return runExport(cmd, storage, lastFlag, sinceFlag, untilFlag, rangeFlag, formatFlag, outFlag,
+				tagFlags, kindFlags, metaFlags)
 		},
 	}
 
@@ -55,8 +67,15 @@ Examples:
 	cmd.Flags().StringVar(&untilFlag, "until", "", "Export entries until duration (24h, 7d) or date (2026-01-17)")
 	cmd.Flags().StringVar(&rangeFlag, "range", "", "Export entries in commit range (A..B)")
 	cmd.Flags().StringSliceVar(&tagFlags, "tag", []string{}, "Filter by tag (can specify multiple times or comma-separated)")
-	cmd.Flags().StringVar(&formatFlag, "format", "", "Output format: json or md (default: json for stdout, md for --out)")
+	cmd.Flags().StringSliceVar(&kindFlags, "kind", []string{},
+		"Filter by kind: entry, decision, incident, milestone, note (repeatable or comma-separated)")
+	cmd.Flags().StringArrayVar(&metaFlags, "meta", nil, "Filter by meta field: key=value, or key to require it (repeatable; all must match)")
+	cmd.Flags().Bool("include-deleted", false, "Include entries deleted with timbers rm")
+	cmd.Flags().Bool("include-archived", false, "Include entries moved aside with timbers archive")
+	cmd.Flags().StringVar(&formatFlag, "format", "", "Output format: json, md, or adr (default: json for stdout, md for --out)")
 	cmd.Flags().StringVar(&outFlag, "out", "", "Output directory (if omitted, writes to stdout)")
+	cmd.Flags().Bool("ndjson", false, "Stream JSON: one entry per line (implies --json)")
+	cmd.Flags().String("query", "", "Filter JSON output through a jq expression (implies --json)")
 
 	return cmd
 }
@@ -64,55 +83,45 @@ Examples:
 // runExport executes the export command.
 func runExport(
 	cmd *cobra.Command, storage *ledger.Storage,
-	lastFlag, sinceFlag, untilFlag, rangeFlag, formatFlag, outFlag string, tagFlags []string,
+	lastFlag, sinceFlag, untilFlag, rangeFlag, formatFlag, outFlag string, tagFlags, kindFlags, metaFlags []string,
 ) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd)).
-		WithStderr(cmd.ErrOrStderr())
+	printer := newPrinter(cmd)
 
 	if err := validateExportFlags(printer, lastFlag, sinceFlag, untilFlag, rangeFlag); err != nil {
 		return err
 	}
 
-	// Parse --since if provided
-	var sinceCutoff time.Time
-	if sinceFlag != "" {
-		var parseErr error
-		sinceCutoff, parseErr = parseSinceValue(sinceFlag)
-		if parseErr != nil {
-			err := output.NewUserError(parseErr.Error())
-			printer.Error(err)
-			return err
-		}
-	}
-
-	// Parse --until if provided
-	var untilCutoff time.Time
-	if untilFlag != "" {
-		var parseErr error
-		untilCutoff, parseErr = parseUntilValue(untilFlag)
-		if parseErr != nil {
-			err := output.NewUserError(parseErr.Error())
-			printer.Error(err)
-			return err
-		}
+	sinceCutoff, untilCutoff, err := parseTimeCutoffs(printer, sinceFlag, untilFlag)
+	if err != nil {
+		return err
 	}
 
 	format := determineFormat(formatFlag, outFlag)
-	if err := validateFormat(printer, format); err != nil {
+	if err = validateFormat(printer, format); err != nil {
+		return err
+	}
+	if err = validateExportStdout(cmd, printer, format, outFlag); err != nil {
+		return err
+	}
+	match, err := parseEntryMatch(kindFlags, metaFlags)
+	if err != nil {
+		printer.Error(err)
 		return err
 	}
 
-	storage, err := ensureStorage(printer, storage)
+	storage, err = ensureStorage(printer, storage)
 	if err != nil {
 		return err
 	}
 
-	entries, err := getExportEntries(printer, storage, lastFlag, sinceCutoff, untilCutoff, rangeFlag, tagFlags)
+	includeArchived(cmd, storage)
+	match = match.hidingDeleted(cmd, storage)
+	entries, err := getExportEntries(printer, storage, lastFlag, sinceCutoff, untilCutoff, rangeFlag, tagFlags, match)
 	if err != nil {
 		return err
 	}
 
-	return writeExportOutput(printer, entries, format, outFlag)
+	return writeExportOutput(printer, storage, entries, format, outFlag)
 }
 
 // validateExportFlags checks that required flags are provided.
@@ -125,6 +134,17 @@ func validateExportFlags(printer *output.Printer, lastFlag, sinceFlag, untilFlag
 	return nil
 }
 
+// validateExportStdout rejects --query and --ndjson unless the export is
+// JSON on stdout, the only output they apply to.
+func validateExportStdout(cmd *cobra.Command, printer *output.Printer, format, outFlag string) error {
+	if (queryExpr(cmd) == "" && !isNDJSONMode(cmd)) || (format == "json" && outFlag == "") {
+		return nil
+	}
+	err := output.NewUserError("--query and --ndjson apply only to JSON written to stdout; drop --out and --format " + format)
+	printer.Error(err)
+	return err
+}
+
 // determineFormat returns the format to use based on flags.
 func determineFormat(formatFlag, outFlag string) string {
 	if formatFlag != "" {
@@ -139,8 +159,8 @@ func determineFormat(formatFlag, outFlag string) string {
 
 // validateFormat checks that the format is valid.
 func validateFormat(printer *output.Printer, format string) error {
-	if format != "json" && format != "md" {
-		err := output.NewUserError("--format must be 'json' or 'md'")
+	if format != "json" && format != "md" && format != "adr" {
+		err := output.NewUserError("--format must be 'json', 'md', or 'adr'")
 		printer.Error(err)
 		return err
 	}
@@ -148,13 +168,13 @@ func validateFormat(printer *output.Printer, format string) error {
 }
 
 // ensureStorage returns the storage, creating one if needed.
-func ensureStorage(printer *output.Printer, storage *ledger.Storage) (*ledger.Storage, error) {
+func ensureStorage(printer output.Reporter, storage *ledger.Storage) (*ledger.Storage, error) {
 	if storage != nil {
 		return storage, nil
 	}
 
 	if !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return nil, err
 	}
@@ -162,9 +182,10 @@ func ensureStorage(printer *output.Printer, storage *ledger.Storage) (*ledger.St
 	return ledger.NewDefaultStorage()
 }
 
-// getExportEntries retrieves entries based on --last, --since, --until, --range, or --tag flags.
+// getExportEntries retrieves entries based on --last, --since, --until, --range, --tag, --kind, or --meta flags.
 func getExportEntries(
-	printer *output.Printer, storage *ledger.Storage, lastFlag string, sinceCutoff, untilCutoff time.Time, rangeFlag string, tagFlags []string,
+	printer *output.Printer, storage *ledger.Storage, lastFlag string, sinceCutoff, untilCutoff time.Time,
+	rangeFlag string, tagFlags []string, match entryMatch,
 ) ([]*ledger.Entry, error) {
 	// If --range is specified, use commit-based filtering
 	if rangeFlag != "" {
@@ -184,20 +205,23 @@ func getExportEntries(
 		if len(tagFlags) > 0 {
 			entries = filterEntriesByTags(entries, tagFlags)
 		}
-		return entries, nil
+		return match.apply(entries), nil
 	}
 
 	// If --since or --until is specified, filter by time
 	if !sinceCutoff.IsZero() || !untilCutoff.IsZero() {
-		return getEntriesByTimeRange(printer, storage, sinceCutoff, untilCutoff, lastFlag, tagFlags)
+		return getEntriesByTimeRange(printer, storage, sinceCutoff, untilCutoff, lastFlag, tagFlags, match)
 	}
 
 	// Otherwise use --last
-	return getEntriesByLast(printer, storage, lastFlag, tagFlags)
+	return getEntriesByLast(printer, storage, lastFlag, tagFlags, match)
 }
 
 // writeExportOutput writes entries to stdout or directory based on flags.
-func writeExportOutput(printer *output.Printer, entries []*ledger.Entry, format, outFlag string) error {
+func writeExportOutput(printer *output.Printer, storage *ledger.Storage, entries []*ledger.Entry, format, outFlag string) error {
+	if format == "adr" {
+		return writeADRExport(printer, storage, entries, outFlag)
+	}
 	if outFlag == "" {
 		return writeToStdout(printer, entries, format)
 	}
@@ -235,6 +259,10 @@ func writeToDirectory(printer *output.Printer, entries []*ledger.Entry, format,
 	}
 
 	if writeErr != nil {
+		if partial := output.AsPartial(writeErr); partial != nil && !printer.IsJSON() {
+			printer.Print("Exported %d of %d entries to %s\n",
+				len(partial.Items)-partial.Failed(), len(partial.Items), outFlag)
+		}
 		printer.Error(writeErr)
 		return writeErr
 	}
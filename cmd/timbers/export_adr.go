@@ -0,0 +1,64 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gorewood/timbers/internal/export"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// writeADRExport writes the decisions among entries as ADR markdown, to
+// stdout or as numbered files in outFlag. Numbers come from every decision in
+// the ledger so they stay stable however the export was filtered.
+func writeADRExport(printer *output.Printer, storage *ledger.Storage, entries []*ledger.Entry, outFlag string) error {
+	all, err := storage.ListEntries()
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	idx := export.NewADRIndex(all)
+	decisions := ledger.Decisions(entries)
+
+	if outFlag == "" {
+		for i, entry := range decisions {
+			if i > 0 {
+				printer.Println("---")
+			}
+			printer.Print("%s", idx.FormatADR(entry))
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(outFlag, 0755); err != nil {
+		sysErr := output.NewSystemError(fmt.Sprintf("failed to create output directory: %v", err))
+		printer.Error(sysErr)
+		return sysErr
+	}
+	if err := export.WriteADRFiles(decisions, idx, outFlag); err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	if printer.IsJSON() {
+		files := make([]string, len(decisions))
+		entryIDs := make([]string, len(decisions))
+		for i, entry := range decisions {
+			files[i] = idx.Filename(entry.ID)
+			entryIDs[i] = entry.ID
+		}
+		return printer.Success(map[string]any{
+			"status":     "ok",
+			"count":      len(decisions),
+			"format":     "adr",
+			"output_dir": outFlag,
+			"entry_ids":  entryIDs,
+			"files":      files,
+		})
+	}
+
+	printer.Print("Exported %d decisions to %s\n", len(decisions), outFlag)
+	return nil
+}
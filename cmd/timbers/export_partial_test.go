@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// TestExportToDirectoryPartialFailure covers export --out when some entry
+// files cannot be written: the rest are still exported and the command
+// exits 4 with each entry's outcome in the JSON error details.
+func TestExportToDirectoryPartialFailure(t *testing.T) {
+	now := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	blockedData := createExportTestEntry("anchor1", "first", now.Add(-time.Hour))
+	notes := map[string][]byte{
+		"anchor1": blockedData,
+		"anchor2": createExportTestEntry("anchor2", "second", now),
+	}
+	blocked, err := ledger.FromJSON(blockedData)
+	if err != nil {
+		t.Fatalf("failed to parse entry: %v", err)
+	}
+
+	// A directory where an entry's file belongs makes that one write fail.
+	tmpDir := t.TempDir()
+	if err = os.Mkdir(filepath.Join(tmpDir, blocked.ID+".json"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newExportCmdInternal(newExportTestStorage(t, notes))
+	cmd.PersistentFlags().Bool("json", false, "")
+	_ = cmd.PersistentFlags().Set("json", "true")
+	cmd.SetArgs([]string{"--last", "2", "--format", "json", "--out", tmpDir})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	var buf strings.Builder
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+
+	err = cmd.Execute()
+	if output.GetExitCode(err) != output.ExitPartial {
+		t.Fatalf("Execute() error = %v (code %d), want exit %d\n%s",
+			err, output.GetExitCode(err), output.ExitPartial, buf.String())
+	}
+
+	var result struct {
+		ErrorCode string `json:"error_code"`
+		Details   struct {
+			Succeeded int                 `json:"succeeded"`
+			Failed    int                 `json:"failed"`
+			Items     []output.ItemResult `json:"items"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(buf.String()), &result); err != nil {
+		t.Fatalf("output is not one JSON error: %v\n%s", err, buf.String())
+	}
+	if result.ErrorCode != output.ErrCodePartial || result.Details.Succeeded != 1 || result.Details.Failed != 1 {
+		t.Errorf("error = %+v, want PARTIAL with 1 succeeded and 1 failed", result)
+	}
+	for _, item := range result.Details.Items {
+		wantStatus := output.ItemOK
+		if item.Item == blocked.ID {
+			wantStatus = output.ItemFailed
+		}
+		if item.Status != wantStatus {
+			t.Errorf("item %s status = %q, want %q", item.Item, item.Status, wantStatus)
+		}
+		if item.Status == output.ItemOK {
+			if _, err := os.Stat(item.ID); err != nil {
+				t.Errorf("exported file %s missing: %v", item.ID, err)
+			}
+		}
+	}
+}
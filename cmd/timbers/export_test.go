@@ -2,6 +2,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -16,44 +17,58 @@ import (
 // mockGitOpsForExport implements ledger.GitOps for testing export command.
 type mockGitOpsForExport struct{}
 
-func (m *mockGitOpsForExport) HEAD() (string, error) {
+func (m *mockGitOpsForExport) HEAD(_ context.Context) (string, error) {
 	return "head123", nil
 }
 
-func (m *mockGitOpsForExport) Log(_, _ string) ([]git.Commit, error) {
+func (m *mockGitOpsForExport) Log(_ context.Context, _, _ string) ([]git.Commit, error) {
 	return nil, nil
 }
 
-func (m *mockGitOpsForExport) LogFirstParent(_, _ string) ([]git.Commit, error) {
+func (m *mockGitOpsForExport) LogFirstParent(_ context.Context, _, _ string) ([]git.Commit, error) {
 	return nil, nil
 }
 
-func (m *mockGitOpsForExport) ResolveCommit(ref string) (string, error) {
+func (m *mockGitOpsForExport) ResolveCommit(_ context.Context, ref string) (string, error) {
 	return ref, nil
 }
 
-func (m *mockGitOpsForExport) CommitsReachableFrom(_ string) ([]git.Commit, error) {
+func (m *mockGitOpsForExport) CommitsReachableFrom(_ context.Context, _ string) ([]git.Commit, error) {
 	return nil, nil
 }
 
-func (m *mockGitOpsForExport) IsAncestorOf(ancestor, descendant string) bool {
+func (m *mockGitOpsForExport) CommitsReachableFromFirstParent(ctx context.Context, sha string) ([]git.Commit, error) {
+	return m.CommitsReachableFrom(ctx, sha)
+}
+
+func (m *mockGitOpsForExport) IsAncestorOf(_ context.Context, ancestor, descendant string) bool {
 	return true
 }
 
-func (m *mockGitOpsForExport) IsOnFirstParentLine(sha, head string) bool {
+func (m *mockGitOpsForExport) IsOnFirstParentLine(_ context.Context, sha, head string) bool {
 	return true
 }
 
-func (m *mockGitOpsForExport) GetDiffstat(_, _ string) (git.Diffstat, error) {
+func (m *mockGitOpsForExport) IsShallow(_ context.Context) bool {
+	return false
+}
+
+func (m *mockGitOpsForExport) GetDiffstat(_ context.Context, _, _ string) (git.Diffstat, error) {
 	return git.Diffstat{}, nil
 }
 
-func (m *mockGitOpsForExport) CommitFiles(sha string) ([]string, error) { return nil, nil }
-func (m *mockGitOpsForExport) CommitFilesMulti(shas []string) (map[string][]string, error) {
+func (m *mockGitOpsForExport) CommitFiles(_ context.Context, sha string) ([]string, error) {
+	return nil, nil
+}
+func (m *mockGitOpsForExport) CommitFilesMulti(_ context.Context, shas []string) (map[string][]string, error) {
 	return make(map[string][]string), nil
 }
 
-func (m *mockGitOpsForExport) DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
+func (m *mockGitOpsForExport) CommitFilesMultiFirstParent(ctx context.Context, shas []string) (map[string][]string, error) {
+	return m.CommitFilesMulti(ctx, shas)
+}
+
+func (m *mockGitOpsForExport) DiffNameOnly(_ context.Context, fromRef, toRef, pathPrefix string) ([]string, error) {
 	return nil, nil
 }
 
@@ -0,0 +1,31 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// firstParentFlag is the flag pending and log share to override the
+// [pending] first_parent config.
+const firstParentFlag = "first-parent"
+
+// registerFirstParentFlag adds --first-parent to cmd.
+func registerFirstParentFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(firstParentFlag, false,
+		"Follow first parents only: merged branches count as their merge commit (default from [pending] first_parent)")
+}
+
+// applyFirstParentFlag switches storage to first-parent traversal when
+// --first-parent was given. An unset flag keeps the config default, so
+// --first-parent=false can turn a configured default off.
+func applyFirstParentFlag(cmd *cobra.Command, storage *ledger.Storage) {
+	if !cmd.Flags().Changed(firstParentFlag) {
+		return
+	}
+	enabled, err := cmd.Flags().GetBool(firstParentFlag)
+	if err == nil {
+		storage.SetFirstParent(enabled)
+	}
+}
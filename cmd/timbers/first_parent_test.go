@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// newMergeRepo returns a documented repo followed by a --no-ff merge of a
+// two-commit side branch, so pending sees two side commits and one merge.
+func newMergeRepo(t *testing.T) string {
+	t.Helper()
+	dir := newLogAnchorRepo(t)
+	if out, err := runLogCmd(t, dir, "Seed work", "--minor"); err != nil {
+		t.Fatalf("timbers log errored: %v\noutput: %s", err, out)
+	}
+	runGit(t, dir, "checkout", "-b", "side")
+	writeAndCommit(t, dir, "a.go", "package main\n", "feat: side a")
+	writeAndCommit(t, dir, "b.go", "package main\n", "feat: side b")
+	runGit(t, dir, "checkout", "-")
+	runGit(t, dir, "merge", "--no-ff", "-m", "Merge branch 'side'", "side")
+	return dir
+}
+
+// pendingSubjects runs `timbers pending --json` with args in dir.
+func pendingSubjects(t *testing.T, dir string, args ...string) []string {
+	t.Helper()
+	var out strings.Builder
+	runInDir(t, dir, func() {
+		cmd := newRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append([]string{"pending", "--json"}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("timbers pending errored: %v\noutput: %s", err, out.String())
+		}
+	})
+	var result pendingResult
+	if err := json.Unmarshal([]byte(out.String()), &result); err != nil {
+		t.Fatalf("parse pending output: %v\n%s", err, out.String())
+	}
+	subjects := make([]string, 0, len(result.Commits))
+	for _, commit := range result.Commits {
+		subjects = append(subjects, commit.Subject)
+	}
+	return subjects
+}
+
+func TestPendingFirstParent(t *testing.T) {
+	dir := newMergeRepo(t)
+
+	if got := pendingSubjects(t, dir); len(got) != 2 || !strings.HasPrefix(got[0], "feat: side") {
+		t.Errorf("pending = %v, want the two side commits", got)
+	}
+	if got := pendingSubjects(t, dir, "--first-parent"); len(got) != 1 || got[0] != "Merge branch 'side'" {
+		t.Errorf("pending --first-parent = %v, want only the merge", got)
+	}
+
+	writeAndCommit(t, dir, ".timbers/config.toml", "[pending]\nfirst_parent = true\n", "chore: timbers config")
+	if got := pendingSubjects(t, dir); len(got) != 1 || got[0] != "Merge branch 'side'" {
+		t.Errorf("pending with first_parent config = %v, want only the merge", got)
+	}
+	if got := pendingSubjects(t, dir, "--first-parent=false"); len(got) != 2 || !strings.HasPrefix(got[0], "feat: side") {
+		t.Errorf("pending --first-parent=false = %v, want the side commits despite the config", got)
+	}
+}
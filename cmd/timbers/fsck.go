@@ -0,0 +1,227 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// fsckResult is what fsck found and rebuilt.
+type fsckResult struct {
+	Files      int              `json:"files"`
+	Entries    int              `json:"entries"`
+	NotTimbers int              `json:"not_timbers"`
+	Corrupt    []string         `json:"corrupt"`
+	Problems   []ledger.Problem `json:"problems"`
+	Skipped    []string         `json:"skipped,omitempty"`
+	Overlaps   []ledger.Overlap `json:"overlaps"`
+	Reindexed  []string         `json:"reindexed,omitempty"`
+	Recorded   []string         `json:"recorded,omitempty"`
+}
+
+// fsckFlags holds the fsck command's flags.
+type fsckFlags struct {
+	reindex bool
+	verify  bool
+}
+
+// newFsckCmd creates the fsck command.
+func newFsckCmd() *cobra.Command {
+	return newFsckCmdInternal(nil)
+}
+
+// newFsckCmdInternal creates the fsck command with optional storage
+// injection. If storage is nil, a real storage is created when the command
+// runs.
+func newFsckCmdInternal(storage *ledger.Storage) *cobra.Command {
+	var flags fsckFlags
+
+	cmd := &cobra.Command{
+		Use:   "fsck",
+		Short: "Validate every ledger file, and rebuild local indexes",
+		Long: `Read every file under .timbers/ and report the ones that are not valid
+entries, then check each entry for:
+
+  schema    fields that do not match the entry schema
+  id        a malformed ID, or one the file name does not carry
+  location  a file outside the directory its ID dates it to
+  anchor    an anchor commit missing from the repository (skipped in a
+            shallow clone)
+  relation  a relation naming an entry that is not in the ledger
+
+Exits non-zero when any entry file is malformed or has a problem.
+
+--verify also compares each entry file with the checksum recorded when
+timbers last wrote it, and reports as "checksum" problems the entries that
+were edited by hand, mangled by a merge, or deleted outside timbers.
+Checksums are kept locally in .git/timbers/, so entries from other clones
+are recorded the first time --verify sees them. Files that match what HEAD
+commits, and entries HEAD no longer has, are trusted as well, so amends
+pulled from other clones pass; edits that are not committed are reported.
+
+Also reports entries that document the same commits, as a cherry-pick or
+rebase can leave a logged range pending again. Overlaps are listed but do
+not fail the check; remove the duplicate with 'timbers rm <id>'.
+
+--reindex first discards the local caches under .git/timbers/ — the search
+index, the entry checksums, and, with [storage] index enabled, the entry
+index — and rebuilds them from the ledger files. Both are refreshed automatically as the ledger
+changes; reindex after upgrading timbers or if search results look stale.
+
+Examples:
+  timbers fsck
+  timbers fsck --verify
+  timbers fsck --reindex --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runFsck(cmd, storage, flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.reindex, "reindex", false, "Rebuild the search index, entry index, and checksums from the ledger files")
+	cmd.Flags().BoolVar(&flags.verify, "verify", false, "Report entries changed outside timbers since it last wrote them")
+
+	return cmd
+}
+
+// runFsck executes the fsck command.
+func runFsck(cmd *cobra.Command, storage *ledger.Storage, flags fsckFlags) error {
+	printer := newPrinter(cmd)
+
+	storage, err := initQueryStorage(storage, printer)
+	if err != nil {
+		return err
+	}
+	// Archived entries are out of the default reads, not out of the ledger.
+	storage.SetIncludeArchived(true)
+
+	entries, stats, reindexed, err := scanLedger(storage, flags.reindex)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	live := ledger.FilterDeleted(entries, storage.DeletedSet())
+	problems, skipped, err := checkLedgerIntegrity(storage, entries, live)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	var recorded []string
+	if flags.verify {
+		if problems, recorded, err = verifyChecksums(storage, problems); err != nil {
+			printer.Error(err)
+			return err
+		}
+	}
+	result := fsckResult{
+		Files: stats.Total, Entries: stats.Parsed, NotTimbers: stats.NotTimbers,
+		Corrupt:   stats.CorruptFiles,
+		Problems:  problems,
+		Skipped:   skipped,
+		Overlaps:  ledger.FindOverlaps(live),
+		Reindexed: reindexed,
+		Recorded:  recorded,
+	}
+	if err := outputFsckResult(printer, result); err != nil {
+		return err
+	}
+	if err := corruptEntriesError(stats); err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return output.NewUserError(strconv.Itoa(len(problems)) + " ledger problem(s) found; see the fsck output")
+	}
+	return nil
+}
+
+// scanLedger reads every ledger file. With reindex it first discards the
+// local indexes and rebuilds them from what it read, returning the names of
+// those rebuilt.
+func scanLedger(storage *ledger.Storage, reindex bool) ([]*ledger.Entry, *ledger.ListStats, []string, error) {
+	var reindexed []string
+	if reindex {
+		enabled, err := storage.ResetIndex()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if enabled {
+			reindexed = append(reindexed, "entries")
+		}
+		if enabled, err = storage.ResetChecksums(); err != nil {
+			return nil, nil, nil, err
+		}
+		if enabled {
+			reindexed = append(reindexed, "checksum")
+		}
+	}
+
+	entries, stats, err := storage.ListEntriesWithStats()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if reindex {
+		rebuilt, rebuildErr := rebuildSearchIndex(storage, entries)
+		if rebuildErr != nil {
+			return nil, nil, nil, rebuildErr
+		}
+		if rebuilt {
+			reindexed = append(reindexed, "search")
+		}
+	}
+	return entries, stats, reindexed, nil
+}
+
+// outputFsckResult prints the scan counts, malformed files, and rebuilt
+// indexes.
+func outputFsckResult(printer *output.Printer, result fsckResult) error {
+	if printer.IsJSON() {
+		if result.Corrupt == nil {
+			result.Corrupt = []string{}
+		}
+		if result.Problems == nil {
+			result.Problems = []ledger.Problem{}
+		}
+		if result.Overlaps == nil {
+			result.Overlaps = []ledger.Overlap{}
+		}
+		return printer.WriteJSON(result)
+	}
+
+	printer.Print("Checked %d files: %d entries, %d not timbers, %d malformed, %d problems, %d overlapping\n",
+		result.Files, result.Entries, result.NotTimbers, len(result.Corrupt), len(result.Problems), len(result.Overlaps))
+	printFsckFindings(printer, result)
+	for _, index := range result.Reindexed {
+		printer.Print("Rebuilt %s index\n", index)
+	}
+	if len(result.Recorded) > 0 {
+		printer.Print("Recorded checksums for %d entries not seen before\n", len(result.Recorded))
+	}
+	return nil
+}
+
+// printFsckFindings lists the malformed files, problems, skipped checks, and
+// overlaps fsck found.
+func printFsckFindings(printer *output.Printer, result fsckResult) {
+	for _, path := range result.Corrupt {
+		printer.Print("  malformed: %s\n", path)
+	}
+	for _, problem := range result.Problems {
+		printer.Print("  %s: %s: %s\n", problem.Kind, problem.Path, problem.Message)
+	}
+	for _, check := range result.Skipped {
+		printer.Print("  skipped %s check: shallow clone\n", check)
+	}
+	for _, overlap := range result.Overlaps {
+		shas := make([]string, len(overlap.Commits))
+		for i, sha := range overlap.Commits {
+			shas[i] = shortSHA(sha)
+		}
+		printer.Print("  overlap: %s share %d commit(s): %s\n",
+			strings.Join(overlap.IDs, ", "), len(shas), strings.Join(shas, ", "))
+	}
+}
@@ -0,0 +1,81 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"cmp"
+	"path/filepath"
+	"slices"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// existingCommitsFunc returns which of the given SHAs are commits in the
+// repository. Overridable in tests to avoid requiring a real git repo.
+var existingCommitsFunc = git.ExistingCommits
+
+// checkLedgerIntegrity checks every entry file, then the relations and
+// anchors of live, the entries not deleted. Relations may name deleted
+// entries, whose files are kept. The anchor check is skipped in a shallow
+// clone, where older anchors lie beyond the fetch depth; skipped names the
+// checks not run. Problems are sorted by path.
+func checkLedgerIntegrity(
+	storage *ledger.Storage, entries, live []*ledger.Entry,
+) (problems []ledger.Problem, skipped []string, err error) {
+	problems, err = storage.CheckFiles()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	known := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		known[entry.ID] = true
+	}
+	pathOf := func(id string) string {
+		path, _ := storage.EntryPath(id)
+		return filepath.ToSlash(path)
+	}
+	problems = append(problems, ledger.CheckRelations(live, known, pathOf)...)
+
+	if storage.IsShallow() {
+		skipped = append(skipped, ledger.ProblemAnchor)
+	} else {
+		var anchors []string
+		seen := make(map[string]bool, len(live))
+		for _, entry := range live {
+			if anchor := entry.Workset.AnchorCommit; anchor != "" && !seen[anchor] {
+				seen[anchor] = true
+				anchors = append(anchors, anchor)
+			}
+		}
+		existing, lookupErr := existingCommitsFunc(anchors)
+		if lookupErr != nil {
+			return nil, nil, lookupErr
+		}
+		problems = append(problems, ledger.CheckAnchors(live, existing, pathOf)...)
+	}
+
+	slices.SortStableFunc(problems, func(left, right ledger.Problem) int {
+		return cmp.Compare(left.Path, right.Path)
+	})
+	return problems, skipped, nil
+}
+
+// verifyChecksums adds to problems the entries changed outside timbers, and
+// returns the entries whose checksums were recorded for the first time.
+// Problems stay sorted by path.
+func verifyChecksums(storage *ledger.Storage, problems []ledger.Problem) ([]ledger.Problem, []string, error) {
+	report, err := storage.VerifyChecksums()
+	if err != nil {
+		return nil, nil, err
+	}
+	if report == nil {
+		return nil, nil, output.NewUserError("--verify needs a git repository to keep checksums in")
+	}
+	problems = append(problems, report.Problems...)
+	slices.SortStableFunc(problems, func(left, right ledger.Problem) int {
+		return cmp.Compare(left.Path, right.Path)
+	})
+	return problems, report.Recorded, nil
+}
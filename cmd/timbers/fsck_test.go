@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// runFsckTest runs fsck over file-backed entries whose search index is
+// cached at indexPath. Every anchor is taken to exist.
+func runFsckTest(t *testing.T, dir, indexPath string, args ...string) (string, error) {
+	t.Helper()
+	files := ledger.NewFileStorage(dir, func(_ string) error { return nil }, func(_, _ string) error { return nil })
+	files.SetSearchIndex(indexPath)
+	return runFsckFiles(t, files, args...)
+}
+
+// runFsckFiles runs fsck over files. Every anchor is taken to exist.
+func runFsckFiles(t *testing.T, files *ledger.FileStorage, args ...string) (string, error) {
+	t.Helper()
+	origExistingCommits := existingCommitsFunc
+	t.Cleanup(func() { existingCommitsFunc = origExistingCommits })
+	existingCommitsFunc = func(shas []string) ([]string, error) { return shas, nil }
+	cmd := newFsckCmdInternal(ledger.NewStorage(&mockGitOpsForQuery{}, files))
+	cmd.PersistentFlags().Bool("json", false, "")
+	var buf strings.Builder
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestFsckReindexRebuildsSearchIndex(t *testing.T) {
+	dir := t.TempDir()
+	entries := searchTestEntries()
+	for _, entry := range entries {
+		writeQueryEntryFile(t, dir, entry)
+	}
+	indexPath := filepath.Join(t.TempDir(), "timbers", "search.db")
+
+	out, err := runFsckTest(t, dir, indexPath, "--reindex", "--json")
+	if err != nil {
+		t.Fatalf("fsck errored: %v\noutput: %s", err, out)
+	}
+	var result fsckResult
+	if err = json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.Entries != 3 || len(result.Corrupt) != 0 || !reflect.DeepEqual(result.Reindexed, []string{"search"}) {
+		t.Errorf("fsck result = %+v, want 3 entries and a rebuilt search index", result)
+	}
+
+	index, err := ledger.LoadSearchIndex(indexPath)
+	if err != nil || index.Refresh(entries) != 0 {
+		t.Errorf("saved search index is not current with the ledger (err %v)", err)
+	}
+}
+
+func TestFsckReportsMalformedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeQueryEntryFile(t, dir, searchTestEntries()[0])
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runFsckTest(t, dir, "")
+	if err == nil || !strings.Contains(err.Error(), "1 malformed entry file") {
+		t.Errorf("fsck error = %v, want the malformed file reported", err)
+	}
+	if !strings.Contains(out, "malformed: ") || !strings.Contains(out, "broken.json") {
+		t.Errorf("fsck output does not list the malformed file:\n%s", out)
+	}
+}
+
+func TestFsckReportsOverlappingEntries(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	original := createQueryTestEntryStruct("abc1234", "original", now)
+	original.Workset.Commits = []string{"abc1234", "def5678"}
+	relogged := createQueryTestEntryStruct("def5678", "relogged", now.Add(time.Hour))
+	relogged.Workset.Commits = []string{"def5678"}
+	unrelated := createQueryTestEntryStruct("fff9999", "unrelated", now.Add(2*time.Hour))
+	for _, entry := range []*ledger.Entry{original, relogged, unrelated} {
+		writeQueryEntryFile(t, dir, entry)
+	}
+
+	out, err := runFsckTest(t, dir, "", "--json")
+	if err != nil {
+		t.Fatalf("fsck errored: %v\noutput: %s", err, out)
+	}
+	var result fsckResult
+	if err = json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	want := []ledger.Overlap{{IDs: []string{original.ID, relogged.ID}, Commits: []string{"def5678"}}}
+	if !reflect.DeepEqual(result.Overlaps, want) {
+		t.Errorf("overlaps = %+v, want %+v", result.Overlaps, want)
+	}
+
+	out, err = runFsckTest(t, dir, "")
+	if err != nil || !strings.Contains(out, "overlap: "+original.ID+", "+relogged.ID+" share 1 commit(s): def5678") {
+		t.Errorf("fsck output does not list the overlap (err %v):\n%s", err, out)
+	}
+}
+
+func TestFsckReportsLedgerProblems(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	healthy := createQueryTestEntryStruct("abc1234", "healthy", now)
+	dangling := createQueryTestEntryStruct("bcd2345", "dangling relation", now.Add(time.Hour))
+	dangling.Relations = []ledger.Relation{{Type: ledger.RelationFixes, ID: "tb_2026-01-01T00:00:00Z_gone00"}}
+	for _, entry := range []*ledger.Entry{healthy, dangling} {
+		writeQueryEntryFile(t, dir, entry)
+	}
+	// Filed under a day its ID does not carry.
+	misfiled := createQueryTestEntryStruct("cde3456", "misfiled", now.Add(3*time.Hour))
+	data, err := misfiled.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeRawFsckFile(t, filepath.Join(dir, "2025", "12", "31", misfiled.ID+".json"), string(data))
+	// A field the schema does not know.
+	extra := strings.Replace(string(data), `"kind"`, `"colour": "red", "kind"`, 1)
+	extra = strings.ReplaceAll(extra, misfiled.ID, "tb_2026-01-15T14:00:00Z_cde345")
+	writeRawFsckFile(t, filepath.Join(dir, "2026", "01", "15", "tb_2026-01-15T14:00:00Z_cde345.json"), extra)
+	// A malformed ID.
+	writeRawFsckFile(t, filepath.Join(dir, "tb_bogus.json"), strings.ReplaceAll(string(data), misfiled.ID, "tb_bogus"))
+
+	origExistingCommits := existingCommitsFunc
+	t.Cleanup(func() { existingCommitsFunc = origExistingCommits })
+	// The misfiled entry cannot be read by its ID, so it also fails the
+	// check as malformed.
+	out, err := runFsckTest(t, dir, "", "--json")
+	if err == nil {
+		t.Error("fsck succeeded with ledger problems")
+	}
+	var result fsckResult
+	if err = json.NewDecoder(strings.NewReader(out)).Decode(&result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	got := make(map[string]string)
+	for _, problem := range result.Problems {
+		got[problem.Kind] = problem.ID
+	}
+	want := map[string]string{
+		ledger.ProblemRelation: dangling.ID,
+		ledger.ProblemLocation: misfiled.ID,
+		ledger.ProblemSchema:   "tb_2026-01-15T14:00:00Z_cde345",
+		ledger.ProblemID:       "tb_bogus",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("problems = %+v, want one of each kind in %v", result.Problems, want)
+	}
+}
+
+func TestFsckReportsMissingAnchors(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	kept := createQueryTestEntryStruct("abc1234", "kept", now)
+	orphan := createQueryTestEntryStruct("dead999", "rebased away", now.Add(time.Hour))
+	for _, entry := range []*ledger.Entry{kept, orphan} {
+		writeQueryEntryFile(t, dir, entry)
+	}
+
+	files := ledger.NewFileStorage(dir, func(_ string) error { return nil }, func(_, _ string) error { return nil })
+	cmd := newFsckCmdInternal(ledger.NewStorage(&mockGitOpsForQuery{}, files))
+	origExistingCommits := existingCommitsFunc
+	t.Cleanup(func() { existingCommitsFunc = origExistingCommits })
+	existingCommitsFunc = func(_ []string) ([]string, error) { return []string{"abc1234" + strings.Repeat("0", 33)}, nil }
+	var buf strings.Builder
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(nil)
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("fsck succeeded with a missing anchor")
+	}
+	if want := "anchor: " + filepath.ToSlash(filepath.Join(dir, ledger.EntryDateDir(orphan.ID), orphan.ID+".json")) +
+		": anchor commit dead999 is not in the repository"; !strings.Contains(buf.String(), want) {
+		t.Errorf("fsck output missing %q:\n%s", want, buf.String())
+	}
+	if strings.Contains(buf.String(), kept.ID) {
+		t.Errorf("fsck reported the entry whose abbreviated anchor exists:\n%s", buf.String())
+	}
+}
+
+func TestFsckVerifyReportsEntriesChangedOutsideTimbers(t *testing.T) {
+	dir := t.TempDir()
+	files := ledger.NewFileStorage(dir, func(_ string) error { return nil }, func(_, _ string) error { return nil })
+	files.SetChecksums(filepath.Join(t.TempDir(), "timbers", "checksums.json"))
+	entries := searchTestEntries()
+	for _, entry := range entries[:2] {
+		if err := files.WriteEntry(entry, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeQueryEntryFile(t, dir, entries[2])
+	pulled := filepath.Join(dir, ledger.EntryDateDir(entries[2].ID), entries[2].ID+".json")
+
+	out, err := runFsckFiles(t, files, "--verify", "--json")
+	if err != nil {
+		t.Fatalf("fsck --verify errored on an untouched ledger: %v\noutput: %s", err, out)
+	}
+	var result fsckResult
+	if err = json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(result.Problems) != 0 || !reflect.DeepEqual(result.Recorded, []string{entries[2].ID}) {
+		t.Errorf("fsck result = %+v, want no problems and %s recorded", result, entries[2].ID)
+	}
+
+	data, err := os.ReadFile(pulled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeRawFsckFile(t, pulled, strings.Replace(string(data), entries[2].Summary.Why, "edited by hand", 1))
+	out, err = runFsckFiles(t, files, "--verify")
+	if err == nil || !strings.Contains(out, "checksum: ") || !strings.Contains(out, filepath.Base(pulled)) {
+		t.Errorf("fsck --verify did not report the hand-edited entry (err %v):\n%s", err, out)
+	}
+
+	if out, err = runFsckFiles(t, files, "--reindex", "--verify"); err != nil {
+		t.Errorf("fsck --verify errored after --reindex accepted the edit: %v\noutput: %s", err, out)
+	}
+}
+
+// writeRawFsckFile writes content to path, creating its directory.
+func writeRawFsckFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,12 +1,10 @@
 package main
 
 import (
-	"context"
 	"io"
 	"os"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 
@@ -22,7 +20,6 @@ type generateFlags struct {
 	input       string
 	temperature float64
 	maxTokens   int
-	timeout     int
 }
 
 // newGenerateCmd creates the generate command.
@@ -78,7 +75,6 @@ Environment variables:
 	cmd.Flags().StringVarP(&flags.input, "input", "i", "", "Input file (default: stdin if no prompt argument)")
 	cmd.Flags().Float64Var(&flags.temperature, "temperature", 0, "Temperature (0.0-1.0, 0 uses model default)")
 	cmd.Flags().IntVar(&flags.maxTokens, "max-tokens", 0, "Max tokens to generate (0 uses model default)")
-	cmd.Flags().IntVar(&flags.timeout, "timeout", 120, "Request timeout in seconds")
 
 	return cmd
 }
@@ -88,9 +84,6 @@ func validateGenerateFlags(flags generateFlags) error {
 	if flags.temperature < 0 || flags.temperature > 2 {
 		return output.NewUserError("temperature must be between 0 and 2, got " + formatFloat(flags.temperature))
 	}
-	if flags.timeout <= 0 {
-		return output.NewUserError("timeout must be positive, got " + formatInt(flags.timeout))
-	}
 	if flags.maxTokens < 0 {
 		return output.NewUserError("max-tokens must be non-negative, got " + formatInt(flags.maxTokens))
 	}
@@ -109,7 +102,7 @@ func formatInt(i int) string {
 
 // runGenerate executes the generate command.
 func runGenerate(cmd *cobra.Command, args []string, flags generateFlags) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 
 	// Validate flags before any other work
 	if err := validateGenerateFlags(flags); err != nil {
@@ -146,11 +139,11 @@ func runGenerate(cmd *cobra.Command, args []string, flags generateFlags) error {
 		MaxTokens:   flags.maxTokens,
 	}
 
-	// Execute with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(flags.timeout)*time.Second)
+	// Execute, bounded by --timeout (or the default LLM request cap)
+	ctx, cancel := llmContext()
 	defer cancel()
 
-	resp, err := client.Complete(ctx, req)
+	resp, err := completeWithProgress(ctx, printer, client, req)
 	if err != nil {
 		sysErr := output.NewSystemErrorWithCause("generation failed", err)
 		printer.Error(sysErr)
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// Environment overrides for the project's [git] settings.
+const (
+	gitBackendEnv = "TIMBERS_GIT_BACKEND"
+	gitTimeoutEnv = "TIMBERS_GIT_TIMEOUT"
+)
+
+// applyGitConfig installs the git backend and the per-process git timeout,
+// each from its environment variable, else from [git] in the project config.
+// An invalid value in the environment is an error; one in the config falls
+// back to the default (exec, no timeout), and 'timbers doctor' reports it,
+// so a typo cannot lock out every command.
+func applyGitConfig() error {
+	cfg := configuredGit()
+	backend, err := gitBackendSetting(cfg.Backend)
+	if err != nil {
+		return err
+	}
+	timeout, err := gitTimeoutSetting(cfg.Timeout)
+	if err != nil {
+		return err
+	}
+	git.SetBackend(backend)
+	git.SetCommandTimeout(timeout)
+	return nil
+}
+
+// gitBackendSetting resolves TIMBERS_GIT_BACKEND, else configured.
+func gitBackendSetting(configured string) (git.Backend, error) {
+	if name := os.Getenv(gitBackendEnv); name != "" {
+		backend, err := git.NewBackend(name)
+		if err != nil {
+			return nil, output.NewUserError(gitBackendEnv + ": " + err.Error())
+		}
+		return backend, nil
+	}
+	if backend, err := git.NewBackend(configured); err == nil {
+		return backend, nil
+	}
+	return git.NewBackend(git.BackendExec)
+}
+
+// gitTimeoutSetting resolves TIMBERS_GIT_TIMEOUT, else configured.
+func gitTimeoutSetting(configured string) (time.Duration, error) {
+	if value := os.Getenv(gitTimeoutEnv); value != "" {
+		timeout, err := parseGitTimeout(value)
+		if err != nil {
+			return 0, output.NewUserError(gitTimeoutEnv + ": " + err.Error())
+		}
+		return timeout, nil
+	}
+	if timeout, err := parseGitTimeout(configured); err == nil {
+		return timeout, nil
+	}
+	return 0, nil
+}
+
+// parseGitTimeout parses a git timeout with the --timeout grammar: a Go
+// duration or a bare number of seconds. Empty and zero mean no limit.
+func parseGitTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	var timeout timeoutValue
+	if err := timeout.Set(value); err != nil {
+		return 0, err
+	}
+	return time.Duration(timeout), nil
+}
+
+// configuredGit returns [git] from the project config, or the zero value
+// outside a repository. The repository is found by looking for .git rather
+// than by asking git, so the settings also work where git is not installed.
+func configuredGit() config.GitConfig {
+	root := findWorktreeRoot()
+	if root == "" {
+		return config.GitConfig{}
+	}
+	cfg, err := config.LoadProject(root)
+	if err != nil {
+		return config.GitConfig{}
+	}
+	return cfg.Git
+}
+
+// findWorktreeRoot returns the nearest directory at or above the working
+// directory that contains .git (a directory, or a file in a linked
+// worktree), or "" when there is none.
+func findWorktreeRoot() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+func TestApplyGitBackend(t *testing.T) {
+	t.Cleanup(func() { git.SetBackend(nil) })
+	dir := newLogAnchorRepo(t)
+	t.Chdir(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, ".timbers"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeConfig := func(body string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, ".timbers", "config.toml"), []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeConfig("[git]\nbackend = \"go-git\"\n")
+	if err := applyGitConfig(); err != nil || git.CurrentBackend().Name() != git.BackendGoGit {
+		t.Errorf("config go-git: backend %q, err %v", git.CurrentBackend().Name(), err)
+	}
+
+	writeConfig("[git]\nbackend = \"libgit2\"\n")
+	if err := applyGitConfig(); err != nil || git.CurrentBackend().Name() != git.BackendExec {
+		t.Errorf("unknown config backend: backend %q, err %v; want exec fallback", git.CurrentBackend().Name(), err)
+	}
+	if check := checkGitBackend(); check.Status != checkWarn {
+		t.Errorf("doctor check = %+v, want a warning", check)
+	}
+
+	t.Setenv(gitBackendEnv, "go-git")
+	if err := applyGitConfig(); err != nil || git.CurrentBackend().Name() != git.BackendGoGit {
+		t.Errorf("env go-git: backend %q, err %v", git.CurrentBackend().Name(), err)
+	}
+	t.Setenv(gitBackendEnv, "bogus")
+	if err := applyGitConfig(); err == nil || !strings.Contains(err.Error(), gitBackendEnv) {
+		t.Errorf("env bogus: err %v, want an error naming %s", err, gitBackendEnv)
+	}
+}
+
+// TestGoGitBackendRunsCommands logs and queries through the go-git backend.
+func TestGoGitBackendRunsCommands(t *testing.T) {
+	t.Cleanup(func() { git.SetBackend(nil) })
+	t.Setenv(gitBackendEnv, git.BackendGoGit)
+	dir := newLogAnchorRepo(t)
+
+	if out, err := runLogCmd(t, dir, "Backend work", "--why", "Exercise go-git", "--how", "Log and query"); err != nil {
+		t.Fatalf("log failed: %v\n%s", err, out)
+	}
+	var out strings.Builder
+	runInDir(t, dir, func() {
+		cmd := newRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"pending", "--json"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("pending failed: %v", err)
+		}
+	})
+	if !strings.Contains(out.String(), `"count": 0`) {
+		t.Errorf("pending after log = %s, want no pending commits", out.String())
+	}
+}
+
+func TestApplyGitTimeout(t *testing.T) {
+	t.Cleanup(func() { git.SetCommandTimeout(0) })
+	dir := newLogAnchorRepo(t)
+	t.Chdir(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, ".timbers"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeConfig := func(body string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, ".timbers", "config.toml"), []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeConfig("[git]\ntimeout = \"45s\"\n")
+	if err := applyGitConfig(); err != nil || git.CommandTimeout() != 45*time.Second {
+		t.Errorf("config 45s: timeout %v, err %v", git.CommandTimeout(), err)
+	}
+
+	writeConfig("[git]\ntimeout = \"soon\"\n")
+	if err := applyGitConfig(); err != nil || git.CommandTimeout() != 0 {
+		t.Errorf("invalid config timeout: timeout %v, err %v; want no limit", git.CommandTimeout(), err)
+	}
+	if check := checkGitTimeout(); check.Status != checkWarn {
+		t.Errorf("doctor check = %+v, want a warning", check)
+	}
+
+	t.Setenv(gitTimeoutEnv, "90")
+	if err := applyGitConfig(); err != nil || git.CommandTimeout() != 90*time.Second {
+		t.Errorf("env 90: timeout %v, err %v; want bare seconds", git.CommandTimeout(), err)
+	}
+	t.Setenv(gitTimeoutEnv, "-1s")
+	if err := applyGitConfig(); err == nil || !strings.Contains(err.Error(), gitTimeoutEnv) {
+		t.Errorf("env -1s: err %v, want an error naming %s", err, gitTimeoutEnv)
+	}
+}
@@ -0,0 +1,236 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// hookEvent is one entry in the `timbers hook run` dispatch table.
+type hookEvent struct {
+	// summary is the one-line description shown in `hook run --help`.
+	summary string
+	// run executes the event. args are the git hook's own arguments.
+	run func(cmd *cobra.Command, args []string) error
+}
+
+// hookEvents maps each event name to its handler. Adding an event is one
+// entry here; dispatch, help text, and [hooks] disabled all follow from it.
+var hookEvents = map[string]hookEvent{
+	"pre-commit": {
+		summary: "block the commit while undocumented commits exist",
+		run:     func(cmd *cobra.Command, _ []string) error { return runPreCommitHook(cmd) },
+	},
+	"post-commit": {
+		summary: "remind to document the new commit",
+		run:     func(cmd *cobra.Command, _ []string) error { return runPostCommitHook(cmd) },
+	},
+	"post-merge": {
+		summary: "remind to document commits left pending after a merge or pull",
+		run:     func(cmd *cobra.Command, _ []string) error { return runPostCommitHook(cmd) },
+	},
+	"post-rewrite": {
+		summary: "relink entries from commits a rebase or amend rewrote to their replacements",
+		run:     runPostRewriteHookEvent,
+	},
+	"prepare-commit-msg": {
+		summary: "add a commented note about undocumented commits to the editor",
+		run:     runPrepareCommitMsgHook,
+	},
+	"commit-msg": {
+		summary: "reject malformed Work-item trailers",
+		run:     runCommitMsgHook,
+	},
+	"claude-stop": {
+		summary: "Claude Code Stop hook: block session end while work is pending",
+		run:     func(cmd *cobra.Command, _ []string) error { return runClaudeStop(cmd) },
+	},
+}
+
+// hookEventHelp renders the event table for help text, sorted by name.
+func hookEventHelp() string {
+	names := make([]string, 0, len(hookEvents))
+	for name := range hookEvents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %-19s %s\n", name, hookEvents[name].summary)
+	}
+	return b.String()
+}
+
+// hookEventDisabled reports whether the project config disables event.
+// Config errors count as enabled — hooks must never break git operations.
+func hookEventDisabled(event string) bool {
+	root, err := git.RepoRoot()
+	if err != nil {
+		return false
+	}
+	cfg, err := config.LoadProject(root)
+	if err != nil {
+		return false
+	}
+	return cfg.HookDisabled(event)
+}
+
+// runPostRewriteHookEvent reads the "<old-sha> <new-sha>" pairs git passes on
+// stdin and relinks ledger entries (and acks) from the rewritten commits to
+// their replacements. Doing this in Go keeps the installed hook a one-line
+// shim, so it works the same under Git for Windows as under POSIX sh. The
+// relinked files are left uncommitted — committing mid-rebase/pull would
+// inject a commit into a flow the user controls — so the hook warns loudly:
+// left as-is, entries keep pointing at orphaned SHAs once the rebase is
+// pushed. Non-blocking; errors are swallowed.
+func runPostRewriteHookEvent(cmd *cobra.Command, _ []string) error {
+	rewrites := make(map[string]string)
+	scanner := bufio.NewScanner(cmd.InOrStdin())
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) >= 2 {
+			rewrites[fields[0]] = fields[1]
+		}
+	}
+	if len(rewrites) == 0 {
+		return nil
+	}
+	storage := postRewriteStorage()
+	if storage == nil {
+		return nil
+	}
+
+	printer := output.NewPrinter(cmd.ErrOrStderr(), false, useColor(cmd))
+	relinked, err := storage.RelinkCommits(rewrites)
+	if err != nil {
+		warnStaleRewrites(printer, storage, rewrites)
+		return nil //nolint:nilerr // hooks must never block a rebase
+	}
+	if len(relinked) > 0 {
+		printer.Print("timbers: relinked %d ledger file(s) to rewritten commit SHAs after rebase.\n", len(relinked))
+		printer.Print("timbers: these are UNCOMMITTED — commit them so the ledger does not point at\n")
+		printer.Print("timbers: orphaned SHAs (git add .timbers && git commit).\n")
+	}
+	return nil
+}
+
+// postRewriteStorage opens the ledger of the current repository, or returns
+// nil when there is no repository or no .timbers directory to relink.
+func postRewriteStorage() *ledger.Storage {
+	if !git.IsRepo() {
+		return nil
+	}
+	root, err := git.RepoRoot()
+	if err != nil {
+		return nil
+	}
+	if info, statErr := os.Stat(filepath.Join(root, ".timbers")); statErr != nil || !info.IsDir() {
+		return nil
+	}
+	storage, err := ledger.NewDefaultStorage()
+	if err != nil {
+		return nil
+	}
+	return storage
+}
+
+// warnStaleRewrites is the fallback when relinking fails: it counts the
+// entries that still reference a rewritten commit and says how to recover.
+func warnStaleRewrites(printer *output.Printer, storage *ledger.Storage, rewrites map[string]string) {
+	entries, err := storage.ListEntries()
+	if err != nil {
+		return
+	}
+	rewritten := make(map[string]bool, len(rewrites))
+	for old := range rewrites {
+		rewritten[old] = true
+	}
+	stale := 0
+	for _, entry := range entries {
+		if entryReferencesAny(entry, rewritten) {
+			stale++
+		}
+	}
+	if stale > 0 {
+		printer.Print("[timbers] %s still reference rewritten commits and could not be relinked; "+
+			"re-document with 'timbers log'\n", formatEntryCount(stale))
+	}
+}
+
+// entryReferencesAny reports whether entry's anchor or workset commits
+// include any SHA in shas.
+func entryReferencesAny(entry *ledger.Entry, shas map[string]bool) bool {
+	if shas[entry.Workset.AnchorCommit] {
+		return true
+	}
+	for _, sha := range entry.Workset.Commits {
+		if shas[sha] {
+			return true
+		}
+	}
+	return false
+}
+
+// runPrepareCommitMsgHook appends a commented reminder to the commit message
+// file when earlier commits are undocumented. Only editor sessions get the
+// note: git strips comment lines there, but keeps them for -m/-F messages,
+// merges, squashes, and amends, so those sources are left untouched.
+func runPrepareCommitMsgHook(_ *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if len(args) > 1 && args[1] != "" && args[1] != "template" {
+		return nil
+	}
+	if !hasActionablePending() {
+		return nil
+	}
+	file, err := os.OpenFile(args[0], os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil //nolint:nilerr // the reminder is optional; never block the commit
+	}
+	defer file.Close() //nolint:errcheck
+	_, _ = file.WriteString("\n# [timbers] Earlier commit(s) are undocumented. " +
+		"Record them with: timbers log \"what\" --why \"why\" --how \"how\"\n")
+	return nil
+}
+
+// workItemKeyRegex matches any line that looks like a Work-item trailer key,
+// well-formed or not.
+var workItemKeyRegex = regexp.MustCompile(`(?i)^work-item\s*:`)
+
+// runCommitMsgHook rejects commit messages whose Work-item trailers do not
+// parse as system:id. A malformed trailer silently falls out of
+// `log --batch` work-item grouping, so catching it at commit time is cheaper.
+func runCommitMsgHook(_ *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return nil
+	}
+	for line := range strings.SplitSeq(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") || !workItemKeyRegex.MatchString(line) {
+			continue
+		}
+		if !workItemTrailerRegex.MatchString(line) {
+			return output.NewUserError(fmt.Sprintf(
+				"timbers: malformed trailer %q; use 'Work-item: system:id' (e.g. Work-item: jira:PROJ-123)", line))
+		}
+	}
+	return nil
+}
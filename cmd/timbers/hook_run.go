@@ -48,29 +48,28 @@ func newHookCmd() *cobra.Command {
 // newHookRunCmd creates the hook run subcommand.
 func newHookRunCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "run <hook-name>",
+		Use:   "run <hook-name> [git-hook-args...]",
 		Short: "Execute hook logic",
-		Long:  `Execute the logic for the specified hook. Called by installed git hooks.`,
-		Args:  cobra.ExactArgs(1),
-		RunE:  runHookRun,
+		Long: `Execute the logic for the specified hook. Called by installed git hooks,
+which pass their own arguments through after the event name.
+
+Events:
+` + hookEventHelp() + `
+Unknown events, and events listed under [hooks] disabled in
+.timbers/config.toml, succeed silently.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runHookRun,
 	}
 }
 
-// runHookRun executes the hook run command.
+// runHookRun dispatches to the registered handler for the hook event.
 func runHookRun(cmd *cobra.Command, args []string) error {
-	hookName := args[0]
-
-	switch hookName {
-	case "pre-commit":
-		return runPreCommitHook(cmd)
-	case "post-commit":
-		return runPostCommitHook(cmd)
-	case "claude-stop":
-		return runClaudeStop(cmd)
-	default:
-		// Unknown hook - silently succeed to not block operations
+	event, ok := hookEvents[args[0]]
+	if !ok || hookEventDisabled(args[0]) {
+		// Unknown or disabled hook - silently succeed to not block operations
 		return nil
 	}
+	return event.run(cmd, args[1:])
 }
 
 // hasActionablePending reports whether pre/post-commit hooks should take
@@ -337,3 +337,94 @@ func TestPreCommitHookGating_SiblingMerge(t *testing.T) {
 		t.Errorf("pre-commit blocked despite first-parent scope; output: %s", out)
 	}
 }
+
+func TestHookEventsRegistry(t *testing.T) {
+	for _, name := range []string{
+		"pre-commit", "post-commit", "post-merge", "post-rewrite",
+		"prepare-commit-msg", "commit-msg", "claude-stop",
+	} {
+		event, ok := hookEvents[name]
+		if !ok {
+			t.Errorf("hook event %q not registered", name)
+			continue
+		}
+		if event.run == nil || event.summary == "" {
+			t.Errorf("hook event %q missing run or summary", name)
+		}
+		if !strings.Contains(hookEventHelp(), name) {
+			t.Errorf("help text missing %q", name)
+		}
+	}
+}
+
+func TestHookEventDisabledByConfig(t *testing.T) {
+	repo := newHookRepo(t)
+	repo.commitFile(t, "internal/feature.go", "package internal\n", "feat: new code")
+
+	cfg := "[hooks]\ndisabled = [\"post-commit\"]\n"
+	if err := os.WriteFile(filepath.Join(repo.dir, ".timbers", "config.toml"), []byte(cfg), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	out, err := repo.runHook(t, "post-commit")
+	if err != nil {
+		t.Fatalf("post-commit hook errored: %v", err)
+	}
+	if strings.Contains(out, postCommitReminder) {
+		t.Errorf("disabled post-commit still printed reminder:\n%s", out)
+	}
+
+	if _, err := repo.runHook(t, "pre-commit"); err == nil {
+		t.Error("pre-commit should still block when only post-commit is disabled")
+	}
+}
+
+func TestCommitMsgHookTrailers(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		wantErr bool
+	}{
+		{"no trailer", "feat: thing\n", false},
+		{"valid trailer", "feat: thing\n\nWork-item: jira:PROJ-1\n", false},
+		{"malformed trailer", "feat: thing\n\nWork-item: PROJ-1\n", true},
+		{"commented trailer ignored", "feat: thing\n# Work-item: PROJ-1\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+			if err := os.WriteFile(path, []byte(tt.message), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			err := runCommitMsgHook(nil, []string{path})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runCommitMsgHook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrepareCommitMsgHookSkipsMessageSource(t *testing.T) {
+	repo := newHookRepo(t)
+	repo.commitFile(t, "internal/feature.go", "package internal\n", "feat: new code")
+
+	path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	if err := os.WriteFile(path, []byte("msg\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	runInDir(t, repo.dir, func() {
+		_ = runPrepareCommitMsgHook(nil, []string{path, "message"})
+		data, _ := os.ReadFile(path)
+		if string(data) != "msg\n" {
+			t.Errorf("-m message was modified: %q", string(data))
+		}
+
+		_ = runPrepareCommitMsgHook(nil, []string{path})
+		data, _ = os.ReadFile(path)
+		if !strings.Contains(string(data), "# [timbers]") {
+			t.Errorf("editor message missing note: %q", string(data))
+		}
+	})
+}
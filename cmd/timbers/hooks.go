@@ -62,10 +62,10 @@ func newHooksListCmd() *cobra.Command {
 
 // runHooksList executes the hooks list command.
 func runHooksList(cmd *cobra.Command, _ []string) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 
 	if !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return err
 	}
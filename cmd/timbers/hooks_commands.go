@@ -36,7 +36,11 @@ sections appended to existing hook files (or creates new files).
 The pre-commit hook blocks commits when undocumented commits exist,
 requiring 'timbers log' before continuing. Use --no-verify to bypass.
 
-Use --force to install even when core.hooksPath points to an unknown location.
+In a Jujutsu (jj) colocated repository, install is skipped: jj never runs
+git hooks, so they would only fire for the occasional plain git command.
+
+Use --force to install even when core.hooksPath points to an unknown location,
+or in a jj colocated repository.
 Use --skip to exit 0 on any conflict (for automation).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runHooksInstall(cmd, force, skip, dryRun)
@@ -44,7 +48,7 @@ Use --skip to exit 0 on any conflict (for automation).`,
 	}
 
 	cmd.Flags().BoolVar(&chain, "chain", false, "Deprecated: append is now the default behavior")
-	cmd.Flags().BoolVar(&force, "force", false, "Install even in unknown hook environments (Tier 4)")
+	cmd.Flags().BoolVar(&force, "force", false, "Install even in unknown hook environments (Tier 4) or jj repositories")
 	cmd.Flags().BoolVar(&skip, "skip", false, "Exit 0 on conflict (for automation)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without doing it")
 
@@ -56,10 +60,10 @@ Use --skip to exit 0 on any conflict (for automation).`,
 
 // runHooksInstall executes the hooks install command.
 func runHooksInstall(cmd *cobra.Command, force, skip, dryRun bool) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 
 	if !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return err
 	}
@@ -79,6 +83,10 @@ func runHooksInstall(cmd *cobra.Command, force, skip, dryRun bool) error {
 
 // performInstall does the actual hook installation using tier-based logic.
 func performInstall(printer *output.Printer, env setup.HookEnvInfo, force, skip bool) error {
+	if env.JJ && !force {
+		return outputInstallSkippedJJ(printer, env)
+	}
+
 	// Tier 4: unknown override — error unless --force or --skip.
 	if env.Tier == setup.HookEnvUnknownOverride && !force {
 		if skip {
@@ -182,6 +190,25 @@ func outputInstallSuccess(
 	return printer.Success(map[string]any{"message": "Hooks installed"})
 }
 
+// jjHooksSkipReason explains why timbers does not install git hooks in a jj
+// colocated repository.
+const jjHooksSkipReason = "jj colocated repository: jj never runs git hooks;" +
+	" use agent steering or `timbers pending` instead (--force installs anyway)"
+
+// outputInstallSkippedJJ outputs the message when install is skipped in a jj
+// colocated repository.
+func outputInstallSkippedJJ(printer *output.Printer, env setup.HookEnvInfo) error {
+	if printer.IsJSON() {
+		return printer.Success(map[string]any{
+			"status":    "skipped",
+			"tier":      tierString(env.Tier),
+			"hooks_dir": env.HooksDir,
+			"reason":    jjHooksSkipReason,
+		})
+	}
+	return printer.Success(map[string]any{"message": "Skipped: " + jjHooksSkipReason})
+}
+
 // outputInstallSkipped outputs the message when install is skipped via --skip.
 func outputInstallSkipped(printer *output.Printer, env setup.HookEnvInfo) error {
 	if printer.IsJSON() {
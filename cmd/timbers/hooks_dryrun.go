@@ -13,16 +13,16 @@ func handleInstallDryRun(
 ) error {
 	hookTypes := []string{"pre-commit", "post-commit", "post-rewrite"}
 	actions := make(map[string]string)
+	plan := make([]plannedAction, 0, len(hookTypes))
 
 	for _, hookType := range hookTypes {
-		actions[hookType] = describeInstallDryRunAction(
-			env, hookType, force,
-		)
+		planned := planInstallHook(env, hookType, force)
+		actions[hookType] = planned.Detail
+		plan = append(plan, planned)
 	}
 
 	if printer.IsJSON() {
-		return printer.Success(map[string]any{
-			"status":       "dry_run",
+		return printer.Success(withPlan(map[string]any{
 			"tier":         tierString(env.Tier),
 			"tier_desc":    tierDescription(env.Tier, env.Owner),
 			"hooks_dir":    env.HooksDir,
@@ -30,7 +30,7 @@ func handleInstallDryRun(
 			"pre_commit":   actions["pre-commit"],
 			"post_commit":  actions["post-commit"],
 			"post_rewrite": actions["post-rewrite"],
-		})
+		}, plan))
 	}
 
 	printer.Section("Dry Run")
@@ -47,24 +47,30 @@ func handleInstallDryRun(
 	return nil
 }
 
-// describeInstallDryRunAction returns the action description for a dry-run.
-func describeInstallDryRunAction(
+// planInstallHook returns the planned action for one hook in a dry-run; its
+// Detail is the description shown per hook.
+func planInstallHook(
 	env setup.HookEnvInfo, hookType string, force bool,
-) string {
+) plannedAction {
 	hookPath := filepath.Join(env.HooksDir, hookType)
+	plan := func(action, detail string) plannedAction {
+		return plannedAction{Action: action, Target: hookPath, Detail: detail}
+	}
 
 	switch {
 	case setup.HasTimbersSection(hookPath):
-		return "already installed (no-op)"
+		return plan(planSkip, "already installed (no-op)")
+	case env.JJ && !force:
+		return plan(planSkip, "would skip (jj colocated repository; use --force)")
 	case env.Tier == setup.HookEnvUnknownOverride && !force:
-		return "would skip (unknown hook environment; use --force)"
+		return plan(planSkip, "would skip (unknown hook environment; use --force)")
 	case setup.HookExists(hookPath):
 		appendable, reason := setup.IsAppendable(hookPath)
 		if !appendable {
-			return "would skip (hook is a " + reason + ")"
+			return plan(planSkip, "would skip (hook is a "+reason+")")
 		}
-		return "would append timbers section"
+		return plan(planModify, "would append timbers section")
 	default:
-		return "would create with timbers section"
+		return plan(planCreate, "would create with timbers section")
 	}
 }
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHooksInstallSkipsJJColocatedRepo(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	if err := os.MkdirAll(filepath.Join(dir, ".jj", "repo"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	runInDir(t, dir, func() {
+		var buf bytes.Buffer
+		cmd := newTestRootCmdWithHooks()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"hooks", "install", "--json"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("hooks install failed: %v\noutput: %s", err, buf.String())
+		}
+
+		var result map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("invalid JSON: %v\noutput: %s", err, buf.String())
+		}
+		reason, _ := result["reason"].(string)
+		if result["status"] != "skipped" || !strings.Contains(reason, "jj") {
+			t.Errorf("result = %v, want skipped for jj", result)
+		}
+	})
+	if _, err := os.Stat(filepath.Join(dir, ".git", "hooks", "pre-commit")); !os.IsNotExist(err) {
+		t.Errorf("pre-commit hook was written in a jj repository (stat err %v)", err)
+	}
+	if res := checkGitHooksJJ(filepath.Join(dir, ".git", "hooks", "pre-commit")); res.Status != checkPass {
+		t.Errorf("doctor status = %s, want pass when hooks are skipped", res.Status)
+	}
+}
@@ -60,10 +60,10 @@ debugging hook integration issues.`,
 
 // runHooksStatus executes the hooks status command.
 func runHooksStatus(cmd *cobra.Command, _ []string) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 
 	if !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return err
 	}
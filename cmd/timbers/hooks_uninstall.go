@@ -36,12 +36,10 @@ Legacy .backup files from old chain installs are restored if present.`,
 
 // runHooksUninstall executes the hooks uninstall command.
 func runHooksUninstall(cmd *cobra.Command, dryRun bool) error {
-	printer := output.NewPrinter(
-		cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd),
-	)
+	printer := newPrinter(cmd)
 
 	if !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return err
 	}
@@ -69,9 +67,9 @@ func performUninstall(printer *output.Printer, hooksDir string) error {
 
 	for _, hookType := range allHookTypes {
 		hookPath := filepath.Join(hooksDir, hookType)
-		if setup.HasTimbersSection(hookPath) {
+		if setup.HasTimbersHook(hookPath, hookType) {
 			anyFound = true
-			if err := setup.RemoveTimbersSection(hookPath); err != nil {
+			if err := setup.RemoveTimbersHook(hookPath, hookType); err != nil {
 				sysErr := output.NewSystemErrorWithCause(
 					"failed to remove "+hookType+" section", err,
 				)
@@ -151,13 +149,16 @@ func outputUninstallSuccess(
 // handleUninstallDryRun handles dry-run output for uninstall.
 func handleUninstallDryRun(printer *output.Printer, hooksDir string) error {
 	actions := make(map[string]string)
+	plan := make([]plannedAction, 0, len(allHookTypes)+1)
 
 	for _, hookType := range allHookTypes {
 		hookPath := filepath.Join(hooksDir, hookType)
-		if setup.HasTimbersSection(hookPath) {
+		if setup.HasTimbersHook(hookPath, hookType) {
 			actions[hookType] = "would remove timbers section"
+			plan = append(plan, plannedAction{Action: planModify, Target: hookPath, Detail: actions[hookType]})
 		} else {
 			actions[hookType] = "not installed (no-op)"
+			plan = append(plan, plannedAction{Action: planSkip, Target: hookPath, Detail: actions[hookType]})
 		}
 	}
 
@@ -167,17 +168,19 @@ func handleUninstallDryRun(printer *output.Printer, hooksDir string) error {
 	if _, err := os.Stat(backupPath); err == nil {
 		hasBackup = true
 		actions["pre-commit"] += " and restore backup"
+		plan = append(plan, plannedAction{
+			Action: planReplace, Target: filepath.Join(hooksDir, "pre-commit"), Detail: "restore pre-commit.backup",
+		})
 	}
 
 	if printer.IsJSON() {
-		return printer.Success(map[string]any{
-			"status":       "dry_run",
+		return printer.Success(withPlan(map[string]any{
 			"hooks_dir":    hooksDir,
 			"pre_commit":   actions["pre-commit"],
 			"post_commit":  actions["post-commit"],
 			"post_rewrite": actions["post-rewrite"],
 			"has_backup":   hasBackup,
-		})
+		}, plan))
 	}
 
 	printer.Section("Dry Run")
@@ -2,9 +2,7 @@
 package main
 
 import (
-	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
@@ -22,6 +20,9 @@ type initFlags struct {
 	noAgent    bool
 	dryRun     bool
 	force      bool
+	withConfig bool
+	monorepo   []string
+	ci         string
 }
 
 // initStepResult tracks the result of a single initialization step.
@@ -39,6 +40,7 @@ type initState struct {
 	postRewriteInstalled  bool
 	postCommitInstalled   bool
 	agentEnvInstalled     bool // true if any agent env integration is present
+	configExists          bool // true if .timbers/config.toml is present
 }
 
 // initStyleSet holds lipgloss styles for init output.
@@ -51,18 +53,16 @@ type initStyleSet struct {
 	accent  lipgloss.Style
 }
 
-// initStyles returns a TTY-aware style set.
-func initStyles(isTTY bool) initStyleSet {
-	if !isTTY {
-		return initStyleSet{}
-	}
+// initStyles returns the init style set in the printer's palette (plain
+// when the printer has no color).
+func initStyles(styles *output.Styles) initStyleSet {
 	return initStyleSet{
-		heading: lipgloss.NewStyle().Bold(true),
-		pass:    lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "10", Dark: "10"}),
-		skip:    lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "8", Dark: "7"}),
-		fail:    lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "9", Dark: "9"}),
-		dim:     lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "8", Dark: "7"}),
-		accent:  lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "12", Dark: "12"}),
+		heading: styles.Bold,
+		pass:    styles.Success,
+		skip:    styles.Dim,
+		fail:    styles.Error.UnsetBold(),
+		dim:     styles.Dim,
+		accent:  styles.Title.UnsetBold(),
 	}
 }
 
@@ -77,6 +77,9 @@ func newInitCmd() *cobra.Command {
 
 This command sets up everything needed to use timbers:
   - Creates the .timbers/ directory for entry storage
+  - Writes a commented .timbers/config.toml with default settings
+  - Scopes monorepo packages in the config (optional, --monorepo)
+  - Writes a CI workflow that gates pull requests (optional, --ci)
   - Adds .gitattributes entry to collapse timbers files in diffs
   - Configures .gitattributes for diff collapsing
   - Installs Git hooks (optional, includes post-rewrite for rebase safety)
@@ -93,6 +96,9 @@ Examples:
   timbers init --no-git-hooks # Skip git hooks info messages
   timbers init --no-agent     # Skip agent environment integration
   timbers init --dry-run      # Show what would be done
+  timbers init --with-config  # Add config.toml to an initialized repo
+  timbers init --monorepo 'packages/*'  # Scope the ledger per package
+  timbers init --ci github    # Add a pull request workflow
   timbers init --force        # Force full re-initialization`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runInit(cmd, flags)
@@ -105,6 +111,9 @@ Examples:
 	cmd.Flags().BoolVar(&flags.noAgent, "no-agent", false, "Skip agent environment integration")
 	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Show what would be done without doing it")
 	cmd.Flags().BoolVar(&flags.force, "force", false, "Force full re-initialization, ignoring current state")
+	cmd.Flags().BoolVar(&flags.withConfig, "with-config", false, "Write .timbers/config.toml even if already initialized")
+	cmd.Flags().StringArrayVar(&flags.monorepo, "monorepo", nil, "Glob of package directories to scope in config (repeatable)")
+	cmd.Flags().StringVar(&flags.ci, "ci", "", "Write a pull request workflow: github or gitlab")
 
 	// Hidden aliases for backward compatibility.
 	cmd.Flags().BoolVar(&flags.gitHooks, "hooks", false, "Alias for --git-hooks")
@@ -126,15 +135,22 @@ func runInit(cmd *cobra.Command, flags *initFlags) error {
 		flags.noAgent = true
 	}
 
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
-	styles := initStyles(printer.IsTTY())
+	printer := newPrinter(cmd)
+	styles := initStyles(printer.Styles())
 
 	if !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return err
 	}
 
+	if flags.ci != "" {
+		if _, err := setup.CIWorkflowPath(flags.ci); err != nil {
+			printer.Error(err)
+			return err
+		}
+	}
+
 	repoName := getRepoName()
 	state := gatherInitState()
 
@@ -145,75 +161,15 @@ func runInit(cmd *cobra.Command, flags *initFlags) error {
 	return performInit(cmd, printer, styles, repoName, state, flags)
 }
 
-// gatherInitState checks the current timbers setup state.
-func gatherInitState() *initState {
-	state := &initState{}
-
-	// Check .timbers/ directory
-	if root, err := git.RepoRoot(); err == nil {
-		timbersDir := filepath.Join(root, ".timbers")
-		info, statErr := os.Stat(timbersDir)
-		state.timbersDirExists = statErr == nil && info.IsDir()
-
-		state.gitattributesHasEntry = checkGitattributesEntry(root)
-	}
-
-	if hooksDir, err := setup.GetHooksDir(); err == nil {
-		preCommitPath := filepath.Join(hooksDir, "pre-commit")
-		state.hooksInstalled = setup.HasTimbersSection(preCommitPath) || setup.CheckHookStatus(preCommitPath).Installed
-
-		postRewritePath := filepath.Join(hooksDir, "post-rewrite")
-		state.postRewriteInstalled = setup.HasTimbersSection(postRewritePath) || checkPostRewriteHook(postRewritePath)
-
-		postCommitPath := filepath.Join(hooksDir, "post-commit")
-		state.postCommitInstalled = setup.HasTimbersSection(postCommitPath) || setup.CheckPostCommitHookStatus(postCommitPath).Installed
-	}
-
-	state.agentEnvInstalled = len(setup.DetectedAgentEnvs()) > 0
-
-	return state
-}
-
-// checkGitattributesEntry checks if .gitattributes contains the timbers linguist-generated line.
-func checkGitattributesEntry(repoRoot string) bool {
-	path := filepath.Join(repoRoot, ".gitattributes")
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return false
-	}
-	return containsTimbersGitattribute(string(data))
-}
-
-// containsTimbersGitattribute returns true if the content contains the timbers linguist-generated line.
-func containsTimbersGitattribute(content string) bool {
-	for line := range strings.SplitSeq(content, "\n") {
-		line = strings.TrimRight(line, "\r")
-		if line == "/.timbers/** linguist-generated" {
-			return true
-		}
-	}
-	return false
-}
-
-// checkPostRewriteHook checks if a post-rewrite hook contains timbers SHA remapping.
-func checkPostRewriteHook(hookPath string) bool {
-	data, err := os.ReadFile(hookPath)
-	if err != nil {
-		return false
-	}
-	return strings.Contains(string(data), ".timbers")
-}
-
 // handleInitDryRun outputs what would be done without making changes.
 func handleInitDryRun(printer *output.Printer, styles initStyleSet, repoName string, state *initState, flags *initFlags) error {
 	steps := buildDryRunSteps(state, flags)
 
 	if printer.IsJSON() {
-		return printer.Success(map[string]any{
-			"status":    "dry_run",
+		return printer.Success(withPlan(map[string]any{
 			"repo_name": repoName,
 			"steps":     steps,
-		})
+		}, planInitSteps(steps)))
 	}
 
 	outputDryRunHumanInit(printer, styles, repoName, steps)
@@ -283,12 +239,21 @@ func handleAlreadyInitialized(printer *output.Printer, styles initStyleSet, repo
 
 // isAlreadyInitialized checks if timbers is fully initialized.
 func isAlreadyInitialized(state *initState, flags *initFlags) bool {
+	if len(flags.monorepo) > 0 || flags.ci != "" {
+		return false
+	}
 	return state.timbersDirExists &&
 		state.gitattributesHasEntry &&
-		(!flags.gitHooks || (state.hooksInstalled && state.postRewriteInstalled && state.postCommitInstalled)) &&
+		(!flags.withConfig || state.configExists) &&
+		(!flags.gitHooks || allHooksInstalled(state)) &&
 		(flags.noAgent || state.agentEnvInstalled)
 }
 
+// allHooksInstalled reports whether every timbers git hook is in place.
+func allHooksInstalled(state *initState) bool {
+	return state.hooksInstalled && state.postRewriteInstalled && state.postCommitInstalled
+}
+
 // outputAlreadyInitialized handles the already-initialized case.
 func outputAlreadyInitialized(printer *output.Printer, styles initStyleSet, repoName string) error {
 	if printer.IsJSON() {
@@ -310,12 +275,14 @@ func outputInitResult(printer *output.Printer, styles initStyleSet, repoName str
 	hooksInstalled := stepSucceeded(steps, "hooks")
 	agentInstalled := stepSucceeded(steps, "agent_env")
 	timbersDirCreated := stepSucceeded(steps, "timbers_dir")
+	configCreated := stepSucceeded(steps, "config")
 
 	if printer.IsJSON() {
 		return printer.Success(map[string]any{
 			"status":              "ok",
 			"repo_name":           repoName,
 			"timbers_dir_created": timbersDirCreated,
+			"config_created":      configCreated,
 			"hooks_installed":     hooksInstalled,
 			"claude_installed":    agentInstalled, // backward compat key
 			"already_initialized": false,
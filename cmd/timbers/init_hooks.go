@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/gorewood/timbers/internal/git"
 	"github.com/gorewood/timbers/internal/output"
 	"github.com/gorewood/timbers/internal/setup"
 )
@@ -98,19 +99,23 @@ func appendPreCommitSection(
 	return initStepResult{Name: "hooks", Status: "ok", Message: msg}
 }
 
-// informHookOpportunity emits info messages about hook availability.
+// informHookOpportunity emits info messages about hook availability
+// (collected into the JSON warnings in JSON mode).
 // Called when --git-hooks is not specified.
-func informHookOpportunity(env setup.HookEnvInfo, printer *output.Printer) {
+func informHookOpportunity(env setup.HookEnvInfo, printer output.Reporter) {
+	if env.JJ {
+		return
+	}
 	switch env.Tier {
 	case setup.HookEnvUncontested:
-		printer.Stderr("%s\n",
+		printer.Info("%s",
 			"Git hooks available for commit-time enforcement."+
 				" Run `timbers init --git-hooks` to install.")
 	case setup.HookEnvExistingHook:
 		if env.HasTimbers {
 			return
 		}
-		printer.Stderr("%s %s\n",
+		printer.Info("%s %s",
 			"Pre-commit hook exists."+
 				" Claude Code steering provides session-end enforcement.",
 			"Run `timbers hooks install` to also add commit-time checks.")
@@ -118,18 +123,27 @@ func informHookOpportunity(env setup.HookEnvInfo, printer *output.Printer) {
 		if env.HasTimbers {
 			return
 		}
-		printer.Stderr("Git hooks managed by %s. %s\n", env.Owner,
+		printer.Info("Git hooks managed by %s. %s", env.Owner,
 			"Claude Code steering provides session-end enforcement."+
 				" Run `timbers hooks install` to integrate.")
 	case setup.HookEnvUnknownOverride:
-		printer.Stderr(
+		printer.Info(
 			"core.hooksPath is set to %s."+
 				" Timbers defers to your configuration."+
-				" Run `timbers doctor` for details.\n",
+				" Run `timbers doctor` for details.",
 			env.HooksDir)
 	}
 }
 
+// jjHooksSkipped is the result of a hook step in a jj colocated repository,
+// where jj never runs git hooks.
+func jjHooksSkipped(name string) initStepResult {
+	return initStepResult{
+		Name: name, Status: "skipped",
+		Message: "jj colocated repository: jj never runs git hooks (`timbers hooks install --force` to install anyway)",
+	}
+}
+
 // executePostRewriteStep runs the post-rewrite hook installation step.
 func executePostRewriteStep(state *initState, flags *initFlags) initStepResult {
 	if flags.noGitHooks {
@@ -138,6 +152,9 @@ func executePostRewriteStep(state *initState, flags *initFlags) initStepResult {
 	if !flags.gitHooks {
 		return initStepResult{Name: "post_rewrite", Status: "skipped", Message: "not requested (use --git-hooks)"}
 	}
+	if git.IsJJColocated() {
+		return jjHooksSkipped("post_rewrite")
+	}
 	return performPostRewriteInstall(state)
 }
 
@@ -190,6 +207,9 @@ func executePostCommitStep(state *initState, flags *initFlags) initStepResult {
 	if !flags.gitHooks {
 		return initStepResult{Name: "post_commit", Status: "skipped", Message: "not requested (use --git-hooks)"}
 	}
+	if git.IsJJColocated() {
+		return jjHooksSkipped("post_commit")
+	}
 	return performPostCommitInstall(state)
 }
 
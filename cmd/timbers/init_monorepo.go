@@ -0,0 +1,112 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+)
+
+// matchMonorepoPackages expands --monorepo glob patterns against the repo
+// root and returns one package scope per matched directory. Files and hidden
+// directories are ignored; results are sorted by path and de-duplicated so
+// overlapping patterns are harmless.
+func matchMonorepoPackages(root string, patterns []string) ([]config.PackageScope, error) {
+	seen := make(map[string]bool)
+	var pkgs []config.PackageScope
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+		if err != nil {
+			return nil, fmt.Errorf("matching package pattern %q: %w", pattern, err)
+		}
+		for _, match := range matches {
+			info, statErr := os.Stat(match)
+			if statErr != nil || !info.IsDir() || strings.HasPrefix(filepath.Base(match), ".") {
+				continue
+			}
+			rel, relErr := filepath.Rel(root, match)
+			if relErr != nil {
+				continue
+			}
+			path := filepath.ToSlash(rel) + "/"
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			pkgs = append(pkgs, config.PackageScope{Name: filepath.Base(match), Path: path})
+		}
+	}
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].Path < pkgs[j].Path })
+	return pkgs, nil
+}
+
+// resolveMonorepoPackages expands --monorepo for the current repository.
+// Returns a ready-made step result and false when there is nothing to scope.
+func resolveMonorepoPackages(flags *initFlags) (string, []config.PackageScope, initStepResult, bool) {
+	if len(flags.monorepo) == 0 {
+		return "", nil, initStepResult{Name: "monorepo", Status: "skipped", Message: "not requested (use --monorepo)"}, false
+	}
+	root, err := git.RepoRoot()
+	if err != nil {
+		return "", nil, initStepResult{Name: "monorepo", Status: "failed", Message: err.Error()}, false
+	}
+	pkgs, err := matchMonorepoPackages(root, flags.monorepo)
+	if err != nil {
+		return "", nil, initStepResult{Name: "monorepo", Status: "failed", Message: "invalid pattern: " + err.Error()}, false
+	}
+	if len(pkgs) == 0 {
+		msg := "no directories match " + strings.Join(flags.monorepo, ", ")
+		return "", nil, initStepResult{Name: "monorepo", Status: "failed", Message: msg}, false
+	}
+	return root, pkgs, initStepResult{}, true
+}
+
+// buildMonorepoStep creates the dry-run step for --monorepo package scoping.
+func buildMonorepoStep(flags *initFlags) initStepResult {
+	_, pkgs, step, ok := resolveMonorepoPackages(flags)
+	if !ok {
+		return step
+	}
+	return initStepResult{
+		Name: "monorepo", Status: "dry_run",
+		Message: "would scope " + describePackages(pkgs) + " in " + config.ProjectFile,
+	}
+}
+
+// performMonorepoInit appends a scope table to .timbers/config.toml for each
+// package directory matched by --monorepo. Already-configured paths are left
+// alone, so re-running with the same pattern is a no-op.
+func performMonorepoInit(flags *initFlags) initStepResult {
+	root, pkgs, step, ok := resolveMonorepoPackages(flags)
+	if !ok {
+		return step
+	}
+
+	added, err := config.AppendPackageScopes(root, pkgs)
+	if err != nil {
+		return initStepResult{Name: "monorepo", Status: "failed", Message: err.Error()}
+	}
+	if len(added) == 0 {
+		return initStepResult{Name: "monorepo", Status: "skipped", Message: "all packages already scoped"}
+	}
+	return initStepResult{Name: "monorepo", Status: "ok", Message: "scoped " + describePackages(added)}
+}
+
+// describePackages renders a short human summary like "3 packages (api, web, cli)".
+func describePackages(pkgs []config.PackageScope) string {
+	names := make([]string, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		names = append(names, pkg.Name)
+	}
+	noun := "packages"
+	if len(pkgs) == 1 {
+		noun = "package"
+	}
+	return strconv.Itoa(len(pkgs)) + " " + noun + " (" + strings.Join(names, ", ") + ")"
+}
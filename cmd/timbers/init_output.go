@@ -69,22 +69,23 @@ func styledStepIcon(styles initStyleSet, status string) string {
 
 // formatStepName converts internal step names to display names.
 func formatStepName(name string) string {
-	switch name {
-	case "timbers_dir":
-		return ".timbers directory"
-	case "gitattributes":
-		return ".gitattributes"
-	case "hooks":
-		return "Git hooks"
-	case "post_rewrite":
-		return "Post-rewrite hook"
-	case "post_commit":
-		return "Post-commit hook"
-	case "agent_env":
-		return "Agent integration"
-	default:
-		return name
+	if display, ok := stepNames[name]; ok {
+		return display
 	}
+	return name
+}
+
+// stepNames maps init step names to their display names.
+var stepNames = map[string]string{
+	"timbers_dir":   ".timbers directory",
+	"config":        "Config file",
+	"monorepo":      "Monorepo packages",
+	"ci":            "CI workflow",
+	"gitattributes": ".gitattributes",
+	"hooks":         "Git hooks",
+	"post_rewrite":  "Post-rewrite hook",
+	"post_commit":   "Post-commit hook",
+	"agent_env":     "Agent integration",
 }
 
 // generatePostRewriteHook returns the full post-rewrite hook script.
@@ -92,39 +93,17 @@ func generatePostRewriteHook() string {
 	return "#!/bin/sh\n" + postRewriteTimbersSection()
 }
 
-// postRewriteTimbersSection returns the timbers SHA remapping section for the post-rewrite hook.
+// postRewriteTimbersSection returns the timbers section for the post-rewrite
+// hook. The SHA relinking itself lives in `timbers hook run post-rewrite`:
+// earlier versions did it inline with find/sed/mktemp, which broke under Git
+// for Windows and on sed variants without -i. doctor --fix swaps those
+// sections for this one.
 func postRewriteTimbersSection() string {
 	return `# timbers post-rewrite hook
-# Remaps SHAs in .timbers/ entries after rebase, then warns so the relink
-# gets committed. Left uncommitted, the entry keeps pointing at the
-# pre-rebase SHA — orphaned once you push, and the rewritten commit then
-# shows up as pending for anyone who clones. We warn rather than auto-commit:
-# committing mid-rebase/pull would inject a commit into a flow the user
-# controls.
-# Keep this POSIX sh: /bin/sh is dash on Debian/Ubuntu (and CI). No bash-isms
-# like 'read -d' — entry paths are date dirs + tb_<id>.json, so newline-
-# delimited 'find | read' is safe.
-_timbers_relinked="$(mktemp)"
-while IFS=' ' read -r old_sha new_sha _extra; do
-  old_short="${old_sha%"${old_sha#???????}"}"
-  new_short="${new_sha%"${new_sha#???????}"}"
-  find .timbers -name '*.json' | while IFS= read -r f; do
-    if grep -q "$old_sha\|$old_short" "$f"; then
-      sed -i.bak \
-        -e "s/$old_sha/$new_sha/g" \
-        -e "s/$old_short/$new_short/g" \
-        "$f"
-      rm -f "$f.bak"
-      printf '%s\n' "$f" >> "$_timbers_relinked"
-    fi
-  done # per-file scan
-done # rewrite pairs
-if [ -s "$_timbers_relinked" ]; then
-  _timbers_n="$(sort -u "$_timbers_relinked" | wc -l | tr -d ' ')"
-  echo "timbers: relinked $_timbers_n ledger file(s) to rewritten commit SHAs after rebase." >&2
-  echo "timbers: these are UNCOMMITTED — commit them so the ledger does not point at" >&2
-  echo "timbers: orphaned SHAs (git add .timbers && git commit)." >&2
+# Relinks .timbers/ entries to the rewritten commit SHAs after a rebase or
+# amend, and warns so the relink gets committed (see timbers hook run --help).
+if command -v timbers >/dev/null 2>&1; then
+  timbers hook run post-rewrite "$@"
 fi
-rm -f "$_timbers_relinked"
 `
 }
@@ -0,0 +1,74 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/setup"
+)
+
+// gatherInitState checks the current timbers setup state.
+func gatherInitState() *initState {
+	state := &initState{}
+
+	// Check .timbers/ directory
+	if root, err := git.RepoRoot(); err == nil {
+		timbersDir := filepath.Join(root, ".timbers")
+		info, statErr := os.Stat(timbersDir)
+		state.timbersDirExists = statErr == nil && info.IsDir()
+
+		state.gitattributesHasEntry = checkGitattributesEntry(root)
+
+		_, statErr = os.Stat(config.ProjectPath(root))
+		state.configExists = statErr == nil
+	}
+
+	if hooksDir, err := setup.GetHooksDir(); err == nil {
+		preCommitPath := filepath.Join(hooksDir, "pre-commit")
+		state.hooksInstalled = setup.HasTimbersSection(preCommitPath) || setup.CheckHookStatus(preCommitPath).Installed
+
+		postRewritePath := filepath.Join(hooksDir, "post-rewrite")
+		state.postRewriteInstalled = setup.HasTimbersSection(postRewritePath) || checkPostRewriteHook(postRewritePath)
+
+		postCommitPath := filepath.Join(hooksDir, "post-commit")
+		state.postCommitInstalled = setup.HasTimbersSection(postCommitPath) || setup.CheckPostCommitHookStatus(postCommitPath).Installed
+	}
+
+	state.agentEnvInstalled = len(setup.DetectedAgentEnvs()) > 0
+
+	return state
+}
+
+// checkGitattributesEntry checks if .gitattributes contains the timbers linguist-generated line.
+func checkGitattributesEntry(repoRoot string) bool {
+	path := filepath.Join(repoRoot, ".gitattributes")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return containsTimbersGitattribute(string(data))
+}
+
+// containsTimbersGitattribute returns true if the content contains the timbers linguist-generated line.
+func containsTimbersGitattribute(content string) bool {
+	for line := range strings.SplitSeq(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == setup.GitattributesLine {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPostRewriteHook checks if a post-rewrite hook contains timbers SHA remapping.
+func checkPostRewriteHook(hookPath string) bool {
+	data, err := os.ReadFile(hookPath)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), ".timbers")
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/gorewood/timbers/internal/config"
 	"github.com/gorewood/timbers/internal/git"
 	"github.com/gorewood/timbers/internal/output"
 	"github.com/gorewood/timbers/internal/setup"
@@ -16,8 +17,11 @@ import (
 
 // buildDryRunSteps constructs the list of dry-run step results.
 func buildDryRunSteps(state *initState, flags *initFlags) []initStepResult {
-	steps := make([]initStepResult, 0, 6)
+	steps := make([]initStepResult, 0, 9)
 	steps = append(steps, buildTimbersDirStep(state))
+	steps = append(steps, buildConfigStep(state))
+	steps = append(steps, buildMonorepoStep(flags))
+	steps = append(steps, buildCIStep(flags))
 	steps = append(steps, buildGitattributesStep(state))
 	steps = append(steps, buildHooksStep(state, flags))
 	steps = append(steps, buildPostRewriteStep(state, flags))
@@ -34,6 +38,32 @@ func buildTimbersDirStep(state *initState) initStepResult {
 	return initStepResult{Name: "timbers_dir", Status: "dry_run", Message: "would create .timbers/ directory"}
 }
 
+// buildConfigStep creates the dry-run step for .timbers/config.toml.
+func buildConfigStep(state *initState) initStepResult {
+	if state.configExists {
+		return initStepResult{Name: "config", Status: "skipped", Message: "already exists"}
+	}
+	return initStepResult{Name: "config", Status: "dry_run", Message: "would write " + config.ProjectFile}
+}
+
+// buildCIStep creates the dry-run step for the --ci workflow.
+func buildCIStep(flags *initFlags) initStepResult {
+	if flags.ci == "" {
+		return initStepResult{Name: "ci", Status: "skipped", Message: "not requested (use --ci github|gitlab)"}
+	}
+	path, err := setup.CIWorkflowPath(flags.ci)
+	if err != nil {
+		return initStepResult{Name: "ci", Status: "failed", Message: err.Error()}
+	}
+	root, err := git.RepoRoot()
+	if err == nil {
+		if _, statErr := os.Stat(filepath.Join(root, filepath.FromSlash(path))); statErr == nil {
+			return initStepResult{Name: "ci", Status: "skipped", Message: path + " already exists"}
+		}
+	}
+	return initStepResult{Name: "ci", Status: "dry_run", Message: "would write " + path}
+}
+
 // buildGitattributesStep creates the dry-run step for .gitattributes.
 func buildGitattributesStep(state *initState) initStepResult {
 	if state.gitattributesHasEntry {
@@ -103,10 +133,13 @@ func executeInitSteps(
 	cmd *cobra.Command, printer *output.Printer, styles initStyleSet,
 	state *initState, flags *initFlags,
 ) []initStepResult {
-	steps := make([]initStepResult, 0, 6)
+	steps := make([]initStepResult, 0, 9)
 
 	for _, stepFn := range []func() initStepResult{
 		func() initStepResult { return performTimbersDirInit(state) },
+		func() initStepResult { return performConfigInit(state) },
+		func() initStepResult { return performMonorepoInit(flags) },
+		func() initStepResult { return performCIInit(flags) },
 		func() initStepResult { return performGitattributesInit(state) },
 		func() initStepResult { return executeHooksStep(state, flags, printer) },
 		func() initStepResult { return executePostRewriteStep(state, flags) },
@@ -134,6 +167,9 @@ func executeHooksStep(state *initState, flags *initFlags, printer *output.Printe
 		return initStepResult{Name: "hooks", Status: "skipped", Message: "could not classify hook environment: " + err.Error()}
 	}
 
+	if flags.gitHooks && env.JJ {
+		return jjHooksSkipped("hooks")
+	}
 	if flags.gitHooks {
 		return performHooksInstallWithTier(env, state)
 	}
@@ -174,6 +210,51 @@ func performTimbersDirInit(state *initState) initStepResult {
 	return initStepResult{Name: "timbers_dir", Status: "ok", Message: "created .timbers/"}
 }
 
+// performConfigInit writes the commented .timbers/config.toml if it doesn't exist.
+// An existing config is never overwritten, even with --force: it holds team choices.
+func performConfigInit(state *initState) initStepResult {
+	if state.configExists {
+		return initStepResult{Name: "config", Status: "skipped", Message: "already exists"}
+	}
+
+	root, err := git.RepoRoot()
+	if err != nil {
+		return initStepResult{Name: "config", Status: "failed", Message: err.Error()}
+	}
+
+	written, err := config.WriteProjectTemplate(root)
+	if err != nil {
+		return initStepResult{Name: "config", Status: "failed", Message: err.Error()}
+	}
+	state.configExists = true
+	if !written {
+		return initStepResult{Name: "config", Status: "skipped", Message: "already exists"}
+	}
+	return initStepResult{Name: "config", Status: "ok", Message: "wrote " + config.ProjectFile}
+}
+
+// performCIInit writes the pull request workflow selected by --ci.
+// An existing workflow file is never overwritten.
+func performCIInit(flags *initFlags) initStepResult {
+	if flags.ci == "" {
+		return initStepResult{Name: "ci", Status: "skipped", Message: "not requested (use --ci github|gitlab)"}
+	}
+
+	root, err := git.RepoRoot()
+	if err != nil {
+		return initStepResult{Name: "ci", Status: "failed", Message: err.Error()}
+	}
+
+	path, written, err := setup.InstallCIWorkflow(root, flags.ci)
+	if err != nil {
+		return initStepResult{Name: "ci", Status: "failed", Message: err.Error()}
+	}
+	if !written {
+		return initStepResult{Name: "ci", Status: "skipped", Message: path + " already exists"}
+	}
+	return initStepResult{Name: "ci", Status: "ok", Message: "wrote " + path}
+}
+
 // performGitattributesInit ensures .gitattributes contains the timbers linguist-generated line.
 func performGitattributesInit(state *initState) initStepResult {
 	if state.gitattributesHasEntry {
@@ -186,7 +267,7 @@ func performGitattributesInit(state *initState) initStepResult {
 	}
 
 	path := filepath.Join(root, ".gitattributes")
-	line := "/.timbers/** linguist-generated"
+	line := setup.GitattributesLine
 
 	existing, readErr := os.ReadFile(path)
 	var content string
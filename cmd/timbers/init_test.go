@@ -3,10 +3,8 @@ package main
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -638,24 +636,28 @@ func TestInitPostRewriteHook(t *testing.T) {
 		if !strings.Contains(string(content), ".timbers") {
 			t.Error("post-rewrite hook missing .timbers reference")
 		}
-		if !strings.Contains(string(content), "sed") {
-			t.Error("post-rewrite hook missing sed command for SHA remapping")
+		if !strings.Contains(string(content), "timbers hook run post-rewrite") {
+			t.Error("post-rewrite hook should delegate SHA remapping to timbers hook run")
 		}
 	})
 }
 
-// runPostRewriteHook executes the generated post-rewrite hook in dir with the
-// given "old new" rewrite pairs on stdin (one per line, as git supplies them),
-// returning combined stderr. Skips on platforms without /bin/sh.
+// runPostRewriteHook runs `timbers hook run post-rewrite` — what the
+// generated hook delegates to — in a fresh git repository at dir, with the
+// given "old new" rewrite pairs on stdin (one per line, as git supplies
+// them), returning its stderr.
 func runPostRewriteHook(t *testing.T, dir, stdin string) string {
 	t.Helper()
-	// #nosec G204 -- hook content is a compile-time constant, test-only
-	cmd := exec.CommandContext(context.Background(), "sh", "-c", generatePostRewriteHook())
-	cmd.Dir = dir
-	cmd.Stdin = strings.NewReader(stdin)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
+	runGit(t, dir, "init")
+	t.Chdir(dir)
+
+	var stdout, stderr bytes.Buffer
+	cmd := newRootCmd()
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"hook", "run", "post-rewrite", "rebase"})
+	if err := cmd.Execute(); err != nil {
 		t.Fatalf("post-rewrite hook failed: %v\nstderr: %s", err, stderr.String())
 	}
 	return stderr.String()
@@ -893,13 +895,13 @@ func TestInitDryRunJSONSteps(t *testing.T) {
 			t.Fatalf("steps is not an array: %T", result["steps"])
 		}
 
-		// Should have 6 steps
-		if len(steps) != 6 {
-			t.Errorf("got %d steps, want 6", len(steps))
+		// Should have 9 steps
+		if len(steps) != 9 {
+			t.Errorf("got %d steps, want 9", len(steps))
 		}
 
 		// Check step names
-		expectedSteps := []string{"timbers_dir", "gitattributes", "hooks", "post_rewrite", "post_commit", "agent_env"}
+		expectedSteps := []string{"timbers_dir", "config", "monorepo", "ci", "gitattributes", "hooks", "post_rewrite", "post_commit", "agent_env"}
 		for i, step := range steps {
 			if i >= len(expectedSteps) {
 				break
@@ -911,3 +913,100 @@ func TestInitDryRunJSONSteps(t *testing.T) {
 		}
 	})
 }
+
+func TestInitMonorepoScopesPackages(t *testing.T) {
+	tempDir := t.TempDir()
+
+	runGit(t, tempDir, "init")
+	runGit(t, tempDir, "config", "user.email", "test@test.com")
+	runGit(t, tempDir, "config", "user.name", "Test User")
+
+	for _, dir := range []string{"packages/api", "packages/web", "packages/.cache"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "packages", "README.md"), []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, tempDir, "add", ".")
+	runGit(t, tempDir, "commit", "-m", "Initial commit")
+
+	runInDir(t, tempDir, func() {
+		var buf bytes.Buffer
+		cmd := newTestRootCmdWithInit()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"init", "--yes", "--no-agent", "--monorepo", "packages/*", "--json"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("command failed: %v\nOutput: %s", err, buf.String())
+		}
+
+		data, err := os.ReadFile(filepath.Join(tempDir, ".timbers", "config.toml"))
+		if err != nil {
+			t.Fatalf("config.toml not written: %v", err)
+		}
+		content := string(data)
+		for _, want := range []string{`path = "packages/api/"`, `path = "packages/web/"`} {
+			if !strings.Contains(content, want) {
+				t.Errorf("config missing %q\n%s", want, content)
+			}
+		}
+		if strings.Contains(content, ".cache") || strings.Contains(content, "README") {
+			t.Errorf("config scoped hidden dir or file:\n%s", content)
+		}
+
+		// Re-running is a no-op for already-scoped packages.
+		buf.Reset()
+		cmd = newTestRootCmdWithInit()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"init", "--yes", "--no-agent", "--monorepo", "packages/*", "--json"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("second init failed: %v\nOutput: %s", err, buf.String())
+		}
+		again, _ := os.ReadFile(filepath.Join(tempDir, ".timbers", "config.toml"))
+		if strings.Count(string(again), "[[scope.packages]]\nname") != 2 {
+			t.Errorf("expected exactly 2 package tables after re-run:\n%s", again)
+		}
+	})
+}
+
+func TestInitCIWorkflow(t *testing.T) {
+	tempDir := t.TempDir()
+
+	runGit(t, tempDir, "init")
+	runGit(t, tempDir, "config", "user.email", "test@test.com")
+	runGit(t, tempDir, "config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(tempDir, "test.txt"), []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, tempDir, "add", "test.txt")
+	runGit(t, tempDir, "commit", "-m", "Initial commit")
+
+	runInDir(t, tempDir, func() {
+		var buf bytes.Buffer
+		cmd := newTestRootCmdWithInit()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"init", "--yes", "--no-agent", "--ci", "github", "--json"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("command failed: %v\nOutput: %s", err, buf.String())
+		}
+		if _, err := os.Stat(filepath.Join(tempDir, ".github", "workflows", "timbers.yml")); err != nil {
+			t.Errorf("workflow not written: %v", err)
+		}
+
+		buf.Reset()
+		cmd = newTestRootCmdWithInit()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"init", "--ci", "jenkins", "--json"})
+		if err := cmd.Execute(); err == nil {
+			t.Fatalf("expected error for unknown CI provider\nOutput: %s", buf.String())
+		}
+		if !strings.Contains(buf.String(), "unknown CI provider") {
+			t.Errorf("output missing provider error: %s", buf.String())
+		}
+	})
+}
@@ -0,0 +1,221 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// linkFlags holds the target entry IDs for each relation type.
+type linkFlags struct {
+	supersedes []string
+	relatesTo  []string
+	fixes      []string
+	dryRun     bool
+}
+
+// relations returns the flags as relations, in RelationTypes order.
+func (f linkFlags) relations() []ledger.Relation {
+	rels := make([]ledger.Relation, 0, len(f.supersedes)+len(f.fixes)+len(f.relatesTo))
+	for _, group := range []struct {
+		relType string
+		ids     []string
+	}{
+		{ledger.RelationSupersedes, f.supersedes},
+		{ledger.RelationFixes, f.fixes},
+		{ledger.RelationRelatesTo, f.relatesTo},
+	} {
+		for _, id := range group.ids {
+			rels = append(rels, ledger.Relation{Type: group.relType, ID: id})
+		}
+	}
+	return rels
+}
+
+// newLinkCmd creates the link command.
+func newLinkCmd() *cobra.Command {
+	return newLinkCmdInternal(nil)
+}
+
+// newLinkCmdInternal creates the link command with optional storage
+// injection. If storage is nil, a real storage is created when the command
+// runs.
+func newLinkCmdInternal(storage *ledger.Storage) *cobra.Command {
+	var flags linkFlags
+
+	cmd := &cobra.Command{
+		Use:   "link <entry-id>",
+		Short: "Relate an entry to earlier entries",
+		Long: `Record that an entry supersedes, fixes, or relates to earlier entries.
+
+Relations are stored on the entry given as the argument and shown by
+timbers show and timbers export. Linking is additive: existing relations are
+kept, and adding one twice is a no-op. Every target must be an existing entry.
+
+Examples:
+  timbers link tb_2026-01-20T10:00:00Z_9d3e2b --supersedes tb_2026-01-15T15:04:05Z_8f2c1a
+  timbers link tb_2026-01-20T10:00:00Z_9d3e2b --fixes tb_2026-01-15T15:04:05Z_8f2c1a
+  timbers link tb_2026-01-20T10:00:00Z_9d3e2b --relates-to tb_2026-01-18T09:00:00Z_4a1b7c --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLink(cmd, storage, args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&flags.supersedes, "supersedes", nil, "Entry this one replaces (repeatable)")
+	cmd.Flags().StringArrayVar(&flags.fixes, "fixes", nil, "Entry whose work this one fixes (repeatable)")
+	cmd.Flags().StringArrayVar(&flags.relatesTo, "relates-to", nil, "Related entry (repeatable)")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Preview changes without writing")
+
+	return cmd
+}
+
+// runLink executes the link command.
+func runLink(cmd *cobra.Command, storage *ledger.Storage, entryID string, flags linkFlags) error {
+	printer := newPrinter(cmd)
+
+	rels := flags.relations()
+	if len(rels) == 0 {
+		err := output.NewUserError("at least one relation must be specified (--supersedes, --fixes, or --relates-to)")
+		printer.Error(err)
+		return err
+	}
+
+	storage, err := initAmendStorage(storage, printer)
+	if err != nil {
+		return err
+	}
+
+	entry, err := storage.GetEntryByID(entryID)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	if err := checkLinkTargets(storage, entry.ID, rels); err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	linked, added := linkEntry(entry, rels)
+
+	if flags.dryRun {
+		return outputLinkDryRun(printer, linked, added)
+	}
+	if len(added) > 0 {
+		if err := storage.WriteEntry(linked, true); err != nil {
+			printer.Error(err)
+			return err
+		}
+	}
+	return outputLinkSuccess(printer, linked, added)
+}
+
+// checkLinkTargets verifies every relation points at an existing entry other
+// than the one being linked.
+func checkLinkTargets(storage *ledger.Storage, entryID string, rels []ledger.Relation) error {
+	for _, rel := range rels {
+		if rel.ID == entryID {
+			return output.NewUserError("--" + rel.Type + ": an entry cannot relate to itself")
+		}
+		if _, err := storage.GetEntryByID(rel.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkEntry returns a copy of entry with rels added, and the relations that
+// were not already present.
+func linkEntry(entry *ledger.Entry, rels []ledger.Relation) (*ledger.Entry, []ledger.Relation) {
+	linked := *entry
+	linked.Relations = append([]ledger.Relation(nil), entry.Relations...)
+
+	var added []ledger.Relation
+	for _, rel := range rels {
+		if linked.AddRelation(rel) {
+			added = append(added, rel)
+		}
+	}
+	if len(added) > 0 {
+		linked.UpdatedAt = time.Now().UTC()
+		linked.RecordRevision(entry, resolveAcker(), linked.UpdatedAt)
+	}
+	return &linked, added
+}
+
+// outputLinkDryRun outputs the relations that would be added.
+func outputLinkDryRun(printer *output.Printer, linked *ledger.Entry, added []ledger.Relation) error {
+	if printer.IsJSON() {
+		var plan []plannedAction
+		if len(added) > 0 {
+			plan = []plannedAction{{Action: planModify, Target: linked.ID, Detail: "link " + formatRelations(added)}}
+		}
+		return printer.WriteJSON(withPlan(map[string]any{
+			"dry_run": true,
+			"entry":   linked,
+			"added":   relationsOrEmpty(added),
+		}, plan))
+	}
+
+	printer.Println("Dry run - relations that would be added:")
+	printer.Println()
+	printer.KeyValue("Entry ID", linked.ID)
+	printer.KeyValue("Relations", formatRelationsOrNone(added))
+	return nil
+}
+
+// outputLinkSuccess outputs the result after linking.
+func outputLinkSuccess(printer *output.Printer, linked *ledger.Entry, added []ledger.Relation) error {
+	status := "linked"
+	if len(added) == 0 {
+		status = "unchanged"
+	}
+	if printer.IsJSON() {
+		return printer.Success(map[string]any{
+			"status":    status,
+			"id":        linked.ID,
+			"added":     relationsOrEmpty(added),
+			"relations": relationsOrEmpty(linked.Relations),
+		})
+	}
+
+	if len(added) == 0 {
+		printer.Println("Entry already has these relations")
+	} else {
+		printer.Println("Entry linked successfully")
+	}
+	printer.Println()
+	printer.KeyValue("Entry ID", linked.ID)
+	printer.KeyValue("Relations", formatRelationsOrNone(linked.Relations))
+	return nil
+}
+
+// formatRelations renders relations as "type id, type id".
+func formatRelations(rels []ledger.Relation) string {
+	parts := make([]string, len(rels))
+	for i, rel := range rels {
+		parts[i] = rel.Type + " " + rel.ID
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatRelationsOrNone is formatRelations, or "(none)" for no relations.
+func formatRelationsOrNone(rels []ledger.Relation) string {
+	if len(rels) == 0 {
+		return "(none)"
+	}
+	return formatRelations(rels)
+}
+
+// relationsOrEmpty returns rels, or an empty slice so JSON shows [] not null.
+func relationsOrEmpty(rels []ledger.Relation) []ledger.Relation {
+	if rels == nil {
+		return []ledger.Relation{}
+	}
+	return rels
+}
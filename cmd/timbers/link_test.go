@@ -0,0 +1,148 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// setupLinkTestStorage writes an earlier and a later entry and returns the
+// storage, its directory, and the two IDs.
+func setupLinkTestStorage(t *testing.T) (*ledger.Storage, string, string, string) {
+	t.Helper()
+	earlier := createQueryTestEntryStruct("aaa111bbb222", "Original fix", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	later := createQueryTestEntryStruct("ccc333ddd444", "Corrected fix", time.Date(2026, 1, 20, 10, 0, 0, 0, time.UTC))
+	storage, dir := setupAmendTestStorage(t, newMockGitOpsForAmend(), nil)
+	for _, entry := range []*ledger.Entry{earlier, later} {
+		if err := storage.WriteEntry(entry, false); err != nil {
+			t.Fatalf("WriteEntry: %v", err)
+		}
+	}
+	return storage, dir, earlier.ID, later.ID
+}
+
+// runLinkTest runs the link command against storage and returns its output.
+func runLinkTest(t *testing.T, storage *ledger.Storage, jsonMode bool, args ...string) (string, error) {
+	t.Helper()
+	cmd := newLinkCmdInternal(storage)
+	if jsonMode {
+		cmd.PersistentFlags().Bool("json", false, "")
+		_ = cmd.PersistentFlags().Set("json", "true")
+	}
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestLinkAddsRelations(t *testing.T) {
+	storage, dir, earlier, later := setupLinkTestStorage(t)
+
+	out, err := runLinkTest(t, storage, false, later, "--supersedes", earlier, "--relates-to", earlier)
+	if err != nil {
+		t.Fatalf("link: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Entry linked successfully") {
+		t.Errorf("output missing success message:\n%s", out)
+	}
+
+	entry := readEntryFromDir(t, dir, later)
+	want := []ledger.Relation{
+		{Type: ledger.RelationSupersedes, ID: earlier},
+		{Type: ledger.RelationRelatesTo, ID: earlier},
+	}
+	if len(entry.Relations) != len(want) {
+		t.Fatalf("relations = %v, want %v", entry.Relations, want)
+	}
+	for i := range want {
+		if entry.Relations[i] != want[i] {
+			t.Errorf("relations[%d] = %v, want %v", i, entry.Relations[i], want[i])
+		}
+	}
+	if !entry.UpdatedAt.After(entry.CreatedAt) {
+		t.Error("expected updated_at to move forward")
+	}
+
+	// Linking again is a no-op.
+	out, err = runLinkTest(t, storage, true, later, "--supersedes", earlier)
+	if err != nil {
+		t.Fatalf("link again: %v\n%s", err, out)
+	}
+	var result map[string]any
+	if err = json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parsing JSON: %v\n%s", err, out)
+	}
+	if result["status"] != "unchanged" {
+		t.Errorf("status = %v, want unchanged", result["status"])
+	}
+	if rels, _ := result["relations"].([]any); len(rels) != 2 {
+		t.Errorf("relations = %v, want 2", result["relations"])
+	}
+}
+
+func TestLinkRejectsBadTargets(t *testing.T) {
+	storage, _, _, later := setupLinkTestStorage(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no relation", []string{later}, "at least one relation"},
+		{"self", []string{later, "--fixes", later}, "cannot relate to itself"},
+		{"missing target", []string{later, "--fixes", "tb_2025-01-01T00:00:00Z_000000"}, "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runLinkTest(t, storage, false, tt.args...)
+			if err == nil {
+				t.Fatalf("expected an error, got output:\n%s", out)
+			}
+			if !strings.Contains(strings.ToLower(err.Error()), tt.want) {
+				t.Errorf("error = %q, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkDryRunWritesNothing(t *testing.T) {
+	storage, dir, earlier, later := setupLinkTestStorage(t)
+
+	out, err := runLinkTest(t, storage, true, later, "--fixes", earlier, "--dry-run")
+	if err != nil {
+		t.Fatalf("link --dry-run: %v\n%s", err, out)
+	}
+	var result map[string]any
+	if err = json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parsing JSON: %v\n%s", err, out)
+	}
+	if plan, _ := result["planned_actions"].([]any); len(plan) != 1 {
+		t.Errorf("planned_actions = %v, want one action", result["planned_actions"])
+	}
+	if entry := readEntryFromDir(t, dir, later); len(entry.Relations) != 0 {
+		t.Errorf("dry run wrote relations %v", entry.Relations)
+	}
+}
+
+func TestShowFieldsListRelations(t *testing.T) {
+	entry := createQueryTestEntryStruct("ccc333ddd444", "Corrected fix", time.Date(2026, 1, 20, 10, 0, 0, 0, time.UTC))
+	entry.Relations = []ledger.Relation{
+		{Type: ledger.RelationRelatesTo, ID: "tb_b"},
+		{Type: ledger.RelationSupersedes, ID: "tb_a"},
+		{Type: ledger.RelationRelatesTo, ID: "tb_c"},
+	}
+	got := map[string]string{}
+	for _, field := range relationFields(entry) {
+		got[field.Key] = field.Value
+	}
+	if got["Supersedes"] != "tb_a" || got["Related"] != "tb_b, tb_c" || len(got) != 2 {
+		t.Errorf("relation rows = %v", got)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"net/url"
+	"path/filepath"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// links renders commit SHAs and entry IDs in human output as terminal
+// hyperlinks: a commit to its page on the remote's forge, an entry to its
+// file in the ledger (what `timbers show` reads). Targets are only resolved
+// when the printer renders links, so piped output costs no git calls. A nil
+// *links renders plain text.
+type links struct {
+	printer *output.Printer
+	storage *ledger.Storage
+	webURL  string
+}
+
+// newLinks prepares links for printer, looking up the remote once.
+// storage may be nil, in which case entry IDs are not linked.
+func newLinks(printer *output.Printer, storage *ledger.Storage) *links {
+	linker := &links{printer: printer, storage: storage}
+	if printer.LinksEnabled() {
+		linker.webURL = git.WebURL()
+	}
+	return linker
+}
+
+// commit returns display linked to sha's commit page.
+func (l *links) commit(sha, display string) string {
+	if l == nil {
+		return display
+	}
+	return l.printer.Link(git.CommitWebURL(l.webURL, sha), display)
+}
+
+// anchor returns anchorDisplay(sha), linked to the commit page when the
+// commit is in the current history.
+func (l *links) anchor(sha string) string {
+	display := anchorDisplay(sha)
+	if display != shortSHA(sha) {
+		return display
+	}
+	return l.commit(sha, display)
+}
+
+// entry returns id linked to its entry file.
+func (l *links) entry(id string) string {
+	if l == nil || l.storage == nil || !l.printer.LinksEnabled() {
+		return id
+	}
+	path, ok := l.storage.EntryPath(id)
+	if !ok {
+		return id
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return id
+	}
+	fileURL := url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}
+	return l.printer.Link(fileURL.String(), id)
+}
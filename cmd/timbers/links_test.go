@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+func TestLinks(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	runGit(t, dir, "remote", "add", "origin", "git@github.com:gorewood/timbers.git")
+	sha := strings.TrimSpace(runGitOutput(t, dir, "rev-parse", "HEAD"))
+
+	timbersDir := filepath.Join(dir, ".timbers")
+	entry := createQueryTestEntryStruct(sha, "linked work", time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC))
+	writeQueryEntryFile(t, timbersDir, entry)
+	storage := ledger.NewStorage(nil, ledger.NewFileStorage(timbersDir, nil, nil))
+
+	runInDir(t, dir, func() {
+		linker := newLinks(output.NewPrinter(&bytes.Buffer{}, false, true), storage)
+
+		commit := linker.anchor(sha)
+		if want := "https://github.com/gorewood/timbers/commit/" + sha; !strings.Contains(commit, "\x1b]8;;"+want+"\x1b\\") {
+			t.Errorf("anchor = %q, want a link to %s", commit, want)
+		}
+		if id := linker.entry(entry.ID); !strings.Contains(id, "\x1b]8;;file://") || !strings.Contains(id, entry.ID) {
+			t.Errorf("entry = %q, want a file link", id)
+		}
+		if id := linker.entry("tb_2026-01-01T00:00:00Z_missing"); strings.Contains(id, "\x1b") {
+			t.Errorf("entry for a missing file = %q, want plain text", id)
+		}
+
+		piped := newLinks(output.NewPrinter(&bytes.Buffer{}, false, false), storage)
+		if got := piped.anchor(sha); got != shortSHA(sha) {
+			t.Errorf("piped anchor = %q, want plain %q", got, shortSHA(sha))
+		}
+	})
+
+	var none *links
+	if got := none.entry(entry.ID); got != entry.ID {
+		t.Errorf("nil links entry = %q, want plain", got)
+	}
+}
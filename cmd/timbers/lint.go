@@ -0,0 +1,166 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// lintFailNone disables the failing exit status.
+const lintFailNone = "none"
+
+// lintFlags holds flag values for the lint command.
+type lintFlags struct {
+	last            string
+	since           string
+	until           string
+	failOn          string
+	placeholderDays int
+	maxCommits      int
+	maxFiles        int
+}
+
+// newLintCmd creates the lint command.
+func newLintCmd() *cobra.Command {
+	return newLintCmdInternal(nil)
+}
+
+// newLintCmdInternal creates the lint command with optional storage injection.
+// If storage is nil, a real storage is created when the command runs.
+func newLintCmdInternal(storage *ledger.Storage) *cobra.Command {
+	var flags lintFlags
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check entry quality with heuristic rules (no LLM)",
+		Long: `Check ledger entries for common quality problems without an LLM.
+
+Rules:
+  placeholder        "Auto-documented" text (error once older than --placeholder-days)
+  why-restates-what  why adds nothing to what (warning)
+  todo-marker        TODO, FIXME, TBD, or XXX left in the text (warning)
+  large-workset      more commits or files than one entry can explain (warning)
+  no-tags            entry has no tags (info)
+
+Exits non-zero when any finding is at or above --fail-on, so it can gate CI.
+
+Examples:
+  timbers lint                          # Lint every entry
+  timbers lint --since 7d --fail-on warning
+  timbers lint --last 20 --json         # Machine-readable findings for CI`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runLint(cmd, storage, flags)
+		},
+	}
+
+	defaults := ledger.DefaultLintOptions()
+	cmd.Flags().StringVar(&flags.last, "last", "", "Lint the last N entries")
+	cmd.Flags().StringVar(&flags.since, "since", "", "Lint entries since duration (24h, 7d) or date")
+	cmd.Flags().StringVar(&flags.until, "until", "", "Lint entries until duration (24h, 7d) or date")
+	cmd.Flags().StringVar(&flags.failOn, "fail-on", ledger.LintError, "Exit non-zero at this severity or above: error, warning, info, or none")
+	cmd.Flags().IntVar(&flags.placeholderDays, "placeholder-days", int(defaults.PlaceholderAge/(24*time.Hour)),
+		"Days an Auto-documented placeholder may stand before it is an error")
+	cmd.Flags().IntVar(&flags.maxCommits, "max-commits", defaults.MaxCommits, "Commits per entry before large-workset fires (0 disables)")
+	cmd.Flags().IntVar(&flags.maxFiles, "max-files", defaults.MaxFiles, "Files per entry before large-workset fires (0 disables)")
+
+	return cmd
+}
+
+// runLint executes the lint command.
+func runLint(cmd *cobra.Command, storage *ledger.Storage, flags lintFlags) error {
+	printer := newPrinter(cmd)
+
+	if flags.failOn != lintFailNone && !slices.Contains(ledger.LintSeverities, flags.failOn) {
+		err := output.NewUserError("--fail-on must be one of: " + strings.Join(ledger.LintSeverities, ", ") + ", " + lintFailNone)
+		printer.Error(err)
+		return err
+	}
+
+	storage, err := initQueryStorage(storage, printer)
+	if err != nil {
+		return err
+	}
+	entries, err := storage.ListEntries()
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	entries, err = selectDraftEntries(printer, storage, entries, flags.last, flags.since, flags.until, "")
+	if err != nil {
+		return err
+	}
+
+	findings := ledger.LintEntries(entries, ledger.LintOptions{
+		PlaceholderAge: time.Duration(flags.placeholderDays) * 24 * time.Hour,
+		MaxCommits:     flags.maxCommits,
+		MaxFiles:       flags.maxFiles,
+	})
+	counts := lintCounts(findings)
+
+	if printer.IsJSON() {
+		if findings == nil {
+			findings = []ledger.LintFinding{}
+		}
+		if err := printer.Success(map[string]any{
+			"entries_checked": len(entries),
+			"findings":        findings,
+			"summary":         counts,
+		}); err != nil {
+			return err
+		}
+	} else {
+		outputLintHuman(printer, len(entries), findings, counts)
+	}
+
+	return lintFailure(findings, flags.failOn)
+}
+
+// lintCounts tallies findings by severity.
+func lintCounts(findings []ledger.LintFinding) map[string]int {
+	counts := make(map[string]int, len(ledger.LintSeverities))
+	for _, severity := range ledger.LintSeverities {
+		counts[severity] = 0
+	}
+	for _, finding := range findings {
+		counts[finding.Severity]++
+	}
+	return counts
+}
+
+// outputLintHuman prints one line per finding and a summary.
+func outputLintHuman(printer *output.Printer, checked int, findings []ledger.LintFinding, counts map[string]int) {
+	for _, finding := range findings {
+		printer.Print("%-7s  %s  %s: %s\n", finding.Severity, finding.EntryID, finding.Rule, finding.Message)
+	}
+	if len(findings) > 0 {
+		printer.Println()
+	}
+	printer.Print("Checked %d entries: %d errors, %d warnings, %d info\n",
+		checked, counts[ledger.LintError], counts[ledger.LintWarning], counts[ledger.LintInfo])
+}
+
+// lintFailure returns an error when any finding is at or above failOn.
+func lintFailure(findings []ledger.LintFinding, failOn string) error {
+	if failOn == lintFailNone {
+		return nil
+	}
+	threshold := ledger.LintSeverityRank(failOn)
+	failing := 0
+	for _, finding := range findings {
+		if ledger.LintSeverityRank(finding.Severity) <= threshold {
+			failing++
+		}
+	}
+	if failing == 0 {
+		return nil
+	}
+	return output.NewUserError(fmt.Sprintf("lint failed: %d findings at %s or above", failing, failOn))
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// runLintTest runs the lint command over file-backed entries.
+func runLintTest(t *testing.T, entries []*ledger.Entry, args ...string) (string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, entry := range entries {
+		writeQueryEntryFile(t, dir, entry)
+	}
+	files := ledger.NewFileStorage(dir, func(_ string) error { return nil }, func(_, _ string) error { return nil })
+	cmd := newLintCmdInternal(ledger.NewStorage(&mockGitOpsForQuery{}, files))
+	cmd.PersistentFlags().Bool("json", false, "")
+	var buf strings.Builder
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func lintTestEntries() []*ledger.Entry {
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	placeholder := createQueryTestEntryStruct("aaa111", "Bump deps", old)
+	placeholder.Summary.Why = ledger.AutoPlaceholder
+	placeholder.Tags = []string{"deps"}
+	restated := createQueryTestEntryStruct("bbb222", "Fix login redirect", old.Add(time.Hour))
+	restated.Summary.Why = "Fix login redirect"
+	restated.Tags = []string{"auth"}
+	return []*ledger.Entry{placeholder, restated}
+}
+
+func TestLintFailsOnErrors(t *testing.T) {
+	out, err := runLintTest(t, lintTestEntries())
+	if err == nil {
+		t.Fatalf("lint with an old placeholder succeeded:\n%s", out)
+	}
+	for _, want := range []string{ledger.RulePlaceholder, ledger.RuleWhyRestatesWhat, "1 errors, 1 warnings"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestLintFailOnThreshold(t *testing.T) {
+	entries := lintTestEntries()[1:]
+
+	if out, err := runLintTest(t, entries); err != nil {
+		t.Errorf("warnings failed the default --fail-on error: %v\n%s", err, out)
+	}
+	if out, err := runLintTest(t, entries, "--fail-on", "warning"); err == nil {
+		t.Errorf("--fail-on warning passed with a warning:\n%s", out)
+	}
+	if out, err := runLintTest(t, lintTestEntries(), "--fail-on", "none"); err != nil {
+		t.Errorf("--fail-on none failed: %v\n%s", err, out)
+	}
+	if out, err := runLintTest(t, entries, "--fail-on", "fatal"); err == nil || !strings.Contains(out, "--fail-on") {
+		t.Errorf("want --fail-on validation error, got err=%v\n%s", err, out)
+	}
+}
+
+func TestLintJSON(t *testing.T) {
+	out, _ := runLintTest(t, lintTestEntries(), "--json", "--fail-on", "none")
+
+	var result struct {
+		EntriesChecked int                  `json:"entries_checked"`
+		Findings       []ledger.LintFinding `json:"findings"`
+		Summary        map[string]int       `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.EntriesChecked != 2 || len(result.Findings) != 2 || result.Summary[ledger.LintError] != 1 {
+		t.Errorf("result = %+v, want 2 entries, 2 findings, 1 error", result)
+	}
+}
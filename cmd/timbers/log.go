@@ -19,24 +19,6 @@ func newLogCmd() *cobra.Command {
 	return newLogCmdInternal(nil, nil)
 }
 
-// logFlags holds all flag values for the log command.
-type logFlags struct {
-	why       string
-	how       string
-	notes     string
-	tags      []string
-	workItems []string
-	who       []string
-	rangeStr  string
-	anchor    string
-	minor     bool
-	dryRun    bool
-	push      bool
-	auto      bool
-	yes       bool
-	batch     bool
-}
-
 // newLogCmdInternal creates the log command with optional storage and dirty checker injection.
 // If storage is nil, a real storage is created when the command runs.
 // If isDirty is nil, git.HasUncommittedChanges is used.
@@ -68,11 +50,33 @@ Examples:
   timbers log "Updated deps" --minor
   timbers log "New feature" --why "User request" --how "New component" --tag feature
   timbers log "Bug fix" --why "Issue #123" --how "Patched" --work-item jira:PROJ-456
+  timbers log "Retry budget" --why "..." --how "..." --meta service=api --meta risk=low
   timbers log "New API" --why "Agents need access" --how "MCP server" --notes "Debated HTTP vs exec wrapping"
   timbers log "Paired work" --why "..." --how "..." --who "Name <email>"
   timbers log --auto              # Extract what/why/how from commit messages
   timbers log --auto --yes        # Auto mode without confirmation
+  timbers log -i                  # Fill in the entry with a form
+  timbers log --edit              # Compose the entry in $EDITOR
+  echo '{"summary":{"why":"...","how":"..."}}' | timbers log --stdin --json
+  timbers log --from-file notes.md  # Log a markdown draft
   timbers log --batch             # Create entries for each work-item group or day
+  timbers log --batch --first-parent  # One entry per merge on the mainline
+  timbers log --batch --group-by path-prefix  # One entry per subsystem touched
+  timbers log "Release" --why "..." --how "..." --require-signed
+  timbers log "Use Postgres" --why "Need transactions" --kind decision --alternative "SQLite"
+  timbers log "Cherry-picked fix" --why "..." --how "..." --range A..B --force
+  timbers log "Refactor" --why "..." --how "..." --numstat
+  timbers log "Key rotation" --why "..." --how "..." --encrypt
+  timbers log "Agent fix" --why "..." --how "..." --push --json
+
+Before writing, the entry is compared with existing entries of the same kind.
+If another entry already covers any of its commits, log refuses (exit 3)
+unless --force is given; a recent entry with a near-identical summary only
+produces a warning.
+
+--kind records something other than a work entry: a decision (what, why,
+and at least one --alternative; how is optional), an incident (what, why, how), a milestone (what and
+why; how is optional), or a note (what only).
 
 Each entry is committed separately (not folded into the code commit). This
 enables reliable pending detection and keeps captured text independent of later
@@ -83,7 +87,60 @@ git log --invert-grep --grep="^timbers: document"
 Contributor attribution is automatic from mailmap-normalized Git authors and
 Co-authored-by trailers. Usually omit --who. Repeat --who "Name <email>" for
 pairing, shared work, bots, or correction; any use replaces the automatic set,
-so provide every intended contributor. Only provide identities intended for repository publication.`
+so provide every intended contributor. --co-author "Name <email>" instead adds
+to the automatic set, for pairs and agents the commits do not credit. Only
+provide identities intended for repository publication.
+
+The GPG/SSH signature status of each commit is recorded in the workset.
+--require-signed refuses to write the entry unless every commit carries a
+valid signature.
+
+Commit trailers named in the [trailers] section of .timbers/config.toml
+(e.g. Ticket = "work-item:jira", Reviewed-by = "notes") are copied into the
+entry's work items, tags, or notes.
+
+--first-parent (default from [pending] first_parent in .timbers/config.toml)
+follows only first parents when collecting pending or --range commits, so a
+merged branch is documented as its merge commit.
+
+--numstat also records each file's insertions and deletions in the workset
+diffstat, so exports and queries can show which files an entry touched.
+
+started_at records when the work began, for cycle time: the earliest commit's
+author date, or --started (2025-01-02, 3d) when the work began earlier.
+
+-i asks for the entry on the terminal: a checklist of pending commits, then
+what, why, how, tags, and work items, each defaulting to its flag. Unchecked
+commits stay pending only if they are newer than every checked one.
+
+--edit opens what, why, how, and notes in the editor git uses, filled in
+from any flags and otherwise from the commit messages. The saved text is
+checked like flag input; an empty What aborts.
+
+--stdin reads the entry as JSON in the entry schema, complete or partial:
+summary, notes, kind, tags, work_items, contributors, meta, started_at, and
+decision fill whatever the flags leave unset. Git-derived fields (id,
+timestamps, workset) are ignored and harvested as usual, and the result is
+checked like flag input.
+
+--from-file reads a markdown draft: optional front matter between "---"
+lines (what, why, how, notes, kind, tags, work_items), then "## What",
+"## Why", "## How", and "## Notes" sections, which win over the front
+matter. A "# Title" line before the first section is the what when nothing
+else sets it. Flags win over the draft.
+
+--push (default from [log] push in .timbers/config.toml) pushes the current
+branch to its upstream, or to origin, once the entry is committed. A failed
+push is a warning; the JSON result reports it under "push".
+
+Tags are also inferred from the files the commits touch, by the glob = tag
+rules in [tags.paths] of .timbers/config.toml (e.g. "internal/llm/**" =
+"llm"). --dry-run lists the inferred tags.
+
+Without --work-item, the branch name is matched against the regex = system
+rules in [log.branch_work_items] (e.g. '^feature/([A-Z]+-\d+)' = "jira");
+the capture group becomes the work item ID. --dry-run shows the detected
+item.`
 
 // logContext holds all data needed to create a log entry.
 type logContext struct {
@@ -92,40 +149,27 @@ type logContext struct {
 	commits      []git.Commit
 	anchor       string
 	diffstat     git.Diffstat
+	files        []string
 	workItems    []ledger.WorkItem
 	contributors []ledger.Contributor
+	commitMeta   []ledger.CommitMeta
+	trailers     trailerFields
+	inferred     logInference
 }
 
 // runLog executes the log command.
 func runLog(cmd *cobra.Command, storage *ledger.Storage, isDirty dirtyChecker, args []string, flags logFlags) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd)).
+	printer := newPrinter(cmd).
 		WithWidth(output.TerminalWidth(cmd.OutOrStdout(), 80))
 
 	storage, err := initLogStorage(storage, printer)
 	if err != nil {
 		return err
 	}
+	applyFirstParentFlag(cmd, storage)
+	applyPushDefault(cmd, &flags)
 
-	// Refuse if working tree is dirty: the auto-commit pathspec-scopes to the
-	// entry file (internal/ledger/filestorage.go: git commit -- <path>), so
-	// staged feature changes stay in the index while the entry rides on the
-	// old HEAD. Push then ships a phantom: an entry whose prose describes
-	// work that isn't in any commit below it. Most often hit when the
-	// pre-commit gate aborted the prior `git commit` and the caller chained
-	// `timbers log` after a newline (no &&). --dry-run is still allowed
-	// because it short-circuits before the auto-commit and only prints what
-	// the entry would look like.
-	if isDirty == nil {
-		isDirty = git.HasUncommittedChanges
-	}
-	if isDirty() && !flags.dryRun {
-		err := output.NewUserError(
-			"working tree has uncommitted changes; commit (or stash) them " +
-				"first to avoid phantom entries. If the prior `git commit` " +
-				"was aborted by the pre-commit gate, your staged changes " +
-				"are still in the index — inspect with: git diff --cached. " +
-				"For a no-op peek, re-run with --dry-run.")
-		printer.Error(err)
+	if err = checkCleanTree(isDirty, flags.dryRun, printer); err != nil {
 		return err
 	}
 
@@ -139,6 +183,24 @@ func runLog(cmd *cobra.Command, storage *ledger.Storage, isDirty dirtyChecker, a
 		return err
 	}
 
+	args, err = flags.applyStdinEntry(cmd.InOrStdin(), args)
+	if err == nil {
+		args, err = flags.applyEntryFile(args)
+	}
+	if err == nil {
+		err = flags.parseValues()
+	}
+	if err == nil {
+		err = flags.attachForm(printer, cmd.InOrStdin())
+	}
+	if err == nil {
+		err = checkEditFlags(flags, printer.IsJSON())
+	}
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+
 	// Dispatch to batch mode if --batch is set
 	if flags.batch {
 		return runBatchLog(storage, flags, printer)
@@ -149,51 +211,17 @@ func runLog(cmd *cobra.Command, storage *ledger.Storage, isDirty dirtyChecker, a
 		return err
 	}
 
-	entry := buildEntry(ctx)
+	entry := buildEntry(storage, ctx)
 
-	if flags.dryRun {
-		return outputDryRun(printer, entry)
-	}
-
-	return executeLogWrite(storage, entry, printer)
-}
-
-// initLogStorage initializes the storage, checking for git repo if needed.
-func initLogStorage(storage *ledger.Storage, printer *output.Printer) (*ledger.Storage, error) {
-	if storage == nil && !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
-		printer.Error(err)
-		return nil, err
+	if err := checkDuplicateWork(storage, entry, flags, printer); err != nil {
+		return err
 	}
 
-	if storage == nil {
-		var err error
-		storage, err = ledger.NewDefaultStorage()
-		if err != nil {
-			printer.Error(err)
-			return nil, err
-		}
+	if flags.dryRun {
+		return outputDryRun(printer, entry, ctx.inferred)
 	}
-	return storage, nil
-}
 
-// resolveAnchorFlag resolves a symbolic --anchor (HEAD, a branch, a short SHA)
-// to a full SHA in place before it flows into range selection or the stored
-// anchor. Persisting a symbolic ref like "HEAD" yields entry ids suffixed
-// "_HEAD" and an anchor that changes meaning per-commit and per-worktree,
-// defeating the since-anchor model. An unresolvable ref errors here rather than
-// writing a phantom entry anchored on nothing. No-op when --anchor is unset.
-func resolveAnchorFlag(storage *ledger.Storage, flags *logFlags, printer *output.Printer) error {
-	if flags.anchor == "" {
-		return nil
-	}
-	resolved, err := storage.ResolveCommit(flags.anchor)
-	if err != nil {
-		printer.Error(err)
-		return err
-	}
-	flags.anchor = resolved
-	return nil
+	return executeLogWrite(storage, entry, flags.push, printer)
 }
 
 // prepareLogContext validates inputs and gathers all data needed for the entry.
@@ -210,23 +238,38 @@ func prepareLogContext(
 		return nil, err
 	}
 
-	parsedWorkItems, err := parseWorkItems(flags.workItems)
-	if err != nil {
-		printer.Error(err)
-		return nil, err
-	}
-
-	if err = resolveAnchorFlag(storage, &flags, printer); err != nil {
+	if err := resolveAnchorFlag(storage, &flags, printer); err != nil {
 		return nil, err
 	}
 
 	commits, fromRef, staleAnchor, err := getLogCommits(storage, flags)
+	if err == nil && flags.form != nil {
+		// The checklist may leave commits out; diff from the oldest kept one.
+		commits, args, flags, err = flags.form.fill(commits, args, flags)
+		fromRef = ""
+	}
+	if err == nil && flags.edit {
+		args, flags, err = editLogContent(commits, args, flags)
+	}
+	var parsedWorkItems []ledger.WorkItem
+	if err == nil {
+		parsedWorkItems, err = parseWorkItems(flags.workItems)
+	}
 	if err != nil {
 		printer.Error(err)
 		return nil, err
 	}
-	contributors, err := resolveLogContributors(commits, flags.who, staleAnchor, printer)
+	contributors, err := resolveLogContributors(commits, flags.who, flags.coAuthors, staleAnchor, printer)
+	if err != nil {
+		return nil, err
+	}
+	sigs, trailers, err := harvestLogCommits(commits, flags.requireSigned)
+	var inferenceRules logInferenceRules
+	if err == nil {
+		inferenceRules, err = loadLogInferenceRules()
+	}
 	if err != nil {
+		printer.Error(err)
 		return nil, err
 	}
 
@@ -239,10 +282,7 @@ func prepareLogContext(
 
 	anchor := determineAnchor(commits, flags.anchor)
 
-	diffstat, err := getDiffstatForRange(storage, fromRef, anchor, commits)
-	if err != nil {
-		diffstat = git.Diffstat{}
-	}
+	diffstat, files := resolveWorksetChanges(storage, fromRef, anchor, commits)
 
 	return &logContext{
 		what:         what,
@@ -250,21 +290,30 @@ func prepareLogContext(
 		commits:      commits,
 		anchor:       anchor,
 		diffstat:     diffstat,
+		files:        files,
 		workItems:    parsedWorkItems,
 		contributors: contributors,
+		commitMeta:   ledger.NewCommitMeta(commits, sigs),
+		trailers:     trailers,
+		inferred:     inferenceRules.infer(files, updatedFlags.workItems),
 	}, nil
 }
 
-// executeLogWrite writes the entry to the .timbers/ directory.
+// executeLogWrite writes the entry to the .timbers/ directory, then pushes
+// the branch when push is set.
 func executeLogWrite(
 	storage *ledger.Storage,
 	entry *ledger.Entry,
+	push bool,
 	printer *output.Printer,
 ) error {
 	if err := storage.WriteEntry(entry, false); err != nil {
 		printer.Error(err)
 		return err
 	}
+	if push {
+		return outputLogSuccess(printer, entry, pushAfterLog(printer))
+	}
 
 	// Push-before-log race detection: if the commit we just documented is
 	// already on the upstream branch, then the user pushed before logging
@@ -272,18 +321,18 @@ func executeLogWrite(
 	// follow-up push, anyone branching off origin sees the content commit
 	// pending with no entry.
 	if anchor := entry.Workset.AnchorCommit; anchor != "" && git.IsPushedToUpstream(anchor) {
-		printer.Warn(
+		printer.Warning(
 			"documented commit %s is already pushed, but this entry is not — "+
 				"run `git push` to sync the entry",
 			shortSHA(anchor),
 		)
 	}
 
-	return outputLogSuccess(printer, entry)
+	return outputLogSuccess(printer, entry, nil)
 }
 
 // buildEntry constructs the ledger entry from the context.
-func buildEntry(ctx *logContext) *ledger.Entry {
+func buildEntry(storage *ledger.Storage, ctx *logContext) *ledger.Entry {
 	now := time.Now().UTC()
 
 	why := ctx.flags.why
@@ -307,21 +356,21 @@ func buildEntry(ctx *logContext) *ledger.Entry {
 		rangeStr = ctx.commits[len(ctx.commits)-1].Short + ".." + ctx.commits[0].Short
 	}
 
-	return &ledger.Entry{
+	entry := &ledger.Entry{
 		Schema:    ledger.SchemaVersion,
-		Kind:      ledger.KindEntry,
-		ID:        ledger.GenerateID(ctx.anchor, now),
+		Kind:      ctx.flags.entryKind(),
+		ID:        storage.NewID(ctx.anchor, now),
 		CreatedAt: now,
+		StartedAt: entryStarted(ctx.flags.startedAt, ctx.commits),
 		UpdatedAt: now,
 		Workset: ledger.Workset{
 			AnchorCommit: ctx.anchor,
 			Commits:      commitSHAs,
 			Range:        rangeStr,
-			Diffstat: &ledger.Diffstat{
-				Files:      ctx.diffstat.Files,
-				Insertions: ctx.diffstat.Insertions,
-				Deletions:  ctx.diffstat.Deletions,
-			},
+			Diffstat:     ledger.NewDiffstat(ctx.diffstat, ctx.flags.numstat),
+			CommitMeta:   ctx.commitMeta,
+			Files:        ctx.files,
+			Branch:       git.AttachedBranch(),
 		},
 		Summary: ledger.Summary{
 			What: ctx.what,
@@ -332,5 +381,14 @@ func buildEntry(ctx *logContext) *ledger.Entry {
 		Tags:         ctx.flags.tags,
 		WorkItems:    ctx.workItems,
 		Contributors: ctx.contributors,
+		Meta:         ctx.flags.meta,
+		Decision:     ctx.flags.decision,
+		Relations:    ctx.flags.relations,
+	}
+	ctx.trailers.apply(entry)
+	ctx.inferred.apply(entry)
+	if ctx.flags.encrypt {
+		entry.Encrypt()
 	}
+	return entry
 }
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorewood/timbers/internal/ledger"
 )
@@ -95,6 +96,45 @@ func TestLogResolvesSymbolicAnchor(t *testing.T) {
 	}
 }
 
+// TestLogRecordsBranch verifies the entry records the branch it was logged on.
+func TestLogRecordsBranch(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	runGit(t, dir, "checkout", "-b", "feat/oauth")
+
+	out, err := runLogCmd(t, dir, "documented on a branch", "--why", "y", "--how", "z")
+	if err != nil {
+		t.Fatalf("timbers log errored: %v\noutput: %s", err, out)
+	}
+	if branch := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).Workset.Branch; branch != "feat/oauth" {
+		t.Errorf("branch = %q, want feat/oauth", branch)
+	}
+}
+
+// TestLogRecordsStarted verifies started_at defaults to the earliest
+// commit's author date and --started overrides it.
+func TestLogRecordsStarted(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	dates := strings.Fields(runGitOutput(t, dir, "log", "--format=%aI"))
+	earliest, err := time.Parse(time.RFC3339, dates[len(dates)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, logErr := runLogCmd(t, dir, "started by default", "--why", "y", "--how", "z"); logErr != nil {
+		t.Fatalf("timbers log errored: %v\noutput: %s", logErr, out)
+	}
+	if started := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).StartedAt; started == nil || !started.Equal(earliest) {
+		t.Errorf("started_at = %v, want %v", started, earliest)
+	}
+
+	dir = newLogAnchorRepo(t)
+	if out, logErr := runLogCmd(t, dir, "started explicitly", "--why", "y", "--how", "z", "--started", "2025-01-02"); logErr != nil {
+		t.Fatalf("timbers log --started errored: %v\noutput: %s", logErr, out)
+	}
+	if started := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).Started(); started.Format(time.DateOnly) != "2025-01-02" {
+		t.Errorf("started_at = %v, want 2025-01-02", started)
+	}
+}
+
 // TestLogRejectsUnknownAnchor verifies a non-existent ref passed to --anchor
 // fails cleanly (user error) instead of writing a phantom entry anchored on an
 // unresolvable ref.
@@ -3,6 +3,7 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"sort"
 	"strings"
@@ -27,6 +28,10 @@ type batchResult struct {
 	Status  string          `json:"status"`
 	Count   int             `json:"count"`
 	Entries []batchEntryRef `json:"entries"`
+	// Push is set when --push pushed (or failed to push) the written entries.
+	Push *pushResult `json:"push,omitempty"`
+	// PlannedActions is set for --dry-run only.
+	PlannedActions []plannedAction `json:"planned_actions,omitempty"`
 }
 
 // batchEntryRef is a lightweight reference to a created entry.
@@ -35,10 +40,21 @@ type batchEntryRef struct {
 	Anchor   string `json:"anchor"`
 	GroupKey string `json:"group_key"`
 	What     string `json:"what"`
+	// InferredTags are the tags [tags.paths] added to the entry.
+	InferredTags []string `json:"inferred_tags,omitempty"`
+	// DetectedWorkItem is the work item [log.branch_work_items] found in
+	// the branch name, when the group had none of its own.
+	DetectedWorkItem string `json:"detected_work_item,omitempty"`
 }
 
-// runBatchLog processes pending commits in batches grouped by work-item or day.
+// runBatchLog processes pending commits in batches grouped by --group-by.
 func runBatchLog(storage *ledger.Storage, flags logFlags, printer *output.Printer) error {
+	if flags.entryKind() != ledger.KindEntry {
+		err := output.NewUserError("--kind cannot be combined with --batch; batch mode records work entries")
+		printer.Error(err)
+		return err
+	}
+
 	// Get pending commits
 	commits, err := getBatchCommits(storage, flags)
 	if err != nil {
@@ -52,8 +68,23 @@ func runBatchLog(storage *ledger.Storage, flags logFlags, printer *output.Printe
 		return err
 	}
 
-	// Group commits by work-item trailer or by day
-	groups := groupCommits(commits)
+	grouping, err := resolveBatchGrouping(flags.groupBy)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	harvest, err := harvestBatch(commits, flags.requireSigned)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	groups, err := groupBatchCommits(commits, grouping, harvest.firstParentLine)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
 
 	if len(groups) == 0 {
 		err := output.NewUserError("no groups found for batch processing")
@@ -62,7 +93,7 @@ func runBatchLog(storage *ledger.Storage, flags logFlags, printer *output.Printe
 	}
 
 	// Process each group
-	return processBatchGroups(storage, groups, flags, printer)
+	return processBatchGroups(storage, groups, harvest, flags, printer)
 }
 
 // getBatchCommits retrieves pending commits for batch processing.
@@ -81,21 +112,21 @@ func getBatchCommits(storage *ledger.Storage, flags logFlags) ([]git.Commit, err
 	return commits, nil
 }
 
-// GroupStrategy defines how commits are grouped: auto, day, or work-item.
+// GroupStrategy defines how commits are grouped: auto, day, work-item,
+// author, path-prefix, or branch-merge.
 type GroupStrategy string
 
 const (
-	GroupStrategyAuto     GroupStrategy = "auto"      // work-item first, fallback to day
-	GroupStrategyDay      GroupStrategy = "day"       // group by YYYY-MM-DD
-	GroupStrategyWorkItem GroupStrategy = "work-item" // group by Work-item trailer
+	GroupStrategyAuto        GroupStrategy = "auto"         // work-item first, fallback to day
+	GroupStrategyDay         GroupStrategy = "day"          // group by YYYY-MM-DD
+	GroupStrategyWorkItem    GroupStrategy = "work-item"    // group by Work-item trailer
+	GroupStrategyAuthor      GroupStrategy = "author"       // group by author email
+	GroupStrategyPathPrefix  GroupStrategy = "path-prefix"  // group by leading directories touched
+	GroupStrategyBranchMerge GroupStrategy = "branch-merge" // group by the merge that brought commits in
 )
 
-// groupCommits groups commits using auto strategy (work-item first, fallback to day).
-func groupCommits(commits []git.Commit) []commitGroup {
-	return groupCommitsByStrategy(commits, GroupStrategyAuto)
-}
-
-// groupCommitsByStrategy groups commits using the specified strategy.
+// groupCommitsByStrategy groups commits using the auto, day, or work-item
+// strategy; see groupBatchCommits for the ones that read git.
 func groupCommitsByStrategy(commits []git.Commit, strategy GroupStrategy) []commitGroup {
 	switch strategy {
 	case GroupStrategyDay:
@@ -107,6 +138,7 @@ func groupCommitsByStrategy(commits []git.Commit, strategy GroupStrategy) []comm
 			return groups
 		}
 		return groupCommitsByDay(commits)
+	case GroupStrategyAuthor, GroupStrategyPathPrefix, GroupStrategyBranchMerge:
 	}
 	return nil // unreachable with valid strategy
 }
@@ -173,55 +205,94 @@ func mapToSortedGroups(groups map[string][]git.Commit) []commitGroup {
 	return result
 }
 
-// processBatchGroups processes each group and creates entries.
+// processBatchGroups builds an entry per group, then writes them all in one
+// transaction: either every built entry is written and committed or none
+// is, so a failed write never leaves a half-documented batch behind. A
+// group whose entry cannot be built is skipped; the rest are still written
+// and the skipped groups are reported as a partial error (exit 4).
 func processBatchGroups(
 	storage *ledger.Storage,
 	groups []commitGroup,
+	harvest *batchHarvest,
 	flags logFlags,
 	printer *output.Printer,
 ) error {
-	var entries []batchEntryRef
+	built, refs, buildErr := buildBatchEntries(storage, groups, harvest, flags, printer)
+	if buildErr != nil && output.AsPartial(buildErr) == nil {
+		printer.Error(buildErr)
+		return buildErr
+	}
 
-	for _, group := range groups {
-		entry, err := processBatchGroup(storage, group, flags, printer)
-		if err != nil {
+	var pushed *pushResult
+	if !flags.dryRun {
+		if err := storage.WriteEntries(built); err != nil {
+			printer.Error(err)
 			return err
 		}
-
-		entries = append(entries, batchEntryRef{
-			ID:       entry.ID,
-			Anchor:   entry.Workset.AnchorCommit,
-			GroupKey: group.key,
-			What:     entry.Summary.What,
-		})
+		if flags.push && len(built) > 0 {
+			pushed = pushAfterLog(printer)
+		}
 	}
 
-	return outputBatchResult(printer, entries, flags.dryRun)
+	if buildErr != nil {
+		// JSON carries the created entries in the error's per-item details.
+		if !printer.IsJSON() {
+			_ = outputBatchResult(printer, refs, pushed, flags.dryRun)
+		}
+		printer.Error(buildErr)
+		return buildErr
+	}
+	return outputBatchResult(printer, refs, pushed, flags.dryRun)
 }
 
-// processBatchGroup creates an entry for a single group of commits.
-func processBatchGroup(
+// buildBatchEntries builds the entry for each group, reporting progress
+// across the groups. It returns a partial error when some groups fail to
+// build, and the first failure when all do.
+func buildBatchEntries(
 	storage *ledger.Storage,
-	group commitGroup,
+	groups []commitGroup,
+	harvest *batchHarvest,
 	flags logFlags,
 	printer *output.Printer,
-) (*ledger.Entry, error) {
-	entry, err := buildBatchEntry(storage, group, flags.tags, flags.who)
-	if err != nil {
-		printer.Error(err)
-		return nil, err
-	}
+) ([]*ledger.Entry, []batchEntryRef, error) {
+	progress := printer.Progress("Building batch entries", len(groups))
+	defer progress.Done()
+
+	built := make([]*ledger.Entry, 0, len(groups))
+	refs := make([]batchEntryRef, 0, len(groups))
+	items := make([]output.ItemResult, 0, len(groups))
+	var firstErr error
+	for _, group := range groups {
+		progress.Increment()
+		entry, inferred, err := buildBatchEntry(storage, group, harvest, flags)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			items = append(items, output.ItemFailedWith(group.key, err))
+			continue
+		}
+		built = append(built, entry)
+		refs = append(refs, batchEntryRef{
+			ID:       entry.ID,
+			Anchor:   entry.Workset.AnchorCommit,
+			GroupKey: group.key,
+			What:     entry.Summary.What,
 
-	if flags.dryRun {
-		return entry, nil
+			InferredTags:     inferred.tags,
+			DetectedWorkItem: detectedWorkItem(entry, inferred),
+		})
+		items = append(items, output.ItemSucceeded(group.key, entry.ID))
 	}
 
-	if err := storage.WriteEntry(entry, false); err != nil {
-		printer.Error(err)
-		return nil, err
+	switch {
+	case len(built) == 0 && firstErr != nil:
+		return nil, nil, firstErr
+	case firstErr != nil:
+		return built, refs, output.NewPartialError(fmt.Sprintf(
+			"built %d of %d batch entries; %d groups failed", len(built), len(groups), len(groups)-len(built)), items)
 	}
-
-	return entry, nil
+	return built, refs, nil
 }
 
 // isWorkItemKey checks if a group key represents a work-item (vs a date or "untracked").
@@ -238,18 +309,22 @@ func isWorkItemKey(key string) bool {
 }
 
 // outputBatchResult outputs the batch processing result.
-func outputBatchResult(printer *output.Printer, entries []batchEntryRef, isDryRun bool) error {
+func outputBatchResult(printer *output.Printer, entries []batchEntryRef, pushed *pushResult, isDryRun bool) error {
 	status := "created"
 	if isDryRun {
 		status = "dry_run"
 	}
 
 	if printer.IsJSON() {
-		return printer.WriteJSON(batchResult{
-			Status:  status,
-			Count:   len(entries),
-			Entries: entries,
-		})
+		result := batchResult{Status: status, Count: len(entries), Entries: entries, Push: pushed}
+		if isDryRun {
+			result.PlannedActions = make([]plannedAction, 0, len(entries))
+			for _, ref := range entries {
+				result.PlannedActions = append(result.PlannedActions,
+					plannedAction{Action: planCreate, Target: ref.ID, Detail: "entry for " + ref.GroupKey})
+			}
+		}
+		return printer.WriteJSON(result)
 	}
 
 	// Human-readable output
@@ -261,7 +336,14 @@ func outputBatchResult(printer *output.Printer, entries []batchEntryRef, isDryRu
 
 	for _, e := range entries {
 		printer.Print("  %s [%s] %s\n", e.ID, e.GroupKey, truncateString(e.What, 50))
+		if isDryRun && len(e.InferredTags) > 0 {
+			printer.Print("      inferred tags: %s\n", strings.Join(e.InferredTags, ", "))
+		}
+		if isDryRun && e.DetectedWorkItem != "" {
+			printer.Print("      work item: %s (from branch)\n", e.DetectedWorkItem)
+		}
 	}
+	printPushResult(printer, pushed)
 
 	return nil
 }
@@ -17,19 +17,13 @@ import "github.com/gorewood/timbers/internal/git"
 // linear-anchor assumptions in pending detection broke in confusing
 // ways (the v0.22.0 osprey-strike friction reported by Laura).
 //
-// HEAD is resolved here (best-effort) rather than threaded through the
-// caller chain; if git.HEAD() fails, we degrade to the legacy behavior
-// of returning commits[0] rather than failing the batch run.
-func pickBatchAnchor(commits []git.Commit) string {
-	if len(commits) == 0 {
-		return ""
-	}
-	head, err := git.HEAD()
-	if err != nil || head == "" {
-		return commits[0].SHA
-	}
+// firstParentLine is HEAD's first-parent line, loaded once per batch by
+// harvestBatch. When it could not be loaded it is empty, and we degrade to
+// the legacy behavior of returning commits[0] rather than failing the
+// batch run.
+func pickBatchAnchor(commits []git.Commit, firstParentLine map[string]bool) string {
 	return pickBatchAnchorWith(commits, func(sha string) bool {
-		return git.IsOnFirstParentLine(sha, head)
+		return firstParentLine[sha]
 	})
 }
 
@@ -8,45 +8,58 @@ import (
 	"github.com/gorewood/timbers/internal/output"
 )
 
-// buildBatchEntry constructs a ledger entry from a commit group.
+// buildBatchEntry constructs a ledger entry from a commit group, returning
+// what the harvested inference rules added to it.
 func buildBatchEntry(
-	storage *ledger.Storage, group commitGroup, tags, who []string,
-) (*ledger.Entry, error) {
+	storage *ledger.Storage, group commitGroup, harvest *batchHarvest, flags logFlags,
+) (*ledger.Entry, logInference, error) {
 	what, why, how := extractAutoContent(group.commits)
 	workItems := extractWorkItemsFromKey(group.key)
-	anchor := pickBatchAnchor(group.commits)
+	anchor := pickBatchAnchor(group.commits, harvest.firstParentLine)
 	diffstat := getBatchDiffstat(storage, group.commits, anchor)
+	files, _ := storage.WorksetFiles(group.commits)
 	now := time.Now().UTC()
-	contributors, err := ledger.ResolveContributors(group.commits, who)
+	contributors, err := ledger.ResolveContributors(group.commits, flags.who)
+	if err == nil {
+		contributors, err = ledger.AddCoAuthors(contributors, flags.coAuthors)
+	}
 	if err != nil {
-		return nil, output.NewUserError(err.Error())
+		return nil, logInference{}, output.NewUserError(err.Error())
 	}
 
-	return &ledger.Entry{
+	entry := &ledger.Entry{
 		Schema:    ledger.SchemaVersion,
 		Kind:      ledger.KindEntry,
-		ID:        ledger.GenerateID(anchor, now),
+		ID:        storage.NewID(anchor, now),
 		CreatedAt: now,
+		StartedAt: ledger.EarliestAuthored(group.commits),
 		UpdatedAt: now,
 		Workset: ledger.Workset{
 			AnchorCommit: anchor,
 			Commits:      extractCommitSHAs(group.commits),
 			Range:        buildCommitRange(group.commits),
-			Diffstat: &ledger.Diffstat{
-				Files:      diffstat.Files,
-				Insertions: diffstat.Insertions,
-				Deletions:  diffstat.Deletions,
-			},
+			Diffstat:     ledger.NewDiffstat(diffstat, flags.numstat),
+			CommitMeta:   ledger.NewCommitMeta(group.commits, harvest.sigs),
+			Files:        files,
+			Branch:       git.AttachedBranch(),
 		},
 		Summary: ledger.Summary{
 			What: what,
 			Why:  why,
 			How:  how,
 		},
-		Tags:         tags,
+		Tags:         flags.tags,
 		WorkItems:    workItems,
 		Contributors: contributors,
-	}, nil
+		Meta:         flags.meta,
+	}
+	mapTrailers(harvest.trailerMap, group.commits, harvest.trailers).apply(entry)
+	inferred := harvest.inference.infer(files, nil)
+	inferred.apply(entry)
+	if flags.encrypt {
+		entry.Encrypt()
+	}
+	return entry, inferred, nil
 }
 
 func extractWorkItemsFromKey(key string) []ledger.WorkItem {
@@ -0,0 +1,227 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// groupStrategies lists every --group-by value, in help order.
+var groupStrategies = []GroupStrategy{
+	GroupStrategyAuto, GroupStrategyWorkItem, GroupStrategyDay,
+	GroupStrategyAuthor, GroupStrategyPathPrefix, GroupStrategyBranchMerge,
+}
+
+// defaultPathDepth is how many directories name a path-prefix group when
+// [batch] path_depth is unset: internal/ledger, cmd/timbers.
+const defaultPathDepth = 2
+
+// Group keys for commits the author and path-prefix strategies cannot place.
+const (
+	rootPathGroup  = "(root)"  // files at the repository root
+	emptyPathGroup = "(empty)" // commits that change no files
+)
+
+// mergeBranchRegex extracts the merged branch from git's and GitHub's
+// default merge subjects.
+var mergeBranchRegex = regexp.MustCompile(
+	`^Merge (?:remote-tracking branch|branch) '([^']+)'|^Merge pull request #\d+ from (\S+)`)
+
+// batchGrouping is how a batch splits its commits: the strategy and, for
+// path-prefix, how many directories name a group.
+type batchGrouping struct {
+	strategy  GroupStrategy
+	pathDepth int
+}
+
+// resolveBatchGrouping returns the grouping for --group-by, falling back to
+// [batch] group_by and then auto. An unknown strategy is a user error.
+func resolveBatchGrouping(groupBy string) (batchGrouping, error) {
+	cfg := loadLogProject().Batch
+	grouping := batchGrouping{strategy: GroupStrategyAuto, pathDepth: defaultPathDepth}
+	if cfg.PathDepth > 0 {
+		grouping.pathDepth = cfg.PathDepth
+	}
+	source := "--group-by"
+	if groupBy == "" {
+		groupBy, source = cfg.GroupBy, "[batch] group_by in "+config.ProjectFile
+	}
+	if groupBy == "" {
+		return grouping, nil
+	}
+	if !slices.Contains(groupStrategies, GroupStrategy(groupBy)) {
+		names := make([]string, len(groupStrategies))
+		for i, strategy := range groupStrategies {
+			names[i] = string(strategy)
+		}
+		return grouping, output.NewUserError("invalid " + source + " " + `"` + groupBy + `"` +
+			"; use " + strings.Join(names, ", "))
+	}
+	grouping.strategy = GroupStrategy(groupBy)
+	return grouping, nil
+}
+
+// groupBatchCommits splits commits into groups by the grouping's strategy.
+// Only path-prefix and branch-merge read git, and only they can fail.
+func groupBatchCommits(commits []git.Commit, grouping batchGrouping, firstParentLine map[string]bool) ([]commitGroup, error) {
+	switch grouping.strategy {
+	case GroupStrategyAuthor:
+		return groupCommitsByAuthor(commits), nil
+	case GroupStrategyPathPrefix:
+		return groupCommitsByPathPrefix(commits, grouping.pathDepth)
+	case GroupStrategyBranchMerge:
+		return groupCommitsByBranchMerge(commits, firstParentLine)
+	case GroupStrategyAuto, GroupStrategyDay, GroupStrategyWorkItem:
+	}
+	return groupCommitsByStrategy(commits, grouping.strategy), nil
+}
+
+// groupCommitsByAuthor groups commits by their mailmap-resolved author
+// email, or by name when a commit has no email.
+func groupCommitsByAuthor(commits []git.Commit) []commitGroup {
+	groups := make(map[string][]git.Commit)
+	for _, commit := range commits {
+		key := strings.ToLower(strings.TrimSpace(commit.AuthorEmail))
+		if key == "" {
+			key = strings.TrimSpace(commit.Author)
+		}
+		if key == "" {
+			key = "unknown"
+		}
+		groups[key] = append(groups[key], commit)
+	}
+	return mapToSortedGroups(groups)
+}
+
+// groupCommitsByPathPrefix groups each commit under the leading depth
+// directories most of its files share, so a commit is never split across
+// entries. Ties go to the alphabetically first prefix. Merges count the
+// files they changed against their first parent.
+func groupCommitsByPathPrefix(commits []git.Commit, depth int) ([]commitGroup, error) {
+	files, err := git.CommitFilesMultiFirstParent(extractCommitSHAs(commits))
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string][]git.Commit)
+	for _, commit := range commits {
+		key := dominantPathPrefix(files[commit.SHA], depth)
+		groups[key] = append(groups[key], commit)
+	}
+	return mapToSortedGroups(groups), nil
+}
+
+// dominantPathPrefix returns the prefix most of files fall under.
+func dominantPathPrefix(files []string, depth int) string {
+	if len(files) == 0 {
+		return emptyPathGroup
+	}
+	counts := make(map[string]int)
+	for _, file := range files {
+		counts[pathPrefix(file, depth)]++
+	}
+	best := ""
+	for prefix, count := range counts {
+		if best == "" || count > counts[best] || (count == counts[best] && prefix < best) {
+			best = prefix
+		}
+	}
+	return best
+}
+
+// pathPrefix returns the first depth directories of file, or rootPathGroup
+// for a file at the root.
+func pathPrefix(file string, depth int) string {
+	dir := path.Dir(file)
+	if dir == "." {
+		return rootPathGroup
+	}
+	parts := strings.Split(dir, "/")
+	return strings.Join(parts[:min(depth, len(parts))], "/")
+}
+
+// groupCommitsByBranchMerge groups the commits each merge on HEAD's
+// first-parent line brought in under the merged branch's name, with the
+// merge itself when it is pending. Commits no merge brought in, such as
+// direct commits to the mainline, are grouped by day. The same branch merged
+// twice is one group.
+func groupCommitsByBranchMerge(commits []git.Commit, firstParentLine map[string]bool) ([]commitGroup, error) {
+	keys := make(map[string]string, len(commits))
+	unplaced := 0
+	for _, commit := range commits {
+		keys[commit.SHA] = ""
+		if commit.IsMerge() && firstParentLine[commit.SHA] {
+			keys[commit.SHA] = mergedBranchName(commit)
+		} else if !firstParentLine[commit.SHA] {
+			unplaced++
+		}
+	}
+	// Clean merges are never pending, so walk the mainline for them, newest
+	// first, until every commit off it has its merge.
+	if unplaced > 0 {
+		if err := placeMergedCommits(keys, unplaced); err != nil {
+			return nil, err
+		}
+	}
+
+	// Keep each group in log order, newest first, like the other strategies.
+	groups := make(map[string][]git.Commit)
+	for _, commit := range commits {
+		key := keys[commit.SHA]
+		if key == "" {
+			key = commit.Date.Format("2006-01-02")
+		}
+		groups[key] = append(groups[key], commit)
+	}
+	return mapToSortedGroups(groups), nil
+}
+
+// placeMergedCommits keys each pending commit in keys that a merge on HEAD's
+// first-parent line brought in by that merge's branch, stopping once
+// unplaced commits have been keyed.
+func placeMergedCommits(keys map[string]string, unplaced int) error {
+	head, err := git.HEAD()
+	if err != nil {
+		return err
+	}
+	mainline, err := git.CommitsReachableFromFirstParent(head)
+	if err != nil {
+		return err
+	}
+	for _, merge := range mainline {
+		if unplaced == 0 {
+			break
+		}
+		if !merge.IsMerge() {
+			continue
+		}
+		merged, err := git.Log(merge.SHA+"^1", merge.SHA+"^2")
+		if err != nil {
+			return err
+		}
+		for _, commit := range merged {
+			if key, pending := keys[commit.SHA]; pending && key == "" {
+				keys[commit.SHA] = mergedBranchName(merge)
+				unplaced--
+			}
+		}
+	}
+	return nil
+}
+
+// mergedBranchName returns the branch a merge commit's subject names, or
+// "merge-<short sha>" for a subject in another form.
+func mergedBranchName(merge git.Commit) string {
+	matches := mergeBranchRegex.FindStringSubmatch(merge.Subject)
+	for _, name := range matches[min(1, len(matches)):] {
+		if name != "" {
+			return name
+		}
+	}
+	return "merge-" + merge.Short
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+func TestGroupCommitsByAuthor(t *testing.T) {
+	commits := []git.Commit{
+		{SHA: "aaa111", Author: "Ada", AuthorEmail: "Ada@Example.com"},
+		{SHA: "bbb222", Author: "Grace", AuthorEmail: "grace@example.com"},
+		{SHA: "ccc333", Author: "Ada", AuthorEmail: "ada@example.com"},
+	}
+
+	groups := groupCommitsByAuthor(commits)
+
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	if groups[1].key != "ada@example.com" || len(groups[1].commits) != 2 {
+		t.Errorf("expected ada@example.com with 2 commits, got %s with %d", groups[1].key, len(groups[1].commits))
+	}
+}
+
+func TestDominantPathPrefix(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  string
+	}{
+		{"single directory", []string{"internal/ledger/entry.go", "internal/ledger/storage.go"}, "internal/ledger"},
+		{"majority wins", []string{"docs/spec.md", "cmd/timbers/log.go", "cmd/timbers/log_flags.go"}, "cmd/timbers"},
+		{"tie goes to first prefix", []string{"docs/spec.md", "cmd/timbers/log.go"}, "cmd/timbers"},
+		{"shallow directory", []string{"docs/spec.md"}, "docs"},
+		{"root file", []string{"README.md"}, rootPathGroup},
+		{"no files", nil, emptyPathGroup},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dominantPathPrefix(tt.files, 2); got != tt.want {
+				t.Errorf("dominantPathPrefix(%v) = %q, want %q", tt.files, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergedBranchName(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    string
+	}{
+		{"Merge branch 'feature/auth'", "feature/auth"},
+		{"Merge branch 'fix-1' into main", "fix-1"},
+		{"Merge remote-tracking branch 'origin/dev'", "origin/dev"},
+		{"Merge pull request #12 from acme/feature-x", "acme/feature-x"},
+		{"Integrate release", "merge-abc1234"},
+	}
+	for _, tt := range tests {
+		if got := mergedBranchName(git.Commit{Short: "abc1234", Subject: tt.subject}); got != tt.want {
+			t.Errorf("mergedBranchName(%q) = %q, want %q", tt.subject, got, tt.want)
+		}
+	}
+}
+
+// batchGroupKeys runs a batch dry run in dir and returns its group keys.
+func batchGroupKeys(t *testing.T, dir string, args ...string) []string {
+	t.Helper()
+	out, err := runLogCmd(t, dir, append([]string{"--batch", "--dry-run", "--json"}, args...)...)
+	if err != nil {
+		t.Fatalf("timbers log --batch errored: %v\noutput: %s", err, out)
+	}
+	var result batchResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	keys := make([]string, len(result.Entries))
+	for i, entry := range result.Entries {
+		keys[i] = entry.GroupKey
+	}
+	return keys
+}
+
+// TestBatchLogGroupByBranchMerge verifies each merged branch becomes one
+// group and mainline commits fall back to day groups.
+func TestBatchLogGroupByBranchMerge(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	runGit(t, dir, "checkout", "-q", "-b", "feature/auth")
+	writeAndCommit(t, dir, "auth.go", "package main\n", "feat: auth")
+	writeAndCommit(t, dir, "auth_test.go", "package main\n", "test: auth")
+	runGit(t, dir, "checkout", "-q", "-")
+	runGit(t, dir, "merge", "-q", "--no-ff", "feature/auth", "-m", "Merge branch 'feature/auth'")
+
+	keys := batchGroupKeys(t, dir, "--group-by", "branch-merge")
+
+	if len(keys) != 2 || !slices.Contains(keys, "feature/auth") {
+		t.Errorf("group keys = %v, want feature/auth and one day", keys)
+	}
+}
+
+// TestBatchLogGroupByPathPrefix verifies commits group by the directories
+// they touch.
+func TestBatchLogGroupByPathPrefix(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, "internal", "ledger"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeAndCommit(t, dir, "internal/ledger/entry.go", "package ledger\n", "feat: entry")
+
+	// The root commit is left out: diff-tree lists no files for it.
+	keys := batchGroupKeys(t, dir, "--group-by", "path-prefix", "--range", "HEAD~2..HEAD")
+
+	if !slices.Equal(keys, []string{"internal/ledger", rootPathGroup}) {
+		t.Errorf("group keys = %v, want [internal/ledger %s]", keys, rootPathGroup)
+	}
+}
+
+// TestBatchLogGroupByFromConfig verifies [batch] group_by picks the
+// strategy when --group-by is not given.
+func TestBatchLogGroupByFromConfig(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, ".timbers"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeAndCommit(t, dir, ".timbers/config.toml", "[batch]\ngroup_by = \"author\"\n", "chore: config")
+
+	keys := batchGroupKeys(t, dir)
+
+	if !slices.Equal(keys, []string{"test@test.com"}) {
+		t.Errorf("group keys = %v, want [test@test.com]", keys)
+	}
+}
+
+func TestLogGroupByRejectsBadUse(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--batch", "--group-by", "team"}, `invalid --group-by "team"`},
+		{[]string{"Work", "--why", "w", "--how", "h", "--group-by", "author"}, "--group-by requires --batch"},
+	}
+	for _, tt := range tests {
+		out, err := runLogCmd(t, dir, tt.args...)
+		if err == nil || !strings.Contains(out+err.Error(), tt.want) {
+			t.Errorf("log %v: err = %v, want %q\noutput: %s", tt.args, err, tt.want, out)
+		}
+	}
+}
@@ -159,7 +159,7 @@ func TestGroupCommits_FallbackToDay(t *testing.T) {
 		{SHA: "bbb222", Short: "bbb222", Subject: "Commit 2", Body: "Also no trailer", Date: day1},
 	}
 
-	groups := groupCommits(commits)
+	groups := groupCommitsByStrategy(commits, GroupStrategyAuto)
 
 	if len(groups) != 1 {
 		t.Fatalf("expected 1 group (by day), got %d", len(groups))
@@ -178,7 +178,7 @@ func TestGroupCommits_UsesTrailerWhenPresent(t *testing.T) {
 		{SHA: "bbb222", Short: "bbb222", Subject: "Commit 2", Body: "No trailer", Date: day1},
 	}
 
-	groups := groupCommits(commits)
+	groups := groupCommitsByStrategy(commits, GroupStrategyAuto)
 
 	// Should use trailer grouping since at least one commit has a trailer
 	groupMap := make(map[string]int)
@@ -438,6 +438,9 @@ func TestBatchLog_JSONDryRun(t *testing.T) {
 	if result.Status != "dry_run" {
 		t.Errorf("expected status 'dry_run', got %q", result.Status)
 	}
+	if len(result.PlannedActions) != result.Count {
+		t.Errorf("expected one planned action per entry, got %d for %d entries", len(result.PlannedActions), result.Count)
+	}
 }
 
 func TestBatchLog_NoPendingCommits(t *testing.T) {
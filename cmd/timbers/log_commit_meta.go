@@ -0,0 +1,91 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strings"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// batchHarvest is the per-commit git data a batch needs beyond what the log
+// query returned, loaded for every commit up front in a fixed number of git
+// processes rather than once per commit or group.
+type batchHarvest struct {
+	sigs            map[string]git.Signature // signature status by SHA; nil when unavailable
+	firstParentLine map[string]bool          // SHAs on HEAD's first-parent line
+	trailerMap      map[string]string        // [trailers] config, lowercased keys
+	trailers        map[string][]git.Trailer // trailers by SHA; nil when none are mapped
+	inference       logInferenceRules        // [tags.paths] and [log.branch_work_items] config
+}
+
+// harvestBatch loads the signatures of commits, their configured trailers,
+// the inference rules, and HEAD's first-parent line. Only a signature
+// failure under --require-signed or an invalid [trailers], [tags.paths], or
+// [log.branch_work_items] config is an error; a first-parent line that cannot be read leaves anchors at each group's
+// newest commit.
+func harvestBatch(commits []git.Commit, requireSigned bool) (*batchHarvest, error) {
+	sigs, err := resolveSignatures(commits, requireSigned)
+	if err != nil {
+		return nil, err
+	}
+	trailerMap, err := projectTrailerMap()
+	if err != nil {
+		return nil, err
+	}
+	inference, err := loadLogInferenceRules()
+	if err != nil {
+		return nil, err
+	}
+	harvest := &batchHarvest{
+		sigs: sigs, trailerMap: trailerMap, trailers: loadCommitTrailers(commits, trailerMap), inference: inference,
+	}
+	if head, headErr := git.HEAD(); headErr == nil {
+		harvest.firstParentLine, _ = git.FirstParentLine(head)
+	}
+	return harvest, nil
+}
+
+// harvestLogCommits reads what a single entry records about its commits
+// beyond the log query: their signatures and configured trailers.
+func harvestLogCommits(commits []git.Commit, requireSigned bool) (map[string]git.Signature, trailerFields, error) {
+	sigs, err := resolveSignatures(commits, requireSigned)
+	if err != nil {
+		return nil, trailerFields{}, err
+	}
+	mapping, err := projectTrailerMap()
+	if err != nil {
+		return nil, trailerFields{}, err
+	}
+	return sigs, mapTrailers(mapping, commits, loadCommitTrailers(commits, mapping)), nil
+}
+
+// resolveSignatures reads the signature status of commits. Without
+// --require-signed a failed lookup is not fatal: the entry is written without
+// signature data. With it, a failed lookup or any commit lacking a valid
+// signature refuses the entry, listing the offending short SHAs.
+func resolveSignatures(commits []git.Commit, requireSigned bool) (map[string]git.Signature, error) {
+	sigs, err := git.CommitSignatures(extractCommitSHAs(commits))
+	if err != nil {
+		if requireSigned {
+			return nil, err
+		}
+		return nil, nil //nolint:nilnil // no signature data is a valid, recordable outcome
+	}
+	if !requireSigned {
+		return sigs, nil
+	}
+
+	var unsigned []string
+	for _, commit := range commits {
+		if !sigs[commit.SHA].Valid() {
+			unsigned = append(unsigned, commit.Short)
+		}
+	}
+	if len(unsigned) > 0 {
+		return nil, output.NewUserError(
+			"--require-signed: commits without a valid signature: " + strings.Join(unsigned, ", ") +
+				"; sign them (git commit --amend -S, or git rebase --exec 'git commit --amend --no-edit -S') and retry")
+	}
+	return sigs, nil
+}
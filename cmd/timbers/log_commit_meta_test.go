@@ -0,0 +1,81 @@
+package main
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+func TestLogRecordsSignatureStatus(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+
+	out, err := runLogCmd(t, dir, "unsigned work", "--why", "y", "--how", "z")
+	if err != nil {
+		t.Fatalf("timbers log errored: %v\noutput: %s", err, out)
+	}
+
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if len(entry.Workset.CommitMeta) != len(entry.Workset.Commits) {
+		t.Fatalf("commit_meta has %d items, want one per commit (%d)",
+			len(entry.Workset.CommitMeta), len(entry.Workset.Commits))
+	}
+	for _, meta := range entry.Workset.CommitMeta {
+		if meta.Signature == nil || meta.Signature.Status != "unsigned" {
+			t.Errorf("commit %s signature = %+v, want status unsigned", meta.SHA, meta.Signature)
+		}
+	}
+	if got := formatSignatures(&entry.Workset); got != "0/2 signed" {
+		t.Errorf("formatSignatures() = %q, want %q", got, "0/2 signed")
+	}
+}
+
+func TestLogRecordsCommitAuthorship(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	headSHA := strings.TrimSpace(runGitOutput(t, dir, "rev-parse", "HEAD"))
+	authored := strings.TrimSpace(runGitOutput(t, dir, "log", "-1", "--format=%at"))
+
+	out, err := runLogCmd(t, dir, "attributed work", "--why", "y", "--how", "z")
+	if err != nil {
+		t.Fatalf("timbers log errored: %v\noutput: %s", err, out)
+	}
+
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	var head *ledger.CommitMeta
+	for i := range entry.Workset.CommitMeta {
+		if entry.Workset.CommitMeta[i].SHA == headSHA {
+			head = &entry.Workset.CommitMeta[i]
+		}
+	}
+	if head == nil {
+		t.Fatalf("commit_meta missing HEAD %s: %+v", headSHA, entry.Workset.CommitMeta)
+	}
+	if head.Author != "Test User" || head.AuthorEmail != "test@test.com" {
+		t.Errorf("author = %q <%s>, want Test User <test@test.com>", head.Author, head.AuthorEmail)
+	}
+	if head.AuthoredAt == nil || strconv.FormatInt(head.AuthoredAt.Unix(), 10) != authored {
+		t.Errorf("authored_at = %v, want unix %s", head.AuthoredAt, authored)
+	}
+	if head.CommittedAt == nil || head.CommittedAt.Location() != time.UTC {
+		t.Errorf("committed_at = %v, want a UTC timestamp", head.CommittedAt)
+	}
+}
+
+func TestLogRequireSignedRejectsUnsignedCommits(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	short := strings.TrimSpace(runGitOutput(t, dir, "rev-parse", "--short", "HEAD"))
+
+	out, err := runLogCmd(t, dir, "unsigned work", "--why", "y", "--how", "z", "--require-signed")
+	if err == nil {
+		t.Fatalf("expected --require-signed to refuse unsigned commits\noutput: %s", out)
+	}
+	if !strings.Contains(out, short) {
+		t.Errorf("error should list unsigned commit %s, got: %s", short, out)
+	}
+	if countJSONFilesInDir(filepath.Join(dir, ".timbers")) != 0 {
+		t.Error("no entry should be written when --require-signed fails")
+	}
+}
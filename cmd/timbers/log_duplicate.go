@@ -0,0 +1,47 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"fmt"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// checkDuplicateWork compares the entry about to be written with existing
+// entries. Hook-driven and manual logging often document the same commits
+// twice; shared commits block the write unless --force, while a similar
+// summary alone only warns. Dry runs warn instead of blocking. The check is
+// best-effort: a ledger that cannot be listed does not stop the write.
+func checkDuplicateWork(storage *ledger.Storage, entry *ledger.Entry, flags logFlags, printer output.Reporter) error {
+	existing, err := storage.ListEntries()
+	if err != nil {
+		return nil //nolint:nilerr // duplicate detection must never block logging on read errors
+	}
+
+	for _, dup := range ledger.FindDuplicates(entry, existing) {
+		if len(dup.SharedCommits) == 0 {
+			printer.Warning("%s has a similar summary (%q); check this is not the same work",
+				dup.Entry.ID, dup.Entry.Summary.What)
+			continue
+		}
+		if flags.force || flags.dryRun {
+			printer.Warning("%s already covers %s", dup.Entry.ID, describeSharedCommits(dup.SharedCommits))
+			continue
+		}
+		err := output.NewConflictError(fmt.Sprintf(
+			"%s already covers %s; amend that entry instead, or re-run with --force to log anyway",
+			dup.Entry.ID, describeSharedCommits(dup.SharedCommits))).WithID(output.ErrCodeDuplicate)
+		printer.Error(err)
+		return err
+	}
+	return nil
+}
+
+// describeSharedCommits renders shared commits for a message.
+func describeSharedCommits(shas []string) string {
+	if len(shas) == 1 {
+		return "commit " + shortSHA(shas[0])
+	}
+	return fmt.Sprintf("%d of these commits (%s, ...)", len(shas), shortSHA(shas[0]))
+}
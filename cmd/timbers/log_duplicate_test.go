@@ -0,0 +1,38 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// TestLogRefusesDuplicateCommits covers duplicate-work detection: a second
+// entry for commits another entry already covers is refused with a conflict
+// unless --force is given.
+func TestLogRefusesDuplicateCommits(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+
+	if out, err := runLogCmd(t, dir, "First pass", "--why", "Seed the repo", "--how", "Initial files"); err != nil {
+		t.Fatalf("first log failed: %v\n%s", err, out)
+	}
+
+	out, err := runLogCmd(t, dir, "Second pass", "--why", "Hook logged it too", "--how", "Same commits",
+		"--range", "HEAD~2..HEAD~1", "--anchor", "HEAD~2")
+	if err == nil || output.GetExitCode(err) != output.ExitConflict || !strings.Contains(out, "--force") {
+		t.Fatalf("duplicate log: err=%v (code %d), want conflict mentioning --force\n%s", err, output.GetExitCode(err), out)
+	}
+	if got := countJSONFilesInDir(filepath.Join(dir, ".timbers")); got != 1 {
+		t.Fatalf("duplicate log wrote an entry: %d entries, want 1", got)
+	}
+
+	out, err = runLogCmd(t, dir, "Second pass", "--why", "Hook logged it too", "--how", "Same commits",
+		"--range", "HEAD~2..HEAD~1", "--anchor", "HEAD~2", "--force")
+	if err != nil {
+		t.Fatalf("log --force failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "already covers") {
+		t.Errorf("log --force output missing overlap warning:\n%s", out)
+	}
+}
@@ -0,0 +1,86 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strconv"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// checkEditFlags rejects flags that choose the entry's content some other
+// way than the editor.
+func checkEditFlags(flags logFlags, jsonMode bool) error {
+	if !flags.edit {
+		return nil
+	}
+	switch {
+	case flags.batch:
+		return output.NewUserError("--edit cannot be combined with --batch")
+	case flags.auto:
+		return output.NewUserError("--edit cannot be combined with --auto; the editor already starts from the commit messages")
+	case flags.interactive:
+		return output.NewUserError("--edit cannot be combined with -i")
+	case jsonMode:
+		return output.NewUserError("--edit opens an editor and cannot be combined with --json")
+	}
+	return nil
+}
+
+// editLogContent opens the entry's what, why, how, and notes in the editor,
+// starting from any values given as args or flags and otherwise from the
+// commit messages. It returns the args and flags to log the edit with;
+// resolveLogContent validates them like any other input.
+func editLogContent(commits []git.Commit, args []string, flags logFlags) ([]string, logFlags, error) {
+	if len(commits) == 0 {
+		return args, flags, nil
+	}
+	what, why, how := extractAutoContent(commits)
+	summary := ledger.Summary{
+		What: editDefault(firstArg(args), what),
+		Why:  editDefault(flags.why, why),
+		How:  editDefault(flags.how, how),
+	}
+
+	edited, err := editText(formatSummaryTemplate(logEditHeader(commits), summary, flags.notes))
+	if err != nil {
+		return nil, flags, err
+	}
+	summary, flags.notes, err = parseSummaryTemplate(edited)
+	if err != nil {
+		return nil, flags, err
+	}
+	if summary.What == "" {
+		return nil, flags, output.NewUserError("log aborted: the edited entry has no what")
+	}
+	flags.why, flags.how = summary.Why, summary.How
+	return []string{summary.What}, flags, nil
+}
+
+// logEditHeader lists the commits being documented, for the editor's
+// comment header.
+func logEditHeader(commits []git.Commit) string {
+	header := "Logging " + strconv.Itoa(len(commits)) + " commit(s):"
+	for _, commit := range commits {
+		header += "\n  " + commit.Short + " " + commit.Subject
+	}
+	return header
+}
+
+// editDefault returns given when set, else extracted unless it is only the
+// auto placeholder.
+func editDefault(given, extracted string) string {
+	if given != "" || extracted == ledger.AutoPlaceholder {
+		return given
+	}
+	return extracted
+}
+
+// firstArg returns args[0], or "" when there are no args.
+func firstArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useFakeEditor points GIT_EDITOR at a script that saves saved over the file
+// being edited. It returns the path where the script keeps the text it was
+// given.
+func useFakeEditor(t *testing.T, saved string) string {
+	t.Helper()
+	dir := t.TempDir()
+	savedPath := filepath.Join(dir, "saved.md")
+	givenPath := filepath.Join(dir, "given.md")
+	script := filepath.Join(dir, "editor.sh")
+	if err := os.WriteFile(savedPath, []byte(saved), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	body := "#!/bin/sh\ncp \"$1\" '" + givenPath + "'\ncp '" + savedPath + "' \"$1\"\n"
+	if err := os.WriteFile(script, []byte(body), 0o700); err != nil { //nolint:gosec // test editor must be executable
+		t.Fatal(err)
+	}
+	t.Setenv("GIT_EDITOR", script)
+	return givenPath
+}
+
+// TestLogEditWritesEditedEntry verifies the editor starts from the commit
+// messages and its saved sections become the entry.
+func TestLogEditWritesEditedEntry(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	given := useFakeEditor(t, "# comment\n\n## What\nEdited what\n\n## Why\nbecause\n\n## How\nlike so\n\n## Notes\nweighed options\n")
+
+	out, err := runLogCmd(t, dir, "--edit", "--why", "flag why")
+	if err != nil {
+		t.Fatalf("timbers log --edit errored: %v\noutput: %s", err, out)
+	}
+	template, err := os.ReadFile(given) //nolint:gosec // test temp file
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## What\nfeat: work; initial\n", "## Why\nflag why\n", "# Logging 2 commit(s):"} {
+		if !strings.Contains(string(template), want) {
+			t.Errorf("template missing %q:\n%s", want, template)
+		}
+	}
+
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if entry.Summary.What != "Edited what" || entry.Summary.Why != "because" || entry.Summary.How != "like so" {
+		t.Errorf("summary = %+v", entry.Summary)
+	}
+	if entry.Notes != "weighed options" {
+		t.Errorf("notes = %q", entry.Notes)
+	}
+}
+
+// TestLogEditValidatesEditedEntry verifies an edit missing a required field
+// or the what writes nothing.
+func TestLogEditValidatesEditedEntry(t *testing.T) {
+	for name, saved := range map[string]string{
+		"no how":  "## What\nEdited\n## Why\nbecause\n## How\n\n",
+		"no what": "## What\n\n## Why\nbecause\n## How\nso\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := newLogAnchorRepo(t)
+			useFakeEditor(t, saved)
+			if out, err := runLogCmd(t, dir, "--edit"); err == nil {
+				t.Fatalf("expected an error; output: %s", out)
+			}
+			if countJSONFilesInDir(filepath.Join(dir, ".timbers")) != 0 {
+				t.Error("no entry should be written for an invalid edit")
+			}
+		})
+	}
+}
+
+// TestLogEditRejectsBatch verifies --edit refuses flags that choose the
+// entry's content another way.
+func TestLogEditRejectsBatch(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	if out, err := runLogCmd(t, dir, "--edit", "--batch"); err == nil {
+		t.Fatalf("expected --edit --batch to fail; output: %s", out)
+	}
+}
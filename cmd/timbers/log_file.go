@@ -0,0 +1,125 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// entryFile is a --from-file draft: optional YAML front matter, then
+// "## What", "## Why", "## How", and "## Notes" sections.
+type entryFile struct {
+	What      string   `yaml:"what"`
+	Why       string   `yaml:"why"`
+	How       string   `yaml:"how"`
+	Notes     string   `yaml:"notes"`
+	Kind      string   `yaml:"kind"`
+	Tags      []string `yaml:"tags"`
+	WorkItems []string `yaml:"work_items"`
+}
+
+// applyEntryFile reads the --from-file draft and fills in the args and
+// flags the command line left unset. It runs before parseValues, like
+// applyStdinEntry.
+func (flags *logFlags) applyEntryFile(args []string) ([]string, error) {
+	if flags.fromFile == "" {
+		return args, nil
+	}
+	switch {
+	case flags.batch:
+		return nil, output.NewUserError("--from-file cannot be combined with --batch")
+	case flags.interactive, flags.edit, flags.stdin:
+		return nil, output.NewUserError("--from-file cannot be combined with -i, --edit, or --stdin")
+	}
+
+	data, err := os.ReadFile(flags.fromFile)
+	if err != nil {
+		return nil, output.NewUserError("cannot read --from-file: " + err.Error())
+	}
+	draft, err := parseEntryFile(string(data))
+	if err != nil {
+		return nil, output.NewUserError("--from-file " + flags.fromFile + ": " + err.Error())
+	}
+
+	if len(args) == 0 && draft.What != "" {
+		args = []string{draft.What}
+	}
+	setIfEmpty(&flags.why, draft.Why)
+	setIfEmpty(&flags.how, draft.How)
+	setIfEmpty(&flags.notes, draft.Notes)
+	if flags.kind == "" || flags.kind == ledger.KindEntry {
+		setIfEmpty(&flags.kind, draft.Kind)
+	}
+	if len(flags.tags) == 0 {
+		flags.tags = draft.Tags
+	}
+	if len(flags.workItems) == 0 {
+		flags.workItems = draft.WorkItems
+	}
+	return args, nil
+}
+
+// parseEntryFile parses a draft. Sections override the same field in the
+// front matter, and a "# Title" line before the first section is the what
+// when neither sets one.
+func parseEntryFile(text string) (entryFile, error) {
+	var draft entryFile
+	front, body := splitEntryFrontmatter(text)
+	if front != "" {
+		decoder := yaml.NewDecoder(strings.NewReader(front))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&draft); err != nil && !errors.Is(err, io.EOF) {
+			return entryFile{}, errors.New("invalid front matter: " + err.Error())
+		}
+	}
+
+	summary, notes, err := parseSummaryTemplate(body)
+	if err != nil {
+		return entryFile{}, err
+	}
+	setIfEmpty(&summary.What, markdownTitle(body))
+	for _, section := range []struct{ value, field *string }{
+		{&summary.What, &draft.What}, {&summary.Why, &draft.Why}, {&summary.How, &draft.How}, {&notes, &draft.Notes},
+	} {
+		if *section.value != "" {
+			*section.field = *section.value
+		}
+	}
+	draft.What, draft.Why, draft.How = strings.TrimSpace(draft.What), strings.TrimSpace(draft.Why), strings.TrimSpace(draft.How)
+	return draft, nil
+}
+
+// splitEntryFrontmatter separates front matter between leading "---" lines
+// from the rest of text.
+func splitEntryFrontmatter(text string) (string, string) {
+	rest, ok := strings.CutPrefix(text, "---\n")
+	if !ok {
+		return "", text
+	}
+	front, body, ok := strings.Cut(rest, "\n---")
+	if !ok {
+		return "", text
+	}
+	return front, body
+}
+
+// markdownTitle returns the text of the first "# " heading before any "## "
+// section, or "".
+func markdownTitle(body string) string {
+	for line := range strings.SplitSeq(body, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			return ""
+		}
+		if title, ok := strings.CutPrefix(line, "# "); ok {
+			return strings.TrimSpace(title)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParseEntryFile(t *testing.T) {
+	draft, err := parseEntryFile("---\nwhy: front why\nkind: decision\ntags: [auth, api]\nwork_items: [jira:PROJ-1]\n---\n" +
+		"# Fix session expiry\n\nIntro text is ignored.\n\n## How\nRefresh early.\n\n## Notes\nConsidered longer TTLs.\n")
+	if err != nil {
+		t.Fatalf("parseEntryFile: %v", err)
+	}
+	want := entryFile{
+		What: "Fix session expiry", Why: "front why", How: "Refresh early.", Notes: "Considered longer TTLs.",
+		Kind: "decision", Tags: []string{"auth", "api"}, WorkItems: []string{"jira:PROJ-1"},
+	}
+	if draft.What != want.What || draft.Why != want.Why || draft.How != want.How || draft.Notes != want.Notes ||
+		draft.Kind != want.Kind || !slices.Equal(draft.Tags, want.Tags) || !slices.Equal(draft.WorkItems, want.WorkItems) {
+		t.Errorf("draft = %+v, want %+v", draft, want)
+	}
+
+	sectionWins, err := parseEntryFile("---\nwhat: front\n---\n# Title\n## What\nSection what\n")
+	if err != nil || sectionWins.What != "Section what" {
+		t.Errorf("What section should win over front matter and title; got %+v (%v)", sectionWins, err)
+	}
+
+	for _, bad := range []string{"---\nwat: 1\n---\n", "## Who\nme\n"} {
+		if _, err := parseEntryFile(bad); err == nil {
+			t.Errorf("parseEntryFile(%q) should fail", bad)
+		}
+	}
+}
+
+// TestLogFromFileWritesEntry verifies a draft becomes the entry, with flags
+// winning over it.
+func TestLogFromFileWritesEntry(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	draftPath := filepath.Join(t.TempDir(), "notes.md")
+	draft := "---\ntags: [docs]\n---\n# Drafted entry\n\n## Why\nDraft why\n\n## How\nDraft how\n"
+	if err := os.WriteFile(draftPath, []byte(draft), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runLogCmd(t, dir, "--from-file", draftPath, "--how", "Flag how")
+	if err != nil {
+		t.Fatalf("timbers log --from-file errored: %v\noutput: %s", err, out)
+	}
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if entry.Summary.What != "Drafted entry" || entry.Summary.Why != "Draft why" || entry.Summary.How != "Flag how" {
+		t.Errorf("summary = %+v", entry.Summary)
+	}
+	if !slices.Equal(entry.Tags, []string{"docs"}) {
+		t.Errorf("tags = %v, want [docs]", entry.Tags)
+	}
+}
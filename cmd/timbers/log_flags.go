@@ -1,7 +1,55 @@
 // Package main provides the entry point for the timbers CLI.
 package main
 
-import "github.com/spf13/cobra"
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// logFlags holds all flag values for the log command.
+type logFlags struct {
+	why       string
+	how       string
+	notes     string
+	tags      []string
+	workItems []string
+	metaPairs []string
+	who       []string
+	coAuthors []string
+	rangeStr  string
+	anchor    string
+	minor     bool
+	dryRun    bool
+	push      bool
+	auto      bool
+	yes       bool
+	batch     bool
+	groupBy   string
+	kind      string
+	force     bool
+	numstat   bool
+	encrypt   bool
+	started   string
+	fromFile  string
+
+	requireSigned bool
+	interactive   bool
+	edit          bool
+	stdin         bool
+	alternatives  []string // --alternative, folded into decision by parseValues
+
+	meta      map[string]string // parsed from metaPairs by parseValues
+	startedAt *time.Time        // parsed from started by parseValues; earliest author date when unset
+	form      *logForm          // set by runLog for -i
+
+	// decision and relations carry ADR fields set by `timbers decide`;
+	// log sets only decision.alternatives, from --alternative.
+	decision  *ledger.Decision
+	relations []ledger.Relation
+}
 
 // logFlagVars holds the flag variable pointers for the log command.
 type logFlagVars struct {
@@ -10,7 +58,9 @@ type logFlagVars struct {
 	notes     *string
 	tags      *[]string
 	workItems *[]string
+	metaPairs *[]string
 	who       *[]string
+	coAuthors *[]string
 	rangeStr  *string
 	anchor    *string
 	minor     *bool
@@ -19,6 +69,19 @@ type logFlagVars struct {
 	auto      *bool
 	yes       *bool
 	batch     *bool
+	groupBy   *string
+	kind      *string
+	force     *bool
+	numstat   *bool
+	encrypt   *bool
+	started   *string
+	fromFile  *string
+
+	requireSigned *bool
+	interactive   *bool
+	edit          *bool
+	stdin         *bool
+	alternatives  *[]string
 }
 
 // toLogFlags converts flag vars to a logFlags struct.
@@ -29,7 +92,9 @@ func (vars *logFlagVars) toLogFlags() logFlags {
 		notes:     *vars.notes,
 		tags:      *vars.tags,
 		workItems: *vars.workItems,
+		metaPairs: *vars.metaPairs,
 		who:       *vars.who,
+		coAuthors: *vars.coAuthors,
 		rangeStr:  *vars.rangeStr,
 		anchor:    *vars.anchor,
 		minor:     *vars.minor,
@@ -38,6 +103,19 @@ func (vars *logFlagVars) toLogFlags() logFlags {
 		auto:      *vars.auto,
 		yes:       *vars.yes,
 		batch:     *vars.batch,
+		groupBy:   *vars.groupBy,
+		kind:      *vars.kind,
+		force:     *vars.force,
+		numstat:   *vars.numstat,
+		encrypt:   *vars.encrypt,
+		started:   *vars.started,
+		fromFile:  *vars.fromFile,
+
+		requireSigned: *vars.requireSigned,
+		interactive:   *vars.interactive,
+		edit:          *vars.edit,
+		stdin:         *vars.stdin,
+		alternatives:  *vars.alternatives,
 	}
 }
 
@@ -49,7 +127,9 @@ func newLogFlagVars() *logFlagVars {
 		notes:     new(string),
 		tags:      new([]string),
 		workItems: new([]string),
+		metaPairs: new([]string),
 		who:       new([]string),
+		coAuthors: new([]string),
 		rangeStr:  new(string),
 		anchor:    new(string),
 		minor:     new(bool),
@@ -58,6 +138,19 @@ func newLogFlagVars() *logFlagVars {
 		auto:      new(bool),
 		yes:       new(bool),
 		batch:     new(bool),
+		groupBy:   new(string),
+		kind:      new(string),
+		force:     new(bool),
+		numstat:   new(bool),
+		encrypt:   new(bool),
+		started:   new(string),
+		fromFile:  new(string),
+
+		requireSigned: new(bool),
+		interactive:   new(bool),
+		edit:          new(bool),
+		stdin:         new(bool),
+		alternatives:  new([]string),
 	}
 }
 
@@ -67,14 +160,31 @@ func registerLogFlags(cmd *cobra.Command, flagVars *logFlagVars) {
 	cmd.Flags().StringVar(flagVars.how, "how", "", "How this change was implemented (required unless --minor or --auto)")
 	cmd.Flags().StringArrayVar(flagVars.tags, "tag", nil, "Tags for categorization (repeatable)")
 	cmd.Flags().StringArrayVar(flagVars.workItems, "work-item", nil, "Work item reference as system:id (repeatable)")
+	cmd.Flags().StringArrayVar(flagVars.metaPairs, "meta", nil, "Custom field as key=value, e.g. service=api (repeatable)")
+	cmd.Flags().BoolVar(flagVars.encrypt, "encrypt", false, "Encrypt summary and notes to the [encryption] recipients")
 	cmd.Flags().StringArrayVar(flagVars.who, "who", nil, "Replace contributors with Name <email> (repeatable)")
+	cmd.Flags().StringArrayVar(flagVars.coAuthors, "co-author", nil,
+		"Add a contributor as Name <email>, keeping the automatic set (repeatable)")
 	cmd.Flags().StringVar(flagVars.rangeStr, "range", "", "Explicit commit range (e.g., abc123..def456)")
 	cmd.Flags().StringVar(flagVars.anchor, "anchor", "", "Override anchor commit (default: HEAD)")
 	cmd.Flags().BoolVar(flagVars.minor, "minor", false, "Trivial change - makes why/how optional")
 	cmd.Flags().BoolVar(flagVars.dryRun, "dry-run", false, "Show what would be written without writing")
-	cmd.Flags().BoolVar(flagVars.push, "push", false, "Push to remote after writing")
+	cmd.Flags().BoolVar(flagVars.push, "push", false, "Push the current branch after writing (default from [log] push)")
 	cmd.Flags().BoolVar(flagVars.auto, "auto", false, "Extract what/why/how from commit messages")
+	cmd.Flags().BoolVarP(flagVars.interactive, "interactive", "i", false, "Fill in the entry with prompts, starting from a commit checklist")
+	cmd.Flags().BoolVar(flagVars.edit, "edit", false, "Compose what/why/how/notes in $EDITOR, starting from the commit messages")
+	cmd.Flags().BoolVar(flagVars.stdin, "stdin", false, "Read the entry (complete or partial) as JSON from stdin")
+	cmd.Flags().StringVar(flagVars.fromFile, "from-file", "", "Read what/why/how/notes/tags from a markdown draft with optional front matter")
 	cmd.Flags().BoolVar(flagVars.yes, "yes", false, "Skip confirmation in auto mode")
 	cmd.Flags().StringVar(flagVars.notes, "notes", "", "Deliberation notes capturing the journey to a decision")
-	cmd.Flags().BoolVar(flagVars.batch, "batch", false, "Create entries grouped by work-item trailer or day")
+	cmd.Flags().BoolVar(flagVars.batch, "batch", false, "Create entries grouped by work-item trailer or day, or by --group-by")
+	cmd.Flags().StringVar(flagVars.groupBy, "group-by", "",
+		"Batch grouping: auto, work-item, day, author, path-prefix, or branch-merge (default from [batch] group_by)")
+	cmd.Flags().StringVar(flagVars.kind, "kind", ledger.KindEntry, "Record kind: entry, decision, incident, milestone, or note")
+	cmd.Flags().StringArrayVar(flagVars.alternatives, "alternative", nil, "Option considered and rejected, for --kind decision (repeatable)")
+	cmd.Flags().BoolVar(flagVars.force, "force", false, "Write even if another entry already covers these commits")
+	cmd.Flags().StringVar(flagVars.started, "started", "", "When the work began, as a date or age (default: earliest commit's author date)")
+	cmd.Flags().BoolVar(flagVars.numstat, "numstat", false, "Record per-file insertions/deletions in the workset")
+	cmd.Flags().BoolVar(flagVars.requireSigned, "require-signed", false, "Refuse unless every commit has a valid GPG/SSH signature")
+	registerFirstParentFlag(cmd)
 }
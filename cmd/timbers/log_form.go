@@ -0,0 +1,227 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// logForm asks for an entry's fields on the terminal, for log -i. What it
+// collects becomes the same args, flags, and commits the flag path uses.
+type logForm struct {
+	printer *output.Printer
+	reader  *bufio.Reader
+}
+
+// attachForm sets up the form for -i, which asks on printer and reads
+// answers from input. It fails when other flags choose the entry's content
+// some other way.
+func (flags *logFlags) attachForm(printer *output.Printer, input io.Reader) error {
+	if !flags.interactive {
+		return nil
+	}
+	if err := checkInteractiveFlags(*flags, printer.IsJSON()); err != nil {
+		return err
+	}
+	flags.form = &logForm{printer: printer, reader: bufio.NewReader(input)}
+	return nil
+}
+
+// checkInteractiveFlags rejects flags that choose content or output some
+// other way than the form.
+func checkInteractiveFlags(flags logFlags, jsonMode bool) error {
+	switch {
+	case flags.batch:
+		return output.NewUserError("-i cannot be combined with --batch")
+	case flags.auto:
+		return output.NewUserError("-i cannot be combined with --auto; the form already offers the commit subjects")
+	case jsonMode:
+		return output.NewUserError("-i is interactive and cannot be combined with --json")
+	}
+	return nil
+}
+
+// fill shows the pending commits as a checklist and asks for what, why,
+// how, tags, and work items, starting from any values given as flags. It
+// returns the checked commits and the args and flags to log them with.
+func (form *logForm) fill(commits []git.Commit, args []string, flags logFlags) ([]git.Commit, []string, logFlags, error) {
+	if len(commits) == 0 {
+		return commits, args, flags, nil
+	}
+	selected, err := form.chooseCommits(commits)
+	if err != nil {
+		return nil, nil, flags, err
+	}
+	what := extractWhat(selected)
+	if len(args) > 0 {
+		what = args[0]
+	}
+	if what, flags, err = form.askSummary(what, flags); err != nil {
+		return nil, nil, flags, err
+	}
+	if flags.tags, err = form.askList("Tags", flags.tags); err != nil {
+		return nil, nil, flags, err
+	}
+	if flags.workItems, err = form.askWorkItems(flags.workItems); err != nil {
+		return nil, nil, flags, err
+	}
+	return selected, []string{what}, flags, nil
+}
+
+// askSummary asks for what, why, and how. Why and how are required when the
+// entry's kind requires them, unless --minor was given.
+func (form *logForm) askSummary(what string, flags logFlags) (string, logFlags, error) {
+	what, err := form.ask("What", what, true)
+	if err != nil {
+		return "", flags, err
+	}
+	kind := flags.entryKind()
+	if flags.why, err = form.ask("Why", flags.why, ledger.RequiresWhy(kind) && !flags.minor); err != nil {
+		return "", flags, err
+	}
+	if flags.how, err = form.ask("How", flags.how, ledger.RequiresHow(kind) && !flags.minor); err != nil {
+		return "", flags, err
+	}
+	return what, flags, nil
+}
+
+// askWorkItems asks for work items until every one parses as system:id.
+func (form *logForm) askWorkItems(current []string) ([]string, error) {
+	for {
+		items, err := form.askList("Work items (system:id)", current)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = parseWorkItems(items); err == nil {
+			return items, nil
+		}
+		form.printer.Println("  " + err.Error())
+	}
+}
+
+// chooseCommits lists commits, newest first, and returns the ones the user
+// checks; all of them when the answer is empty.
+func (form *logForm) chooseCommits(commits []git.Commit) ([]git.Commit, error) {
+	form.printer.Section("Pending commits")
+	for i, commit := range commits {
+		form.printer.Print("  [x] %2d  %s %s\n", i+1, commit.Short, commit.Subject)
+	}
+	for {
+		answer, err := form.readLine("Commits to document (e.g. 1,3-4) [all]: ")
+		if err != nil {
+			return nil, err
+		}
+		indexes, err := parseCommitSelection(answer, len(commits))
+		if err == nil && len(indexes) == 0 {
+			err = output.NewUserError("check at least one commit")
+		}
+		if err != nil {
+			form.printer.Println("  " + err.Error())
+			continue
+		}
+		selected := make([]git.Commit, 0, len(indexes))
+		for _, index := range indexes {
+			selected = append(selected, commits[index])
+		}
+		return selected, nil
+	}
+}
+
+// ask prompts for one field, offering current as the default. A required
+// field is asked again until it has a value.
+func (form *logForm) ask(label, current string, required bool) (string, error) {
+	prompt := label + ": "
+	if current != "" {
+		prompt = label + " [" + current + "]: "
+	}
+	for {
+		answer, err := form.readLine(prompt)
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = current
+		}
+		if answer != "" || !required {
+			return answer, nil
+		}
+		form.printer.Println("  " + label + " is required")
+	}
+}
+
+// askList prompts for a comma-separated list, offering current as the
+// default; "-" clears it.
+func (form *logForm) askList(label string, current []string) ([]string, error) {
+	answer, err := form.ask(label+" (comma-separated, - for none)", strings.Join(current, ", "), false)
+	if err != nil || answer == "-" {
+		return nil, err
+	}
+	var items []string
+	for item := range strings.SplitSeq(answer, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// readLine prints prompt and reads one trimmed line. Input that ends before
+// the form is complete aborts the entry.
+func (form *logForm) readLine(prompt string) (string, error) {
+	form.printer.Print("%s", prompt)
+	line, err := form.reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", output.NewUserError("input ended before the form was complete; nothing was logged")
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// parseCommitSelection parses a checklist answer such as "1,3-4" into
+// zero-based indexes in list order. An empty answer or "all" selects all
+// count commits.
+func parseCommitSelection(answer string, count int) ([]int, error) {
+	if answer == "" || strings.EqualFold(answer, "all") {
+		answer = "1-" + strconv.Itoa(count)
+	}
+	chosen := make([]bool, count)
+	for part := range strings.SplitSeq(answer, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		low, high, err := parseCommitRange(part, count)
+		if err != nil {
+			return nil, err
+		}
+		for i := low; i <= high; i++ {
+			chosen[i-1] = true
+		}
+	}
+	var indexes []int
+	for i, isChosen := range chosen {
+		if isChosen {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes, nil
+}
+
+// parseCommitRange parses one checklist item, "3" or "3-4", into the
+// one-based bounds it covers.
+func parseCommitRange(part string, count int) (int, int, error) {
+	first, last, isRange := strings.Cut(part, "-")
+	if !isRange {
+		last = first
+	}
+	low, lowErr := strconv.Atoi(strings.TrimSpace(first))
+	high, highErr := strconv.Atoi(strings.TrimSpace(last))
+	if lowErr != nil || highErr != nil || low < 1 || high > count || low > high {
+		return 0, 0, output.NewUserError("pick commits by number from 1 to " + strconv.Itoa(count) + ", like 1,3-4")
+	}
+	return low, high, nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// runLogFormCmd runs `timbers log -i` in dir, answering the form with input.
+func runLogFormCmd(t *testing.T, dir, input string, args ...string) (string, error) {
+	t.Helper()
+	var out strings.Builder
+	var execErr error
+	runInDir(t, dir, func() {
+		cmd := newRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetIn(strings.NewReader(input))
+		cmd.SetArgs(append([]string{"log", "-i"}, args...))
+		execErr = cmd.Execute()
+	})
+	return out.String(), execErr
+}
+
+// TestLogInteractiveWritesEntry verifies the form's answers become the entry,
+// with only the checked commits in its workset.
+func TestLogInteractiveWritesEntry(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	headSHA := strings.TrimSpace(runGitOutput(t, dir, "rev-parse", "HEAD"))
+
+	// Checklist, what, why (blank is asked again), how, tags, work items
+	// (malformed is asked again).
+	input := "1\nForm entry\n\nbecause\nlike so\nui, cli\nPROJ-1\njira:PROJ-1\n"
+	out, err := runLogFormCmd(t, dir, input)
+	if err != nil {
+		t.Fatalf("timbers log -i errored: %v\noutput: %s", err, out)
+	}
+	if !strings.Contains(out, "Why is required") {
+		t.Errorf("blank why should be asked again; output: %s", out)
+	}
+
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if entry.Summary.What != "Form entry" || entry.Summary.Why != "because" || entry.Summary.How != "like so" {
+		t.Errorf("summary = %+v", entry.Summary)
+	}
+	if !slices.Equal(entry.Tags, []string{"ui", "cli"}) {
+		t.Errorf("tags = %v, want [ui cli]", entry.Tags)
+	}
+	if len(entry.WorkItems) != 1 || entry.WorkItems[0].System != "jira" || entry.WorkItems[0].ID != "PROJ-1" {
+		t.Errorf("work items = %+v, want jira:PROJ-1", entry.WorkItems)
+	}
+	if !slices.Equal(entry.Workset.Commits, []string{headSHA}) {
+		t.Errorf("commits = %v, want only HEAD %s", entry.Workset.Commits, headSHA)
+	}
+}
+
+// TestLogInteractiveAbortsOnEOF verifies input that ends mid-form writes nothing.
+func TestLogInteractiveAbortsOnEOF(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	if out, err := runLogFormCmd(t, dir, "\nhalf done\n"); err == nil {
+		t.Fatalf("expected an error for incomplete input; output: %s", out)
+	}
+	if countJSONFilesInDir(filepath.Join(dir, ".timbers")) != 0 {
+		t.Error("no entry should be written when the form is not completed")
+	}
+}
+
+// TestLogInteractiveRejectsBatch verifies -i refuses flags that choose the
+// entry's content another way.
+func TestLogInteractiveRejectsBatch(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	if out, err := runLogFormCmd(t, dir, "", "--batch"); err == nil {
+		t.Fatalf("expected -i --batch to fail; output: %s", out)
+	}
+}
+
+func TestParseCommitSelection(t *testing.T) {
+	tests := []struct {
+		answer  string
+		want    []int
+		wantErr bool
+	}{
+		{answer: "", want: []int{0, 1, 2, 3}},
+		{answer: "all", want: []int{0, 1, 2, 3}},
+		{answer: "1", want: []int{0}},
+		{answer: "3-4, 1", want: []int{0, 2, 3}},
+		{answer: "2,2", want: []int{1}},
+		{answer: "0", wantErr: true},
+		{answer: "5", wantErr: true},
+		{answer: "3-2", wantErr: true},
+		{answer: "x", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseCommitSelection(tt.answer, 4)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCommitSelection(%q) error = %v, wantErr %v", tt.answer, err, tt.wantErr)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("parseCommitSelection(%q) = %v, want %v", tt.answer, got, tt.want)
+		}
+	}
+}
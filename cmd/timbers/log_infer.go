@@ -0,0 +1,81 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"slices"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// logInferenceRules are the config rules log uses to fill in fields from the
+// repository: [tags.paths] and [log.branch_work_items].
+type logInferenceRules struct {
+	pathTags        []ledger.PathTagRule
+	branchWorkItems []ledger.BranchWorkItemRule
+}
+
+// logInference is what log derived from the repository rather than from
+// flags, shown in the dry-run preview.
+type logInference struct {
+	tags     []string         // from the files the commits touch
+	workItem *ledger.WorkItem // from the branch name; nil when none matched
+	branch   string           // the branch workItem was found in
+}
+
+// loadLogInferenceRules reads the inference rules from the project config.
+// Malformed rules fail the log, like an invalid [trailers] target.
+func loadLogInferenceRules() (logInferenceRules, error) {
+	cfg := loadLogProject()
+	pathTags, err := ledger.ParsePathTagRules(cfg.Tags.Paths)
+	if err != nil {
+		return logInferenceRules{}, output.NewUserError("invalid [tags.paths] in " + config.ProjectFile + ": " + err.Error())
+	}
+	branchWorkItems, err := ledger.ParseBranchWorkItemRules(cfg.Log.BranchWorkItems)
+	if err != nil {
+		return logInferenceRules{}, output.NewUserError("invalid [log.branch_work_items] in " + config.ProjectFile + ": " + err.Error())
+	}
+	return logInferenceRules{pathTags: pathTags, branchWorkItems: branchWorkItems}, nil
+}
+
+// infer applies the rules to files and the current branch. The branch is
+// only searched for a work item when none was given with --work-item.
+func (rules logInferenceRules) infer(files, givenWorkItems []string) logInference {
+	inferred := logInference{tags: ledger.InferPathTags(rules.pathTags, files)}
+	if len(givenWorkItems) > 0 || len(rules.branchWorkItems) == 0 {
+		return inferred
+	}
+	branch := git.AttachedBranch()
+	if item, found := ledger.DetectBranchWorkItem(rules.branchWorkItems, branch); found {
+		inferred.workItem, inferred.branch = &item, branch
+	}
+	return inferred
+}
+
+// apply adds the inferred tags to entry, skipping ones it already has, and
+// the detected work item when the entry has none from flags or trailers.
+func (inferred logInference) apply(entry *ledger.Entry) {
+	if len(inferred.tags) > 0 {
+		// Batch entries share the --tag slice; never append into it.
+		entry.Tags = slices.Clone(entry.Tags)
+	}
+	for _, tag := range inferred.tags {
+		if !slices.Contains(entry.Tags, tag) {
+			entry.Tags = append(entry.Tags, tag)
+		}
+	}
+	if inferred.workItem != nil && len(entry.WorkItems) == 0 {
+		entry.WorkItems = []ledger.WorkItem{*inferred.workItem}
+	}
+}
+
+// detectedWorkItem returns the branch work item inferred added to entry as
+// "system:id", or "" when it added none.
+func detectedWorkItem(entry *ledger.Entry, inferred logInference) string {
+	if inferred.workItem == nil || !slices.Contains(entry.WorkItems, *inferred.workItem) {
+		return ""
+	}
+	return inferred.workItem.System + ":" + inferred.workItem.ID
+}
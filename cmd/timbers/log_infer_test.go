@@ -0,0 +1,149 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newPathTagRepo returns a log repo whose config tags Go files "go" and
+// docs/ "docs".
+func newPathTagRepo(t *testing.T) string {
+	t.Helper()
+	dir := newLogAnchorRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, ".timbers"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeAndCommit(t, dir, ".timbers/config.toml", "[tags.paths]\n\"*.go\" = \"go\"\n\"docs/**\" = \"docs\"\n", "chore: config")
+	return dir
+}
+
+// TestLogInfersTagsFromPaths verifies [tags.paths] rules tag the entry by the
+// files its commits touch, next to --tag values.
+func TestLogInfersTagsFromPaths(t *testing.T) {
+	dir := newPathTagRepo(t)
+
+	out, err := runLogCmd(t, dir, "Tagged", "--why", "w", "--how", "h", "--tag", "feature")
+	if err != nil {
+		t.Fatalf("timbers log errored: %v\noutput: %s", err, out)
+	}
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if !slices.Equal(entry.Tags, []string{"feature", "go"}) {
+		t.Errorf("tags = %v, want [feature go]", entry.Tags)
+	}
+}
+
+// TestLogDryRunShowsInferredTags verifies the dry run lists inferred tags.
+func TestLogDryRunShowsInferredTags(t *testing.T) {
+	dir := newPathTagRepo(t)
+
+	out, err := runLogCmd(t, dir, "Tagged", "--why", "w", "--how", "h", "--dry-run", "--json")
+	if err != nil {
+		t.Fatalf("timbers log --dry-run errored: %v\noutput: %s", err, out)
+	}
+	if !strings.Contains(out, `"inferred_tags": [`) || !strings.Contains(out, `"go"`) {
+		t.Errorf("dry run should list the inferred go tag:\n%s", out)
+	}
+}
+
+// TestLogRejectsBadPathTagRule verifies a malformed rule fails the log.
+func TestLogRejectsBadPathTagRule(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, ".timbers"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeAndCommit(t, dir, ".timbers/config.toml", "[tags.paths]\n\"src/[\" = \"x\"\n", "chore: config")
+
+	if out, err := runLogCmd(t, dir, "Tagged", "--why", "w", "--how", "h"); err == nil {
+		t.Fatalf("expected an invalid [tags.paths] error; output: %s", out)
+	}
+}
+
+// newBranchWorkItemRepo returns a log repo on branch feature/PROJ-123-auth
+// whose config maps feature branches to jira work items.
+func newBranchWorkItemRepo(t *testing.T) string {
+	t.Helper()
+	dir := newLogAnchorRepo(t)
+	runGit(t, dir, "checkout", "-q", "-b", "feature/PROJ-123-auth")
+	if err := os.MkdirAll(filepath.Join(dir, ".timbers"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeAndCommit(t, dir, ".timbers/config.toml",
+		"[log.branch_work_items]\n'^feature/([A-Z]+-\\d+)' = \"jira\"\n", "chore: config")
+	return dir
+}
+
+// TestLogDetectsWorkItemFromBranch verifies a [log.branch_work_items] rule
+// fills in the work item when --work-item is not given.
+func TestLogDetectsWorkItemFromBranch(t *testing.T) {
+	dir := newBranchWorkItemRepo(t)
+
+	out, err := runLogCmd(t, dir, "Auth", "--why", "w", "--how", "h")
+	if err != nil {
+		t.Fatalf("timbers log errored: %v\noutput: %s", err, out)
+	}
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if len(entry.WorkItems) != 1 || entry.WorkItems[0].System != "jira" || entry.WorkItems[0].ID != "PROJ-123" {
+		t.Errorf("work items = %+v, want [jira:PROJ-123]", entry.WorkItems)
+	}
+}
+
+// TestLogWorkItemFlagOverridesBranch verifies --work-item suppresses branch
+// detection.
+func TestLogWorkItemFlagOverridesBranch(t *testing.T) {
+	dir := newBranchWorkItemRepo(t)
+
+	out, err := runLogCmd(t, dir, "Auth", "--why", "w", "--how", "h", "--work-item", "github:7")
+	if err != nil {
+		t.Fatalf("timbers log errored: %v\noutput: %s", err, out)
+	}
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if len(entry.WorkItems) != 1 || entry.WorkItems[0].System != "github" {
+		t.Errorf("work items = %+v, want only [github:7]", entry.WorkItems)
+	}
+}
+
+// TestLogDryRunShowsDetectedWorkItem verifies the dry run names the detected
+// work item and the branch it came from.
+func TestLogDryRunShowsDetectedWorkItem(t *testing.T) {
+	dir := newBranchWorkItemRepo(t)
+
+	out, err := runLogCmd(t, dir, "Auth", "--why", "w", "--how", "h", "--dry-run", "--json")
+	if err != nil {
+		t.Fatalf("timbers log --dry-run errored: %v\noutput: %s", err, out)
+	}
+	for _, want := range []string{`"detected_work_item": {`, `"id": "PROJ-123"`, `"branch": "feature/PROJ-123-auth"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dry run should contain %s:\n%s", want, out)
+		}
+	}
+}
+
+// TestLogBatchDetectsWorkItemFromBranch verifies batch groups without a
+// work item of their own take the branch's.
+func TestLogBatchDetectsWorkItemFromBranch(t *testing.T) {
+	dir := newBranchWorkItemRepo(t)
+
+	out, err := runLogCmd(t, dir, "--batch", "--dry-run", "--json")
+	if err != nil {
+		t.Fatalf("timbers log --batch --dry-run errored: %v\noutput: %s", err, out)
+	}
+	if !strings.Contains(out, `"detected_work_item": "jira:PROJ-123"`) {
+		t.Errorf("batch dry run should list the detected work item:\n%s", out)
+	}
+}
+
+// TestLogRejectsBadBranchWorkItemRule verifies a malformed rule fails the log.
+func TestLogRejectsBadBranchWorkItemRule(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, ".timbers"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeAndCommit(t, dir, ".timbers/config.toml", "[log.branch_work_items]\n'([A-Z]+' = \"jira\"\n", "chore: config")
+
+	if out, err := runLogCmd(t, dir, "Auth", "--why", "w", "--how", "h"); err == nil {
+		t.Fatalf("expected an invalid [log.branch_work_items] error; output: %s", out)
+	}
+}
@@ -3,6 +3,8 @@ package main
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gorewood/timbers/internal/ledger"
@@ -12,33 +14,75 @@ import (
 // outputDryRun outputs what would be written without actually writing.
 // The human path renders the entry as an aligned panel (rounded box at a TTY,
 // borderless plain text when piped) with substance leading and bookkeeping
-// (ID, Anchor) at the bottom.
-func outputDryRun(printer *output.Printer, entry *ledger.Entry) error {
+// (ID, Anchor) at the bottom. What inferred derived from the repository, tags
+// from [tags.paths] and a work item from the branch name, is listed after the
+// substance.
+func outputDryRun(printer *output.Printer, entry *ledger.Entry, inferred logInference) error {
+	detected := detectedWorkItem(entry, inferred)
 	if printer.IsJSON() {
-		return printer.Success(map[string]any{
-			"status": "dry_run",
-			"entry":  entryToMap(entry),
-		})
+		result := map[string]any{"entry": entryToMap(entry)}
+		if len(inferred.tags) > 0 {
+			result["inferred_tags"] = inferred.tags
+		}
+		if detected != "" {
+			result["detected_work_item"] = map[string]string{
+				"system": inferred.workItem.System,
+				"id":     inferred.workItem.ID,
+				"branch": inferred.branch,
+			}
+		}
+		return printer.Success(withPlan(result,
+			[]plannedAction{{Action: planCreate, Target: entry.ID, Detail: "entry anchored at " + shortSHA(entry.Workset.AnchorCommit)}}))
 	}
 
-	printer.FieldsBox("Dry Run Preview", dryRunFields(entry))
+	fields := dryRunFields(entry)
+	var derived []output.Field
+	if len(inferred.tags) > 0 {
+		derived = append(derived, output.Field{Key: "Inferred", Value: strings.Join(inferred.tags, ", ") + " (from paths)"})
+	}
+	if detected != "" {
+		derived = append(derived, output.Field{Key: "Detected", Value: detected + " (from branch " + inferred.branch + ")"})
+	}
+	fields = slices.Insert(fields, len(substanceFields(entry)), derived...)
+	printer.FieldsBox("Dry Run Preview", fields)
 	return nil
 }
 
 // formatDiffstat formats a diffstat as a human-readable string.
-func formatDiffstat(ds *ledger.Diffstat) string {
-	if ds == nil {
+func formatDiffstat(diffstat *ledger.Diffstat) string {
+	if diffstat == nil {
 		return "0 changed"
 	}
-	return fmt.Sprintf("%d changed, +%d -%d", ds.Files, ds.Insertions, ds.Deletions)
+	out := fmt.Sprintf("%d changed, +%d -%d", diffstat.Files, diffstat.Insertions, diffstat.Deletions)
+	if diffstat.BinaryFiles > 0 {
+		out += fmt.Sprintf(", %d binary", diffstat.BinaryFiles)
+	}
+	if diffstat.GeneratedFiles > 0 {
+		out += fmt.Sprintf(", %d generated", diffstat.GeneratedFiles)
+	}
+	if len(diffstat.Renames) > 0 {
+		out += fmt.Sprintf(", %d renamed", len(diffstat.Renames))
+	}
+	return out
 }
 
-// outputLogSuccess outputs the success result.
-func outputLogSuccess(printer *output.Printer, entry *ledger.Entry) error {
+// formatSignatures summarizes workset signature status as "N/M signed", or
+// "" when the entry predates signature capture.
+func formatSignatures(workset *ledger.Workset) string {
+	signed, known := workset.SignedCount()
+	if known == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d signed", signed, known)
+}
+
+// outputLogSuccess outputs the success result, with the push made by
+// --push when pushed is not nil.
+func outputLogSuccess(printer *output.Printer, entry *ledger.Entry, pushed *pushResult) error {
 	if printer.IsJSON() {
 		commitSHAs := make([]string, len(entry.Workset.Commits))
 		copy(commitSHAs, entry.Workset.Commits)
-		return printer.Success(map[string]any{
+		result := map[string]any{
 			"status":  "created",
 			"id":      entry.ID,
 			"anchor":  entry.Workset.AnchorCommit,
@@ -46,11 +90,16 @@ func outputLogSuccess(printer *output.Printer, entry *ledger.Entry) error {
 			"suggested_commands": []string{
 				"timbers show --latest",
 			},
-		})
+		}
+		if pushed != nil {
+			result["push"] = pushed
+		}
+		return printer.Success(result)
 	}
 
 	_ = printer.Success(map[string]any{"message": "Created entry " + entry.ID})
 	printer.Println("  " + entry.Summary.What)
+	printPushResult(printer, pushed)
 
 	return nil
 }
@@ -64,11 +113,13 @@ func entryToMap(entry *ledger.Entry) map[string]any {
 	}
 
 	if entry.Workset.Diffstat != nil {
-		workset["diffstat"] = map[string]any{
-			"files":      entry.Workset.Diffstat.Files,
-			"insertions": entry.Workset.Diffstat.Insertions,
-			"deletions":  entry.Workset.Diffstat.Deletions,
-		}
+		workset["diffstat"] = entry.Workset.Diffstat
+	}
+	if len(entry.Workset.CommitMeta) > 0 {
+		workset["commit_meta"] = entry.Workset.CommitMeta
+	}
+	if len(entry.Workset.Files) > 0 {
+		workset["files"] = entry.Workset.Files
 	}
 
 	result := map[string]any{
@@ -4,6 +4,7 @@ package main
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gorewood/timbers/internal/git"
 	"github.com/gorewood/timbers/internal/ledger"
@@ -11,8 +12,15 @@ import (
 )
 
 // validateBasicInput validates basic input before commits are fetched.
-// This only validates range format; content validation happens in resolveLogContent.
+// This only validates range format and kind; content validation happens in
+// resolveLogContent.
 func validateBasicInput(_ []string, flags logFlags) error {
+	if _, err := ledger.ParseKind(flags.kind); err != nil {
+		return output.NewUserError("--kind: " + err.Error())
+	}
+	if flags.groupBy != "" {
+		return output.NewUserError("--group-by requires --batch")
+	}
 	if flags.rangeStr != "" {
 		if err := validateRangeFormat(flags.rangeStr); err != nil {
 			return err
@@ -21,6 +29,14 @@ func validateBasicInput(_ []string, flags logFlags) error {
 	return nil
 }
 
+// entryKind returns the --kind value, treating empty as ledger.KindEntry.
+func (flags logFlags) entryKind() string {
+	if flags.kind == "" {
+		return ledger.KindEntry
+	}
+	return flags.kind
+}
+
 // resolveLogContent determines what/why/how values based on mode (auto, minor, or manual).
 // Returns the what value and potentially modified flags with why/how populated.
 func resolveLogContent(args []string, flags logFlags, commits []git.Commit) (string, logFlags, error) {
@@ -62,15 +78,18 @@ func resolveManualContent(args []string, flags logFlags, commits []git.Commit) (
 	}
 	if strings.TrimSpace(what) == "" {
 		return "", flags, output.NewUserError(
-			"could not derive what from commit subjects; provide an explicit <what> argument")
+			"could not derive what from commit subjects; provide an explicit <what> argument").WithID(output.ErrCodeMissingWhat)
 	}
 
 	if !flags.minor {
-		if flags.why == "" {
-			return "", flags, output.NewUserError("--why flag is required (use --minor or --auto for alternatives)")
+		kind := flags.entryKind()
+		if flags.why == "" && ledger.RequiresWhy(kind) {
+			return "", flags, output.NewUserError("--why flag is required (use --minor or --auto for alternatives)").
+				WithID(output.ErrCodeMissingWhy)
 		}
-		if flags.how == "" {
-			return "", flags, output.NewUserError("--how flag is required (use --minor or --auto for alternatives)")
+		if flags.how == "" && ledger.RequiresHow(kind) {
+			return "", flags, output.NewUserError("--how flag is required (use --minor or --auto for alternatives)").
+				WithID(output.ErrCodeMissingHow)
 		}
 	}
 
@@ -84,7 +103,7 @@ func resolveManualContent(args []string, flags logFlags, commits []git.Commit) (
 func extractAutoContent(commits []git.Commit) (what, why, how string) {
 	what = extractWhat(commits)
 	if what == "" {
-		what = "Auto-documented"
+		what = ledger.AutoPlaceholder
 	}
 
 	// Extract why/how from first commit with body content
@@ -112,10 +131,10 @@ func extractAutoContent(commits []git.Commit) (what, why, how string) {
 
 	// Default values if nothing extracted
 	if why == "" {
-		why = "Auto-documented"
+		why = ledger.AutoPlaceholder
 	}
 	if how == "" {
-		how = "Auto-documented"
+		how = ledger.AutoPlaceholder
 	}
 
 	return what, why, how
@@ -205,3 +224,72 @@ func parseWorkItem(item string) (string, string, error) {
 
 	return system, itemID, nil
 }
+
+// parseMetaFlags parses --meta key=value pairs; see ledger.ParseMeta.
+func parseMetaFlags(pairs []string, allowEmpty bool) (map[string]string, error) {
+	meta, err := ledger.ParseMeta(pairs, allowEmpty)
+	if err != nil {
+		return nil, output.NewUserError("--meta: " + err.Error())
+	}
+	return meta, nil
+}
+
+// parseValues parses the flags that carry structured values: --alternative
+// into decision, --meta into meta, and --started into startedAt.
+func (flags *logFlags) parseValues() error {
+	if err := flags.resolveAlternatives(); err != nil {
+		return err
+	}
+	meta, err := parseMetaFlags(flags.metaPairs, false)
+	if err != nil {
+		return err
+	}
+	flags.meta = meta
+	if flags.started == "" {
+		return nil
+	}
+	if flags.batch {
+		return output.NewUserError("--started cannot be combined with --batch; each batch entry starts at its first commit")
+	}
+	started, err := parseTimeValue(flags.started)
+	if err != nil {
+		return output.NewUserError("invalid --started value " + flags.started + "; use a date (2025-01-01), a timestamp, or an age (3d)")
+	}
+	if started.After(time.Now()) {
+		return output.NewUserError("--started " + flags.started + " is in the future")
+	}
+	started = started.UTC()
+	flags.startedAt = &started
+	return nil
+}
+
+// resolveAlternatives folds --alternative into the decision fields and
+// refuses a decision that names no alternatives, which the ledger would
+// reject on write.
+func (flags *logFlags) resolveAlternatives() error {
+	kind := flags.entryKind()
+	if len(flags.alternatives) > 0 {
+		if kind != ledger.KindDecision {
+			return output.NewUserError("--alternative requires --kind decision")
+		}
+		decision := ledger.Decision{}
+		if flags.decision != nil {
+			decision = *flags.decision
+		}
+		decision.Alternatives = flags.alternatives
+		flags.decision = &decision
+	}
+	if ledger.RequiresAlternatives(kind) && (flags.decision == nil || len(flags.decision.Alternatives) == 0) {
+		return output.NewUserError("--alternative is required for a decision: name the options considered and rejected")
+	}
+	return nil
+}
+
+// entryStarted returns the --started time, or the earliest author date of
+// commits when it was not given.
+func entryStarted(startedAt *time.Time, commits []git.Commit) *time.Time {
+	if startedAt != nil {
+		return startedAt
+	}
+	return ledger.EarliestAuthored(commits)
+}
@@ -0,0 +1,47 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// pushResult reports the push --push makes after writing, in log's JSON
+// result.
+type pushResult struct {
+	Status string `json:"status"` // "pushed" or "failed"
+	Remote string `json:"remote,omitempty"`
+	Branch string `json:"branch,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// applyPushDefault sets flags.push from the [log] push config when --push
+// was not given, so --push=false can turn a configured default off.
+func applyPushDefault(cmd *cobra.Command, flags *logFlags) {
+	if cmd.Flags().Changed("push") {
+		return
+	}
+	flags.push = loadLogProject().Log.Push
+}
+
+// pushAfterLog pushes the current branch once entries are written. A failed
+// push is reported, not returned: the entries are already committed and only
+// need a later `git push`.
+func pushAfterLog(printer *output.Printer) *pushResult {
+	remote, branch, err := git.PushCurrentBranch()
+	if err != nil {
+		printer.Warning("entry written, but the push failed (%s); run `git push` to sync it", err.Error())
+		return &pushResult{Status: "failed", Remote: remote, Branch: branch, Error: err.Error()}
+	}
+	return &pushResult{Status: "pushed", Remote: remote, Branch: branch}
+}
+
+// printPushResult reports a successful push in human output; failures were
+// already warned about.
+func printPushResult(printer *output.Printer, pushed *pushResult) {
+	if pushed != nil && pushed.Status == "pushed" {
+		printer.Println("  Pushed to " + pushed.Remote + "/" + pushed.Branch)
+	}
+}
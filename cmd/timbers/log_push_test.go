@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLogPushPushesEntryCommit verifies --push sends the entry commit to
+// origin and reports it in the JSON result.
+func TestLogPushPushesEntryCommit(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	runGit(t, dir, "init", "--bare", remote)
+	runGit(t, dir, "remote", "add", "origin", remote)
+	branch := strings.TrimSpace(runGitOutput(t, dir, "branch", "--show-current"))
+
+	out, err := runLogCmd(t, dir, "Pushed entry", "--why", "w", "--how", "h", "--push", "--json")
+	if err != nil {
+		t.Fatalf("timbers log --push errored: %v\noutput: %s", err, out)
+	}
+	var result struct {
+		Push pushResult `json:"push"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse JSON: %v\n%s", err, out)
+	}
+	if result.Push.Status != "pushed" || result.Push.Remote != "origin" || result.Push.Branch != branch {
+		t.Errorf("push = %+v, want pushed to origin/%s", result.Push, branch)
+	}
+
+	head := strings.TrimSpace(runGitOutput(t, dir, "rev-parse", "HEAD"))
+	if pushed := strings.TrimSpace(runGitOutput(t, remote, "rev-parse", branch)); pushed != head {
+		t.Errorf("remote %s = %s, want the entry commit %s", branch, pushed, head)
+	}
+}
+
+// TestLogPushFailureStillWritesEntry verifies a failed push is reported but
+// keeps the written entry and a zero exit.
+func TestLogPushFailureStillWritesEntry(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+
+	out, err := runLogCmd(t, dir, "Unpushed entry", "--why", "w", "--how", "h", "--push", "--json")
+	if err != nil {
+		t.Fatalf("a failed push should not fail the log: %v\noutput: %s", err, out)
+	}
+	if !strings.Contains(out, `"status": "failed"`) {
+		t.Errorf("JSON should report the failed push:\n%s", out)
+	}
+	if countJSONFilesInDir(filepath.Join(dir, ".timbers")) != 1 {
+		t.Error("the entry should be written even when the push fails")
+	}
+}
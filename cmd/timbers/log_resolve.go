@@ -10,10 +10,10 @@ import (
 )
 
 func resolveLogContributors(
-	commits []git.Commit, who []string, staleAnchor bool, printer *output.Printer,
+	commits []git.Commit, who, coAuthors []string, staleAnchor bool, printer output.Reporter,
 ) ([]ledger.Contributor, error) {
 	if staleAnchor {
-		printer.Warn("stale anchor (likely squash merge); self-heals with this entry")
+		printer.Warning("stale anchor (likely squash merge); self-heals with this entry")
 	}
 	if len(commits) == 0 {
 		err := output.NewUserError("no pending commits to document. To log a specific commit or range " +
@@ -22,6 +22,9 @@ func resolveLogContributors(
 		return nil, err
 	}
 	contributors, err := ledger.ResolveContributors(commits, who)
+	if err == nil {
+		contributors, err = ledger.AddCoAuthors(contributors, coAuthors)
+	}
 	if err != nil {
 		err = output.NewUserError(err.Error())
 		printer.Error(err)
@@ -80,6 +83,20 @@ func determineAnchor(commits []git.Commit, anchorOverride string) string {
 	return ""
 }
 
+// resolveWorksetChanges gets the diffstat and the changed file paths for the
+// commits being logged. Both are best effort: the entry is still written
+// when git cannot describe the change.
+func resolveWorksetChanges(
+	storage *ledger.Storage, fromRef, anchor string, commits []git.Commit,
+) (git.Diffstat, []string) {
+	diffstat, err := getDiffstatForRange(storage, fromRef, anchor, commits)
+	if err != nil {
+		diffstat = git.Diffstat{}
+	}
+	files, _ := storage.WorksetFiles(commits)
+	return diffstat, files
+}
+
 // getDiffstatForRange gets the diffstat for a commit range.
 func getDiffstatForRange(
 	storage *ledger.Storage,
@@ -94,3 +111,72 @@ func getDiffstatForRange(
 	}
 	return storage.GetDiffstat(fromRef, toRef)
 }
+
+// resolveAnchorFlag resolves a symbolic --anchor (HEAD, a branch, a short SHA)
+// to a full SHA in place before it flows into range selection or the stored
+// anchor. Persisting a symbolic ref like "HEAD" yields entry ids suffixed
+// "_HEAD" and an anchor that changes meaning per-commit and per-worktree,
+// defeating the since-anchor model. An unresolvable ref errors here rather than
+// writing a phantom entry anchored on nothing. No-op when --anchor is unset.
+func resolveAnchorFlag(storage *ledger.Storage, flags *logFlags, printer *output.Printer) error {
+	if flags.anchor == "" {
+		return nil
+	}
+	resolved, err := storage.ResolveCommit(flags.anchor)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	flags.anchor = resolved
+	return nil
+}
+
+// checkCleanTree refuses a dirty working tree. The auto-commit
+// pathspec-scopes to the entry file (internal/ledger/filestorage.go: git
+// commit -- <path>), so staged feature changes stay in the index while the
+// entry rides on the old HEAD. Push then ships a phantom: an entry whose
+// prose describes work that isn't in any commit below it. Most often hit when
+// the pre-commit gate aborted the prior `git commit` and the caller chained
+// `timbers log` after a newline (no &&). --dry-run is still allowed because
+// it short-circuits before the auto-commit and only prints what the entry
+// would look like; it warns instead, so JSON callers see the tree state too.
+// If isDirty is nil, git.HasUncommittedChanges is used.
+func checkCleanTree(isDirty dirtyChecker, dryRun bool, printer output.Reporter) error {
+	if isDirty == nil {
+		isDirty = git.HasUncommittedChanges
+	}
+	if !isDirty() {
+		return nil
+	}
+	if dryRun {
+		printer.Warning("working tree has uncommitted changes; a real run would refuse until they are committed")
+		return nil
+	}
+	err := output.NewUserError(
+		"working tree has uncommitted changes; commit (or stash) them " +
+			"first to avoid phantom entries. If the prior `git commit` " +
+			"was aborted by the pre-commit gate, your staged changes " +
+			"are still in the index — inspect with: git diff --cached. " +
+			"For a no-op peek, re-run with --dry-run.")
+	printer.Error(err)
+	return err
+}
+
+// initLogStorage initializes the storage, checking for git repo if needed.
+func initLogStorage(storage *ledger.Storage, printer *output.Printer) (*ledger.Storage, error) {
+	if storage == nil && !git.IsRepo() {
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
+		printer.Error(err)
+		return nil, err
+	}
+
+	if storage == nil {
+		var err error
+		storage, err = ledger.NewDefaultStorage()
+		if err != nil {
+			printer.Error(err)
+			return nil, err
+		}
+	}
+	return storage, nil
+}
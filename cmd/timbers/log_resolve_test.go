@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+func TestCheckCleanTree(t *testing.T) {
+	dirty := func() bool { return true }
+	clean := func() bool { return false }
+
+	tests := []struct {
+		name         string
+		isDirty      dirtyChecker
+		dryRun       bool
+		wantErr      bool
+		wantWarnings int
+	}{
+		{"clean tree", clean, false, false, 0},
+		{"dirty tree refuses", dirty, false, true, 0},
+		{"dirty dry run warns", dirty, true, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := output.NewRecordingPrinter()
+			err := checkCleanTree(tt.isDirty, tt.dryRun, recorder)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkCleanTree() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && (!errors.Is(recorder.Err(), err) || output.GetExitCode(err) != output.ExitUserError) {
+				t.Errorf("reported error = %v, want the returned user error", recorder.Err())
+			}
+			if got := len(recorder.Warnings()); got != tt.wantWarnings {
+				t.Errorf("warnings = %v, want %d", recorder.Warnings(), tt.wantWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// applyStdinEntry reads an entry as JSON from input, for log --stdin, and
+// fills in the args and flags the command line left unset. The entry may be
+// partial; fields timbers derives from git (id, timestamps, workset) are
+// ignored and harvested as usual. It runs before parseValues, so stdin meta
+// and started_at are checked like their flags.
+func (flags *logFlags) applyStdinEntry(input io.Reader, args []string) ([]string, error) {
+	if !flags.stdin {
+		return args, nil
+	}
+	switch {
+	case flags.batch:
+		return nil, output.NewUserError("--stdin cannot be combined with --batch")
+	case flags.interactive, flags.edit:
+		return nil, output.NewUserError("--stdin cannot be combined with -i or --edit")
+	}
+
+	var entry ledger.Entry
+	decoder := json.NewDecoder(input)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&entry); err != nil {
+		return nil, output.NewUserError("--stdin: invalid entry JSON: " + err.Error())
+	}
+
+	if len(args) == 0 && entry.Summary.What != "" {
+		args = []string{entry.Summary.What}
+	}
+	setIfEmpty(&flags.why, entry.Summary.Why)
+	setIfEmpty(&flags.how, entry.Summary.How)
+	setIfEmpty(&flags.notes, entry.Notes)
+	if flags.kind == "" || flags.kind == ledger.KindEntry {
+		setIfEmpty(&flags.kind, entry.Kind)
+	}
+	if entry.StartedAt != nil {
+		setIfEmpty(&flags.started, entry.StartedAt.Format(time.RFC3339))
+	}
+	if len(flags.tags) == 0 {
+		flags.tags = entry.Tags
+	}
+	if len(flags.workItems) == 0 {
+		for _, item := range entry.WorkItems {
+			flags.workItems = append(flags.workItems, item.System+":"+item.ID)
+		}
+	}
+	if len(flags.who) == 0 {
+		for _, contributor := range entry.Contributors {
+			flags.who = append(flags.who, contributor.Name+" <"+contributor.Email+">")
+		}
+	}
+	if flags.decision == nil {
+		flags.decision = entry.Decision
+	}
+	flags.metaPairs = append(stdinMetaPairs(entry.Meta, flags.metaPairs), flags.metaPairs...)
+	return args, nil
+}
+
+// stdinMetaPairs returns meta as sorted key=value pairs, leaving out keys
+// that flagPairs set.
+func stdinMetaPairs(meta map[string]string, flagPairs []string) []string {
+	pairs := make([]string, 0, len(meta))
+	for key, value := range meta {
+		overridden := slices.ContainsFunc(flagPairs, func(pair string) bool {
+			flagKey, _, _ := strings.Cut(pair, "=")
+			return strings.TrimSpace(flagKey) == key
+		})
+		if !overridden {
+			pairs = append(pairs, key+"="+value)
+		}
+	}
+	slices.Sort(pairs)
+	return pairs
+}
+
+// setIfEmpty sets *field to value when *field is empty.
+func setIfEmpty(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
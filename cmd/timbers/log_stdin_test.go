@@ -0,0 +1,79 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// runLogStdinCmd runs `timbers log --stdin` in dir with input on stdin.
+func runLogStdinCmd(t *testing.T, dir, input string, args ...string) (string, error) {
+	t.Helper()
+	var out strings.Builder
+	var execErr error
+	runInDir(t, dir, func() {
+		cmd := newRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetIn(strings.NewReader(input))
+		cmd.SetArgs(append([]string{"log", "--stdin"}, args...))
+		execErr = cmd.Execute()
+	})
+	return out.String(), execErr
+}
+
+// TestLogStdinMergesWithFlagsAndGit verifies a partial entry on stdin fills
+// what the flags leave unset, while the workset still comes from git.
+func TestLogStdinMergesWithFlagsAndGit(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	headSHA := strings.TrimSpace(runGitOutput(t, dir, "rev-parse", "HEAD"))
+	input := `{
+	  "summary": {"why": "Line one\n\nLine \"two\"", "how": "stdin how"},
+	  "tags": ["agent"],
+	  "work_items": [{"system": "jira", "id": "PROJ-7"}],
+	  "meta": {"service": "api", "risk": "low"},
+	  "workset": {"anchor_commit": "ignored"}
+	}`
+	out, err := runLogStdinCmd(t, dir, input, "--how", "flag how", "--meta", "risk=high")
+	if err != nil {
+		t.Fatalf("timbers log --stdin errored: %v\noutput: %s", err, out)
+	}
+
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if entry.Summary.What != "feat: work; initial" {
+		t.Errorf("what = %q, want the commit subjects", entry.Summary.What)
+	}
+	if entry.Summary.Why != "Line one\n\nLine \"two\"" || entry.Summary.How != "flag how" {
+		t.Errorf("summary = %+v", entry.Summary)
+	}
+	if !slices.Equal(entry.Tags, []string{"agent"}) || len(entry.WorkItems) != 1 || entry.WorkItems[0].ID != "PROJ-7" {
+		t.Errorf("tags = %v, work items = %+v", entry.Tags, entry.WorkItems)
+	}
+	if entry.Meta["service"] != "api" || entry.Meta["risk"] != "high" {
+		t.Errorf("meta = %v, want service=api and the flag's risk=high", entry.Meta)
+	}
+	if entry.Workset.AnchorCommit != headSHA {
+		t.Errorf("anchor = %q, want HEAD %s", entry.Workset.AnchorCommit, headSHA)
+	}
+}
+
+// TestLogStdinRejectsBadInput verifies malformed or incomplete JSON writes
+// nothing.
+func TestLogStdinRejectsBadInput(t *testing.T) {
+	for name, input := range map[string]string{
+		"not json":      "why: because",
+		"unknown field": `{"summary": {"why": "a", "how": "b"}, "wat": 1}`,
+		"missing how":   `{"summary": {"why": "a"}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := newLogAnchorRepo(t)
+			if out, err := runLogStdinCmd(t, dir, input); err == nil {
+				t.Fatalf("expected an error; output: %s", out)
+			}
+			if countJSONFilesInDir(filepath.Join(dir, ".timbers")) != 0 {
+				t.Error("no entry should be written for bad input")
+			}
+		})
+	}
+}
@@ -3,10 +3,12 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -58,11 +60,11 @@ func newMockGitOpsForLog() *mockGitOpsForLog {
 	return &mockGitOpsForLog{}
 }
 
-func (m *mockGitOpsForLog) HEAD() (string, error) {
+func (m *mockGitOpsForLog) HEAD(_ context.Context) (string, error) {
 	return m.head, m.headErr
 }
 
-func (m *mockGitOpsForLog) Log(fromRef, _ string) ([]git.Commit, error) {
+func (m *mockGitOpsForLog) Log(_ context.Context, fromRef, _ string) ([]git.Commit, error) {
 	// The --anchor fallback resolves a single commit via LogRange(anchor^, anchor);
 	// distinguish that from the pending-range call so tests can set them apart.
 	if m.rangeCommits != nil && strings.HasSuffix(fromRef, "^") {
@@ -71,36 +73,50 @@ func (m *mockGitOpsForLog) Log(fromRef, _ string) ([]git.Commit, error) {
 	return m.commits, m.commitsErr
 }
 
-func (m *mockGitOpsForLog) LogFirstParent(_, _ string) ([]git.Commit, error) {
+func (m *mockGitOpsForLog) LogFirstParent(_ context.Context, _, _ string) ([]git.Commit, error) {
 	return m.commits, m.commitsErr
 }
 
-func (m *mockGitOpsForLog) ResolveCommit(ref string) (string, error) {
+func (m *mockGitOpsForLog) ResolveCommit(_ context.Context, ref string) (string, error) {
 	return ref, nil
 }
 
-func (m *mockGitOpsForLog) CommitsReachableFrom(_ string) ([]git.Commit, error) {
+func (m *mockGitOpsForLog) CommitsReachableFrom(_ context.Context, _ string) ([]git.Commit, error) {
 	return m.reachableResult, m.reachableErr
 }
 
-func (m *mockGitOpsForLog) IsAncestorOf(ancestor, descendant string) bool {
+func (m *mockGitOpsForLog) CommitsReachableFromFirstParent(ctx context.Context, sha string) ([]git.Commit, error) {
+	return m.CommitsReachableFrom(ctx, sha)
+}
+
+func (m *mockGitOpsForLog) IsAncestorOf(_ context.Context, ancestor, descendant string) bool {
 	return true
 }
 
-func (m *mockGitOpsForLog) IsOnFirstParentLine(sha, head string) bool {
+func (m *mockGitOpsForLog) IsOnFirstParentLine(_ context.Context, sha, head string) bool {
 	return true
 }
 
-func (m *mockGitOpsForLog) GetDiffstat(_, _ string) (git.Diffstat, error) {
+func (m *mockGitOpsForLog) IsShallow(_ context.Context) bool {
+	return false
+}
+
+func (m *mockGitOpsForLog) GetDiffstat(_ context.Context, _, _ string) (git.Diffstat, error) {
 	return m.diffstat, m.diffstatErr
 }
 
-func (m *mockGitOpsForLog) CommitFiles(sha string) ([]string, error) { return nil, nil }
-func (m *mockGitOpsForLog) CommitFilesMulti(shas []string) (map[string][]string, error) {
+func (m *mockGitOpsForLog) CommitFiles(_ context.Context, sha string) ([]string, error) {
+	return nil, nil
+}
+func (m *mockGitOpsForLog) CommitFilesMulti(_ context.Context, shas []string) (map[string][]string, error) {
 	return make(map[string][]string), nil
 }
 
-func (m *mockGitOpsForLog) DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
+func (m *mockGitOpsForLog) CommitFilesMultiFirstParent(ctx context.Context, shas []string) (map[string][]string, error) {
+	return m.CommitFilesMulti(ctx, shas)
+}
+
+func (m *mockGitOpsForLog) DiffNameOnly(_ context.Context, fromRef, toRef, pathPrefix string) ([]string, error) {
 	return nil, nil
 }
 
@@ -363,7 +379,7 @@ func TestLogCommand(t *testing.T) {
 			args:         []string{"Feature", "--why", "Need it", "--how", "Built it", "--dry-run"},
 			jsonOutput:   true,
 			wantErr:      false,
-			wantContains: []string{`"status": "dry_run"`, `"entry":`},
+			wantContains: []string{`"status": "dry_run"`, `"entry":`, `"planned_actions"`, `"action": "create"`},
 		},
 		{
 			name: "explicit range flag",
@@ -618,6 +634,40 @@ func TestLogDirtyTreeRefuses(t *testing.T) {
 		}
 	})
 
+	t.Run("dirty tree with --dry-run --json reports a warning", func(t *testing.T) {
+		mock := newMockGitOpsForLog()
+		mock.head = "abc123def456789"
+		mock.reachableResult = []git.Commit{
+			{SHA: "abc123def456789", Short: "abc123d", Subject: "Latest commit"},
+		}
+		mock.diffstat = git.Diffstat{Files: 1, Insertions: 10, Deletions: 0}
+
+		storage, _ := newLogTestStorage(t, mock)
+		cmd := newLogCmdInternal(storage, func() bool { return true })
+		cmd.PersistentFlags().Bool("json", false, "")
+		cmd.SetArgs([]string{"Test entry", "--why", "Testing", "--how", "Via test", "--dry-run", "--json"})
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("dry-run on dirty tree should succeed, got error: %v (output: %s)", err, buf.String())
+		}
+		var result map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("expected a single JSON object: %v (output: %s)", err, buf.String())
+		}
+		warnings, _ := result["warnings"].([]any)
+		var warning string
+		if len(warnings) == 1 {
+			warning, _ = warnings[0].(string)
+		}
+		if !strings.Contains(warning, "uncommitted changes") {
+			t.Errorf("warnings = %v, want the dirty-tree warning", result["warnings"])
+		}
+	})
+
 	t.Run("clean tree succeeds without warning", func(t *testing.T) {
 		mock := newMockGitOpsForLog()
 		mock.head = "def456789012345"
@@ -1283,3 +1333,127 @@ func TestLogWhoReplacesAutomaticContributors(t *testing.T) {
 		t.Fatalf("Contributors = %#v, want sorted explicit replacement", got)
 	}
 }
+
+func TestLogCoAuthorAddsToAutomaticContributors(t *testing.T) {
+	mock := newMockGitOpsForLog()
+	mock.head = "abc123def456789"
+	mock.reachableResult = []git.Commit{{
+		SHA: "abc123def456789", Short: "abc123d", Subject: "work",
+		Author: "Git Author", AuthorEmail: "git@example.com",
+	}}
+	storage, _ := newLogTestStorage(t, mock)
+	cmd := newLogCmdWithStorage(storage)
+	cmd.PersistentFlags().Bool("json", false, "")
+	_ = cmd.PersistentFlags().Set("json", "true")
+	cmd.SetArgs([]string{
+		"Paired work", "--why", "reason", "--how", "method", "--dry-run",
+		"--co-author", "Pair Bot <pair-bot@example.com>",
+	})
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetErr(&output)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	var result struct {
+		Entry ledger.Entry `json:"entry"`
+	}
+	if err := json.Unmarshal(output.Bytes(), &result); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, output.String())
+	}
+	if got := result.Entry.Contributors; len(got) != 2 || got[0].Email != "git@example.com" ||
+		got[1].Email != "pair-bot@example.com" || got[1].Sources[0] != ledger.ContributorSourceExplicit {
+		t.Fatalf("Contributors = %#v, want the git author plus the explicit co-author", got)
+	}
+}
+
+func TestLogKindDecisionWithoutHow(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+
+	out, err := runLogCmd(t, dir, "Use Postgres", "--why", "We need transactions", "--kind", "decision", "--alternative", "SQLite")
+	if err != nil {
+		t.Fatalf("timbers log --kind decision errored: %v\noutput: %s", err, out)
+	}
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if entry.Kind != ledger.KindDecision {
+		t.Errorf("kind = %q, want %q", entry.Kind, ledger.KindDecision)
+	}
+	if entry.Summary.How != "" {
+		t.Errorf("how = %q, want empty for a decision without --how", entry.Summary.How)
+	}
+	if entry.Decision == nil || !slices.Equal(entry.Decision.Alternatives, []string{"SQLite"}) {
+		t.Errorf("decision = %+v, want alternatives [SQLite]", entry.Decision)
+	}
+}
+
+func TestLogKindRequiredFields(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+
+	if out, err := runLogCmd(t, dir, "Outage", "--why", "Disk full", "--kind", "incident"); err == nil ||
+		!strings.Contains(out, "--how flag is required") {
+		t.Errorf("incident without --how should fail on --how, got err=%v output: %s", err, out)
+	}
+	if out, err := runLogCmd(t, dir, "Use Postgres", "--why", "Transactions", "--kind", "decision"); err == nil ||
+		!strings.Contains(out, "--alternative is required") {
+		t.Errorf("decision without --alternative should fail, got err=%v output: %s", err, out)
+	}
+	if out, err := runLogCmd(t, dir, "Work", "--why", "w", "--how", "h", "--alternative", "SQLite"); err == nil ||
+		!strings.Contains(out, "--alternative requires --kind decision") {
+		t.Errorf("--alternative on an entry should fail, got err=%v output: %s", err, out)
+	}
+	if out, err := runLogCmd(t, dir, "Idea", "--kind", "memo"); err == nil || !strings.Contains(out, `unknown kind "memo"`) {
+		t.Errorf("unknown kind should be rejected, got err=%v output: %s", err, out)
+	}
+	if out, err := runLogCmd(t, dir, "Remember the cache warms at boot", "--kind", "note"); err != nil {
+		t.Fatalf("note with only what errored: %v\noutput: %s", err, out)
+	}
+	if entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers")); entry.Kind != ledger.KindNote {
+		t.Errorf("kind = %q, want %q", entry.Kind, ledger.KindNote)
+	}
+}
+
+func TestLogNumstatRecordsPerFileStats(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+
+	out, err := runLogCmd(t, dir, "Add feature", "--why", "Needed", "--how", "New file", "--numstat")
+	if err != nil {
+		t.Fatalf("timbers log --numstat errored: %v\noutput: %s", err, out)
+	}
+	diffstat := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).Workset.Diffstat
+	if diffstat == nil {
+		t.Fatal("diffstat is nil")
+	}
+	var found bool
+	for _, file := range diffstat.PerFile {
+		if file.Path == "feature.go" {
+			found = file.Insertions == 1 && file.Deletions == 0
+		}
+	}
+	if !found {
+		t.Errorf("per_file = %+v, want feature.go with +1/-0", diffstat.PerFile)
+	}
+}
+
+func TestLogWithoutNumstatOmitsPerFileStats(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+
+	if out, err := runLogCmd(t, dir, "Add feature", "--why", "Needed", "--how", "New file"); err != nil {
+		t.Fatalf("timbers log errored: %v\noutput: %s", err, out)
+	}
+	diffstat := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).Workset.Diffstat
+	if diffstat == nil || diffstat.Files == 0 || len(diffstat.PerFile) != 0 {
+		t.Errorf("diffstat = %+v, want totals without per_file", diffstat)
+	}
+}
+
+func TestLogRecordsWorksetFiles(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+
+	if out, err := runLogCmd(t, dir, "Add feature", "--why", "Needed", "--how", "New file"); err != nil {
+		t.Fatalf("timbers log errored: %v\noutput: %s", err, out)
+	}
+	files := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).Workset.Files
+	if !slices.Contains(files, "feature.go") || !slices.IsSorted(files) {
+		t.Errorf("workset.files = %v, want a sorted list including feature.go", files)
+	}
+}
@@ -0,0 +1,133 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// Trailer targets accepted in the [trailers] config section.
+const (
+	trailerTargetWorkItem = "work-item"
+	trailerTargetTag      = "tag"
+	trailerTargetNotes    = "notes"
+)
+
+// trailerFields is what the [trailers] config maps out of commit trailers.
+type trailerFields struct {
+	workItems []ledger.WorkItem
+	tags      []string
+	notes     []string
+}
+
+// projectTrailerMap returns the [trailers] section of the project config
+// with lowercased keys, empty outside a repository or when the file does
+// not parse. Targets are validated, so a typo fails the log instead of
+// silently dropping trailers.
+func projectTrailerMap() (map[string]string, error) {
+	cfg := loadLogProject()
+	mapping := make(map[string]string, len(cfg.Trailers))
+	for key, target := range cfg.Trailers {
+		if !validTrailerTarget(target) {
+			return nil, output.NewUserError("invalid [trailers] target " + target + " for " + key +
+				" in " + config.ProjectFile + ": want work-item, work-item:<system>, tag, or notes")
+		}
+		mapping[strings.ToLower(key)] = target
+	}
+	return mapping, nil
+}
+
+// loadLogProject returns the project config, or the defaults outside a
+// repository or when the file does not parse.
+func loadLogProject() config.Project {
+	if root, err := git.RepoRoot(); err == nil {
+		if loaded, loadErr := config.LoadProject(root); loadErr == nil {
+			return loaded
+		}
+	}
+	return config.DefaultProject()
+}
+
+// validTrailerTarget reports whether target names an entry field.
+func validTrailerTarget(target string) bool {
+	if system, ok := strings.CutPrefix(target, trailerTargetWorkItem+":"); ok {
+		return system != "" && !strings.Contains(system, ":")
+	}
+	return target == trailerTargetWorkItem || target == trailerTargetTag || target == trailerTargetNotes
+}
+
+// loadCommitTrailers reads the trailers of commits when mapping selects any.
+// A failed read leaves entries without trailer fields rather than failing
+// the log.
+func loadCommitTrailers(commits []git.Commit, mapping map[string]string) map[string][]git.Trailer {
+	if len(mapping) == 0 || len(commits) == 0 {
+		return nil
+	}
+	trailers, err := git.CommitTrailers(extractCommitSHAs(commits))
+	if err != nil {
+		return nil
+	}
+	return trailers
+}
+
+// mapTrailers collects the fields mapping selects from the trailers of
+// commits, oldest commit first. Work-item values that are not system:id are
+// skipped.
+func mapTrailers(mapping map[string]string, commits []git.Commit, trailers map[string][]git.Trailer) trailerFields {
+	var fields trailerFields
+	for _, commit := range slices.Backward(commits) {
+		for _, trailer := range trailers[commit.SHA] {
+			fields.add(mapping[strings.ToLower(trailer.Key)], trailer)
+		}
+	}
+	return fields
+}
+
+// add files one trailer under target.
+func (fields *trailerFields) add(target string, trailer git.Trailer) {
+	switch {
+	case target == trailerTargetTag:
+		fields.tags = append(fields.tags, trailer.Value)
+	case target == trailerTargetNotes:
+		fields.notes = append(fields.notes, trailer.Key+": "+trailer.Value)
+	case strings.HasPrefix(target, trailerTargetWorkItem):
+		item := trailer.Value
+		if system, ok := strings.CutPrefix(target, trailerTargetWorkItem+":"); ok {
+			item = system + ":" + trailer.Value
+		}
+		if system, itemID, err := parseWorkItem(item); err == nil {
+			fields.workItems = append(fields.workItems, ledger.WorkItem{System: system, ID: itemID})
+		}
+	}
+}
+
+// apply merges fields into entry, skipping values it already has.
+func (fields trailerFields) apply(entry *ledger.Entry) {
+	for _, item := range fields.workItems {
+		if !slices.Contains(entry.WorkItems, item) {
+			entry.WorkItems = append(entry.WorkItems, item)
+		}
+	}
+	if len(fields.tags) > 0 {
+		// Batch entries share the --tag slice; never append into it.
+		entry.Tags = slices.Clone(entry.Tags)
+	}
+	for _, tag := range fields.tags {
+		if !slices.Contains(entry.Tags, tag) {
+			entry.Tags = append(entry.Tags, tag)
+		}
+	}
+	for _, note := range fields.notes {
+		switch {
+		case entry.Notes == "":
+			entry.Notes = note
+		case !slices.Contains(strings.Split(entry.Notes, "\n"), note):
+			entry.Notes += "\n" + note
+		}
+	}
+}
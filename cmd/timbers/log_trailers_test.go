@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// newTrailerRepo returns a repo whose config maps trailers, with a pending
+// commit carrying Ticket, Reviewed-by, and Area trailers.
+func newTrailerRepo(t *testing.T, trailersConfig string) string {
+	t.Helper()
+	dir := newLogAnchorRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, ".timbers"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeAndCommit(t, dir, ".timbers/config.toml", "[trailers]\n"+trailersConfig, "chore: timbers config")
+	writeAndCommit(t, dir, "login.go", "package main\n",
+		"fix: login redirect\n\nTicket: PROJ-42\nReviewed-by: Ada <ada@example.com>\nArea: auth\n")
+	return dir
+}
+
+func TestLogMapsConfiguredTrailers(t *testing.T) {
+	dir := newTrailerRepo(t, "ticket = \"work-item:jira\"\nReviewed-By = \"notes\"\narea = \"tag\"\n")
+
+	out, err := runLogCmd(t, dir, "Fix login", "--why", "Redirect lost", "--how", "Keep URL", "--tag", "bugfix")
+	if err != nil {
+		t.Fatalf("timbers log errored: %v\noutput: %s", err, out)
+	}
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if want := []ledger.WorkItem{{System: "jira", ID: "PROJ-42"}}; !reflect.DeepEqual(entry.WorkItems, want) {
+		t.Errorf("work_items = %+v, want %+v", entry.WorkItems, want)
+	}
+	if want := []string{"bugfix", "auth"}; !reflect.DeepEqual(entry.Tags, want) {
+		t.Errorf("tags = %v, want %v", entry.Tags, want)
+	}
+	if entry.Notes != "Reviewed-by: Ada <ada@example.com>" {
+		t.Errorf("notes = %q, want the Reviewed-by trailer", entry.Notes)
+	}
+}
+
+func TestLogRejectsUnknownTrailerTarget(t *testing.T) {
+	dir := newTrailerRepo(t, "ticket = \"summary\"\n")
+
+	out, err := runLogCmd(t, dir, "Fix login", "--why", "Redirect lost", "--how", "Keep URL")
+	if err == nil || !strings.Contains(out, "invalid [trailers] target summary") {
+		t.Errorf("expected an invalid target error, got %v\noutput: %s", err, out)
+	}
+}
+
+func TestTrailerFieldsApplyDoesNotShareTags(t *testing.T) {
+	shared := make([]string, 1, 4)
+	shared[0] = "batch"
+	first := &ledger.Entry{Tags: shared}
+	second := &ledger.Entry{Tags: shared}
+
+	trailerFields{tags: []string{"one"}}.apply(first)
+	trailerFields{tags: []string{"two"}}.apply(second)
+	if !slices.Equal(first.Tags, []string{"batch", "one"}) || !slices.Equal(second.Tags, []string{"batch", "two"}) {
+		t.Errorf("tags = %v and %v, want each entry's own trailer tag", first.Tags, second.Tags)
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/charmbracelet/fang"
 	"github.com/spf13/cobra"
@@ -25,13 +28,25 @@ var (
 
 // isJSONMode reads the --json persistent flag from the command hierarchy.
 // This replaces the former global jsonFlag variable, making commands
-// independently testable without shared mutable state.
+// independently testable without shared mutable state. --yaml, --ndjson,
+// and --query imply structured output too.
 func isJSONMode(cmd *cobra.Command) bool {
 	flag := cmd.Flags().Lookup("json")
 	if flag == nil {
 		// Walk up to root to find the persistent flag
 		flag = cmd.Root().PersistentFlags().Lookup("json")
 	}
+	return (flag != nil && flag.Value.String() == "true") || isYAMLMode(cmd) || isNDJSONMode(cmd) || queryExpr(cmd) != ""
+}
+
+// isYAMLMode reads the --yaml persistent flag from the command hierarchy.
+func isYAMLMode(cmd *cobra.Command) bool {
+	return streamFlag(cmd, "yaml") == "true"
+}
+
+// isNDJSONMode reads the --ndjson flag of commands that list entries.
+func isNDJSONMode(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup("ndjson")
 	return flag != nil && flag.Value.String() == "true"
 }
 
@@ -72,12 +87,33 @@ func main() {
 }
 
 func run() int {
+	// Ctrl-C (or SIGTERM) cancels the command's context instead of killing
+	// the process, so git children are reaped, temp files are removed by
+	// their deferred cleanup, and the exit code says "canceled". Once the
+	// first signal lands, stop restores the default handler: a second Ctrl-C
+	// kills immediately if something is not honoring the context.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
 	cmd := newRootCmd()
-	err := fang.Execute(context.Background(), cmd,
+	if code, ran := dispatchPlugin(ctx, cmd, os.Args[1:], os.Stdin, os.Stdout, os.Stderr); ran {
+		return code
+	}
+	start := time.Now()
+	err := fang.Execute(ctx, cmd,
 		fang.WithVersion(buildVersion()),
-		fang.WithErrorHandler(newErrorHandler(output.IsTTY(os.Stderr))),
+		fang.WithErrorHandler(newErrorHandler(output.IsTTY(os.Stderr) && !output.NoColorEnv())),
 	)
-	return output.GetExitCode(err)
+	code := output.GetExitCode(err)
+	if err != nil && ctx.Err() != nil {
+		code = output.ExitCanceled
+	}
+	recordTelemetry(cmd, os.Args[1:], time.Since(start), code)
+	return code
 }
 
 // newRootCmd creates the root command for the timbers CLI.
@@ -97,37 +133,95 @@ All commands support --json for structured output.`,
 		Version:       buildVersion(),
 		SilenceUsage:  true,
 		SilenceErrors: true,
-		RunE: func(cmd *cobra.Command, _ []string) error {
-			// If --json flag is set but no subcommand, output JSON error
-			if isJSONMode(cmd) {
-				printer := output.NewPrinter(cmd.OutOrStdout(), true, false)
-				err := output.NewUserError("no command specified. Run 'timbers --help' for usage")
-				printer.Error(err)
-				return err
-			}
-			// Otherwise show help
-			return cmd.Help()
-		},
+		RunE:          runRoot,
 	}
 
+	// Change into --repo first, so everything below resolves there.
 	// Load .env.local (then .env) for API keys that can't be exported to env.
 	// Environment variables always take precedence over file values.
-	cmd.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
+	// Then bound the command by --timeout; the cancel func lives in this
+	// closure so each root command cleans up its own deadline.
+	var cancelTimeout context.CancelFunc
+	cmd.PersistentPreRunE = func(sub *cobra.Command, _ []string) error {
+		if err := applyRepoFlag(sub); err != nil {
+			return err
+		}
 		loadEnvFiles()
+		if err := applyGitConfig(); err != nil {
+			newPrinter(sub).Error(err)
+			return err
+		}
+		if err := validateStreamFlags(sub); err != nil {
+			return err
+		}
+		applyVerbosity(sub)
+		if err := validateQueryFlag(sub); err != nil {
+			newPrinter(sub).Error(err)
+			return err
+		}
+		if err := applyReadOnly(sub); err != nil {
+			newPrinter(sub).Error(err)
+			return err
+		}
+		cancelTimeout = applyTimeout(sub)
+		return nil
+	}
+	cmd.PersistentPostRunE = func(_ *cobra.Command, _ []string) error {
+		if cancelTimeout != nil {
+			cancelTimeout()
+		}
 		return nil
 	}
 
+	addPersistentFlags(cmd)
+
+	// Define command groups and add commands
+	addCommandGroups(cmd)
+	addCommands(cmd)
+
+	return cmd
+}
+
+// runRoot runs timbers without a subcommand: help, or a JSON error in
+// --json mode.
+func runRoot(cmd *cobra.Command, _ []string) error {
+	if isJSONMode(cmd) {
+		printer := newPrinter(cmd)
+		err := output.NewUserError("no command specified. Run 'timbers --help' for usage")
+		printer.Error(err)
+		return err
+	}
+	return cmd.Help() //nolint:wrapcheck // cobra help errors are reported as-is
+}
+
+// addPersistentFlags adds the global flags available to every subcommand.
+func addPersistentFlags(cmd *cobra.Command) {
 	// Add persistent --json flag (available to all subcommands)
 	cmd.PersistentFlags().Bool("json", false, "Output in JSON format")
+	cmd.PersistentFlags().Bool("yaml", false, "Output in YAML format (same schema as --json)")
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "Suppress warnings and hints")
+	cmd.PersistentFlags().BoolP("verbose", "v", false, "Show debug detail, such as the git commands run")
+	addRepoFlag(cmd)
 
 	// Add persistent --color flag (available to all subcommands)
 	cmd.PersistentFlags().String("color", "auto", "Color output: never, auto, always")
+	cmd.PersistentFlags().Bool("accessible", accessibleDefault(),
+		"Screen-reader-friendly text: no color, boxes, rules, or symbols (env TIMBERS_ACCESSIBLE)")
 
-	// Define command groups and add commands
-	addCommandGroups(cmd)
-	addCommands(cmd)
+	// Add persistent stream policy flags (env vars set the default for pipelines)
+	cmd.PersistentFlags().String("json-errors", streamDefault("TIMBERS_JSON_ERRORS", streamStdout),
+		"Where JSON errors go: stdout or stderr (env TIMBERS_JSON_ERRORS)")
+	cmd.PersistentFlags().String("warnings", streamDefault("TIMBERS_WARNINGS", streamStderr),
+		"Where human-mode warnings go: stderr or stdout (env TIMBERS_WARNINGS)")
 
-	return cmd
+	// Add persistent --read-only flag (env var sets the default for agents)
+	cmd.PersistentFlags().Bool("read-only", readOnlyDefault(),
+		"Refuse commands and ledger writes that change the repository (env TIMBERS_READ_ONLY)")
+
+	// Add persistent --timeout flag (0 means no limit)
+	var timeout timeoutValue
+	cmd.PersistentFlags().Var(&timeout, "timeout",
+		"Abort the command after this long: git, LLM requests, and pushes (e.g. 30s, 2m; bare numbers are seconds)")
 }
 
 // loadEnvFiles loads env files in priority order. First match for each
@@ -157,36 +251,61 @@ func addCommandGroups(cmd *cobra.Command) {
 
 // addCommands adds all subcommands with their group assignments.
 func addCommands(cmd *cobra.Command) {
-	// Core commands: log, ack, pending, status, amend
+	// Core commands: log, ack, decide, amend, link, rm, pending, status
 	addGroupedCommand(cmd, newLogCmd(), "core")
 	addGroupedCommand(cmd, newAckCmd(), "core")
+	addGroupedCommand(cmd, newDecideCmd(), "core")
 	addGroupedCommand(cmd, newAmendCmd(), "core")
+	addGroupedCommand(cmd, newLinkCmd(), "core")
+	addGroupedCommand(cmd, newRmCmd(), "core")
+	addGroupedCommand(cmd, newMergeEntriesCmd(), "core")
+	addGroupedCommand(cmd, newSplitCmd(), "core")
 	addGroupedCommand(cmd, newPendingCmd(), "core")
 	addGroupedCommand(cmd, newStatusCmd(), "core")
 
-	// Query commands: show, query, export
+	// Query commands: show, query, search, export, why
 	addGroupedCommand(cmd, newShowCmd(), "query")
 	addGroupedCommand(cmd, newQueryCmd(), "query")
+	addGroupedCommand(cmd, newSearchCmd(), "query")
 	addGroupedCommand(cmd, newExportCmd(), "query")
+	addGroupedCommand(cmd, newWhyCmd(), "query")
 
-	// Agent commands: prime, draft, report, generate, serve
+	// Agent commands: prime, draft, report, summarize, review, generate, serve
 	addGroupedCommand(cmd, newPrimeCmd(), "agent")
 	addGroupedCommand(cmd, newDraftCmd(), "agent")
 	addGroupedCommand(cmd, newReportCmd(), "agent")
+	addGroupedCommand(cmd, newSummarizeCmd(), "agent")
+	addGroupedCommand(cmd, newReviewCmd(), "agent")
 	addGroupedCommand(cmd, newGenerateCmd(), "agent")
 	addGroupedCommand(cmd, newServeCmd(), "agent")
+	addGroupedCommand(cmd, newSchemaCmd(), "agent")
 
-	// Admin commands: init, uninstall, doctor, hooks, setup, onboard
+	addAdminCommands(cmd)
+
+	// Hidden internal commands
+	cmd.AddCommand(newHookCmd())
+}
+
+// addAdminCommands adds the admin group: init, uninstall, doctor, lint, fsck,
+// archive, hooks, setup, onboard.
+func addAdminCommands(cmd *cobra.Command) {
 	addGroupedCommand(cmd, newInitCmd(), "admin")
 	addGroupedCommand(cmd, newUninstallCmd(), "admin")
 	addGroupedCommand(cmd, newDoctorCmd(), "admin")
+	addGroupedCommand(cmd, newVersionCmd(), "admin")
+	addGroupedCommand(cmd, newUpgradeCmd(), "admin")
+	addGroupedCommand(cmd, newTelemetryCmd(), "admin")
+	addGroupedCommand(cmd, newPluginsCmd(), "admin")
+	addGroupedCommand(cmd, newLintCmd(), "admin")
+	addGroupedCommand(cmd, newFsckCmd(), "admin")
+	addGroupedCommand(cmd, newArchiveCmd(), "admin")
+	addGroupedCommand(cmd, newRemapCmd(), "admin")
+	addGroupedCommand(cmd, newReanchorCmd(), "admin")
+	addGroupedCommand(cmd, newMigrateCmd(), "admin")
 	addGroupedCommand(cmd, newHooksCmd(), "admin")
 	addGroupedCommand(cmd, newSetupCmd(), "admin")
 	addGroupedCommand(cmd, newOnboardCmd(), "admin")
 	addGroupedCommand(cmd, newTimbersignoreHelpCmd(), "admin")
-
-	// Hidden internal commands
-	cmd.AddCommand(newHookCmd())
 }
 
 // addGroupedCommand adds a subcommand with a group assignment.
@@ -2,9 +2,13 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
 )
 
 func TestRootCommand_Version(t *testing.T) {
@@ -130,6 +134,53 @@ func TestRootCommand_ColorFlag_InHelp(t *testing.T) {
 	}
 }
 
+func TestRootCommand_TimeoutFlag(t *testing.T) {
+	cmd := newRootCmd()
+	flag := cmd.PersistentFlags().Lookup("timeout")
+	if flag == nil {
+		t.Fatal("--timeout flag should be a persistent flag")
+	}
+	if got := getTimeout(cmd); got != 0 {
+		t.Errorf("default timeout = %v, want 0 (no limit)", got)
+	}
+
+	if err := cmd.PersistentFlags().Set("timeout", "30s"); err != nil {
+		t.Fatal(err)
+	}
+	if got := getTimeout(cmd); got != 30*time.Second {
+		t.Errorf("getTimeout() = %v, want 30s", got)
+	}
+}
+
+func TestApplyTimeout_BoundsGitContext(t *testing.T) {
+	t.Cleanup(func() { git.SetContext(nil) })
+
+	cmd := newRootCmd()
+	cmd.SetContext(context.Background())
+	if err := cmd.PersistentFlags().Set("timeout", "1m"); err != nil {
+		t.Fatal(err)
+	}
+
+	cancel := applyTimeout(cmd)
+	if cancel == nil {
+		t.Fatal("applyTimeout() should return a cancel func when --timeout is set")
+	}
+	defer cancel()
+	if _, ok := git.Context().Deadline(); !ok {
+		t.Error("git context should carry the --timeout deadline")
+	}
+
+	// A later command without --timeout must not inherit the deadline.
+	next := newRootCmd()
+	next.SetContext(context.Background())
+	if applyTimeout(next) != nil {
+		t.Error("applyTimeout() without --timeout should return nil")
+	}
+	if _, ok := git.Context().Deadline(); ok {
+		t.Error("git context should be reset when --timeout is unset")
+	}
+}
+
 func TestGetColorMode(t *testing.T) {
 	tests := []struct {
 		name string
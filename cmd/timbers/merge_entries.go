@@ -0,0 +1,254 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// mergeFlags holds the flag values for the merge-entries command.
+type mergeFlags struct {
+	what   string
+	why    string
+	how    string
+	reason string
+	noEdit bool
+	dryRun bool
+}
+
+// newMergeEntriesCmd creates the merge-entries command.
+func newMergeEntriesCmd() *cobra.Command {
+	return newMergeEntriesCmdInternal(nil)
+}
+
+// newMergeEntriesCmdInternal creates the merge-entries command with optional
+// storage injection. If storage is nil, a real storage is created when the
+// command runs.
+func newMergeEntriesCmdInternal(storage *ledger.Storage) *cobra.Command {
+	var flags mergeFlags
+
+	cmd := &cobra.Command{
+		Use:   "merge-entries <entry-id> <entry-id>...",
+		Short: "Combine entries that document the same work into one",
+		Long: `Combine two or more entries into a new one, for work that was logged twice.
+
+The merged entry unions the commits, files, tags, work items, contributors,
+meta fields, and relations of the originals, and joins their summaries.
+In a terminal the joined summary opens in your git editor for a final pass;
+--what, --why, and --how replace a field first, and --no-edit skips the editor.
+Each original gets a tombstone, as with timbers rm, and relations other
+entries hold to an original are pointed at the merged entry. Everything is
+written in one commit.
+
+Examples:
+  timbers merge-entries tb_2026-01-15T15:04:05Z_8f2c1a tb_2026-01-15T16:10:00Z_8f2c1a
+  timbers merge-entries <id> <id> --what "Add OAuth login" --no-edit
+  timbers merge-entries <id> <id> <id> --dry-run --json`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMergeEntries(cmd, storage, args, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.what, "what", "", "Replace the joined what")
+	cmd.Flags().StringVar(&flags.why, "why", "", "Replace the joined why")
+	cmd.Flags().StringVar(&flags.how, "how", "", "Replace the joined how")
+	cmd.Flags().StringVar(&flags.reason, "reason", "", "Reason recorded in the originals' tombstones (default \"merged into <id>\")")
+	cmd.Flags().BoolVar(&flags.noEdit, "no-edit", false, "Do not open the editor on the merged summary")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Show what would be written without writing")
+
+	return cmd
+}
+
+// mergePlan is everything a merge writes.
+type mergePlan struct {
+	merged     *ledger.Entry
+	originals  []*ledger.Entry
+	retargeted []*ledger.Entry
+	tombstones []*ledger.Tombstone
+}
+
+// runMergeEntries executes the merge-entries command.
+func runMergeEntries(cmd *cobra.Command, storage *ledger.Storage, ids []string, flags mergeFlags) error {
+	printer := newPrinter(cmd)
+
+	storage, err := initAmendStorage(storage, printer)
+	if err != nil {
+		return err
+	}
+	plan, err := planMerge(cmd, printer, storage, ids, flags)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	if flags.dryRun {
+		return outputMergeDryRun(printer, plan)
+	}
+	if err := storage.WriteMerge(plan.merged, plan.retargeted, plan.tombstones); err != nil {
+		printer.Error(err)
+		return err
+	}
+	return outputMergeSuccess(printer, plan)
+}
+
+// planMerge reads the originals and builds the merged entry, the retargeted
+// entries, and the tombstones.
+func planMerge(
+	cmd *cobra.Command, printer *output.Printer, storage *ledger.Storage, ids []string, flags mergeFlags,
+) (*mergePlan, error) {
+	originals, err := loadMergeOriginals(storage, ids)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	merged, err := ledger.CombineEntries(originals, now)
+	if err != nil {
+		return nil, output.NewUserError("cannot merge: " + err.Error())
+	}
+	if editErr := editMergedSummary(cmd, printer, merged, originals, flags); editErr != nil {
+		return nil, editErr
+	}
+	merged.ID = storage.NewID(merged.Workset.AnchorCommit, now)
+	merged.Workset.Diffstat = worksetDiffstat(storage, merged.Workset, hasPerFileDiffstat(originals))
+
+	originalIDs := entryIDs(originals)
+	retargeted, err := rewriteRelations(storage, originalIDs, now, func(entry *ledger.Entry) bool {
+		return entry.RetargetRelations(originalIDs, merged.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(flags.reason)
+	if reason == "" {
+		reason = "merged into " + merged.ID
+	}
+	who := resolveAcker()
+	tombstones := make([]*ledger.Tombstone, len(originals))
+	for i, original := range originals {
+		tombstones[i] = &ledger.Tombstone{
+			Schema: ledger.SchemaVersion, Kind: ledger.KindTombstone, ID: ledger.TombstoneID(original.ID),
+			DeletedAt: now, Who: who, TargetID: original.ID, Reason: reason,
+		}
+	}
+	return &mergePlan{merged: merged, originals: originals, retargeted: retargeted, tombstones: tombstones}, nil
+}
+
+// loadMergeOriginals reads the entries to merge, refusing repeats and
+// entries already deleted.
+func loadMergeOriginals(storage *ledger.Storage, ids []string) ([]*ledger.Entry, error) {
+	deleted := storage.DeletedSet()
+	originals := make([]*ledger.Entry, 0, len(ids))
+	for _, id := range ids {
+		entry, err := storage.GetEntryByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if deleted[entry.ID] {
+			return nil, output.NewConflictError("entry already deleted: " + entry.ID).WithID(output.ErrCodeEntryExists)
+		}
+		if slices.ContainsFunc(originals, func(seen *ledger.Entry) bool { return seen.ID == entry.ID }) {
+			return nil, output.NewUserError("entry listed twice: " + entry.ID)
+		}
+		originals = append(originals, entry)
+	}
+	return originals, nil
+}
+
+// editMergedSummary applies --what, --why, and --how, then opens the
+// summary in the editor when a user is at a terminal to edit it.
+func editMergedSummary(
+	cmd *cobra.Command, printer *output.Printer, merged *ledger.Entry, originals []*ledger.Entry, flags mergeFlags,
+) error {
+	for _, override := range []struct {
+		value string
+		field *string
+	}{{flags.what, &merged.Summary.What}, {flags.why, &merged.Summary.Why}, {flags.how, &merged.Summary.How}} {
+		if strings.TrimSpace(override.value) != "" {
+			*override.field = strings.TrimSpace(override.value)
+		}
+	}
+
+	if !flags.noEdit && !flags.dryRun && !printer.IsJSON() && canPromptEditor(cmd.InOrStdin()) {
+		if err := editInEditor(merged, originals); err != nil {
+			return err
+		}
+	}
+	if merged.Summary.What == "" {
+		return output.NewUserError("merge aborted: the merged entry has no what")
+	}
+	return nil
+}
+
+// editInEditor replaces the merged summary and notes with the user's edit.
+func editInEditor(merged *ledger.Entry, originals []*ledger.Entry) error {
+	edited, err := editText(formatSummaryTemplate("Merging "+strings.Join(entryIDs(originals), ", ")+" into one entry.",
+		merged.Summary, merged.Notes))
+	if err != nil {
+		return err
+	}
+	merged.Summary, merged.Notes, err = parseSummaryTemplate(edited)
+	return err
+}
+
+// mergeResult is the JSON form of a merge.
+func mergeResult(plan *mergePlan) map[string]any {
+	return map[string]any{
+		"id":          plan.merged.ID,
+		"merged_from": entryIDs(plan.originals),
+		"retargeted":  entryIDs(plan.retargeted),
+		"entry":       plan.merged,
+	}
+}
+
+// outputMergeDryRun reports what a merge would write.
+func outputMergeDryRun(printer *output.Printer, plan *mergePlan) error {
+	if printer.IsJSON() {
+		actions := make([]plannedAction, 0, 1+len(plan.retargeted)+len(plan.tombstones))
+		actions = append(actions, plannedAction{Action: planCreate, Target: plan.merged.ID, Detail: "merged entry"})
+		for _, entry := range plan.retargeted {
+			actions = append(actions, plannedAction{Action: planModify, Target: entry.ID, Detail: "relations point at " + plan.merged.ID})
+		}
+		for _, tombstone := range plan.tombstones {
+			actions = append(actions, plannedAction{Action: planCreate, Target: tombstone.ID, Detail: "tombstone for " + tombstone.TargetID})
+		}
+		return printer.WriteJSON(withPlan(mergeResult(plan), actions))
+	}
+	printer.Println("Dry run - would merge " + strconv.Itoa(len(plan.originals)) + " entries:")
+	printer.Println()
+	printMergeSummary(printer, plan)
+	return nil
+}
+
+// outputMergeSuccess prints the result after the merge is committed.
+func outputMergeSuccess(printer *output.Printer, plan *mergePlan) error {
+	if printer.IsJSON() {
+		result := mergeResult(plan)
+		result["status"] = "merged"
+		return printer.Success(result)
+	}
+	printer.Println("Merged " + strconv.Itoa(len(plan.originals)) + " entries")
+	printer.Println()
+	printMergeSummary(printer, plan)
+	return nil
+}
+
+// printMergeSummary prints the merged entry and what changed around it.
+func printMergeSummary(printer *output.Printer, plan *mergePlan) {
+	printer.KeyValue("Entry ID", plan.merged.ID)
+	printer.KeyValue("What", plan.merged.Summary.What)
+	printer.KeyValue("Commits", strconv.Itoa(len(plan.merged.Workset.Commits)))
+	for _, tombstone := range plan.tombstones {
+		printer.KeyValue("Deleted", tombstone.TargetID)
+	}
+	for _, entry := range plan.retargeted {
+		printer.KeyValue("Relinked", entry.ID)
+	}
+}
@@ -0,0 +1,133 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// runMergeTest runs the merge-entries command against storage and returns
+// its output.
+func runMergeTest(t *testing.T, storage *ledger.Storage, jsonMode bool, args ...string) (string, error) {
+	t.Helper()
+	cmd := newMergeEntriesCmdInternal(storage)
+	if jsonMode {
+		cmd.PersistentFlags().Bool("json", false, "")
+		_ = cmd.PersistentFlags().Set("json", "true")
+	}
+	buf := &bytes.Buffer{}
+	cmd.SetIn(&bytes.Buffer{}) // not a terminal: never opens an editor
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// setupMergeTestStorage adds a third entry that relates to earlier to the
+// link test storage.
+func setupMergeTestStorage(t *testing.T) (*ledger.Storage, string, string, string, string) {
+	t.Helper()
+	storage, dir, earlier, later := setupLinkTestStorage(t)
+	related := createQueryTestEntryStruct("eee555fff666", "Follow-up", time.Date(2026, 1, 25, 10, 0, 0, 0, time.UTC))
+	related.Relations = []ledger.Relation{{Type: ledger.RelationFixes, ID: earlier}}
+	if err := storage.WriteEntry(related, false); err != nil {
+		t.Fatalf("WriteEntry: %v", err)
+	}
+	return storage, dir, earlier, later, related.ID
+}
+
+func TestMergeEntriesCombinesAndRetargets(t *testing.T) {
+	storage, dir, earlier, later, related := setupMergeTestStorage(t)
+
+	out, err := runMergeTest(t, storage, true, earlier, later, "--what", "Fix the login redirect", "--no-edit")
+	if err != nil {
+		t.Fatalf("merge-entries: %v\n%s", err, out)
+	}
+	var result struct {
+		Status     string   `json:"status"`
+		ID         string   `json:"id"`
+		MergedFrom []string `json:"merged_from"`
+		Retargeted []string `json:"retargeted"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parsing JSON: %v\n%s", err, out)
+	}
+	if result.Status != "merged" || !slices.Equal(result.Retargeted, []string{related}) {
+		t.Errorf("result = %+v", result)
+	}
+
+	merged := readEntryFromDir(t, dir, result.ID)
+	if merged.Summary.What != "Fix the login redirect" {
+		t.Errorf("what = %q, want the --what override", merged.Summary.What)
+	}
+	if len(merged.Workset.Commits) != 2 || merged.Workset.AnchorCommit != "ccc333ddd444" {
+		t.Errorf("workset = %+v, want both commits anchored at the newest", merged.Workset)
+	}
+
+	ids := queryIDs(t, storage)
+	slices.Sort(ids)
+	want := []string{result.ID, related}
+	slices.Sort(want)
+	if !slices.Equal(ids, want) {
+		t.Errorf("query = %v, want %v", ids, want)
+	}
+	if rels := readEntryFromDir(t, dir, related).Relations; len(rels) != 1 || rels[0].ID != result.ID {
+		t.Errorf("relations = %+v, want retargeted at %s", rels, result.ID)
+	}
+
+	if _, err := runMergeTest(t, storage, false, earlier, related, "--no-edit"); err == nil ||
+		!strings.Contains(err.Error(), "already deleted") {
+		t.Errorf("merging a merged entry = %v, want an already deleted error", err)
+	}
+}
+
+func TestMergeEntriesDryRun(t *testing.T) {
+	storage, _, earlier, later, _ := setupMergeTestStorage(t)
+
+	out, err := runMergeTest(t, storage, false, earlier, later, "--dry-run")
+	if err != nil {
+		t.Fatalf("merge-entries --dry-run: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Dry run - would merge 2 entries") || !strings.Contains(out, "Corrected fix; Original fix") {
+		t.Errorf("dry-run output missing plan:\n%s", out)
+	}
+	if ids := queryIDs(t, storage); len(ids) != 3 {
+		t.Errorf("dry run changed the ledger: query = %v", ids)
+	}
+}
+
+func TestMergeEntriesRejectsBadInput(t *testing.T) {
+	storage, _, _, earlier := setupLinkTestStorage(t)
+
+	if _, err := runMergeTest(t, storage, false, earlier, earlier, "--no-edit"); err == nil ||
+		!strings.Contains(err.Error(), "listed twice") {
+		t.Errorf("repeated ID = %v, want a listed twice error", err)
+	}
+	if _, err := runMergeTest(t, storage, false, earlier, "tb_missing", "--no-edit"); err == nil {
+		t.Error("unknown ID expected an error")
+	}
+}
+
+func TestSummaryTemplateRoundTrip(t *testing.T) {
+	summary := ledger.Summary{What: "Add OAuth login", Why: "SSO request\n\nAudit finding", How: "Callback handler"}
+	text := formatSummaryTemplate("Merging two entries.", summary, "See the RFC")
+
+	got, notes, err := parseSummaryTemplate(text)
+	if err != nil {
+		t.Fatalf("parseSummaryTemplate: %v", err)
+	}
+	if got != summary || notes != "See the RFC" {
+		t.Errorf("round trip = %+v, %q", got, notes)
+	}
+
+	if _, _, err := parseSummaryTemplate("## Who\nme\n"); err == nil {
+		t.Error("unknown section expected an error")
+	}
+}
@@ -0,0 +1,160 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// newMigrateCmd creates the migrate command.
+func newMigrateCmd() *cobra.Command {
+	return newMigrateCmdInternal(nil)
+}
+
+// newMigrateCmdInternal creates the migrate command with optional storage
+// injection. If storage is nil, a real storage is created when the command
+// runs.
+func newMigrateCmdInternal(storage *ledger.Storage) *cobra.Command {
+	var toFlag string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate [--to <version>]",
+		Short: "Upgrade ledger records to a newer schema version",
+		Long: `Rewrite every entry, ack, and tombstone written under an older schema
+version (the "schema" field, e.g. timbers.devlog/v1) in the current one.
+
+Each version step has its own transform:
+` + migrationSummaries() + `
+Every migrated record is validated before anything is written; if one would
+break, no file changes. The whole .timbers directory is first copied to
+.git/timbers/backups/<timestamp>/, then the changed files are written
+atomically and committed in one commit. --dry-run prints each file's
+field-level changes instead. Records already at the target version are left
+alone, so migrate is safe to re-run. Older records stay readable without
+migrating.
+
+Examples:
+  timbers migrate --dry-run
+  timbers migrate --to v2
+  timbers migrate --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runMigrate(cmd, storage, toFlag, dryRun)
+		},
+	}
+
+	current := ledger.SchemaVersionOf(ledger.SchemaVersion)
+	cmd.Flags().StringVar(&toFlag, "to", "", "Target schema version (default: the current one, "+current+")")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show each file's changes without writing")
+
+	return cmd
+}
+
+// migrationSummaries describes each migration for the help text, one
+// indented line per version step.
+func migrationSummaries() string {
+	var builder strings.Builder
+	for _, migration := range ledger.Migrations {
+		builder.WriteString("  " + migration.From + " -> " + migration.To + ": " + migration.Summary + "\n")
+	}
+	return builder.String()
+}
+
+// runMigrate executes the migrate command.
+func runMigrate(cmd *cobra.Command, storage *ledger.Storage, toFlag string, dryRun bool) error {
+	printer := newPrinter(cmd)
+
+	target, err := ledger.ParseSchemaVersion(toFlag)
+	if err != nil {
+		err = output.NewUserError("--to: " + err.Error())
+		printer.Error(err)
+		return err
+	}
+	storage, err = ensureStorage(printer, storage)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		changes, planErr := storage.PlanSchemaMigration(target)
+		if planErr != nil {
+			printer.Error(planErr)
+			return planErr
+		}
+		return printMigrateResult(printer, target, changes, "", true)
+	}
+
+	gitDir, err := git.Dir()
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	backupDir := ledger.SchemaBackupPath(gitDir, time.Now())
+	changes, err := storage.MigrateSchema(target, backupDir)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	if len(changes) == 0 {
+		backupDir = ""
+	}
+	return printMigrateResult(printer, target, changes, backupDir, false)
+}
+
+// printMigrateResult reports the migrated (or, on a dry run, migratable)
+// ledger files and their field-level changes.
+func printMigrateResult(printer *output.Printer, target string, changes []ledger.SchemaChange, backupDir string, dryRun bool) error {
+	if printer.IsJSON() {
+		return printMigrateJSON(printer, target, changes, backupDir, dryRun)
+	}
+
+	schema := "timbers.devlog/" + target
+	if len(changes) == 0 {
+		printer.Println("Ledger is already at " + schema)
+		return nil
+	}
+	verb := "Migrated"
+	if dryRun {
+		verb = "Would migrate"
+	}
+	printer.Print("%s %d ledger file(s) to %s\n", verb, len(changes), schema)
+	for _, change := range changes {
+		printer.Print("  %s (%s)\n", change.ID, change.From)
+		for _, line := range change.Diff {
+			printer.Print("    %s\n", line)
+		}
+	}
+	if backupDir != "" {
+		printer.KeyValue("Backup", backupDir)
+	}
+	return nil
+}
+
+// printMigrateJSON writes the migrate result, with a plan on a dry run.
+func printMigrateJSON(printer *output.Printer, target string, changes []ledger.SchemaChange, backupDir string, dryRun bool) error {
+	migrated := make([]map[string]any, 0, len(changes))
+	plan := make([]plannedAction, 0, len(changes))
+	for _, change := range changes {
+		migrated = append(migrated, map[string]any{"id": change.ID, "path": change.Path, "from": change.From, "changes": change.Diff})
+		plan = append(plan, plannedAction{Action: planModify, Target: change.Path, Detail: "migrate " + change.From + " to " + target})
+	}
+	fields := map[string]any{
+		"status":   "migrated",
+		"to":       "timbers.devlog/" + target,
+		"migrated": migrated,
+	}
+	if backupDir != "" {
+		fields["backup"] = backupDir
+	}
+	if !dryRun {
+		return printer.WriteJSON(fields)
+	}
+	return printer.WriteJSON(withPlan(fields, plan))
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// newMigrateRepo returns a repo whose ledger holds one committed
+// timbers.devlog/v1 decision that supersedes another through the old
+// decision.supersedes field.
+func newMigrateRepo(t *testing.T) (string, *ledger.Entry) {
+	t.Helper()
+	dir := newLogAnchorRepo(t)
+	head := strings.TrimSpace(runGitOutput(t, dir, "rev-parse", "HEAD"))
+
+	entry := createQueryTestEntryStruct(head, "Use Postgres", time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC))
+	entry.Schema = "timbers.devlog/v1"
+	entry.Kind = ledger.KindDecision
+	entry.Decision = &ledger.Decision{Status: ledger.DecisionAccepted, Supersedes: "tb_2026-01-01T10:00:00Z_aaaaaa"}
+	writeQueryEntryFile(t, filepath.Join(dir, ".timbers"), entry)
+	runGit(t, dir, "add", ".timbers")
+	runGit(t, dir, "commit", "-m", "add v1 decision", "--no-verify")
+	return dir, entry
+}
+
+// runMigrateCmd runs `timbers migrate --json` in dir and decodes the result.
+func runMigrateCmd(t *testing.T, dir string, args ...string) (map[string]any, error) {
+	t.Helper()
+	var out strings.Builder
+	var execErr error
+	runInDir(t, dir, func() {
+		cmd := newRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append([]string{"migrate", "--json"}, args...))
+		execErr = cmd.Execute()
+	})
+	var result map[string]any
+	if err := json.Unmarshal([]byte(out.String()), &result); err != nil {
+		t.Fatalf("decode %q: %v", out.String(), err)
+	}
+	return result, execErr //nolint:wrapcheck // the command error is asserted as-is
+}
+
+func TestMigrateToV2(t *testing.T) {
+	dir, original := newMigrateRepo(t)
+
+	result, err := runMigrateCmd(t, dir, "--to", "v2")
+	if err != nil {
+		t.Fatalf("migrate --to v2: %v (%v)", err, result)
+	}
+	if migrated, _ := result["migrated"].([]any); len(migrated) != 1 {
+		t.Errorf("result = %v, want one migrated entry", result)
+	}
+	backup, _ := result["backup"].(string)
+	if _, statErr := os.Stat(filepath.Join(backup, ledger.EntryDateDir(original.ID))); statErr != nil {
+		t.Errorf("backup %q missing the entry's directory: %v", backup, statErr)
+	}
+
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if entry.Schema != ledger.SchemaVersion || entry.Decision.Supersedes != "" || entry.SupersedesID() != original.Decision.Supersedes {
+		t.Errorf("migrated entry = schema %s, decision %+v, relations %v", entry.Schema, entry.Decision, entry.Relations)
+	}
+	subject := strings.TrimSpace(runGitOutput(t, dir, "log", "-1", "--format=%s"))
+	if subject != "timbers: migrate ledger to timbers.devlog/v2" {
+		t.Errorf("last commit = %q, want the migration commit", subject)
+	}
+
+	// Already migrated: nothing to do, and no backup.
+	result, err = runMigrateCmd(t, dir)
+	if err != nil {
+		t.Fatalf("second migrate: %v", err)
+	}
+	if migrated, _ := result["migrated"].([]any); len(migrated) != 0 || result["backup"] != nil {
+		t.Errorf("second migrate = %v, want a no-op", result)
+	}
+}
+
+func TestMigrateDryRunWritesNothing(t *testing.T) {
+	dir, _ := newMigrateRepo(t)
+
+	result, err := runMigrateCmd(t, dir, "--dry-run")
+	if err != nil {
+		t.Fatalf("migrate --dry-run: %v (%v)", err, result)
+	}
+	migrated, _ := result["migrated"].([]any)
+	plan, _ := result["planned_actions"].([]any)
+	if len(migrated) != 1 || len(plan) != 1 {
+		t.Fatalf("result = %v, want one planned migration", result)
+	}
+	changes, _ := migrated[0].(map[string]any)["changes"].([]any)
+	if !strings.Contains(jsonString(t, changes), `- decision.supersedes`) {
+		t.Errorf("changes = %v, want decision.supersedes removed", changes)
+	}
+	if schema := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).Schema; schema != "timbers.devlog/v1" {
+		t.Errorf("dry run rewrote the entry: schema %s", schema)
+	}
+}
+
+func TestMigrateRejectsUnknownVersion(t *testing.T) {
+	dir, _ := newMigrateRepo(t)
+
+	if _, err := runMigrateCmd(t, dir, "--to", "v9"); err == nil {
+		t.Error("migrate --to v9 should fail")
+	}
+}
+
+// jsonString encodes value for substring checks.
+func jsonString(t *testing.T, value any) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
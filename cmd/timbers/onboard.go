@@ -55,7 +55,7 @@ Examples:
 
 // runOnboard executes the onboard command.
 func runOnboard(cmd *cobra.Command, formatFlag, targetFlag string) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 
 	// Validate target flag
 	if targetFlag != "claude" && targetFlag != "agents" {
@@ -0,0 +1,80 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// packageFlag is the flag pending, query, and draft share to limit
+// themselves to one [[scope.packages]] entry.
+const packageFlag = "package"
+
+// registerPackageFlag adds --package to cmd.
+func registerPackageFlag(cmd *cobra.Command) {
+	cmd.Flags().String(packageFlag, "",
+		"Limit to one monorepo package from [[scope.packages]] in "+config.ProjectFile+" (see 'timbers init --monorepo')")
+}
+
+// packagePathFilter returns the directory of the package named by --package
+// as a path filter ("services/api/..."), or "" when the flag is not set. An
+// unknown package is a user error naming the configured ones.
+func packagePathFilter(cmd *cobra.Command) (string, error) {
+	name, _ := cmd.Flags().GetString(packageFlag)
+	if name == "" {
+		return "", nil
+	}
+	cfg := loadLogProject()
+	if dir, ok := cfg.PackagePath(name); ok {
+		return strings.TrimSuffix(dir, "/") + "/...", nil
+	}
+	if len(cfg.Scope.Packages) == 0 {
+		return "", output.NewUserError("--package " + name + ": no [[scope.packages]] in " + config.ProjectFile +
+			"; add them with 'timbers init --monorepo <glob>'")
+	}
+	names := make([]string, len(cfg.Scope.Packages))
+	for i, pkg := range cfg.Scope.Packages {
+		names[i] = pkg.Name
+	}
+	return "", output.NewUserError(`unknown --package "` + name + `"; configured: ` + strings.Join(names, ", "))
+}
+
+// packageCommits keeps the pending commits that change files in the
+// --package directory, or all of them when --package is not set.
+func packageCommits(cmd *cobra.Command, commits []git.Commit) ([]git.Commit, error) {
+	filter, err := packagePathFilter(cmd)
+	if err != nil {
+		return nil, err
+	}
+	kept, err := filterCommitsByPath(commits, filter)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to list files changed by pending commits", err)
+	}
+	return kept, nil
+}
+
+// filterCommitsByPath keeps the commits that change a file matching filter
+// (see ledger.MatchPath). An empty filter keeps all.
+func filterCommitsByPath(commits []git.Commit, filter string) ([]git.Commit, error) {
+	if filter == "" || len(commits) == 0 {
+		return commits, nil
+	}
+	files, err := git.CommitFilesMulti(extractCommitSHAs(commits))
+	if err != nil {
+		return nil, err
+	}
+	var kept []git.Commit
+	for _, commit := range commits {
+		if slices.ContainsFunc(files[commit.SHA], func(file string) bool { return ledger.MatchPath(filter, file) }) {
+			kept = append(kept, commit)
+		}
+	}
+	return kept, nil
+}
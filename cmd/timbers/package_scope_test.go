@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newPackageRepo returns a documented repository with api and web packages
+// configured in [[scope.packages]], then one pending commit in each.
+func newPackageRepo(t *testing.T) string {
+	t.Helper()
+	dir := newLogAnchorRepo(t)
+	for _, sub := range []string{".timbers", "services/api", "services/web"} {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.FromSlash(sub)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeAndCommit(t, dir, ".timbers/config.toml",
+		"[[scope.packages]]\nname = \"api\"\npath = \"services/api/\"\n\n"+
+			"[[scope.packages]]\nname = \"web\"\npath = \"services/web/\"\n", "chore: scope packages")
+	if out, err := runLogCmd(t, dir, "Scope packages", "--why", "Per-team ledgers", "--how", "Config"); err != nil {
+		t.Fatalf("timbers log errored: %v\noutput: %s", err, out)
+	}
+	writeAndCommit(t, dir, "services/api/main.go", "package main\n", "feat: api")
+	writeAndCommit(t, dir, "services/web/main.go", "package main\n", "feat: web")
+	return dir
+}
+
+func TestPendingPackage(t *testing.T) {
+	dir := newPackageRepo(t)
+
+	out, err := runTimbersCmd(t, dir, "pending", "--package", "api", "--json")
+	if err != nil {
+		t.Fatalf("pending --package errored: %v\noutput: %s", err, out)
+	}
+	var result pendingResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	if len(result.Commits) != 1 || result.Commits[0].Subject != "feat: api" {
+		t.Errorf("pending --package api = %+v, want only feat: api", result.Commits)
+	}
+}
+
+func TestQueryPackage(t *testing.T) {
+	dir := newPackageRepo(t)
+	if out, err := runLogCmd(t, dir, "--batch", "--group-by", "path-prefix"); err != nil {
+		t.Fatalf("timbers log --batch errored: %v\noutput: %s", err, out)
+	}
+
+	out, err := runTimbersCmd(t, dir, "query", "--last", "10", "--package", "web", "--json")
+	if err != nil {
+		t.Fatalf("query --package errored: %v\noutput: %s", err, out)
+	}
+	var entries []struct {
+		Workset struct {
+			Files []string `json:"files"`
+		} `json:"workset"`
+	}
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	if len(entries) != 1 || strings.Join(entries[0].Workset.Files, ",") != "services/web/main.go" {
+		t.Errorf("query --package web = %+v, want the services/web entry", entries)
+	}
+}
+
+func TestPackageRejectsUnknownName(t *testing.T) {
+	dir := newPackageRepo(t)
+
+	for _, args := range [][]string{
+		{"pending", "--package", "cli"},
+		{"query", "--last", "1", "--package", "cli"},
+		{"draft", "changelog", "--last", "1", "--package", "cli"},
+	} {
+		out, err := runTimbersCmd(t, dir, args...)
+		if err == nil || !strings.Contains(out, `unknown --package "cli"; configured: api, web`) {
+			t.Errorf("%v: err = %v, want unknown package error\noutput: %s", args, err, out)
+		}
+	}
+}
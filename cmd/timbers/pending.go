@@ -18,6 +18,7 @@ type pendingResult struct {
 	LastEntry                *entryReference `json:"last_entry,omitempty"`
 	Commits                  []commitSummary `json:"commits,omitempty"`
 	AnchorOffFirstParentLine bool            `json:"anchor_off_first_parent_line,omitempty"`
+	ShallowHistory           bool            `json:"shallow_history,omitempty"`
 }
 
 // entryReference is a simplified reference to a ledger entry.
@@ -57,7 +58,13 @@ Examples:
   timbers pending              # List all undocumented commits
   timbers pending --count      # Show only the count of pending commits
   timbers pending --explain    # Show why each commit is kept or skipped
-  timbers pending --json       # Output pending commits as JSON`,
+  timbers pending --first-parent  # Count merged branches as their merge
+  timbers pending --package api   # Only commits that change the api package
+  timbers pending --json       # Output pending commits as JSON
+
+With --first-parent (or first_parent = true under [pending] in
+.timbers/config.toml), commits brought in by a merge are not listed on
+their own; the merge itself is pending and is documented as one unit.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runPending(cmd, storage, countOnly, explain)
 		},
@@ -65,6 +72,8 @@ Examples:
 
 	cmd.Flags().BoolVar(&countOnly, "count", false, "Show count only, without commit list")
 	cmd.Flags().BoolVar(&explain, "explain", false, "Classify every commit in range (kept vs skip reason) — verify .timbersignore rules")
+	registerFirstParentFlag(cmd)
+	registerPackageFlag(cmd)
 
 	return cmd
 }
@@ -76,7 +85,7 @@ func acquirePendingStorage(injected *ledger.Storage, printer *output.Printer) (*
 		return injected, nil
 	}
 	if !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return nil, err
 	}
@@ -90,12 +99,14 @@ func acquirePendingStorage(injected *ledger.Storage, printer *output.Printer) (*
 
 // runPending executes the pending command.
 func runPending(cmd *cobra.Command, storage *ledger.Storage, countOnly, explain bool) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd).
+		WithWidth(output.TerminalWidth(cmd.OutOrStdout(), 80))
 
 	storage, err := acquirePendingStorage(storage, printer)
 	if err != nil {
 		return err
 	}
+	applyFirstParentFlag(cmd, storage)
 
 	// During rebase/merge/cherry-pick, pending counts are unreliable —
 	// check early to avoid wasted git work that produces garbage results.
@@ -119,17 +130,25 @@ func runPending(cmd *cobra.Command, storage *ledger.Storage, countOnly, explain
 	if errors.Is(err, ledger.ErrStaleAnchor) {
 		return outputStaleAnchor(printer, latest)
 	}
+	if commits, err = packageCommits(cmd, commits); err != nil {
+		printer.Error(err)
+		return err
+	}
 
 	// Build result
 	result := buildPendingResult(commits, latest)
 	result.AnchorOffFirstParentLine = anchorOffFirstParent(storage)
+	result.ShallowHistory = storage.IsShallow()
+	if result.ShallowHistory {
+		printer.Warning("Shallow clone: pending covers only the fetched history. For exact results: %s", git.UnshallowHint)
+	}
 
 	// Output based on mode
 	if printer.IsJSON() {
 		return outputPendingJSON(printer, result)
 	}
 
-	outputPendingHuman(printer, result, countOnly)
+	outputPendingHuman(printer, result, countOnly, newLinks(printer, nil))
 	return nil
 }
 
@@ -166,7 +185,7 @@ func outputStaleAnchor(printer *output.Printer, latest *ledger.Entry) error {
 		return printer.Success(data)
 	}
 
-	printer.Warn("Anchor commit no longer in history (likely squash merge or rebase)")
+	printer.Warning("Anchor commit no longer in history (likely squash merge or rebase)")
 	printer.Println("No action needed — do not re-document. The anchor self-heals on your next timbers log.")
 	return nil
 }
@@ -183,7 +202,7 @@ func outputMidOperation(printer *output.Printer) error {
 		})
 	}
 
-	printer.Warn("Git operation in progress (rebase, merge, or cherry-pick)")
+	printer.Warning("Git operation in progress (rebase, merge, or cherry-pick)")
 	printer.Println("Pending count unreliable — complete the operation, then check again.")
 	return nil
 }
@@ -217,7 +236,7 @@ func buildPendingResult(commits []git.Commit, latest *ledger.Entry) *pendingResu
 }
 
 // outputPendingJSON outputs the result as JSON.
-func outputPendingJSON(printer *output.Printer, result *pendingResult) error {
+func outputPendingJSON(printer output.Reporter, result *pendingResult) error {
 	// No entries yet — report clean state so agents detect fresh install.
 	if result.LastEntry == nil {
 		return printer.Success(map[string]any{
@@ -235,6 +254,9 @@ func outputPendingJSON(printer *output.Printer, result *pendingResult) error {
 	if result.AnchorOffFirstParentLine {
 		data["anchor_off_first_parent_line"] = true
 	}
+	if result.ShallowHistory {
+		data["shallow_history"] = true
+	}
 
 	// Add suggested commands based on state
 	if result.Count > 0 {
@@ -247,7 +269,7 @@ func outputPendingJSON(printer *output.Printer, result *pendingResult) error {
 }
 
 // outputPendingHuman outputs the result in human-readable format.
-func outputPendingHuman(printer *output.Printer, result *pendingResult, countOnly bool) {
+func outputPendingHuman(printer *output.Printer, result *pendingResult, countOnly bool, linker *links) {
 	// No entries yet — fresh install, show friendly message instead of
 	// dumping the entire pre-timbers history as "pending" work.
 	if result.LastEntry == nil {
@@ -281,19 +303,19 @@ func outputPendingHuman(printer *output.Printer, result *pendingResult, countOnl
 	// Build table rows from commits
 	rows := make([][]string, 0, len(result.Commits))
 	for _, c := range result.Commits {
-		rows = append(rows, []string{c.Short, c.Subject})
+		rows = append(rows, []string{linker.commit(c.SHA, c.Short), c.Subject})
 	}
 
 	// Render commit table
-	printer.Table([]string{"SHA", "Subject"}, rows)
+	printer.TableWith([]string{"SHA", "Subject"}, rows, output.TableOptions{Fit: true})
 
 	// Summary with count
 	printer.Println()
-	printer.KeyValue("Count", strconv.Itoa(result.Count))
-
+	summary := []output.Field{{Key: "Count", Value: strconv.Itoa(result.Count)}}
 	if result.LastEntry != nil {
-		printer.KeyValue("Since", result.LastEntry.ID)
+		summary = append(summary, output.Field{Key: "Since", Value: result.LastEntry.ID})
 	}
+	printer.KV(summary)
 
 	// Suggest command
 	printer.Println()
@@ -311,7 +333,7 @@ func outputPendingHuman(printer *output.Printer, result *pendingResult, countOnl
 	// situation and point at the existing escape hatches.
 	if result.AnchorOffFirstParentLine {
 		printer.Println()
-		printer.Warn("Latest entry's anchor is on a merged-in side branch")
+		printer.Warning("Latest entry's anchor is on a merged-in side branch")
 		printer.Println("This is the cross-agent merge case. Coverage from side-branch entries")
 		printer.Println("still applies via docSet, but the linear `since-anchor` model is opaque here.")
 		printer.Println("Escape hatches:")
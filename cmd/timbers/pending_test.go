@@ -3,6 +3,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -28,44 +29,58 @@ type mockGitOpsForPending struct {
 	anchorOnFirstParent bool // returned by IsOnFirstParentLine; default false models the Laura case
 }
 
-func (m *mockGitOpsForPending) HEAD() (string, error) {
+func (m *mockGitOpsForPending) HEAD(_ context.Context) (string, error) {
 	return m.head, m.headErr
 }
 
-func (m *mockGitOpsForPending) Log(fromRef, toRef string) ([]git.Commit, error) {
+func (m *mockGitOpsForPending) Log(_ context.Context, fromRef, toRef string) ([]git.Commit, error) {
 	return m.commits, m.commitsErr
 }
 
-func (m *mockGitOpsForPending) LogFirstParent(fromRef, toRef string) ([]git.Commit, error) {
+func (m *mockGitOpsForPending) LogFirstParent(_ context.Context, fromRef, toRef string) ([]git.Commit, error) {
 	return m.commits, m.commitsErr
 }
 
-func (m *mockGitOpsForPending) ResolveCommit(ref string) (string, error) {
+func (m *mockGitOpsForPending) ResolveCommit(_ context.Context, ref string) (string, error) {
 	return ref, nil
 }
 
-func (m *mockGitOpsForPending) CommitsReachableFrom(sha string) ([]git.Commit, error) {
+func (m *mockGitOpsForPending) CommitsReachableFrom(_ context.Context, sha string) ([]git.Commit, error) {
 	return m.reachableResult, m.reachableErr
 }
 
-func (m *mockGitOpsForPending) IsAncestorOf(ancestor, descendant string) bool {
+func (m *mockGitOpsForPending) CommitsReachableFromFirstParent(ctx context.Context, sha string) ([]git.Commit, error) {
+	return m.CommitsReachableFrom(ctx, sha)
+}
+
+func (m *mockGitOpsForPending) IsAncestorOf(_ context.Context, ancestor, descendant string) bool {
 	return true
 }
 
-func (m *mockGitOpsForPending) IsOnFirstParentLine(sha, head string) bool {
+func (m *mockGitOpsForPending) IsOnFirstParentLine(_ context.Context, sha, head string) bool {
 	return m.anchorOnFirstParent
 }
 
-func (m *mockGitOpsForPending) GetDiffstat(fromRef, toRef string) (git.Diffstat, error) {
+func (m *mockGitOpsForPending) IsShallow(_ context.Context) bool {
+	return false
+}
+
+func (m *mockGitOpsForPending) GetDiffstat(_ context.Context, fromRef, toRef string) (git.Diffstat, error) {
 	return git.Diffstat{}, nil
 }
 
-func (m *mockGitOpsForPending) CommitFiles(sha string) ([]string, error) { return nil, nil }
-func (m *mockGitOpsForPending) CommitFilesMulti(shas []string) (map[string][]string, error) {
+func (m *mockGitOpsForPending) CommitFiles(_ context.Context, sha string) ([]string, error) {
+	return nil, nil
+}
+func (m *mockGitOpsForPending) CommitFilesMulti(_ context.Context, shas []string) (map[string][]string, error) {
 	return make(map[string][]string), nil
 }
 
-func (m *mockGitOpsForPending) DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
+func (m *mockGitOpsForPending) CommitFilesMultiFirstParent(ctx context.Context, shas []string) (map[string][]string, error) {
+	return m.CommitFilesMulti(ctx, shas)
+}
+
+func (m *mockGitOpsForPending) DiffNameOnly(_ context.Context, fromRef, toRef, pathPrefix string) ([]string, error) {
 	return nil, nil
 }
 
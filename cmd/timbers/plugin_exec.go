@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// pluginFlagEnv maps the root flags that reach a plugin through the
+// environment to their variables. Where timbers reads the same variable as
+// the flag's default, a plugin calling back through TIMBERS_BIN inherits the
+// setting too; for --read-only that keeps a plugin from writing what
+// 'timbers --read-only log' would refuse.
+var pluginFlagEnv = map[string]string{
+	"color":       "TIMBERS_COLOR",
+	"yaml":        "TIMBERS_YAML",
+	"quiet":       "TIMBERS_QUIET",
+	"verbose":     "TIMBERS_VERBOSE",
+	"accessible":  "TIMBERS_ACCESSIBLE",
+	"json-errors": "TIMBERS_JSON_ERRORS",
+	"warnings":    "TIMBERS_WARNINGS",
+	"read-only":   "TIMBERS_READ_ONLY",
+}
+
+// pluginWaitDelay is how long a canceled plugin's children may keep its
+// stdio open before timbers stops waiting for them.
+const pluginWaitDelay = time.Second
+
+// pluginCall is a command line resolved to a plugin.
+type pluginCall struct {
+	name    string
+	args    []string
+	json    bool
+	dir     string
+	timeout string   // --timeout, unparsed
+	env     []string // NAME=value for root flags in pluginFlagEnv
+	dropped string   // a root flag a plugin cannot receive, refused at dispatch
+}
+
+// dispatchPlugin runs the plugin args name, if args name a command that is
+// not built in and timbers-<name> is on PATH. Reports whether a plugin ran,
+// and its exit code. Root flags before the name are consumed: --json and
+// those in pluginFlagEnv reach the plugin through the environment,
+// -C/--repo sets its working directory, and --timeout bounds it like any
+// command. The plugin is killed when ctx is canceled (Ctrl-C).
+func dispatchPlugin(
+	ctx context.Context, root *cobra.Command, args []string, stdin io.Reader, stdout, stderr io.Writer,
+) (int, bool) {
+	call, ok := parsePluginCall(root, args)
+	if !ok || isBuiltinCommand(root, call.name) {
+		return 0, false
+	}
+	path, err := exec.LookPath(pluginPrefix + call.name)
+	if err != nil {
+		return 0, false
+	}
+	if call.dropped != "" {
+		_, _ = fmt.Fprintf(stderr, "Error: --%s is not supported for plugins; pass it to the plugin after its name\n", call.dropped)
+		return output.ExitUserError, true
+	}
+	var timeout timeoutValue
+	if call.timeout != "" {
+		if err := timeout.Set(call.timeout); err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error: --timeout: %v\n", err)
+			return output.ExitUserError, true
+		}
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout))
+		defer cancel()
+	}
+
+	plugin := exec.CommandContext(ctx, path, call.args...) //nolint:gosec // running plugins from PATH is the feature
+	plugin.Stdin, plugin.Stdout, plugin.Stderr = stdin, stdout, stderr
+	plugin.Dir = call.dir
+	plugin.WaitDelay = pluginWaitDelay
+	plugin.Env = append(os.Environ(), pluginEnv(call)...)
+	err = plugin.Run()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return output.ExitSuccess, true
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		_, _ = fmt.Fprintf(stderr, "Error: plugin %s timed out after %s\n", path, time.Duration(timeout))
+		return output.ExitSystemError, true
+	case ctx.Err() != nil:
+		return output.ExitCanceled, true
+	case errors.As(err, &exitErr):
+		return exitErr.ExitCode(), true
+	default:
+		_, _ = fmt.Fprintf(stderr, "Error: running plugin %s: %v\n", path, err)
+		return output.ExitSystemError, true
+	}
+}
+
+// parsePluginCall finds the subcommand name in args, skipping root flags
+// (and the values of those that take one). Returns false when args hold no
+// name, or stop at "--".
+func parsePluginCall(root *cobra.Command, args []string) (pluginCall, bool) {
+	var call pluginCall
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return call, false
+		}
+		if !strings.HasPrefix(arg, "-") {
+			call.name = arg
+			call.args = args[i+1:]
+			call.json = call.json || containsFlag(call.args, "--json")
+			return call, true
+		}
+
+		if consumesNext := call.applyRootFlag(root, arg, args[i+1:]); consumesNext {
+			i++ // the flag's value is the next argument
+		}
+	}
+	return call, false
+}
+
+// applyRootFlag records a root flag for the plugin and reports whether the
+// flag takes the next argument as its value. A root flag with no way to
+// reach the plugin is recorded as dropped.
+func (call *pluginCall) applyRootFlag(root *cobra.Command, arg string, rest []string) bool {
+	name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+	flag := root.PersistentFlags().Lookup(name)
+	if len(name) == 1 {
+		flag = root.PersistentFlags().ShorthandLookup(name)
+	}
+	if flag == nil {
+		return false
+	}
+	if !hasValue && len(rest) > 0 {
+		value = rest[0]
+	}
+	isBool := flag.NoOptDefVal != ""
+	if isBool && !hasValue {
+		value = flag.NoOptDefVal
+	}
+	switch env, forwarded := pluginFlagEnv[flag.Name]; {
+	case flag.Name == "json":
+		call.json = value == "true"
+	case flag.Name == repoFlag:
+		call.dir = value
+	case flag.Name == "timeout":
+		call.timeout = value
+	case forwarded && isBool:
+		call.env = append(call.env, env+"="+boolEnv(value))
+	case forwarded:
+		call.env = append(call.env, env+"="+value)
+	default:
+		call.dropped = flag.Name
+	}
+	return !hasValue && !isBool
+}
+
+// boolEnv renders a bool flag value as "1" or "0".
+func boolEnv(value string) string {
+	if enabled, err := strconv.ParseBool(value); err == nil && enabled {
+		return "1"
+	}
+	return "0"
+}
+
+// containsFlag reports whether args contain flag, bare or as flag=true.
+func containsFlag(args []string, flag string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		if arg == flag || arg == flag+"=true" {
+			return true
+		}
+	}
+	return false
+}
+
+// pluginEnv returns the variables a plugin receives on top of the caller's
+// environment.
+func pluginEnv(call pluginCall) []string {
+	var env []string
+	if call.json {
+		env = append(env, "TIMBERS_JSON=1")
+	}
+	env = append(env, call.env...)
+	if exe, err := os.Executable(); err == nil {
+		env = append(env, "TIMBERS_BIN="+exe)
+	}
+	return env
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix is the executable name prefix that makes a program on PATH
+// a timbers plugin: timbers-foo runs as 'timbers foo'.
+const pluginPrefix = "timbers-"
+
+// pluginInfo describes one plugin found on PATH.
+type pluginInfo struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Shadowed is set when a built-in command has the same name; the
+	// built-in always wins.
+	Shadowed bool `json:"shadowed"`
+}
+
+// newPluginsCmd creates the plugins parent command with subcommands.
+func newPluginsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugins",
+		Short: "Manage external subcommand plugins",
+		Long: `Manage external subcommand plugins.
+
+Like git, timbers runs any executable named timbers-<name> on PATH as
+'timbers <name>' when <name> is not a built-in command. Arguments after the
+name are passed through unchanged, as are stdin, stdout, stderr, the
+environment, and the exit code. Plugins also receive:
+
+  TIMBERS_JSON=1      when --json was given (before or after the name)
+  TIMBERS_COLOR       the --color setting, when given
+  TIMBERS_BIN         path of the timbers binary, for calling back into it
+
+Other root flags before the name arrive the same way: --yaml, -q, and -v
+as TIMBERS_YAML, TIMBERS_QUIET, and TIMBERS_VERBOSE (1 or 0), and
+--accessible, --json-errors, --warnings, and --read-only as the variables
+timbers itself reads for them, so calls back through TIMBERS_BIN honor them. -C/--repo sets the plugin's working directory, and
+--timeout and Ctrl-C stop the plugin like any command.
+
+Subcommands:
+  list  Show plugins found on PATH
+
+Examples:
+  timbers plugins list
+  timbers plugins list --json`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "Show plugins found on PATH",
+		Args:  cobra.NoArgs,
+		RunE:  runPluginsList,
+	})
+	return cmd
+}
+
+// runPluginsList executes the plugins list subcommand.
+func runPluginsList(cmd *cobra.Command, _ []string) error {
+	printer := newPrinter(cmd)
+	plugins := findPlugins(os.Getenv("PATH"), cmd.Root())
+
+	if printer.IsJSON() {
+		return printer.WriteJSON(map[string]any{"plugins": plugins})
+	}
+	if len(plugins) == 0 {
+		printer.Println("No plugins found. Put an executable named " + pluginPrefix + "<name> on PATH.")
+		return nil
+	}
+	rows := make([][]string, 0, len(plugins))
+	for _, plugin := range plugins {
+		note := ""
+		if plugin.Shadowed {
+			note = "shadowed by built-in"
+		}
+		rows = append(rows, []string{plugin.Name, plugin.Path, note})
+	}
+	printer.Table([]string{"NAME", "PATH", ""}, rows)
+	return nil
+}
+
+// findPlugins scans the directories of path for plugin executables. When a
+// name appears in several directories, the first wins, as it would when
+// run.
+func findPlugins(path string, root *cobra.Command) []pluginInfo {
+	seen := make(map[string]bool)
+	var plugins []pluginInfo
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry)
+			if !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, pluginInfo{
+				Name:     name,
+				Path:     filepath.Join(dir, entry.Name()),
+				Shadowed: isBuiltinCommand(root, name),
+			})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// pluginName returns the plugin name for a PATH entry, and whether the
+// entry is an executable plugin at all.
+func pluginName(entry os.DirEntry) (string, bool) {
+	fileName := entry.Name()
+	if !strings.HasPrefix(fileName, pluginPrefix) || entry.IsDir() {
+		return "", false
+	}
+	info, err := entry.Info()
+	if err != nil {
+		return "", false
+	}
+	name := strings.TrimPrefix(fileName, pluginPrefix)
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	} else if info.Mode().Perm()&0o111 == 0 {
+		return "", false
+	}
+	return name, name != ""
+}
+
+// isBuiltinCommand reports whether name (or an alias) is a subcommand of
+// root.
+func isBuiltinCommand(root *cobra.Command, name string) bool {
+	for _, sub := range root.Commands() {
+		if sub.Name() == name || sub.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help" || name == "completion"
+}
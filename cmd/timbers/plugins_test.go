@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// writePlugin puts an executable shell script named timbers-<name> in dir.
+func writePlugin(t *testing.T, dir, name, script string) {
+	t.Helper()
+	path := filepath.Join(dir, pluginPrefix+name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil { //nolint:gosec // test plugin must be executable
+		t.Fatal(err)
+	}
+}
+
+func TestParsePluginCall(t *testing.T) {
+	root := newRootCmd()
+	tests := []struct {
+		args     []string
+		wantName string
+		wantArgs []string
+		wantJSON bool
+		wantEnv  string
+		wantOK   bool
+	}{
+		{[]string{"foo", "a", "b"}, "foo", []string{"a", "b"}, false, "", true},
+		{[]string{"--json", "foo"}, "foo", []string{}, true, "", true},
+		{[]string{"foo", "--json"}, "foo", []string{"--json"}, true, "", true},
+		{[]string{"--timeout", "30s", "--color", "never", "foo", "x"}, "foo", []string{"x"}, false, "TIMBERS_COLOR=never", true},
+		{[]string{"--color=always", "foo"}, "foo", []string{}, false, "TIMBERS_COLOR=always", true},
+		{[]string{"-q", "--warnings", "stdout", "foo"}, "foo", []string{}, false, "TIMBERS_QUIET=1 TIMBERS_WARNINGS=stdout", true},
+		{[]string{"--accessible=false", "foo"}, "foo", []string{}, false, "TIMBERS_ACCESSIBLE=0", true},
+		{[]string{"--json"}, "", nil, true, "", false},
+		{[]string{"--", "foo"}, "", nil, false, "", false},
+	}
+	for _, tt := range tests {
+		call, ok := parsePluginCall(root, tt.args)
+		if call.dir != "" || call.dropped != "" {
+			t.Errorf("parsePluginCall(%v) dir = %q, dropped = %q, want none", tt.args, call.dir, call.dropped)
+		}
+		if ok != tt.wantOK || call.name != tt.wantName || call.json != tt.wantJSON || strings.Join(call.env, " ") != tt.wantEnv {
+			t.Errorf("parsePluginCall(%v) = %+v, %v", tt.args, call, ok)
+			continue
+		}
+		if ok && strings.Join(call.args, " ") != strings.Join(tt.wantArgs, " ") {
+			t.Errorf("parsePluginCall(%v) args = %v, want %v", tt.args, call.args, tt.wantArgs)
+		}
+	}
+}
+
+func TestParsePluginCallRepoFlag(t *testing.T) {
+	root := newRootCmd()
+	for _, args := range [][]string{{"-C", "other", "foo"}, {"--repo", "other", "foo"}, {"--repo=other", "foo"}} {
+		call, ok := parsePluginCall(root, args)
+		if !ok || call.name != "foo" || call.dir != "other" {
+			t.Errorf("parsePluginCall(%v) = %+v, %v; want foo run in other", args, call, ok)
+		}
+	}
+}
+
+func TestDispatchPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "hello", `echo "args=$* json=$TIMBERS_JSON"; exit 7`)
+	writePlugin(t, dir, "log", `echo shadowed`)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	root := newRootCmd()
+
+	t.Run("runs plugin with args, env, and exit code", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code, ran := dispatchPlugin(context.Background(), root, []string{"--json", "hello", "one", "two"}, nil, &stdout, &stderr)
+		if !ran || code != 7 {
+			t.Fatalf("dispatchPlugin() = %d, %v; want 7, true (stderr: %s)", code, ran, stderr.String())
+		}
+		if got := strings.TrimSpace(stdout.String()); got != "args=one two json=1" {
+			t.Errorf("plugin output = %q", got)
+		}
+	})
+
+	t.Run("built-in wins over plugin", func(t *testing.T) {
+		if _, ran := dispatchPlugin(context.Background(), root, []string{"log", "x"}, nil, &bytes.Buffer{}, &bytes.Buffer{}); ran {
+			t.Error("a built-in command must not dispatch to a plugin")
+		}
+	})
+
+	t.Run("unknown command without plugin falls through", func(t *testing.T) {
+		if _, ran := dispatchPlugin(context.Background(), root, []string{"nope"}, nil, &bytes.Buffer{}, &bytes.Buffer{}); ran {
+			t.Error("expected no plugin for an unknown name")
+		}
+	})
+
+	t.Run("list marks shadowed plugins", func(t *testing.T) {
+		plugins := findPlugins(dir, root)
+		if len(plugins) != 2 {
+			t.Fatalf("findPlugins() = %+v, want 2 plugins", plugins)
+		}
+		if plugins[0].Name != "hello" || plugins[0].Shadowed {
+			t.Errorf("plugins[0] = %+v, want unshadowed hello", plugins[0])
+		}
+		if plugins[1].Name != "log" || !plugins[1].Shadowed {
+			t.Errorf("plugins[1] = %+v, want shadowed log", plugins[1])
+		}
+	})
+}
+
+// TestDispatchPluginContext verifies --timeout and cancellation reach the
+// plugin process.
+func TestDispatchPluginContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "slow", `sleep 5`)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	root := newRootCmd()
+
+	t.Run("--timeout kills the plugin", func(t *testing.T) {
+		var stderr bytes.Buffer
+		start := time.Now()
+		code, ran := dispatchPlugin(context.Background(), root, []string{"--timeout", "100ms", "slow"}, nil, &bytes.Buffer{}, &stderr)
+		if !ran || code != output.ExitSystemError || !strings.Contains(stderr.String(), "timed out") {
+			t.Errorf("dispatchPlugin() = %d, %v; want a timeout error (stderr: %s)", code, ran, stderr.String())
+		}
+		if elapsed := time.Since(start); elapsed > 3*time.Second {
+			t.Errorf("plugin ran for %s, want it killed at the timeout", elapsed)
+		}
+	})
+
+	t.Run("canceled context stops the plugin", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		code, ran := dispatchPlugin(ctx, root, []string{"slow"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+		if !ran || code != output.ExitCanceled {
+			t.Errorf("dispatchPlugin() = %d, %v; want %d, true", code, ran, output.ExitCanceled)
+		}
+	})
+
+}
+
+func TestDispatchPluginRefusesDroppedRootFlag(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "hello", `echo ran`)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	root := newRootCmd()
+	root.PersistentFlags().String("profile", "", "a root flag plugins have no variable for")
+
+	var stdout, stderr bytes.Buffer
+	code, ran := dispatchPlugin(context.Background(), root, []string{"--profile", "x", "hello"}, nil, &stdout, &stderr)
+	if !ran || code != output.ExitUserError || stdout.Len() != 0 || !strings.Contains(stderr.String(), "--profile") {
+		t.Errorf("dispatchPlugin() = %d, %v; want a usage error naming --profile (stdout: %s, stderr: %s)",
+			code, ran, stdout.String(), stderr.String())
+	}
+}
+
+// TestDispatchPluginForwardsReadOnly verifies --read-only reaches a plugin as
+// TIMBERS_READ_ONLY, which makes a write through TIMBERS_BIN refused.
+func TestDispatchPluginForwardsReadOnly(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "evil", `printf %s "$TIMBERS_READ_ONLY"`)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	var stdout, stderr bytes.Buffer
+	code, ran := dispatchPlugin(context.Background(), newRootCmd(), []string{"--read-only", "evil"}, nil, &stdout, &stderr)
+	if !ran || code != output.ExitSuccess || stdout.String() != "1" {
+		t.Fatalf("dispatchPlugin() = %d, %v, TIMBERS_READ_ONLY = %q; want 1 (stderr: %s)", code, ran, stdout.String(), stderr.String())
+	}
+
+	// What the plugin's "$TIMBERS_BIN" log would run with.
+	t.Setenv("TIMBERS_READ_ONLY", stdout.String())
+	repo := newLogAnchorRepo(t)
+	out, err := runTimbersCmd(t, repo, "log", "Sneaky", "--why", "w", "--how", "h")
+	if err == nil || !strings.Contains(out, "read-only mode") {
+		t.Errorf("log under the plugin's environment: err = %v, want read-only refusal\noutput: %s", err, out)
+	}
+	if n := countJSONFilesInDir(filepath.Join(repo, ".timbers")); n != 0 {
+		t.Errorf("%d entries written under read-only, want 0", n)
+	}
+}
@@ -120,6 +120,7 @@ Examples:
 	cmd.Flags().BoolVar(&guideFlag, "guide", false, "Alias for --full")
 	cmd.Flags().BoolVar(&hookFlag, "hook", false, "Output compact hook-friendly context")
 	cmd.Flags().BoolVar(&exportFlag, "export", false, "Output default workflow content for customization")
+	cmd.Flags().Bool("include-archived", false, "Include entries moved aside with timbers archive")
 
 	return cmd
 }
@@ -152,7 +153,7 @@ func resolveStorage(storage *ledger.Storage) (*ledger.Storage, error) {
 
 // runPrime executes the prime command.
 func runPrime(cmd *cobra.Command, storage *ledger.Storage, lastN int, verbose bool, full bool) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 
 	resolved, err := resolveStorage(storage)
 	if errors.Is(err, errNotInitialized) {
@@ -166,6 +167,8 @@ func runPrime(cmd *cobra.Command, storage *ledger.Storage, lastN int, verbose bo
 		return err
 	}
 
+	includeArchived(cmd, resolved)
+
 	// Gather all context
 	result, gatherErr := gatherPrimeContext(resolved, lastN, verbose)
 	if gatherErr != nil {
@@ -205,7 +208,7 @@ func gatherPrimeContext(storage *ledger.Storage, lastN int, verbose bool) (*prim
 		return nil, err
 	}
 
-	allEntries, err := storage.ListEntries()
+	entryCount, err := storage.CountEntries()
 	if err != nil {
 		return nil, err
 	}
@@ -239,7 +242,7 @@ func gatherPrimeContext(storage *ledger.Storage, lastN int, verbose bool) (*prim
 		Branch:         branch,
 		Head:           head,
 		TimbersDir:     filepath.Join(root, ".timbers"),
-		EntryCount:     len(allEntries),
+		EntryCount:     entryCount,
 		Pending:        buildPrimePending(pendingCommits, classified),
 		StaleAnchor:    staleAnchor,
 		RecentEntries:  buildPrimeEntries(recentEntries, verbose),
@@ -263,8 +266,7 @@ func loadWorkflowContent(repoRoot string) (string, bool) {
 
 // outputPrimeFullHuman outputs the full guide in human-readable format.
 func outputPrimeFullHuman(printer *output.Printer, result *primeResult) {
-	printer.Println("Timbers Session Context")
-	printer.Println("=======================")
+	printer.Heading("Timbers Session Context", "=")
 	printer.Println()
 	shortHead := result.Head
 	if len(shortHead) > 7 {
@@ -273,8 +275,7 @@ func outputPrimeFullHuman(printer *output.Printer, result *primeResult) {
 	printer.Print("Repository: %s (%s)\n", result.Repo, result.Branch)
 	printer.Print("HEAD: %s\n", shortHead)
 	printer.Println()
-	printer.Println("Ledger Status")
-	printer.Println("-------------")
+	printer.Heading("Ledger Status", "-")
 	printer.Print("  Entries: %d\n", result.EntryCount)
 	switch {
 	case result.StaleAnchor:
@@ -297,8 +298,7 @@ func outputPrimeFullHuman(printer *output.Printer, result *primeResult) {
 
 // outputPrimeRecentWork prints the recent entries section.
 func outputPrimeRecentWork(printer *output.Printer, entries []primeEntry) {
-	printer.Println("Recent Work")
-	printer.Println("-----------")
+	printer.Heading("Recent Work", "-")
 	if len(entries) == 0 {
 		printer.Println("  (no entries)")
 	} else {
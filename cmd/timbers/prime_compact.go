@@ -20,7 +20,11 @@ func outputPrimeCompactHuman(printer *output.Printer, result *primeResult) {
 
 	printer.Println("Rules:")
 	printer.Println(`- After each git commit: timbers log "what" --why "why" --how "how"`)
-	printer.Println("- Order: commit → timbers log → push (never push before logging — it strands the entry)")
+	if printer.IsAccessible() {
+		printer.Println("- Order: commit, then timbers log, then push (never push before logging; it strands the entry)")
+	} else {
+		printer.Println("- Order: commit → timbers log → push (never push before logging — it strands the entry)")
+	}
 	printer.Println("- Before handoff: timbers pending must be 0")
 	printer.Println("- Contributor attribution is automatic; usually omit --who.")
 	printer.Println(`- Pairing/shared/correction: --who "Name <email>" is repeatable and replaces the automatic set.`)
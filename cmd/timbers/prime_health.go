@@ -48,8 +48,15 @@ func outputPrimeHealth(printer *output.Printer, health []primeHealthItem) {
 	if len(health) == 0 {
 		return
 	}
-	printer.Println("Health")
-	printer.Println("------")
+	printer.Heading("Health", "-")
+	if printer.IsAccessible() {
+		for _, item := range health {
+			printer.Print("Warning: %s\n", item.Message)
+		}
+		printer.Println("Run 'timbers doctor --fix' to resolve.")
+		printer.Println()
+		return
+	}
 	for _, item := range health {
 		printer.Print("  !!  %s\n", item.Message)
 	}
@@ -3,6 +3,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -25,44 +26,58 @@ type mockGitOpsForPrime struct {
 	reachableErr    error
 }
 
-func (m *mockGitOpsForPrime) HEAD() (string, error) {
+func (m *mockGitOpsForPrime) HEAD(_ context.Context) (string, error) {
 	return m.head, m.headErr
 }
 
-func (m *mockGitOpsForPrime) Log(fromRef, toRef string) ([]git.Commit, error) {
+func (m *mockGitOpsForPrime) Log(_ context.Context, fromRef, toRef string) ([]git.Commit, error) {
 	return m.commits, m.commitsErr
 }
 
-func (m *mockGitOpsForPrime) LogFirstParent(fromRef, toRef string) ([]git.Commit, error) {
+func (m *mockGitOpsForPrime) LogFirstParent(_ context.Context, fromRef, toRef string) ([]git.Commit, error) {
 	return m.commits, m.commitsErr
 }
 
-func (m *mockGitOpsForPrime) ResolveCommit(ref string) (string, error) {
+func (m *mockGitOpsForPrime) ResolveCommit(_ context.Context, ref string) (string, error) {
 	return ref, nil
 }
 
-func (m *mockGitOpsForPrime) CommitsReachableFrom(sha string) ([]git.Commit, error) {
+func (m *mockGitOpsForPrime) CommitsReachableFrom(_ context.Context, sha string) ([]git.Commit, error) {
 	return m.reachableResult, m.reachableErr
 }
 
-func (m *mockGitOpsForPrime) IsAncestorOf(ancestor, descendant string) bool {
+func (m *mockGitOpsForPrime) CommitsReachableFromFirstParent(ctx context.Context, sha string) ([]git.Commit, error) {
+	return m.CommitsReachableFrom(ctx, sha)
+}
+
+func (m *mockGitOpsForPrime) IsAncestorOf(_ context.Context, ancestor, descendant string) bool {
 	return true
 }
 
-func (m *mockGitOpsForPrime) IsOnFirstParentLine(sha, head string) bool {
+func (m *mockGitOpsForPrime) IsOnFirstParentLine(_ context.Context, sha, head string) bool {
 	return true
 }
 
-func (m *mockGitOpsForPrime) GetDiffstat(fromRef, toRef string) (git.Diffstat, error) {
+func (m *mockGitOpsForPrime) IsShallow(_ context.Context) bool {
+	return false
+}
+
+func (m *mockGitOpsForPrime) GetDiffstat(_ context.Context, fromRef, toRef string) (git.Diffstat, error) {
 	return git.Diffstat{}, nil
 }
 
-func (m *mockGitOpsForPrime) CommitFiles(sha string) ([]string, error) { return nil, nil }
-func (m *mockGitOpsForPrime) CommitFilesMulti(shas []string) (map[string][]string, error) {
+func (m *mockGitOpsForPrime) CommitFiles(_ context.Context, sha string) ([]string, error) {
+	return nil, nil
+}
+func (m *mockGitOpsForPrime) CommitFilesMulti(_ context.Context, shas []string) (map[string][]string, error) {
 	return make(map[string][]string), nil
 }
 
-func (m *mockGitOpsForPrime) DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
+func (m *mockGitOpsForPrime) CommitFilesMultiFirstParent(ctx context.Context, shas []string) (map[string][]string, error) {
+	return m.CommitFilesMulti(ctx, shas)
+}
+
+func (m *mockGitOpsForPrime) DiffNameOnly(_ context.Context, fromRef, toRef, pathPrefix string) ([]string, error) {
 	return nil, nil
 }
 
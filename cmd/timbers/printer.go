@@ -0,0 +1,200 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/jsonquery"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// Stream names accepted by --json-errors and --warnings.
+const (
+	streamStdout = "stdout"
+	streamStderr = "stderr"
+)
+
+// newPrinter creates the printer a command writes through: --json and
+// --color decide the format, errors and warnings get the command's stderr,
+// and --json-errors/--warnings decide which of them land on stdout. Commands
+// should use this rather than output.NewPrinter so the stream policy applies
+// everywhere. A --query expression, on commands that have one, filters
+// every JSON result.
+func newPrinter(cmd *cobra.Command) *output.Printer {
+	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd)).
+		WithStderr(cmd.ErrOrStderr()).
+		WithStreams(streamPolicy(cmd)).
+		WithVerbosity(verbosity(cmd))
+	printer = applyColor(cmd, printer)
+	if streamFlag(cmd, "accessible") == "true" {
+		printer = printer.WithAccessible()
+	}
+	switch {
+	case isYAMLMode(cmd):
+		printer = printer.WithYAML()
+	case isNDJSONMode(cmd):
+		printer = printer.WithNDJSON()
+	}
+	if expr := queryExpr(cmd); expr != "" {
+		// An invalid expression was already rejected by validateQueryFlag.
+		if query, err := jsonquery.Compile(expr); err == nil {
+			printer = printer.WithFilter(func(data any) ([]any, error) {
+				return query.Apply(git.Context(), data)
+			})
+		}
+	}
+	return printer
+}
+
+// applyColor settles color beyond NewPrinter's TTY and NO_COLOR checks:
+// --color always forces it on, as CLICOLOR_FORCE does under --color auto.
+// With color on, the project's [theme] sets the palette.
+func applyColor(cmd *cobra.Command, printer *output.Printer) *output.Printer {
+	mode := getColorMode(cmd)
+	if mode == "always" || (mode != "never" && output.ForceColorEnv()) {
+		printer = printer.WithColor(true)
+	}
+	if !printer.ColorEnabled() {
+		return printer
+	}
+	return printer.WithTheme(projectTheme())
+}
+
+// projectTheme returns the [theme] section of the project config as an
+// output.Theme, or the default theme outside a repository or when the
+// section is invalid (`timbers doctor` reports why).
+func projectTheme() output.Theme {
+	root, err := git.RepoRoot()
+	if err != nil {
+		return output.Theme{}
+	}
+	cfg, err := config.LoadProject(root)
+	if err != nil {
+		return output.Theme{}
+	}
+	theme := output.Theme{Background: cfg.Theme.Background, Colors: cfg.Theme.Colors}
+	if theme.Validate() != nil {
+		return output.Theme{}
+	}
+	return theme
+}
+
+// accessibleDefault reads TIMBERS_ACCESSIBLE, or the ACCESSIBLE convention
+// shared with other terminal tools, as a boolean.
+func accessibleDefault() bool {
+	for _, name := range []string{"TIMBERS_ACCESSIBLE", "ACCESSIBLE"} {
+		if enabled, err := strconv.ParseBool(os.Getenv(name)); err == nil {
+			return enabled
+		}
+	}
+	return false
+}
+
+// queryExpr returns the command's --query expression, or "" when it has
+// none. Only commands that define the flag accept one.
+func queryExpr(cmd *cobra.Command) string {
+	flag := cmd.Flags().Lookup("query")
+	if flag == nil {
+		return ""
+	}
+	return flag.Value.String()
+}
+
+// validateQueryFlag rejects a --query expression that does not compile, so
+// a typo fails before the command does any work.
+func validateQueryFlag(cmd *cobra.Command) error {
+	expr := queryExpr(cmd)
+	if expr == "" {
+		return nil
+	}
+	if _, err := jsonquery.Compile(expr); err != nil {
+		return output.NewUserError(err.Error()).WithID(output.ErrCodeInvalidQuery)
+	}
+	return nil
+}
+
+// streamPolicy reads --json-errors and --warnings from the command
+// hierarchy. Unknown values fall back to the defaults; validateStreamFlags
+// rejects them before a command runs.
+func streamPolicy(cmd *cobra.Command) output.StreamPolicy {
+	return output.StreamPolicy{
+		JSONErrorsToStderr: streamFlag(cmd, "json-errors") == streamStderr,
+		WarningsToStdout:   streamFlag(cmd, "warnings") == streamStdout,
+	}
+}
+
+// streamFlag returns the value of a persistent flag, or "" when the command
+// is not attached to the root (as in unit tests).
+func streamFlag(cmd *cobra.Command, name string) string {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil {
+		flag = cmd.Root().PersistentFlags().Lookup(name)
+	}
+	if flag == nil {
+		return ""
+	}
+	return flag.Value.String()
+}
+
+// verbosity reads -q/--quiet and -v/--verbose. Commands with their own
+// --quiet or --verbose (doctor, status, prime) shadow the global flag; the
+// local value then sets the level too.
+func verbosity(cmd *cobra.Command) output.Verbosity {
+	switch {
+	case streamFlag(cmd, "quiet") == "true":
+		return output.VerbosityQuiet
+	case streamFlag(cmd, "verbose") == "true":
+		return output.VerbosityVerbose
+	default:
+		return output.VerbosityNormal
+	}
+}
+
+// applyVerbosity traces git commands to stderr under --verbose. Always
+// resets the trace, so it never leaks between commands run in one process.
+func applyVerbosity(cmd *cobra.Command) {
+	if verbosity(cmd) < output.VerbosityVerbose {
+		git.SetTrace(nil)
+		return
+	}
+	printer := newPrinter(cmd)
+	git.SetTrace(func(args []string, elapsed time.Duration) {
+		printer.Debug("git %s (%s)", strings.Join(args, " "), elapsed.Round(time.Millisecond))
+	})
+}
+
+// validateStreamFlags rejects --json-errors and --warnings values other
+// than stdout and stderr, --yaml combined with --json or --ndjson, and
+// --quiet combined with --verbose.
+func validateStreamFlags(cmd *cobra.Command) error {
+	if isYAMLMode(cmd) && (streamFlag(cmd, "json") == "true" || isNDJSONMode(cmd)) {
+		return output.NewUserError("--yaml cannot be combined with --json or --ndjson")
+	}
+	if streamFlag(cmd, "quiet") == "true" && streamFlag(cmd, "verbose") == "true" {
+		return output.NewUserError("--quiet and --verbose are mutually exclusive")
+	}
+	for _, name := range []string{"json-errors", "warnings"} {
+		switch value := streamFlag(cmd, name); value {
+		case "", streamStdout, streamStderr:
+		default:
+			return output.NewUserError("--" + name + " must be stdout or stderr, got " + value)
+		}
+	}
+	return nil
+}
+
+// streamDefault returns the value of env, or fallback when it is unset, so
+// pipelines can set the stream policy once for every invocation.
+func streamDefault(env, fallback string) string {
+	if value := os.Getenv(env); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// runStreamTest runs the root command outside a git repository, where show
+// fails, and returns what landed on stdout and stderr.
+func runStreamTest(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	var stdout, stderr bytes.Buffer
+	cmd := newRootCmd()
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestJSONErrorsDefaultToStdout(t *testing.T) {
+	stdout, _, err := runStreamTest(t, "show", "--latest", "--json")
+	if err == nil {
+		t.Fatal("expected an error outside a git repository")
+	}
+	var result map[string]any
+	if jsonErr := json.Unmarshal([]byte(stdout), &result); jsonErr != nil || result["error"] == nil {
+		t.Errorf("expected a JSON error on stdout, got %q", stdout)
+	}
+}
+
+func TestJSONErrorsToStderr(t *testing.T) {
+	stdout, stderr, err := runStreamTest(t, "show", "--latest", "--json", "--json-errors", "stderr")
+	if err == nil {
+		t.Fatal("expected an error outside a git repository")
+	}
+	if stdout != "" {
+		t.Errorf("stdout should stay empty, got %q", stdout)
+	}
+	var result map[string]any
+	if jsonErr := json.Unmarshal([]byte(stderr), &result); jsonErr != nil || result["error"] == nil {
+		t.Errorf("expected a JSON error on stderr, got %q", stderr)
+	}
+}
+
+func TestJSONErrorsFromEnv(t *testing.T) {
+	t.Setenv("TIMBERS_JSON_ERRORS", "stderr")
+	stdout, stderr, _ := runStreamTest(t, "show", "--latest", "--json")
+	if stdout != "" || !strings.Contains(stderr, `"error"`) {
+		t.Errorf("TIMBERS_JSON_ERRORS=stderr not applied: stdout=%q stderr=%q", stdout, stderr)
+	}
+}
+
+func TestHumanErrorsGoToStderr(t *testing.T) {
+	stdout, stderr, err := runStreamTest(t, "show", "--latest")
+	if err == nil {
+		t.Fatal("expected an error outside a git repository")
+	}
+	if stdout != "" {
+		t.Errorf("human error leaked to stdout: %q", stdout)
+	}
+	if !strings.Contains(stderr, "Error") {
+		t.Errorf("expected the error on stderr, got %q", stderr)
+	}
+}
+
+func TestStreamFlagsRejectUnknownValues(t *testing.T) {
+	_, _, err := runStreamTest(t, "status", "--warnings", "syslog")
+	if err == nil || !strings.Contains(err.Error(), "--warnings must be stdout or stderr") {
+		t.Errorf("expected a --warnings validation error, got %v", err)
+	}
+}
+
+func TestQueryFlagRejectsInvalidExpression(t *testing.T) {
+	stdout, _, err := runStreamTest(t, "show", "--latest", "--query", ".[")
+	if err == nil || !strings.Contains(err.Error(), "invalid --query") {
+		t.Fatalf("expected an invalid --query error, got %v", err)
+	}
+	if !strings.Contains(stdout, `"error"`) {
+		t.Errorf("--query implies --json; expected a JSON error on stdout, got %q", stdout)
+	}
+}
+
+func TestQueryFlagFiltersJSON(t *testing.T) {
+	now := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	dir := t.TempDir()
+	first := createQueryTestEntryStruct("anchor1", "first", now.Add(-time.Hour))
+	second := createQueryTestEntryStruct("anchor2", "second", now)
+	writeQueryEntryFile(t, dir, first)
+	writeQueryEntryFile(t, dir, second)
+	files := ledger.NewFileStorage(dir, func(string) error { return nil }, func(string, string) error { return nil })
+
+	cmd := newQueryCmdInternal(ledger.NewStorage(&mockGitOpsForQuery{}, files))
+	cmd.SetArgs([]string{"--last", "5", "--query", ".[].summary.what"})
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := stdout.String(), "\"second\"\n\"first\"\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestYAMLFlag(t *testing.T) {
+	stdout, _, err := runStreamTest(t, "version", "--yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var info map[string]any
+	if yamlErr := yaml.Unmarshal([]byte(stdout), &info); yamlErr != nil || info["version"] == nil {
+		t.Errorf("expected YAML build info, got %q (%v)", stdout, yamlErr)
+	}
+
+	if _, _, err := runStreamTest(t, "version", "--yaml", "--json"); err == nil {
+		t.Error("expected --json with --yaml to be rejected")
+	}
+}
+
+func TestNDJSONFlag(t *testing.T) {
+	now := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	dir := t.TempDir()
+	writeQueryEntryFile(t, dir, createQueryTestEntryStruct("anchor1", "first", now.Add(-time.Hour)))
+	writeQueryEntryFile(t, dir, createQueryTestEntryStruct("anchor2", "second", now))
+	files := ledger.NewFileStorage(dir, func(string) error { return nil }, func(string, string) error { return nil })
+
+	cmd := newExportCmdInternal(ledger.NewStorage(&mockGitOpsForQuery{}, files))
+	cmd.SetArgs([]string{"--last", "5", "--ndjson"})
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per entry, got %q", stdout.String())
+	}
+	for _, line := range lines {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil || entry["id"] == nil {
+			t.Errorf("line %q is not an entry object (%v)", line, err)
+		}
+	}
+}
+
+func TestVerboseTracesGitCommands(t *testing.T) {
+	t.Cleanup(func() { git.SetTrace(nil) })
+	stdout, stderr, _ := runStreamTest(t, "status", "--json", "--verbose")
+	if !strings.Contains(stderr, "debug: git rev-parse") {
+		t.Errorf("expected traced git commands on stderr, got %q", stderr)
+	}
+	if strings.Contains(stdout, "debug:") {
+		t.Errorf("debug output leaked to stdout: %q", stdout)
+	}
+}
+
+func TestQuietAndVerboseConflict(t *testing.T) {
+	if _, _, err := runStreamTest(t, "version", "-q", "-v"); err == nil {
+		t.Error("expected -q with -v to be rejected")
+	}
+}
+
+func TestAccessibleDoctorUsesWords(t *testing.T) {
+	for name, args := range map[string][]string{
+		"flag": {"doctor", "--accessible"},
+		"env":  {"doctor"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if name == "env" {
+				t.Setenv("TIMBERS_ACCESSIBLE", "1")
+			}
+			var stdout bytes.Buffer
+			runInDir(t, newLogAnchorRepo(t), func() {
+				cmd := newRootCmd()
+				cmd.SetOut(&stdout)
+				cmd.SetErr(&bytes.Buffer{})
+				cmd.SetArgs(args)
+				_ = cmd.Execute()
+			})
+			out := stdout.String()
+			for _, symbol := range []string{"  ok  ", "!!", "XX", "->"} {
+				if strings.Contains(out, symbol) {
+					t.Errorf("output contains %q:\n%s", symbol, out)
+				}
+			}
+			if !strings.Contains(out, "passed,") || !strings.Contains(out, "Passed: ") {
+				t.Errorf("output lacks spoken statuses:\n%s", out)
+			}
+		})
+	}
+}
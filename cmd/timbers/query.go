@@ -26,6 +26,9 @@ func newQueryCmdInternal(storage *ledger.Storage) *cobra.Command {
 	var untilFlag string
 	var rangeFlag string
 	var tagFlags []string
+	var kindFlags []string
+	var metaFlags []string
+	var pathFlags []string
 	var onelineFlag bool
 
 	cmd := &cobra.Command{
@@ -43,9 +46,17 @@ Examples:
   timbers query --last 3 --oneline            # Show last 3 in compact format
   timbers query --range v1.0.0..v1.1.0         # Show entries in commit range
   timbers query --last 10 --tag security      # Show last 10 entries tagged with security
-  timbers query --since 7d --tag bug,fix      # Show entries from last week tagged with bug or fix`,
+  timbers query --since 7d --tag bug,fix      # Show entries from last week tagged with bug or fix
+  timbers query --last 20 --kind decision     # Show the last 20 decisions
+  timbers query --since 30d --meta risk=high  # Show recent entries with meta risk=high
+  timbers query --last 10 --path src/auth/... # Show the last 10 entries touching files under src/auth
+  timbers query --last 10 --branch feat/oauth # Show the last 10 entries logged on feat/oauth
+  timbers query --since 30d --reachable-from main  # Show recent entries whose work has reached main
+  timbers query --last 5 --query '.[].id'     # Print just the IDs
+  timbers query --since 30d --ndjson | jq -c .id  # One entry per line`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runQuery(cmd, storage, lastFlag, sinceFlag, untilFlag, rangeFlag, tagFlags, onelineFlag)
+			return runQuery(cmd, storage, lastFlag, sinceFlag, untilFlag, rangeFlag,
+				tagFlags, kindFlags, metaFlags, pathFlags, onelineFlag)
 		},
 	}
 
@@ -54,7 +65,19 @@ Examples:
 	cmd.Flags().StringVar(&untilFlag, "until", "", "Retrieve entries until duration (24h, 7d) or date (2026-01-17)")
 	cmd.Flags().StringVar(&rangeFlag, "range", "", "Retrieve entries in commit range (A..B)")
 	cmd.Flags().StringSliceVar(&tagFlags, "tag", []string{}, "Filter by tag (can specify multiple times or comma-separated)")
+	cmd.Flags().StringSliceVar(&kindFlags, "kind", []string{},
+		"Filter by kind: entry, decision, incident, milestone, note (repeatable or comma-separated)")
+	cmd.Flags().StringArrayVar(&metaFlags, "meta", nil, "Filter by meta field: key=value, or key to require it (repeatable; all must match)")
+	cmd.Flags().StringArrayVar(&pathFlags, "path", nil,
+		"Filter by changed file: a path, a directory, dir/... for everything under it, or a glob (repeatable; any may match)")
+	registerPackageFlag(cmd)
+	cmd.Flags().String("branch", "", "Filter by the branch an entry was logged on")
+	cmd.Flags().String("reachable-from", "", "Keep entries whose anchor commit is in the history of this ref")
+	cmd.Flags().Bool("include-deleted", false, "Include entries deleted with timbers rm")
+	cmd.Flags().Bool("include-archived", false, "Include entries moved aside with timbers archive")
 	cmd.Flags().BoolVar(&onelineFlag, "oneline", false, "Show compact format: <id>  <what>")
+	cmd.Flags().Bool("ndjson", false, "Stream JSON: one entry per line (implies --json)")
+	cmd.Flags().String("query", "", "Filter JSON output through a jq expression (implies --json)")
 
 	return cmd
 }
@@ -66,18 +89,26 @@ type queryParams struct {
 	untilCutoff time.Time
 	rangeStr    string
 	tags        []string
+	match       entryMatch
 }
 
 // runQuery executes the query command.
 func runQuery(
 	cmd *cobra.Command, storage *ledger.Storage,
-	lastFlag, sinceFlag, untilFlag, rangeFlag string, tagFlags []string, onelineFlag bool,
+	lastFlag, sinceFlag, untilFlag, rangeFlag string, tagFlags, kindFlags, metaFlags, pathFlags []string, onelineFlag bool,
 ) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd)).
-		WithStderr(cmd.ErrOrStderr())
+	printer := newPrinter(cmd).
+		WithWidth(output.TerminalWidth(cmd.OutOrStdout(), 80))
 
 	// Parse and validate flags
 	params, err := parseQueryFlags(lastFlag, sinceFlag, untilFlag, rangeFlag, tagFlags)
+	if err == nil {
+		params.match, err = parseEntryMatch(kindFlags, metaFlags)
+		params.match.paths = pathFlags
+	}
+	if err == nil {
+		params.match.pkg, err = packagePathFilter(cmd)
+	}
 	if err != nil {
 		printer.Error(err)
 		return err
@@ -88,51 +119,20 @@ func runQuery(
 	if err != nil {
 		return err
 	}
-
-	allEntries, err := readQueryEntries(printer, storage)
-	if err != nil {
+	includeArchived(cmd, storage)
+	params.match = params.match.hidingDeleted(cmd, storage)
+	if params.match, err = params.match.scopedToHistory(cmd, storage); err != nil {
+		printer.Error(err)
 		return err
 	}
-	entries, err := selectQueryEntries(printer, storage, allEntries, params)
+
+	entries, err := queryEntries(printer, storage, params)
 	if err != nil {
 		return err
 	}
 
 	// Output based on mode
-	return outputQueryResults(printer, entries, onelineFlag)
-}
-
-func readQueryEntries(printer *output.Printer, storage *ledger.Storage) ([]*ledger.Entry, error) {
-	entries, stats, err := storage.ListEntriesWithStats()
-	if err != nil {
-		printer.Error(err)
-		return nil, err
-	}
-	// JSON remains the established entry-array contract. Human reads warn;
-	// artifact-producing commands fail closed instead of emitting partial work.
-	if integrityErr := corruptEntriesError(stats); integrityErr != nil && !printer.IsJSON() {
-		printer.Stderr("timbers: warning: %s\n", integrityErr)
-	}
-	return entries, nil
-}
-
-func selectQueryEntries(
-	printer *output.Printer, storage *ledger.Storage, allEntries []*ledger.Entry, params *queryParams,
-) ([]*ledger.Entry, error) {
-	entries := allEntries
-	if params.rangeStr != "" {
-		var err error
-		entries, err = getEntriesByRangeFromEntries(printer, storage, entries, params.rangeStr)
-		if err != nil {
-			return nil, err
-		}
-	}
-	entries = applyQueryFilters(entries, params.sinceCutoff, params.untilCutoff, params.tags)
-	sortEntriesByCreatedAt(entries)
-	if params.count > 0 && len(entries) > params.count {
-		entries = entries[:params.count]
-	}
-	return entries, nil
+	return outputQueryResults(printer, entries, onelineFlag, newLinks(printer, storage))
 }
 
 // parseQueryFlags validates and parses the query flags.
@@ -213,9 +213,9 @@ func parseQueryTagFlags(tagFlags []string, params *queryParams) {
 }
 
 // initQueryStorage initializes storage, checking for git repo if needed.
-func initQueryStorage(storage *ledger.Storage, printer *output.Printer) (*ledger.Storage, error) {
+func initQueryStorage(storage *ledger.Storage, printer output.Reporter) (*ledger.Storage, error) {
 	if storage == nil && !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return nil, err
 	}
@@ -233,17 +233,17 @@ func initQueryStorage(storage *ledger.Storage, printer *output.Printer) (*ledger
 }
 
 // outputQueryResults outputs entries based on the output mode.
-func outputQueryResults(printer *output.Printer, entries []*ledger.Entry, onelineFlag bool) error {
+func outputQueryResults(printer *output.Printer, entries []*ledger.Entry, onelineFlag bool, linker *links) error {
 	if printer.IsJSON() {
 		return outputQueryJSON(printer, entries)
 	}
 
 	if onelineFlag {
-		outputQueryOneline(printer, entries)
+		outputQueryOneline(printer, entries, linker)
 		return nil
 	}
 
-	outputQueryHuman(printer, entries)
+	outputQueryHuman(printer, entries, linker)
 	return nil
 }
 
@@ -268,25 +268,25 @@ func applyQueryFilters(entries []*ledger.Entry, sinceCutoff, untilCutoff time.Ti
 }
 
 // outputQueryJSON outputs the entries as JSON array.
-func outputQueryJSON(printer *output.Printer, entries []*ledger.Entry) error {
+func outputQueryJSON(printer output.Reporter, entries []*ledger.Entry) error {
 	return printer.WriteJSON(entries)
 }
 
 // outputQueryOneline outputs entries in compact table format: ID | Date | What
-func outputQueryOneline(printer *output.Printer, entries []*ledger.Entry) {
+func outputQueryOneline(printer *output.Printer, entries []*ledger.Entry, linker *links) {
 	headers := []string{"ID", "Date", "What"}
 	rows := make([][]string, 0, len(entries))
 
 	for _, entry := range entries {
-		date := entry.CreatedAt.Format("2006-01-02")
-		rows = append(rows, []string{entry.ID, date, entry.Summary.What})
+		created := entry.CreatedAt.Format("2006-01-02")
+		rows = append(rows, []string{linker.entry(entry.ID), created, entry.Summary.What})
 	}
 
-	printer.Table(headers, rows)
+	printer.TableWith(headers, rows, output.TableOptions{Fit: true})
 }
 
 // outputQueryHuman outputs entries in human-readable format.
-func outputQueryHuman(printer *output.Printer, entries []*ledger.Entry) {
+func outputQueryHuman(printer *output.Printer, entries []*ledger.Entry, linker *links) {
 	if len(entries) == 0 {
 		printer.Println("No entries found")
 		return
@@ -296,17 +296,24 @@ func outputQueryHuman(printer *output.Printer, entries []*ledger.Entry) {
 		if i > 0 {
 			printer.Println("────────────────────────────────────────")
 		}
-		outputQueryEntry(printer, entry)
+		outputQueryEntry(printer, entry, linker)
 	}
 }
 
 // outputQueryEntry outputs a single entry in human-readable format.
-func outputQueryEntry(printer *output.Printer, entry *ledger.Entry) {
-	printer.Section(entry.ID)
+func outputQueryEntry(printer *output.Printer, entry *ledger.Entry, linker *links) {
+	printer.Section(linker.entry(entry.ID))
+	if kind := entry.KindOrDefault(); kind != ledger.KindEntry {
+		printer.KeyValue("Kind", kind)
+	}
 	printer.KeyValue("What", entry.Summary.What)
-	printer.KeyValue("Why", entry.Summary.Why)
-	printer.KeyValue("How", entry.Summary.How)
-	printer.KeyValue("Anchor", anchorDisplay(entry.Workset.AnchorCommit))
+	if entry.Summary.Why != "" {
+		printer.KeyValue("Why", entry.Summary.Why)
+	}
+	if entry.Summary.How != "" {
+		printer.KeyValue("How", entry.Summary.How)
+	}
+	printer.KeyValue("Anchor", linker.anchor(entry.Workset.AnchorCommit))
 	printer.KeyValue("Created", entry.CreatedAt.Format("2006-01-02 15:04:05 UTC"))
 
 	if len(entry.Tags) > 0 {
@@ -0,0 +1,72 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// queryEntries selects the entries a query prints, newest first. A plain
+// --last N, optionally time-bounded, reads only the entries it needs; a
+// range, tag, kind, or meta filter scans the whole ledger.
+func queryEntries(printer *output.Printer, storage *ledger.Storage, params *queryParams) ([]*ledger.Entry, error) {
+	if params.rangeStr != "" || len(params.tags) > 0 || params.match.pageLimit(params.count) == 0 {
+		allEntries, err := readQueryEntries(printer, storage)
+		if err != nil {
+			return nil, err
+		}
+		return selectQueryEntries(printer, storage, allEntries, params)
+	}
+	entries, stats, err := storage.ListWithStats(ledger.ListOptions{
+		Since: params.sinceCutoff,
+		Until: params.untilCutoff,
+		Limit: params.match.pageLimit(params.count),
+	})
+	if err != nil {
+		printer.Error(err)
+		return nil, err
+	}
+	if integrityErr := corruptEntriesError(stats); integrityErr != nil {
+		printer.Warning("%s", integrityErr)
+	}
+	entries = params.match.apply(entries)
+	if len(entries) > params.count {
+		entries = entries[:params.count]
+	}
+	return entries, nil
+}
+
+func readQueryEntries(printer output.Reporter, storage *ledger.Storage) ([]*ledger.Entry, error) {
+	entries, stats, err := storage.ListEntriesWithStats()
+	if err != nil {
+		printer.Error(err)
+		return nil, err
+	}
+	// Reads warn (JSON keeps the entry-array contract on stdout, so the
+	// warning goes to stderr); artifact-producing commands fail closed
+	// instead of emitting partial work.
+	if integrityErr := corruptEntriesError(stats); integrityErr != nil {
+		printer.Warning("%s", integrityErr)
+	}
+	return entries, nil
+}
+
+func selectQueryEntries(
+	printer *output.Printer, storage *ledger.Storage, allEntries []*ledger.Entry, params *queryParams,
+) ([]*ledger.Entry, error) {
+	entries := allEntries
+	if params.rangeStr != "" {
+		var err error
+		entries, err = getEntriesByRangeFromEntries(printer, storage, entries, params.rangeStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	entries = applyQueryFilters(entries, params.sinceCutoff, params.untilCutoff, params.tags)
+	entries = params.match.apply(entries)
+	sortEntriesByCreatedAt(entries)
+	if params.count > 0 && len(entries) > params.count {
+		entries = entries[:params.count]
+	}
+	return entries, nil
+}
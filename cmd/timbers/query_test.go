@@ -2,6 +2,8 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -15,44 +17,58 @@ import (
 // mockGitOpsForQuery implements ledger.GitOps for testing query command.
 type mockGitOpsForQuery struct{}
 
-func (m *mockGitOpsForQuery) HEAD() (string, error) {
+func (m *mockGitOpsForQuery) HEAD(_ context.Context) (string, error) {
 	return "abc123def456", nil
 }
 
-func (m *mockGitOpsForQuery) Log(fromRef, toRef string) ([]git.Commit, error) {
+func (m *mockGitOpsForQuery) Log(_ context.Context, fromRef, toRef string) ([]git.Commit, error) {
 	return nil, nil
 }
 
-func (m *mockGitOpsForQuery) LogFirstParent(fromRef, toRef string) ([]git.Commit, error) {
+func (m *mockGitOpsForQuery) LogFirstParent(_ context.Context, fromRef, toRef string) ([]git.Commit, error) {
 	return nil, nil
 }
 
-func (m *mockGitOpsForQuery) ResolveCommit(ref string) (string, error) {
+func (m *mockGitOpsForQuery) ResolveCommit(_ context.Context, ref string) (string, error) {
 	return ref, nil
 }
 
-func (m *mockGitOpsForQuery) CommitsReachableFrom(sha string) ([]git.Commit, error) {
+func (m *mockGitOpsForQuery) CommitsReachableFrom(_ context.Context, sha string) ([]git.Commit, error) {
 	return nil, nil
 }
 
-func (m *mockGitOpsForQuery) IsAncestorOf(ancestor, descendant string) bool {
+func (m *mockGitOpsForQuery) CommitsReachableFromFirstParent(ctx context.Context, sha string) ([]git.Commit, error) {
+	return m.CommitsReachableFrom(ctx, sha)
+}
+
+func (m *mockGitOpsForQuery) IsAncestorOf(_ context.Context, ancestor, descendant string) bool {
 	return true
 }
 
-func (m *mockGitOpsForQuery) IsOnFirstParentLine(sha, head string) bool {
+func (m *mockGitOpsForQuery) IsOnFirstParentLine(_ context.Context, sha, head string) bool {
 	return true
 }
 
-func (m *mockGitOpsForQuery) GetDiffstat(fromRef, toRef string) (git.Diffstat, error) {
+func (m *mockGitOpsForQuery) IsShallow(_ context.Context) bool {
+	return false
+}
+
+func (m *mockGitOpsForQuery) GetDiffstat(_ context.Context, fromRef, toRef string) (git.Diffstat, error) {
 	return git.Diffstat{}, nil
 }
 
-func (m *mockGitOpsForQuery) CommitFiles(sha string) ([]string, error) { return nil, nil }
-func (m *mockGitOpsForQuery) CommitFilesMulti(shas []string) (map[string][]string, error) {
+func (m *mockGitOpsForQuery) CommitFiles(_ context.Context, sha string) ([]string, error) {
+	return nil, nil
+}
+func (m *mockGitOpsForQuery) CommitFilesMulti(_ context.Context, shas []string) (map[string][]string, error) {
 	return make(map[string][]string), nil
 }
 
-func (m *mockGitOpsForQuery) DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
+func (m *mockGitOpsForQuery) CommitFilesMultiFirstParent(ctx context.Context, shas []string) (map[string][]string, error) {
+	return m.CommitFilesMulti(ctx, shas)
+}
+
+func (m *mockGitOpsForQuery) DiffNameOnly(_ context.Context, fromRef, toRef, pathPrefix string) ([]string, error) {
 	return nil, nil
 }
 
@@ -83,6 +99,10 @@ func TestQueryCommand(t *testing.T) {
 		name           string
 		lastFlag       string
 		tagFlags       []string
+		kindFlags      []string
+		metaFlags      []string
+		pathFlags      []string
+		branchFlag     string
 		onelineFlag    bool
 		jsonOutput     bool
 		entries        []*ledger.Entry
@@ -238,6 +258,66 @@ func TestQueryCommand(t *testing.T) {
 			wantContains:   []string{"second"},
 			wantNotContain: []string{"first"},
 		},
+		{
+			name:      "filter by kind",
+			lastFlag:  "1",
+			kindFlags: []string{"decision"},
+			entries: []*ledger.Entry{
+				withKind(createQueryTestEntryStruct("anchor1", "use postgres", now.Add(-1*time.Hour)), ledger.KindDecision),
+				createQueryTestEntryStruct("anchor2", "newer work", now),
+			},
+			wantErr:        false,
+			wantContains:   []string{"use postgres", "Kind", "decision"},
+			wantNotContain: []string{"newer work"},
+		},
+		{
+			name:         "unknown kind",
+			lastFlag:     "1",
+			kindFlags:    []string{"memo"},
+			entries:      nil,
+			wantErr:      true,
+			wantContains: []string{`unknown kind "memo"`},
+		},
+		{
+			name:      "filter by meta",
+			lastFlag:  "1",
+			metaFlags: []string{"service=api"},
+			entries: []*ledger.Entry{
+				withMeta(createQueryTestEntryStruct("anchor1", "api work", now.Add(-1*time.Hour)), "service", "api"),
+				withMeta(createQueryTestEntryStruct("anchor2", "web work", now), "service", "web"),
+			},
+			wantContains:   []string{"api work"},
+			wantNotContain: []string{"web work"},
+		},
+		{
+			name:      "filter by changed path",
+			lastFlag:  "5",
+			pathFlags: []string{"src/auth/..."},
+			entries: []*ledger.Entry{
+				withFiles(createQueryTestEntryStruct("anchor1", "auth work", now.Add(-1*time.Hour)), "src/auth/login.go"),
+				withFiles(createQueryTestEntryStruct("anchor2", "docs work", now), "docs/spec.md"),
+			},
+			wantContains:   []string{"auth work"},
+			wantNotContain: []string{"docs work"},
+		},
+		{
+			name:       "filter by branch",
+			lastFlag:   "5",
+			branchFlag: "feat/oauth",
+			entries: []*ledger.Entry{
+				withBranch(createQueryTestEntryStruct("anchor1", "oauth work", now.Add(-1*time.Hour)), "feat/oauth"),
+				withBranch(createQueryTestEntryStruct("anchor2", "main work", now), "main"),
+			},
+			wantContains:   []string{"oauth work"},
+			wantNotContain: []string{"main work"},
+		},
+		{
+			name:         "invalid meta filter",
+			lastFlag:     "1",
+			metaFlags:    []string{"Service=api"},
+			wantErr:      true,
+			wantContains: []string{`invalid meta key "Service"`},
+		},
 	}
 
 	for _, tt := range tests {
@@ -276,6 +356,24 @@ func TestQueryCommand(t *testing.T) {
 					t.Fatalf("failed to set tag flag: %v", err)
 				}
 			}
+			for _, kind := range tt.kindFlags {
+				if err := cmd.Flags().Set("kind", kind); err != nil {
+					t.Fatalf("failed to set kind flag: %v", err)
+				}
+			}
+			for _, meta := range tt.metaFlags {
+				if err := cmd.Flags().Set("meta", meta); err != nil {
+					t.Fatalf("failed to set meta flag: %v", err)
+				}
+			}
+			for _, path := range tt.pathFlags {
+				if err := cmd.Flags().Set("path", path); err != nil {
+					t.Fatalf("failed to set path flag: %v", err)
+				}
+			}
+			if err := cmd.Flags().Set("branch", tt.branchFlag); err != nil {
+				t.Fatalf("failed to set branch flag: %v", err)
+			}
 
 			// Capture output
 			var buf strings.Builder
@@ -343,7 +441,7 @@ func TestQueryCorruptEntryDiagnostics(t *testing.T) {
 		}
 	})
 
-	t.Run("json shape remains an array without warning", func(t *testing.T) {
+	t.Run("json shape remains an array with the warning on stderr", func(t *testing.T) {
 		cmd := newQueryCmdInternal(storage)
 		cmd.PersistentFlags().Bool("json", false, "")
 		if err := cmd.PersistentFlags().Set("json", "true"); err != nil {
@@ -359,8 +457,14 @@ func TestQueryCorruptEntryDiagnostics(t *testing.T) {
 		if !strings.HasPrefix(strings.TrimSpace(stdout.String()), "[") {
 			t.Fatalf("stdout = %q, want JSON array", stdout.String())
 		}
-		if stderr.Len() != 0 {
-			t.Fatalf("stderr = %q, want empty", stderr.String())
+		var flushed struct {
+			Warnings []string `json:"warnings"`
+		}
+		if err := json.Unmarshal([]byte(stderr.String()), &flushed); err != nil {
+			t.Fatalf("stderr = %q, want a warnings object: %v", stderr.String(), err)
+		}
+		if len(flushed.Warnings) != 1 || !strings.Contains(flushed.Warnings[0], badPath) {
+			t.Fatalf("warnings = %v, want one naming %q", flushed.Warnings, badPath)
 		}
 	})
 }
@@ -370,6 +474,33 @@ func createQueryTestEntryStruct(anchor, what string, created time.Time) *ledger.
 	return createQueryTestEntryStructWithTags(anchor, what, created, nil)
 }
 
+// withMeta sets one meta field on entry and returns it.
+func withMeta(entry *ledger.Entry, key, value string) *ledger.Entry {
+	if entry.Meta == nil {
+		entry.Meta = make(map[string]string)
+	}
+	entry.Meta[key] = value
+	return entry
+}
+
+// withFiles sets entry's workset files and returns it.
+func withFiles(entry *ledger.Entry, files ...string) *ledger.Entry {
+	entry.Workset.Files = files
+	return entry
+}
+
+// withBranch sets the branch entry was logged on and returns it.
+func withBranch(entry *ledger.Entry, branch string) *ledger.Entry {
+	entry.Workset.Branch = branch
+	return entry
+}
+
+// withKind sets entry's kind and returns it.
+func withKind(entry *ledger.Entry, kind string) *ledger.Entry {
+	entry.Kind = kind
+	return entry
+}
+
 // createQueryTestEntryStructWithTags creates a valid entry struct with tags for testing query command.
 func createQueryTestEntryStructWithTags(anchor, what string, created time.Time, tags []string) *ledger.Entry {
 	return &ledger.Entry{
@@ -390,3 +521,34 @@ func createQueryTestEntryStructWithTags(anchor, what string, created time.Time,
 		Tags: tags,
 	}
 }
+
+func TestQueryReachableFrom(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	mainBranch := strings.TrimSpace(runGitOutput(t, dir, "rev-parse", "--abbrev-ref", "HEAD"))
+	onMain := strings.TrimSpace(runGitOutput(t, dir, "rev-parse", "HEAD"))
+	runGit(t, dir, "checkout", "-b", "feat/side")
+	writeAndCommit(t, dir, "side.go", "package main\n", "feat: side work")
+	onSide := strings.TrimSpace(runGitOutput(t, dir, "rev-parse", "HEAD"))
+	runGit(t, dir, "checkout", mainBranch)
+
+	now := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	ledgerDir := filepath.Join(dir, ".timbers")
+	writeQueryEntryFile(t, ledgerDir, createQueryTestEntryStruct(onMain, "merged work", now.Add(-time.Hour)))
+	writeQueryEntryFile(t, ledgerDir, createQueryTestEntryStruct(onSide, "branch work", now))
+
+	var out strings.Builder
+	var execErr error
+	runInDir(t, dir, func() {
+		cmd := newRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs([]string{"query", "--last", "5", "--oneline", "--reachable-from", mainBranch})
+		execErr = cmd.Execute()
+	})
+	if execErr != nil {
+		t.Fatalf("query --reachable-from: %v\n%s", execErr, out.String())
+	}
+	if !strings.Contains(out.String(), "merged work") || strings.Contains(out.String(), "branch work") {
+		t.Errorf("output = %q, want only the entry anchored on %s", out.String(), mainBranch)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// mutatingCommand describes a command --read-only refuses. A command in the
+// table mutates unless one of its exempt flags (--dry-run, say) is set; when
+// onlyWith is non-empty it mutates only when one of those flags is set.
+type mutatingCommand struct {
+	path     string
+	exempt   []string
+	onlyWith []string
+}
+
+// mutatingCommands lists every command that changes the repository, its
+// hooks, agent settings, or the installed binary, by path below the root.
+var mutatingCommands = []mutatingCommand{
+	{path: "log", exempt: []string{"dry-run"}},
+	{path: "ack", exempt: []string{"dry-run"}},
+	{path: "decide", exempt: []string{"dry-run"}},
+	{path: "amend", exempt: []string{"dry-run"}},
+	{path: "link", exempt: []string{"dry-run"}},
+	{path: "rm", exempt: []string{"dry-run"}},
+	{path: "merge-entries", exempt: []string{"dry-run"}},
+	{path: "split", exempt: []string{"dry-run"}},
+	{path: "init", exempt: []string{"dry-run"}},
+	{path: "uninstall", exempt: []string{"dry-run"}},
+	{path: "remap", exempt: []string{"dry-run"}},
+	{path: "reanchor", exempt: []string{"dry-run"}},
+	{path: "migrate", exempt: []string{"dry-run"}},
+	{path: "archive", exempt: []string{"dry-run"}},
+	{path: "setup claude", exempt: []string{"check", "dry-run"}},
+	{path: "hooks install", exempt: []string{"dry-run"}},
+	{path: "hooks uninstall", exempt: []string{"dry-run"}},
+	{path: "upgrade", exempt: []string{"check", "dry-run"}},
+	{path: "doctor", onlyWith: []string{"fix"}},
+	{path: "review", onlyWith: []string{"apply"}},
+}
+
+// readOnlyDefault reads TIMBERS_READ_ONLY as a boolean, so an agent's
+// environment can enforce read-only mode for every invocation.
+func readOnlyDefault() bool {
+	enabled, err := strconv.ParseBool(os.Getenv("TIMBERS_READ_ONLY"))
+	return err == nil && enabled
+}
+
+// isReadOnly reads the --read-only persistent flag.
+func isReadOnly(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup("read-only")
+	if flag == nil {
+		flag = cmd.Root().PersistentFlags().Lookup("read-only")
+	}
+	return flag != nil && flag.Value.String() == "true"
+}
+
+// applyReadOnly installs read-only mode for the ledger package and refuses
+// cmd up front if it is a mutating command. Always resets the ledger
+// setting, so state never leaks between commands run in one process.
+func applyReadOnly(cmd *cobra.Command) error {
+	enabled := isReadOnly(cmd)
+	ledger.SetReadOnly(enabled)
+	if !enabled {
+		return nil
+	}
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	for _, mc := range mutatingCommands {
+		if mc.path == path && mc.mutates(cmd) {
+			return output.NewUserError("read-only mode: 'timbers " + path +
+				"' changes the repository (unset --read-only / TIMBERS_READ_ONLY to run it)").WithID(output.ErrCodeReadOnly)
+		}
+	}
+	return nil
+}
+
+// mutates reports whether cmd, as invoked, would make changes.
+func (mc mutatingCommand) mutates(cmd *cobra.Command) bool {
+	if len(mc.onlyWith) > 0 {
+		return anyFlagSet(cmd, mc.onlyWith)
+	}
+	return !anyFlagSet(cmd, mc.exempt)
+}
+
+// anyFlagSet reports whether any of the named boolean flags is true.
+func anyFlagSet(cmd *cobra.Command, names []string) bool {
+	for _, name := range names {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Value.String() == "true" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+func TestMutatingCommandsExist(t *testing.T) {
+	root := newRootCmd()
+	for _, mutating := range mutatingCommands {
+		cmd, _, err := root.Find(strings.Fields(mutating.path))
+		if err != nil || cmd.CommandPath() != "timbers "+mutating.path {
+			t.Errorf("mutating command %q does not resolve", mutating.path)
+			continue
+		}
+		for _, name := range append(append([]string{}, mutating.exempt...), mutating.onlyWith...) {
+			if cmd.Flags().Lookup(name) == nil {
+				t.Errorf("%q has no --%s flag", mutating.path, name)
+			}
+		}
+	}
+}
+
+func TestApplyReadOnly(t *testing.T) {
+	t.Cleanup(func() { ledger.SetReadOnly(false) })
+
+	tests := []struct {
+		args    []string
+		refused bool
+	}{
+		{[]string{"log", "--read-only"}, true},
+		{[]string{"log", "--read-only", "--dry-run"}, false},
+		{[]string{"log"}, false},
+		{[]string{"hooks", "install", "--read-only"}, true},
+		{[]string{"doctor", "--read-only"}, false},
+		{[]string{"doctor", "--read-only", "--fix"}, true},
+		{[]string{"query", "--read-only"}, false},
+		{[]string{"upgrade", "--read-only", "--check"}, false},
+	}
+	for _, tt := range tests {
+		root := newRootCmd()
+		cmd, flags, err := root.Find(tt.args)
+		if err != nil {
+			t.Fatalf("Find(%v): %v", tt.args, err)
+		}
+		if err = cmd.ParseFlags(flags); err != nil {
+			t.Fatalf("ParseFlags(%v): %v", tt.args, err)
+		}
+		err = applyReadOnly(cmd)
+		if (err != nil) != tt.refused {
+			t.Errorf("applyReadOnly(%v) error = %v, want refused=%v", tt.args, err, tt.refused)
+		}
+		if ledger.ReadOnly() != strings.Contains(strings.Join(tt.args, " "), "--read-only") {
+			t.Errorf("applyReadOnly(%v) left ledger read-only = %v", tt.args, ledger.ReadOnly())
+		}
+	}
+}
+
+func TestReadOnlyDefault(t *testing.T) {
+	t.Setenv("TIMBERS_READ_ONLY", "1")
+	if !isReadOnly(newRootCmd()) {
+		t.Error("TIMBERS_READ_ONLY=1 should default --read-only to true")
+	}
+	t.Setenv("TIMBERS_READ_ONLY", "")
+	if isReadOnly(newRootCmd()) {
+		t.Error("--read-only should default to false")
+	}
+}
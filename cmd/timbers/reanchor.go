@@ -0,0 +1,184 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// reanchorFlags holds the reanchor command's flags.
+type reanchorFlags struct {
+	fromRewrite bool
+	to          string
+	dryRun      bool
+	noStage     bool
+}
+
+// newReanchorCmd creates the reanchor command.
+func newReanchorCmd() *cobra.Command {
+	return newReanchorCmdInternal(nil)
+}
+
+// newReanchorCmdInternal creates the reanchor command with optional storage
+// injection. If storage is nil, a real storage is created when the command
+// runs.
+func newReanchorCmdInternal(storage *ledger.Storage) *cobra.Command {
+	var flags reanchorFlags
+
+	cmd := &cobra.Command{
+		Use:   "reanchor (--from-rewrite [<file>] | <entry-id> --to <sha>)",
+		Short: "Move entries onto rewritten commits",
+		Long: `Update anchor_commit, workset commits, and ranges in every entry and ack
+from the "<old-sha> <new-sha>" list git hands a post-rewrite hook.
+
+--from-rewrite reads that list from <file>, or from stdin when no file is
+given, so a custom hook or script can pipe git's list straight through:
+
+  timbers reanchor --from-rewrite < .git/rebase-merge/rewritten-list
+
+Every changed entry and ack is validated before anything is written; if one
+would break, no file changes. Changed files are then written atomically and
+staged (git add), ready to commit. Unlike the post-rewrite hook, which leaves
+its changes unstaged mid-rebase, reanchor runs when you choose to.
+
+For rewrites without such a list (filter-repo, a rebase on another machine),
+see timbers remap.
+
+<entry-id> --to <sha> moves one entry by hand, as when a squash merge has
+replaced the commits it covered. The entry is anchored at <sha>; its commits
+still in <sha>'s history are kept and the rest dropped. The range, files, and
+diffstat are recomputed against the new anchor, the change is recorded in the
+entry's revision history, and the entry is committed.
+
+Examples:
+  timbers reanchor --from-rewrite rewritten.txt
+  timbers reanchor --from-rewrite --dry-run < rewritten.txt
+  timbers reanchor --from-rewrite --no-stage --json < rewritten.txt
+  timbers reanchor tb_2026-01-15T15:04:05Z_8f2c1a --to 4e1d9b2 --dry-run`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReanchor(cmd, storage, args, flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.fromRewrite, "from-rewrite", false, "Read post-rewrite \"<old> <new>\" pairs from <file> or stdin")
+	cmd.Flags().StringVar(&flags.to, "to", "", "Anchor the entry given as an argument at this commit")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Show what would be reanchored without writing")
+	cmd.Flags().BoolVar(&flags.noStage, "no-stage", false, "Leave the changed files unstaged")
+
+	return cmd
+}
+
+// runReanchor executes the reanchor command.
+func runReanchor(cmd *cobra.Command, storage *ledger.Storage, args []string, flags reanchorFlags) error {
+	printer := newPrinter(cmd)
+
+	if flags.to != "" {
+		return runReanchorEntry(printer, storage, args, flags)
+	}
+	if !flags.fromRewrite {
+		err := output.NewUserError("specify --from-rewrite [<file>] with the post-rewrite SHA map, or <entry-id> --to <sha>")
+		printer.Error(err)
+		return err
+	}
+	storage, err := ensureStorage(printer, storage)
+	if err != nil {
+		return err
+	}
+
+	rewrites, err := readPostRewriteMap(cmd.InOrStdin(), args)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	files, err := reanchorFiles(storage, rewrites, flags)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	return printReanchorResult(printer, rewrites, files, flags)
+}
+
+// readPostRewriteMap parses the SHA map from the file in args, or from
+// stdin, and resolves abbreviated SHAs.
+func readPostRewriteMap(stdin io.Reader, args []string) (map[string]string, error) {
+	source := stdin
+	if len(args) == 1 && args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return nil, output.NewUserError("cannot read rewrite map: " + err.Error())
+		}
+		defer file.Close() //nolint:errcheck
+		source = file
+	}
+	parsed, err := ledger.ParseRewriteMap(source)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // already an output error
+	}
+	rewrites := make(map[string]string, len(parsed))
+	if err := mergeRewrites(rewrites, parsed); err != nil {
+		return nil, err
+	}
+	return rewrites, nil
+}
+
+// reanchorFiles plans, relinks, or relinks and stages the ledger files.
+func reanchorFiles(storage *ledger.Storage, rewrites map[string]string, flags reanchorFlags) ([]string, error) {
+	switch {
+	case flags.dryRun:
+		return storage.PlanRelink(rewrites) //nolint:wrapcheck // ledger errors are output errors
+	case flags.noStage:
+		return storage.RelinkCommits(rewrites) //nolint:wrapcheck // ledger errors are output errors
+	default:
+		return storage.ReanchorCommits(rewrites) //nolint:wrapcheck // ledger errors are output errors
+	}
+}
+
+// printReanchorResult reports the reanchored ledger files.
+func printReanchorResult(printer *output.Printer, rewrites map[string]string, files []string, flags reanchorFlags) error {
+	ids := ledgerFileIDs(files)
+	staged := !flags.dryRun && !flags.noStage
+	if printer.IsJSON() {
+		return printReanchorJSON(printer, rewrites, files, staged, flags.dryRun)
+	}
+
+	verb := "Reanchored"
+	if flags.dryRun {
+		verb = "Would reanchor"
+	}
+	printer.Print("%s %d ledger file(s) using %d commit mapping(s)\n", verb, len(ids), len(rewrites))
+	for _, id := range ids {
+		printer.Print("  %s\n", id)
+	}
+	switch {
+	case len(ids) == 0 || flags.dryRun:
+	case staged:
+		printer.Println("Changes are staged: git commit -m \"timbers: reanchor entries\"")
+	default:
+		printer.Println("These changes are uncommitted: git add .timbers && git commit")
+	}
+	return nil
+}
+
+// printReanchorJSON writes the reanchor result, with a plan on a dry run.
+func printReanchorJSON(printer *output.Printer, rewrites map[string]string, files []string, staged, dryRun bool) error {
+	fields := map[string]any{
+		"status":     "reanchored",
+		"mappings":   len(rewrites),
+		"reanchored": ledgerFileIDs(files),
+		"staged":     staged,
+	}
+	if !dryRun {
+		return printer.WriteJSON(fields)
+	}
+	plan := make([]plannedAction, 0, len(files))
+	for _, path := range files {
+		plan = append(plan, plannedAction{Action: planModify, Target: path, Detail: "rewrite commit SHAs"})
+	}
+	return printer.WriteJSON(withPlan(fields, plan))
+}
@@ -0,0 +1,120 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// runReanchorEntry moves one entry onto the commit named by --to.
+func runReanchorEntry(printer *output.Printer, storage *ledger.Storage, args []string, flags reanchorFlags) error {
+	if err := checkReanchorEntryArgs(args, flags); err != nil {
+		printer.Error(err)
+		return err
+	}
+	storage, err := initAmendStorage(storage, printer)
+	if err != nil {
+		return err
+	}
+
+	entry, updated, err := planReanchorEntry(storage, args[0], flags.to)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	if flags.dryRun {
+		return outputReanchorEntry(printer, entry, updated, true)
+	}
+	if err := storage.WriteEntry(updated, true); err != nil {
+		printer.Error(err)
+		return err
+	}
+	return outputReanchorEntry(printer, entry, updated, false)
+}
+
+// checkReanchorEntryArgs rejects flags that only apply to --from-rewrite
+// and requires the one entry ID --to applies to.
+func checkReanchorEntryArgs(args []string, flags reanchorFlags) error {
+	switch {
+	case flags.fromRewrite:
+		return output.NewUserError("--to cannot be combined with --from-rewrite")
+	case flags.noStage:
+		return output.NewUserError("--no-stage only applies with --from-rewrite")
+	case len(args) != 1:
+		return output.NewUserError("--to needs the ID of the entry to reanchor")
+	}
+	return nil
+}
+
+// planReanchorEntry reads the entry and returns it with a copy moved onto
+// target: its commits still in target's history are kept, the files and diffstat
+// are recomputed from git where it can, and a revision is recorded.
+func planReanchorEntry(storage *ledger.Storage, id, target string) (*ledger.Entry, *ledger.Entry, error) {
+	entry, err := storage.GetEntryByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if storage.DeletedSet()[entry.ID] {
+		return nil, nil, output.NewConflictError("entry already deleted: " + entry.ID).WithID(output.ErrCodeEntryExists)
+	}
+	anchor, err := storage.ResolveCommit(target)
+	if err != nil {
+		return nil, nil, output.NewUserError("cannot resolve --to " + target + ": " + err.Error())
+	}
+	if anchor == entry.Workset.AnchorCommit {
+		return nil, nil, output.NewUserError(entry.ID + " is already anchored at " + shortSHA(anchor))
+	}
+
+	updated := *entry
+	updated.Workset = ledger.ReanchorWorkset(entry.Workset, anchor, func(sha string) bool {
+		return storage.IsAncestorOf(sha, anchor)
+	})
+	if files := worksetFiles(storage, updated.Workset.Commits); files != nil {
+		updated.Workset.Files = files
+	}
+	if stat := worksetDiffstat(storage, updated.Workset, hasPerFileDiffstat([]*ledger.Entry{entry})); stat != nil {
+		updated.Workset.Diffstat = stat
+	}
+	updated.UpdatedAt = time.Now().UTC()
+	updated.RecordRevision(entry, resolveAcker(), updated.UpdatedAt)
+	return entry, &updated, nil
+}
+
+// outputReanchorEntry reports the entry's old and new anchor, with a plan
+// on a dry run.
+func outputReanchorEntry(printer *output.Printer, entry, updated *ledger.Entry, dryRun bool) error {
+	if printer.IsJSON() {
+		fields := map[string]any{
+			"status":   "reanchored",
+			"id":       updated.ID,
+			"previous": entry.Workset.AnchorCommit,
+			"anchor":   updated.Workset.AnchorCommit,
+			"range":    updated.Workset.Range,
+			"commits":  updated.Workset.Commits,
+			"entry":    updated,
+		}
+		if !dryRun {
+			return printer.Success(fields)
+		}
+		fields["dry_run"] = true
+		return printer.WriteJSON(withPlan(fields, []plannedAction{{
+			Action: planModify, Target: updated.ID,
+			Detail: "anchor " + shortSHA(entry.Workset.AnchorCommit) + " -> " + shortSHA(updated.Workset.AnchorCommit),
+		}}))
+	}
+
+	verb := "Reanchored"
+	if dryRun {
+		verb = "Would reanchor"
+	}
+	printer.Println(verb + " " + updated.ID)
+	printer.KeyValue("Anchor", shortSHA(entry.Workset.AnchorCommit)+" -> "+shortSHA(updated.Workset.AnchorCommit))
+	printer.KeyValue("Commits", strconv.Itoa(len(updated.Workset.Commits)))
+	if updated.Workset.Range != "" {
+		printer.KeyValue("Range", updated.Workset.Range)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runReanchorCmd runs `timbers reanchor --json` with stdin in dir and
+// decodes the result.
+func runReanchorCmd(t *testing.T, dir, stdin string, args ...string) (map[string]any, error) {
+	t.Helper()
+	var out strings.Builder
+	var execErr error
+	runInDir(t, dir, func() {
+		cmd := newRootCmd()
+		cmd.SetIn(strings.NewReader(stdin))
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append([]string{"reanchor", "--json"}, args...))
+		execErr = cmd.Execute()
+	})
+	var result map[string]any
+	if err := json.Unmarshal([]byte(out.String()), &result); err != nil {
+		t.Fatalf("decode %q: %v", out.String(), err)
+	}
+	return result, execErr //nolint:wrapcheck // the command error is asserted as-is
+}
+
+func TestReanchorFromRewriteStagesEntries(t *testing.T) {
+	dir, oldSHA, newSHA := newRemapRepo(t)
+
+	result, err := runReanchorCmd(t, dir, oldSHA+" "+newSHA+"\n", "--from-rewrite")
+	if err != nil {
+		t.Fatalf("reanchor --from-rewrite: %v (%v)", err, result)
+	}
+	if reanchored, _ := result["reanchored"].([]any); len(reanchored) != 1 || result["staged"] != true {
+		t.Errorf("result = %v, want one staged entry", result)
+	}
+	if anchor := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).Workset.AnchorCommit; anchor != newSHA {
+		t.Errorf("anchor = %s, want %s", anchor, newSHA)
+	}
+	if staged := runGitOutput(t, dir, "diff", "--cached", "--name-only"); !strings.Contains(staged, ".timbers/") {
+		t.Errorf("staged files = %q, want the reanchored entry", staged)
+	}
+}
+
+func TestReanchorRequiresFromRewrite(t *testing.T) {
+	dir, _, _ := newRemapRepo(t)
+
+	if _, err := runReanchorCmd(t, dir, ""); err == nil {
+		t.Error("reanchor without --from-rewrite should fail")
+	}
+}
+
+func TestReanchorEntryTo(t *testing.T) {
+	dir, oldSHA, newSHA := newRemapRepo(t)
+	id := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).ID
+
+	result, err := runReanchorCmd(t, dir, "", id, "--to", "HEAD", "--dry-run")
+	if err != nil || result["anchor"] != newSHA {
+		t.Fatalf("dry run: %v, result = %v", err, result)
+	}
+	if anchor := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).Workset.AnchorCommit; anchor != oldSHA {
+		t.Fatalf("dry run changed the anchor to %s", anchor)
+	}
+
+	if result, err = runReanchorCmd(t, dir, "", id, "--to", "HEAD"); err != nil {
+		t.Fatalf("reanchor --to: %v (%v)", err, result)
+	}
+	entry := onlyEntryInDir(t, filepath.Join(dir, ".timbers"))
+	if entry.Workset.AnchorCommit != newSHA || len(entry.Workset.Commits) != 1 || entry.Workset.Commits[0] != newSHA {
+		t.Errorf("workset = %+v, want anchored at and covering only %s", entry.Workset, newSHA)
+	}
+	if len(entry.Revisions) != 1 || entry.Revisions[0].Changes[0].Field != "workset.anchor_commit" ||
+		entry.Revisions[0].Changes[0].Before != oldSHA {
+		t.Errorf("revisions = %+v, want the anchor change recorded", entry.Revisions)
+	}
+
+	if _, err = runReanchorCmd(t, dir, "", id, "--to", newSHA); err == nil {
+		t.Error("reanchoring onto the current anchor should fail")
+	}
+	if _, err = runReanchorCmd(t, dir, "", "--to", "HEAD"); err == nil {
+		t.Error("--to without an entry ID should fail")
+	}
+}
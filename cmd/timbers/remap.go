@@ -0,0 +1,248 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// remapFlags holds the remap command's flags.
+type remapFlags struct {
+	maps     []string
+	fromFile string
+	auto     bool
+	dryRun   bool
+}
+
+// newRemapCmd creates the remap command.
+func newRemapCmd() *cobra.Command {
+	return newRemapCmdInternal(nil)
+}
+
+// newRemapCmdInternal creates the remap command with optional storage injection.
+// If storage is nil, a real storage is created when the command runs.
+func newRemapCmdInternal(storage *ledger.Storage) *cobra.Command {
+	var flags remapFlags
+
+	cmd := &cobra.Command{
+		Use:   "remap",
+		Short: "Rewrite commit SHAs across the ledger after a history rewrite",
+		Long: `Rewrite anchor commits, workset SHAs, and ranges in every entry and ack
+after a rebase, squash, or git filter-repo.
+
+The post-rewrite hook relinks entries during an ordinary rebase. Rewrites it
+never sees (filter-repo, a rebase on another machine, a force-pushed branch)
+leave entries pointing at orphaned commits; remap repairs them in bulk.
+
+Mappings come from any combination of:
+  --map old=new       One pair; repeatable. Either side may be abbreviated.
+  --from-file <path>  "old new" or "old=new" per line; # comments allowed.
+                      git filter-repo's .git/filter-repo/commit-map works as-is.
+  --auto              Reads filter-repo's commit-map if present, then matches
+                      each orphaned commit to the one reachable from HEAD with
+                      the same author, author date, and subject. Ambiguous or
+                      squashed commits are reported, not guessed.
+
+Changed files are left uncommitted for review.
+
+Examples:
+  timbers remap --auto --dry-run
+  timbers remap --map abc1234=def5678
+  timbers remap --from-file .git/filter-repo/commit-map --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRemap(cmd, storage, flags)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&flags.maps, "map", nil, "Rewrite old=new (repeatable)")
+	cmd.Flags().StringVar(&flags.fromFile, "from-file", "", "Read old/new SHA pairs from a file")
+	cmd.Flags().BoolVar(&flags.auto, "auto", false, "Detect rewritten commits by author, date, and subject")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Show what would be remapped without writing")
+
+	return cmd
+}
+
+// runRemap executes the remap command.
+func runRemap(cmd *cobra.Command, storage *ledger.Storage, flags remapFlags) error {
+	printer := newPrinter(cmd)
+
+	if len(flags.maps) == 0 && flags.fromFile == "" && !flags.auto {
+		err := output.NewUserError("specify --map old=new, --from-file <path>, or --auto")
+		printer.Error(err)
+		return err
+	}
+	storage, err := ensureStorage(printer, storage)
+	if err != nil {
+		return err
+	}
+
+	rewrites, unmatched, err := collectRewrites(storage, flags)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	if len(unmatched) > 0 {
+		printer.Warning("%d orphaned commit(s) have no unique rewritten counterpart: %s",
+			len(unmatched), strings.Join(abbreviateSHAs(unmatched), ", "))
+	}
+
+	var files []string
+	if flags.dryRun {
+		files, err = storage.PlanRelink(rewrites)
+	} else {
+		files, err = storage.RelinkCommits(rewrites)
+	}
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	return printRemapResult(printer, rewrites, files, unmatched, flags.dryRun)
+}
+
+// collectRewrites merges the mappings from every requested source: an
+// explicit --map wins over --from-file, which wins over --auto. Also returns
+// the orphaned commits --auto could not match.
+func collectRewrites(storage *ledger.Storage, flags remapFlags) (map[string]string, []string, error) {
+	rewrites := make(map[string]string)
+	var unmatched []string
+	if flags.auto {
+		detected, orphans, err := detectRewrites(storage)
+		if err != nil {
+			return nil, nil, err
+		}
+		rewrites, unmatched = detected, orphans
+	}
+	if flags.fromFile != "" {
+		parsed, err := readRewriteMapFile(flags.fromFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := mergeRewrites(rewrites, parsed); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, pair := range flags.maps {
+		if err := mergeMapFlag(rewrites, pair); err != nil {
+			return nil, nil, err
+		}
+	}
+	return rewrites, unmatched, nil
+}
+
+// mergeMapFlag adds one --map old=new pair to rewrites.
+func mergeMapFlag(rewrites map[string]string, pair string) error {
+	old, replacement, ok := strings.Cut(pair, "=")
+	if !ok || old == "" || replacement == "" {
+		return output.NewUserError("--map expects old=new, got " + pair)
+	}
+	return mergeRewrites(rewrites, map[string]string{old: replacement})
+}
+
+// readRewriteMapFile parses the --from-file commit map.
+func readRewriteMapFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, output.NewUserError("cannot read commit map: " + err.Error())
+	}
+	defer file.Close() //nolint:errcheck
+	return ledger.ParseRewriteMap(file)
+}
+
+// mergeRewrites resolves each pair to full SHAs and adds it to rewrites.
+func mergeRewrites(rewrites, pairs map[string]string) error {
+	for old, replacement := range pairs {
+		fullOld, err := resolveRemapSHA(old, true)
+		if err != nil {
+			return err
+		}
+		fullNew, err := resolveRemapSHA(replacement, false)
+		if err != nil {
+			return err
+		}
+		rewrites[fullOld] = fullNew
+	}
+	return nil
+}
+
+// resolveRemapSHA expands an abbreviated sha to a full commit SHA. Full
+// SHAs are taken as given: the old side of a mapping may already be
+// garbage-collected, and a filter-repo map can hold thousands of pairs.
+func resolveRemapSHA(sha string, old bool) (string, error) {
+	if len(sha) == 40 {
+		return sha, nil
+	}
+	full, err := resolveCommitSHA(sha)
+	if err != nil && old {
+		return "", output.NewUserError("cannot resolve " + sha + "; give the full 40-character SHA of the old commit")
+	}
+	return full, err
+}
+
+// printRemapResult reports the remapped ledger files.
+func printRemapResult(printer *output.Printer, rewrites map[string]string, files, unmatched []string, dryRun bool) error {
+	ids := ledgerFileIDs(files)
+	if printer.IsJSON() {
+		fields := map[string]any{
+			"status":    "remapped",
+			"mappings":  len(rewrites),
+			"remapped":  ids,
+			"unmatched": nonNilStrings(unmatched),
+		}
+		if !dryRun {
+			return printer.WriteJSON(fields)
+		}
+		plan := make([]plannedAction, 0, len(files))
+		for _, path := range files {
+			plan = append(plan, plannedAction{Action: planModify, Target: path, Detail: "rewrite commit SHAs"})
+		}
+		return printer.WriteJSON(withPlan(fields, plan))
+	}
+
+	verb := "Remapped"
+	if dryRun {
+		verb = "Would remap"
+	}
+	printer.Print("%s %d ledger file(s) using %d commit mapping(s)\n", verb, len(ids), len(rewrites))
+	for _, id := range ids {
+		printer.Print("  %s\n", id)
+	}
+	if !dryRun && len(ids) > 0 {
+		printer.Println("These changes are uncommitted: git add .timbers && git commit")
+	}
+	return nil
+}
+
+// ledgerFileIDs returns the entry or ack IDs of ledger file paths, sorted.
+func ledgerFileIDs(paths []string) []string {
+	ids := make([]string, 0, len(paths))
+	for _, path := range paths {
+		ids = append(ids, strings.TrimSuffix(filepath.Base(path), ".json"))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// nonNilStrings returns values, or an empty slice so JSON gets [] not null.
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// abbreviateSHAs shortens SHAs for human-readable messages.
+func abbreviateSHAs(shas []string) []string {
+	short := make([]string, 0, len(shas))
+	for _, sha := range shas {
+		short = append(short, shortSHA(sha))
+	}
+	return short
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// filterRepoCommitMap is where git filter-repo records its rewrites,
+// relative to the git directory.
+const filterRepoCommitMap = "filter-repo/commit-map"
+
+// detectRewrites finds ledger commits that are no longer reachable from
+// HEAD and maps each to its rewritten counterpart: first from filter-repo's
+// commit-map, then by matching author, author date, and subject. Returns
+// the mappings and the orphans left unmatched, including any whose objects
+// were already garbage-collected.
+func detectRewrites(storage *ledger.Storage) (map[string]string, []string, error) {
+	entries, err := storage.ListEntries()
+	if err != nil {
+		return nil, nil, err
+	}
+	acks, err := storage.ListAcks()
+	if err != nil {
+		return nil, nil, err
+	}
+	head, err := git.HEAD()
+	if err != nil {
+		return nil, nil, err
+	}
+	current, err := git.CommitsReachableFrom(head)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rewrites, err := readFilterRepoMap()
+	if err != nil {
+		return nil, nil, err
+	}
+	orphans := orphanedCommits(referencedLedgerCommits(entries, acks), current, rewrites)
+	if len(orphans) == 0 {
+		return rewrites, nil, nil
+	}
+
+	found, err := git.LookupCommits(orphans)
+	if err != nil {
+		return nil, nil, err
+	}
+	matched, unmatched := ledger.MatchRewrites(found, current)
+	maps.Copy(rewrites, matched)
+	unmatched = append(unmatched, missingCommits(orphans, found)...)
+	sort.Strings(unmatched)
+	return rewrites, unmatched, nil
+}
+
+// readFilterRepoMap reads git filter-repo's commit-map, if this repository
+// has one, keeping only the pairs that actually changed.
+func readFilterRepoMap() (map[string]string, error) {
+	rewrites := make(map[string]string)
+	gitDir, err := git.Dir()
+	if err != nil {
+		return rewrites, nil //nolint:nilerr // no git dir means no map to read
+	}
+	file, err := os.Open(filepath.Join(gitDir, filterRepoCommitMap))
+	if err != nil {
+		return rewrites, nil //nolint:nilerr // no filter-repo run in this repository
+	}
+	defer func() { _ = file.Close() }()
+
+	parsed, err := ledger.ParseRewriteMap(file)
+	if err != nil {
+		return nil, output.NewUserError(filterRepoCommitMap + ": " + err.Error())
+	}
+	for old, replacement := range parsed {
+		if old != replacement {
+			rewrites[old] = replacement
+		}
+	}
+	return rewrites, nil
+}
+
+// referencedLedgerCommits returns every commit the ledger points at:
+// entry anchors and worksets, and acked SHAs.
+func referencedLedgerCommits(entries []*ledger.Entry, acks []*ledger.Ack) []string {
+	shas := ledger.ReferencedCommits(entries)
+	for _, ack := range acks {
+		shas = append(shas, ack.TargetSHA)
+	}
+	return shas
+}
+
+// orphanedCommits returns the full SHAs in referenced that are neither
+// reachable from HEAD nor already mapped, sorted and deduplicated.
+func orphanedCommits(referenced []string, current []git.Commit, mapped map[string]string) []string {
+	reachable := make(map[string]bool, len(current))
+	for _, commit := range current {
+		reachable[commit.SHA] = true
+	}
+	seen := make(map[string]bool)
+	var orphans []string
+	for _, sha := range referenced {
+		if len(sha) != 40 || reachable[sha] || seen[sha] || mapped[sha] != "" {
+			continue
+		}
+		seen[sha] = true
+		orphans = append(orphans, sha)
+	}
+	sort.Strings(orphans)
+	return orphans
+}
+
+// missingCommits returns the SHAs in orphans that LookupCommits could not
+// find.
+func missingCommits(orphans []string, found []git.Commit) []string {
+	present := make(map[string]bool, len(found))
+	for _, commit := range found {
+		present[commit.SHA] = true
+	}
+	var missing []string
+	for _, sha := range orphans {
+		if !present[sha] {
+			missing = append(missing, sha)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+// newRemapRepo creates a repo whose ledger entry is anchored at a commit
+// that is then amended without the post-rewrite hook, orphaning the anchor.
+// Returns the repo dir, the orphaned SHA, and its replacement.
+func newRemapRepo(t *testing.T) (string, string, string) {
+	t.Helper()
+	dir := newLogAnchorRepo(t)
+	oldSHA := strings.TrimSpace(runGitOutput(t, dir, "rev-parse", "HEAD"))
+
+	entry := createQueryTestEntryStruct(oldSHA, "documented work", time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC))
+	writeQueryEntryFile(t, filepath.Join(dir, ".timbers"), entry)
+
+	env := []string{"GIT_COMMITTER_DATE=2030-01-01T00:00:00Z"}
+	if _, err := git.RunInDir(dir, env, "commit", "--amend", "--no-edit", "--no-verify"); err != nil {
+		t.Fatalf("amend: %v", err)
+	}
+	newSHA := strings.TrimSpace(runGitOutput(t, dir, "rev-parse", "HEAD"))
+	if newSHA == oldSHA {
+		t.Fatal("amend did not change the SHA")
+	}
+	return dir, oldSHA, newSHA
+}
+
+// runRemapCmd runs `timbers remap --json` in dir and decodes the result.
+func runRemapCmd(t *testing.T, dir string, args ...string) (map[string]any, error) {
+	t.Helper()
+	var out strings.Builder
+	var execErr error
+	runInDir(t, dir, func() {
+		cmd := newRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append([]string{"remap", "--json"}, args...))
+		execErr = cmd.Execute()
+	})
+	var result map[string]any
+	if err := json.Unmarshal([]byte(out.String()), &result); err != nil {
+		t.Fatalf("decode %q: %v", out.String(), err)
+	}
+	return result, execErr //nolint:wrapcheck // the command error is asserted as-is
+}
+
+func TestRemapAuto(t *testing.T) {
+	dir, oldSHA, newSHA := newRemapRepo(t)
+
+	result, err := runRemapCmd(t, dir, "--auto")
+	if err != nil {
+		t.Fatalf("remap --auto: %v (%v)", err, result)
+	}
+	if remapped, _ := result["remapped"].([]any); len(remapped) != 1 {
+		t.Errorf("remapped = %v, want one entry", result["remapped"])
+	}
+	anchor := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).Workset.AnchorCommit
+	if anchor != newSHA {
+		t.Errorf("anchor = %s, want %s (was %s)", anchor, newSHA, oldSHA)
+	}
+}
+
+func TestRemapDryRunWritesNothing(t *testing.T) {
+	dir, oldSHA, newSHA := newRemapRepo(t)
+
+	result, err := runRemapCmd(t, dir, "--map", oldSHA[:7]+"="+newSHA[:7], "--dry-run")
+	if err != nil {
+		t.Fatalf("remap --dry-run: %v (%v)", err, result)
+	}
+	if result["status"] != "dry_run" {
+		t.Errorf("status = %v, want dry_run", result["status"])
+	}
+	if plan, _ := result["planned_actions"].([]any); len(plan) != 1 {
+		t.Errorf("planned_actions = %v, want one modify", result["planned_actions"])
+	}
+	if anchor := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).Workset.AnchorCommit; anchor != oldSHA {
+		t.Errorf("dry run rewrote the anchor to %s", anchor)
+	}
+}
+
+func TestRemapFromFile(t *testing.T) {
+	dir, oldSHA, newSHA := newRemapRepo(t)
+	mapFile := filepath.Join(t.TempDir(), "commit-map")
+	if err := os.WriteFile(mapFile, []byte("old new\n"+oldSHA+" "+newSHA+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if result, err := runRemapCmd(t, dir, "--from-file", mapFile); err != nil {
+		t.Fatalf("remap --from-file: %v (%v)", err, result)
+	}
+	if anchor := onlyEntryInDir(t, filepath.Join(dir, ".timbers")).Workset.AnchorCommit; anchor != newSHA {
+		t.Errorf("anchor = %s, want %s", anchor, newSHA)
+	}
+}
+
+func TestRemapRequiresASource(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	if _, err := runRemapCmd(t, dir); err == nil {
+		t.Error("expected an error without --map, --from-file, or --auto")
+	}
+}
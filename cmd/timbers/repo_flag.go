@@ -0,0 +1,39 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// repoFlag names the global flag that runs timbers in another directory.
+const repoFlag = "repo"
+
+// addRepoFlag adds the persistent -C/--repo flag.
+func addRepoFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringP(repoFlag, "C", "",
+		"Run as if timbers was started in <path>, like git -C")
+}
+
+// applyRepoFlag changes into the --repo directory before anything looks at
+// the repository. As with git -C, everything then resolves there: the repo
+// root, the ledger, config and .env files, and relative path arguments.
+// Reports an unusable directory through the printer.
+func applyRepoFlag(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup(repoFlag)
+	if flag == nil {
+		flag = cmd.Root().PersistentFlags().Lookup(repoFlag)
+	}
+	if flag == nil || flag.Value.String() == "" {
+		return nil
+	}
+	if err := os.Chdir(flag.Value.String()); err != nil {
+		userErr := output.NewUserError("cannot use --repo " + flag.Value.String() + ": " + err.Error())
+		newPrinter(cmd).Error(userErr)
+		return userErr
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runRootFromElsewhere runs timbers with args from an unrelated directory.
+func runRootFromElsewhere(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var out strings.Builder
+	var execErr error
+	runInDir(t, t.TempDir(), func() {
+		cmd := newRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(args)
+		execErr = cmd.Execute()
+	})
+	return out.String(), execErr
+}
+
+func TestRepoFlagRunsInAnotherCheckout(t *testing.T) {
+	repo := newLogAnchorRepo(t)
+
+	if out, err := runRootFromElsewhere(t, "--repo", repo, "log", "Seed work", "--minor"); err != nil {
+		t.Fatalf("timbers --repo <repo> log: %v\n%s", err, out)
+	}
+	onlyEntryInDir(t, filepath.Join(repo, ".timbers"))
+
+	writeAndCommit(t, repo, "more.go", "package main\n", "feat: more")
+	out, err := runRootFromElsewhere(t, "-C", repo, "pending", "--count")
+	if err != nil || strings.TrimSpace(out) != "1" {
+		t.Errorf("timbers -C <repo> pending --count = %q, %v; want 1", out, err)
+	}
+}
+
+func TestRepoFlagRejectsMissingDirectory(t *testing.T) {
+	out, err := runRootFromElsewhere(t, "-C", filepath.Join(t.TempDir(), "missing"), "pending")
+	if err == nil || !strings.Contains(out, "cannot use --repo") {
+		t.Errorf("expected a --repo error, got %v\n%s", err, out)
+	}
+}
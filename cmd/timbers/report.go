@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -56,8 +55,7 @@ Examples:
 }
 
 func runReport(cmd *cobra.Command, profileName string, flags draftFlags) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd)).
-		WithStderr(cmd.ErrOrStderr())
+	printer := newPrinter(cmd)
 	tmpl, err := draft.LoadTemplate(profileName)
 	if err != nil {
 		return reportUserError(printer, err.Error())
@@ -157,9 +155,9 @@ func runGeneratedReport(
 	if err != nil {
 		return reportUserError(printer, err.Error())
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	ctx, cancel := llmContext()
 	defer cancel()
-	resp, err := client.Complete(ctx, llm.Request{Prompt: rendered})
+	resp, err := completeWithProgress(ctx, printer, client, llm.Request{Prompt: rendered})
 	if err != nil {
 		sysErr := output.NewSystemErrorWithCause("LLM request failed", err)
 		printer.Error(sysErr)
@@ -174,7 +172,7 @@ func runGeneratedReport(
 	return outputGeneratedReport(printer, profileName, tmpl, rendered, content, entries, metadata, flags.withFrontmatter)
 }
 
-func reportUserError(printer *output.Printer, message string) error {
+func reportUserError(printer output.Reporter, message string) error {
 	err := output.NewUserError(message)
 	printer.Error(err)
 	return err
@@ -0,0 +1,205 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/draft"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/llm"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// reviewTemplate is the draft template that asks the model for a JSON
+// critique of each entry.
+const reviewTemplate = "review"
+
+// completeFunc sends a prompt to a model and returns its raw response.
+type completeFunc func(ctx context.Context, prompt string) (string, error)
+
+// reviewFlags holds flag values for the review command.
+type reviewFlags struct {
+	last     string
+	model    string
+	provider string
+	apply    bool
+	yes      bool
+}
+
+// newReviewCmd creates the review command.
+func newReviewCmd() *cobra.Command {
+	return newReviewCmdInternal(nil, nil)
+}
+
+// newReviewCmdInternal creates the review command with optional storage and
+// model injection. If complete is nil, the configured LLM is used.
+func newReviewCmdInternal(storage *ledger.Storage, complete completeFunc) *cobra.Command {
+	var flags reviewFlags
+
+	cmd := &cobra.Command{
+		Use:   "review [<entry-id>...]",
+		Short: "Critique entry quality with the configured LLM",
+		Long: `Ask the configured LLM to critique entries and suggest better text.
+
+The model flags vague rationale, missing how, and summaries that restate the
+diff, and proposes rewritten what/why/how using only facts in the entry. With
+--apply, each suggestion is shown as a before/after and written through amend
+once you confirm it (--yes confirms all).
+
+Examples:
+  timbers review tb_2026-01-15T15:04:05Z_8f2c1a
+  timbers review --last 5
+  timbers review --last 10 --model sonnet --apply
+  timbers review --last 3 --apply --yes --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReview(cmd, storage, complete, args, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.last, "last", "", "Review the last N entries")
+	cmd.Flags().StringVarP(&flags.model, "model", "m", "", "Model name (default: [llm] model from config)")
+	cmd.Flags().StringVarP(&flags.provider, "provider", "p", "", "Provider (anthropic, openai, google, local) - inferred if omitted")
+	cmd.Flags().BoolVar(&flags.apply, "apply", false, "Offer to amend entries with the suggested text")
+	cmd.Flags().BoolVar(&flags.yes, "yes", false, "With --apply, amend without asking")
+
+	return cmd
+}
+
+// runReview executes the review command.
+func runReview(cmd *cobra.Command, storage *ledger.Storage, complete completeFunc, ids []string, flags reviewFlags) error {
+	printer := newPrinter(cmd)
+
+	if err := validateReviewInput(printer, ids, flags); err != nil {
+		printer.Error(err)
+		return err
+	}
+	storage, err := initQueryStorage(storage, printer)
+	if err != nil {
+		return err
+	}
+	entries, err := reviewEntries(storage, ids, flags.last)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	if len(entries) == 0 {
+		printer.Println("No entries to review.")
+		return nil
+	}
+
+	reviews, err := requestReviews(printer, entries, complete, flags)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	if flags.apply {
+		return applyReviews(cmd, printer, storage, entries, reviews, flags.yes)
+	}
+	return outputReviews(printer, entries, reviews)
+}
+
+// validateReviewInput checks the entry selection and --apply flags.
+func validateReviewInput(printer output.Reporter, ids []string, flags reviewFlags) error {
+	if (len(ids) == 0) == (flags.last == "") {
+		return output.NewUserError("specify entry IDs or --last N, not both")
+	}
+	if flags.last != "" {
+		if count, err := strconv.Atoi(flags.last); err != nil || count <= 0 {
+			return output.NewUserError("--last must be a positive integer")
+		}
+	}
+	if flags.yes && !flags.apply {
+		return output.NewUserError("--yes only applies with --apply")
+	}
+	if flags.apply && !flags.yes && printer.IsJSON() {
+		return output.NewUserError("--apply with --json cannot prompt; add --yes")
+	}
+	return nil
+}
+
+// reviewEntries loads the entries named by ids, or the last N entries.
+func reviewEntries(storage *ledger.Storage, ids []string, last string) ([]*ledger.Entry, error) {
+	if len(ids) > 0 {
+		entries := make([]*ledger.Entry, 0, len(ids))
+		for _, id := range ids {
+			entry, err := storage.GetEntryByID(id)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+		return entries, nil
+	}
+
+	count, _ := strconv.Atoi(last)
+	entries, err := storage.GetLastNEntries(count)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// requestReviews renders the review template and parses the model's
+// critique of each entry.
+func requestReviews(
+	printer *output.Printer, entries []*ledger.Entry, complete completeFunc, flags reviewFlags,
+) ([]draft.EntryReview, error) {
+	tmpl, err := draft.LoadTemplate(reviewTemplate)
+	if err != nil {
+		return nil, output.NewUserError(err.Error())
+	}
+	prompt, err := draft.Render(tmpl, buildRenderContext(entries, "", nil))
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to render", err)
+	}
+
+	if complete == nil {
+		client, clientErr := llm.New(configuredModel(flags.model), llm.Provider(flags.provider))
+		if clientErr != nil {
+			return nil, output.NewUserError(clientErr.Error())
+		}
+		complete = func(ctx context.Context, text string) (string, error) {
+			resp, requestErr := completeWithProgress(ctx, printer, client, llm.Request{Prompt: text})
+			if requestErr != nil {
+				return "", requestErr
+			}
+			return resp.Content, nil
+		}
+	}
+
+	ctx, cancel := llmContext()
+	defer cancel()
+	content, err := complete(ctx, prompt)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("LLM request failed", err)
+	}
+
+	reviews, err := draft.ParseReviews(content)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("model returned an unusable review", err)
+	}
+	return matchReviews(entries, reviews), nil
+}
+
+// matchReviews keeps one review per reviewed entry, in entry order. Reviews
+// for IDs that were not sent are dropped, and an entry the model skipped gets
+// an empty review.
+func matchReviews(entries []*ledger.Entry, reviews []draft.EntryReview) []draft.EntryReview {
+	byID := make(map[string]draft.EntryReview, len(reviews))
+	for _, review := range reviews {
+		if _, seen := byID[review.ID]; !seen {
+			byID[review.ID] = review
+		}
+	}
+	matched := make([]draft.EntryReview, len(entries))
+	for i, entry := range entries {
+		review := byID[entry.ID]
+		review.ID = entry.ID
+		matched[i] = review
+	}
+	return matched
+}
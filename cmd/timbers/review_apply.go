@@ -0,0 +1,129 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/draft"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// outputReviews prints each entry's issues and suggested text.
+func outputReviews(printer *output.Printer, entries []*ledger.Entry, reviews []draft.EntryReview) error {
+	if printer.IsJSON() {
+		return printer.Success(map[string]any{"reviews": reviews})
+	}
+
+	for i, review := range reviews {
+		if i > 0 {
+			printer.Println()
+		}
+		printer.KeyValue("Entry", review.ID)
+		printer.KeyValue("What", entries[i].Summary.What)
+		if len(review.Issues) == 0 {
+			printer.Println("  No issues found.")
+			continue
+		}
+		for _, issue := range review.Issues {
+			printer.Println("  - " + issue)
+		}
+		printSuggestions(printer, entries[i], review.Suggested)
+	}
+	return nil
+}
+
+// printSuggestions shows each suggested field next to the current text.
+func printSuggestions(printer *output.Printer, entry *ledger.Entry, suggested draft.SuggestedSummary) {
+	for _, field := range []struct{ name, before, after string }{
+		{"What", entry.Summary.What, suggested.What},
+		{"Why", entry.Summary.Why, suggested.Why},
+		{"How", entry.Summary.How, suggested.How},
+	} {
+		if field.after == "" || field.after == field.before {
+			continue
+		}
+		printer.Println()
+		printer.Section(field.name)
+		printer.Println("  Before: " + field.before)
+		printer.Println("  After:  " + field.after)
+	}
+}
+
+// applyReviews amends each entry that has a suggestion, asking first unless
+// yes is set.
+func applyReviews(
+	cmd *cobra.Command, printer *output.Printer, storage *ledger.Storage,
+	entries []*ledger.Entry, reviews []draft.EntryReview, yes bool,
+) error {
+	reader := bufio.NewReader(cmd.InOrStdin())
+	var applied, skipped []string
+	for i, review := range reviews {
+		flags := suggestionAmendFlags(entries[i], review.Suggested)
+		if flags.what == "" && flags.why == "" && flags.how == "" {
+			continue
+		}
+		if !yes {
+			printer.Println()
+			printer.KeyValue("Entry", review.ID)
+			for _, issue := range review.Issues {
+				printer.Println("  - " + issue)
+			}
+			printSuggestions(printer, entries[i], review.Suggested)
+			if !confirmAmend(printer, reader) {
+				skipped = append(skipped, review.ID)
+				continue
+			}
+		}
+		if err := storage.WriteEntry(amendEntry(entries[i], flags), true); err != nil {
+			printer.Error(err)
+			return err
+		}
+		applied = append(applied, review.ID)
+	}
+
+	if printer.IsJSON() {
+		return printer.Success(map[string]any{"reviews": reviews, "amended": nonNil(applied), "skipped": nonNil(skipped)})
+	}
+	printer.Println()
+	printer.Print("Amended %d entries, skipped %d.\n", len(applied), len(skipped))
+	return nil
+}
+
+// suggestionAmendFlags turns a suggestion into amend flags, leaving out
+// fields that are empty or unchanged.
+func suggestionAmendFlags(entry *ledger.Entry, suggested draft.SuggestedSummary) amendFlags {
+	var flags amendFlags
+	if suggested.What != entry.Summary.What {
+		flags.what = suggested.What
+	}
+	if suggested.Why != entry.Summary.Why {
+		flags.why = suggested.Why
+	}
+	if suggested.How != entry.Summary.How {
+		flags.how = suggested.How
+	}
+	return flags
+}
+
+// confirmAmend asks whether to apply the suggestion shown above it.
+func confirmAmend(printer *output.Printer, reader *bufio.Reader) bool {
+	printer.Print("%s", "  ? Apply this suggestion? [y/N] ")
+	response, err := reader.ReadString('\n')
+	if err != nil && response == "" {
+		return false
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
+
+// nonNil returns ids, or an empty slice so JSON renders [] rather than null.
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
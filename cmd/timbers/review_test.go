@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/draft"
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// runReviewTest runs the review command over file-backed entries with a
+// canned model response. It returns the output and the storage so callers
+// can check amendments.
+func runReviewTest(
+	t *testing.T, entries []*ledger.Entry, response, stdin string, args ...string,
+) (string, *ledger.Storage, error) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, entry := range entries {
+		writeQueryEntryFile(t, dir, entry)
+	}
+	files := ledger.NewFileStorage(dir, func(_ string) error { return nil }, func(_, _ string) error { return nil })
+	storage := ledger.NewStorage(&mockGitOpsForQuery{}, files)
+	complete := func(_ context.Context, prompt string) (string, error) {
+		if len(entries) > 0 && !strings.Contains(prompt, entries[0].ID) {
+			t.Errorf("prompt is missing entry %s", entries[0].ID)
+		}
+		return response, nil
+	}
+
+	cmd := newReviewCmdInternal(storage, complete)
+	cmd.PersistentFlags().Bool("json", false, "")
+	var buf strings.Builder
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), storage, err
+}
+
+func reviewTestEntries() []*ledger.Entry {
+	now := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	vague := createQueryTestEntryStruct("aaa111", "Cleanup", now)
+	vague.Summary.Why = "cleanup"
+	fine := createQueryTestEntryStruct("bbb222", "Cache pending counts", now.Add(time.Hour))
+	return []*ledger.Entry{vague, fine}
+}
+
+func reviewTestResponse(entries []*ledger.Entry) string {
+	return "```json\n[" +
+		`{"id":"` + entries[0].ID + `","issues":["Vague why"],"suggested":{"why":"Dead flags confused new contributors"}},` +
+		`{"id":"` + entries[1].ID + `","issues":[],"suggested":{}},` +
+		`{"id":"tb_2020-01-01T00:00:00Z_ffffff","issues":["Not sent"],"suggested":{"why":"x"}}` +
+		"]\n```"
+}
+
+func TestReviewPrintsIssues(t *testing.T) {
+	entries := reviewTestEntries()
+
+	out, _, err := runReviewTest(t, entries, reviewTestResponse(entries), "", "--last", "2")
+	if err != nil {
+		t.Fatalf("review errored: %v\noutput: %s", err, out)
+	}
+	for _, want := range []string{"Vague why", "Dead flags confused new contributors", "No issues found."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Not sent") {
+		t.Errorf("output includes a review for an entry that was not sent:\n%s", out)
+	}
+}
+
+func TestReviewApplyConfirms(t *testing.T) {
+	entries := reviewTestEntries()
+
+	out, storage, err := runReviewTest(t, entries, reviewTestResponse(entries), "n\n", entries[0].ID, "--apply")
+	if err != nil {
+		t.Fatalf("review --apply errored: %v\noutput: %s", err, out)
+	}
+	if got, _ := storage.GetEntryByID(entries[0].ID); got.Summary.Why != "cleanup" {
+		t.Errorf("declined suggestion was applied: why = %q", got.Summary.Why)
+	}
+
+	out, storage, err = runReviewTest(t, entries, reviewTestResponse(entries), "y\n", entries[0].ID, "--apply")
+	if err != nil {
+		t.Fatalf("review --apply errored: %v\noutput: %s", err, out)
+	}
+	if got, _ := storage.GetEntryByID(entries[0].ID); got.Summary.Why != "Dead flags confused new contributors" {
+		t.Errorf("accepted suggestion not applied: why = %q", got.Summary.Why)
+	}
+}
+
+func TestReviewApplyYesJSON(t *testing.T) {
+	entries := reviewTestEntries()
+
+	out, _, err := runReviewTest(t, entries, reviewTestResponse(entries), "", "--last", "2", "--apply", "--yes", "--json")
+	if err != nil {
+		t.Fatalf("review --apply --yes errored: %v\noutput: %s", err, out)
+	}
+	var result struct {
+		Reviews []draft.EntryReview `json:"reviews"`
+		Amended []string            `json:"amended"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(result.Reviews) != 2 || len(result.Amended) != 1 || result.Amended[0] != entries[0].ID {
+		t.Errorf("result = %+v, want 2 reviews and only %s amended", result, entries[0].ID)
+	}
+}
+
+func TestReviewValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no selection", nil, "--last"},
+		{"ids and last", []string{"tb_x", "--last", "2"}, "not both"},
+		{"bad last", []string{"--last", "0"}, "positive integer"},
+		{"yes without apply", []string{"--last", "1", "--yes"}, "--apply"},
+		{"json apply without yes", []string{"--last", "1", "--apply", "--json"}, "--yes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, _, err := runReviewTest(t, nil, "[]", "", tt.args...)
+			if err == nil || !strings.Contains(out, tt.want) {
+				t.Errorf("want error mentioning %q, got err=%v output: %s", tt.want, err, out)
+			}
+		})
+	}
+}
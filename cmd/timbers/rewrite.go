@@ -0,0 +1,80 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// entryIDs returns the IDs of entries, in order.
+func entryIDs(entries []*ledger.Entry) []string {
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.ID
+	}
+	return ids
+}
+
+// hasPerFileDiffstat reports whether any of entries recorded per-file
+// diffstats, so entries rewritten from them keep doing so.
+func hasPerFileDiffstat(entries []*ledger.Entry) bool {
+	return slices.ContainsFunc(entries, func(entry *ledger.Entry) bool {
+		return entry.Workset.Diffstat != nil && len(entry.Workset.Diffstat.PerFile) > 0
+	})
+}
+
+// worksetDiffstat recomputes the diffstat of a rewritten workset from git,
+// from the parent of its oldest commit to its anchor. Best effort: nil when
+// git cannot produce it, since adding up the diffstats it was built from
+// would double-count or overcount.
+func worksetDiffstat(storage *ledger.Storage, workset ledger.Workset, perFile bool) *ledger.Diffstat {
+	commits := workset.Commits
+	stat, err := storage.GetDiffstat(commits[len(commits)-1]+"^", workset.AnchorCommit)
+	if err != nil {
+		return nil
+	}
+	return ledger.NewDiffstat(stat, perFile)
+}
+
+// worksetFiles lists the files commits changed. Best effort: nil when git
+// cannot list them.
+func worksetFiles(storage *ledger.Storage, shas []string) []string {
+	commits := make([]git.Commit, len(shas))
+	for i, sha := range shas {
+		commits[i] = git.Commit{SHA: sha}
+	}
+	files, err := storage.WorksetFiles(commits)
+	if err != nil {
+		return nil
+	}
+	return files
+}
+
+// rewriteRelations returns copies of the ledger's entries, other than those
+// in skipIDs, that retarget changes, each with a revision recorded.
+func rewriteRelations(
+	storage *ledger.Storage, skipIDs []string, now time.Time, retarget func(*ledger.Entry) bool,
+) ([]*ledger.Entry, error) {
+	entries, err := storage.ListEntries()
+	if err != nil {
+		return nil, err
+	}
+	var rewritten []*ledger.Entry
+	for _, entry := range entries {
+		if slices.Contains(skipIDs, entry.ID) {
+			continue
+		}
+		updated := *entry
+		updated.Relations = slices.Clone(entry.Relations)
+		if !retarget(&updated) {
+			continue
+		}
+		updated.UpdatedAt = now
+		updated.RecordRevision(entry, resolveAcker(), now)
+		rewritten = append(rewritten, &updated)
+	}
+	return rewritten, nil
+}
@@ -0,0 +1,131 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// newRmCmd creates the rm command.
+func newRmCmd() *cobra.Command {
+	return newRmCmdInternal(nil)
+}
+
+// newRmCmdInternal creates the rm command with optional storage injection.
+// If storage is nil, a real storage is created when the command runs.
+func newRmCmdInternal(storage *ledger.Storage) *cobra.Command {
+	var reason string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "rm <entry-id>",
+		Short: "Delete an entry by recording a tombstone",
+		Long: `Delete an entry by writing a tombstone record (who, when, and why) next to it.
+
+The entry file itself is kept, so the ledger stays append-only in spirit and
+the deletion is auditable in git history. Deleted entries are hidden from
+query and export unless --include-deleted is passed; timbers show still finds
+them by ID. Their commits stay documented for pending detection.
+
+Examples:
+  timbers rm tb_2026-01-15T15:04:05Z_8f2c1a --reason "Logged against the wrong repo"
+  timbers rm tb_2026-01-15T15:04:05Z_8f2c1a --reason "Duplicate of tb_..." --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRm(cmd, storage, args[0], reason, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "One-line explanation of why the entry is deleted (required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be written without writing")
+	_ = cmd.MarkFlagRequired("reason")
+
+	return cmd
+}
+
+// runRm executes the rm command.
+func runRm(cmd *cobra.Command, storage *ledger.Storage, entryID, reason string, dryRun bool) error {
+	printer := newPrinter(cmd)
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		err := output.NewUserError("--reason must not be empty")
+		printer.Error(err)
+		return err
+	}
+
+	storage, err := initAmendStorage(storage, printer)
+	if err != nil {
+		return err
+	}
+
+	entry, err := storage.GetEntryByID(entryID)
+	if err == nil && storage.DeletedSet()[entry.ID] {
+		err = output.NewConflictError("entry already deleted: " + entry.ID).WithID(output.ErrCodeEntryExists)
+	}
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	now := time.Now().UTC()
+	tombstone := &ledger.Tombstone{
+		Schema:    ledger.SchemaVersion,
+		Kind:      ledger.KindTombstone,
+		ID:        ledger.TombstoneID(entry.ID),
+		DeletedAt: now,
+		Who:       resolveAcker(),
+		TargetID:  entry.ID,
+		Reason:    reason,
+	}
+
+	if dryRun {
+		return outputRmDryRun(printer, tombstone)
+	}
+
+	if err := storage.WriteTombstone(tombstone); err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	return outputRmSuccess(printer, tombstone)
+}
+
+// outputRmDryRun reports what would be written without writing.
+func outputRmDryRun(printer *output.Printer, tombstone *ledger.Tombstone) error {
+	if printer.IsJSON() {
+		return printer.Success(withPlan(map[string]any{
+			"tombstone_id": tombstone.ID,
+			"target_id":    tombstone.TargetID,
+			"reason":       tombstone.Reason,
+			"who":          tombstone.Who,
+		}, []plannedAction{{Action: planCreate, Target: tombstone.ID, Detail: "tombstone for " + tombstone.TargetID}}))
+	}
+	printer.Println("Would delete " + tombstone.TargetID)
+	printer.KeyValue("Tombstone", tombstone.ID)
+	printer.KeyValue("Reason", tombstone.Reason)
+	if tombstone.Who.Name != "" || tombstone.Who.Email != "" {
+		printer.KeyValue("Who", tombstone.Who.Name+" <"+tombstone.Who.Email+">")
+	}
+	return nil
+}
+
+// outputRmSuccess prints the summary after the tombstone is committed.
+func outputRmSuccess(printer *output.Printer, tombstone *ledger.Tombstone) error {
+	if printer.IsJSON() {
+		return printer.Success(map[string]any{
+			"status":       "deleted",
+			"tombstone_id": tombstone.ID,
+			"target_id":    tombstone.TargetID,
+			"reason":       tombstone.Reason,
+		})
+	}
+	printer.Println("Deleted " + tombstone.TargetID)
+	printer.Println("  " + tombstone.Reason)
+	return nil
+}
@@ -0,0 +1,101 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// runRmTest runs the rm command against storage and returns its output.
+func runRmTest(t *testing.T, storage *ledger.Storage, args ...string) (string, error) {
+	t.Helper()
+	cmd := newRmCmdInternal(storage)
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// queryIDs runs query --last 10 --json with extra args and returns the IDs.
+func queryIDs(t *testing.T, storage *ledger.Storage, args ...string) []string {
+	t.Helper()
+	cmd := newQueryCmdInternal(storage)
+	cmd.PersistentFlags().Bool("json", false, "")
+	_ = cmd.PersistentFlags().Set("json", "true")
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(append([]string{"--last", "10"}, args...))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("query: %v\n%s", err, buf)
+	}
+	var entries []ledger.Entry
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("parsing query JSON: %v\n%s", err, buf)
+	}
+	ids := make([]string, len(entries))
+	for i := range entries {
+		ids[i] = entries[i].ID
+	}
+	return ids
+}
+
+func TestRmHidesEntryFromQuery(t *testing.T) {
+	storage, _, earlier, later := setupLinkTestStorage(t)
+
+	out, err := runRmTest(t, storage, later, "--reason", "Logged against the wrong repo")
+	if err != nil {
+		t.Fatalf("rm: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Deleted "+later) {
+		t.Errorf("output missing deletion message:\n%s", out)
+	}
+
+	if ids := queryIDs(t, storage); !slices.Equal(ids, []string{earlier}) {
+		t.Errorf("query = %v, want only %s", ids, earlier)
+	}
+	if ids := queryIDs(t, storage, "--include-deleted"); len(ids) != 2 {
+		t.Errorf("query --include-deleted = %v, want both entries", ids)
+	}
+	// The entry itself is kept and still found by ID.
+	if _, err := storage.GetEntryByID(later); err != nil {
+		t.Errorf("GetEntryByID after rm: %v", err)
+	}
+
+	if _, err := runRmTest(t, storage, later, "--reason", "again"); err == nil || !strings.Contains(err.Error(), "already deleted") {
+		t.Errorf("second rm = %v, want an already deleted error", err)
+	}
+}
+
+func TestRmRejectsBadInput(t *testing.T) {
+	storage, _, _, later := setupLinkTestStorage(t)
+
+	if _, err := runRmTest(t, storage, later, "--reason", "  "); err == nil {
+		t.Error("rm with a blank reason expected an error")
+	}
+	if _, err := runRmTest(t, storage, "tb_2025-01-01T00:00:00Z_000000", "--reason", "x"); err == nil {
+		t.Error("rm of a missing entry expected an error")
+	}
+}
+
+func TestRmDryRunWritesNothing(t *testing.T) {
+	storage, _, _, later := setupLinkTestStorage(t)
+
+	out, err := runRmTest(t, storage, later, "--reason", "Duplicate", "--dry-run")
+	if err != nil {
+		t.Fatalf("rm --dry-run: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Would delete "+later) {
+		t.Errorf("output missing dry-run message:\n%s", out)
+	}
+	if ids := queryIDs(t, storage); len(ids) != 2 {
+		t.Errorf("dry run hid entries: query = %v", ids)
+	}
+}
@@ -0,0 +1,130 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// statusSchema is the JSON Schema for `timbers status --json`.
+//
+//go:embed schemas/status.schema.json
+var statusSchema []byte
+
+// schemaIDBase prefixes the $id of every schema timbers publishes.
+const schemaIDBase = "https://github.com/gorewood/timbers/schema/timbers.devlog/v1/"
+
+// schemaInfo describes one schema the schema command can print.
+type schemaInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// schemaCatalog lists the schemas in the order they are documented.
+var schemaCatalog = []schemaInfo{
+	{"entry", "a ledger entry (.timbers/<id>.json)"},
+	{"export", "export --json to stdout: an array of entries"},
+	{"query", "query --json: an array of entries"},
+	{"status", "status --json"},
+}
+
+// newSchemaCmd creates the schema command.
+func newSchemaCmd() *cobra.Command {
+	validArgs := make([]string, 0, len(schemaCatalog))
+	for _, info := range schemaCatalog {
+		validArgs = append(validArgs, info.Name)
+	}
+
+	return &cobra.Command{
+		Use:   "schema [entry|export|query|status]",
+		Short: "Print JSON Schemas for entries and command output",
+		Long: `Print the JSON Schema (draft 2020-12) for the ledger entry format or a
+command's --json output, so pipelines can validate what timbers writes and
+generate typed clients from it.
+
+Without an argument, lists the available schemas. The schema is printed as
+JSON whatever the output mode; --yaml renders it as YAML.
+
+Examples:
+  timbers schema                 # List available schemas
+  timbers schema entry           # Schema for .timbers/ entry files
+  timbers schema query > q.json  # Schema for timbers query --json`,
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: validArgs,
+		RunE:      runSchema,
+	}
+}
+
+// runSchema prints the named schema, or the catalog without a name.
+func runSchema(cmd *cobra.Command, args []string) error {
+	printer := newPrinter(cmd)
+
+	if len(args) == 0 {
+		if printer.IsJSON() {
+			return printer.WriteJSON(map[string]any{"schemas": schemaCatalog})
+		}
+		for _, info := range schemaCatalog {
+			printer.Print("%-8s %s\n", info.Name, info.Description)
+		}
+		return nil
+	}
+
+	schema, err := commandSchema(args[0])
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	return printer.WriteJSON(json.RawMessage(schema))
+}
+
+// commandSchema returns the schema named name.
+func commandSchema(name string) ([]byte, error) {
+	switch name {
+	case "entry":
+		return ledger.EntrySchema, nil
+	case "export":
+		return entryArraySchema("export.json", "timbers export --json")
+	case "query":
+		return entryArraySchema("query.json", "timbers query --json")
+	case "status":
+		return statusSchema, nil
+	}
+	return nil, output.NewUserError(fmt.Sprintf("unknown schema %q: want entry, export, query, or status", name))
+}
+
+// entryArraySchema wraps the entry schema as an array of entries. The entry
+// schema's $defs move to the top level, so its "#/$defs/..." references
+// still resolve, and the entry itself becomes $defs/entry.
+func entryArraySchema(id, title string) ([]byte, error) {
+	var entry map[string]any
+	if err := json.Unmarshal(ledger.EntrySchema, &entry); err != nil {
+		return nil, output.NewSystemErrorWithCause("parsing embedded entry schema", err)
+	}
+	defs, _ := entry["$defs"].(map[string]any)
+	if defs == nil {
+		defs = map[string]any{}
+	}
+	delete(entry, "$defs")
+	delete(entry, "$schema")
+	delete(entry, "$id")
+	defs["entry"] = entry
+
+	data, err := json.Marshal(map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     schemaIDBase + id,
+		"title":   title,
+		"type":    "array",
+		"items":   map[string]any{"$ref": "#/$defs/entry"},
+		"$defs":   defs,
+	})
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("encoding "+id+" schema", err)
+	}
+	return data, nil
+}
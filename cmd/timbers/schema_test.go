@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// resolveSchema parses and resolves the named schema.
+func resolveSchema(t *testing.T, name string) *jsonschema.Resolved {
+	t.Helper()
+	data, err := commandSchema(name)
+	if err != nil {
+		t.Fatalf("commandSchema(%q) error = %v", name, err)
+	}
+	var schema jsonschema.Schema
+	if err = json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("schema %q is not a JSON Schema: %v", name, err)
+	}
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		t.Fatalf("schema %q does not resolve: %v", name, err)
+	}
+	return resolved
+}
+
+// validateOutput checks command JSON output against the named schema.
+func validateOutput(t *testing.T, name, out string) {
+	t.Helper()
+	var instance any
+	if err := json.Unmarshal([]byte(out), &instance); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if err := resolveSchema(t, name).Validate(instance); err != nil {
+		t.Errorf("%s output does not match its schema: %v\n%s", name, err, out)
+	}
+}
+
+func TestSchemasResolve(t *testing.T) {
+	for _, info := range schemaCatalog {
+		resolveSchema(t, info.Name)
+	}
+	if err := resolveSchema(t, "status").Validate(map[string]any{"repo": "r"}); err == nil {
+		t.Error("status schema accepted an object missing required fields")
+	}
+	if _, err := commandSchema("nope"); err == nil {
+		t.Error("commandSchema(nope) should fail")
+	}
+}
+
+// TestSchemasMatchCommandOutput runs status and query in a repo with an
+// entry and validates their JSON against the published schemas.
+func TestSchemasMatchCommandOutput(t *testing.T) {
+	dir := newLogAnchorRepo(t)
+	if out, err := runLogCmd(t, dir, "Seed work", "--why", "Schema test", "--how", "Logged it"); err != nil {
+		t.Fatalf("log failed: %v\n%s", err, out)
+	}
+
+	for _, tt := range []struct {
+		schema string
+		args   []string
+	}{
+		{"status", []string{"status", "--json"}},
+		{"status", []string{"status", "--json", "--verbose"}},
+		{"query", []string{"query", "--json", "--last", "5"}},
+		{"export", []string{"export", "--json", "--last", "5"}},
+	} {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			var out strings.Builder
+			runInDir(t, dir, func() {
+				cmd := newRootCmd()
+				cmd.SetOut(&out)
+				cmd.SetArgs(tt.args)
+				if err := cmd.Execute(); err != nil {
+					t.Fatalf("%v failed: %v", tt.args, err)
+				}
+			})
+			validateOutput(t, tt.schema, out.String())
+		})
+	}
+}
+
+func TestSchemaCommand(t *testing.T) {
+	stdout, _, err := runStreamTest(t, "schema", "entry")
+	if err != nil {
+		t.Fatalf("schema entry failed: %v", err)
+	}
+	var schema map[string]any
+	if err = json.Unmarshal([]byte(stdout), &schema); err != nil || schema["title"] != "timbers ledger entry" {
+		t.Errorf("schema entry = %q, want the entry schema", stdout)
+	}
+
+	stdout, _, err = runStreamTest(t, "schema", "--json")
+	if err != nil || !strings.Contains(stdout, `"name": "status"`) {
+		t.Errorf("schema --json = %q (err %v), want the catalog", stdout, err)
+	}
+
+	if _, _, err := runStreamTest(t, "schema", "bogus"); err == nil {
+		t.Error("schema bogus should fail")
+	}
+}
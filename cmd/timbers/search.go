@@ -0,0 +1,142 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// searchFlags holds flag values for the search command.
+type searchFlags struct {
+	limit    int
+	kinds    []string
+	semantic bool
+}
+
+// newSearchCmd creates the search command.
+func newSearchCmd() *cobra.Command {
+	return newSearchCmdInternal(nil)
+}
+
+// newSearchCmdInternal creates the search command with optional storage injection.
+// If storage is nil, a real storage is created when the command runs.
+func newSearchCmdInternal(storage *ledger.Storage) *cobra.Command {
+	var flags searchFlags
+
+	cmd := &cobra.Command{
+		Use:   "search <terms>...",
+		Short: "Search entries by relevance",
+		Long: `Search ledger entries and list them by relevance.
+
+By default entries are ranked by keyword match over what, why, how, notes,
+tags, and work items, using an inverted index cached under .git/timbers/ and
+updated for new or amended entries on each search (rebuild it with
+timbers fsck --reindex). --semantic ranks by embedding similarity instead, which
+finds rationale phrased differently from the query. It needs [llm]
+embedding_model in .timbers/config.toml; vectors are cached under .git/timbers/
+and only new or amended entries are embedded on each search.
+
+Examples:
+  timbers search token refresh                  # Keyword search
+  timbers search "why is the cache per-process" --semantic
+  timbers search flaky tests --kind incident --limit 5 --json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSearch(cmd, storage, strings.Join(args, " "), flags)
+		},
+	}
+
+	cmd.Flags().IntVar(&flags.limit, "limit", 10, "Maximum number of results")
+	cmd.Flags().StringSliceVar(&flags.kinds, "kind", []string{},
+		"Filter by kind: entry, decision, incident, milestone, note (repeatable or comma-separated)")
+	cmd.Flags().BoolVar(&flags.semantic, "semantic", false, "Rank by embedding similarity ([llm] embedding_model)")
+
+	return cmd
+}
+
+// runSearch executes the search command.
+func runSearch(cmd *cobra.Command, storage *ledger.Storage, query string, flags searchFlags) error {
+	printer := newPrinter(cmd)
+
+	kinds, err := parseKindFlags(flags.kinds)
+	if err == nil && flags.limit <= 0 {
+		err = output.NewUserError("--limit must be a positive integer")
+	}
+	if err == nil && !flags.semantic && len(ledger.QueryTerms(query)) == 0 {
+		err = output.NewUserError("search terms have no searchable words")
+	}
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	storage, err = initQueryStorage(storage, printer)
+	if err != nil {
+		return err
+	}
+	entries, err := storage.ListEntries()
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	ranked, err := rankForSearch(storage, entries, kinds, query, flags.limit, flags.semantic)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	return outputSearchResults(printer, query, flags.semantic, ranked)
+}
+
+// rankForSearch ranks the entries of kinds (all entries when kinds is empty)
+// by keyword match through the search index, or by embedding similarity when
+// semantic is set.
+func rankForSearch(
+	storage *ledger.Storage, entries []*ledger.Entry, kinds []string, query string, limit int, semantic bool,
+) ([]ledger.ScoredEntry, error) {
+	if !semantic {
+		// The index covers every entry; filtering first would drop the
+		// other kinds from it on each search.
+		index := refreshedSearchIndex(storage, entries)
+		return index.Rank(ledger.FilterEntriesByKinds(entries, kinds), query, limit), nil
+	}
+	entries = ledger.FilterEntriesByKinds(entries, kinds)
+	ctx, cancel := llmContext()
+	defer cancel()
+	return semanticRank(ctx, entries, query, limit)
+}
+
+// outputSearchResults prints ranked entries, one per line, most relevant first.
+func outputSearchResults(printer *output.Printer, query string, semantic bool, ranked []ledger.ScoredEntry) error {
+	mode := "keyword"
+	if semantic {
+		mode = "semantic"
+	}
+
+	if printer.IsJSON() {
+		results := make([]map[string]any, len(ranked))
+		for i, scored := range ranked {
+			results[i] = map[string]any{
+				"id":         scored.Entry.ID,
+				"kind":       scored.Entry.KindOrDefault(),
+				"created_at": scored.Entry.CreatedAt,
+				"what":       scored.Entry.Summary.What,
+				"score":      scored.Score,
+			}
+		}
+		return printer.Success(map[string]any{"query": query, "mode": mode, "results": results})
+	}
+
+	if len(ranked) == 0 {
+		printer.Println("No matching entries.")
+		return nil
+	}
+	for _, scored := range ranked {
+		printer.Print("%s  %5.2f  %s\n", scored.Entry.ID, scored.Score, scored.Entry.Summary.What)
+	}
+	return nil
+}
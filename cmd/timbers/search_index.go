@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// refreshedSearchIndex returns storage's cached search index brought up to
+// date with entries, saving it when anything changed. The cache is only an
+// optimization: without a cache location, or when it cannot be read or
+// saved, search builds the index in memory instead of failing.
+func refreshedSearchIndex(storage *ledger.Storage, entries []*ledger.Entry) *ledger.SearchIndex {
+	path := storage.SearchIndexPath()
+	index := ledger.NewSearchIndex()
+	if path != "" {
+		if loaded, err := ledger.LoadSearchIndex(path); err == nil {
+			index = loaded
+		}
+	}
+	if index.Refresh(entries) > 0 && path != "" {
+		_ = index.Save(path)
+	}
+	return index
+}
+
+// rebuildSearchIndex indexes entries from scratch and saves the index.
+// Reports whether storage has a search index to rebuild.
+func rebuildSearchIndex(storage *ledger.Storage, entries []*ledger.Entry) (bool, error) {
+	path := storage.SearchIndexPath()
+	if path == "" {
+		return false, nil
+	}
+	index := ledger.NewSearchIndex()
+	index.Refresh(entries)
+	if err := index.Save(path); err != nil {
+		return true, output.NewSystemErrorWithCause("failed to save search index", err)
+	}
+	return true, nil
+}
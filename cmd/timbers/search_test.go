@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// runSearchTest runs the search command over file-backed entries.
+func runSearchTest(t *testing.T, entries []*ledger.Entry, args ...string) (string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, entry := range entries {
+		writeQueryEntryFile(t, dir, entry)
+	}
+	files := ledger.NewFileStorage(dir, func(_ string) error { return nil }, func(_, _ string) error { return nil })
+	cmd := newSearchCmdInternal(ledger.NewStorage(&mockGitOpsForQuery{}, files))
+	cmd.PersistentFlags().Bool("json", false, "")
+	var buf strings.Builder
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func searchTestEntries() []*ledger.Entry {
+	now := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	return []*ledger.Entry{
+		createQueryTestEntryStruct("aaa111", "Rotate refresh tokens", now),
+		withKind(createQueryTestEntryStruct("bbb222", "Refresh tokens cache outage", now.Add(time.Hour)), ledger.KindIncident),
+		createQueryTestEntryStruct("ccc333", "Bump dependencies", now.Add(2*time.Hour)),
+	}
+}
+
+func TestSearchKeyword(t *testing.T) {
+	entries := searchTestEntries()
+
+	out, err := runSearchTest(t, entries, "rotate", "refresh", "tokens")
+	if err != nil {
+		t.Fatalf("search errored: %v\noutput: %s", err, out)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], entries[0].ID) {
+		t.Errorf("search output = %q, want the rotate entry first and the outage entry second", out)
+	}
+	if strings.Contains(out, entries[2].ID) {
+		t.Errorf("search output includes unrelated entry:\n%s", out)
+	}
+}
+
+func TestSearchKindAndJSON(t *testing.T) {
+	entries := searchTestEntries()
+
+	out, err := runSearchTest(t, entries, "tokens", "--kind", "incident", "--json")
+	if err != nil {
+		t.Fatalf("search errored: %v\noutput: %s", err, out)
+	}
+	var result struct {
+		Mode    string `json:"mode"`
+		Results []struct {
+			ID   string `json:"id"`
+			Kind string `json:"kind"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.Mode != "keyword" || len(result.Results) != 1 || result.Results[0].ID != entries[1].ID {
+		t.Errorf("search --kind incident = %+v, want only %s", result, entries[1].ID)
+	}
+}
+
+func TestSearchSemanticRequiresEmbeddingModel(t *testing.T) {
+	t.Chdir(t.TempDir())
+	out, err := runSearchTest(t, searchTestEntries(), "token", "--semantic")
+	if err == nil || !strings.Contains(out, "embedding_model") {
+		t.Errorf("want embedding_model error, got err=%v output: %s", err, out)
+	}
+}
@@ -0,0 +1,53 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/llm"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// embeddingIndexFile is the semantic search cache, relative to the git
+// directory so it is per-clone and never committed.
+const embeddingIndexFile = "timbers/embeddings.json"
+
+// semanticRank ranks entries by embedding similarity to query. The cached
+// index is refreshed first, so only entries added or amended since the last
+// search are sent to the embedding model.
+func semanticRank(ctx context.Context, entries []*ledger.Entry, query string, limit int) ([]ledger.ScoredEntry, error) {
+	model := projectLLMConfig().EmbeddingModel
+	if model == "" {
+		return nil, output.NewUserError("semantic search needs an embedding model: set [llm] embedding_model in " +
+			".timbers/config.toml (e.g. \"text-embedding-3-small\")")
+	}
+	client, err := llm.New(model, "")
+	if err != nil {
+		return nil, output.NewUserError(err.Error())
+	}
+
+	gitDir, err := git.Dir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(gitDir, filepath.FromSlash(embeddingIndexFile))
+	idx, err := ledger.LoadEmbeddingIndex(path, model)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to load embedding index", err)
+	}
+	if _, err = idx.Refresh(ctx, entries, client); err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to embed entries", err)
+	}
+	if err = idx.Save(path); err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to save embedding index", err)
+	}
+
+	vectors, err := client.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to embed query", err)
+	}
+	return idx.Rank(entries, vectors[0], limit), nil
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"github.com/spf13/cobra"
 
-	"github.com/gorewood/timbers/internal/output"
 	"github.com/gorewood/timbers/internal/setup"
 )
 
@@ -98,7 +97,7 @@ Examples:
 
 // runSetupClaude executes the setup claude command.
 func runSetupClaude(cmd *cobra.Command, project, check, remove, dryRun bool) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 
 	hookPath, scope, err := setup.ResolveClaudeSettingsPath(project)
 	if err != nil {
@@ -119,7 +118,7 @@ func runSetupClaude(cmd *cobra.Command, project, check, remove, dryRun bool) err
 
 // runSetupList lists available integrations and their status.
 func runSetupList(cmd *cobra.Command) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 
 	envs := setup.AllAgentEnvs()
 	integrations := make([]integrationInfo, 0, len(envs))
@@ -50,13 +50,12 @@ func runSetupClaudeRemove(printer *output.Printer, hookPath, scope string, dryRu
 
 	if dryRun {
 		if printer.IsJSON() {
-			return printer.Success(map[string]any{
-				"status":      "dry_run",
+			return printer.Success(withPlan(map[string]any{
 				"integration": "claude",
 				"action":      "would remove",
 				"location":    hookPath,
 				"scope":       scope,
-			})
+			}, []plannedAction{{Action: planModify, Target: hookPath, Detail: "remove timbers section"}}))
 		}
 		printer.Section("Dry Run")
 		printer.KeyValue("Action", "would remove timbers section")
@@ -93,14 +92,17 @@ func runSetupClaudeInstall(printer *output.Printer, hookPath, scope string, dryR
 		}
 
 		if printer.IsJSON() {
-			return printer.Success(map[string]any{
-				"status":            "dry_run",
+			planned := plannedAction{Action: planCreate, Target: hookPath, Detail: "install timbers section"}
+			if installed {
+				planned = plannedAction{Action: planModify, Target: hookPath, Detail: "update timbers section"}
+			}
+			return printer.Success(withPlan(map[string]any{
 				"integration":       "claude",
 				"action":            action,
 				"location":          hookPath,
 				"scope":             scope,
 				"already_installed": installed,
-			})
+			}, []plannedAction{planned}))
 		}
 		printer.Section("Dry Run")
 		printer.KeyValue("Action", action)
@@ -3,6 +3,7 @@ package main
 
 import (
 	"errors"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -20,6 +21,7 @@ func newShowCmd() *cobra.Command {
 // If storage is nil, a real storage is created when the command runs.
 func newShowCmdInternal(storage *ledger.Storage) *cobra.Command {
 	var latestFlag bool
+	var historyFlag bool
 
 	cmd := &cobra.Command{
 		Use:   "show [<id>]",
@@ -29,21 +31,26 @@ func newShowCmdInternal(storage *ledger.Storage) *cobra.Command {
 Examples:
   timbers show tb_2026-01-15T15:04:05Z_8f2c1a  # Show specific entry
   timbers show --latest                        # Show most recent entry
-  timbers show --latest --json                 # Show as JSON`,
+  timbers show --latest --json                 # Show as JSON
+  timbers show tb_2026-01-15T15:04:05Z_8f2c1a --history  # Who changed what, and when
+  timbers show --latest --query .summary.why   # Print one field`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runShow(cmd, storage, args, latestFlag)
+			return runShow(cmd, storage, args, latestFlag, historyFlag)
 		},
 	}
 
 	cmd.Flags().BoolVar(&latestFlag, "latest", false, "Show the most recent entry")
+	cmd.Flags().BoolVar(&historyFlag, "history", false, "Show the entry's revisions (amend, link)")
+	cmd.Flags().Bool("include-archived", false, "Find entries moved aside with timbers archive")
+	cmd.Flags().String("query", "", "Filter JSON output through a jq expression (implies --json)")
 
 	return cmd
 }
 
 // runShow executes the show command.
-func runShow(cmd *cobra.Command, storage *ledger.Storage, args []string, latestFlag bool) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd)).
+func runShow(cmd *cobra.Command, storage *ledger.Storage, args []string, latestFlag, historyFlag bool) error {
+	printer := newPrinter(cmd).
 		WithWidth(output.TerminalWidth(cmd.OutOrStdout(), 80))
 
 	if err := validateShowArgs(args, latestFlag); err != nil {
@@ -56,6 +63,7 @@ func runShow(cmd *cobra.Command, storage *ledger.Storage, args []string, latestF
 		printer.Error(err)
 		return err
 	}
+	includeArchived(cmd, storage)
 
 	// Get the entry
 	entry, err := getShowEntry(storage, args, latestFlag)
@@ -66,10 +74,16 @@ func runShow(cmd *cobra.Command, storage *ledger.Storage, args []string, latestF
 
 	// Output based on mode
 	if printer.IsJSON() {
+		if historyFlag {
+			return outputShowHistoryJSON(printer, entry)
+		}
 		return outputShowJSON(printer, entry)
 	}
 
-	outputShowHuman(printer, entry)
+	outputShowHuman(printer, entry, newLinks(printer, storage))
+	if historyFlag {
+		outputShowHistory(printer, entry)
+	}
 	return nil
 }
 
@@ -90,7 +104,7 @@ func resolveShowStorage(storage *ledger.Storage) (*ledger.Storage, error) {
 		return storage, nil
 	}
 	if !git.IsRepo() {
-		return nil, output.NewSystemError("not in a git repository")
+		return nil, output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 	}
 	return ledger.NewDefaultStorage()
 }
@@ -112,16 +126,62 @@ func getShowEntry(storage *ledger.Storage, args []string, latestFlag bool) (*led
 }
 
 // outputShowJSON outputs the entry as JSON.
-func outputShowJSON(printer *output.Printer, entry *ledger.Entry) error {
+func outputShowJSON(printer output.Reporter, entry *ledger.Entry) error {
 	return printer.WriteJSON(entry)
 }
 
+// outputShowHistoryJSON outputs the entry's ID and revisions, oldest first.
+func outputShowHistoryJSON(printer output.Reporter, entry *ledger.Entry) error {
+	revisions := entry.Revisions
+	if revisions == nil {
+		revisions = []ledger.Revision{}
+	}
+	return printer.WriteJSON(map[string]any{"id": entry.ID, "revisions": revisions})
+}
+
+// outputShowHistory lists the entry's revisions under the panel, oldest
+// first: when and by whom, then each changed field as before → after.
+func outputShowHistory(printer *output.Printer, entry *ledger.Entry) {
+	printer.Section("History")
+	if len(entry.Revisions) == 0 {
+		printer.Println("  No revisions since the entry was written")
+		return
+	}
+	for _, rev := range entry.Revisions {
+		printer.Println("  " + rev.RevisedAt.Format("2006-01-02 15:04:05 UTC") + "  " + formatReviser(rev.By))
+		for _, change := range rev.Changes {
+			printer.Println("    " + change.Field + ": " + formatRevisionValue(change.Before) + " → " + formatRevisionValue(change.After))
+		}
+	}
+}
+
+// formatReviser renders who made a revision, or "(unknown)" when git had no
+// identity configured.
+func formatReviser(reviser ledger.Acker) string {
+	switch {
+	case reviser.Name == "" && reviser.Email == "":
+		return "(unknown)"
+	case reviser.Email == "":
+		return reviser.Name
+	default:
+		return reviser.Name + " <" + reviser.Email + ">"
+	}
+}
+
+// formatRevisionValue quotes a field value, or shows (none) for an unset one.
+func formatRevisionValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return strconv.Quote(value)
+}
+
 // outputShowHuman outputs the entry as an aligned panel: the ID is the title
 // (the thing you copy), substance (what/why/how/notes/tags/work) leads, and
-// workset bookkeeping trails after a separator. Rounded box at a TTY,
-// borderless plain text when piped.
-func outputShowHuman(printer *output.Printer, entry *ledger.Entry) {
-	printer.FieldsBox(entry.ID, showFields(entry))
+// workset bookkeeping trails after a separator. Rounded box at a TTY, with
+// the ID and anchor as hyperlinks; borderless plain text when piped.
+func outputShowHuman(printer *output.Printer, entry *ledger.Entry, linker *links) {
+	printer.FieldsBox(linker.entry(entry.ID), showFields(entry, linker))
 }
 
 // shaExistsFunc is the function used to check if a SHA exists in the repo.
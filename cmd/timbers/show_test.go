@@ -3,6 +3,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -19,44 +20,58 @@ import (
 // mockGitOpsForShow implements ledger.GitOps for testing show command.
 type mockGitOpsForShow struct{}
 
-func (m *mockGitOpsForShow) HEAD() (string, error) {
+func (m *mockGitOpsForShow) HEAD(_ context.Context) (string, error) {
 	return "abc123def456", nil
 }
 
-func (m *mockGitOpsForShow) Log(fromRef, toRef string) ([]git.Commit, error) {
+func (m *mockGitOpsForShow) Log(_ context.Context, fromRef, toRef string) ([]git.Commit, error) {
 	return nil, nil
 }
 
-func (m *mockGitOpsForShow) LogFirstParent(fromRef, toRef string) ([]git.Commit, error) {
+func (m *mockGitOpsForShow) LogFirstParent(_ context.Context, fromRef, toRef string) ([]git.Commit, error) {
 	return nil, nil
 }
 
-func (m *mockGitOpsForShow) ResolveCommit(ref string) (string, error) {
+func (m *mockGitOpsForShow) ResolveCommit(_ context.Context, ref string) (string, error) {
 	return ref, nil
 }
 
-func (m *mockGitOpsForShow) CommitsReachableFrom(sha string) ([]git.Commit, error) {
+func (m *mockGitOpsForShow) CommitsReachableFrom(_ context.Context, sha string) ([]git.Commit, error) {
 	return nil, nil
 }
 
-func (m *mockGitOpsForShow) IsAncestorOf(ancestor, descendant string) bool {
+func (m *mockGitOpsForShow) CommitsReachableFromFirstParent(ctx context.Context, sha string) ([]git.Commit, error) {
+	return m.CommitsReachableFrom(ctx, sha)
+}
+
+func (m *mockGitOpsForShow) IsAncestorOf(_ context.Context, ancestor, descendant string) bool {
 	return true
 }
 
-func (m *mockGitOpsForShow) IsOnFirstParentLine(sha, head string) bool {
+func (m *mockGitOpsForShow) IsOnFirstParentLine(_ context.Context, sha, head string) bool {
 	return true
 }
 
-func (m *mockGitOpsForShow) GetDiffstat(fromRef, toRef string) (git.Diffstat, error) {
+func (m *mockGitOpsForShow) IsShallow(_ context.Context) bool {
+	return false
+}
+
+func (m *mockGitOpsForShow) GetDiffstat(_ context.Context, fromRef, toRef string) (git.Diffstat, error) {
 	return git.Diffstat{}, nil
 }
 
-func (m *mockGitOpsForShow) CommitFiles(sha string) ([]string, error) { return nil, nil }
-func (m *mockGitOpsForShow) CommitFilesMulti(shas []string) (map[string][]string, error) {
+func (m *mockGitOpsForShow) CommitFiles(_ context.Context, sha string) ([]string, error) {
+	return nil, nil
+}
+func (m *mockGitOpsForShow) CommitFilesMulti(_ context.Context, shas []string) (map[string][]string, error) {
 	return make(map[string][]string), nil
 }
 
-func (m *mockGitOpsForShow) DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
+func (m *mockGitOpsForShow) CommitFilesMultiFirstParent(ctx context.Context, shas []string) (map[string][]string, error) {
+	return m.CommitFilesMulti(ctx, shas)
+}
+
+func (m *mockGitOpsForShow) DiffNameOnly(_ context.Context, fromRef, toRef, pathPrefix string) ([]string, error) {
 	return nil, nil
 }
 
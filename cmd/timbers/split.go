@@ -0,0 +1,251 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// splitFlags holds the flag values for the split command.
+type splitFlags struct {
+	file   string
+	reason string
+	dryRun bool
+}
+
+// newSplitCmd creates the split command.
+func newSplitCmd() *cobra.Command {
+	return newSplitCmdInternal(nil)
+}
+
+// newSplitCmdInternal creates the split command with optional storage
+// injection. If storage is nil, a real storage is created when the command
+// runs.
+func newSplitCmdInternal(storage *ledger.Storage) *cobra.Command {
+	var flags splitFlags
+
+	cmd := &cobra.Command{
+		Use:   "split <entry-id>",
+		Short: "Split an entry's commits into several entries",
+		Long: `Split an entry that covered too many commits into several, each with its own
+what, why, and how.
+
+The entry opens in your git editor as a plan: "=== Entry" blocks, each listing
+commits (a SHA or prefix per line) followed by ## What, ## Why, ## How, and
+## Notes sections. Move every commit under exactly one block. Without a
+terminal, write the same plan to a file and pass --file.
+
+Each new entry is anchored at its newest commit and keeps the original's
+tags, work items, contributors, meta, and relations. The original gets a
+tombstone, as with timbers rm, and relations other entries hold to it point
+at every new entry. Everything is written in one commit.
+
+Examples:
+  timbers split tb_2026-01-15T15:04:05Z_8f2c1a
+  timbers split tb_2026-01-15T15:04:05Z_8f2c1a --file split.md --dry-run --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSplit(cmd, storage, args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.file, "file", "", "Read the split plan from a file instead of the editor")
+	cmd.Flags().StringVar(&flags.reason, "reason", "", "Reason recorded in the original's tombstone (default \"split into <ids>\")")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Show what would be written without writing")
+
+	return cmd
+}
+
+// splitPlan is everything a split writes.
+type splitPlan struct {
+	original   *ledger.Entry
+	parts      []*ledger.Entry
+	retargeted []*ledger.Entry
+	tombstone  *ledger.Tombstone
+}
+
+// runSplit executes the split command.
+func runSplit(cmd *cobra.Command, storage *ledger.Storage, id string, flags splitFlags) error {
+	printer := newPrinter(cmd)
+
+	storage, err := initAmendStorage(storage, printer)
+	if err != nil {
+		return err
+	}
+	plan, err := planSplit(cmd, printer, storage, id, flags)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	if flags.dryRun {
+		return outputSplitDryRun(printer, plan)
+	}
+	if err := storage.WriteSplit(plan.parts, plan.retargeted, plan.tombstone); err != nil {
+		printer.Error(err)
+		return err
+	}
+	return outputSplitSuccess(printer, plan)
+}
+
+// planSplit reads the entry and the split plan and builds the new entries,
+// the retargeted entries, and the tombstone.
+func planSplit(
+	cmd *cobra.Command, printer *output.Printer, storage *ledger.Storage, id string, flags splitFlags,
+) (*splitPlan, error) {
+	original, err := loadSplitOriginal(storage, id)
+	if err != nil {
+		return nil, err
+	}
+	text, err := readSplitPlan(cmd, printer, storage, original, flags.file)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := parseSplitTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+	specs, err := resolveSplitCommits(blocks, original.Workset.Commits)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	parts, err := ledger.SplitEntry(original, specs, now)
+	if err != nil {
+		return nil, output.NewUserError("cannot split: " + err.Error())
+	}
+	perFile := hasPerFileDiffstat([]*ledger.Entry{original})
+	for _, part := range parts {
+		part.ID = storage.NewID(part.Workset.AnchorCommit, now)
+		part.Workset.Files = worksetFiles(storage, part.Workset.Commits)
+		part.Workset.Diffstat = worksetDiffstat(storage, part.Workset, perFile)
+	}
+
+	partIDs := entryIDs(parts)
+	retargeted, err := rewriteRelations(storage, []string{original.ID}, now, func(entry *ledger.Entry) bool {
+		return entry.FanOutRelations(original.ID, partIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(flags.reason)
+	if reason == "" {
+		reason = "split into " + strings.Join(partIDs, ", ")
+	}
+	tombstone := &ledger.Tombstone{
+		Schema: ledger.SchemaVersion, Kind: ledger.KindTombstone, ID: ledger.TombstoneID(original.ID),
+		DeletedAt: now, Who: resolveAcker(), TargetID: original.ID, Reason: reason,
+	}
+	return &splitPlan{original: original, parts: parts, retargeted: retargeted, tombstone: tombstone}, nil
+}
+
+// loadSplitOriginal reads the entry to split, refusing one already deleted
+// or covering a single commit.
+func loadSplitOriginal(storage *ledger.Storage, id string) (*ledger.Entry, error) {
+	original, err := storage.GetEntryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if storage.DeletedSet()[original.ID] {
+		return nil, output.NewConflictError("entry already deleted: " + original.ID).WithID(output.ErrCodeEntryExists)
+	}
+	if len(original.Workset.Commits) < 2 {
+		return nil, output.NewUserError("cannot split " + original.ID + ": it covers a single commit")
+	}
+	return original, nil
+}
+
+// readSplitPlan returns the split plan from --file, or from the editor when
+// a user is at a terminal to write it.
+func readSplitPlan(
+	cmd *cobra.Command, printer *output.Printer, storage *ledger.Storage, original *ledger.Entry, file string,
+) (string, error) {
+	if file != "" {
+		data, err := os.ReadFile(file) //nolint:gosec // user-specified plan file
+		if err != nil {
+			return "", output.NewUserError("failed to read split plan: " + err.Error())
+		}
+		return string(data), nil
+	}
+	if printer.IsJSON() || !canPromptEditor(cmd.InOrStdin()) {
+		return "", output.NewUserError("no terminal to edit the split plan in; write it to a file and pass --file")
+	}
+	return editText(formatSplitTemplate(original, commitSubjects(storage, original.Workset)))
+}
+
+// commitSubjects maps the commits of workset to their subjects. Best
+// effort: commits git cannot find are left out.
+func commitSubjects(storage *ledger.Storage, workset ledger.Workset) map[string]string {
+	subjects := make(map[string]string, len(workset.Commits))
+	commits, err := storage.LogRange(workset.Commits[len(workset.Commits)-1]+"^", workset.AnchorCommit)
+	if err != nil {
+		return subjects
+	}
+	for _, commit := range commits {
+		subjects[commit.SHA] = commit.Subject
+	}
+	return subjects
+}
+
+// splitResult is the JSON form of a split.
+func splitResult(plan *splitPlan) map[string]any {
+	return map[string]any{
+		"id":         plan.original.ID,
+		"split_into": entryIDs(plan.parts),
+		"retargeted": entryIDs(plan.retargeted),
+		"entries":    plan.parts,
+	}
+}
+
+// outputSplitDryRun reports what a split would write.
+func outputSplitDryRun(printer *output.Printer, plan *splitPlan) error {
+	if printer.IsJSON() {
+		actions := make([]plannedAction, 0, len(plan.parts)+len(plan.retargeted)+1)
+		for _, part := range plan.parts {
+			actions = append(actions, plannedAction{Action: planCreate, Target: part.ID, Detail: "split from " + plan.original.ID})
+		}
+		for _, entry := range plan.retargeted {
+			actions = append(actions, plannedAction{Action: planModify, Target: entry.ID, Detail: "relations point at the split entries"})
+		}
+		actions = append(actions, plannedAction{Action: planCreate, Target: plan.tombstone.ID, Detail: "tombstone for " + plan.original.ID})
+		return printer.WriteJSON(withPlan(splitResult(plan), actions))
+	}
+	printer.Println("Dry run - would split " + plan.original.ID + " into " + strconv.Itoa(len(plan.parts)) + " entries:")
+	printSplitSummary(printer, plan)
+	return nil
+}
+
+// outputSplitSuccess prints the result after the split is committed.
+func outputSplitSuccess(printer *output.Printer, plan *splitPlan) error {
+	if printer.IsJSON() {
+		result := splitResult(plan)
+		result["status"] = "split"
+		return printer.Success(result)
+	}
+	printer.Println("Split " + plan.original.ID + " into " + strconv.Itoa(len(plan.parts)) + " entries")
+	printSplitSummary(printer, plan)
+	return nil
+}
+
+// printSplitSummary prints each new entry and the entries relinked to them.
+func printSplitSummary(printer *output.Printer, plan *splitPlan) {
+	for _, part := range plan.parts {
+		printer.Println()
+		printer.KeyValue("Entry ID", part.ID)
+		printer.KeyValue("What", part.Summary.What)
+		printer.KeyValue("Commits", strconv.Itoa(len(part.Workset.Commits)))
+	}
+	if len(plan.retargeted) > 0 {
+		printer.Println()
+	}
+	for _, entry := range plan.retargeted {
+		printer.KeyValue("Relinked", entry.ID)
+	}
+}
@@ -0,0 +1,124 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// splitEntryMarker starts each entry of a split template.
+const splitEntryMarker = "=== Entry"
+
+// formatSplitTemplate renders entry for splitting: a first block holding all
+// of its commits and its summary, and an empty block to move commits into.
+// subjects maps commit SHAs to their subjects, where known.
+func formatSplitTemplate(entry *ledger.Entry, subjects map[string]string) string {
+	var builder strings.Builder
+	builder.WriteString("# Splitting " + entry.ID + " into separate entries.\n")
+	builder.WriteString("# Each \"" + splitEntryMarker + "\" line starts an entry: its commits, one per line,\n")
+	builder.WriteString("# then its summary. Every commit goes under exactly one entry. Copy a\n")
+	builder.WriteString("# block for more entries; one left with no commits and no What is dropped.\n")
+	builder.WriteString("# Lines starting with '#' are ignored.\n")
+
+	builder.WriteString("\n" + splitEntryMarker + "\n")
+	for _, sha := range entry.Workset.Commits {
+		builder.WriteString(strings.TrimSpace(shortSHA(sha)+" "+subjects[sha]) + "\n")
+	}
+	writeSummarySections(&builder, entry.Summary, entry.Notes)
+
+	builder.WriteString("\n" + splitEntryMarker + "\n")
+	writeSummarySections(&builder, ledger.Summary{}, "")
+	return builder.String()
+}
+
+// splitBlock is one entry block of a split template, before its commit
+// prefixes are resolved.
+type splitBlock struct {
+	commits []string
+	summary ledger.Summary
+	notes   string
+}
+
+// parseSplitTemplate reads back a template written by formatSplitTemplate.
+// Blocks with neither commits nor a what are dropped; any other block needs
+// both.
+func parseSplitTemplate(text string) ([]splitBlock, error) {
+	var chunks [][]string
+	for line := range strings.SplitSeq(text, "\n") {
+		if strings.TrimSpace(line) == splitEntryMarker {
+			chunks = append(chunks, nil)
+			continue
+		}
+		if len(chunks) > 0 {
+			chunks[len(chunks)-1] = append(chunks[len(chunks)-1], line)
+		}
+	}
+
+	var blocks []splitBlock
+	for i, lines := range chunks {
+		block, err := parseSplitBlock(lines)
+		if err != nil {
+			return nil, err
+		}
+		number := strconv.Itoa(i + 1)
+		switch {
+		case len(block.commits) == 0 && block.summary.What == "":
+			continue
+		case len(block.commits) == 0:
+			return nil, output.NewUserError("entry " + number + " of the split has no commits")
+		case block.summary.What == "":
+			return nil, output.NewUserError("entry " + number + " of the split has no what")
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// parseSplitBlock reads one entry block: commit lines, whose first word is
+// a SHA or SHA prefix, up to the first section, then the summary sections.
+func parseSplitBlock(lines []string) (splitBlock, error) {
+	var block splitBlock
+	for _, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			break
+		}
+		if fields := strings.Fields(line); len(fields) > 0 && !strings.HasPrefix(fields[0], "#") {
+			block.commits = append(block.commits, fields[0])
+		}
+	}
+	summary, notes, err := parseSummaryTemplate(strings.Join(lines, "\n"))
+	if err != nil {
+		return splitBlock{}, err
+	}
+	block.summary, block.notes = summary, notes
+	return block, nil
+}
+
+// resolveSplitCommits expands the commit prefixes of blocks to the full
+// SHAs of commits, the workset being split.
+func resolveSplitCommits(blocks []splitBlock, commits []string) ([]ledger.SplitPart, error) {
+	parts := make([]ledger.SplitPart, len(blocks))
+	for i, block := range blocks {
+		parts[i] = ledger.SplitPart{Summary: block.summary, Notes: block.notes}
+		for _, prefix := range block.commits {
+			var matches []string
+			for _, sha := range commits {
+				if strings.HasPrefix(sha, prefix) {
+					matches = append(matches, sha)
+				}
+			}
+			switch len(matches) {
+			case 0:
+				return nil, output.NewUserError("commit " + prefix + " is not in the entry")
+			case 1:
+				parts[i].Commits = append(parts[i].Commits, matches[0])
+			default:
+				return nil, output.NewUserError("commit prefix " + prefix + " is ambiguous")
+			}
+		}
+	}
+	return parts, nil
+}
@@ -0,0 +1,168 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// runSplitTest runs the split command against storage and returns its output.
+func runSplitTest(t *testing.T, storage *ledger.Storage, jsonMode bool, args ...string) (string, error) {
+	t.Helper()
+	cmd := newSplitCmdInternal(storage)
+	if jsonMode {
+		cmd.PersistentFlags().Bool("json", false, "")
+		_ = cmd.PersistentFlags().Set("json", "true")
+	}
+	buf := &bytes.Buffer{}
+	cmd.SetIn(&bytes.Buffer{}) // not a terminal: never opens an editor
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// setupSplitTestStorage writes an entry covering three commits and another
+// that fixes it, and returns their IDs.
+func setupSplitTestStorage(t *testing.T) (*ledger.Storage, string, string, string) {
+	t.Helper()
+	storage, dir := setupAmendTestStorage(t, newMockGitOpsForAmend(), nil)
+	wide := createQueryTestEntryStruct("ccc333ddd444", "Auth and billing", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	wide.Workset.Commits = []string{"ccc333ddd444", "bbb222ccc333", "aaa111bbb222"}
+	wide.Tags = []string{"backend"}
+	fix := createQueryTestEntryStruct("eee555fff666", "Fix billing rounding", time.Date(2026, 1, 20, 10, 0, 0, 0, time.UTC))
+	fix.Relations = []ledger.Relation{{Type: ledger.RelationFixes, ID: wide.ID}}
+	for _, entry := range []*ledger.Entry{wide, fix} {
+		if err := storage.WriteEntry(entry, false); err != nil {
+			t.Fatalf("WriteEntry: %v", err)
+		}
+	}
+	return storage, dir, wide.ID, fix.ID
+}
+
+// writeSplitPlan writes a split plan file and returns its path.
+func writeSplitPlan(t *testing.T, plan string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "split.md")
+	if err := os.WriteFile(path, []byte(plan), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const testSplitPlan = `# comment
+=== Entry
+ccc333d Billing
+bbb222c Billing tests
+
+## What
+Add billing
+## Why
+Invoices
+## How
+Stripe client
+
+=== Entry
+aaa111b Auth
+
+## What
+Add auth
+## Why
+Login
+## How
+OAuth
+`
+
+func TestSplitCreatesEntriesAndRetargets(t *testing.T) {
+	storage, dir, wide, fix := setupSplitTestStorage(t)
+
+	out, err := runSplitTest(t, storage, true, wide, "--file", writeSplitPlan(t, testSplitPlan))
+	if err != nil {
+		t.Fatalf("split: %v\n%s", err, out)
+	}
+	var result struct {
+		Status    string   `json:"status"`
+		SplitInto []string `json:"split_into"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parsing JSON: %v\n%s", err, out)
+	}
+	if result.Status != "split" || len(result.SplitInto) != 2 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	billing := readEntryFromDir(t, dir, result.SplitInto[0])
+	if billing.Summary.What != "Add billing" || billing.Workset.AnchorCommit != "ccc333ddd444" ||
+		!slices.Equal(billing.Workset.Commits, []string{"ccc333ddd444", "bbb222ccc333"}) {
+		t.Errorf("billing entry = %+v", billing)
+	}
+	auth := readEntryFromDir(t, dir, result.SplitInto[1])
+	if auth.Summary.How != "OAuth" || auth.Workset.AnchorCommit != "aaa111bbb222" || !slices.Equal(auth.Tags, []string{"backend"}) {
+		t.Errorf("auth entry = %+v", auth)
+	}
+
+	ids := queryIDs(t, storage)
+	if slices.Contains(ids, wide) || len(ids) != 3 {
+		t.Errorf("query = %v, want the two split entries and the fix", ids)
+	}
+	rels := readEntryFromDir(t, dir, fix).Relations
+	if len(rels) != 2 || rels[0].ID != result.SplitInto[0] || rels[1].ID != result.SplitInto[1] {
+		t.Errorf("relations = %+v, want one to each split entry", rels)
+	}
+}
+
+func TestSplitRejectsBadPlans(t *testing.T) {
+	tests := []struct {
+		name string
+		plan string
+		want string
+	}{
+		{"commit left out", "=== Entry\nccc333d\n## What\nA\n=== Entry\nbbb222c\n## What\nB\n", "in no part"},
+		{"commit twice", strings.Replace(testSplitPlan, "aaa111b Auth", "aaa111b Auth\nccc333d", 1), "more than one part"},
+		{"unknown commit", strings.Replace(testSplitPlan, "aaa111b", "fff999", 1), "not in the entry"},
+		{"missing what", strings.Replace(testSplitPlan, "Add auth", "", 1), "has no what"},
+		{"single part", "=== Entry\nccc333d\nbbb222c\naaa111b\n## What\nAll\n", "at least two parts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, _, wide, _ := setupSplitTestStorage(t)
+			_, err := runSplitTest(t, storage, false, wide, "--file", writeSplitPlan(t, tt.plan), "--dry-run")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("split = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitWithoutTerminalNeedsFile(t *testing.T) {
+	storage, _, wide, _ := setupSplitTestStorage(t)
+
+	if _, err := runSplitTest(t, storage, false, wide); err == nil || !strings.Contains(err.Error(), "--file") {
+		t.Errorf("split without a terminal = %v, want a pointer to --file", err)
+	}
+}
+
+func TestSplitTemplateRoundTrip(t *testing.T) {
+	entry := createQueryTestEntryStruct("ccc333ddd444", "Auth and billing", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	entry.Workset.Commits = []string{"ccc333ddd444", "aaa111bbb222"}
+	text := formatSplitTemplate(entry, map[string]string{"ccc333ddd444": "Billing"})
+
+	blocks, err := parseSplitTemplate(text)
+	if err != nil {
+		t.Fatalf("parseSplitTemplate: %v", err)
+	}
+	// The empty second block is dropped.
+	if len(blocks) != 1 || !slices.Equal(blocks[0].commits, []string{"ccc333d", "aaa111b"}) ||
+		blocks[0].summary != entry.Summary {
+		t.Errorf("blocks = %+v", blocks)
+	}
+}
@@ -54,11 +54,11 @@ Examples:
 
 // runStatus executes the status command.
 func runStatus(cmd *cobra.Command, _ []string, verbose bool) error {
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 
 	// Check if we're in a git repo
 	if !git.IsRepo() {
-		err := output.NewSystemError("not in a git repository")
+		err := output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 		printer.Error(err)
 		return err
 	}
@@ -101,7 +101,7 @@ func runStatus(cmd *cobra.Command, _ []string, verbose bool) error {
 // gatherStatus collects all status information.
 func gatherStatus(verbose bool) (*statusResult, error) {
 	// Get repo root and extract name
-	root, err := git.RepoRoot()
+	root, err := statusRoot()
 	if err != nil {
 		return nil, err
 	}
@@ -119,11 +119,12 @@ func gatherStatus(verbose bool) (*statusResult, error) {
 		return nil, err
 	}
 
-	// Check .timbers/ directory
-	timbersDir := filepath.Join(root, ".timbers")
-	dirInfo, statErr := os.Stat(timbersDir)
-	dirExists := statErr == nil && dirInfo.IsDir()
+	store, storeErr := ledger.NewDefaultStorage()
+	if storeErr != nil {
+		return nil, storeErr
+	}
 
+	timbersDir, dirExists := statusLedgerDir(store, root)
 	result := &statusResult{
 		Repo:       repoName,
 		Branch:     branch,
@@ -132,12 +133,6 @@ func gatherStatus(verbose bool) (*statusResult, error) {
 		DirExists:  dirExists,
 	}
 
-	// Get entry count
-	store, storeErr := ledger.NewDefaultStorage()
-	if storeErr != nil {
-		return nil, storeErr
-	}
-
 	if verbose {
 		entries, stats, statsErr := store.ListEntriesWithStats()
 		if statsErr != nil {
@@ -166,30 +161,59 @@ func gatherStatus(verbose bool) (*statusResult, error) {
 	return result, nil
 }
 
+// statusRoot returns the repository root, or the git directory of a bare
+// repository, which has no root.
+func statusRoot() (string, error) {
+	if git.IsBare() {
+		return git.Dir()
+	}
+	return git.RepoRoot()
+}
+
+// statusLedgerDir returns where the ledger lives and whether it exists: the
+// working directory's .timbers/, or "<tree-ish>:.timbers" when the storage
+// reads a tree, as in a bare repository.
+func statusLedgerDir(store *ledger.Storage, root string) (string, bool) {
+	if treeish := store.Treeish(); treeish != "" {
+		return treeish + ":.timbers", store.DirExists()
+	}
+	timbersDir := filepath.Join(root, ".timbers")
+	dirInfo, statErr := os.Stat(timbersDir)
+	return timbersDir, statErr == nil && dirInfo.IsDir()
+}
+
 // printHumanStatus outputs status in human-readable format.
 func printHumanStatus(printer *output.Printer, status *statusResult, verbose bool) {
 	printer.Section("Repository")
-	printer.KeyValue("Repo", status.Repo)
-	printer.KeyValue("Branch", status.Branch)
-	printer.KeyValue("HEAD", status.Head[:min(12, len(status.Head))])
+	printer.KV([]output.Field{
+		{Key: "Repo", Value: status.Repo},
+		{Key: "Branch", Value: status.Branch},
+		{Key: "HEAD", Value: status.Head[:min(12, len(status.Head))]},
+	})
 
 	printer.Section("Timbers Storage")
-	printer.KeyValue("Directory", status.TimbersDir)
-	printer.KeyValue("Initialized", formatBool(status.DirExists))
+	storage := []output.Field{
+		{Key: "Directory", Value: status.TimbersDir},
+		{Key: "Initialized", Value: formatBool(status.DirExists)},
+	}
+	if !verbose {
+		printer.KV(append(storage, output.Field{Key: "Entries", Value: strconv.Itoa(status.EntryCount)}))
+		return
+	}
 
-	if verbose {
-		printer.KeyValue("Files Total", strconv.Itoa(status.FilesTotal))
-		printer.KeyValue("Entries", strconv.Itoa(status.EntryCount))
-		if status.FilesSkipped > 0 {
-			skippedStr := fmt.Sprintf("%d (%d not timbers, %d parse error)",
-				status.FilesSkipped, status.NotTimbers, status.ParseErrors)
-			printer.KeyValue("Skipped", skippedStr)
-		}
-		printer.KeyValue("Infra-skipped since last entry",
-			strconv.Itoa(status.InfraSkippedSinceEntry)+" commits")
-	} else {
-		printer.KeyValue("Entries", strconv.Itoa(status.EntryCount))
+	storage = append(storage,
+		output.Field{Key: "Files Total", Value: strconv.Itoa(status.FilesTotal)},
+		output.Field{Key: "Entries", Value: strconv.Itoa(status.EntryCount)},
+	)
+	if status.FilesSkipped > 0 {
+		storage = append(storage, output.Field{Key: "Skipped", Value: fmt.Sprintf("%d (%d not timbers, %d parse error)",
+			status.FilesSkipped, status.NotTimbers, status.ParseErrors)})
 	}
+	storage = append(storage, output.Field{
+		Key:   "Infra-skipped since last entry",
+		Value: strconv.Itoa(status.InfraSkippedSinceEntry) + " commits",
+	})
+	printer.KV(storage)
 }
 
 // formatBool returns a human-readable boolean string.
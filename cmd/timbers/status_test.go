@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gorewood/timbers/internal/git"
 	"github.com/gorewood/timbers/internal/ledger"
 )
 
@@ -302,12 +303,8 @@ func runInDir(t *testing.T, dir string, testFunc func()) {
 // runGit runs a git command in the given directory.
 func runGit(t *testing.T, dir string, args ...string) {
 	t.Helper()
-	ctx := context.Background()
-	cmd := exec.CommandContext(ctx, "git", args...)
-	cmd.Dir = dir
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		t.Fatalf("git %v failed: %v\nOutput: %s", args, err, out)
+	if _, err := git.RunInDir(dir, nil, args...); err != nil {
+		t.Fatalf("git %v failed: %v", args, err)
 	}
 }
 
@@ -0,0 +1,140 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/draft"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// summaryAudiences maps --audience values to the draft template used for
+// each. Projects can override either template in .timbers/templates/.
+var summaryAudiences = map[string]string{
+	"eng":  "narrative-eng",
+	"exec": "narrative-exec",
+}
+
+// summarizeFlags holds flag values for the summarize command.
+type summarizeFlags struct {
+	since           string
+	until           string
+	rng             string // "range" is a keyword
+	audience        string
+	appendText      string
+	model           string
+	provider        string
+	promptOnly      bool
+	withFrontmatter bool
+}
+
+// newSummarizeCmd creates the summarize command.
+func newSummarizeCmd() *cobra.Command {
+	var flags summarizeFlags
+
+	cmd := &cobra.Command{
+		Use:   "summarize",
+		Short: "Write a narrative of a period's work with the configured LLM",
+		Long: `Write a cohesive narrative of the entries in a period.
+
+The period's entries are rendered through the narrative-eng or narrative-exec
+draft template (chosen by --audience) and sent to the configured LLM ([llm]
+model in .timbers/config.toml, or --model). Use --prompt-only to pipe the
+prompt to another tool instead.
+
+Examples:
+  timbers summarize --since 14d                          # Engineering narrative
+  timbers summarize --since 2026-01-01 --until 2026-01-31 --audience exec
+  timbers summarize --range v1.2.0..v1.3.0 --model sonnet --with-frontmatter
+  timbers summarize --since 7d --prompt-only | claude -p`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSummarize(cmd, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.since, "since", "", "Summarize entries since duration (24h, 7d) or date")
+	cmd.Flags().StringVar(&flags.until, "until", "", "Summarize entries until duration (24h, 7d) or date")
+	cmd.Flags().StringVar(&flags.rng, "range", "", "Summarize entries in commit range (A..B)")
+	cmd.Flags().StringVar(&flags.audience, "audience", "eng", "Audience: eng or exec")
+	cmd.Flags().StringVar(&flags.appendText, "append", "", "Append extra instructions to the prompt")
+	cmd.Flags().StringVarP(&flags.model, "model", "m", "", "Model name (default: [llm] model from config)")
+	cmd.Flags().StringVarP(&flags.provider, "provider", "p", "", "Provider (anthropic, openai, google, local) - inferred if omitted")
+	cmd.Flags().BoolVar(&flags.promptOnly, "prompt-only", false, "Print the rendered prompt instead of calling the LLM")
+	cmd.Flags().BoolVar(&flags.withFrontmatter, "with-frontmatter", false, "Include generation metadata as TOML frontmatter")
+
+	return cmd
+}
+
+// runSummarize executes the summarize command.
+func runSummarize(cmd *cobra.Command, flags summarizeFlags) error {
+	printer := newPrinter(cmd)
+
+	templateName, err := validateSummarizeInput(flags)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	tmpl, err := draft.LoadTemplate(templateName)
+	if err != nil {
+		userErr := output.NewUserError(err.Error())
+		printer.Error(userErr)
+		return userErr
+	}
+
+	entries, err := getDraftEntries(printer, "", flags.since, flags.until, flags.rng, "")
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		if printer.IsJSON() {
+			return printer.Success(map[string]any{"template": templateName, "entry_count": 0, "response": ""})
+		}
+		printer.Println("No entries in this period; nothing to summarize.")
+		return nil
+	}
+
+	rendered, err := draft.Render(tmpl, buildRenderContext(entries, flags.appendText, nil))
+	if err != nil {
+		sysErr := output.NewSystemErrorWithCause("failed to render", err)
+		printer.Error(sysErr)
+		return sysErr
+	}
+	if flags.promptOnly {
+		if printer.IsJSON() {
+			return printer.Success(map[string]any{"template": templateName, "prompt": rendered, "entry_count": len(entries)})
+		}
+		printer.Print("%s\n", rendered)
+		return nil
+	}
+
+	selFlags := draftSelectionFlags{since: flags.since, until: flags.until, rng: flags.rng}
+	return runDraftWithLLM(
+		printer, rendered, templateName, tmpl, entries,
+		configuredModel(flags.model), flags.provider, flags.withFrontmatter, selFlags,
+	)
+}
+
+// validateSummarizeInput checks the period and audience flags and returns the
+// template for the audience.
+func validateSummarizeInput(flags summarizeFlags) (string, error) {
+	if flags.since == "" && flags.rng == "" {
+		return "", output.NewUserError("specify the period with --since (and optionally --until) or --range")
+	}
+	templateName, ok := summaryAudiences[flags.audience]
+	if !ok {
+		audiences := make([]string, 0, len(summaryAudiences))
+		for name := range summaryAudiences {
+			audiences = append(audiences, name)
+		}
+		slices.Sort(audiences)
+		return "", output.NewUserError("--audience must be one of: " + strings.Join(audiences, ", "))
+	}
+	if flags.withFrontmatter && flags.promptOnly {
+		return "", output.NewUserError("--with-frontmatter cannot be combined with --prompt-only")
+	}
+	return templateName, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func runSummarizeCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := newRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(append([]string{"summarize"}, args...))
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestSummarizePromptOnlyByAudience(t *testing.T) {
+	dir := newReportRepo(t)
+	t.Chdir(dir)
+	sha := strings.TrimSpace(runReportGit(t, dir, "rev-parse", "HEAD"))
+	writeReportEntry(t, filepath.Join(dir, ".timbers"),
+		reportEntry("tb_2026-07-14T12:00:00Z_"+sha[:6], sha, "Ship the period narrative", time.Now().Add(-time.Hour)))
+
+	eng, err := runSummarizeCmd(t, "--since", "1d", "--prompt-only")
+	if err != nil {
+		t.Fatalf("summarize error = %v\n%s", err, eng)
+	}
+	if !strings.Contains(eng, "Engineers on or joining the team") || !strings.Contains(eng, "Ship the period narrative") {
+		t.Errorf("eng prompt missing audience or entry:\n%s", eng)
+	}
+
+	execOut, err := runSummarizeCmd(t, "--since", "1d", "--audience", "exec", "--prompt-only")
+	if err != nil {
+		t.Fatalf("summarize --audience exec error = %v\n%s", err, execOut)
+	}
+	if !strings.Contains(execOut, "Executives and stakeholders") {
+		t.Errorf("exec prompt missing audience:\n%s", execOut)
+	}
+}
+
+func TestSummarizeEmptyPeriodSkipsLLM(t *testing.T) {
+	dir := newReportRepo(t)
+	t.Chdir(dir)
+	sha := strings.TrimSpace(runReportGit(t, dir, "rev-parse", "HEAD"))
+	writeReportEntry(t, filepath.Join(dir, ".timbers"),
+		reportEntry("tb_2020-01-01T12:00:00Z_"+sha[:6], sha, "Old work", time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)))
+
+	out, err := runSummarizeCmd(t, "--since", "1d", "--model", "no-such-provider-model")
+	if err != nil {
+		t.Fatalf("summarize error = %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "nothing to summarize") {
+		t.Errorf("output = %q, want empty-period message", out)
+	}
+}
+
+func TestSummarizeValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no period", nil, "--since"},
+		{"unknown audience", []string{"--since", "7d", "--audience", "board"}, "eng, exec"},
+		{"frontmatter without LLM", []string{"--since", "7d", "--prompt-only", "--with-frontmatter"}, "--prompt-only"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runSummarizeCmd(t, tt.args...)
+			if err == nil || !strings.Contains(out, tt.want) {
+				t.Errorf("want error mentioning %q, got err=%v output: %s", tt.want, err, out)
+			}
+		})
+	}
+}
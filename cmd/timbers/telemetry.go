@@ -0,0 +1,164 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/output"
+	"github.com/gorewood/timbers/internal/telemetry"
+)
+
+// telemetryEndpoint receives flushed telemetry batches. Release builds do
+// not set one yet, so unless TIMBERS_TELEMETRY_ENDPOINT names an endpoint,
+// events stay in the local spool and flushing is a no-op.
+var telemetryEndpoint = ""
+
+// telemetryFlushTimeout bounds a background flush.
+const telemetryFlushTimeout = 10 * time.Second
+
+// newTelemetryCmd creates the telemetry parent command with subcommands.
+func newTelemetryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Manage opt-in anonymous usage telemetry",
+		Long: `Manage anonymous usage telemetry. It is off until you turn it on.
+
+When on, each command records its name (e.g. "timbers log"), duration,
+exit code, the timbers version, and OS/architecture, plus a random install
+ID. Arguments, flag values, paths, repository data, and ledger content are
+never collected. Events are spooled locally. They are sent in small batches
+in the background only once TIMBERS_TELEMETRY_ENDPOINT names a collection
+endpoint; until then nothing leaves the machine.
+
+DO_NOT_TRACK=1 or TIMBERS_TELEMETRY=off disables telemetry regardless of
+this setting.
+
+Subcommands:
+  on      Opt in
+  off     Opt out and delete any unsent events
+  status  Show the setting and what is spooled
+
+Examples:
+  timbers telemetry status
+  timbers telemetry on
+  timbers telemetry off`,
+	}
+
+	cmd.AddCommand(newTelemetrySetCmd("on", "Opt in to anonymous usage telemetry", true))
+	cmd.AddCommand(newTelemetrySetCmd("off", "Opt out and delete any unsent events", false))
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Show the telemetry setting and spooled events",
+		Args:  cobra.NoArgs,
+		RunE:  runTelemetryStatus,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:    "flush",
+		Short:  "Send spooled telemetry events",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE:   runTelemetryFlush,
+	})
+	return cmd
+}
+
+// newTelemetrySetCmd creates the on and off subcommands.
+func newTelemetrySetCmd(use, short string, enabled bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			printer := newPrinter(cmd)
+			dir := config.Dir()
+			if dir == "" {
+				err := output.NewSystemError("could not determine config directory")
+				printer.Error(err)
+				return err
+			}
+			if _, err := telemetry.SetEnabled(dir, enabled, time.Now()); err != nil {
+				sysErr := output.NewSystemErrorWithCause("saving telemetry setting", err)
+				printer.Error(sysErr)
+				return sysErr
+			}
+			if enabled && telemetry.EnvDisabled() {
+				printer.Warning("DO_NOT_TRACK or TIMBERS_TELEMETRY=off is set; nothing is recorded while it is")
+			}
+			return printTelemetryStatus(printer, dir)
+		},
+	}
+}
+
+// runTelemetryStatus executes the telemetry status subcommand.
+func runTelemetryStatus(cmd *cobra.Command, _ []string) error {
+	return printTelemetryStatus(newPrinter(cmd), config.Dir())
+}
+
+// printTelemetryStatus reports the opt-in state and spool.
+func printTelemetryStatus(printer *output.Printer, dir string) error {
+	settings, err := telemetry.LoadSettings(dir)
+	if err != nil {
+		sysErr := output.NewSystemErrorWithCause("reading telemetry settings", err)
+		printer.Error(sysErr)
+		return sysErr
+	}
+	events, _ := telemetry.Pending(dir)
+	_, active := telemetry.Active(dir)
+	endpoint := resolveTelemetryEndpoint()
+
+	if printer.IsJSON() {
+		return printer.WriteJSON(map[string]any{
+			"enabled":      settings.Enabled,
+			"active":       active,
+			"env_disabled": telemetry.EnvDisabled(),
+			"install_id":   settings.InstallID,
+			"spooled":      len(events),
+			"endpoint":     endpoint,
+		})
+	}
+
+	state := "off"
+	switch {
+	case active:
+		state = "on"
+	case settings.Enabled:
+		state = "on, but disabled by DO_NOT_TRACK or TIMBERS_TELEMETRY"
+	}
+	if endpoint == "" {
+		endpoint = "none (events stay local)"
+	}
+	printer.KV([]output.Field{
+		{Key: "Telemetry", Value: state},
+		{Key: "Spooled", Value: strconv.Itoa(len(events)) + " event(s)"},
+		{Key: "Endpoint", Value: endpoint},
+	})
+	return nil
+}
+
+// runTelemetryFlush sends the spool. It runs detached after a command when
+// a batch is due, so it reports nothing and never fails loudly.
+func runTelemetryFlush(_ *cobra.Command, _ []string) error {
+	dir := config.Dir()
+	settings, active := telemetry.Active(dir)
+	if !active {
+		return nil
+	}
+	ctx, cancel := requestContext(telemetryFlushTimeout)
+	defer cancel()
+	_, _ = telemetry.Flush(ctx, dir, resolveTelemetryEndpoint(), settings.InstallID, http.DefaultClient)
+	return nil
+}
+
+// resolveTelemetryEndpoint returns TIMBERS_TELEMETRY_ENDPOINT if set, else
+// the build's endpoint.
+func resolveTelemetryEndpoint() string {
+	if endpoint := os.Getenv("TIMBERS_TELEMETRY_ENDPOINT"); endpoint != "" {
+		return endpoint
+	}
+	return telemetryEndpoint
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/telemetry"
+)
+
+// recordTelemetry spools one event for the command args resolved to, if the
+// user opted in, and starts a background flush when a batch is due. Only the
+// command path is recorded, never args or flag values. Failures are
+// ignored: telemetry must never affect a command's outcome.
+func recordTelemetry(root *cobra.Command, args []string, elapsed time.Duration, code int) {
+	dir := config.Dir()
+	if _, active := telemetry.Active(dir); !active {
+		return
+	}
+	executed, _, err := root.Find(args)
+	if err != nil || isTelemetryCommand(executed) {
+		return
+	}
+
+	event := telemetry.Event{
+		Command:    executed.CommandPath(),
+		DurationMS: elapsed.Milliseconds(),
+		ExitCode:   code,
+		Version:    version,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Time:       time.Now().UTC().Truncate(time.Hour),
+	}
+	if telemetry.Record(dir, event) != nil {
+		return
+	}
+	if resolveTelemetryEndpoint() != "" && telemetry.Due(dir) {
+		startTelemetryFlush()
+	}
+}
+
+// isTelemetryCommand reports whether cmd is the telemetry command or one of
+// its subcommands, which are not themselves recorded.
+func isTelemetryCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Name() == "telemetry" && c.Parent() != nil && !c.Parent().HasParent() {
+			return true
+		}
+	}
+	return false
+}
+
+// startTelemetryFlush runs 'timbers telemetry flush' detached, so sending
+// never delays the command the user ran.
+func startTelemetryFlush() {
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+	flush := exec.Command(exe, "telemetry", "flush") //nolint:gosec,noctx // re-executes this binary, detached on purpose
+	if flush.Start() == nil {
+		_ = flush.Process.Release()
+	}
+}
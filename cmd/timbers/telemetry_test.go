@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/telemetry"
+)
+
+func TestTelemetryCommand(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TIMBERS_CONFIG_HOME", dir)
+	t.Setenv("DO_NOT_TRACK", "")
+	t.Setenv("TIMBERS_TELEMETRY", "")
+	t.Setenv("TIMBERS_TELEMETRY_ENDPOINT", "")
+
+	status := func(t *testing.T, args ...string) map[string]any {
+		t.Helper()
+		var out bytes.Buffer
+		cmd := newRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append([]string{"telemetry", "--json"}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("telemetry %v failed: %v", args, err)
+		}
+		var result map[string]any
+		if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+			t.Fatalf("invalid JSON %q: %v", out.String(), err)
+		}
+		return result
+	}
+
+	if got := status(t, "status"); got["enabled"] != false {
+		t.Errorf("telemetry should default to off, got %v", got)
+	}
+
+	root := newRootCmd()
+	recordTelemetry(root, []string{"status", "--json"}, time.Second, 0)
+	if events, _ := telemetry.Pending(dir); len(events) != 0 {
+		t.Fatalf("recorded %d events while opted out", len(events))
+	}
+
+	if got := status(t, "on"); got["active"] != true {
+		t.Errorf("expected active after 'telemetry on', got %v", got)
+	}
+	recordTelemetry(root, []string{"log", "secret summary", "--why", "private"}, time.Second, 2)
+	recordTelemetry(root, []string{"telemetry", "status"}, time.Second, 0)
+	events, _ := telemetry.Pending(dir)
+	if len(events) != 1 {
+		t.Fatalf("spooled %d events, want 1 (telemetry commands are not recorded)", len(events))
+	}
+	if events[0].Command != "timbers log" || events[0].ExitCode != 2 || events[0].DurationMS != 1000 {
+		t.Errorf("event = %+v, want command path, exit code, and duration only", events[0])
+	}
+
+	if got := status(t, "off"); got["enabled"] != false || got["spooled"] != float64(0) {
+		t.Errorf("expected off with an empty spool, got %v", got)
+	}
+}
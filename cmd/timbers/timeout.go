@@ -0,0 +1,126 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/llm"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// defaultLLMTimeout caps a single LLM request when --timeout is not set.
+const defaultLLMTimeout = 2 * time.Minute
+
+// timeoutValue is the --timeout flag: a Go duration ("30s", "2m") or a bare
+// number of seconds, matching the old generate --timeout. Zero means no
+// limit.
+type timeoutValue time.Duration
+
+// String implements pflag.Value.
+func (v *timeoutValue) String() string {
+	return time.Duration(*v).String()
+}
+
+// Set implements pflag.Value.
+func (v *timeoutValue) Set(value string) error {
+	timeout, err := time.ParseDuration(value)
+	if secs, atoiErr := strconv.Atoi(value); atoiErr == nil {
+		timeout, err = time.Duration(secs)*time.Second, nil
+	}
+	if err != nil {
+		return fmt.Errorf("invalid timeout %q: use a duration like 30s or 2m", value)
+	}
+	if timeout < 0 {
+		return fmt.Errorf("invalid timeout %q: must not be negative", value)
+	}
+	*v = timeoutValue(timeout)
+	return nil
+}
+
+// Type implements pflag.Value.
+func (v *timeoutValue) Type() string {
+	return "duration"
+}
+
+// getTimeout reads the --timeout persistent flag from the command hierarchy.
+// Returns 0 (no limit) if the flag is unset or not found.
+func getTimeout(cmd *cobra.Command) time.Duration {
+	flag := cmd.Flags().Lookup("timeout")
+	if flag == nil {
+		flag = cmd.Root().PersistentFlags().Lookup("timeout")
+	}
+	if flag == nil {
+		return 0
+	}
+	timeout, err := time.ParseDuration(flag.Value.String())
+	if err != nil {
+		return 0
+	}
+	return timeout
+}
+
+// applyTimeout derives the command's context from --timeout and installs it
+// for the git package, so every git subprocess the command spawns is killed
+// once the deadline passes. Always resets the git context, even without a
+// timeout, so state never leaks between commands run in one process. The
+// installed context also carries a fresh git query cache, so repeated HEAD
+// and RepoRoot lookups cost one subprocess per invocation — except for
+// long-running commands, whose answers would go stale.
+// Returns the cancel func for the deadline, or nil when there is none.
+func applyTimeout(cmd *cobra.Command) context.CancelFunc {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var cancel context.CancelFunc
+	if timeout := getTimeout(cmd); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		cmd.SetContext(ctx)
+	}
+	if !longRunningCommands[cmd.CommandPath()] {
+		ctx = git.WithQueryCache(ctx)
+	}
+	git.SetContext(ctx)
+	return cancel
+}
+
+// longRunningCommands serve many requests from one invocation, so the git
+// query cache is never attached to them.
+var longRunningCommands = map[string]bool{
+	"timbers serve": true,
+}
+
+// requestContext bounds a network request (an LLM call, say) by timeout
+// within the running command's context, which applyTimeout installed for
+// the git package. Ctrl-C and --timeout therefore end the request too.
+func requestContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(git.Context(), timeout)
+}
+
+// llmContext bounds an LLM request within the running command's context.
+// Under --timeout the command's deadline is the only bound, so a long
+// --timeout is never undercut; otherwise the request is capped at
+// defaultLLMTimeout.
+func llmContext() (context.Context, context.CancelFunc) {
+	ctx := git.Context()
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, defaultLLMTimeout)
+}
+
+// completeWithProgress sends req to client, showing progress (a spinner, or
+// JSON progress events) while the model works.
+func completeWithProgress(
+	ctx context.Context, printer *output.Printer, client *llm.Client, req llm.Request,
+) (*llm.Response, error) {
+	progress := printer.Progress("Waiting for "+client.Model(), 0)
+	defer progress.Done()
+	return client.Complete(ctx, req)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+func TestTimeoutValue_Set(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "30s", want: 30 * time.Second},
+		{input: "2m", want: 2 * time.Minute},
+		{input: "120", want: 120 * time.Second},
+		{input: "0", want: 0},
+		{input: "-5s", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var value timeoutValue
+			err := value.Set(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && time.Duration(value) != tt.want {
+				t.Errorf("Set(%q) = %v, want %v", tt.input, time.Duration(value), tt.want)
+			}
+		})
+	}
+}
+
+func TestRootCommand_TimeoutAcceptsBareSeconds(t *testing.T) {
+	cmd := newRootCmd()
+	if err := cmd.PersistentFlags().Set("timeout", "90"); err != nil {
+		t.Fatal(err)
+	}
+	if got := getTimeout(cmd); got != 90*time.Second {
+		t.Errorf("getTimeout() = %v, want 1m30s", got)
+	}
+}
+
+func TestLLMContext(t *testing.T) {
+	t.Cleanup(func() { git.SetContext(nil) })
+
+	git.SetContext(context.Background())
+	ctx, cancel := llmContext()
+	deadline, ok := ctx.Deadline()
+	cancel()
+	if !ok || time.Until(deadline) > defaultLLMTimeout {
+		t.Errorf("without --timeout, deadline should be the default cap; got %v (set %v)", deadline, ok)
+	}
+
+	// A command deadline longer than the default cap must not be undercut.
+	cmdCtx, cmdCancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cmdCancel()
+	git.SetContext(cmdCtx)
+	ctx, cancel = llmContext()
+	defer cancel()
+	want, _ := cmdCtx.Deadline()
+	if got, _ := ctx.Deadline(); !got.Equal(want) {
+		t.Errorf("deadline = %v, want the command's %v", got, want)
+	}
+}
@@ -17,8 +17,13 @@ func newUninstallCmd() *cobra.Command {
 	var dryRun, force, removeBinary, keepData bool
 	cmd := &cobra.Command{
 		Use: "uninstall", Short: "Remove timbers from the current repository",
-		Long: `Remove timbers components: .timbers/ directory, hooks, agent integrations.
-Use --keep-data to preserve ledger data. Use --binary to remove the binary.`,
+		Long: `Remove timbers components: .timbers/ directory and config, git hooks
+(pre-commit, post-commit, post-rewrite), agent integrations at every scope,
+and the .gitattributes entry.
+Use --keep-data to preserve ledger data. Use --binary to remove the binary.
+
+The --json report lists what was removed and what was left behind (kept
+data, CI workflows scaffolded by init --ci, failed removals).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runUninstall(cmd, dryRun, force, removeBinary, keepData)
 		},
@@ -37,7 +42,7 @@ func runUninstall(cmd *cobra.Command, dryRun, force, removeBinary, keepData bool
 	if kn, _ := cmd.Flags().GetBool("keep-notes"); kn {
 		keepData = true
 	}
-	printer := output.NewPrinter(cmd.OutOrStdout(), isJSONMode(cmd), useColor(cmd))
+	printer := newPrinter(cmd)
 	info, err := gatherUninstallInfo(removeBinary)
 	if err != nil {
 		printer.Error(err)
@@ -81,11 +86,17 @@ func outputDryRunUninstall(printer *output.Printer, info *setup.UninstallInfo, b
 
 func outputDryRunJSON(printer *output.Printer, info *setup.UninstallInfo, binary, keep bool) error {
 	data := map[string]any{
-		"status": "dry_run", "in_repo": info.InRepo, "keep_data": keep,
+		"in_repo": info.InRepo, "keep_data": keep,
 		"timbers_dir_exists": info.TimbersDirExists, "entry_count": info.EntryCount,
 		"hooks_installed":    info.HooksInstalled,
 		"agent_integrations": len(info.AgentEnvs),
 	}
+	remove, left := uninstallReport(info, binary, keep, false)
+	data["would_remove"], data["left_behind"] = remove, left
+	plan := make([]plannedAction, 0, len(remove))
+	for _, item := range remove {
+		plan = append(plan, plannedAction{Action: planRemove, Target: item.Path, Detail: item.Component})
+	}
 	// Backward compatibility: keep claude_installed for JSON consumers.
 	claudeInstalled := false
 	for _, ae := range info.AgentEnvs {
@@ -105,11 +116,11 @@ func outputDryRunJSON(printer *output.Printer, info *setup.UninstallInfo, binary
 	if info.RepoName != "" {
 		data["repo_name"] = info.RepoName
 	}
-	return printer.Success(data)
+	return printer.Success(withPlan(data, plan))
 }
 
 func outputDryRunHuman(printer *output.Printer, info *setup.UninstallInfo, binary, keep bool) error {
-	styles := uninstallStyles(printer.IsTTY())
+	styles := uninstallStyles(printer.Styles())
 	msg := "Dry run: Would perform the following actions:"
 	if info.RepoName != "" {
 		msg = "Dry run: Would remove timbers from " + info.RepoName
@@ -128,7 +139,8 @@ func outputDryRunHuman(printer *output.Printer, info *setup.UninstallInfo, binar
 }
 
 func hasAnyComponents(info *setup.UninstallInfo, binary bool) bool {
-	return info.TimbersDirExists || info.HooksInstalled || info.HasAgentEnvs() || binary
+	return info.TimbersDirExists || info.HooksInstalled || len(info.ExtraHooks) > 0 ||
+		info.GitattributesHasEntry || info.HasAgentEnvs() || binary
 }
 
 func formatEntryCount(count int) string {
@@ -147,8 +159,11 @@ func printComponents(printer *output.Printer, styles uninstallStyleSet, info *se
 			printer.Println(styles.bullet.Render(indent+"• ") + ".timbers/ directory: " + entry)
 		}
 	}
-	if info.HooksInstalled {
-		printer.Println(styles.bullet.Render(indent+"• ") + "Git hooks: pre-commit")
+	if hooks := installedHookNames(info); len(hooks) > 0 {
+		printer.Println(styles.bullet.Render(indent+"• ") + "Git hooks: " + strings.Join(hooks, ", "))
+	}
+	if info.GitattributesHasEntry {
+		printer.Println(styles.bullet.Render(indent+"• ") + ".gitattributes: linguist-generated entry")
 	}
 	for _, ae := range info.AgentEnvs {
 		printer.Println(styles.bullet.Render(indent+"• ") + ae.Display + " integration: " + ae.Scope)
@@ -156,11 +171,26 @@ func printComponents(printer *output.Printer, styles uninstallStyleSet, info *se
 	if binary {
 		printer.Println(styles.bullet.Render(indent+"• ") + "Binary: " + info.BinaryPath)
 	}
+	for _, wf := range info.CIWorkflows {
+		printer.Println(styles.dim.Render(indent + "• CI workflow: " + wf + " (keeping, tracked file)"))
+	}
+}
+
+// installedHookNames lists the git hooks that carry timbers content.
+func installedHookNames(info *setup.UninstallInfo) []string {
+	var names []string
+	if info.HooksInstalled {
+		names = append(names, "pre-commit")
+	}
+	for _, hook := range info.ExtraHooks {
+		names = append(names, hook.Name)
+	}
+	return names
 }
 
 func confirmUninstall(cmd *cobra.Command, info *setup.UninstallInfo, binary, keep bool) bool {
 	printer := output.NewPrinter(cmd.OutOrStdout(), false, useColor(cmd))
-	styles := uninstallStyles(printer.IsTTY())
+	styles := uninstallStyles(printer.Styles())
 	if info.RepoName != "" {
 		printer.Println(styles.warning.Render("Removing timbers from " + info.RepoName + "..."))
 	}
@@ -189,8 +219,11 @@ func doUninstall(info *setup.UninstallInfo, binary, keep bool) []string {
 	agentErrs := setup.RemoveAgentEnvs(info)
 	errs = append(errs, agentErrs...)
 	errs = uninstallHooks(info, errs)
+	errs = append(errs, setup.RemoveExtraHooks(info)...)
+	errs = uninstallGitattributes(info, errs)
 	if !keep {
 		errs = uninstallTimbersDir(info, errs)
+		errs = uninstallConfig(info, errs)
 	}
 	errs = uninstallBinary(info, binary, errs)
 	return errs
@@ -200,12 +233,43 @@ func uninstallHooks(info *setup.UninstallInfo, errs []string) []string {
 	if !info.InRepo || !info.HooksInstalled {
 		return errs
 	}
-	removed, restored, err := setup.RemoveGitHook(info.PreCommitHookPath, info.HooksHasBackup, info.PreCommitBackupPath)
-	info.HooksRemoved = removed
-	info.HooksRestored = restored
-	if err != nil {
-		errs = append(errs, "hooks: "+err.Error())
+	// Legacy chain installs own the whole file and restore the backup;
+	// everything else strips only the timbers part of the hook.
+	if info.HooksHasBackup {
+		removed, restored, err := setup.RemoveGitHook(info.PreCommitHookPath, true, info.PreCommitBackupPath)
+		info.HooksRemoved = removed
+		info.HooksRestored = restored
+		if err != nil {
+			errs = append(errs, "hooks: "+err.Error())
+		}
+		return errs
+	}
+	if err := setup.RemoveTimbersHook(info.PreCommitHookPath, "pre-commit"); err != nil {
+		return append(errs, "hooks: "+err.Error())
+	}
+	info.HooksRemoved = true
+	return errs
+}
+
+func uninstallGitattributes(info *setup.UninstallInfo, errs []string) []string {
+	if !info.InRepo || !info.GitattributesHasEntry {
+		return errs
 	}
+	if err := setup.RemoveGitattributesLine(info.GitattributesPath); err != nil {
+		return append(errs, ".gitattributes: "+err.Error())
+	}
+	info.GitattributesRemoved = true
+	return errs
+}
+
+func uninstallConfig(info *setup.UninstallInfo, errs []string) []string {
+	if !info.InRepo || !info.ConfigExists {
+		return errs
+	}
+	if err := setup.RemoveConfigFile(info.ConfigPath); err != nil {
+		return append(errs, "config: "+err.Error())
+	}
+	info.ConfigRemoved = true
 	return errs
 }
 
@@ -268,57 +332,17 @@ func reportUninstallJSON(printer *output.Printer, info *setup.UninstallInfo, bin
 	if binary {
 		data["binary_removed"] = info.BinaryRemoved
 	}
+	data["removed"], data["left_behind"] = uninstallReport(info, binary, keep, true)
 	return printer.Success(data)
 }
 
-func reportUninstallHuman(printer *output.Printer, info *setup.UninstallInfo, binary, keep bool, errs []string) error {
-	styles := uninstallStyles(printer.IsTTY())
-	printer.Println()
-	printRemovalSummary(printer, styles, info, binary, keep)
-	printer.Println()
-	if len(errs) > 0 {
-		printer.Println(styles.warning.Render("Completed with errors: " + strings.Join(errs, "; ")))
-		return nil
-	}
-	msg := "Timbers removed. Your git history is unchanged."
-	if keep {
-		msg = "Timbers tooling removed. Ledger data preserved."
-	}
-	printer.Println(styles.dim.Render("  " + msg))
-	return nil
-}
-
-func printRemovalSummary(printer *output.Printer, styles uninstallStyleSet, info *setup.UninstallInfo, binary, keep bool) {
-	for _, ae := range info.AgentEnvs {
-		if ae.Removed {
-			printer.Println(styles.success.Render("  ok ") + ae.Display + " integration removed")
-		}
-	}
-	if info.HooksRemoved {
-		msg := "Git hooks removed"
-		if info.HooksRestored {
-			msg += " (original restored)"
-		}
-		printer.Println(styles.success.Render("  ok ") + msg)
-	}
-	if info.TimbersDirRemoved && !keep {
-		printer.Println(styles.success.Render("  ok ") + ".timbers/ entries removed")
-	}
-	if binary && info.BinaryRemoved {
-		printer.Println(styles.success.Render("  ok ") + "Binary removed")
-	}
-}
-
 type uninstallStyleSet struct{ warning, success, dim, bullet lipgloss.Style }
 
-func uninstallStyles(isTTY bool) uninstallStyleSet {
-	if !isTTY {
-		return uninstallStyleSet{}
-	}
+func uninstallStyles(styles *output.Styles) uninstallStyleSet {
 	return uninstallStyleSet{
-		warning: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "11", Dark: "11"}),
-		success: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "10", Dark: "10"}),
-		dim:     lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "8", Dark: "7"}),
-		bullet:  lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "11", Dark: "11"}),
+		warning: styles.Warning,
+		success: styles.Success,
+		dim:     styles.Dim,
+		bullet:  styles.Warning,
 	}
 }
@@ -0,0 +1,138 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strings"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/output"
+	"github.com/gorewood/timbers/internal/setup"
+)
+
+// uninstallReport splits every component timbers installed into items that
+// are (or, before the run, would be) removed and items left behind. After the
+// run (done=true) a planned item whose removal failed moves to left_behind.
+// Slices are never nil so JSON consumers always see arrays.
+func uninstallReport(info *setup.UninstallInfo, binary, keep, done bool) ([]setup.UninstallItem, []setup.UninstallItem) {
+	split := uninstallSplit{removed: []setup.UninstallItem{}, left: []setup.UninstallItem{}, done: done}
+
+	for _, ae := range info.AgentEnvs {
+		split.add(true, ae.Removed, setup.UninstallItem{Component: "agent_env:" + ae.Name, Path: ae.Path})
+	}
+	split.add(info.InRepo && info.HooksInstalled, info.HooksRemoved,
+		setup.UninstallItem{Component: "hook:pre-commit", Path: info.PreCommitHookPath})
+	for _, hook := range info.ExtraHooks {
+		split.add(true, hook.Removed, setup.UninstallItem{Component: "hook:" + hook.Name, Path: hook.Path})
+	}
+	split.add(info.InRepo && info.GitattributesHasEntry, info.GitattributesRemoved,
+		setup.UninstallItem{Component: "gitattributes", Path: info.GitattributesPath})
+
+	ledger := setup.UninstallItem{Component: "ledger", Path: info.TimbersDirPath}
+	cfg := setup.UninstallItem{Component: "config", Path: info.ConfigPath}
+	if keep {
+		split.keep(info.TimbersDirExists, ledger)
+		split.keep(info.ConfigExists, cfg)
+	} else {
+		split.add(info.InRepo && info.TimbersDirExists, info.TimbersDirRemoved, ledger)
+		split.add(info.InRepo && info.ConfigExists, info.ConfigRemoved, cfg)
+	}
+
+	for _, wf := range info.CIWorkflows {
+		split.left = append(split.left, setup.UninstallItem{
+			Component: "ci_workflow", Path: wf,
+			Reason: "tracked workflow file; delete it if no longer wanted",
+		})
+	}
+	split.add(binary, info.BinaryRemoved, setup.UninstallItem{Component: "binary", Path: info.BinaryPath})
+	return split.removed, split.left
+}
+
+// uninstallSplit accumulates uninstallReport's removed and left-behind items.
+type uninstallSplit struct {
+	removed []setup.UninstallItem
+	left    []setup.UninstallItem
+	done    bool
+}
+
+// add files a planned item under removed, or under left when the run is
+// done and its removal failed.
+func (split *uninstallSplit) add(planned, ok bool, item setup.UninstallItem) {
+	switch {
+	case !planned:
+		return
+	case split.done && !ok:
+		item.Reason = "removal failed"
+		split.left = append(split.left, item)
+	default:
+		split.removed = append(split.removed, item)
+	}
+}
+
+// keep files an existing item preserved by --keep-data under left.
+func (split *uninstallSplit) keep(exists bool, item setup.UninstallItem) {
+	if exists {
+		item.Reason = "--keep-data"
+		split.left = append(split.left, item)
+	}
+}
+
+func reportUninstallHuman(printer *output.Printer, info *setup.UninstallInfo, binary, keep bool, errs []string) error {
+	styles := uninstallStyles(printer.Styles())
+	printer.Println()
+	printRemovalSummary(printer, styles, info, binary, keep)
+	if _, left := uninstallReport(info, binary, keep, true); len(left) > 0 {
+		printer.Println()
+		printer.Println("  Left behind:")
+		for _, item := range left {
+			printer.Println(styles.dim.Render("    • " + item.Path + " (" + item.Reason + ")"))
+		}
+	}
+	printer.Println()
+	if len(errs) > 0 {
+		printer.Println(styles.warning.Render("Completed with errors: " + strings.Join(errs, "; ")))
+		return nil
+	}
+	msg := "Timbers removed. Your git history is unchanged."
+	if keep {
+		msg = "Timbers tooling removed. Ledger data preserved."
+	}
+	printer.Println(styles.dim.Render("  " + msg))
+	return nil
+}
+
+func printRemovalSummary(printer *output.Printer, styles uninstallStyleSet, info *setup.UninstallInfo, binary, keep bool) {
+	for _, ae := range info.AgentEnvs {
+		if ae.Removed {
+			printer.Println(styles.success.Render("  ok ") + ae.Display + " integration removed")
+		}
+	}
+	printHookRemovals(printer, styles, info)
+	if info.GitattributesRemoved {
+		printer.Println(styles.success.Render("  ok ") + ".gitattributes entry removed")
+	}
+	if info.TimbersDirRemoved && !keep {
+		printer.Println(styles.success.Render("  ok ") + ".timbers/ entries removed")
+	}
+	if info.ConfigRemoved {
+		printer.Println(styles.success.Render("  ok ") + config.ProjectFile + " removed")
+	}
+	if binary && info.BinaryRemoved {
+		printer.Println(styles.success.Render("  ok ") + "Binary removed")
+	}
+}
+
+// printHookRemovals prints the git hooks removed by uninstall.
+func printHookRemovals(printer *output.Printer, styles uninstallStyleSet, info *setup.UninstallInfo) {
+	if info.HooksRemoved {
+		msg := "Git hooks removed"
+		if info.HooksRestored {
+			msg += " (original restored)"
+		}
+		printer.Println(styles.success.Render("  ok ") + msg)
+	}
+	for _, hook := range info.ExtraHooks {
+		if hook.Removed {
+			printer.Println(styles.success.Render("  ok ") + hook.Name + " hook removed")
+		}
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/setup"
 )
 
 func TestUninstallDryRunJSON(t *testing.T) {
@@ -363,6 +366,90 @@ func TestUninstallKeepData(t *testing.T) {
 	})
 }
 
+func TestUninstallRemovesAllComponents(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+
+	runGit(t, tempDir, "init")
+	runGit(t, tempDir, "config", "user.email", "test@test.com")
+	runGit(t, tempDir, "config", "user.name", "Test User")
+
+	hooksDir := filepath.Join(tempDir, ".git", "hooks")
+	postCommit := filepath.Join(hooksDir, "post-commit")
+	postRewrite := filepath.Join(hooksDir, "post-rewrite")
+	if err := os.WriteFile(postCommit, []byte(setup.GeneratePostCommitHook()), 0o755); err != nil { //nolint:gosec
+		t.Fatalf("failed to write hook: %v", err)
+	}
+	userHook := "#!/bin/sh\necho mine\n"
+	if err := os.WriteFile(postRewrite, []byte(userHook+"\n"+postRewriteTimbersSection()), 0o755); err != nil { //nolint:gosec
+		t.Fatalf("failed to write hook: %v", err)
+	}
+	gitattributes := filepath.Join(tempDir, ".gitattributes")
+	if err := os.WriteFile(gitattributes, []byte(setup.GitattributesLine+"\n"), 0o600); err != nil {
+		t.Fatalf("failed to write .gitattributes: %v", err)
+	}
+	if _, err := config.WriteProjectTemplate(tempDir); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, _, err := setup.InstallCIWorkflow(tempDir, "github"); err != nil {
+		t.Fatalf("failed to write workflow: %v", err)
+	}
+
+	runInDir(t, tempDir, func() {
+		var buf bytes.Buffer
+		cmd := newRootCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"uninstall", "--force", "--json"})
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("command failed: %v", err)
+		}
+
+		var result struct {
+			Status     string                `json:"status"`
+			Removed    []setup.UninstallItem `json:"removed"`
+			LeftBehind []setup.UninstallItem `json:"left_behind"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("failed to parse JSON: %v\nOutput: %s", err, buf.String())
+		}
+		if result.Status != "ok" {
+			t.Errorf("status = %q, want ok", result.Status)
+		}
+
+		removed := make(map[string]bool)
+		for _, item := range result.Removed {
+			removed[item.Component] = true
+		}
+		for _, want := range []string{"hook:post-commit", "hook:post-rewrite", "gitattributes", "config"} {
+			if !removed[want] {
+				t.Errorf("removed missing %q: %+v", want, result.Removed)
+			}
+		}
+		if len(result.LeftBehind) != 1 || result.LeftBehind[0].Component != "ci_workflow" {
+			t.Errorf("left_behind = %+v, want the CI workflow", result.LeftBehind)
+		}
+	})
+
+	if _, err := os.Stat(postCommit); !os.IsNotExist(err) {
+		t.Error("post-commit hook should be removed")
+	}
+	data, err := os.ReadFile(postRewrite)
+	if err != nil {
+		t.Fatalf("post-rewrite hook should keep user content: %v", err)
+	}
+	if string(data) != userHook {
+		t.Errorf("post-rewrite = %q, want %q", string(data), userHook)
+	}
+	if _, err := os.Stat(gitattributes); !os.IsNotExist(err) {
+		t.Error(".gitattributes should be removed when it only held the timbers rule")
+	}
+	if _, err := os.Stat(config.ProjectPath(tempDir)); !os.IsNotExist(err) {
+		t.Error("config file should be removed")
+	}
+}
+
 func TestUninstallIdempotent(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -0,0 +1,245 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/output"
+	"github.com/gorewood/timbers/internal/selfupdate"
+)
+
+// upgradeTimeout bounds the release lookup and download when --timeout is
+// not set.
+const upgradeTimeout = 2 * time.Minute
+
+// upgradeFlags holds flag values for the upgrade command.
+type upgradeFlags struct {
+	check   bool
+	dryRun  bool
+	version string
+}
+
+// upgradeDeps are the upgrade command's outside-world dependencies,
+// injectable for tests.
+type upgradeDeps struct {
+	client     *selfupdate.Client
+	executable func() (string, error)
+	goos       string
+	goarch     string
+}
+
+// upgradeResult is the JSON shape of the upgrade command.
+type upgradeResult struct {
+	Current         string `json:"current"`
+	Latest          string `json:"latest"`
+	UpdateAvailable bool   `json:"update_available"`
+	Upgraded        bool   `json:"upgraded"`
+	Path            string `json:"path,omitempty"`
+}
+
+// newUpgradeCmd creates the upgrade command.
+func newUpgradeCmd() *cobra.Command {
+	return newUpgradeCmdInternal(upgradeDeps{
+		client:     selfupdate.NewClient(),
+		executable: os.Executable,
+		goos:       runtime.GOOS,
+		goarch:     runtime.GOARCH,
+	})
+}
+
+// newUpgradeCmdInternal creates the upgrade command with injected
+// dependencies.
+func newUpgradeCmdInternal(deps upgradeDeps) *cobra.Command {
+	var flags upgradeFlags
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade timbers to the latest release",
+		Long: `Upgrade timbers in place to the latest GitHub release.
+
+The release archive for this platform is downloaded, verified against the
+release's published SHA-256 checksums, and swapped in for the running
+binary. Nothing is installed if verification fails.
+
+--check only reports whether a newer release exists; with --json, CI can
+read update_available. --version installs a specific release instead,
+including an older one.
+
+Binaries managed by a package manager should be upgraded through it.
+
+Examples:
+  timbers upgrade                      # Install the latest release
+  timbers upgrade --check              # Report whether an update exists
+  timbers upgrade --check --json | jq -e '.update_available | not'
+  timbers upgrade --version v0.9.0     # Install a specific release`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runUpgrade(cmd, deps, flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.check, "check", false, "Report whether an update is available without installing it")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Show what would be installed without downloading it")
+	cmd.Flags().StringVar(&flags.version, "version", "", "Install this release tag instead of the latest")
+
+	return cmd
+}
+
+// runUpgrade resolves the target release and, unless --check, installs it.
+func runUpgrade(cmd *cobra.Command, deps upgradeDeps, flags upgradeFlags) error {
+	printer := newPrinter(cmd)
+	if isDevBuild() && !flags.check && !flags.dryRun && flags.version == "" {
+		err := output.NewUserError("this is a dev build; pass --version to replace it with a release")
+		printer.Error(err)
+		return err
+	}
+
+	target, err := upgradeTarget(deps, flags)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	result := upgradeResult{Current: version, Latest: target, UpdateAvailable: updateAvailable(target, flags.version != "")}
+	if flags.check || !result.UpdateAvailable {
+		return printUpgradeResult(printer, result, flags.check)
+	}
+	if flags.dryRun {
+		return printUpgradePlan(printer, deps, result)
+	}
+	path, err := installRelease(deps, target)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	result.Upgraded = true
+	result.Path = path
+	return printUpgradeResult(printer, result, false)
+}
+
+// upgradeTarget returns the release to install: --version, or the latest
+// release.
+func upgradeTarget(deps upgradeDeps, flags upgradeFlags) (string, error) {
+	if target := normalizeTag(flags.version); target != "" {
+		return target, nil
+	}
+	ctx, cancel := requestContext(upgradeTimeout)
+	defer cancel()
+	latest, err := deps.client.Latest(ctx)
+	if err != nil {
+		return "", output.NewSystemErrorWithCause("checking latest release", err)
+	}
+	return latest, nil
+}
+
+// installRelease downloads, verifies, and installs release tag over the
+// running binary, returning the path it replaced.
+func installRelease(deps upgradeDeps, tag string) (string, error) {
+	exe, err := deps.executable()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		return "", output.NewSystemErrorWithCause("locating the running binary", err)
+	}
+
+	ctx, cancel := requestContext(upgradeTimeout)
+	defer cancel()
+	asset := selfupdate.AssetName(deps.goos, deps.goarch)
+	archive, err := deps.client.Download(ctx, tag, asset)
+	if err != nil {
+		return "", output.NewSystemErrorWithCause("downloading "+tag, err)
+	}
+	binary, err := selfupdate.ExtractBinary(archive, asset)
+	if err != nil {
+		return "", output.NewSystemErrorWithCause("unpacking "+asset, err)
+	}
+	if err := selfupdate.ReplaceExecutable(exe, binary); err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			return "", output.NewUserError("cannot write " + exe + ": rerun with permission to replace it, or reinstall with install.sh")
+		}
+		return "", output.NewSystemErrorWithCause("installing "+tag, err)
+	}
+	return exe, nil
+}
+
+// printUpgradeResult reports the outcome in JSON or human form.
+func printUpgradeResult(printer *output.Printer, result upgradeResult, check bool) error {
+	if printer.IsJSON() {
+		return printer.WriteJSON(result)
+	}
+	switch {
+	case result.Upgraded:
+		printer.Print("Upgraded timbers %s → %s (%s)\n", result.Current, result.Latest, result.Path)
+	case result.UpdateAvailable && check:
+		printer.Print("Update available: %s → %s\nRun 'timbers upgrade' to install it.\n", result.Current, result.Latest)
+	case isDevBuild():
+		printer.Print("timbers is a dev build; the latest release is %s\n", result.Latest)
+	default:
+		printer.Print("timbers %s is up to date\n", result.Current)
+	}
+	return nil
+}
+
+// printUpgradePlan reports the binary an upgrade would replace.
+func printUpgradePlan(printer *output.Printer, deps upgradeDeps, result upgradeResult) error {
+	exe, err := deps.executable()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		sysErr := output.NewSystemErrorWithCause("locating the running binary", err)
+		printer.Error(sysErr)
+		return sysErr
+	}
+	asset := selfupdate.AssetName(deps.goos, deps.goarch)
+	detail := "install " + result.Latest + " from " + asset + " after verifying its checksum"
+
+	if printer.IsJSON() {
+		return printer.WriteJSON(withPlan(map[string]any{
+			"current":          result.Current,
+			"latest":           result.Latest,
+			"update_available": result.UpdateAvailable,
+			"path":             exe,
+		}, []plannedAction{{Action: planReplace, Target: exe, Detail: detail}}))
+	}
+	printer.Print("Would upgrade timbers %s → %s (%s)\n", result.Current, result.Latest, exe)
+	return nil
+}
+
+// updateAvailable reports whether target should be installed over the
+// running version. An explicit --version always counts unless it is the
+// running version; a dev build never has an update of its own.
+func updateAvailable(target string, explicit bool) bool {
+	if explicit {
+		return normalizeTag(version) != target
+	}
+	if isDevBuild() {
+		return false
+	}
+	return selfupdate.IsNewer(target, version)
+}
+
+// isDevBuild reports whether this binary was built without a release
+// version.
+func isDevBuild() bool {
+	return version == "dev" || version == ""
+}
+
+// normalizeTag adds the "v" prefix release tags carry, so --version 0.9.0
+// and v0.9.0 name the same release.
+func normalizeTag(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if tag == "" || strings.HasPrefix(tag, "v") {
+		return tag
+	}
+	return "v" + tag
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorewood/timbers/internal/selfupdate"
+)
+
+// setVersion overrides the build version for one test.
+func setVersion(t *testing.T, v string) {
+	t.Helper()
+	saved := version
+	version = v
+	t.Cleanup(func() { version = saved })
+}
+
+// newUpgradeTestDeps serves release v0.9.0 with a verified linux/amd64
+// archive holding binary, and points the executable at exe.
+func newUpgradeTestDeps(t *testing.T, exe, binary string) upgradeDeps {
+	t.Helper()
+	var buf bytes.Buffer
+	gzWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzWriter)
+	if err := tarWriter.WriteHeader(&tar.Header{Name: "timbers", Mode: 0o755, Size: int64(len(binary)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tarWriter.Write([]byte(binary)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	archive := buf.Bytes()
+	sum := sha256.Sum256(archive)
+	asset := selfupdate.AssetName("linux", "amd64")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/releases/latest", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name": "v0.9.0"}`))
+	})
+	mux.HandleFunc("/download/v0.9.0/checksums.txt", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(hex.EncodeToString(sum[:]) + "  " + asset + "\n"))
+	})
+	mux.HandleFunc("/download/v0.9.0/"+asset, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return upgradeDeps{
+		client:     &selfupdate.Client{APIURL: server.URL + "/api", DownloadURL: server.URL + "/download", HTTP: server.Client()},
+		executable: func() (string, error) { return exe, nil },
+		goos:       "linux",
+		goarch:     "amd64",
+	}
+}
+
+func runUpgradeTest(t *testing.T, deps upgradeDeps, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := newUpgradeCmdInternal(deps)
+	cmd.PersistentFlags().Bool("json", false, "")
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestUpgradeCommand(t *testing.T) {
+	t.Run("check reports available update", func(t *testing.T) {
+		setVersion(t, "0.8.0")
+		out, err := runUpgradeTest(t, newUpgradeTestDeps(t, "", ""), "--check", "--json")
+		if err != nil {
+			t.Fatalf("upgrade --check failed: %v", err)
+		}
+		var result upgradeResult
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("invalid JSON %q: %v", out, err)
+		}
+		if !result.UpdateAvailable || result.Upgraded || result.Latest != "v0.9.0" {
+			t.Errorf("result = %+v, want update available and not installed", result)
+		}
+	})
+
+	t.Run("up to date installs nothing", func(t *testing.T) {
+		setVersion(t, "v0.9.0")
+		out, err := runUpgradeTest(t, newUpgradeTestDeps(t, "", ""))
+		if err != nil {
+			t.Fatalf("upgrade failed: %v", err)
+		}
+		if !strings.Contains(out, "up to date") {
+			t.Errorf("output = %q, want up to date", out)
+		}
+	})
+
+	t.Run("replaces binary with verified release", func(t *testing.T) {
+		setVersion(t, "0.8.0")
+		exe := filepath.Join(t.TempDir(), "timbers")
+		if err := os.WriteFile(exe, []byte("old binary"), 0o755); err != nil { //nolint:gosec // test binary
+			t.Fatal(err)
+		}
+		if _, err := runUpgradeTest(t, newUpgradeTestDeps(t, exe, "new binary")); err != nil {
+			t.Fatalf("upgrade failed: %v", err)
+		}
+		data, err := os.ReadFile(exe)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "new binary" {
+			t.Errorf("binary = %q, want new binary", data)
+		}
+	})
+
+	t.Run("dry run plans the replacement without installing", func(t *testing.T) {
+		setVersion(t, "0.8.0")
+		exe := filepath.Join(t.TempDir(), "timbers")
+		if err := os.WriteFile(exe, []byte("old binary"), 0o755); err != nil { //nolint:gosec // test binary
+			t.Fatal(err)
+		}
+		out, err := runUpgradeTest(t, newUpgradeTestDeps(t, exe, "new binary"), "--dry-run", "--json")
+		if err != nil {
+			t.Fatalf("upgrade --dry-run failed: %v", err)
+		}
+		var result struct {
+			Status string          `json:"status"`
+			Plan   []plannedAction `json:"planned_actions"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("invalid JSON %q: %v", out, err)
+		}
+		if result.Status != "dry_run" || len(result.Plan) != 1 || result.Plan[0].Action != planReplace || result.Plan[0].Target != exe {
+			t.Errorf("result = %+v, want one replace of %s", result, exe)
+		}
+		if data, _ := os.ReadFile(exe); string(data) != "old binary" {
+			t.Error("dry run must not replace the binary")
+		}
+	})
+
+	t.Run("dev build refuses without version", func(t *testing.T) {
+		setVersion(t, "dev")
+		if _, err := runUpgradeTest(t, newUpgradeTestDeps(t, "", "")); err == nil {
+			t.Error("expected error upgrading a dev build without --version")
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/output"
+	"github.com/gorewood/timbers/internal/selfupdate"
+)
+
+// versionCheckTimeout bounds the --check-update release lookup.
+const versionCheckTimeout = 5 * time.Second
+
+// versionInfo is the JSON shape of the version command.
+type versionInfo struct {
+	Version   string       `json:"version"`
+	Commit    string       `json:"commit"`
+	Date      string       `json:"date"`
+	GoVersion string       `json:"go_version"`
+	Platform  string       `json:"platform"`
+	Update    *updateCheck `json:"update,omitempty"`
+}
+
+// updateCheck is the --check-update result. Error is set instead of Latest
+// when the lookup fails; the command still succeeds so scripts always get
+// the local build info.
+type updateCheck struct {
+	Latest          string `json:"latest,omitempty"`
+	UpdateAvailable bool   `json:"update_available"`
+	Error           string `json:"error,omitempty"`
+}
+
+// newVersionCmd creates the version command.
+func newVersionCmd() *cobra.Command {
+	return newVersionCmdInternal(selfupdate.NewClient())
+}
+
+// newVersionCmdInternal creates the version command with an injected
+// release client.
+func newVersionCmdInternal(client *selfupdate.Client) *cobra.Command {
+	var checkUpdate bool
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show build information",
+		Long: `Show the timbers version, commit, build date, Go version, and platform.
+
+--check-update also looks up the latest GitHub release and reports whether
+it is newer. A failed lookup is reported in the output, not as an error.
+
+Examples:
+  timbers version
+  timbers version --json
+  timbers version --json --check-update | jq .update.update_available`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runVersion(cmd, client, checkUpdate)
+		},
+	}
+
+	cmd.Flags().BoolVar(&checkUpdate, "check-update", false, "Compare against the latest release")
+
+	return cmd
+}
+
+// runVersion executes the version command.
+func runVersion(cmd *cobra.Command, client *selfupdate.Client, checkUpdate bool) error {
+	printer := newPrinter(cmd)
+
+	info := versionInfo{
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if checkUpdate {
+		info.Update = checkForUpdate(client)
+	}
+
+	if printer.IsJSON() {
+		return printer.WriteJSON(info)
+	}
+
+	fields := []output.Field{
+		{Key: "Version", Value: info.Version},
+		{Key: "Commit", Value: info.Commit},
+		{Key: "Date", Value: info.Date},
+		{Key: "Go", Value: info.GoVersion},
+		{Key: "Platform", Value: info.Platform},
+	}
+	if info.Update != nil {
+		fields = append(fields, output.Field{Key: "Latest", Value: describeUpdate(info.Update)})
+	}
+	printer.KV(fields)
+	return nil
+}
+
+// checkForUpdate looks up the latest release and compares it to this build.
+func checkForUpdate(client *selfupdate.Client) *updateCheck {
+	ctx, cancel := requestContext(versionCheckTimeout)
+	defer cancel()
+
+	latest, err := client.Latest(ctx)
+	if err != nil {
+		return &updateCheck{Error: err.Error()}
+	}
+	return &updateCheck{Latest: latest, UpdateAvailable: updateAvailable(latest, false)}
+}
+
+// describeUpdate renders an update check for human output.
+func describeUpdate(check *updateCheck) string {
+	switch {
+	case check.Error != "":
+		return "unknown (" + check.Error + ")"
+	case check.UpdateAvailable:
+		return check.Latest + " (run 'timbers upgrade')"
+	default:
+		return check.Latest
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/gorewood/timbers/internal/selfupdate"
+)
+
+func runVersionTest(t *testing.T, client *selfupdate.Client, args ...string) string {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := newVersionCmdInternal(client)
+	cmd.PersistentFlags().Bool("json", false, "")
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("version %v failed: %v", args, err)
+	}
+	return out.String()
+}
+
+func TestVersionCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/releases/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"tag_name": "v0.9.0"}`))
+	}))
+	t.Cleanup(server.Close)
+	client := &selfupdate.Client{APIURL: server.URL, HTTP: server.Client()}
+
+	t.Run("json build info", func(t *testing.T) {
+		setVersion(t, "0.8.0")
+		var info versionInfo
+		if err := json.Unmarshal([]byte(runVersionTest(t, client, "--json")), &info); err != nil {
+			t.Fatal(err)
+		}
+		if info.Version != "0.8.0" || info.GoVersion != runtime.Version() || info.Platform != runtime.GOOS+"/"+runtime.GOARCH {
+			t.Errorf("info = %+v", info)
+		}
+		if info.Update != nil {
+			t.Error("update should be omitted without --check-update")
+		}
+	})
+
+	t.Run("check update", func(t *testing.T) {
+		setVersion(t, "0.8.0")
+		var info versionInfo
+		if err := json.Unmarshal([]byte(runVersionTest(t, client, "--json", "--check-update")), &info); err != nil {
+			t.Fatal(err)
+		}
+		if info.Update == nil || info.Update.Latest != "v0.9.0" || !info.Update.UpdateAvailable {
+			t.Errorf("update = %+v, want v0.9.0 available", info.Update)
+		}
+	})
+
+	t.Run("failed check still succeeds", func(t *testing.T) {
+		broken := &selfupdate.Client{APIURL: server.URL + "/missing", HTTP: server.Client()}
+		var info versionInfo
+		if err := json.Unmarshal([]byte(runVersionTest(t, broken, "--json", "--check-update")), &info); err != nil {
+			t.Fatal(err)
+		}
+		if info.Update == nil || info.Update.Error == "" {
+			t.Errorf("update = %+v, want lookup error", info.Update)
+		}
+	})
+
+	t.Run("human output", func(t *testing.T) {
+		setVersion(t, "0.8.0")
+		out := runVersionTest(t, client, "--check-update")
+		if !strings.Contains(out, "0.8.0") || !strings.Contains(out, "timbers upgrade") {
+			t.Errorf("output = %q", out)
+		}
+	})
+}
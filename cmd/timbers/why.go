@@ -0,0 +1,269 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/draft"
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/llm"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// whyTemplate is the draft template that turns a question and the retrieved
+// entries into a prompt. Projects can override it in .timbers/templates/.
+const whyTemplate = "why"
+
+// citationRegex matches entry IDs an answer cites.
+var citationRegex = regexp.MustCompile(`tb_(?:[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9:-]+Z_[0-9a-f-]+|[0-7][0-9A-HJKMNP-TV-Z]{25})`)
+
+// whyFlags holds flag values for the why command.
+type whyFlags struct {
+	limit      int
+	model      string
+	provider   string
+	promptOnly bool
+	semantic   bool
+}
+
+// newWhyCmd creates the why command.
+func newWhyCmd() *cobra.Command {
+	return newWhyCmdInternal(nil)
+}
+
+// newWhyCmdInternal creates the why command with optional storage injection.
+// If storage is nil, a real storage is created when the command runs.
+func newWhyCmdInternal(storage *ledger.Storage) *cobra.Command {
+	var flags whyFlags
+
+	cmd := &cobra.Command{
+		Use:   "why <question>",
+		Short: "Answer a question from the ledger, citing entries",
+		Long: `Answer a question about the project's history from the ledger.
+
+The entries most relevant to the question (by keyword match over what, why,
+how, notes, tags, and work items, or by embedding similarity with --semantic)
+are rendered through the "why" draft
+template and sent to the configured LLM ([llm] model in .timbers/config.toml,
+or --model). The answer cites the entry IDs it draws on.
+
+Examples:
+  timbers why "why did we switch from notes to files?"
+  timbers why "how is pending computed" --limit 5 --model sonnet
+  timbers why "what made us distrust the old retry logic" --semantic
+  timbers why "auth token refresh" --prompt-only | my-llm   # Render the prompt only`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWhy(cmd, storage, args[0], flags)
+		},
+	}
+
+	cmd.Flags().IntVar(&flags.limit, "limit", 8, "Maximum number of entries to give the LLM")
+	cmd.Flags().StringVarP(&flags.model, "model", "m", "", "Model name (default: [llm] model from config)")
+	cmd.Flags().StringVarP(&flags.provider, "provider", "p", "", "Provider (anthropic, openai, google, local) - inferred if omitted")
+	cmd.Flags().BoolVar(&flags.promptOnly, "prompt-only", false, "Print the rendered prompt instead of calling the LLM")
+	cmd.Flags().BoolVar(&flags.semantic, "semantic", false, "Retrieve entries by embedding similarity ([llm] embedding_model)")
+
+	return cmd
+}
+
+// runWhy executes the why command.
+func runWhy(cmd *cobra.Command, storage *ledger.Storage, question string, flags whyFlags) error {
+	printer := newPrinter(cmd)
+
+	if err := validateWhyInput(question, flags); err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	storage, err := initQueryStorage(storage, printer)
+	if err != nil {
+		return err
+	}
+	entries, err := storage.ListEntries()
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+
+	ranked, err := rankForSearch(storage, entries, nil, question, flags.limit, flags.semantic)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	if len(ranked) == 0 {
+		return outputWhyNoMatch(printer, question)
+	}
+
+	prompt, err := renderWhyPrompt(question, ranked)
+	if err != nil {
+		printer.Error(err)
+		return err
+	}
+	if flags.promptOnly {
+		if printer.IsJSON() {
+			return printer.Success(map[string]any{"question": question, "prompt": prompt, "entries": whyEntryRefs(ranked)})
+		}
+		printer.Print("%s\n", prompt)
+		return nil
+	}
+
+	return answerWhy(printer, question, prompt, ranked, flags)
+}
+
+// validateWhyInput checks the question and flags before any ledger work.
+func validateWhyInput(question string, flags whyFlags) error {
+	if !flags.semantic && len(ledger.QueryTerms(question)) == 0 {
+		return output.NewUserError("question has no searchable words; ask about a feature, file, or decision")
+	}
+	if flags.limit <= 0 {
+		return output.NewUserError("--limit must be a positive integer")
+	}
+	return nil
+}
+
+// renderWhyPrompt renders the why template for the ranked entries.
+func renderWhyPrompt(question string, ranked []ledger.ScoredEntry) (string, error) {
+	tmpl, err := draft.LoadTemplate(whyTemplate)
+	if err != nil {
+		return "", output.NewUserError(err.Error())
+	}
+
+	entries := make([]*ledger.Entry, len(ranked))
+	for i, scored := range ranked {
+		entries[i] = scored.Entry
+	}
+	renderCtx := buildRenderContext(entries, "", map[string]string{"question": question})
+	projected, err := draft.ProjectEntries(entries, draft.ProjectionNarrative, nil)
+	if err != nil {
+		return "", output.NewSystemErrorWithCause("failed to project entries", err)
+	}
+	renderCtx.EntriesJSON = projected
+
+	rendered, err := draft.Render(tmpl, renderCtx)
+	if err != nil {
+		return "", output.NewSystemError(fmt.Sprintf("failed to render: %v", err))
+	}
+	return rendered, nil
+}
+
+// answerWhy sends the prompt to the LLM and prints the answer with the
+// entries it cited.
+func answerWhy(printer *output.Printer, question, prompt string, ranked []ledger.ScoredEntry, flags whyFlags) error {
+	client, err := llm.New(configuredModel(flags.model), llm.Provider(flags.provider))
+	if err != nil {
+		userErr := output.NewUserError(err.Error())
+		printer.Error(userErr)
+		return userErr
+	}
+
+	ctx, cancel := llmContext()
+	defer cancel()
+
+	resp, err := completeWithProgress(ctx, printer, client, llm.Request{Prompt: prompt})
+	if err != nil {
+		sysErr := output.NewSystemErrorWithCause("LLM request failed", err)
+		printer.Error(sysErr)
+		return sysErr
+	}
+
+	answer := draft.SanitizeLLMOutput(resp.Content)
+	cited := citedEntries(answer, ranked)
+
+	if printer.IsJSON() {
+		citations := make([]string, len(cited))
+		for i, entry := range cited {
+			citations[i] = entry.ID
+		}
+		return printer.Success(map[string]any{
+			"question":  question,
+			"model":     resp.Model,
+			"answer":    answer,
+			"citations": citations,
+			"entries":   whyEntryRefs(ranked),
+		})
+	}
+
+	printer.Print("%s\n", answer)
+	if len(cited) > 0 {
+		printer.Println()
+		printer.Println("Sources:")
+		for _, entry := range cited {
+			printer.Print("  %s  %s\n", entry.ID, entry.Summary.What)
+		}
+	}
+	return nil
+}
+
+// outputWhyNoMatch reports that no entry matched, without calling the LLM.
+func outputWhyNoMatch(printer *output.Printer, question string) error {
+	if printer.IsJSON() {
+		return printer.Success(map[string]any{
+			"question":  question,
+			"answer":    "",
+			"citations": []string{},
+			"entries":   []map[string]any{},
+		})
+	}
+	printer.Println("No ledger entries match that question. Try different keywords, or 'timbers query --last 20'.")
+	return nil
+}
+
+// citedEntries returns the ranked entries whose IDs appear in answer, in the
+// order they were first cited. IDs that were not retrieved are ignored: the
+// model cannot have read them.
+func citedEntries(answer string, ranked []ledger.ScoredEntry) []*ledger.Entry {
+	byID := make(map[string]*ledger.Entry, len(ranked))
+	for _, scored := range ranked {
+		byID[scored.Entry.ID] = scored.Entry
+	}
+
+	var cited []*ledger.Entry
+	seen := make(map[string]bool)
+	for _, id := range citationRegex.FindAllString(answer, -1) {
+		if entry, ok := byID[id]; ok && !seen[id] {
+			seen[id] = true
+			cited = append(cited, entry)
+		}
+	}
+	return cited
+}
+
+// whyEntryRefs summarizes the retrieved entries for JSON output.
+func whyEntryRefs(ranked []ledger.ScoredEntry) []map[string]any {
+	refs := make([]map[string]any, len(ranked))
+	for i, scored := range ranked {
+		refs[i] = map[string]any{
+			"id":    scored.Entry.ID,
+			"what":  scored.Entry.Summary.What,
+			"score": scored.Score,
+		}
+	}
+	return refs
+}
+
+// configuredModel returns flagModel, or the project's [llm] model when the
+// flag is empty.
+func configuredModel(flagModel string) string {
+	if flagModel != "" {
+		return flagModel
+	}
+	return projectLLMConfig().Model
+}
+
+// projectLLMConfig returns the [llm] section of the project config, or the
+// defaults outside a repository or when the file does not parse.
+func projectLLMConfig() config.LLMConfig {
+	cfg := config.DefaultProject()
+	if root, err := git.RepoRoot(); err == nil {
+		if loaded, loadErr := config.LoadProject(root); loadErr == nil {
+			cfg = loaded
+		}
+	}
+	return cfg.LLM
+}
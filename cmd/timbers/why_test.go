@@ -0,0 +1,82 @@
+// Package main provides the entry point for the timbers CLI.
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// runWhyTest runs the why command over file-backed entries.
+func runWhyTest(t *testing.T, entries []*ledger.Entry, args ...string) (string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, entry := range entries {
+		writeQueryEntryFile(t, dir, entry)
+	}
+	files := ledger.NewFileStorage(dir, func(_ string) error { return nil }, func(_, _ string) error { return nil })
+	cmd := newWhyCmdInternal(ledger.NewStorage(&mockGitOpsForQuery{}, files))
+	var buf strings.Builder
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestWhyPromptOnly(t *testing.T) {
+	now := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	tokens := createQueryTestEntryStruct("aaa111", "Rotate refresh tokens", now)
+	other := createQueryTestEntryStruct("bbb222", "Bump dependencies", now.Add(time.Hour))
+
+	out, err := runWhyTest(t, []*ledger.Entry{tokens, other}, "why rotate refresh tokens?", "--prompt-only")
+	if err != nil {
+		t.Fatalf("why --prompt-only errored: %v\noutput: %s", err, out)
+	}
+	for _, want := range []string{"why rotate refresh tokens?", tokens.ID, "Rotate refresh tokens"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("prompt missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, other.ID) {
+		t.Errorf("prompt includes unrelated entry %s", other.ID)
+	}
+}
+
+func TestWhyNoMatchSkipsLLM(t *testing.T) {
+	now := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	out, err := runWhyTest(t, []*ledger.Entry{createQueryTestEntryStruct("aaa111", "Bump dependencies", now)},
+		"kubernetes autoscaling", "--model", "no-such-provider-model")
+	if err != nil {
+		t.Fatalf("why with no match errored: %v\noutput: %s", err, out)
+	}
+	if !strings.Contains(out, "No ledger entries match") {
+		t.Errorf("output = %q, want no-match message", out)
+	}
+}
+
+func TestWhyRejectsEmptyQuestion(t *testing.T) {
+	if out, err := runWhyTest(t, nil, "why is it?"); err == nil || !strings.Contains(out, "no searchable words") {
+		t.Errorf("want searchable-words error, got err=%v output: %s", err, out)
+	}
+}
+
+func TestCitedEntries(t *testing.T) {
+	now := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	first := createQueryTestEntryStruct("aaa111", "first", now)
+	second := createQueryTestEntryStruct("bbb222", "second", now.Add(time.Hour))
+	ranked := []ledger.ScoredEntry{{Entry: first, Score: 2}, {Entry: second, Score: 1}}
+
+	answer := "Because of X [" + second.ID + "], later revisited [" + second.ID + "] and [tb_2020-01-01T00:00:00Z_ffffff]."
+	cited := citedEntries(answer, ranked)
+	if len(cited) != 1 || cited[0] != second {
+		t.Errorf("citedEntries() = %v, want only %s (deduplicated, unknown IDs dropped)", cited, second.ID)
+	}
+
+	first.ID = ledger.IDSchemeULID.Generate("aaa111", now)
+	if cited := citedEntries("Per ["+first.ID+"].", ranked); len(cited) != 1 || cited[0] != first {
+		t.Errorf("citedEntries() = %v, want the ULID entry %s", cited, first.ID)
+	}
+}
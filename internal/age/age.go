@@ -0,0 +1,76 @@
+// Package age encrypts and decrypts entry payloads with the age CLI
+// (https://age-encryption.org). Like internal/git, it runs the executable
+// rather than linking a library, so keys and plugins work exactly as they do
+// for the user's own age invocations.
+package age
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// IdentityEnv names the environment variable holding the path of the age
+// identity file used to decrypt entries.
+const IdentityEnv = "TIMBERS_AGE_IDENTITY"
+
+// Sealer encrypts to a fixed set of recipients and decrypts with one
+// identity file.
+type Sealer struct {
+	// Recipients are age or SSH public keys, passed to age -r.
+	Recipients []string
+	// Identity is the path of the identity file, passed to age -i. Empty
+	// means entries cannot be decrypted.
+	Identity string
+}
+
+// New returns a Sealer for recipients, reading the identity path from
+// IdentityEnv.
+func New(recipients []string) *Sealer {
+	return &Sealer{Recipients: recipients, Identity: os.Getenv(IdentityEnv)}
+}
+
+// Seal encrypts plaintext to every recipient and returns ASCII-armored
+// ciphertext.
+func (s *Sealer) Seal(plaintext []byte) (string, error) {
+	if len(s.Recipients) == 0 {
+		return "", errors.New("no encryption recipients configured")
+	}
+	args := []string{"--encrypt", "--armor"}
+	for _, recipient := range s.Recipients {
+		args = append(args, "-r", recipient)
+	}
+	out, err := run(plaintext, args...)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Open decrypts armored ciphertext produced by Seal.
+func (s *Sealer) Open(ciphertext string) ([]byte, error) {
+	if s.Identity == "" {
+		return nil, fmt.Errorf("no age identity (set %s)", IdentityEnv)
+	}
+	return run([]byte(ciphertext), "--decrypt", "-i", s.Identity)
+}
+
+// run pipes input through age with args and returns its output.
+func run(input []byte, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(context.Background(), "age", args...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("age %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("age %s: %w", args[0], err)
+	}
+	return out, nil
+}
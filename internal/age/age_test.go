@@ -0,0 +1,66 @@
+package age
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeAge puts an "age" script on PATH that records its arguments and
+// reverses its input, so tests can check the plumbing without real keys.
+func fakeAge(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake age script needs a POSIX shell")
+	}
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\nrev\n"
+	if err := os.WriteFile(filepath.Join(dir, "age"), []byte(script), 0o755); err != nil { //nolint:gosec // test executable
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return argsFile
+}
+
+func TestSealAndOpen(t *testing.T) {
+	argsFile := fakeAge(t)
+	sealer := &Sealer{Recipients: []string{"age1alice", "age1bob"}, Identity: "/keys/me.txt"}
+
+	sealed, err := sealer.Seal([]byte("secret\n"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if sealed != "terces\n" {
+		t.Errorf("Seal = %q", sealed)
+	}
+	args, _ := os.ReadFile(argsFile)
+	if got := strings.TrimSpace(string(args)); got != "--encrypt --armor -r age1alice -r age1bob" {
+		t.Errorf("Seal args = %q", got)
+	}
+
+	opened, err := sealer.Open(sealed)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if string(opened) != "secret\n" {
+		t.Errorf("Open = %q", opened)
+	}
+	args, _ = os.ReadFile(argsFile)
+	if got := strings.TrimSpace(string(args)); got != "--decrypt -i /keys/me.txt" {
+		t.Errorf("Open args = %q", got)
+	}
+}
+
+func TestSealerNeedsKeys(t *testing.T) {
+	t.Setenv(IdentityEnv, "")
+	sealer := New(nil)
+	if _, err := sealer.Seal([]byte("x")); err == nil {
+		t.Error("Seal without recipients expected an error")
+	}
+	if _, err := sealer.Open("x"); err == nil || !strings.Contains(err.Error(), IdentityEnv) {
+		t.Errorf("Open without identity = %v, want it to mention %s", err, IdentityEnv)
+	}
+}
@@ -1,4 +1,5 @@
-// Package config provides the global configuration directory for timbers.
+// Package config provides the global configuration directory and the
+// per-repository project configuration for timbers.
 package config
 
 import (
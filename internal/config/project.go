@@ -0,0 +1,242 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ProjectFile is the per-repository configuration file, relative to the
+// repository root. It lives inside .timbers/ so it travels with the ledger.
+const ProjectFile = ".timbers/config.toml"
+
+// Project is the per-repository configuration read from ProjectFile.
+// Every field is optional: a missing file or missing key yields the value
+// from DefaultProject, so callers never need to nil-check sections.
+type Project struct {
+	Tags    TagsConfig    `toml:"tags"`
+	Batch   BatchConfig   `toml:"batch"`
+	Pending PendingConfig `toml:"pending"`
+	Log     LogConfig     `toml:"log"`
+	LLM     LLMConfig     `toml:"llm"`
+	Scope   ScopeConfig   `toml:"scope"`
+	Hooks   HooksConfig   `toml:"hooks"`
+	Storage StorageConfig `toml:"storage"`
+	Theme   ThemeConfig   `toml:"theme"`
+	Git     GitConfig     `toml:"git"`
+	// Encryption configures 'timbers log --encrypt'.
+	Encryption EncryptionConfig `toml:"encryption"`
+	// Trailers maps commit trailer keys (case-insensitive, e.g. "Ticket")
+	// to the entry field 'timbers log' copies their values into:
+	// "work-item", "work-item:<system>", "tag", or "notes".
+	Trailers map[string]string `toml:"trailers"`
+}
+
+// TagsConfig controls the tags 'timbers log' adds on its own.
+type TagsConfig struct {
+	// Paths maps path globs (e.g. "internal/llm/**") to a tag 'timbers log'
+	// adds when an entry's commits touch a matching file.
+	Paths map[string]string `toml:"paths"`
+}
+
+// BatchConfig controls how `timbers log --batch` groups pending commits.
+type BatchConfig struct {
+	// GroupBy is the grouping strategy: "auto", "work-item", "day",
+	// "author", "path-prefix", or "branch-merge".
+	GroupBy string `toml:"group_by"`
+	// PathDepth is how many leading directories name a path-prefix group.
+	PathDepth int `toml:"path_depth"`
+}
+
+// PendingConfig controls which commits count as pending.
+type PendingConfig struct {
+	// FirstParent follows only first parents, so merged branches are
+	// documented at the merge rather than commit by commit.
+	FirstParent bool `toml:"first_parent"`
+}
+
+// LogConfig sets defaults for 'timbers log'.
+type LogConfig struct {
+	// Push pushes the current branch after each entry is written, as
+	// --push does.
+	Push bool `toml:"push"`
+	// BranchWorkItems maps branch-name regexes to a work item system; the
+	// first capture group (or the whole match) is the ID, used when
+	// --work-item is not given.
+	BranchWorkItems map[string]string `toml:"branch_work_items"`
+}
+
+// LLMConfig selects the models used by the LLM-backed commands.
+type LLMConfig struct {
+	// Model is the default model for why, review, and summarize when
+	// --model is not given (e.g. "haiku").
+	Model string `toml:"model"`
+	// EmbeddingModel enables semantic search (e.g. "text-embedding-3-small",
+	// "local-nomic-embed-text"). Empty disables it.
+	EmbeddingModel string `toml:"embedding_model"`
+}
+
+// ScopeConfig restricts which paths of the repository the ledger covers.
+type ScopeConfig struct {
+	// Packages splits a monorepo into per-team scopes, one per subdirectory;
+	// --package limits pending, query, and draft to one of them.
+	Packages []PackageScope `toml:"packages"`
+}
+
+// HooksConfig controls which events `timbers hook run` acts on.
+type HooksConfig struct {
+	// Disabled lists hook events (e.g. "post-commit") that become no-ops.
+	Disabled []string `toml:"disabled"`
+}
+
+// StorageConfig controls how entry files are arranged under .timbers/.
+type StorageConfig struct {
+	// Layout is "day" (YYYY/MM/DD), "month" (YYYY/MM), "flat", or "hash"
+	// (two-character hash shards, for very high-volume ledgers).
+	Layout string `toml:"layout"`
+	// Strict validates entries against the embedded JSON Schema on read and
+	// write, rejecting malformed files instead of partially loading them.
+	Strict bool `toml:"strict"`
+	// IDScheme is "anchor" (tb_<time>_<sha>), "random", which appends a
+	// random suffix so entries on the same commit in the same second can't
+	// collide, or "ulid" (tb_<ULID>).
+	IDScheme string `toml:"id_scheme"`
+	// Index keeps a local cache of parsed entries in the git directory, so
+	// listing, query, and stats on large ledgers skip unchanged files.
+	Index bool `toml:"index"`
+}
+
+// ThemeConfig sets the palette for human output.
+type ThemeConfig struct {
+	// Background is "auto" (adapt to the terminal), "dark", or "light".
+	Background string `toml:"background"`
+	// Colors overrides palette roles (error, success, warning, dim, title,
+	// key, accent, border) with an ANSI code ("0"-"255") or "#rrggbb".
+	Colors map[string]string `toml:"colors"`
+}
+
+// GitConfig controls how timbers talks to the repository.
+type GitConfig struct {
+	// Backend is "exec" (run the git executable) or "go-git" (read the
+	// repository in-process, for hosts where spawning git is slow or git
+	// is not installed). go-git covers history reads; writes still need git.
+	Backend string `toml:"backend"`
+	// Timeout bounds each git process ("30s", or bare seconds), so a hung
+	// fetch or credential prompt is killed even without --timeout. Empty
+	// means no per-process limit.
+	Timeout string `toml:"timeout"`
+}
+
+// EncryptionConfig lists who can read encrypted entries.
+type EncryptionConfig struct {
+	// Recipients are age public keys (age1...) or SSH public keys the
+	// summary and notes of encrypted entries are sealed to.
+	Recipients []string `toml:"recipients"`
+}
+
+// HookDisabled reports whether event is listed in Hooks.Disabled.
+func (p Project) HookDisabled(event string) bool {
+	return slices.Contains(p.Hooks.Disabled, event)
+}
+
+// PackagePath returns the directory of the [[scope.packages]] entry named
+// name, and whether there is one.
+func (p Project) PackagePath(name string) (string, bool) {
+	for _, pkg := range p.Scope.Packages {
+		if pkg.Name == name {
+			return pkg.Path, true
+		}
+	}
+	return "", false
+}
+
+// PackageScope maps a monorepo package name to its directory.
+type PackageScope struct {
+	Name string `toml:"name"`
+	// Path is the slash-separated directory relative to the repo root,
+	// with a trailing slash so it can be used as a path prefix.
+	Path string `toml:"path"`
+}
+
+// DefaultProject returns the configuration used when no config file exists.
+func DefaultProject() Project {
+	return Project{
+		Batch:   BatchConfig{GroupBy: "auto", PathDepth: 2},
+		LLM:     LLMConfig{Model: "haiku"},
+		Storage: StorageConfig{Layout: "day", IDScheme: "anchor"},
+		Theme:   ThemeConfig{Background: "auto"},
+		Git:     GitConfig{Backend: "exec"},
+	}
+}
+
+// ProjectPath returns the absolute path of the config file for repoRoot.
+func ProjectPath(repoRoot string) string {
+	return filepath.Join(repoRoot, filepath.FromSlash(ProjectFile))
+}
+
+// LoadProject reads the config file under repoRoot, layering it over
+// DefaultProject. A missing file is not an error.
+func LoadProject(repoRoot string) (Project, error) {
+	cfg := DefaultProject()
+	path := ProjectPath(repoRoot)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("reading %s: %w", ProjectFile, err)
+	}
+	if _, err := toml.Decode(string(data), &cfg); err != nil {
+		return DefaultProject(), fmt.Errorf("parsing %s: %w", ProjectFile, err)
+	}
+	return cfg, nil
+}
+
+// AppendPackageScopes adds a [[scope.packages]] table for each package whose
+// path is not already configured, writing the commented template first when
+// no config exists. Appending keeps any hand edits to the file intact.
+// Returns the packages that were added.
+func AppendPackageScopes(repoRoot string, pkgs []PackageScope) ([]PackageScope, error) {
+	if _, err := WriteProjectTemplate(repoRoot); err != nil {
+		return nil, err
+	}
+	cfg, err := LoadProject(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(cfg.Scope.Packages))
+	for _, pkg := range cfg.Scope.Packages {
+		known[pkg.Path] = true
+	}
+
+	var added []PackageScope
+	var buf strings.Builder
+	for _, pkg := range pkgs {
+		if known[pkg.Path] {
+			continue
+		}
+		known[pkg.Path] = true
+		added = append(added, pkg)
+		fmt.Fprintf(&buf, "\n[[scope.packages]]\nname = %q\npath = %q\n", pkg.Name, pkg.Path)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	path := ProjectPath(repoRoot)
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", ProjectFile, err)
+	}
+	defer file.Close() //nolint:errcheck
+	if _, err := file.WriteString(buf.String()); err != nil {
+		return nil, fmt.Errorf("writing %s: %w", ProjectFile, err)
+	}
+	return added, nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadProject_MissingFileReturnsDefaults(t *testing.T) {
+	cfg, err := LoadProject(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadProject() error = %v", err)
+	}
+	if !reflect.DeepEqual(cfg, DefaultProject()) {
+		t.Errorf("LoadProject() = %+v, want defaults %+v", cfg, DefaultProject())
+	}
+}
+
+func TestProjectTemplate_MatchesDefaults(t *testing.T) {
+	root := t.TempDir()
+	written, err := WriteProjectTemplate(root)
+	if err != nil {
+		t.Fatalf("WriteProjectTemplate() error = %v", err)
+	}
+	if !written {
+		t.Fatal("WriteProjectTemplate() = false, want true on first write")
+	}
+
+	cfg, err := LoadProject(root)
+	if err != nil {
+		t.Fatalf("LoadProject() error = %v", err)
+	}
+	def := DefaultProject()
+	if cfg.Batch != def.Batch || cfg.LLM != def.LLM {
+		t.Errorf("template config = %+v, want defaults %+v", cfg, def)
+	}
+	if len(cfg.Tags.Paths) != 0 || len(cfg.Scope.Packages) != 0 {
+		t.Errorf("template lists should be empty, got %+v", cfg)
+	}
+}
+
+func TestWriteProjectTemplate_KeepsExistingFile(t *testing.T) {
+	root := t.TempDir()
+	path := ProjectPath(root)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	custom := "[llm]\nmodel = \"sonnet\"\n"
+	if err := os.WriteFile(path, []byte(custom), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := WriteProjectTemplate(root)
+	if err != nil {
+		t.Fatalf("WriteProjectTemplate() error = %v", err)
+	}
+	if written {
+		t.Error("WriteProjectTemplate() = true, want false when file exists")
+	}
+
+	cfg, err := LoadProject(root)
+	if err != nil {
+		t.Fatalf("LoadProject() error = %v", err)
+	}
+	if cfg.LLM.Model != "sonnet" {
+		t.Errorf("LLM.Model = %q, want %q", cfg.LLM.Model, "sonnet")
+	}
+	if cfg.Batch.GroupBy != "auto" {
+		t.Errorf("Batch.GroupBy = %q, want default %q", cfg.Batch.GroupBy, "auto")
+	}
+}
+
+func TestLoadProject_MalformedFile(t *testing.T) {
+	root := t.TempDir()
+	path := ProjectPath(root)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("[llm\nmodel ="), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadProject(root)
+	if err == nil {
+		t.Fatal("LoadProject() expected error for malformed TOML")
+	}
+	if !reflect.DeepEqual(cfg, DefaultProject()) {
+		t.Errorf("LoadProject() on error = %+v, want defaults", cfg)
+	}
+}
+
+func TestAppendPackageScopes_Idempotent(t *testing.T) {
+	root := t.TempDir()
+	pkgs := []PackageScope{
+		{Name: "api", Path: "services/api/"},
+		{Name: "web", Path: "services/web/"},
+	}
+
+	added, err := AppendPackageScopes(root, pkgs)
+	if err != nil {
+		t.Fatalf("AppendPackageScopes() error = %v", err)
+	}
+	if len(added) != 2 {
+		t.Fatalf("first call added %d packages, want 2", len(added))
+	}
+
+	added, err = AppendPackageScopes(root, pkgs)
+	if err != nil {
+		t.Fatalf("AppendPackageScopes() second call error = %v", err)
+	}
+	if len(added) != 0 {
+		t.Errorf("second call added %d packages, want 0", len(added))
+	}
+
+	cfg, err := LoadProject(root)
+	if err != nil {
+		t.Fatalf("LoadProject() error = %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Scope.Packages, pkgs) {
+		t.Errorf("Scope.Packages = %+v, want %+v", cfg.Scope.Packages, pkgs)
+	}
+	if path, ok := cfg.PackagePath("web"); !ok || path != "services/web/" {
+		t.Errorf("PackagePath(web) = %q, %v; want services/web/, true", path, ok)
+	}
+	if _, ok := cfg.PackagePath("cli"); ok {
+		t.Error("PackagePath(cli) found a package that is not configured")
+	}
+}
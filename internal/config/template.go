@@ -0,0 +1,163 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ProjectTemplate is the commented config written by `timbers init`.
+// Uncommented values mirror DefaultProject so the scaffolded file is a no-op
+// until the team edits it.
+const ProjectTemplate = `# Timbers project configuration.
+# Every setting is optional; deleting a line restores the built-in default.
+
+# Tags 'timbers log' infers from the files an entry's commits touch, as
+# glob = tag. "**" spans directories; a glob without "/" matches file
+# names anywhere. 'timbers log --dry-run' shows the inferred tags.
+# [tags.paths]
+# "internal/llm/**" = "llm"
+# "docs/**" = "docs"
+
+[batch]
+# How 'timbers log --batch' groups pending commits:
+#   auto         - by Work-item trailer, falling back to day
+#   work-item    - by Work-item trailer only
+#   day          - by author date (YYYY-MM-DD)
+#   author       - by author email
+#   path-prefix  - by the leading directories most of a commit's files share
+#   branch-merge - by the merge that brought the commits onto the mainline
+# 'timbers log --batch --group-by' overrides this setting.
+group_by = "auto"
+# Directories that name a path-prefix group (2: internal/ledger).
+path_depth = 2
+
+[pending]
+# Follow only first parents when finding pending commits and walking
+# --range, so merge-heavy repositories are documented at the merge instead
+# of per merged commit. 'timbers pending/log --first-parent[=false]'
+# overrides this setting.
+first_parent = false
+
+[log]
+# Push the current branch after 'timbers log' writes an entry, so agent
+# sessions never strand an entry locally. 'timbers log --push[=false]'
+# overrides this setting.
+push = false
+
+# Work items 'timbers log' detects in the branch name when no --work-item
+# is given, as regex = system. The first capture group is the ID.
+# 'timbers log --dry-run' shows the detected item.
+# [log.branch_work_items]
+# '^(?:feature|fix)/([A-Z]+-\d+)' = "jira"
+
+[trailers]
+# Commit trailers 'timbers log' copies into entries, keyed by trailer name
+# (case-insensitive). Targets:
+#   work-item          - the value is a work item as system:id
+#   work-item:<system> - the value is an ID in <system>
+#   tag                - the value becomes a tag
+#   notes              - "Key: value" is appended to the entry's notes
+# Ticket = "work-item:jira"
+# Reviewed-by = "notes"
+
+[llm]
+# Default model for 'timbers why', 'timbers review', and 'timbers summarize'
+# when --model is not given (e.g. haiku, sonnet, gemini-flash, gpt-5-nano,
+# local-<name>). 'timbers draft' and 'timbers generate' take --model only.
+model = "haiku"
+# Embedding model for 'timbers search --semantic' and 'timbers why --semantic'
+# (e.g. text-embedding-3-small, gemini-embedding-001, local-nomic-embed-text).
+# Vectors are cached in .git/timbers/, never committed. Empty disables it.
+# embedding_model = "text-embedding-3-small"
+
+# Monorepo packages, one table per team-owned subdirectory. 'timbers
+# pending', 'timbers query', and 'timbers draft' take --package <name> to
+# cover only that directory. 'timbers init --monorepo "packages/*"' appends
+# these for you.
+# [[scope.packages]]
+# name = "api"
+# path = "services/api/"
+
+[hooks]
+# Hook events 'timbers hook run' should ignore, e.g. ["post-commit"] to drop
+# the reminder while keeping the pre-commit gate. Events: pre-commit,
+# post-commit, post-merge, post-rewrite, prepare-commit-msg, commit-msg.
+disabled = []
+
+[storage]
+# How entry files are arranged under .timbers/:
+#   day   - YYYY/MM/DD/<id>.json
+#   month - YYYY/MM/<id>.json
+#   flat  - <id>.json
+#   hash  - <2-char hash shard>/<id>.json (very high-volume ledgers)
+# Existing entries stay readable after a change; 'timbers doctor --fix'
+# moves them into the new layout.
+layout = "day"
+# Validate entries against the JSON Schema on read and write. Files written
+# by other tools with unknown or mistyped fields are rejected (and reported
+# by 'timbers doctor') instead of being partially loaded.
+strict = false
+# Entry ID scheme:
+#   anchor - tb_<time>_<sha> (entries on one commit in one second collide)
+#   random - tb_<time>_<sha>-<random>, for batch and import heavy ledgers
+#   ulid   - tb_<ULID>, time-sorted with millisecond precision and no anchor
+id_scheme = "anchor"
+# Cache parsed entries in .git/timbers/index.db so query, stats, and other
+# listings skip re-reading unchanged files. Worth enabling for ledgers with
+# thousands of entries; the cache is local and never committed.
+index = false
+
+[theme]
+# Palette for human output in a terminal:
+#   auto  - adapt to the terminal's background
+#   dark  - colors for dark backgrounds
+#   light - colors for light backgrounds
+# NO_COLOR (or CLICOLOR=0) turns color off; CLICOLOR_FORCE=1 keeps it on
+# when piped. '--color' overrides both.
+background = "auto"
+# Override single roles with an ANSI code (0-255) or "#rrggbb". Roles:
+# error, success, warning, dim, title, key, accent, border.
+# [theme.colors]
+# title = "33"
+# dim = "#8a8a8a"
+
+[git]
+# How timbers reads the repository:
+#   exec   - run the git executable (default)
+#   go-git - read history in-process, for Windows or containers where
+#            spawning git is slow or git is missing; commits, pushes, and
+#            diffstats still run git. .mailmap is not applied.
+# TIMBERS_GIT_BACKEND overrides this setting.
+backend = "exec"
+# Kill any single git process that runs longer than this (e.g. "30s"), so a
+# hung fetch or credential prompt cannot wedge a command. Unset means no
+# per-process limit; --timeout still bounds the whole command.
+# TIMBERS_GIT_TIMEOUT overrides this setting.
+# timeout = "30s"
+
+[encryption]
+# Public keys 'timbers log --encrypt' seals summaries and notes to, for
+# ledgers mirrored somewhere public. IDs, worksets, and tags stay readable.
+# Reading needs the age CLI and a matching identity file in
+# TIMBERS_AGE_IDENTITY; without one, encrypted entries show as (encrypted).
+# recipients = ["age1..."]
+recipients = []
+`
+
+// WriteProjectTemplate writes ProjectTemplate under repoRoot unless a config
+// file already exists. Returns true when the file was written.
+func WriteProjectTemplate(repoRoot string) (bool, error) {
+	path := ProjectPath(repoRoot)
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, fmt.Errorf("creating %s: %w", filepath.Dir(ProjectFile), err)
+	}
+	// #nosec G306 -- config is a tracked file, needs standard perms
+	if err := os.WriteFile(path, []byte(ProjectTemplate), 0o644); err != nil {
+		return false, fmt.Errorf("writing %s: %w", ProjectFile, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,51 @@
+package draft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// EntryReview is the model's critique of one entry, as produced by the
+// built-in review template.
+type EntryReview struct {
+	ID        string           `json:"id"`
+	Issues    []string         `json:"issues"`
+	Suggested SuggestedSummary `json:"suggested"`
+}
+
+// SuggestedSummary holds replacement summary text. Empty fields mean the
+// current text is fine.
+type SuggestedSummary struct {
+	What string `json:"what,omitempty"`
+	Why  string `json:"why,omitempty"`
+	How  string `json:"how,omitempty"`
+}
+
+// IsEmpty reports whether no field has a suggestion.
+func (s SuggestedSummary) IsEmpty() bool {
+	return s.What == "" && s.Why == "" && s.How == ""
+}
+
+// ParseReviews extracts the JSON array of reviews from a model response.
+// Models often wrap JSON in a code fence or a sentence despite instructions,
+// so everything outside the outermost brackets is ignored.
+func ParseReviews(content string) ([]EntryReview, error) {
+	start := strings.Index(content, "[")
+	end := strings.LastIndex(content, "]")
+	if start < 0 || end < start {
+		return nil, errors.New("response does not contain a JSON array of reviews")
+	}
+
+	var reviews []EntryReview
+	if err := json.Unmarshal([]byte(content[start:end+1]), &reviews); err != nil {
+		return nil, fmt.Errorf("parsing review JSON: %w", err)
+	}
+	for i := range reviews {
+		reviews[i].Suggested.What = strings.TrimSpace(reviews[i].Suggested.What)
+		reviews[i].Suggested.Why = strings.TrimSpace(reviews[i].Suggested.Why)
+		reviews[i].Suggested.How = strings.TrimSpace(reviews[i].Suggested.How)
+	}
+	return reviews, nil
+}
@@ -0,0 +1,53 @@
+package draft
+
+import "testing"
+
+func TestParseReviews(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantCount int
+		wantWhy   string
+		wantErr   bool
+	}{
+		{
+			name:      "bare array",
+			content:   `[{"id":"tb_a","issues":["Vague why"],"suggested":{"why":" Because X "}}]`,
+			wantCount: 1,
+			wantWhy:   "Because X",
+		},
+		{
+			name:      "fenced with preamble",
+			content:   "Here are the reviews:\n```json\n[{\"id\":\"tb_a\",\"issues\":[],\"suggested\":{}}]\n```",
+			wantCount: 1,
+		},
+		{
+			name:    "no array",
+			content: "The entries look fine.",
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			content: `[{"id": }]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviews, err := ParseReviews(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseReviews() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(reviews) != tt.wantCount {
+				t.Fatalf("ParseReviews() returned %d reviews, want %d", len(reviews), tt.wantCount)
+			}
+			if reviews[0].Suggested.Why != tt.wantWhy {
+				t.Errorf("Suggested.Why = %q, want %q", reviews[0].Suggested.Why, tt.wantWhy)
+			}
+		})
+	}
+}
@@ -234,7 +234,8 @@ func TestListBuiltins(t *testing.T) {
 	// Check that expected templates are present
 	expectedNames := []string{
 		"changelog", "decision-digest", "devblog", "standup",
-		"sprint-report", "pr-description", "release-notes",
+		"sprint-report", "pr-description", "release-notes", "why",
+		"narrative-eng", "narrative-exec", "review",
 	}
 	found := make(map[string]bool)
 	for _, tmpl := range templates {
@@ -0,0 +1,189 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// maxSlugLength caps the title part of ADR filenames.
+const maxSlugLength = 50
+
+// ADRIndex numbers decision entries and resolves supersession links between
+// them. Build it from every decision in the ledger, not just the ones being
+// exported, so an ADR keeps its number however the export is filtered.
+type ADRIndex struct {
+	numbers      map[string]int
+	filenames    map[string]string
+	titles       map[string]string
+	supersededBy map[string]string
+}
+
+// NewADRIndex numbers decisions 1..n oldest first (see ledger.Decisions).
+// Entries of other kinds are ignored.
+func NewADRIndex(entries []*ledger.Entry) *ADRIndex {
+	idx := &ADRIndex{
+		numbers:      make(map[string]int),
+		filenames:    make(map[string]string),
+		titles:       make(map[string]string),
+		supersededBy: make(map[string]string),
+	}
+	for i, entry := range ledger.Decisions(entries) {
+		number := i + 1
+		idx.numbers[entry.ID] = number
+		idx.titles[entry.ID] = entry.Summary.What
+		idx.filenames[entry.ID] = fmt.Sprintf("%04d-%s.md", number, slugify(entry.Summary.What))
+		if replaced := entry.SupersedesID(); replaced != "" {
+			idx.supersededBy[replaced] = entry.ID
+		}
+	}
+	return idx
+}
+
+// Number returns the ADR number for an entry ID, or 0 if it is not a
+// decision.
+func (idx *ADRIndex) Number(id string) int {
+	return idx.numbers[id]
+}
+
+// Filename returns the ADR filename (NNNN-slug.md) for an entry ID, or "" if
+// it is not a decision.
+func (idx *ADRIndex) Filename(id string) string {
+	return idx.filenames[id]
+}
+
+// FormatADR renders a decision entry as a Nygard-style ADR: status, context
+// (summary.why), decision (summary.what), consequences (summary.how), plus
+// the alternatives considered and the commits it is anchored to.
+func (idx *ADRIndex) FormatADR(entry *ledger.Entry) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "# %d. %s\n\n", idx.Number(entry.ID), entry.Summary.What)
+	fmt.Fprintf(&builder, "Date: %s\n\n", entry.CreatedAt.Format("2006-01-02"))
+
+	idx.writeStatus(&builder, entry)
+	writeSection(&builder, "Context", entry.Summary.Why)
+	writeSection(&builder, "Decision", entry.Summary.What)
+	writeSection(&builder, "Consequences", entry.Summary.How)
+
+	if entry.Decision != nil && len(entry.Decision.Alternatives) > 0 {
+		builder.WriteString("## Alternatives Considered\n\n")
+		for _, alt := range entry.Decision.Alternatives {
+			fmt.Fprintf(&builder, "- %s\n", alt)
+		}
+		builder.WriteString("\n")
+	}
+	if entry.Notes != "" {
+		writeSection(&builder, "Notes", entry.Notes)
+	}
+
+	builder.WriteString("## Evidence\n\n")
+	fmt.Fprintf(&builder, "- Ledger entry: %s\n", entry.ID)
+	commitCount := len(entry.Workset.Commits)
+	if commitRange := computeCommitRange(entry); commitRange != "" {
+		fmt.Fprintf(&builder, "- Commits: %d (%s)\n", commitCount, commitRange)
+	} else {
+		fmt.Fprintf(&builder, "- Commits: %d\n", commitCount)
+	}
+	if len(entry.Contributors) > 0 {
+		identities := make([]string, len(entry.Contributors))
+		for i, contributor := range entry.Contributors {
+			identities[i] = contributorIdentity(contributor)
+		}
+		fmt.Fprintf(&builder, "- Contributors: %s\n", strings.Join(identities, ", "))
+	}
+	return builder.String()
+}
+
+// writeStatus writes the Status section with supersession links resolved to
+// ADR numbers where the other decision is known.
+func (idx *ADRIndex) writeStatus(builder *strings.Builder, entry *ledger.Entry) {
+	status := ledger.DecisionAccepted
+	if entry.Decision != nil && entry.Decision.Status != "" {
+		status = entry.Decision.Status
+	}
+	if _, replaced := idx.supersededBy[entry.ID]; replaced {
+		status = ledger.DecisionSuperseded
+	}
+
+	builder.WriteString("## Status\n\n")
+	builder.WriteString(capitalize(status) + "\n\n")
+	if replaced := entry.SupersedesID(); replaced != "" {
+		fmt.Fprintf(builder, "Supersedes %s\n\n", idx.link(replaced))
+	}
+	if newer, ok := idx.supersededBy[entry.ID]; ok {
+		fmt.Fprintf(builder, "Superseded by %s\n\n", idx.link(newer))
+	}
+}
+
+// link renders a markdown link to another ADR, or the bare entry ID when
+// that entry is not a known decision.
+func (idx *ADRIndex) link(id string) string {
+	if idx.numbers[id] == 0 {
+		return id
+	}
+	return fmt.Sprintf("[%d. %s](%s)", idx.numbers[id], idx.titles[id], idx.filenames[id])
+}
+
+// writeSection writes a level-2 heading and body, noting when the body is
+// empty rather than dropping the section: ADR readers expect all of them.
+func writeSection(builder *strings.Builder, heading, body string) {
+	if strings.TrimSpace(body) == "" {
+		body = "Not recorded."
+	}
+	fmt.Fprintf(builder, "## %s\n\n%s\n\n", heading, body)
+}
+
+// WriteADRFiles writes each decision in entries as a numbered ADR file to
+// dir. Entries that are not decisions are skipped.
+func WriteADRFiles(entries []*ledger.Entry, idx *ADRIndex, dir string) error {
+	for _, entry := range entries {
+		name := idx.Filename(entry.ID)
+		if name == "" {
+			continue
+		}
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(idx.FormatADR(entry)), 0600); err != nil {
+			return output.NewSystemError(fmt.Sprintf("failed to write file %s: %v", filename, err))
+		}
+	}
+	return nil
+}
+
+// slugify lowercases title and collapses runs of non-alphanumerics to single
+// dashes, for use in filenames.
+func slugify(title string) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && builder.Len() > 0 {
+			builder.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimRight(builder.String(), "-")
+	if runes := []rune(slug); len(runes) > maxSlugLength {
+		slug = strings.TrimRight(string(runes[:maxSlugLength]), "-")
+	}
+	if slug == "" {
+		return "decision"
+	}
+	return slug
+}
+
+// capitalize upper-cases the first letter of s.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,103 @@
+package export
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/ledger"
+)
+
+// testDecision returns a decision entry created at the given day of 2026-01.
+func testDecision(what string, day int) *ledger.Entry {
+	created := time.Date(2026, 1, day, 12, 0, 0, 0, time.UTC)
+	return &ledger.Entry{
+		Schema:    ledger.SchemaVersion,
+		Kind:      ledger.KindDecision,
+		ID:        ledger.GenerateID("abc123def456", created),
+		CreatedAt: created,
+		UpdatedAt: created,
+		Workset:   ledger.Workset{AnchorCommit: "abc123def456", Commits: []string{"abc123def456"}},
+		Summary:   ledger.Summary{What: what, Why: "Context for " + what},
+		Decision:  &ledger.Decision{Status: ledger.DecisionAccepted},
+	}
+}
+
+func TestADRIndex_NumbersAndSupersession(t *testing.T) {
+	older := testDecision("Use MySQL", 2)
+	newer := testDecision("Use Postgres: v16!", 9)
+	newer.Decision.Supersedes = older.ID
+	work := testEntry()
+
+	// Input order and non-decisions must not affect numbering.
+	idx := NewADRIndex([]*ledger.Entry{newer, work, older})
+
+	if got := idx.Filename(older.ID); got != "0001-use-mysql.md" {
+		t.Errorf("older filename = %q, want 0001-use-mysql.md", got)
+	}
+	if got := idx.Filename(newer.ID); got != "0002-use-postgres-v16.md" {
+		t.Errorf("newer filename = %q, want 0002-use-postgres-v16.md", got)
+	}
+	if idx.Number(work.ID) != 0 {
+		t.Errorf("work entry numbered %d, want 0", idx.Number(work.ID))
+	}
+
+	oldADR := idx.FormatADR(older)
+	for _, want := range []string{"# 1. Use MySQL", "Superseded", "Superseded by [2. Use Postgres: v16!](0002-use-postgres-v16.md)"} {
+		if !strings.Contains(oldADR, want) {
+			t.Errorf("older ADR missing %q:\n%s", want, oldADR)
+		}
+	}
+	newADR := idx.FormatADR(newer)
+	for _, want := range []string{"Supersedes [1. Use MySQL](0001-use-mysql.md)", "## Consequences\n\nNot recorded."} {
+		if !strings.Contains(newADR, want) {
+			t.Errorf("newer ADR missing %q:\n%s", want, newADR)
+		}
+	}
+}
+
+func TestFormatADR_Contributors(t *testing.T) {
+	decision := testDecision("Use Postgres", 2)
+	decision.Contributors = []ledger.Contributor{
+		{Name: "Alice", Email: "alice@example.com"},
+		{Name: "Bob", Email: "bob@example.com"},
+	}
+
+	adr := NewADRIndex([]*ledger.Entry{decision}).FormatADR(decision)
+
+	if want := "- Contributors: Alice <alice@example.com>, Bob <bob@example.com>\n"; !strings.Contains(adr, want) {
+		t.Errorf("ADR missing %q:\n%s", want, adr)
+	}
+}
+
+func TestWriteADRFiles_SkipsNonDecisions(t *testing.T) {
+	dir := t.TempDir()
+	decision := testDecision("Adopt ULIDs", 3)
+	entries := []*ledger.Entry{decision, testEntry()}
+
+	if err := WriteADRFiles(entries, NewADRIndex(entries), dir); err != nil {
+		t.Fatalf("WriteADRFiles() error = %v", err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name() != "0001-adopt-ulids.md" {
+		t.Fatalf("files = %v, want only 0001-adopt-ulids.md", files)
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Use Postgres":            "use-postgres",
+		"  --Weird!! Title--  ":   "weird-title",
+		"???":                     "decision",
+		strings.Repeat("ab ", 40): "ab-ab-ab-ab-ab-ab-ab-ab-ab-ab-ab-ab-ab-ab-ab-ab-ab",
+	}
+	for in, want := range tests {
+		if got := slugify(in); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
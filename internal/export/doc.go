@@ -5,10 +5,11 @@
 //
 // # Supported Formats
 //
-// The package supports two output formats:
+// The package supports three output formats:
 //
 //   - JSON: Machine-readable format preserving the full entry schema
 //   - Markdown: Human-readable format with YAML frontmatter
+//   - ADR: Numbered architecture decision records for decision entries
 //
 // # JSON Export
 //
@@ -17,7 +18,7 @@
 //	export.FormatJSON(printer, entries)           // Write to printer
 //	export.WriteJSONFiles(entries, "/path/to/dir") // Write individual files
 //
-// Each entry is written with the full timbers.devlog/v1 schema, suitable
+// Each entry is written with the full timbers.devlog schema, suitable
 // for consumption by other tools or data pipelines.
 //
 // # Markdown Export
@@ -55,9 +56,23 @@
 //	- Commits: 3 (abc1234..def5678)
 //	- Files changed: 8 (+245/-12)
 //
+// Other kinds label the summary sections by what the fields hold: a decision
+// has Decision/Context/Consequences, an incident What happened/Impact/
+// Resolution, and a milestone Milestone/Why it matters. Entries with
+// relations gain a Related section before the evidence.
+//
+// # ADR Export
+//
+// Decision entries render as Nygard-style ADRs. Numbers are assigned oldest
+// first across every decision passed to NewADRIndex:
+//
+//	idx := export.NewADRIndex(allEntries)
+//	export.WriteADRFiles(decisions, idx, "docs/adr") // 0001-use-postgres.md, ...
+//
 // # File Naming
 //
 // When writing to files, entries are named by their ID:
 //   - JSON: <entry-id>.json
 //   - Markdown: <entry-id>.md
+//   - ADR: NNNN-<slugified-what>.md
 package export
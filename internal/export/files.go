@@ -0,0 +1,54 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gorewood/timbers/internal/ledger"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// writeEntryFiles writes each entry to <dir>/<entry-id><ext> using render.
+// A failed entry does not stop the rest: when some entries fail, it returns
+// a partial error (exit 4) listing every entry's outcome; when all fail, it
+// returns the first failure.
+func writeEntryFiles(entries []*ledger.Entry, dir, ext string, render func(*ledger.Entry) ([]byte, error)) error {
+	items := make([]output.ItemResult, 0, len(entries))
+	var firstErr error
+	for _, entry := range entries {
+		filename := filepath.Join(dir, entry.ID+ext)
+		err := writeEntryFile(entry, filename, render)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			items = append(items, output.ItemFailedWith(entry.ID, err))
+			continue
+		}
+		items = append(items, output.ItemSucceeded(entry.ID, filename))
+	}
+
+	partial := &output.PartialError{Items: items}
+	switch failed := partial.Failed(); {
+	case failed == 0:
+		return nil
+	case failed == len(items):
+		return firstErr
+	default:
+		return output.NewPartialError(
+			fmt.Sprintf("exported %d of %d entries; %d failed", len(items)-failed, len(items), failed), items)
+	}
+}
+
+// writeEntryFile renders one entry and writes it to filename.
+func writeEntryFile(entry *ledger.Entry, filename string, render func(*ledger.Entry) ([]byte, error)) error {
+	data, err := render(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, data, 0600); err != nil {
+		return output.NewSystemError(fmt.Sprintf("failed to write file %s: %v", filename, err))
+	}
+	return nil
+}
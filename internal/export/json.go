@@ -4,8 +4,6 @@ package export
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/gorewood/timbers/internal/ledger"
 	"github.com/gorewood/timbers/internal/output"
@@ -17,22 +15,14 @@ func FormatJSON(printer *output.Printer, entries []*ledger.Entry) error {
 }
 
 // WriteJSONFiles writes each entry as a separate JSON file to the output directory.
-// Files are named <entry-id>.json.
+// Files are named <entry-id>.json. See writeEntryFiles for how failures are
+// reported.
 func WriteJSONFiles(entries []*ledger.Entry, dir string) error {
-	for _, entry := range entries {
-		filename := filepath.Join(dir, entry.ID+".json")
-
-		// Marshal entry to JSON
+	return writeEntryFiles(entries, dir, ".json", func(entry *ledger.Entry) ([]byte, error) {
 		data, err := json.MarshalIndent(entry, "", "  ")
 		if err != nil {
-			return output.NewSystemError(fmt.Sprintf("failed to marshal entry %s: %v", entry.ID, err))
-		}
-
-		// Write to file
-		if err := os.WriteFile(filename, data, 0600); err != nil {
-			return output.NewSystemError(fmt.Sprintf("failed to write file %s: %v", filename, err))
+			return nil, output.NewSystemError(fmt.Sprintf("failed to marshal entry %s: %v", entry.ID, err))
 		}
-	}
-
-	return nil
+		return data, nil
+	})
 }
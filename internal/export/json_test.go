@@ -12,10 +12,10 @@ import (
 	"github.com/gorewood/timbers/internal/output"
 )
 
-// testEntry creates a fully populated entry for testing.
+// testEntry creates a fully populated timbers.devlog/v1 entry for testing.
 func testEntry() *ledger.Entry {
 	return &ledger.Entry{
-		Schema:    ledger.SchemaVersion,
+		Schema:    "timbers.devlog/v1",
 		Kind:      ledger.KindEntry,
 		ID:        "tb_2026-01-15T15:04:05Z_8f2c1a",
 		CreatedAt: time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC),
@@ -42,10 +42,10 @@ func testEntry() *ledger.Entry {
 	}
 }
 
-// minimalEntry creates an entry with only required fields.
+// minimalEntry creates a timbers.devlog/v1 entry with only required fields.
 func minimalEntry() *ledger.Entry {
 	return &ledger.Entry{
-		Schema:    ledger.SchemaVersion,
+		Schema:    "timbers.devlog/v1",
 		Kind:      ledger.KindEntry,
 		ID:        "tb_2026-01-15T15:04:05Z_abc123",
 		CreatedAt: time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC),
@@ -161,6 +161,37 @@ func TestFormatJSON(t *testing.T) {
 	}
 }
 
+// TestFormatJSON_MigratedV1Entry checks a v1 entry exports at the current
+// schema once migrated.
+func TestFormatJSON_MigratedV1Entry(t *testing.T) {
+	v1, err := testEntry().ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, changed, err := ledger.MigrateRecord(v1, "v2")
+	if err != nil || !changed {
+		t.Fatalf("MigrateRecord() = changed %v, %v", changed, err)
+	}
+	entry, err := ledger.FromJSON(migrated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := FormatJSON(output.NewPrinter(&buf, true, false), []*ledger.Entry{entry}); err != nil {
+		t.Fatalf("FormatJSON() error = %v", err)
+	}
+	for _, field := range []string{
+		`"schema": "timbers.devlog/v2"`,
+		`"id": "tb_2026-01-15T15:04:05Z_8f2c1a"`,
+		`"what": "Fixed authentication bypass vulnerability"`,
+	} {
+		if !containsString(buf.String(), field) {
+			t.Errorf("FormatJSON() output missing expected field: %s\nGot: %s", field, buf.String())
+		}
+	}
+}
+
 func TestFormatJSON_NilEntries(t *testing.T) {
 	var buf bytes.Buffer
 	printer := output.NewPrinter(&buf, true, false)
@@ -3,12 +3,11 @@ package export
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/gorewood/timbers/internal/ledger"
-	"github.com/gorewood/timbers/internal/output"
 )
 
 // FormatMarkdown formats a single entry as a markdown document.
@@ -18,6 +17,7 @@ func FormatMarkdown(entry *ledger.Entry) string {
 
 	writeFrontmatter(&builder, entry)
 	writeSummary(&builder, entry)
+	writeRelations(&builder, entry)
 	writeEvidence(&builder, entry)
 
 	return builder.String()
@@ -28,11 +28,22 @@ func writeFrontmatter(builder *strings.Builder, entry *ledger.Entry) {
 	builder.WriteString("---\n")
 	builder.WriteString("schema: timbers.export/v1\n")
 	fmt.Fprintf(builder, "id: %s\n", entry.ID)
+	if kind := entry.KindOrDefault(); kind != ledger.KindEntry {
+		fmt.Fprintf(builder, "kind: %s\n", kind)
+	}
 
 	// Format date as YYYY-MM-DD
 	dateStr := entry.CreatedAt.Format("2006-01-02")
 	fmt.Fprintf(builder, "date: %s\n", dateStr)
 
+	// When the work began, and hours from then until the entry was logged
+	if started := entry.Started(); !started.IsZero() {
+		fmt.Fprintf(builder, "started: %s\n", started.Format("2006-01-02"))
+	}
+	if cycle, ok := entry.CycleTime(); ok {
+		fmt.Fprintf(builder, "cycle_time_hours: %.1f\n", cycle.Hours())
+	}
+
 	// Anchor commit short SHA
 	shortSHA := entry.Workset.AnchorCommit
 	if len(shortSHA) > 12 {
@@ -47,15 +58,72 @@ func writeFrontmatter(builder *strings.Builder, entry *ledger.Entry) {
 		fmt.Fprintf(builder, "tags: [%s]\n", strings.Join(entry.Tags, ", "))
 	}
 
+	// Contributors, as Name <email>
+	if len(entry.Contributors) > 0 {
+		builder.WriteString("contributors:\n")
+		for _, contributor := range entry.Contributors {
+			fmt.Fprintf(builder, "  - %q\n", contributorIdentity(contributor))
+		}
+	}
+
+	// Custom meta fields, by key
+	if len(entry.Meta) > 0 {
+		builder.WriteString("meta:\n")
+		for _, key := range slices.Sorted(maps.Keys(entry.Meta)) {
+			fmt.Fprintf(builder, "  %s: %s\n", key, entry.Meta[key])
+		}
+	}
+
 	builder.WriteString("---\n\n")
 }
 
-// writeSummary writes the title and What/Why/How sections.
+// summaryLabel names the what/why/how sections for one kind of entry.
+type summaryLabel struct {
+	what, why, how string
+}
+
+// summaryLabels names the summary sections by what each field means for the
+// kind: an incident's why is its impact, a decision's how its consequences.
+// Kinds not listed use What/Why/How.
+var summaryLabels = map[string]summaryLabel{
+	ledger.KindDecision:  {what: "Decision", why: "Context", how: "Consequences"},
+	ledger.KindIncident:  {what: "What happened", why: "Impact", how: "Resolution"},
+	ledger.KindMilestone: {what: "Milestone", why: "Why it matters", how: "How"},
+	ledger.KindNote:      {what: "Note", why: "Why", how: "How"},
+}
+
+// writeSummary writes the title and the what/why/how sections, labelled for
+// the entry's kind (see summaryLabels). Why and How are omitted when a kind
+// that does not require them left them empty.
 func writeSummary(builder *strings.Builder, entry *ledger.Entry) {
+	kind := entry.KindOrDefault()
+	label, ok := summaryLabels[kind]
+	if !ok {
+		label = summaryLabel{what: "What", why: "Why", how: "How"}
+	}
 	fmt.Fprintf(builder, "# %s\n\n", entry.Summary.What)
-	fmt.Fprintf(builder, "**What:** %s\n\n", entry.Summary.What)
-	fmt.Fprintf(builder, "**Why:** %s\n\n", entry.Summary.Why)
-	fmt.Fprintf(builder, "**How:** %s\n\n", entry.Summary.How)
+	fmt.Fprintf(builder, "**%s:** %s\n\n", label.what, entry.Summary.What)
+	if entry.Summary.Why != "" || ledger.RequiresWhy(kind) {
+		fmt.Fprintf(builder, "**%s:** %s\n\n", label.why, entry.Summary.Why)
+	}
+	if entry.Summary.How != "" || ledger.RequiresHow(kind) {
+		fmt.Fprintf(builder, "**%s:** %s\n\n", label.how, entry.Summary.How)
+	}
+}
+
+// writeRelations writes the Related section listing the entries this one
+// supersedes, fixes, or relates to. Omitted when there are none.
+func writeRelations(builder *strings.Builder, entry *ledger.Entry) {
+	if len(entry.Relations) == 0 {
+		return
+	}
+	builder.WriteString("## Related\n\n")
+	for _, relType := range ledger.RelationTypes {
+		for _, id := range entry.RelationIDs(relType) {
+			fmt.Fprintf(builder, "- %s: %s\n", relType, id)
+		}
+	}
+	builder.WriteString("\n")
 }
 
 // writeEvidence writes the Evidence section with commits and diffstat.
@@ -72,10 +140,86 @@ func writeEvidence(builder *strings.Builder, entry *ledger.Entry) {
 	builder.WriteString("\n")
 
 	if entry.Workset.Diffstat != nil {
-		fmt.Fprintf(builder, "- Files changed: %d (+%d/-%d)\n",
+		fmt.Fprintf(builder, "- Files changed: %d (+%d/-%d)",
 			entry.Workset.Diffstat.Files,
 			entry.Workset.Diffstat.Insertions,
 			entry.Workset.Diffstat.Deletions)
+		writeExcludedCounts(builder, entry.Workset.Diffstat)
+		writeFileChanges(builder, entry.Workset)
+	}
+
+	if signed, known := entry.Workset.SignedCount(); known > 0 {
+		fmt.Fprintf(builder, "- Signed commits: %d/%d\n", signed, known)
+		writeUnsignedCommits(builder, entry.Workset.CommitMeta)
+	}
+}
+
+// writeFileChanges lists the files under the Files changed line: every file
+// with its line counts when the entry carries a per-file breakdown
+// (log --numstat), otherwise the renames and the recorded file paths.
+func writeFileChanges(builder *strings.Builder, workset ledger.Workset) {
+	diffstat := workset.Diffstat
+	if len(diffstat.PerFile) == 0 {
+		for _, rename := range diffstat.Renames {
+			fmt.Fprintf(builder, "  - renamed: %s → %s\n", rename.From, rename.To)
+		}
+		writeFilePaths(builder, workset.Files, diffstat.Renames)
+		return
+	}
+	for _, file := range diffstat.PerFile {
+		path := file.Path
+		if file.From != "" {
+			path = file.From + " → " + file.Path
+		}
+		switch {
+		case file.Generated:
+			fmt.Fprintf(builder, "  - %s (generated)\n", path)
+		case file.Binary:
+			fmt.Fprintf(builder, "  - %s (binary)\n", path)
+		default:
+			fmt.Fprintf(builder, "  - %s (+%d/-%d)\n", path, file.Insertions, file.Deletions)
+		}
+	}
+}
+
+// writeFilePaths lists the recorded workset files, leaving out rename
+// targets already listed.
+func writeFilePaths(builder *strings.Builder, files []string, renames []ledger.Rename) {
+	for _, file := range files {
+		if !slices.ContainsFunc(renames, func(rename ledger.Rename) bool { return rename.To == file }) {
+			fmt.Fprintf(builder, "  - %s\n", file)
+		}
+	}
+}
+
+// writeExcludedCounts finishes the Files changed line with the binary and
+// generated files that contribute no line counts.
+func writeExcludedCounts(builder *strings.Builder, diffstat *ledger.Diffstat) {
+	var excluded []string
+	if diffstat.BinaryFiles > 0 {
+		excluded = append(excluded, fmt.Sprintf("%d binary", diffstat.BinaryFiles))
+	}
+	if diffstat.GeneratedFiles > 0 {
+		excluded = append(excluded, fmt.Sprintf("%d generated", diffstat.GeneratedFiles))
+	}
+	if len(excluded) > 0 {
+		fmt.Fprintf(builder, "; %s not counted", strings.Join(excluded, ", "))
+	}
+	builder.WriteString("\n")
+}
+
+// writeUnsignedCommits lists commits whose signature did not verify, so a
+// reviewer can see exactly which provenance is missing.
+func writeUnsignedCommits(builder *strings.Builder, meta []ledger.CommitMeta) {
+	for _, commit := range meta {
+		if commit.Signature == nil || commit.Signature.Valid() {
+			continue
+		}
+		sha := commit.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		fmt.Fprintf(builder, "  - %s: %s\n", sha, commit.Signature.Status)
 	}
 }
 
@@ -105,19 +249,15 @@ func computeCommitRange(entry *ledger.Entry) string {
 }
 
 // WriteMarkdownFiles writes each entry as a separate markdown file to the output directory.
-// Files are named <entry-id>.md.
+// Files are named <entry-id>.md. See writeEntryFiles for how failures are
+// reported.
 func WriteMarkdownFiles(entries []*ledger.Entry, dir string) error {
-	for _, entry := range entries {
-		filename := filepath.Join(dir, entry.ID+".md")
-
-		// Format entry
-		content := FormatMarkdown(entry)
-
-		// Write to file
-		if err := os.WriteFile(filename, []byte(content), 0600); err != nil {
-			return output.NewSystemError(fmt.Sprintf("failed to write file %s: %v", filename, err))
-		}
-	}
+	return writeEntryFiles(entries, dir, ".md", func(entry *ledger.Entry) ([]byte, error) {
+		return []byte(FormatMarkdown(entry)), nil
+	})
+}
 
-	return nil
+// contributorIdentity formats a contributor as "Name <email>".
+func contributorIdentity(contributor ledger.Contributor) string {
+	return contributor.Name + " <" + contributor.Email + ">"
 }
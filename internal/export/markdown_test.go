@@ -179,6 +179,103 @@ func TestFormatMarkdown_NoDiffstatField(t *testing.T) {
 	}
 }
 
+func TestFormatMarkdown_SignedCommits(t *testing.T) {
+	entry := minimalEntry()
+	entry.Workset.Commits = []string{"aaaaaaa1111", "bbbbbbb2222"}
+	entry.Workset.CommitMeta = []ledger.CommitMeta{
+		{SHA: "aaaaaaa1111", Signature: &ledger.CommitSignature{Status: "good", Key: "ABCD"}},
+		{SHA: "bbbbbbb2222", Signature: &ledger.CommitSignature{Status: "unsigned"}},
+	}
+
+	result := FormatMarkdown(entry)
+
+	for _, want := range []string{"- Signed commits: 1/2", "  - bbbbbbb: unsigned"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("FormatMarkdown() missing %q\nGot:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "aaaaaaa: good") {
+		t.Errorf("FormatMarkdown() should not list validly signed commits\nGot:\n%s", result)
+	}
+	if strings.Contains(FormatMarkdown(minimalEntry()), "Signed commits") {
+		t.Error("FormatMarkdown() should omit the signature line when no signature data was captured")
+	}
+}
+
+func TestFormatMarkdown_Renames(t *testing.T) {
+	entry := minimalEntry()
+	entry.Workset.Diffstat = &ledger.Diffstat{
+		Files:   1,
+		Renames: []ledger.Rename{{From: "old.go", To: "new.go"}},
+	}
+
+	result := FormatMarkdown(entry)
+
+	if !strings.Contains(result, "  - renamed: old.go → new.go") {
+		t.Errorf("FormatMarkdown() should list renamed files\nGot:\n%s", result)
+	}
+}
+
+func TestFormatMarkdown_WorksetFiles(t *testing.T) {
+	entry := minimalEntry()
+	entry.Workset.Diffstat = &ledger.Diffstat{
+		Files:   2,
+		Renames: []ledger.Rename{{From: "old.go", To: "new.go"}},
+	}
+	entry.Workset.Files = []string{"main.go", "new.go"}
+
+	result := FormatMarkdown(entry)
+
+	if !strings.Contains(result, "  - renamed: old.go → new.go\n  - main.go\n") {
+		t.Errorf("FormatMarkdown() should list workset files after renames\nGot:\n%s", result)
+	}
+	if strings.Count(result, "new.go") != 1 {
+		t.Errorf("FormatMarkdown() should list a renamed file once\nGot:\n%s", result)
+	}
+}
+
+func TestFormatMarkdown_PerFile(t *testing.T) {
+	entry := minimalEntry()
+	entry.Workset.Diffstat = &ledger.Diffstat{
+		Files:      3,
+		Insertions: 7,
+		Deletions:  2,
+		Renames:    []ledger.Rename{{From: "old.go", To: "new.go"}},
+		PerFile: []ledger.FileStat{
+			{Path: "main.go", Insertions: 7, Deletions: 2},
+			{Path: "new.go", From: "old.go"},
+			{Path: "logo.png", Binary: true},
+		},
+	}
+
+	result := FormatMarkdown(entry)
+
+	for _, want := range []string{
+		"  - main.go (+7/-2)\n",
+		"  - old.go → new.go (+0/-0)\n",
+		"  - logo.png (binary)\n",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("FormatMarkdown() missing %q\nGot:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "renamed:") {
+		t.Errorf("FormatMarkdown() should not list renames twice when per-file stats exist\nGot:\n%s", result)
+	}
+}
+
+func TestFormatMarkdown_ExcludedFiles(t *testing.T) {
+	entry := minimalEntry()
+	entry.Workset.Diffstat = &ledger.Diffstat{Files: 5, Insertions: 10, Deletions: 2, BinaryFiles: 1, GeneratedFiles: 2}
+
+	result := FormatMarkdown(entry)
+
+	want := "- Files changed: 5 (+10/-2); 1 binary, 2 generated not counted\n"
+	if !strings.Contains(result, want) {
+		t.Errorf("FormatMarkdown() missing %q\nGot:\n%s", want, result)
+	}
+}
+
 func TestComputeCommitRange(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -407,3 +504,102 @@ func TestFormatMarkdown_AnchorCommitTruncation(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatMarkdown_Relations(t *testing.T) {
+	entry := minimalEntry()
+	entry.Relations = []ledger.Relation{
+		{Type: ledger.RelationRelatesTo, ID: "tb_2026-01-10T10:00:00Z_cccccc"},
+		{Type: ledger.RelationSupersedes, ID: "tb_2026-01-05T10:00:00Z_aaaaaa"},
+	}
+
+	result := FormatMarkdown(entry)
+
+	want := "## Related\n\n- supersedes: tb_2026-01-05T10:00:00Z_aaaaaa\n- relates-to: tb_2026-01-10T10:00:00Z_cccccc\n\n## Evidence"
+	if !strings.Contains(result, want) {
+		t.Errorf("FormatMarkdown() missing related section %q\nGot:\n%s", want, result)
+	}
+	if strings.Contains(FormatMarkdown(minimalEntry()), "## Related") {
+		t.Error("FormatMarkdown() should omit the related section without relations")
+	}
+}
+
+func TestFormatMarkdown_KindLabels(t *testing.T) {
+	tests := []struct {
+		kind string
+		want []string
+		omit string
+	}{
+		{ledger.KindEntry, []string{"**What:**", "**Why:**", "**How:**"}, ""},
+		{ledger.KindDecision, []string{"**Decision:**", "**Context:**", "**Consequences:**"}, "**What:**"},
+		{ledger.KindIncident, []string{"**What happened:**", "**Impact:**", "**Resolution:**"}, "**What:**"},
+		{ledger.KindMilestone, []string{"**Milestone:**", "**Why it matters:**"}, "**How:**"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			entry := minimalEntry()
+			entry.Kind = tt.kind
+			if tt.kind == ledger.KindMilestone {
+				entry.Summary.How = ""
+			}
+
+			result := FormatMarkdown(entry)
+
+			for _, want := range tt.want {
+				if !strings.Contains(result, want) {
+					t.Errorf("FormatMarkdown() missing %q\nGot:\n%s", want, result)
+				}
+			}
+			if tt.omit != "" && strings.Contains(result, tt.omit) {
+				t.Errorf("FormatMarkdown() should not contain %q\nGot:\n%s", tt.omit, result)
+			}
+		})
+	}
+}
+
+func TestFormatMarkdown_Meta(t *testing.T) {
+	entry := minimalEntry()
+	entry.Meta = map[string]string{"service": "api", "risk": "low"}
+
+	result := FormatMarkdown(entry)
+
+	if want := "meta:\n  risk: low\n  service: api\n---"; !strings.Contains(result, want) {
+		t.Errorf("FormatMarkdown() missing meta frontmatter %q\nGot:\n%s", want, result)
+	}
+	if strings.Contains(FormatMarkdown(minimalEntry()), "meta:") {
+		t.Error("FormatMarkdown() should omit meta without fields")
+	}
+}
+
+func TestFormatMarkdown_CycleTime(t *testing.T) {
+	entry := minimalEntry()
+	started := entry.CreatedAt.Add(-36 * time.Hour)
+	entry.StartedAt = &started
+
+	result := FormatMarkdown(entry)
+
+	for _, want := range []string{"started: 2026-01-14\n", "cycle_time_hours: 36.0\n"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("FormatMarkdown() missing %q\nGot:\n%s", want, result)
+		}
+	}
+	if strings.Contains(FormatMarkdown(minimalEntry()), "cycle_time_hours:") {
+		t.Error("FormatMarkdown() should omit cycle time without a start")
+	}
+}
+
+func TestFormatMarkdown_Contributors(t *testing.T) {
+	entry := minimalEntry()
+	entry.Contributors = []ledger.Contributor{
+		{Name: "Alice", Email: "alice@example.com", Sources: []string{ledger.ContributorSourceGitAuthor}},
+		{Name: "Pair Bot", Email: "bot@example.com", Sources: []string{ledger.ContributorSourceCoAuthoredBy}},
+	}
+
+	result := FormatMarkdown(entry)
+
+	if want := "contributors:\n  - \"Alice <alice@example.com>\"\n  - \"Pair Bot <bot@example.com>\"\n"; !strings.Contains(result, want) {
+		t.Errorf("FormatMarkdown() missing contributors frontmatter %q\nGot:\n%s", want, result)
+	}
+	if strings.Contains(FormatMarkdown(minimalEntry()), "contributors:") {
+		t.Error("FormatMarkdown() should omit contributors when there are none")
+	}
+}
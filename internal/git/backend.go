@@ -0,0 +1,75 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Backend names, as accepted by NewBackend and the [git] backend setting.
+const (
+	BackendExec  = "exec"   // shell out to the git executable (default)
+	BackendGoGit = "go-git" // read the repository in-process with go-git
+)
+
+// Backend implements the repository reads that every command relies on:
+// locating the repository, resolving refs, and walking history. The
+// package-level functions of the same names dispatch to the installed
+// backend (see SetBackend). Everything else — writes, pushes,
+// diffstats, status — always runs the git executable.
+type Backend interface {
+	// Name returns the backend's name (BackendExec or BackendGoGit).
+	Name() string
+	IsRepo(ctx context.Context) bool
+	RepoRoot(ctx context.Context) (string, error)
+	HEAD(ctx context.Context) (string, error)
+	ResolveCommit(ctx context.Context, ref string) (string, error)
+	// Log returns fromRef..toRef, newest first, following only first
+	// parents when firstParent is set.
+	Log(ctx context.Context, fromRef, toRef string, firstParent bool) ([]Commit, error)
+	// CommitsReachableFrom returns every commit reachable from ref, newest
+	// first, following only first parents when firstParent is set.
+	CommitsReachableFrom(ctx context.Context, ref string, firstParent bool) ([]Commit, error)
+	IsAncestorOf(ctx context.Context, ancestor, descendant string) bool
+	CommitFiles(ctx context.Context, sha string) ([]string, error)
+}
+
+var (
+	backendMu sync.RWMutex
+	backend   Backend = execBackend{}
+)
+
+// NewBackend returns the backend named name; "" means BackendExec.
+func NewBackend(name string) (Backend, error) {
+	switch name {
+	case "", BackendExec:
+		return execBackend{}, nil
+	case BackendGoGit:
+		return goGitBackend{}, nil
+	}
+	return nil, fmt.Errorf("unknown git backend %q: want %s or %s", name, BackendExec, BackendGoGit)
+}
+
+// SetBackend installs b for the dispatching functions. A nil b restores the
+// exec backend. The CLI sets it once per command from the project config.
+func SetBackend(b Backend) {
+	if b == nil {
+		b = execBackend{}
+	}
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	backend = b
+}
+
+// CurrentBackend returns the installed backend.
+func CurrentBackend() Backend {
+	backendMu.RLock()
+	defer backendMu.RUnlock()
+	return backend
+}
+
+// execBackend runs the git executable for every operation.
+type execBackend struct{}
+
+// Name implements Backend.
+func (execBackend) Name() string { return BackendExec }
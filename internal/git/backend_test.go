@@ -0,0 +1,182 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// setupBackendRepo builds a repository with a merged side branch, a tag,
+// and a co-authored commit, chdirs into it, and returns the SHA of HEAD~1
+// on the first-parent line.
+func setupBackendRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	setupGitRepoWithCommit(t, dir)
+	// Distinct commit times keep the newest-first order unambiguous.
+	tick := 0
+	must := func(args ...string) string {
+		t.Helper()
+		tick++
+		stamp := fmt.Sprintf("2026-01-01T00:%02d:00Z", tick)
+		t.Setenv("GIT_AUTHOR_DATE", stamp)
+		t.Setenv("GIT_COMMITTER_DATE", stamp)
+		out, err := Run(args...)
+		if err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+		return out
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("a.txt", "a\n")
+	must("add", "a.txt")
+	must("commit", "-m", "Add a\n\nLonger body.\n\nCo-authored-by: Pat Doe <pat@example.com>")
+	base := must("rev-parse", "HEAD")
+	must("tag", "-a", "v1", "-m", "release")
+
+	must("checkout", "-b", "side")
+	write("b.txt", "b\n")
+	must("add", "b.txt")
+	must("commit", "-m", "Add b")
+	must("checkout", "-")
+	write("a.txt", "a2\n")
+	write("c.txt", "c\n")
+	must("add", ".")
+	must("commit", "-m", "Change a, add c")
+	must("merge", "--no-ff", "side", "-m", "Merge side")
+	return base
+}
+
+// onBoth runs the same call against the go-git and exec backends, failing
+// the test if either errors.
+func onBoth[T any](t *testing.T, run func(Backend) (T, error)) (T, T) {
+	t.Helper()
+	want, err := run(execBackend{})
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	got, err := run(goGitBackend{})
+	if err != nil {
+		t.Fatalf("go-git: %v", err)
+	}
+	return got, want
+}
+
+func TestBackendsAgree(t *testing.T) {
+	base := setupBackendRepo(t)
+	ctx := t.Context()
+	execB, goGitB := execBackend{}, goGitBackend{}
+
+	equal := func(name string, got, want any) {
+		t.Helper()
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: go-git = %#v, exec = %#v", name, got, want)
+		}
+	}
+
+	equal("IsRepo", goGitB.IsRepo(ctx), execB.IsRepo(ctx))
+	gotRoot, wantRoot := onBoth(t, func(b Backend) (string, error) { return b.RepoRoot(ctx) })
+	gotRoot, _ = filepath.EvalSymlinks(gotRoot)
+	wantRoot, _ = filepath.EvalSymlinks(wantRoot)
+	equal("RepoRoot", gotRoot, wantRoot)
+	gotHead, wantHead := onBoth(t, func(b Backend) (string, error) { return b.HEAD(ctx) })
+	equal("HEAD", gotHead, wantHead)
+	for _, ref := range []string{"HEAD~1", "v1", base[:10], "side"} {
+		got, want := onBoth(t, func(b Backend) (string, error) { return b.ResolveCommit(ctx, ref) })
+		equal("ResolveCommit "+ref, got, want)
+	}
+	for _, firstParent := range []bool{false, true} {
+		got, want := onBoth(t, func(b Backend) ([]Commit, error) { return b.Log(ctx, base, "HEAD", firstParent) })
+		equal("Log", shas(got), shas(want))
+	}
+	for _, firstParent := range []bool{false, true} {
+		got, want := onBoth(t, func(b Backend) ([]Commit, error) { return b.CommitsReachableFrom(ctx, "HEAD", firstParent) })
+		equal("CommitsReachableFrom", shas(got), shas(want))
+	}
+	for _, ref := range []string{base, "HEAD", "HEAD^2", "HEAD^1"} {
+		got, want := onBoth(t, func(b Backend) ([]string, error) { return b.CommitFiles(ctx, ref) })
+		equal("CommitFiles "+ref, got, want)
+	}
+	equal("IsAncestorOf", goGitB.IsAncestorOf(ctx, base, "HEAD"), execB.IsAncestorOf(ctx, base, "HEAD"))
+	equal("IsAncestorOf reverse", goGitB.IsAncestorOf(ctx, "HEAD", base), execB.IsAncestorOf(ctx, "HEAD", base))
+	equal("IsAncestorOf self", goGitB.IsAncestorOf(ctx, base, base), execB.IsAncestorOf(ctx, base, base))
+}
+
+func TestGoGitCommitFields(t *testing.T) {
+	base := setupBackendRepo(t)
+	ctx := t.Context()
+	want, err := execBackend{}.CommitsReachableFrom(ctx, base, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := goGitBackend{}.CommitsReachableFrom(ctx, base, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("go-git returned %d commits, exec %d", len(got), len(want))
+	}
+	for idx := range want {
+		got[idx].Date, want[idx].Date = got[idx].Date.UTC(), want[idx].Date.UTC()
+		got[idx].CommitDate, want[idx].CommitDate = got[idx].CommitDate.UTC(), want[idx].CommitDate.UTC()
+		if !reflect.DeepEqual(got[idx], want[idx]) {
+			t.Errorf("commit %d: go-git = %+v\nexec = %+v", idx, got[idx], want[idx])
+		}
+	}
+}
+
+func TestSetBackend(t *testing.T) {
+	t.Cleanup(func() { SetBackend(nil) })
+	for _, name := range []string{"", BackendExec, BackendGoGit} {
+		b, err := NewBackend(name)
+		if err != nil {
+			t.Fatalf("NewBackend(%q) error = %v", name, err)
+		}
+		SetBackend(b)
+		if want := map[bool]string{true: BackendExec, false: name}[name == ""]; CurrentBackend().Name() != want {
+			t.Errorf("CurrentBackend().Name() = %q, want %q", CurrentBackend().Name(), want)
+		}
+	}
+	if _, err := NewBackend("libgit2"); err == nil {
+		t.Error("NewBackend(libgit2) should fail")
+	}
+	SetBackend(nil)
+	if CurrentBackend().Name() != BackendExec {
+		t.Errorf("SetBackend(nil) left %q installed", CurrentBackend().Name())
+	}
+}
+
+// shas lists the commits' SHAs in order.
+func shas(commits []Commit) []string {
+	out := make([]string, len(commits))
+	for idx, commit := range commits {
+		out[idx] = commit.SHA
+	}
+	return out
+}
+
+// TestBackendsHonorCanceledContext checks that neither backend does work
+// once its context has ended.
+func TestBackendsHonorCanceledContext(t *testing.T) {
+	base := setupBackendRepo(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	for _, b := range []Backend{execBackend{}, goGitBackend{}} {
+		if _, err := b.Log(ctx, base, "HEAD", false); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s Log() error = %v, want context.Canceled", b.Name(), err)
+		}
+		if _, err := b.HEAD(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s HEAD() error = %v, want context.Canceled", b.Name(), err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Package git — per-invocation query cache.
+package git
+
+import (
+	"context"
+	"os"
+	"sync"
+)
+
+// queryCacheKey is the context key for a *queryCache.
+type queryCacheKey struct{}
+
+// queryCache remembers the answers to repository-level queries (HEAD, the
+// repo root, the current branch) for one command invocation.
+type queryCache struct {
+	mu      sync.Mutex
+	answers map[string]any
+}
+
+// refMovingSubcommands are the git subcommands that can change a cached
+// answer: HEAD, the current branch, or the repository itself. Running one
+// under a caching context clears its cache.
+var refMovingSubcommands = map[string]bool{
+	"am": true, "branch": true, "checkout": true, "cherry-pick": true,
+	"clone": true, "commit": true, "fetch": true, "init": true, "merge": true,
+	"pull": true, "rebase": true, "reset": true, "revert": true, "stash": true,
+	"switch": true, "symbolic-ref": true, "update-ref": true, "worktree": true,
+}
+
+// WithQueryCache returns a context whose git queries — IsRepo, IsBare,
+// IsShallow, IsJJColocated, RepoRoot, Dir, CurrentBranch, and HEAD — each run
+// at most once per working directory, however often a command asks. Any git
+// subcommand run under the context that can move HEAD or a branch (commit,
+// reset, ...) clears the cache, so a command that writes sees its own writes. Changes
+// made outside this package (another process committing) are not seen, so
+// scope the context to one short-lived command invocation.
+func WithQueryCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, queryCacheKey{}, &queryCache{answers: make(map[string]any)})
+}
+
+// cachedQuery returns the answer to the query name from ctx's cache,
+// running query on a miss. Only successful answers are kept. Without a
+// cache in ctx, query always runs.
+func cachedQuery[T any](ctx context.Context, name string, query func() (T, error)) (T, error) {
+	cache, _ := ctx.Value(queryCacheKey{}).(*queryCache)
+	if cache == nil {
+		return query()
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return query()
+	}
+	key := cwd + "\x00" + name
+
+	cache.mu.Lock()
+	answer, ok := cache.answers[key].(T)
+	cache.mu.Unlock()
+	if ok {
+		return answer, nil
+	}
+	answer, err = query()
+	if err == nil {
+		cache.mu.Lock()
+		cache.answers[key] = answer
+		cache.mu.Unlock()
+	}
+	return answer, err
+}
+
+// invalidateQueryCache clears ctx's cache when args run a subcommand that
+// can change a cached answer.
+func invalidateQueryCache(ctx context.Context, args []string) {
+	cache, _ := ctx.Value(queryCacheKey{}).(*queryCache)
+	if cache == nil || !refMovingSubcommands[subcommand(args)] {
+		return
+	}
+	cache.mu.Lock()
+	clear(cache.answers)
+	cache.mu.Unlock()
+}
+
+// subcommand returns the git subcommand in args, skipping global options
+// such as -c key=value.
+func subcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "-c" || args[i] == "-C":
+			i++
+		case len(args[i]) > 0 && args[i][0] == '-':
+		default:
+			return args[i]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,44 @@
+package git
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithQueryCache(t *testing.T) {
+	setupGitRepoWithCommit(t, t.TempDir())
+	SetContext(WithQueryCache(context.Background()))
+	t.Cleanup(func() { SetContext(nil) })
+
+	var first, second string
+	count := countProcesses(t, func() {
+		first, _ = HEAD()
+		second, _ = HEAD()
+		_, _ = RepoRoot()
+		_, _ = RepoRoot()
+		_ = IsRepo()
+		_ = IsRepo()
+	})
+	if count != 3 || first != second {
+		t.Errorf("cached queries started %d git processes (HEAD %s then %s), want 3", count, first, second)
+	}
+
+	if _, err := Run("commit", "--allow-empty", "-m", "next"); err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := HEAD(); after == first {
+		t.Error("HEAD still cached after a commit")
+	}
+}
+
+func TestQueryCacheOffByDefault(t *testing.T) {
+	setupGitRepoWithCommit(t, t.TempDir())
+
+	count := countProcesses(t, func() {
+		_, _ = HEAD()
+		_, _ = HEAD()
+	})
+	if count != 2 {
+		t.Errorf("uncached HEAD twice started %d git processes, want 2", count)
+	}
+}
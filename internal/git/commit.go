@@ -2,9 +2,7 @@
 package git
 
 import (
-	"bytes"
 	"context"
-	"os/exec"
 	"strconv"
 	"strings"
 	"time"
@@ -33,9 +31,29 @@ func (c Commit) IsMerge() bool {
 
 // Diffstat represents the change statistics for a range of commits.
 type Diffstat struct {
-	Files      int // Number of files changed
-	Insertions int // Number of lines inserted
-	Deletions  int // Number of lines deleted
+	Files          int        // Number of files changed, including binary and generated
+	Insertions     int        // Number of lines inserted, excluding generated files
+	Deletions      int        // Number of lines deleted, excluding generated files
+	BinaryFiles    int        // Binary files changed (no line counts)
+	GeneratedFiles int        // Files marked linguist-generated/vendored in .gitattributes
+	Renames        []Rename   // Files moved or renamed (detected with -M)
+	PerFile        []FileStat // One record per changed file, in diff order
+}
+
+// FileStat is the change to a single file within a diff.
+type FileStat struct {
+	Path       string // Post-image path
+	From       string // Pre-image path when the file was renamed
+	Insertions int
+	Deletions  int
+	Binary     bool // No line counts
+	Generated  bool // Marked linguist-generated/vendored; excluded from totals
+}
+
+// Rename is a file moved from one path to another within a diff.
+type Rename struct {
+	From string
+	To   string
 }
 
 // commitSeparator is used to delimit commits in log output.
@@ -49,7 +67,12 @@ const fieldSeparator = "---FIELD---"
 // Walks the full DAG — merge commits are visited and their second parents
 // are followed, so commits brought in by a merge appear in the result.
 func Log(fromRef, toRef string) ([]Commit, error) {
-	return logRange(fromRef, toRef, false)
+	return LogContext(Context(), fromRef, toRef)
+}
+
+// LogContext is Log with an explicit context.
+func LogContext(ctx context.Context, fromRef, toRef string) ([]Commit, error) {
+	return CurrentBackend().Log(ctx, fromRef, toRef, false)
 }
 
 // LogFirstParent returns commits in the given range (fromRef..toRef) using
@@ -60,11 +83,16 @@ func Log(fromRef, toRef string) ([]Commit, error) {
 // linear history of the current branch — useful for "what work happened on
 // this branch?" without picking up commits authored elsewhere and merged in.
 func LogFirstParent(fromRef, toRef string) ([]Commit, error) {
-	return logRange(fromRef, toRef, true)
+	return LogFirstParentContext(Context(), fromRef, toRef)
+}
+
+// LogFirstParentContext is LogFirstParent with an explicit context.
+func LogFirstParentContext(ctx context.Context, fromRef, toRef string) ([]Commit, error) {
+	return CurrentBackend().Log(ctx, fromRef, toRef, true)
 }
 
-// logRange is the shared implementation for Log and LogFirstParent.
-func logRange(fromRef, toRef string, firstParent bool) ([]Commit, error) {
+// Log implements Backend by running git log.
+func (execBackend) Log(ctx context.Context, fromRef, toRef string, firstParent bool) ([]Commit, error) {
 	rangeSpec := fromRef + ".." + toRef
 	args := []string{"log", "--pretty=format:" + commitFormat()}
 	if firstParent {
@@ -72,13 +100,13 @@ func logRange(fromRef, toRef string, firstParent bool) ([]Commit, error) {
 	}
 	args = append(args, rangeSpec)
 
-	out, err := Run(args...)
+	out, err := RunContext(ctx, args...)
 	if err != nil {
-		return nil, output.NewSystemErrorWithCause("failed to get git log for range "+rangeSpec, err)
+		return nil, output.NewSystemErrorWithCause("failed to get git log for range "+rangeSpec+shallowRangeHint(ctx), err)
 	}
 
 	commits := parseCommits(out)
-	normalizeCoAuthors(commits)
+	normalizeCoAuthors(ctx, commits)
 	return commits, nil
 }
 
@@ -113,13 +141,40 @@ func commitFormat() string {
 // CommitsReachableFrom returns all commits reachable from the given ref.
 // Commits are returned in reverse chronological order (newest first).
 func CommitsReachableFrom(sha string) ([]Commit, error) {
-	out, err := Run("log", "--pretty=format:"+commitFormat(), sha)
+	return CommitsReachableFromContext(Context(), sha)
+}
+
+// CommitsReachableFromContext is CommitsReachableFrom with an explicit context.
+func CommitsReachableFromContext(ctx context.Context, sha string) ([]Commit, error) {
+	return CurrentBackend().CommitsReachableFrom(ctx, sha, false)
+}
+
+// CommitsReachableFromFirstParent returns the commits on the first-parent
+// line of the given ref, newest first. Like LogFirstParent, commits brought
+// in by merges are not visited; the merges themselves are.
+func CommitsReachableFromFirstParent(sha string) ([]Commit, error) {
+	return CommitsReachableFromFirstParentContext(Context(), sha)
+}
+
+// CommitsReachableFromFirstParentContext is CommitsReachableFromFirstParent
+// with an explicit context.
+func CommitsReachableFromFirstParentContext(ctx context.Context, sha string) ([]Commit, error) {
+	return CurrentBackend().CommitsReachableFrom(ctx, sha, true)
+}
+
+// CommitsReachableFrom implements Backend by running git log.
+func (execBackend) CommitsReachableFrom(ctx context.Context, sha string, firstParent bool) ([]Commit, error) {
+	args := []string{"log", "--pretty=format:" + commitFormat()}
+	if firstParent {
+		args = append(args, "--first-parent")
+	}
+	out, err := RunContext(ctx, append(args, sha)...)
 	if err != nil {
 		return nil, output.NewSystemErrorWithCause("failed to get commits from "+sha, err)
 	}
 
 	commits := parseCommits(out)
-	normalizeCoAuthors(commits)
+	normalizeCoAuthors(ctx, commits)
 	return commits, nil
 }
 
@@ -198,98 +253,3 @@ func parseCommitFields(commitStr string) (Commit, bool) {
 		ParentCount: parentCount,
 	}, true
 }
-
-// CommitFiles returns the list of files changed by the given commit.
-func CommitFiles(sha string) ([]string, error) {
-	out, err := Run("diff-tree", "--no-commit-id", "--name-only", "-r", sha)
-	if err != nil {
-		return nil, output.NewSystemErrorWithCause("failed to get files for commit "+sha, err)
-	}
-	if out == "" {
-		return nil, nil
-	}
-	var files []string
-	for line := range strings.SplitSeq(out, "\n") {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			files = append(files, line)
-		}
-	}
-	return files, nil
-}
-
-// CommitFilesMulti returns the files changed by each commit using a single git process.
-// Uses git diff-tree --stdin for batch processing instead of one subprocess per commit.
-// Returns a map from full SHA to file list. Commits with no changed files get a nil slice.
-func CommitFilesMulti(shas []string) (map[string][]string, error) {
-	if len(shas) == 0 {
-		return make(map[string][]string), nil
-	}
-
-	input := strings.Join(shas, "\n") + "\n"
-	cmd := exec.CommandContext(context.Background(), "git", "diff-tree", "-r", "--name-only", "--stdin")
-	cmd.Stdin = strings.NewReader(input)
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		errMsg := strings.TrimSpace(stderr.String())
-		if errMsg == "" {
-			errMsg = err.Error()
-		}
-		return nil, output.NewSystemErrorWithCause("git diff-tree --stdin failed: "+errMsg, err)
-	}
-
-	// Build lookup set for input SHAs
-	shaSet := make(map[string]bool, len(shas))
-	for _, sha := range shas {
-		shaSet[sha] = true
-	}
-
-	// Parse output: each commit SHA appears on its own line, followed by changed files.
-	result := make(map[string][]string, len(shas))
-	var current string
-	for line := range strings.SplitSeq(strings.TrimSpace(stdout.String()), "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-		if shaSet[line] {
-			current = line
-			if _, ok := result[current]; !ok {
-				result[current] = nil
-			}
-		} else if current != "" {
-			result[current] = append(result[current], line)
-		}
-	}
-
-	return result, nil
-}
-
-// DiffNameOnly returns file paths changed between fromRef and toRef,
-// optionally filtered to a path prefix.
-// Uses git diff --name-only fromRef..toRef -- [pathPrefix].
-func DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
-	args := []string{"diff", "--name-only", fromRef + ".." + toRef}
-	if pathPrefix != "" {
-		args = append(args, "--", pathPrefix)
-	}
-	out, err := Run(args...)
-	if err != nil {
-		return nil, output.NewSystemErrorWithCause("failed to get diff for range "+fromRef+".."+toRef, err)
-	}
-	if out == "" {
-		return nil, nil
-	}
-	var files []string
-	for line := range strings.SplitSeq(out, "\n") {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			files = append(files, line)
-		}
-	}
-	return files, nil
-}
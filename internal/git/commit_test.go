@@ -230,7 +230,7 @@ func TestResolveRefOrEmptyTree(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := resolveRefOrEmptyTree(tt.ref)
+			got := resolveRefOrEmptyTree(t.Context(), tt.ref)
 			if tt.wantTree && got != emptyTreeSHA {
 				t.Errorf("resolveRefOrEmptyTree(%q) = %q, want empty tree SHA", tt.ref, got)
 			}
@@ -0,0 +1,157 @@
+// Package git — per-commit and per-range file listings.
+// Split out of commit.go to keep that file under the file-length-limit.
+package git
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// CommitFiles returns the list of files changed by the given commit.
+// Renames are detected (-M) and list both the old and the new path, so a
+// file moved out of a directory still counts as a change to it.
+func CommitFiles(sha string) ([]string, error) {
+	return CommitFilesContext(Context(), sha)
+}
+
+// CommitFilesContext is CommitFiles with an explicit context.
+func CommitFilesContext(ctx context.Context, sha string) ([]string, error) {
+	return CurrentBackend().CommitFiles(ctx, sha)
+}
+
+// CommitFiles implements Backend by running git diff-tree.
+func (execBackend) CommitFiles(ctx context.Context, sha string) ([]string, error) {
+	out, err := RunContext(ctx, "diff-tree", "--no-commit-id", "-r", "-M", "--name-status", "-z", sha)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to get files for commit "+sha, err)
+	}
+	return parseNameStatus(out), nil
+}
+
+// CommitFilesMulti returns the files changed by each commit using a single git process.
+// Uses git diff-tree --stdin for batch processing instead of one subprocess per commit.
+// Returns a map from full SHA to file list. Commits with no changed files get a nil slice.
+func CommitFilesMulti(shas []string) (map[string][]string, error) {
+	return CommitFilesMultiContext(Context(), shas)
+}
+
+// CommitFilesMultiContext is CommitFilesMulti with an explicit context.
+func CommitFilesMultiContext(ctx context.Context, shas []string) (map[string][]string, error) {
+	return commitFilesMulti(ctx, shas)
+}
+
+// CommitFilesMultiFirstParent is CommitFilesMulti, except that a merge
+// commit lists the files it changed against its first parent — the work it
+// brought onto the first-parent line — instead of none.
+func CommitFilesMultiFirstParent(shas []string) (map[string][]string, error) {
+	return CommitFilesMultiFirstParentContext(Context(), shas)
+}
+
+// CommitFilesMultiFirstParentContext is CommitFilesMultiFirstParent with an
+// explicit context.
+func CommitFilesMultiFirstParentContext(ctx context.Context, shas []string) (map[string][]string, error) {
+	return commitFilesMulti(ctx, shas, "--diff-merges=first-parent")
+}
+
+// commitFilesMulti runs one diff-tree --stdin over shas, with extra options.
+func commitFilesMulti(ctx context.Context, shas []string, extra ...string) (map[string][]string, error) {
+	if len(shas) == 0 {
+		return make(map[string][]string), nil
+	}
+
+	args := append([]string{"diff-tree", "-r", "-M", "--name-status", "-z", "--stdin"}, extra...)
+	out, err := RunStdinContext(ctx, strings.Join(shas, "\n")+"\n", args...)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to get files for commits", err)
+	}
+
+	return parseDiffTreeMulti(out, shas), nil
+}
+
+// parseDiffTreeMulti splits diff-tree --stdin -z output into each commit's
+// paths: every commit SHA is its own NUL-terminated field, followed by the
+// name-status records of its changed files.
+func parseDiffTreeMulti(out string, shas []string) map[string][]string {
+	shaSet := make(map[string]bool, len(shas))
+	for _, sha := range shas {
+		shaSet[sha] = true
+	}
+
+	result := make(map[string][]string, len(shas))
+	tokens := strings.Split(out, "\x00")
+	var current string
+	for idx := 0; idx < len(tokens); idx++ {
+		token := strings.TrimSpace(tokens[idx])
+		switch {
+		case token == "":
+		case shaSet[token]:
+			current = token
+			if _, ok := result[current]; !ok {
+				result[current] = nil
+			}
+		default:
+			paths := nameStatusPaths(token)
+			if idx+paths >= len(tokens) {
+				return result
+			}
+			if current != "" {
+				result[current] = append(result[current], tokens[idx+1:idx+1+paths]...)
+			}
+			idx += paths
+		}
+	}
+	return result
+}
+
+// DiffNameOnly returns file paths changed between fromRef and toRef,
+// optionally filtered to a path prefix. Like CommitFiles, a rename lists
+// both its old and new path.
+// Uses git diff -M --name-status fromRef..toRef -- [pathPrefix].
+func DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
+	return DiffNameOnlyContext(Context(), fromRef, toRef, pathPrefix)
+}
+
+// DiffNameOnlyContext is DiffNameOnly with an explicit context.
+func DiffNameOnlyContext(ctx context.Context, fromRef, toRef, pathPrefix string) ([]string, error) {
+	args := []string{"diff", "-M", "--name-status", "-z", fromRef + ".." + toRef}
+	if pathPrefix != "" {
+		args = append(args, "--", pathPrefix)
+	}
+	out, err := RunContext(ctx, args...)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to get diff for range "+fromRef+".."+toRef, err)
+	}
+	return parseNameStatus(out), nil
+}
+
+// parseNameStatus returns the paths in `--name-status -z` output, in order.
+// Each record is "<status>\0<path>\0"; renames and copies ("R100", "C075")
+// carry the old path and then the new one, and both are returned.
+func parseNameStatus(out string) []string {
+	var files []string
+	tokens := strings.Split(out, "\x00")
+	for idx := 0; idx < len(tokens); idx++ {
+		status := strings.TrimSpace(tokens[idx])
+		if status == "" {
+			continue
+		}
+		paths := nameStatusPaths(status)
+		if idx+paths >= len(tokens) {
+			break
+		}
+		files = append(files, tokens[idx+1:idx+1+paths]...)
+		idx += paths
+	}
+	return files
+}
+
+// nameStatusPaths returns how many paths follow a name-status letter:
+// two for a rename or copy, one otherwise.
+func nameStatusPaths(status string) int {
+	if strings.HasPrefix(status, "R") || strings.HasPrefix(status, "C") {
+		return 2
+	}
+	return 1
+}
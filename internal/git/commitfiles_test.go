@@ -0,0 +1,53 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCommitFilesListsBothSidesOfRename(t *testing.T) {
+	dir := t.TempDir()
+	setupGitRepoWithCommit(t, dir)
+	if err := os.MkdirAll(filepath.Join(dir, "old"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "old", "name with space.go"), []byte(strings.Repeat("code\n", 20)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mustRun(t, "add", "-A")
+	mustRun(t, "commit", "-m", "add")
+	mustRun(t, "mv", "old", "new")
+	mustRun(t, "commit", "-m", "move")
+	head, _ := HEAD()
+
+	want := []string{"old/name with space.go", "new/name with space.go"}
+	files, err := CommitFiles(head)
+	if err != nil || !reflect.DeepEqual(files, want) {
+		t.Errorf("CommitFiles() = %q, %v; want %q", files, err, want)
+	}
+	multi, err := CommitFilesMulti([]string{head})
+	if err != nil || !reflect.DeepEqual(multi[head], want) {
+		t.Errorf("CommitFilesMulti() = %q, %v; want %q", multi[head], err, want)
+	}
+	ranged, err := DiffNameOnly("HEAD~1", "HEAD", "old/")
+	if err != nil || !reflect.DeepEqual(ranged, want[:1]) {
+		t.Errorf("DiffNameOnly(old/) = %q, %v; want %q", ranged, err, want[:1])
+	}
+}
+
+func TestCommitFilesMultiFirstParentListsMergedFiles(t *testing.T) {
+	setupBackendRepo(t)
+	merge, _ := HEAD()
+
+	plain, err := CommitFilesMulti([]string{merge})
+	if err != nil || len(plain[merge]) != 0 {
+		t.Errorf("CommitFilesMulti(merge) = %q, %v; want no files", plain[merge], err)
+	}
+	firstParent, err := CommitFilesMultiFirstParent([]string{merge})
+	if err != nil || !reflect.DeepEqual(firstParent[merge], []string{"b.txt"}) {
+		t.Errorf("CommitFilesMultiFirstParent(merge) = %q, %v; want [b.txt]", firstParent[merge], err)
+	}
+}
@@ -3,92 +3,170 @@
 package git
 
 import (
-	"regexp"
+	"context"
 	"strconv"
 	"strings"
 
 	"github.com/gorewood/timbers/internal/output"
 )
 
-// diffstatLineRegex matches the summary line of git diff --stat
-// Example: " 3 files changed, 45 insertions(+), 12 deletions(-)"
-var diffstatLineRegex = regexp.MustCompile(`(\d+)\s+files?\s+changed(?:,\s+(\d+)\s+insertions?\(\+\))?(?:,\s+(\d+)\s+deletions?\(-\))?`)
-
 // emptyTreeSHA is the SHA of git's empty tree object.
 // Used when diffing from a root commit (which has no parent).
 const emptyTreeSHA = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
 
 // GetDiffstat returns the change statistics for the given commit range.
 // The 'fromRef' ref is exclusive, 'toRef' is inclusive.
+// Renames are detected (-M): a moved file counts once, with only its content
+// edits as insertions/deletions, and the old/new pair lands in Renames.
+// Binary files and paths marked linguist-generated/linguist-vendored in
+// .gitattributes are counted in BinaryFiles/GeneratedFiles and contribute
+// no insertions or deletions, so vendored churn doesn't swamp the evidence.
+// PerFile breaks the totals down by file.
 // If fromRef doesn't exist (e.g., parent of root commit), uses empty tree.
 func GetDiffstat(fromRef, toRef string) (Diffstat, error) {
-	resolvedFrom := resolveRefOrEmptyTree(fromRef)
+	return GetDiffstatContext(Context(), fromRef, toRef)
+}
+
+// GetDiffstatContext is GetDiffstat with an explicit context.
+func GetDiffstatContext(ctx context.Context, fromRef, toRef string) (Diffstat, error) {
+	resolvedFrom := resolveRefOrEmptyTree(ctx, fromRef)
 	rangeSpec := resolvedFrom + ".." + toRef
-	out, err := Run("diff", "--stat", rangeSpec)
+	out, err := RunContext(ctx, "diff", "-M", "--numstat", "-z", rangeSpec)
 	if err != nil {
 		return Diffstat{}, output.NewSystemErrorWithCause("failed to get diffstat for range "+rangeSpec, err)
 	}
 
-	return parseDiffstat(out), nil
+	records := parseNumstat(out)
+	paths := make([]string, len(records))
+	for idx, rec := range records {
+		paths[idx] = rec.path
+	}
+	return summarizeNumstat(records, generatedPaths(ctx, paths)), nil
 }
 
 // resolveRefOrEmptyTree resolves a ref, returning empty tree SHA if it doesn't exist.
 // This handles the case of "SHA^" for root commits.
-func resolveRefOrEmptyTree(ref string) string {
+func resolveRefOrEmptyTree(ctx context.Context, ref string) string {
 	if ref == "" {
 		return emptyTreeSHA
 	}
-	_, err := Run("rev-parse", "--verify", "--quiet", ref)
+	_, err := RunContext(ctx, "rev-parse", "--verify", "--quiet", ref)
 	if err != nil {
 		return emptyTreeSHA
 	}
 	return ref
 }
 
-// parseDiffstat extracts file, insertion, and deletion counts from git diff --stat output.
-func parseDiffstat(out string) Diffstat {
-	summaryLine := findSummaryLine(out)
-	if summaryLine == "" {
-		return Diffstat{}
-	}
+// numstatRecord is one file from `git diff --numstat -z`.
+type numstatRecord struct {
+	path       string // post-image path
+	insertions int
+	deletions  int
+	binary     bool    // numstat reports "-" counts for binary files
+	rename     *Rename // set when -M paired the file with an old path
+}
 
-	return extractDiffstatFromSummary(summaryLine)
+// parseNumstat parses `git diff --numstat -z` output. Each record is
+// "<ins>\t<del>\t<path>\0"; a rename leaves <path> empty and follows it with
+// "<old>\0<new>\0". Binary files report "-" for both counts.
+func parseNumstat(out string) []numstatRecord {
+	var records []numstatRecord
+	tokens := strings.Split(out, "\x00")
+	for idx := 0; idx < len(tokens); idx++ {
+		counts := strings.SplitN(tokens[idx], "\t", 3)
+		if len(counts) != 3 {
+			continue
+		}
+		rec := numstatRecord{
+			path:       counts[2],
+			insertions: parseCount(counts[0]),
+			deletions:  parseCount(counts[1]),
+			binary:     counts[0] == "-" && counts[1] == "-",
+		}
+		if rec.path == "" && idx+2 < len(tokens) {
+			rec.rename = &Rename{From: tokens[idx+1], To: tokens[idx+2]}
+			rec.path = rec.rename.To
+			idx += 2
+		}
+		records = append(records, rec)
+	}
+	return records
 }
 
-// findSummaryLine finds the last non-empty line in the diff stat output.
-func findSummaryLine(out string) string {
-	lines := strings.Split(out, "\n")
-	for idx := len(lines) - 1; idx >= 0; idx-- {
-		line := strings.TrimSpace(lines[idx])
-		if line != "" {
-			return line
+// summarizeNumstat totals numstat records. Every file counts toward Files;
+// binary files are tallied separately (they have no line counts), and files
+// in generated are tallied separately and kept out of Insertions/Deletions.
+// Every record, whatever its kind, also lands in PerFile.
+func summarizeNumstat(records []numstatRecord, generated map[string]bool) Diffstat {
+	var stat Diffstat
+	for _, rec := range records {
+		stat.Files++
+		file := FileStat{
+			Path:       rec.path,
+			Insertions: rec.insertions,
+			Deletions:  rec.deletions,
+			Binary:     rec.binary,
+			Generated:  generated[rec.path],
+		}
+		if rec.rename != nil {
+			stat.Renames = append(stat.Renames, *rec.rename)
+			file.From = rec.rename.From
+		}
+		stat.PerFile = append(stat.PerFile, file)
+		switch {
+		case file.Generated:
+			stat.GeneratedFiles++
+		case rec.binary:
+			stat.BinaryFiles++
+		default:
+			stat.Insertions += rec.insertions
+			stat.Deletions += rec.deletions
 		}
 	}
-	return ""
+	return stat
 }
 
-// parseMatchInt extracts an int from a regex match group, returning 0 on error.
-func parseMatchInt(matches []string, idx int) int {
-	if idx >= len(matches) || matches[idx] == "" {
-		return 0
+// generatedAttrs are the gitattributes that mark a path as generated or
+// vendored (the same ones GitHub uses to collapse diffs).
+var generatedAttrs = []string{"linguist-generated", "linguist-vendored"}
+
+// generatedPaths returns the subset of paths that .gitattributes marks as
+// generated or vendored. Attribute lookup failures yield an empty set so the
+// diffstat degrades to counting everything.
+func generatedPaths(ctx context.Context, paths []string) map[string]bool {
+	generated := make(map[string]bool)
+	if len(paths) == 0 {
+		return generated
 	}
-	val, err := strconv.Atoi(matches[idx])
+	// Diff paths are relative to the repository root; check-attr resolves
+	// them against the working directory, so run it from the root.
+	root, err := CurrentBackend().RepoRoot(ctx)
 	if err != nil {
-		return 0
+		return generated
 	}
-	return val
-}
-
-// extractDiffstatFromSummary parses the diffstat summary line using regex.
-func extractDiffstatFromSummary(summaryLine string) Diffstat {
-	matches := diffstatLineRegex.FindStringSubmatch(summaryLine)
-	if matches == nil {
-		return Diffstat{}
+	args := append([]string{"check-attr", "-z"}, generatedAttrs...)
+	args = append(args, "--")
+	args = append(args, paths...)
+	out, err := RunInDirContext(ctx, root, nil, args...)
+	if err != nil {
+		return generated
 	}
+	// Output is "<path>\0<attr>\0<value>\0" per path/attribute pair.
+	tokens := strings.Split(out, "\x00")
+	for idx := 0; idx+2 < len(tokens); idx += 3 {
+		if value := tokens[idx+2]; value == "set" || value == "true" {
+			generated[tokens[idx]] = true
+		}
+	}
+	return generated
+}
 
-	return Diffstat{
-		Files:      parseMatchInt(matches, 1),
-		Insertions: parseMatchInt(matches, 2),
-		Deletions:  parseMatchInt(matches, 3),
+// parseCount converts a numstat count to an int; "-" (binary) and malformed
+// values count as 0.
+func parseCount(field string) int {
+	val, err := strconv.Atoi(strings.TrimSpace(field))
+	if err != nil {
+		return 0
 	}
+	return val
 }
@@ -0,0 +1,110 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseNumstat(t *testing.T) {
+	out := "3\t1\tmain.go\x00" +
+		"0\t0\t\x00old/name.go\x00new/name.go\x00" +
+		"-\t-\tlogo.png\x00" +
+		"120\t80\tvendor/lib.go\x00"
+
+	got := summarizeNumstat(parseNumstat(out), map[string]bool{"vendor/lib.go": true})
+	want := Diffstat{
+		Files:          4,
+		Insertions:     3,
+		Deletions:      1,
+		BinaryFiles:    1,
+		GeneratedFiles: 1,
+		Renames:        []Rename{{From: "old/name.go", To: "new/name.go"}},
+		PerFile: []FileStat{
+			{Path: "main.go", Insertions: 3, Deletions: 1},
+			{Path: "new/name.go", From: "old/name.go"},
+			{Path: "logo.png", Binary: true},
+			{Path: "vendor/lib.go", Insertions: 120, Deletions: 80, Generated: true},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("summarizeNumstat() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetDiffstat_ExcludesGeneratedAndBinary(t *testing.T) {
+	dir := t.TempDir()
+	setupGitRepoWithCommit(t, dir)
+
+	files := map[string]string{
+		".gitattributes":  "gen/** linguist-generated\n",
+		"gen/api.pb.go":   strings.Repeat("generated\n", 100),
+		"main.go":         "package main\n",
+		"assets/logo.bin": "\x00\x01\x02binary",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mustRun(t, "add", "-A")
+	mustRun(t, "commit", "-m", "add files")
+
+	stat, err := GetDiffstat("HEAD~1", "HEAD")
+	if err != nil {
+		t.Fatalf("GetDiffstat() error = %v", err)
+	}
+	want := Diffstat{
+		Files: 4, Insertions: 2, BinaryFiles: 1, GeneratedFiles: 1,
+		PerFile: []FileStat{
+			{Path: ".gitattributes", Insertions: 1},
+			{Path: "assets/logo.bin", Binary: true},
+			{Path: "gen/api.pb.go", Insertions: 100, Generated: true},
+			{Path: "main.go", Insertions: 1},
+		},
+	}
+	if !reflect.DeepEqual(stat, want) {
+		t.Errorf("GetDiffstat() = %+v, want %+v", stat, want)
+	}
+}
+
+func TestGetDiffstat_DetectsRenames(t *testing.T) {
+	dir := t.TempDir()
+	setupGitRepoWithCommit(t, dir)
+
+	content := strings.Repeat("line of code\n", 50)
+	if err := os.WriteFile(filepath.Join(dir, "before.go"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mustRun(t, "add", "before.go")
+	mustRun(t, "commit", "-m", "add file")
+	mustRun(t, "mv", "before.go", "after.go")
+	mustRun(t, "commit", "-m", "rename file")
+
+	stat, err := GetDiffstat("HEAD~1", "HEAD")
+	if err != nil {
+		t.Fatalf("GetDiffstat() error = %v", err)
+	}
+	want := Diffstat{
+		Files:   1,
+		Renames: []Rename{{From: "before.go", To: "after.go"}},
+		PerFile: []FileStat{{Path: "after.go", From: "before.go"}},
+	}
+	if !reflect.DeepEqual(stat, want) {
+		t.Errorf("GetDiffstat() = %+v, want %+v (a pure rename should not count as +50/-50)", stat, want)
+	}
+}
+
+// mustRun runs a git command in the current directory, failing the test on error.
+func mustRun(t *testing.T, args ...string) {
+	t.Helper()
+	if _, err := Run(args...); err != nil {
+		t.Fatalf("git %v: %v", args, err)
+	}
+}
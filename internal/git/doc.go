@@ -20,6 +20,84 @@
 //	output, err := git.Run("status", "--short")
 //	output, err := git.RunContext(ctx, "log", "--oneline", "-5")
 //
+// To target a repository other than the working directory, or to inject
+// environment variables, use RunInDir:
+//
+//	output, err := git.RunInDir(otherRepo, []string{"GIT_AUTHOR_NAME=bot"}, "log", "-1")
+//
+// # Backends
+//
+// IsRepo, RepoRoot, HEAD, ResolveCommit, Log, LogFirstParent,
+// CommitsReachableFrom, IsAncestorOf, and CommitFiles dispatch to the
+// installed Backend: exec (the default) or go-git, which reads the
+// repository in-process. Everything else always runs git.
+//
+//	backend, err := git.NewBackend(git.BackendGoGit)
+//	git.SetBackend(backend)
+//
+// # Bare Repositories
+//
+// IsBare reports a repository without a working tree. TreeFiles reads a
+// directory's files out of a tree-ish instead, with one git ls-tree and one
+// git cat-file --batch:
+//
+//	files, err := git.TreeFiles("HEAD", ".timbers")
+//
+// # Batch Harvesting
+//
+// Functions that take many SHAs cost a fixed number of git processes, not
+// one per commit. LookupCommits and CommitFilesMulti feed SHAs to a single
+// git log --stdin; FirstParentLine walks the first-parent history once so
+// callers can test membership in a map instead of calling
+// IsOnFirstParentLine per commit. CommitFilesMultiFirstParent lists what
+// each merge changed against its first parent, for callers that document
+// merges rather than the commits they bring in (see also
+// CommitsReachableFromFirstParent). RunStdin is the plumbing underneath.
+//
+// # Trailers
+//
+// CommitTrailers returns every "Key: value" trailer of many commits from one
+// git log --stdin; ParseTrailers reads a message through git
+// interpret-trailers --parse. Both unfold continuation lines.
+//
+//	trailers, err := git.CommitTrailers(shas)
+//	reviewers := git.TrailerValues(trailers[sha], "Reviewed-by")
+//
+// # Cancellation and Timeouts
+//
+// Functions without a context parameter run under the package context set by
+// SetContext (context.Background by default). The CLI installs each command's
+// context there, so a global --timeout bounds every git subprocess. A git
+// process whose context ends is killed; a timed-out call returns a system
+// error naming the git subcommand. Each of those functions also has a
+// Context variant (HEADContext, LogContext, GetDiffstatContext, ...) for
+// callers with their own context, such as an MCP request.
+//
+// SetCommandTimeout additionally bounds every single git process, whatever
+// its context, so one hung invocation cannot stall a long-running command:
+//
+//	git.SetCommandTimeout(30 * time.Second)
+//
+// # Shallow Clones
+//
+// IsShallow detects a shallow clone, where history stops at the fetch depth;
+// a failed range walk in one says so and points at UnshallowHint.
+//
+// # Jujutsu
+//
+// IsJJColocated detects a jj repository colocated with its git store. jj keeps
+// HEAD detached at the working-copy commit's parent, so HEAD needs no
+// translation; CurrentBranch reports the bookmark there instead of "HEAD".
+//
+// # Query Cache
+//
+// A context from WithQueryCache answers IsRepo, IsBare, IsShallow,
+// IsJJColocated, RepoRoot, Dir, CurrentBranch, and HEAD at most once per
+// working directory; a git subcommand that can move HEAD (commit, reset,
+// checkout, ...) run under it clears the cache. The CLI installs a fresh one per command invocation:
+//
+//	git.SetContext(git.WithQueryCache(ctx))
+//
 // # Commit Operations
 //
 // For working with commits and commit history:
@@ -36,7 +114,7 @@
 // Example:
 //
 //	if !git.IsRepo() {
-//	    return output.NewSystemError("not in a git repository")
+//	    return output.NewSystemError("not in a git repository").WithID(output.ErrCodeNotARepo)
 //	}
 //	sha, err := git.HEAD()
 //	if err != nil {
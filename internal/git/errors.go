@@ -0,0 +1,62 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GitError describes a failed git invocation: the arguments, how git exited,
+// and what it printed to stderr. It is the Cause of the *output.ExitError
+// returned by Run and friends, so callers can recover it with errors.As, and
+// it implements output.ErrorDetailer so the CLI shows the failing command
+// instead of a bare "git command failed".
+//
+//nolint:revive // GitError reads better than Error at call sites (git.GitError vs git.Error).
+type GitError struct {
+	Args     []string // arguments passed to git, without the "git" itself
+	ExitCode int      // git's exit status; -1 if git never exited normally
+	Stderr   string   // trimmed stderr output
+	Err      error    // underlying exec or context error
+}
+
+// Error implements the error interface.
+func (e *GitError) Error() string {
+	msg := e.command()
+	if e.ExitCode >= 0 {
+		msg += fmt.Sprintf(" exited %d", e.ExitCode)
+	} else if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	if e.Stderr != "" {
+		msg += ": " + e.Stderr
+	}
+	return msg
+}
+
+// Unwrap returns the underlying exec or context error.
+func (e *GitError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorDetails returns the failing command for JSON error output.
+func (e *GitError) ErrorDetails() map[string]any {
+	return map[string]any{
+		"command":   "git",
+		"args":      e.Args,
+		"exit_code": e.ExitCode,
+		"stderr":    e.Stderr,
+	}
+}
+
+// DetailLine renders the failing command and exit status for humans.
+func (e *GitError) DetailLine() string {
+	if e.ExitCode >= 0 {
+		return fmt.Sprintf("while running: %s (exit %d)", e.command(), e.ExitCode)
+	}
+	return "while running: " + e.command()
+}
+
+// command renders the invocation as a shell-like string.
+func (e *GitError) command() string {
+	return strings.TrimSpace("git " + strings.Join(e.Args, " "))
+}
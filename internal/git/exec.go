@@ -0,0 +1,233 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// waitDelay bounds how long a killed git process may hold its output pipes
+// open. Credential helpers and pagers spawned by git inherit those pipes and
+// can outlive the git process itself; without the delay Wait blocks on them.
+const waitDelay = 2 * time.Second
+
+var (
+	baseCtxMu      sync.RWMutex
+	baseCtx        = context.Background()
+	trace          TraceFunc
+	commandTimeout time.Duration
+)
+
+// TraceFunc observes each git invocation after it finishes: its arguments
+// and how long it took.
+type TraceFunc func(args []string, elapsed time.Duration)
+
+// SetTrace installs fn to observe every git invocation, for --verbose
+// output. A nil fn turns tracing off.
+func SetTrace(fn TraceFunc) {
+	baseCtxMu.Lock()
+	defer baseCtxMu.Unlock()
+	trace = fn
+}
+
+// traceFunc returns the installed TraceFunc, if any.
+func traceFunc() TraceFunc {
+	baseCtxMu.RLock()
+	defer baseCtxMu.RUnlock()
+	return trace
+}
+
+// SetCommandTimeout bounds every single git process to d, independently of
+// the command-wide context: a hung fetch or credential prompt is killed after
+// d even when --timeout is unset or longer. Zero or negative d removes the
+// bound.
+func SetCommandTimeout(d time.Duration) {
+	baseCtxMu.Lock()
+	defer baseCtxMu.Unlock()
+	commandTimeout = max(d, 0)
+}
+
+// CommandTimeout returns the per-process bound installed by
+// SetCommandTimeout; zero means none.
+func CommandTimeout() time.Duration {
+	baseCtxMu.RLock()
+	defer baseCtxMu.RUnlock()
+	return commandTimeout
+}
+
+// commandContext derives the context for one git process from ctx, applying
+// the per-process timeout when one is set.
+func commandContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d := CommandTimeout(); d > 0 {
+		return context.WithTimeout(ctx, d)
+	}
+	return context.WithCancel(ctx)
+}
+
+// SetContext installs the context that every git invocation in this package
+// runs under when the caller does not pass one explicitly. The CLI sets it
+// once per command so that --timeout and cancellation reach helpers like
+// HEAD and Log, which take no context parameter. A nil ctx resets to
+// context.Background.
+func SetContext(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	baseCtxMu.Lock()
+	defer baseCtxMu.Unlock()
+	baseCtx = ctx //nolint:fatcontext // replaces the package context, not nesting it
+}
+
+// Context returns the context installed by SetContext.
+func Context() context.Context {
+	baseCtxMu.RLock()
+	defer baseCtxMu.RUnlock()
+	return baseCtx
+}
+
+// Run executes a git command with the given arguments under Context().
+// It captures stdout and returns it as a trimmed string.
+// Returns an *output.ExitError on failure with appropriate exit code.
+func Run(args ...string) (string, error) {
+	return RunContext(Context(), args...)
+}
+
+// RunContext executes a git command with the given context and arguments.
+// It captures stdout and returns it as a trimmed string.
+// Returns an *output.ExitError on failure with appropriate exit code.
+func RunContext(ctx context.Context, args ...string) (string, error) {
+	return runContextEnv(ctx, "", nil, args...)
+}
+
+// RunWithEnv runs git with extra environment variables appended to the current
+// process environment (KEY=VALUE strings). Used when a git invocation — and any
+// hooks it spawns — must see a variable the parent doesn't already export, e.g.
+// exempting timbers' own entry commit from the cross-agent-debt gate.
+func RunWithEnv(extraEnv []string, args ...string) (string, error) {
+	return runContextEnv(Context(), "", extraEnv, args...)
+}
+
+// RunInDir runs git in dir instead of the process working directory, with
+// extraEnv (KEY=VALUE strings, may be nil) appended to the environment. It
+// lets one process operate on several repositories — workspace aggregation,
+// tests, the MCP server — without os.Chdir, which is process-global and
+// unsafe once goroutines are involved. An empty dir means the working
+// directory.
+func RunInDir(dir string, extraEnv []string, args ...string) (string, error) {
+	return runContextEnv(Context(), dir, extraEnv, args...)
+}
+
+// RunInDirContext is RunInDir with an explicit context.
+func RunInDirContext(ctx context.Context, dir string, extraEnv []string, args ...string) (string, error) {
+	return runContextEnv(ctx, dir, extraEnv, args...)
+}
+
+// RunStdin runs git under Context() with stdin as its standard input, for
+// plumbing that reads object names from stdin (log --stdin, cat-file
+// --batch, diff-tree --stdin): one process handles any number of commits
+// without running into argument-length limits.
+func RunStdin(stdin string, args ...string) (string, error) {
+	return RunStdinContext(Context(), stdin, args...)
+}
+
+// RunStdinContext is RunStdin with an explicit context.
+func RunStdinContext(ctx context.Context, stdin string, args ...string) (string, error) {
+	out, err := runRaw(ctx, "", nil, strings.NewReader(stdin), args...)
+	return strings.TrimSpace(string(out)), err
+}
+
+// runContextEnv runs git under ctx in dir ("" for the working directory)
+// and returns its trimmed stdout.
+func runContextEnv(ctx context.Context, dir string, extraEnv []string, args ...string) (string, error) {
+	out, err := runRaw(ctx, dir, extraEnv, nil, args...)
+	return strings.TrimSpace(string(out)), err
+}
+
+// runRaw runs git under ctx in dir ("" for the working directory), feeding
+// it stdin when non-nil, and returns its stdout untouched. When ctx ends the
+// process is killed and, after waitDelay, its pipes are closed so a hung
+// child (e.g. a credential helper waiting on a prompt) cannot wedge the
+// caller.
+func runRaw(parent context.Context, dir string, extraEnv []string, stdin io.Reader, args ...string) ([]byte, error) {
+	ctx, cancel := commandContext(parent)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.WaitDelay = waitDelay
+	cmd.Dir = dir
+	cmd.Stdin = stdin
+	if len(extraEnv) > 0 {
+		cmd.Env = append(os.Environ(), extraEnv...)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	started := time.Now()
+	err := cmd.Run()
+	invalidateQueryCache(parent, args)
+	if fn := traceFunc(); fn != nil {
+		fn(args, time.Since(started))
+	}
+	if err != nil {
+		gitErr := &GitError{
+			Args:     args,
+			ExitCode: -1,
+			Stderr:   strings.TrimSpace(stderr.String()),
+			Err:      err,
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			gitErr.ExitCode = exitErr.ExitCode()
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			gitErr.Err = ctxErr
+			return nil, contextError(gitErr, parent.Err() == nil)
+		}
+
+		// Check if git is not found
+		var execErr *exec.Error
+		if errors.As(err, &execErr) {
+			return nil, output.NewSystemErrorWithCause("git not found: ensure git is installed and in PATH", gitErr).
+				WithID(output.ErrCodeGitFailed)
+		}
+
+		// Git command failed - include stderr in message
+		errMsg := gitErr.Stderr
+		if errMsg == "" {
+			errMsg = err.Error()
+		}
+		return nil, output.NewSystemErrorWithCause("git command failed: "+errMsg, gitErr).WithID(output.ErrCodeGitFailed)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// contextError describes a git invocation that was cut short by its context.
+// gitErr.Err holds the context error, so errors.Is(err, context.Canceled)
+// and context.DeadlineExceeded work on the result. perProcess reports that
+// the per-process timeout fired rather than the caller's context.
+func contextError(gitErr *GitError, perProcess bool) error {
+	sub := "git"
+	if len(gitErr.Args) > 0 {
+		sub += " " + gitErr.Args[0]
+	}
+	if perProcess {
+		return output.NewSystemErrorWithCause(
+			sub+" exceeded the per-command git timeout of "+CommandTimeout().String()+
+				" and was killed (raise [git] timeout or TIMBERS_GIT_TIMEOUT)", gitErr)
+	}
+	if errors.Is(gitErr.Err, context.DeadlineExceeded) {
+		return output.NewSystemErrorWithCause(sub+" timed out and was killed (raise --timeout or check for a hung credential helper)", gitErr)
+	}
+	return output.NewSystemErrorWithCause(sub+" canceled", gitErr)
+}
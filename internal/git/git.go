@@ -2,98 +2,105 @@
 package git
 
 import (
-	"bytes"
 	"context"
-	"errors"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
 	"github.com/gorewood/timbers/internal/output"
 )
 
-// Run executes a git command with the given arguments.
-// It captures stdout and returns it as a trimmed string.
-// Returns an *output.ExitError on failure with appropriate exit code.
-func Run(args ...string) (string, error) {
-	return RunContext(context.Background(), args...)
-}
-
-// RunContext executes a git command with the given context and arguments.
-// It captures stdout and returns it as a trimmed string.
-// Returns an *output.ExitError on failure with appropriate exit code.
-func RunContext(ctx context.Context, args ...string) (string, error) {
-	return runContextEnv(ctx, nil, args...)
-}
-
-// RunWithEnv runs git with extra environment variables appended to the current
-// process environment (KEY=VALUE strings). Used when a git invocation — and any
-// hooks it spawns — must see a variable the parent doesn't already export, e.g.
-// exempting timbers' own entry commit from the cross-agent-debt gate.
-func RunWithEnv(extraEnv []string, args ...string) (string, error) {
-	return runContextEnv(context.Background(), extraEnv, args...)
-}
-
-func runContextEnv(ctx context.Context, extraEnv []string, args ...string) (string, error) {
-	cmd := exec.CommandContext(ctx, "git", args...)
-	if len(extraEnv) > 0 {
-		cmd.Env = append(os.Environ(), extraEnv...)
-	}
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	err := cmd.Run()
-	if err != nil {
-		// Check if git is not found
-		var execErr *exec.Error
-		if errors.As(err, &execErr) {
-			return "", output.NewSystemError("git not found: ensure git is installed and in PATH")
-		}
-
-		// Git command failed - include stderr in message
-		errMsg := strings.TrimSpace(stderr.String())
-		if errMsg == "" {
-			errMsg = err.Error()
-		}
-		return "", output.NewSystemErrorWithCause("git command failed: "+errMsg, err)
-	}
-
-	return strings.TrimSpace(stdout.String()), nil
-}
-
 // IsRepo checks if the current directory is inside a git repository.
 func IsRepo() bool {
-	_, err := Run("rev-parse", "--git-dir")
+	ctx := Context()
+	isRepo, _ := cachedQuery(ctx, "is-repo", func() (bool, error) {
+		return CurrentBackend().IsRepo(ctx), nil
+	})
+	return isRepo
+}
+
+// IsRepo implements Backend by running git.
+func (execBackend) IsRepo(ctx context.Context) bool {
+	_, err := RunContext(ctx, "rev-parse", "--git-dir")
 	return err == nil
 }
 
 // RepoRoot returns the root directory of the current git repository.
 // Returns an error if not in a git repository.
 func RepoRoot() (string, error) {
-	root, err := Run("rev-parse", "--show-toplevel")
+	ctx := Context()
+	return cachedQuery(ctx, "repo-root", func() (string, error) {
+		return CurrentBackend().RepoRoot(ctx)
+	})
+}
+
+// RepoRoot implements Backend by running git.
+func (execBackend) RepoRoot(ctx context.Context) (string, error) {
+	root, err := RunContext(ctx, "rev-parse", "--show-toplevel")
 	if err != nil {
-		return "", output.NewSystemErrorWithCause("not in a git repository", err)
+		return "", output.NewSystemErrorWithCause("not in a git repository", err).WithID(output.ErrCodeNotARepo)
 	}
 	return root, nil
 }
 
+// Dir returns the absolute path of the current repository's git directory
+// (".git", or the per-worktree directory in a linked worktree). Local caches
+// that must never be committed live under it.
+func Dir() (string, error) {
+	return cachedQuery(Context(), "git-dir", func() (string, error) {
+		dir, err := Run("rev-parse", "--absolute-git-dir")
+		if err != nil {
+			return "", output.NewSystemErrorWithCause("not in a git repository", err).WithID(output.ErrCodeNotARepo)
+		}
+		return dir, nil
+	})
+}
+
 // CurrentBranch returns the name of the current branch.
 // Returns an error if not in a git repository or HEAD is detached.
+// In a jj colocated repository, where HEAD is always detached, it returns a
+// bookmark on HEAD, or "HEAD" when there is none.
 func CurrentBranch() (string, error) {
-	branch, err := Run("rev-parse", "--abbrev-ref", "HEAD")
-	if err != nil {
-		return "", output.NewSystemErrorWithCause("failed to get current branch", err)
+	return cachedQuery(Context(), "current-branch", func() (string, error) {
+		branch, err := Run("rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			return "", output.NewSystemErrorWithCause("failed to get current branch", err)
+		}
+		if branch == "HEAD" && IsJJColocated() {
+			if bookmark := jjBookmarkAtHEAD(); bookmark != "" {
+				return bookmark, nil
+			}
+		}
+		return branch, nil
+	})
+}
+
+// AttachedBranch returns the checked-out branch, or "" on a detached HEAD or
+// when git cannot tell.
+func AttachedBranch() string {
+	branch, err := CurrentBranch()
+	if err != nil || branch == "HEAD" {
+		return ""
 	}
-	return branch, nil
+	return branch
 }
 
 // HEAD returns the full SHA of the current HEAD commit.
 // Returns an error if not in a git repository or no commits exist.
 func HEAD() (string, error) {
-	sha, err := Run("rev-parse", "HEAD")
+	return HEADContext(Context())
+}
+
+// HEADContext is HEAD with an explicit context.
+func HEADContext(ctx context.Context) (string, error) {
+	return cachedQuery(ctx, "head", func() (string, error) {
+		return CurrentBackend().HEAD(ctx)
+	})
+}
+
+// HEAD implements Backend by running git.
+func (execBackend) HEAD(ctx context.Context) (string, error) {
+	sha, err := RunContext(ctx, "rev-parse", "HEAD")
 	if err != nil {
 		return "", output.NewSystemErrorWithCause("failed to get HEAD", err)
 	}
@@ -106,12 +113,22 @@ func HEAD() (string, error) {
 // per-commit and per-worktree and defeats the since-anchor model. Returns a
 // user error when the ref is empty or does not resolve to a commit.
 func ResolveCommit(ref string) (string, error) {
+	return ResolveCommitContext(Context(), ref)
+}
+
+// ResolveCommitContext is ResolveCommit with an explicit context.
+func ResolveCommitContext(ctx context.Context, ref string) (string, error) {
+	return CurrentBackend().ResolveCommit(ctx, ref)
+}
+
+// ResolveCommit implements Backend by running git.
+func (execBackend) ResolveCommit(ctx context.Context, ref string) (string, error) {
 	if ref == "" {
 		return "", output.NewUserError("empty commit ref")
 	}
 	// The ^{commit} peel forces resolution to a commit object, so tags and
 	// tree-ish refs that aren't commits are rejected rather than half-resolved.
-	sha, err := Run("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	sha, err := RunContext(ctx, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
 	if err != nil || sha == "" {
 		return "", output.NewUserError("could not resolve commit ref: " + ref)
 	}
@@ -134,10 +151,20 @@ func SHAExists(sha string) bool {
 // Useful for detecting anchors that exist in the object store but were rewritten
 // by rebase or squash merge and are no longer reachable from HEAD.
 func IsAncestorOf(ancestor, descendant string) bool {
+	return IsAncestorOfContext(Context(), ancestor, descendant)
+}
+
+// IsAncestorOfContext is IsAncestorOf with an explicit context.
+func IsAncestorOfContext(ctx context.Context, ancestor, descendant string) bool {
+	return CurrentBackend().IsAncestorOf(ctx, ancestor, descendant)
+}
+
+// IsAncestorOf implements Backend by running git.
+func (execBackend) IsAncestorOf(ctx context.Context, ancestor, descendant string) bool {
 	if ancestor == "" || descendant == "" {
 		return false
 	}
-	_, err := Run("merge-base", "--is-ancestor", ancestor, descendant)
+	_, err := RunContext(ctx, "merge-base", "--is-ancestor", ancestor, descendant)
 	return err == nil
 }
 
@@ -154,19 +181,16 @@ func IsAncestorOf(ancestor, descendant string) bool {
 // empty, so callers degrade gracefully (no diagnostic, no false
 // positive).
 func IsOnFirstParentLine(sha, head string) bool {
+	return IsOnFirstParentLineContext(Context(), sha, head)
+}
+
+// IsOnFirstParentLineContext is IsOnFirstParentLine with an explicit context.
+func IsOnFirstParentLineContext(ctx context.Context, sha, head string) bool {
 	if sha == "" || head == "" {
 		return false
 	}
-	out, err := Run("rev-list", "--first-parent", "--max-count=5000", head)
-	if err != nil {
-		return false
-	}
-	for line := range strings.SplitSeq(out, "\n") {
-		if strings.TrimSpace(line) == sha {
-			return true
-		}
-	}
-	return false
+	line, err := FirstParentLineContext(ctx, head)
+	return err == nil && line[sha]
 }
 
 // IsPushedToUpstream returns true if the given SHA is reachable from the
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorewood/timbers/internal/output"
 )
@@ -493,3 +494,131 @@ func setupGitRepoWithCommit(t *testing.T, dir string) {
 		}
 	}
 }
+
+func TestRunHonorsPackageContext(t *testing.T) {
+	t.Cleanup(func() { SetContext(nil) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	SetContext(ctx)
+
+	_, err := Run("version")
+	if err == nil {
+		t.Fatal("Run() under canceled context should fail")
+	}
+	if !strings.Contains(err.Error(), "git version canceled") {
+		t.Errorf("error = %q, want cancellation message", err.Error())
+	}
+
+	SetContext(nil)
+	if _, err := Run("version"); err != nil {
+		t.Errorf("Run() after reset error = %v", err)
+	}
+}
+
+func TestRunContextKillsHungProcess(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	// The shell alias spawns a grandchild that holds git's stdout open,
+	// the same shape as a credential helper stuck on a prompt.
+	_, err := RunContext(ctx, "-c", "alias.hang=!sleep 30", "hang")
+	if err == nil {
+		t.Fatal("RunContext() should fail when the deadline passes")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("RunContext() returned after %v, want prompt kill", elapsed)
+	}
+	if !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("error = %q, want timeout message", err.Error())
+	}
+	var exitErr *output.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != output.ExitSystemError {
+		t.Errorf("error should be a system ExitError, got %T", err)
+	}
+}
+
+func TestCommandTimeoutKillsHungProcess(t *testing.T) {
+	SetCommandTimeout(100 * time.Millisecond)
+	t.Cleanup(func() { SetCommandTimeout(0) })
+
+	start := time.Now()
+	_, err := Run("-c", "alias.hang=!sleep 30", "hang")
+	if err == nil {
+		t.Fatal("Run() should fail when the per-command timeout passes")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Run() returned after %v, want prompt kill", elapsed)
+	}
+	if !strings.Contains(err.Error(), "per-command git timeout of 100ms") {
+		t.Errorf("error = %q, want per-command timeout message", err.Error())
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error should wrap context.DeadlineExceeded, got %v", err)
+	}
+
+	// The bound is per process: quick commands still run.
+	if _, err := Run("--version"); err != nil {
+		t.Errorf("Run(--version) under a timeout: %v", err)
+	}
+}
+
+func TestRunReturnsGitError(t *testing.T) {
+	_, err := Run("rev-parse", "--verify", "--quiet", "refs/heads/definitely-not-a-branch")
+	if err == nil {
+		t.Fatal("Run() expected error for unknown ref")
+	}
+
+	var gitErr *GitError
+	if !errors.As(err, &gitErr) {
+		t.Fatalf("Run() error should wrap *GitError, got %T", err)
+	}
+	if gitErr.ExitCode != 1 {
+		t.Errorf("ExitCode = %d, want 1", gitErr.ExitCode)
+	}
+	if len(gitErr.Args) == 0 || gitErr.Args[0] != "rev-parse" {
+		t.Errorf("Args = %v, want rev-parse invocation", gitErr.Args)
+	}
+	if got := gitErr.DetailLine(); !strings.Contains(got, "git rev-parse") || !strings.Contains(got, "exit 1") {
+		t.Errorf("DetailLine() = %q, want command and exit code", got)
+	}
+
+	var detailer output.ErrorDetailer
+	if !errors.As(err, &detailer) {
+		t.Error("Run() error should expose output.ErrorDetailer")
+	}
+}
+
+func TestRunInDir(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := RunInDir(dir, nil, "init", "--quiet"); err != nil {
+		t.Fatalf("RunInDir(init) error: %v", err)
+	}
+
+	env := []string{
+		"GIT_AUTHOR_NAME=Dir Author", "GIT_AUTHOR_EMAIL=dir@example.com",
+		"GIT_COMMITTER_NAME=Dir Author", "GIT_COMMITTER_EMAIL=dir@example.com",
+	}
+	if _, err := RunInDir(dir, env, "commit", "--allow-empty", "-m", "in dir"); err != nil {
+		t.Fatalf("RunInDir(commit) error: %v", err)
+	}
+
+	author, err := RunInDir(dir, nil, "log", "-1", "--format=%an <%ae>")
+	if err != nil {
+		t.Fatalf("RunInDir(log) error: %v", err)
+	}
+	if author != "Dir Author <dir@example.com>" {
+		t.Errorf("author = %q, want injected identity", author)
+	}
+
+	root, err := RunInDir(dir, nil, "rev-parse", "--show-toplevel")
+	if err != nil {
+		t.Fatalf("RunInDir(rev-parse) error: %v", err)
+	}
+	wantRoot, _ := filepath.EvalSymlinks(dir)
+	gotRoot, _ := filepath.EvalSymlinks(root)
+	if gotRoot != wantRoot {
+		t.Errorf("toplevel = %q, want %q (process cwd must not matter)", gotRoot, wantRoot)
+	}
+}
@@ -0,0 +1,340 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// coAuthorTrailer matches a Co-authored-by trailer line.
+var coAuthorTrailer = regexp.MustCompile(`(?i)^co-authored-by:\s*(.+)$`)
+
+// goGitBackend reads the repository in-process with go-git, so the hot read
+// paths need no git executable and no process startup. It differs from exec
+// in two ways: .mailmap is not applied to author or co-author identities,
+// and Short is always the first 7 characters of the SHA.
+type goGitBackend struct{}
+
+// Name implements Backend.
+func (goGitBackend) Name() string { return BackendGoGit }
+
+// open opens the repository containing the working directory. It is opened
+// per call: go-git reads refs and packs at open, and a cached handle would
+// miss commits made since. go-git takes no context, so open is where each
+// operation first checks whether ctx has ended; history walks check again
+// per commit.
+func (goGitBackend) open(ctx context.Context) (*gogit.Repository, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, output.NewSystemErrorWithCause("git backend canceled", err)
+	}
+	repo, err := gogit.PlainOpenWithOptions(".", &gogit.PlainOpenOptions{
+		DetectDotGit:          true,
+		EnableDotGitCommonDir: true,
+	})
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("not in a git repository", err).WithID(output.ErrCodeNotARepo)
+	}
+	return repo, nil
+}
+
+// IsRepo implements Backend.
+func (b goGitBackend) IsRepo(ctx context.Context) bool {
+	_, err := b.open(ctx)
+	return err == nil
+}
+
+// RepoRoot implements Backend. Bare repositories have no root.
+func (b goGitBackend) RepoRoot(ctx context.Context) (string, error) {
+	repo, err := b.open(ctx)
+	if err != nil {
+		return "", err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", output.NewSystemErrorWithCause("not in a git repository", err).WithID(output.ErrCodeNotARepo)
+	}
+	return worktree.Filesystem.Root(), nil
+}
+
+// HEAD implements Backend.
+func (b goGitBackend) HEAD(ctx context.Context) (string, error) {
+	repo, err := b.open(ctx)
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", output.NewSystemErrorWithCause("failed to get HEAD", err)
+	}
+	return head.Hash().String(), nil
+}
+
+// ResolveCommit implements Backend.
+func (b goGitBackend) ResolveCommit(ctx context.Context, ref string) (string, error) {
+	if ref == "" {
+		return "", output.NewUserError("empty commit ref")
+	}
+	repo, err := b.open(ctx)
+	if err != nil {
+		return "", err
+	}
+	commit, err := resolveCommitObject(repo, ref)
+	if err != nil {
+		return "", output.NewUserError("could not resolve commit ref: " + ref)
+	}
+	return commit.Hash.String(), nil
+}
+
+// Log implements Backend: commits reachable from toRef and not from
+// fromRef, newest commit time first, as git log orders them.
+func (b goGitBackend) Log(ctx context.Context, fromRef, toRef string, firstParent bool) ([]Commit, error) {
+	repo, err := b.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := logRange(ctx, repo, fromRef, toRef, firstParent)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to get git log for range "+fromRef+".."+toRef, err)
+	}
+	return commits, nil
+}
+
+// logRange walks the commits of fromRef..toRef for Log.
+func logRange(ctx context.Context, repo *gogit.Repository, fromRef, toRef string, firstParent bool) ([]Commit, error) {
+	fromCommit, err := resolveCommitObject(repo, fromRef)
+	if err != nil {
+		return nil, err
+	}
+	toCommit, err := resolveCommitObject(repo, toRef)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[plumbing.Hash]bool)
+	err = object.NewCommitPreorderIter(fromCommit, nil, nil).ForEach(func(commit *object.Commit) error {
+		excluded[commit.Hash] = true
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking %s: %w", fromRef, err)
+	}
+	if firstParent {
+		return firstParentRange(ctx, toCommit, excluded)
+	}
+
+	var commits []Commit
+	err = object.NewCommitIterCTime(toCommit, excluded, nil).ForEach(func(commit *object.Commit) error {
+		commits = append(commits, commitFromObject(commit))
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking %s: %w", toRef, err)
+	}
+	return commits, nil
+}
+
+// firstParentRange follows first parents from tip until an excluded commit
+// or the root.
+func firstParentRange(ctx context.Context, tip *object.Commit, excluded map[plumbing.Hash]bool) ([]Commit, error) {
+	var commits []Commit
+	for commit := tip; commit != nil && !excluded[commit.Hash]; {
+		commits = append(commits, commitFromObject(commit))
+		var err error
+		if commit, err = firstParentOf(commit); err != nil {
+			return nil, err
+		}
+		if err = ctx.Err(); err != nil {
+			return nil, fmt.Errorf("walking first parents: %w", err)
+		}
+	}
+	return commits, nil
+}
+
+// CommitsReachableFrom implements Backend.
+func (b goGitBackend) CommitsReachableFrom(ctx context.Context, ref string, firstParent bool) ([]Commit, error) {
+	repo, err := b.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	start, err := resolveCommitObject(repo, ref)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to get commits from "+ref, err)
+	}
+	if firstParent {
+		commits, walkErr := firstParentRange(ctx, start, nil)
+		if walkErr != nil {
+			return nil, output.NewSystemErrorWithCause("failed to get commits from "+ref, walkErr)
+		}
+		return commits, nil
+	}
+	var commits []Commit
+	err = object.NewCommitIterCTime(start, nil, nil).ForEach(func(commit *object.Commit) error {
+		commits = append(commits, commitFromObject(commit))
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to get commits from "+ref, err)
+	}
+	return commits, nil
+}
+
+// IsAncestorOf implements Backend.
+func (b goGitBackend) IsAncestorOf(ctx context.Context, ancestor, descendant string) bool {
+	if ancestor == "" || descendant == "" {
+		return false
+	}
+	repo, err := b.open(ctx)
+	if err != nil {
+		return false
+	}
+	older, err := resolveCommitObject(repo, ancestor)
+	if err != nil {
+		return false
+	}
+	newer, err := resolveCommitObject(repo, descendant)
+	if err != nil {
+		return false
+	}
+	is, err := older.IsAncestor(newer)
+	return err == nil && is
+}
+
+// CommitFiles implements Backend. Like git diff-tree, it lists nothing for
+// root and merge commits.
+func (b goGitBackend) CommitFiles(ctx context.Context, sha string) ([]string, error) {
+	repo, err := b.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	files, err := commitFiles(repo, sha)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to get files for commit "+sha, err)
+	}
+	return files, nil
+}
+
+// commitFiles lists the paths sha changed against its only parent, sorted.
+func commitFiles(repo *gogit.Repository, sha string) ([]string, error) {
+	commit, err := resolveCommitObject(repo, sha)
+	if err != nil || commit.NumParents() != 1 {
+		return nil, err
+	}
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return nil, fmt.Errorf("reading parent: %w", err)
+	}
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("reading parent tree: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("reading tree: %w", err)
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, fmt.Errorf("diffing trees: %w", err)
+	}
+
+	var files []string
+	for _, change := range changes {
+		name := change.To.Name
+		if name == "" {
+			name = change.From.Name
+		}
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// resolveCommitObject resolves a revision to a commit, peeling tags.
+func resolveCommitObject(repo *gogit.Repository, ref string) (*object.Commit, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", ref, err)
+	}
+	obj, err := repo.Object(plumbing.AnyObject, *hash)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", ref, err)
+	}
+	for {
+		switch typed := obj.(type) {
+		case *object.Commit:
+			return typed, nil
+		case *object.Tag:
+			if obj, err = typed.Object(); err != nil {
+				return nil, fmt.Errorf("peeling %s: %w", ref, err)
+			}
+		default:
+			return nil, errors.New(ref + " is not a commit")
+		}
+	}
+}
+
+// firstParentOf returns commit's first parent, or nil for a root commit.
+func firstParentOf(commit *object.Commit) (*object.Commit, error) {
+	if commit.NumParents() == 0 {
+		return nil, nil //nolint:nilnil // a root commit has no parent; callers stop the walk on nil
+	}
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return nil, fmt.Errorf("reading parent of %s: %w", commit.Hash, err)
+	}
+	return parent, nil
+}
+
+// commitFromObject converts a go-git commit to a Commit with the fields
+// parseCommitFields fills from git log.
+func commitFromObject(commit *object.Commit) Commit {
+	sha := commit.Hash.String()
+	subject, body := splitMessage(commit.Message)
+	return Commit{
+		SHA:         sha,
+		Short:       sha[:7],
+		Subject:     subject,
+		Body:        body,
+		Author:      commit.Author.Name,
+		AuthorEmail: commit.Author.Email,
+		CoAuthors:   coAuthorsFromMessage(commit.Message),
+		Date:        commit.Author.When,
+		CommitDate:  commit.Committer.When,
+		ParentCount: commit.NumParents(),
+	}
+}
+
+// splitMessage splits a commit message as %s and %b do: the subject is the
+// first paragraph joined onto one line, the body everything after it.
+func splitMessage(message string) (string, string) {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+	subject, body, _ := strings.Cut(message, "\n\n")
+	return strings.Join(strings.Fields(subject), " "), strings.TrimSpace(body)
+}
+
+// coAuthorsFromMessage parses Co-authored-by trailers from the message's
+// final paragraph.
+func coAuthorsFromMessage(message string) []Identity {
+	paragraphs := strings.Split(strings.TrimSpace(message), "\n\n")
+	if len(paragraphs) < 2 {
+		return nil
+	}
+	var values []string
+	for line := range strings.SplitSeq(paragraphs[len(paragraphs)-1], "\n") {
+		if match := coAuthorTrailer.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			values = append(values, match[1])
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return parseCoAuthors(strings.Join(values, "\x1e"))
+}
@@ -0,0 +1,99 @@
+// Package git — loading data for many commits in a constant number of git
+// processes, for batch logging and remapping.
+package git
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// firstParentWalkLimit bounds how far FirstParentLine walks back from head.
+const firstParentWalkLimit = 5000
+
+// FirstParentLine returns the SHAs on head's first-parent line, at most
+// firstParentWalkLimit of them, from one git rev-list. Callers testing many
+// commits against the same head load it once instead of calling
+// IsOnFirstParentLine per commit.
+func FirstParentLine(head string) (map[string]bool, error) {
+	return FirstParentLineContext(Context(), head)
+}
+
+// FirstParentLineContext is FirstParentLine with an explicit context.
+func FirstParentLineContext(ctx context.Context, head string) (map[string]bool, error) {
+	out, err := RunContext(ctx, "rev-list", "--first-parent", "--max-count="+strconv.Itoa(firstParentWalkLimit), head)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to walk the first-parent line of "+head, err)
+	}
+	line := make(map[string]bool)
+	for sha := range strings.SplitSeq(out, "\n") {
+		if sha = strings.TrimSpace(sha); sha != "" {
+			line[sha] = true
+		}
+	}
+	return line, nil
+}
+
+// LookupCommits returns the commits named by shas, in order, whether or not
+// they are reachable from any ref — after a rebase the originals linger in
+// the object store until gc. SHAs whose objects are gone are skipped. It
+// runs three git processes however many SHAs there are: cat-file
+// --batch-check to drop the missing ones, log --stdin for the metadata,
+// and check-mailmap for co-authors.
+func LookupCommits(shas []string) ([]Commit, error) {
+	return LookupCommitsContext(Context(), shas)
+}
+
+// LookupCommitsContext is LookupCommits with an explicit context.
+func LookupCommitsContext(ctx context.Context, shas []string) ([]Commit, error) {
+	present, err := existingCommits(ctx, shas)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to look up commits", err)
+	}
+	if len(present) == 0 {
+		return nil, nil
+	}
+	out, err := RunStdinContext(ctx, strings.Join(present, "\n")+"\n",
+		"log", "--no-walk=unsorted", "--stdin", "--pretty=format:"+commitFormat())
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to look up commits", err)
+	}
+
+	commits := parseCommits(out)
+	normalizeCoAuthors(ctx, commits)
+	return commits, nil
+}
+
+// ExistingCommits returns the full SHA of each name in shas that resolves
+// to a commit object, in order, from one git process. Names of missing
+// objects, or of objects that are not commits, are left out.
+func ExistingCommits(shas []string) ([]string, error) {
+	present, err := existingCommits(Context(), shas)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to look up commits", err)
+	}
+	return present, nil
+}
+
+// existingCommits returns the full SHA of each name in shas that resolves
+// to a commit object, in order, from one git cat-file --batch-check.
+func existingCommits(ctx context.Context, shas []string) ([]string, error) {
+	if len(shas) == 0 {
+		return nil, nil
+	}
+	out, err := RunStdinContext(ctx, strings.Join(shas, "\n")+"\n",
+		"cat-file", "--batch-check=%(objectname) %(objecttype)")
+	if err != nil {
+		return nil, err
+	}
+	present := make([]string, 0, len(shas))
+	for line := range strings.SplitSeq(out, "\n") {
+		// "<sha> <type>", or "<name> missing" for an absent object.
+		if sha, objectType, _ := strings.Cut(line, " "); objectType == "commit" {
+			present = append(present, sha)
+		}
+	}
+	return present, nil
+}
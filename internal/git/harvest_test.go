@@ -0,0 +1,103 @@
+package git
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// countProcesses runs fn with tracing on and returns how many git
+// processes it started.
+func countProcesses(t *testing.T, fn func()) int {
+	t.Helper()
+	count := 0
+	SetTrace(func([]string, time.Duration) { count++ })
+	t.Cleanup(func() { SetTrace(nil) })
+	fn()
+	SetTrace(nil)
+	return count
+}
+
+func TestLookupCommits(t *testing.T) {
+	dir := t.TempDir()
+	setupGitRepoWithCommit(t, dir)
+	shas := make([]string, 0, 10)
+	for idx := range 10 {
+		if _, err := Run("commit", "--allow-empty", "-m", "commit "+strings.Repeat("x", idx)); err != nil {
+			t.Fatal(err)
+		}
+		sha, err := HEAD()
+		if err != nil {
+			t.Fatal(err)
+		}
+		shas = append(shas, sha)
+	}
+	// Oldest first, with a missing SHA in the middle.
+	query := append(append([]string{}, shas[:5]...), strings.Repeat("0", 40))
+	query = append(query, shas[5:]...)
+
+	var commits []Commit
+	var err error
+	processes := countProcesses(t, func() { commits, err = LookupCommits(query) })
+	if err != nil {
+		t.Fatalf("LookupCommits() error = %v", err)
+	}
+	if len(commits) != len(shas) {
+		t.Fatalf("LookupCommits() returned %d commits, want %d", len(commits), len(shas))
+	}
+	for idx, commit := range commits {
+		if commit.SHA != shas[idx] {
+			t.Errorf("commit %d = %s, want %s (input order)", idx, commit.SHA, shas[idx])
+		}
+	}
+	if processes > 3 {
+		t.Errorf("LookupCommits() of %d SHAs ran %d git processes, want at most 3", len(query), processes)
+	}
+
+	if commits, err := LookupCommits([]string{strings.Repeat("0", 40)}); err != nil || commits != nil {
+		t.Errorf("LookupCommits(missing) = %v, %v; want nil, nil", commits, err)
+	}
+}
+
+func TestFirstParentLine(t *testing.T) {
+	dir := t.TempDir()
+	setupGitRepoWithCommit(t, dir)
+	base, _ := HEAD()
+	for _, args := range [][]string{
+		{"checkout", "-q", "-b", "side"},
+		{"commit", "--allow-empty", "-m", "side"},
+		{"checkout", "-q", "-"},
+		{"commit", "--allow-empty", "-m", "main"},
+		{"merge", "--no-ff", "-q", "-m", "merge", "side"},
+	} {
+		if _, err := Run(args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	head, _ := HEAD()
+	side, _ := ResolveCommit("side")
+	mainline, _ := ResolveCommit("HEAD^1")
+
+	line, err := FirstParentLine(head)
+	if err != nil {
+		t.Fatalf("FirstParentLine() error = %v", err)
+	}
+	for sha, want := range map[string]bool{head: true, mainline: true, base: true, side: false} {
+		if line[sha] != want {
+			t.Errorf("FirstParentLine()[%s] = %v, want %v", sha[:7], line[sha], want)
+		}
+		if got := IsOnFirstParentLine(sha, head); got != want {
+			t.Errorf("IsOnFirstParentLine(%s) = %v, want %v", sha[:7], got, want)
+		}
+	}
+}
+
+func TestRunStdin(t *testing.T) {
+	dir := t.TempDir()
+	setupGitRepoWithCommit(t, dir)
+	head, _ := HEAD()
+	out, err := RunStdin(head+"\n", "cat-file", "--batch-check=%(objecttype)")
+	if err != nil || out != "commit" {
+		t.Errorf("RunStdin() = %q, %v; want commit", out, err)
+	}
+}
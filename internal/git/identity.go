@@ -49,7 +49,7 @@ func validIdentityEmail(email string) bool {
 
 // normalizeCoAuthors applies Git's repository mailmap to trailer identities.
 // Failure leaves the already parsed identities unchanged.
-func normalizeCoAuthors(commits []Commit) {
+func normalizeCoAuthors(ctx context.Context, commits []Commit) {
 	input := make([]string, 0, len(commits))
 	positions := make([][2]int, 0, len(commits))
 	for commitIdx := range commits {
@@ -62,7 +62,9 @@ func normalizeCoAuthors(commits []Commit) {
 		return
 	}
 
-	cmd := exec.CommandContext(context.Background(), "git", "check-mailmap", "--stdin")
+	cmdCtx, cancel := commandContext(ctx)
+	defer cancel()
+	cmd := exec.CommandContext(cmdCtx, "git", "check-mailmap", "--stdin")
 	cmd.Stdin = strings.NewReader(strings.Join(input, "\n") + "\n")
 	out, err := cmd.Output()
 	if err != nil {
@@ -0,0 +1,45 @@
+// Package git — Jujutsu (jj) colocated repository support.
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IsJJColocated reports whether the repository is a Jujutsu (jj) repository
+// colocated with its git store: a .jj directory beside .git at the root.
+//
+// jj keeps git HEAD detached at the parent of its working-copy commit (@-),
+// the newest finished change; @ itself is the change in progress, so HEAD
+// is already the commit timbers should document. jj never runs git hooks,
+// and its rebases rewrite commits without firing post-rewrite. Returns
+// false on any error.
+func IsJJColocated() bool {
+	return IsJJColocatedContext(Context())
+}
+
+// IsJJColocatedContext is IsJJColocated with an explicit context.
+func IsJJColocatedContext(ctx context.Context) bool {
+	colocated, _ := cachedQuery(ctx, "is-jj", func() (bool, error) {
+		root, err := CurrentBackend().RepoRoot(ctx)
+		if err != nil {
+			return false, nil //nolint:nilerr // outside a repository there is no jj store
+		}
+		info, err := os.Stat(filepath.Join(root, ".jj", "repo"))
+		return err == nil && info.IsDir(), nil
+	})
+	return colocated
+}
+
+// jjBookmarkAtHEAD returns a branch pointing at HEAD, which in a colocated
+// repository is a jj bookmark on @-, or "" when there is none.
+func jjBookmarkAtHEAD() string {
+	out, err := Run("for-each-ref", "--points-at", "HEAD", "--format=%(refname:short)", "refs/heads")
+	if err != nil || out == "" {
+		return ""
+	}
+	first, _, _ := strings.Cut(out, "\n")
+	return first
+}
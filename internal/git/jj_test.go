@@ -0,0 +1,29 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJJColocatedRepo(t *testing.T) {
+	dir := t.TempDir()
+	setupGitRepoWithCommit(t, dir)
+	if IsJJColocated() {
+		t.Fatal("IsJJColocated() = true without a .jj directory")
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".jj", "repo"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if !IsJJColocated() {
+		t.Fatal("IsJJColocated() = false with .jj/repo at the root")
+	}
+
+	// jj keeps HEAD detached at @-, with bookmarks exported as branches.
+	mustRun(t, "branch", "feature")
+	mustRun(t, "checkout", "--detach")
+	if branch, err := CurrentBranch(); err != nil || branch != "feature" {
+		t.Errorf("CurrentBranch() = %q, %v; want the bookmark on HEAD", branch, err)
+	}
+}
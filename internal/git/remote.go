@@ -0,0 +1,102 @@
+package git
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// defaultRemote is the remote used when the current branch has no upstream.
+const defaultRemote = "origin"
+
+// WebURL returns the browsable URL of the repository on its forge (e.g.
+// "https://github.com/owner/repo"), taken from the current branch's upstream
+// remote, else origin. Returns "" when there is no such remote or its URL
+// has no web form (a local path, say).
+func WebURL() string {
+	remote := defaultRemote
+	if upstream, err := Run("rev-parse", "--abbrev-ref", "@{u}"); err == nil {
+		if name, _, ok := strings.Cut(upstream, "/"); ok && name != "" {
+			remote = name
+		}
+	}
+	remoteURL, err := Run("remote", "get-url", remote)
+	if err != nil {
+		return ""
+	}
+	return RemoteWebURL(remoteURL)
+}
+
+// RemoteWebURL converts a remote URL to the repository's web URL. It
+// accepts https, ssh, and git URLs and the scp-like "git@host:owner/repo"
+// form, dropping credentials, ports, and the ".git" suffix. Returns "" for
+// anything else.
+func RemoteWebURL(remote string) string {
+	remote = strings.TrimSpace(remote)
+	var host, path string
+	if !strings.Contains(remote, "://") {
+		// scp-like: [user@]host:path
+		hostPart, pathPart, ok := strings.Cut(remote, ":")
+		if !ok || strings.Contains(hostPart, "/") {
+			return ""
+		}
+		_, host, _ = strings.Cut(hostPart, "@")
+		if host == "" {
+			host = hostPart
+		}
+		path = pathPart
+	} else {
+		parsed, err := url.Parse(remote)
+		if err != nil {
+			return ""
+		}
+		switch parsed.Scheme {
+		case "https", "http", "ssh", "git", "git+ssh":
+		default:
+			return ""
+		}
+		host, path = parsed.Hostname(), parsed.Path
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || path == "" {
+		return ""
+	}
+	return "https://" + host + "/" + path
+}
+
+// CommitWebURL returns the web page for sha in the repository at webURL (as
+// returned by WebURL), or "" when either is empty.
+func CommitWebURL(webURL, sha string) string {
+	if webURL == "" || sha == "" {
+		return ""
+	}
+	// Bitbucket is the one major forge that pluralizes the path.
+	if strings.Contains(webURL, "bitbucket.") {
+		return webURL + "/commits/" + sha
+	}
+	return webURL + "/commit/" + sha
+}
+
+// PushCurrentBranch pushes HEAD to the current branch's upstream, or to the
+// same-named branch on origin when it has none. It returns the remote and
+// branch pushed to. Fails when HEAD is detached.
+func PushCurrentBranch() (string, string, error) {
+	branch := AttachedBranch()
+	if branch == "" {
+		return "", "", output.NewUserError("HEAD is detached; check out a branch to push")
+	}
+	remote, err := Run("config", "--get", "branch."+branch+".remote")
+	if err != nil || remote == "" || remote == "." {
+		remote = defaultRemote
+	}
+	target := branch
+	if merge, mergeErr := Run("config", "--get", "branch."+branch+".merge"); mergeErr == nil && merge != "" {
+		target = strings.TrimPrefix(merge, "refs/heads/")
+	}
+	if _, err := Run("push", remote, "HEAD:refs/heads/"+target); err != nil {
+		return remote, target, err
+	}
+	return remote, target, nil
+}
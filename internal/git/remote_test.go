@@ -0,0 +1,41 @@
+package git
+
+import "testing"
+
+func TestRemoteWebURL(t *testing.T) {
+	tests := []struct {
+		remote string
+		want   string
+	}{
+		{"git@github.com:gorewood/timbers.git", "https://github.com/gorewood/timbers"},
+		{"https://github.com/gorewood/timbers.git", "https://github.com/gorewood/timbers"},
+		{"https://token@gitlab.com/group/sub/repo", "https://gitlab.com/group/sub/repo"},
+		{"ssh://git@git.example.com:2222/team/repo.git", "https://git.example.com/team/repo"},
+		{"git://example.org/repo.git/", "https://example.org/repo"},
+		{"/srv/git/repo.git", ""},
+		{"file:///srv/git/repo.git", ""},
+		{"../sibling", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := RemoteWebURL(tt.remote); got != tt.want {
+			t.Errorf("RemoteWebURL(%q) = %q, want %q", tt.remote, got, tt.want)
+		}
+	}
+}
+
+func TestCommitWebURL(t *testing.T) {
+	tests := []struct {
+		web, sha, want string
+	}{
+		{"https://github.com/o/r", "abc123", "https://github.com/o/r/commit/abc123"},
+		{"https://bitbucket.org/o/r", "abc123", "https://bitbucket.org/o/r/commits/abc123"},
+		{"", "abc123", ""},
+		{"https://github.com/o/r", "", ""},
+	}
+	for _, tt := range tests {
+		if got := CommitWebURL(tt.web, tt.sha); got != tt.want {
+			t.Errorf("CommitWebURL(%q, %q) = %q, want %q", tt.web, tt.sha, got, tt.want)
+		}
+	}
+}
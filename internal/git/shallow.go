@@ -0,0 +1,53 @@
+// Package git — shallow clone detection.
+package git
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// UnshallowHint tells the user how to fetch the full history of a shallow
+// clone.
+const UnshallowHint = "git fetch --unshallow (in GitHub Actions, set fetch-depth: 0 on actions/checkout)"
+
+// IsShallow reports whether the repository is a shallow clone, as CI
+// checkouts often are: history stops at the fetch depth, so an anchor or
+// range start beyond it is missing rather than rewritten. Returns false on
+// any git error.
+func IsShallow() bool {
+	return IsShallowContext(Context())
+}
+
+// IsShallowContext is IsShallow with an explicit context.
+func IsShallowContext(ctx context.Context) bool {
+	shallow, _ := cachedQuery(ctx, "is-shallow", func() (bool, error) {
+		out, err := RunContext(ctx, "rev-parse", "--is-shallow-repository")
+		return err == nil && out == "true", nil
+	})
+	return shallow
+}
+
+// FetchedCommitCount returns how many commits are reachable from HEAD — in
+// a shallow clone, the depth of the fetched history.
+func FetchedCommitCount() (int, error) {
+	out, err := Run("rev-list", "--count", "HEAD")
+	if err != nil {
+		return 0, err
+	}
+	count, err := strconv.Atoi(out)
+	if err != nil {
+		return 0, output.NewSystemErrorWithCause("failed to count commits", err)
+	}
+	return count, nil
+}
+
+// shallowRangeHint is appended to a failed range walk's message in a
+// shallow clone, where the likely cause is a ref beyond the fetched history.
+func shallowRangeHint(ctx context.Context) string {
+	if !IsShallowContext(ctx) {
+		return ""
+	}
+	return " (shallow clone: the range may start before the fetched history; run " + UnshallowHint + ")"
+}
@@ -0,0 +1,34 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsShallowDetectsDepthLimitedClone(t *testing.T) {
+	src := t.TempDir()
+	setupGitRepoWithCommit(t, src)
+	mustRun(t, "commit", "--allow-empty", "-m", "second")
+	mustRun(t, "commit", "--allow-empty", "-m", "third")
+	if IsShallow() {
+		t.Fatal("IsShallow() = true for a full repository")
+	}
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	mustRun(t, "clone", "--depth", "1", "file://"+src, clone)
+	if err := os.Chdir(clone); err != nil {
+		t.Fatal(err)
+	}
+	if !IsShallow() {
+		t.Fatal("IsShallow() = false for a --depth 1 clone")
+	}
+	if count, err := FetchedCommitCount(); err != nil || count != 1 {
+		t.Errorf("FetchedCommitCount() = %d, %v; want 1", count, err)
+	}
+	_, err := Log("HEAD~2", "HEAD")
+	if err == nil || !strings.Contains(err.Error(), "shallow clone") {
+		t.Errorf("Log beyond the fetched history = %v, want a shallow clone hint", err)
+	}
+}
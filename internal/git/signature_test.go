@@ -0,0 +1,42 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommitSignatures_Unsigned(t *testing.T) {
+	setupGitRepoWithCommit(t, t.TempDir())
+
+	head, err := HEAD()
+	if err != nil {
+		t.Fatalf("HEAD() error = %v", err)
+	}
+	sigs, err := CommitSignatures([]string{head})
+	if err != nil {
+		t.Fatalf("CommitSignatures() error = %v", err)
+	}
+	sig, ok := sigs[head]
+	if !ok {
+		t.Fatalf("CommitSignatures() missing %s in %v", head, sigs)
+	}
+	if sig.Status != SignatureUnsigned || sig.Valid() {
+		t.Errorf("signature = %+v, want unsigned and not valid", sig)
+	}
+}
+
+func TestCommitSignatures_Empty(t *testing.T) {
+	sigs, err := CommitSignatures(nil)
+	if err != nil || len(sigs) != 0 {
+		t.Errorf("CommitSignatures(nil) = %v, %v; want empty map, nil", sigs, err)
+	}
+}
+
+func TestCommitSignatures_UnknownCommit(t *testing.T) {
+	setupGitRepoWithCommit(t, t.TempDir())
+
+	_, err := CommitSignatures([]string{strings.Repeat("0", 40)})
+	if err == nil {
+		t.Error("CommitSignatures() expected error for unknown commit")
+	}
+}
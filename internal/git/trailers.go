@@ -0,0 +1,92 @@
+// Package git — commit trailer parsing.
+package git
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// Trailer is one "Key: value" trailer from the end of a commit message,
+// such as Reviewed-by or Co-authored-by. Continuation lines are unfolded
+// into Value. Key keeps the spelling used in the message.
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// ParseTrailers returns the trailers of a commit message, in order, as
+// git interpret-trailers --parse reads them.
+func ParseTrailers(message string) ([]Trailer, error) {
+	return ParseTrailersContext(Context(), message)
+}
+
+// ParseTrailersContext is ParseTrailers with an explicit context.
+func ParseTrailersContext(ctx context.Context, message string) ([]Trailer, error) {
+	out, err := RunStdinContext(ctx, message, "interpret-trailers", "--parse")
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to parse commit trailers", err)
+	}
+	var trailers []Trailer
+	for line := range strings.SplitSeq(out, "\n") {
+		if trailer, ok := parseTrailerLine(line); ok {
+			trailers = append(trailers, trailer)
+		}
+	}
+	return trailers, nil
+}
+
+// CommitTrailers returns the trailers of each commit in shas, keyed by full
+// SHA. Commits without trailers are absent from the map. One git log
+// --stdin reads every commit, using the trailer parser behind git
+// interpret-trailers.
+func CommitTrailers(shas []string) (map[string][]Trailer, error) {
+	return CommitTrailersContext(Context(), shas)
+}
+
+// CommitTrailersContext is CommitTrailers with an explicit context.
+func CommitTrailersContext(ctx context.Context, shas []string) (map[string][]Trailer, error) {
+	result := make(map[string][]Trailer)
+	if len(shas) == 0 {
+		return result, nil
+	}
+	out, err := RunStdinContext(ctx, strings.Join(shas, "\n")+"\n",
+		"log", "--no-walk=unsorted", "--stdin",
+		"--pretty=format:%H%x1f%(trailers:unfold,separator=%x1f)%x1e")
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to read commit trailers", err)
+	}
+	for record := range strings.SplitSeq(out, "\x1e") {
+		fields := strings.Split(strings.TrimSpace(record), "\x1f")
+		for _, field := range fields[1:] {
+			if trailer, ok := parseTrailerLine(field); ok {
+				result[fields[0]] = append(result[fields[0]], trailer)
+			}
+		}
+	}
+	return result, nil
+}
+
+// TrailerValues returns the values of every trailer named key, compared
+// case-insensitively.
+func TrailerValues(trailers []Trailer, key string) []string {
+	var values []string
+	for _, trailer := range trailers {
+		if strings.EqualFold(trailer.Key, key) {
+			values = append(values, trailer.Value)
+		}
+	}
+	return values
+}
+
+// parseTrailerLine splits a "Key: value" line. Lines without a key or
+// value are not trailers.
+func parseTrailerLine(line string) (Trailer, bool) {
+	key, value, ok := strings.Cut(line, ":")
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if !ok || key == "" || value == "" {
+		return Trailer{}, false
+	}
+	return Trailer{Key: key, Value: value}, true
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+const trailerMessage = `Fix login redirect
+
+The callback dropped the return URL.
+
+Reviewed-by: Ada Lovelace <ada@example.com>
+Ticket: PROJ-42
+Co-authored-by: Grace Hopper
+ <grace@example.com>
+`
+
+func TestParseTrailers(t *testing.T) {
+	setupGitRepoWithCommit(t, t.TempDir())
+
+	trailers, err := ParseTrailers(trailerMessage)
+	if err != nil {
+		t.Fatalf("ParseTrailers() error = %v", err)
+	}
+	want := []Trailer{
+		{Key: "Reviewed-by", Value: "Ada Lovelace <ada@example.com>"},
+		{Key: "Ticket", Value: "PROJ-42"},
+		{Key: "Co-authored-by", Value: "Grace Hopper <grace@example.com>"},
+	}
+	if !reflect.DeepEqual(trailers, want) {
+		t.Errorf("ParseTrailers() = %+v, want %+v", trailers, want)
+	}
+
+	none, err := ParseTrailers("Subject only\n")
+	if err != nil || len(none) != 0 {
+		t.Errorf("ParseTrailers(no trailers) = %+v, %v; want none", none, err)
+	}
+}
+
+func TestCommitTrailers(t *testing.T) {
+	setupGitRepoWithCommit(t, t.TempDir())
+	plain, err := HEAD()
+	if err != nil {
+		t.Fatalf("HEAD() error = %v", err)
+	}
+	mustRun(t, "commit", "--allow-empty", "-m", trailerMessage)
+	trailed, err := HEAD()
+	if err != nil {
+		t.Fatalf("HEAD() error = %v", err)
+	}
+
+	trailers, err := CommitTrailers([]string{plain, trailed})
+	if err != nil {
+		t.Fatalf("CommitTrailers() error = %v", err)
+	}
+	if _, ok := trailers[plain]; ok {
+		t.Errorf("commit without trailers has %+v", trailers[plain])
+	}
+	got := trailers[trailed]
+	if len(got) != 3 {
+		t.Fatalf("CommitTrailers()[%s] = %+v, want 3 trailers", trailed, got)
+	}
+	if got[2].Value != "Grace Hopper <grace@example.com>" {
+		t.Errorf("folded trailer = %q, want it unfolded", got[2].Value)
+	}
+	if values := TrailerValues(got, "ticket"); !reflect.DeepEqual(values, []string{"PROJ-42"}) {
+		t.Errorf("TrailerValues(ticket) = %v, want [PROJ-42]", values)
+	}
+}
@@ -0,0 +1,102 @@
+// Package git — reading files out of a tree-ish, for bare repositories.
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// IsBare reports whether the current repository is bare: it has a git
+// directory but no working tree, as on a CI mirror.
+func IsBare() bool {
+	isBare, _ := cachedQuery(Context(), "is-bare", func() (bool, error) {
+		out, err := Run("rev-parse", "--is-bare-repository")
+		return err == nil && out == "true", nil
+	})
+	return isBare
+}
+
+// TreeFiles returns the contents of every file under dir in treeish, keyed by
+// slash-separated path relative to the repository root. It needs no working
+// tree, so it works in bare repositories. A dir absent from the tree yields
+// an empty map. Contents are read with one git ls-tree and one
+// git cat-file --batch, however many files there are.
+func TreeFiles(treeish, dir string) (map[string][]byte, error) {
+	return TreeFilesContext(Context(), treeish, dir)
+}
+
+// TreeFilesContext is TreeFiles with an explicit context.
+func TreeFilesContext(ctx context.Context, treeish, dir string) (map[string][]byte, error) {
+	out, err := RunContext(ctx, "ls-tree", "-r", "-z", "--full-tree", treeish, "--", dir)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to list "+dir+" in "+treeish, err)
+	}
+
+	var paths, blobs []string
+	for record := range strings.SplitSeq(out, "\x00") {
+		// "<mode> SP <type> SP <object> TAB <path>"
+		meta, path, found := strings.Cut(record, "\t")
+		fields := strings.Fields(meta)
+		if !found || len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		paths = append(paths, path)
+		blobs = append(blobs, fields[2])
+	}
+
+	contents, err := CatFileBatch(ctx, blobs)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to read "+dir+" from "+treeish, err)
+	}
+	files := make(map[string][]byte, len(paths))
+	for idx, path := range paths {
+		files[path] = contents[idx]
+	}
+	return files, nil
+}
+
+// CatFileBatch returns the raw contents of each object, in order, read by a
+// single git cat-file --batch process.
+func CatFileBatch(ctx context.Context, objects []string) ([][]byte, error) {
+	if len(objects) == 0 {
+		return nil, nil
+	}
+	out, err := runRaw(ctx, "", nil, strings.NewReader(strings.Join(objects, "\n")+"\n"), "cat-file", "--batch")
+	if err != nil {
+		return nil, err
+	}
+	return parseCatFileBatch(bufio.NewReader(bytes.NewReader(out)), len(objects))
+}
+
+// parseCatFileBatch reads count objects in git cat-file --batch format:
+// "<sha> <type> <size>\n<contents>\n" each, or "<name> missing\n".
+func parseCatFileBatch(reader *bufio.Reader, count int) ([][]byte, error) {
+	contents := make([][]byte, 0, count)
+	for range count {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("reading cat-file header: %w", err)
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("object %s", strings.TrimSpace(header))
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("bad cat-file header %q: %w", strings.TrimSpace(header), err)
+		}
+		body := make([]byte, size+1) // contents plus the trailing newline
+		if _, err := io.ReadFull(reader, body); err != nil {
+			return nil, fmt.Errorf("reading object %s: %w", fields[0], err)
+		}
+		contents = append(contents, body[:size])
+	}
+	return contents, nil
+}
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTreeFilesInBareRepo(t *testing.T) {
+	src := t.TempDir()
+	setupGitRepoWithCommit(t, src)
+	if err := os.MkdirAll(filepath.Join(src, ".timbers", "2026"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		".timbers/2026/a.json": "{\"a\":1}\n",
+		".timbers/b.json":      "line one\nline two",
+		"README.md":            "not ledger\n",
+	}
+	for path, body := range files {
+		if err := os.WriteFile(filepath.Join(src, filepath.FromSlash(path)), []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := Run("add", "-A"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Run("commit", "-m", "ledger"); err != nil {
+		t.Fatal(err)
+	}
+	if IsBare() {
+		t.Error("IsBare() = true in a working tree")
+	}
+
+	bare := filepath.Join(t.TempDir(), "mirror.git")
+	//nolint:gosec // test helper with fixed command
+	if out, err := exec.CommandContext(t.Context(), "git", "clone", "--bare", src, bare).CombinedOutput(); err != nil {
+		t.Fatalf("clone --bare: %v\n%s", err, out)
+	}
+	t.Chdir(bare)
+	if !IsBare() {
+		t.Fatal("IsBare() = false in a bare clone")
+	}
+
+	got, err := TreeFiles("HEAD", ".timbers")
+	if err != nil {
+		t.Fatalf("TreeFiles() error = %v", err)
+	}
+	want := map[string][]byte{
+		".timbers/2026/a.json": []byte(files[".timbers/2026/a.json"]),
+		".timbers/b.json":      []byte(files[".timbers/b.json"]),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TreeFiles() = %q, want %q", got, want)
+	}
+
+	got, err = TreeFiles("HEAD", "missing")
+	if err != nil || len(got) != 0 {
+		t.Errorf("TreeFiles(missing) = %q, %v; want empty", got, err)
+	}
+	if _, err := TreeFiles("no-such-ref", ".timbers"); err == nil {
+		t.Error("TreeFiles(no-such-ref) should fail")
+	}
+}
+
+func TestParseCatFileBatch(t *testing.T) {
+	input := "aaa blob 3\nx\ny\nbbb blob 0\n\n"
+	got, err := parseCatFileBatch(bufio.NewReader(strings.NewReader(input)), 2)
+	if err != nil {
+		t.Fatalf("parseCatFileBatch() error = %v", err)
+	}
+	if want := [][]byte{[]byte("x\ny"), {}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCatFileBatch() = %q, want %q", got, want)
+	}
+
+	_, err = parseCatFileBatch(bufio.NewReader(strings.NewReader("ccc missing\n")), 1)
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("missing object error = %v, want it named", err)
+	}
+}
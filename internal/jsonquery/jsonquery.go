@@ -0,0 +1,74 @@
+// Package jsonquery evaluates jq expressions against command output, so
+// agents can extract fields without an external jq binary.
+package jsonquery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/itchyny/gojq"
+)
+
+// Query is a compiled jq expression.
+type Query struct {
+	expr string
+	code *gojq.Code
+}
+
+// Compile parses and compiles expr. The expression is sandboxed: it sees
+// only its input, with no environment variables ($ENV, env) and no
+// input/inputs.
+func Compile(expr string) (*Query, error) {
+	parsed, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --query %q: %w", expr, err)
+	}
+	code, err := gojq.Compile(parsed, gojq.WithEnvironLoader(func() []string { return nil }))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --query %q: %w", expr, err)
+	}
+	return &Query{expr: expr, code: code}, nil
+}
+
+// Apply runs the query on data, which is first converted to plain JSON
+// values (so struct tags decide field names, as in the unfiltered output),
+// and returns every value the expression emits.
+func (q *Query) Apply(ctx context.Context, data any) ([]any, error) {
+	input, err := normalize(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []any
+	iter := q.code.RunWithContext(ctx, input)
+	for {
+		value, ok := iter.Next()
+		if !ok {
+			return results, nil
+		}
+		if err, isErr := value.(error); isErr {
+			var halt *gojq.HaltError
+			if errors.As(err, &halt) && halt.Value() == nil {
+				return results, nil
+			}
+			return results, fmt.Errorf("--query %q: %w", q.expr, err)
+		}
+		results = append(results, value)
+	}
+}
+
+// normalize round-trips data through encoding/json into the generic values
+// gojq operates on.
+func normalize(data any) (any, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encoding JSON: %w", err)
+	}
+	var input any
+	if err := json.Unmarshal(encoded, &input); err != nil {
+		return nil, fmt.Errorf("decoding JSON: %w", err)
+	}
+	return input, nil
+}
@@ -0,0 +1,63 @@
+package jsonquery
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type sample struct {
+	ID   string   `json:"id"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+func TestApply(t *testing.T) {
+	data := []sample{{ID: "a", Tags: []string{"x"}}, {ID: "b"}}
+
+	tests := []struct {
+		name string
+		expr string
+		want []any
+	}{
+		{name: "identity field", expr: ".[0].id", want: []any{"a"}},
+		{name: "struct tags decide names", expr: ".[1] | keys", want: []any{[]any{"id"}}},
+		{name: "multiple outputs", expr: ".[].id", want: []any{"a", "b"}},
+		{name: "no outputs", expr: "empty", want: nil},
+		{name: "environment hidden", expr: "$ENV | length", want: []any{0}},
+		{name: "halt stops quietly", expr: ".[0].id, halt", want: []any{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := Compile(tt.expr)
+			if err != nil {
+				t.Fatalf("Compile(%q) error = %v", tt.expr, err)
+			}
+			got, err := query.Apply(context.Background(), data)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Apply() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileInvalid(t *testing.T) {
+	_, err := Compile(".[")
+	if err == nil || !strings.Contains(err.Error(), "invalid --query") {
+		t.Errorf("Compile() error = %v, want invalid --query", err)
+	}
+}
+
+func TestApplyRuntimeError(t *testing.T) {
+	query, err := Compile(".id + 1")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if _, err := query.Apply(context.Background(), sample{ID: "a"}); err == nil {
+		t.Error("Apply() error = nil, want type error")
+	}
+}
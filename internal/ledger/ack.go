@@ -11,7 +11,7 @@ import (
 // KindAck is the kind identifier for ack records — a "decision to skip"
 // note that documents why a particular commit doesn't merit a content
 // entry. Lives alongside entries under the same schema family
-// (timbers.devlog/v1) but has a different shape.
+// (timbers.devlog) but has a different shape.
 const KindAck = "ack"
 
 // ackIDPrefix is the prefix for all ack IDs (parallel to "tb_" for entries).
@@ -31,14 +31,22 @@ func (fs *FileStorage) ackPath(id string) string {
 // existing "timbers: document <id>" convention but with "ack" in place
 // of the entry id-prefix.
 func (fs *FileStorage) WriteAck(ack *Ack) error {
+	if err := fs.checkWritable("write ack " + ack.ID); err != nil {
+		return err
+	}
 	if err := ack.Validate(); err != nil {
 		return output.NewUserError(err.Error())
 	}
+	unlock, err := fs.lock("write ack " + ack.ID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	path := fs.ackPath(ack.ID)
 
-	if _, err := os.Stat(path); err == nil {
-		return output.NewConflictError("ack already exists: " + ack.ID)
+	if _, statErr := os.Stat(path); statErr == nil {
+		return output.NewConflictError("ack already exists: " + ack.ID).WithID(output.ErrCodeEntryExists)
 	}
 
 	data, err := ack.ToJSON()
@@ -76,19 +84,15 @@ func (fs *FileStorage) WriteAck(ack *Ack) error {
 // entries and acks can share the same date-dir layout.
 func (fs *FileStorage) ListAcks() ([]*Ack, error) {
 	var acks []*Ack
-	walkErr := filepath.WalkDir(fs.dir, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if d.IsDir() || !strings.HasSuffix(d.Name(), ".json") {
+	walkErr := fs.walkFiles(func(path, fileName string) error {
+		if !strings.HasSuffix(fileName, ".json") {
 			return nil
 		}
-		name := strings.TrimSuffix(d.Name(), ".json")
+		name := strings.TrimSuffix(fileName, ".json")
 		if !strings.HasPrefix(name, ackIDPrefix) {
 			return nil
 		}
-		//nolint:gosec // path comes from WalkDir under fs.dir
-		data, readErr := os.ReadFile(path)
+		data, readErr := fs.readFile(path)
 		if readErr != nil {
 			//nolint:nilerr // ListAcks is best-effort; unreadable files are silently skipped so a single bad file doesn't break pending detection
 			return nil
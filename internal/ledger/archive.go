@@ -0,0 +1,175 @@
+// Package ledger — archiving old entries out of the default reads.
+package ledger
+
+import (
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// ArchiveDir is the directory under the ledger directory that archived
+// entries move to. It mirrors the layout: an entry archived from 2025/03/14/
+// lands in archive/2025/03/14/.
+const ArchiveDir = "archive"
+
+// SetIncludeArchived sets whether reads see archived entries. Off by
+// default: listings skip the archive directory and IDs there are not found.
+func (fs *FileStorage) SetIncludeArchived(include bool) {
+	fs.includeArchived = include
+}
+
+// archiveRoot returns the archive directory.
+func (fs *FileStorage) archiveRoot() string {
+	return filepath.Join(fs.dir, ArchiveDir)
+}
+
+// inArchive reports whether path is under the archive directory.
+func (fs *FileStorage) inArchive(path string) bool {
+	return strings.HasPrefix(filepath.FromSlash(path), fs.archiveRoot()+string(filepath.Separator))
+}
+
+// skipArchiveDir returns filepath.SkipDir for the archive directory, so a
+// walk of the ledger passes over it.
+func (fs *FileStorage) skipArchiveDir(path string) error {
+	if path == fs.archiveRoot() {
+		return filepath.SkipDir
+	}
+	return nil
+}
+
+// archivePaths returns the archived form of each of paths, entry paths
+// under the ledger directory.
+func (fs *FileStorage) archivePaths(paths []string) []string {
+	archived := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if rel, err := filepath.Rel(fs.dir, path); err == nil {
+			archived = append(archived, filepath.Join(fs.archiveRoot(), rel))
+		}
+	}
+	return archived
+}
+
+// archiveMoves returns [from, to] pairs for every live entry created before
+// cutoff, newest first, and their IDs. The newest entry is never archived:
+// it is where pending commits start.
+func (fs *FileStorage) archiveMoves(cutoff time.Time) ([][2]string, []string, error) {
+	files, err := fs.entryFiles()
+	if err != nil {
+		return nil, nil, err
+	}
+	var moves [][2]string
+	var ids []string
+	var newest *Entry
+	for _, file := range files {
+		entry := fs.readLiveEntry(file.path)
+		if entry == nil {
+			continue
+		}
+		if newest == nil || newerFirst(entry, newest) < 0 {
+			newest = entry
+		}
+		if entry.CreatedAt.Before(cutoff) {
+			moves = append(moves, [2]string{file.path, fs.archivePaths([]string{file.path})[0]})
+			ids = append(ids, entry.ID)
+		}
+	}
+	if newest == nil {
+		return nil, nil, nil
+	}
+	if i := slices.Index(ids, newest.ID); i >= 0 {
+		moves, ids = slices.Delete(moves, i, i+1), slices.Delete(ids, i, i+1)
+	}
+	return moves, ids, nil
+}
+
+// readLiveEntry reads the entry file at path, or returns nil when it is
+// archived or does not parse.
+func (fs *FileStorage) readLiveEntry(path string) *Entry {
+	if fs.inArchive(path) {
+		return nil
+	}
+	data, err := fs.readFile(path)
+	if err != nil {
+		return nil
+	}
+	entry, err := FromJSON(data)
+	if err != nil {
+		return nil
+	}
+	return entry
+}
+
+// PlanArchive returns the IDs ArchiveBefore would archive, without moving
+// anything.
+func (fs *FileStorage) PlanArchive(cutoff time.Time) ([]string, error) {
+	_, ids, err := fs.archiveMoves(cutoff)
+	return ids, err
+}
+
+// ArchiveBefore moves every entry created before cutoff into the archive
+// directory and commits the moves in one commit. Acks and tombstones stay
+// put. Returns the archived IDs.
+func (fs *FileStorage) ArchiveBefore(cutoff time.Time) ([]string, error) {
+	operation := "archive entries created before " + cutoff.Format(time.DateOnly)
+	if err := fs.checkWritable(operation); err != nil {
+		return nil, err
+	}
+	unlock, err := fs.lock(operation)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	moves, ids, err := fs.archiveMoves(cutoff)
+	if err != nil || len(moves) == 0 {
+		return nil, err
+	}
+	paths := make([]string, 0, 2*len(moves))
+	for _, move := range moves {
+		if err := moveEntryFile(move[0], move[1]); err != nil {
+			return nil, output.NewSystemErrorWithCause("failed to archive entry", err)
+		}
+		pruneEmptyDirs(filepath.Dir(move[0]), fs.dir)
+		paths = append(paths, move[0], move[1])
+	}
+	for _, path := range paths {
+		if err := fs.gitAdd(path); err != nil {
+			return nil, output.NewSystemErrorWithCause("failed to stage archived entry", err)
+		}
+	}
+	message := "timbers: archive " + strconv.Itoa(len(ids)) + " entries created before " + cutoff.Format(time.DateOnly)
+	if err := fs.commitPaths(paths, message); err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to commit archived entries", err)
+	}
+	return ids, nil
+}
+
+// SetIncludeArchived sets whether reads see archived entries; see
+// FileStorage.SetIncludeArchived.
+func (s *Storage) SetIncludeArchived(include bool) {
+	if s.files != nil {
+		s.files.SetIncludeArchived(include)
+	}
+}
+
+// PlanArchive returns the IDs ArchiveBefore would archive, or nil if file
+// storage is not configured.
+func (s *Storage) PlanArchive(cutoff time.Time) ([]string, error) {
+	if s.files == nil {
+		return nil, nil
+	}
+	return s.files.PlanArchive(cutoff)
+}
+
+// ArchiveBefore archives the entries created before cutoff; see
+// FileStorage.ArchiveBefore.
+func (s *Storage) ArchiveBefore(cutoff time.Time) ([]string, error) {
+	if s.files == nil {
+		return nil, output.NewSystemError("storage not configured for writes")
+	}
+	return s.files.ArchiveBefore(cutoff)
+}
@@ -0,0 +1,83 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestArchiveBefore(t *testing.T) {
+	store := NewFileStorage(t.TempDir(), noopGitAdd, noopGitCommit)
+	oldest := makeTestEntry("aaa1111111", time.Date(2024, 3, 14, 10, 0, 0, 0, time.UTC))
+	older := makeTestEntry("bbb2222222", time.Date(2024, 11, 2, 10, 0, 0, 0, time.UTC))
+	recent := makeTestEntry("ccc3333333", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	for _, entry := range []*Entry{oldest, older, recent} {
+		if err := store.WriteEntry(entry, false); err != nil {
+			t.Fatalf("WriteEntry: %v", err)
+		}
+	}
+	cutoff := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	planned, err := store.PlanArchive(cutoff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := store.CountEntries(); len(planned) != 2 || count != 3 {
+		t.Fatalf("planned %v with %d entries left; want two planned and nothing moved", planned, count)
+	}
+
+	archived, err := store.ArchiveBefore(cutoff)
+	if err != nil {
+		t.Fatalf("ArchiveBefore: %v", err)
+	}
+	if want := []string{older.ID, oldest.ID}; !slices.Equal(archived, want) {
+		t.Errorf("archived = %v, want %v", archived, want)
+	}
+	archivedPath := filepath.Join(store.Dir(), ArchiveDir, "2024", "03", "14", IDToFilename(oldest.ID)+".json")
+	if _, statErr := os.Stat(archivedPath); statErr != nil {
+		t.Errorf("archived file: %v", statErr)
+	}
+	if _, statErr := os.Stat(filepath.Join(store.Dir(), "2024")); !os.IsNotExist(statErr) {
+		t.Errorf("emptied layout directory still exists: %v", statErr)
+	}
+
+	entries, err := store.ListEntries()
+	if err != nil || len(entries) != 1 || entries[0].ID != recent.ID {
+		t.Errorf("default listing = %d entries (%v), want only the recent one", len(entries), err)
+	}
+	if _, err := store.ReadEntry(oldest.ID); err == nil {
+		t.Error("reading an archived entry by default should fail")
+	}
+
+	store.SetIncludeArchived(true)
+	if entries, _ := store.ListEntries(); len(entries) != 3 {
+		t.Errorf("listing with archived entries = %d, want 3", len(entries))
+	}
+	if _, err := store.ReadEntry(oldest.ID); err != nil {
+		t.Errorf("ReadEntry with archived entries: %v", err)
+	}
+}
+
+func TestArchiveBeforeKeepsNewestEntry(t *testing.T) {
+	store := NewFileStorage(t.TempDir(), noopGitAdd, noopGitCommit)
+	first := makeTestEntry("aaa1111111", time.Date(2024, 3, 14, 10, 0, 0, 0, time.UTC))
+	second := makeTestEntry("bbb2222222", time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC))
+	for _, entry := range []*Entry{first, second} {
+		if err := store.WriteEntry(entry, false); err != nil {
+			t.Fatalf("WriteEntry: %v", err)
+		}
+	}
+
+	archived, err := store.ArchiveBefore(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(archived, []string{first.ID}) {
+		t.Errorf("archived = %v, want all but the newest", archived)
+	}
+	if moves, _ := store.MisplacedEntries(); moves != 0 {
+		t.Errorf("misplaced entries = %d; archived files must not count", moves)
+	}
+}
@@ -0,0 +1,45 @@
+// Package ledger — atomic file writes.
+// Split out of filestorage.go to keep that file under the file-length-limit.
+package ledger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// atomicWrite writes data to path using write-to-temp-then-rename.
+// The temp file is created in the same directory as path.
+func atomicWrite(path string, data []byte) error {
+	tmpPath, err := writeTemp(filepath.Dir(path), data)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename temp file: %w", err)
+	}
+	return nil
+}
+
+// writeTemp writes data to a new temp file in dir and returns its path.
+// The temp file is removed on failure.
+func writeTemp(dir string, data []byte) (string, error) {
+	tmpFile, err := os.CreateTemp(dir, ".tmp-*.json")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("write data: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("close temp file: %w", err)
+	}
+	return tmpPath, nil
+}
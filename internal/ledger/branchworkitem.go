@@ -0,0 +1,60 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// BranchWorkItemRule detects a work item in a branch name: the first
+// capture group of Pattern, or the whole match without one, is an ID in
+// System.
+type BranchWorkItemRule struct {
+	Pattern *regexp.Regexp
+	System  string
+}
+
+// ParseBranchWorkItemRules turns the [log.branch_work_items] config, regex
+// → system, into rules sorted by pattern so the first match is stable.
+func ParseBranchWorkItemRules(patterns map[string]string) ([]BranchWorkItemRule, error) {
+	rules := make([]BranchWorkItemRule, 0, len(patterns))
+	for pattern, system := range patterns {
+		system = strings.TrimSpace(system)
+		if system == "" || strings.Contains(system, ":") {
+			return nil, fmt.Errorf("branch pattern %q needs a work item system without ':', got %q", pattern, system)
+		}
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid branch pattern %q: %w", pattern, err)
+		}
+		if compiled.NumSubexp() > 1 {
+			return nil, errors.New("branch pattern " + pattern + " has more than one capture group; capture only the ID")
+		}
+		rules = append(rules, BranchWorkItemRule{Pattern: compiled, System: system})
+	}
+	slices.SortFunc(rules, func(a, b BranchWorkItemRule) int {
+		return strings.Compare(a.Pattern.String(), b.Pattern.String())
+	})
+	return rules, nil
+}
+
+// DetectBranchWorkItem returns the work item the first matching rule finds
+// in branch, and false when none matches.
+func DetectBranchWorkItem(rules []BranchWorkItemRule, branch string) (WorkItem, bool) {
+	if branch == "" {
+		return WorkItem{}, false
+	}
+	for _, rule := range rules {
+		match := rule.Pattern.FindStringSubmatch(branch)
+		if match == nil {
+			continue
+		}
+		id := match[len(match)-1]
+		if id != "" {
+			return WorkItem{System: rule.System, ID: id}, true
+		}
+	}
+	return WorkItem{}, false
+}
@@ -0,0 +1,46 @@
+package ledger
+
+import "testing"
+
+func TestDetectBranchWorkItem(t *testing.T) {
+	rules, err := ParseBranchWorkItemRules(map[string]string{
+		`^(?:feature|fix)/([A-Z]+-\d+)`: "jira",
+		`gh-(\d+)`:                      "github",
+		`^release/v\d+`:                 "release",
+	})
+	if err != nil {
+		t.Fatalf("ParseBranchWorkItemRules: %v", err)
+	}
+
+	tests := []struct {
+		branch string
+		want   WorkItem
+		found  bool
+	}{
+		{"feature/PROJ-123-add-auth", WorkItem{System: "jira", ID: "PROJ-123"}, true},
+		{"fix/OPS-7", WorkItem{System: "jira", ID: "OPS-7"}, true},
+		{"chore/gh-42-cleanup", WorkItem{System: "github", ID: "42"}, true},
+		{"release/v2", WorkItem{System: "release", ID: "release/v2"}, true},
+		{"main", WorkItem{}, false},
+		{"", WorkItem{}, false},
+	}
+	for _, tt := range tests {
+		got, found := DetectBranchWorkItem(rules, tt.branch)
+		if got != tt.want || found != tt.found {
+			t.Errorf("DetectBranchWorkItem(%q) = %+v, %v; want %+v, %v", tt.branch, got, found, tt.want, tt.found)
+		}
+	}
+}
+
+func TestParseBranchWorkItemRulesRejectsBadRules(t *testing.T) {
+	for _, patterns := range []map[string]string{
+		{`([A-Z]+-\d+)`: ""},
+		{`([A-Z]+-\d+)`: "jira:x"},
+		{`([A-Z]+`: "jira"},
+		{`(feature)/([A-Z]+-\d+)`: "jira"},
+	} {
+		if _, err := ParseBranchWorkItemRules(patterns); err == nil {
+			t.Errorf("ParseBranchWorkItemRules(%v) should fail", patterns)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Package ledger — integrity checks over the ledger files.
+package ledger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// Problem kinds reported by the integrity checks.
+const (
+	ProblemSchema   = "schema"   // the file does not match EntrySchema
+	ProblemID       = "id"       // the ID is malformed or is not the one the file name carries
+	ProblemLocation = "location" // the file is in a directory no layout puts its ID in
+	ProblemAnchor   = "anchor"   // the anchor commit is not in the repository
+	ProblemRelation = "relation" // a relation names an entry that does not exist
+	ProblemChecksum = "checksum" // the file changed or vanished since timbers last wrote it
+)
+
+// Problem is one integrity problem found in an entry file.
+type Problem struct {
+	Kind    string `json:"kind"`
+	Path    string `json:"path"`
+	ID      string `json:"id,omitempty"`
+	Message string `json:"message"`
+}
+
+// CheckFiles reads every entry file and reports schema violations,
+// malformed IDs or IDs that disagree with the file name, and files outside
+// the directories any layout puts their ID in. Files that do not parse are
+// left to ListEntriesWithStats, which counts them as corrupt.
+func (fs *FileStorage) CheckFiles() ([]Problem, error) {
+	var problems []Problem
+	err := fs.walkFiles(func(path, fileName string) error {
+		name, ok := strings.CutSuffix(fileName, ".json")
+		if !ok || strings.HasPrefix(name, ackIDPrefix) || strings.HasPrefix(name, tombstoneIDPrefix) {
+			return nil
+		}
+		data, readErr := fs.readFile(path)
+		if readErr != nil {
+			return nil //nolint:nilerr // unreadable files are reported as corrupt
+		}
+		entry, parseErr := FromJSON(data)
+		if parseErr != nil {
+			return nil //nolint:nilerr // unparsable files are reported as corrupt
+		}
+		problems = append(problems, fs.checkFile(path, name, entry, data)...)
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, output.NewSystemErrorWithCause("failed to walk storage directory", err)
+	}
+	return problems, nil
+}
+
+// checkFile reports the problems with the entry file at path, named name
+// without its extension.
+func (fs *FileStorage) checkFile(path, name string, entry *Entry, data []byte) []Problem {
+	var problems []Problem
+	report := func(kind, message string) {
+		problems = append(problems, Problem{Kind: kind, Path: filepath.ToSlash(path), ID: entry.ID, Message: message})
+	}
+	if err := ValidateSchema(data); err != nil {
+		report(ProblemSchema, err.Error())
+	}
+	switch fileID := FilenameToID(name); {
+	case !wellFormedID(entry.ID):
+		report(ProblemID, "malformed ID "+quoteID(entry.ID)+"; want tb_<RFC3339 time>_<suffix> or tb_<ULID>")
+	case entry.ID != fileID:
+		report(ProblemID, "file is named for "+fileID+" but holds "+entry.ID)
+	case !slices.Contains(fs.candidateEntryPaths(entry.ID), path):
+		want := fs.Layout().Dir(entry.ID)
+		if want == "" {
+			want = "."
+		}
+		report(ProblemLocation, "file is not where any layout puts its ID; the "+string(fs.Layout())+
+			" layout puts it in "+filepath.ToSlash(want))
+	}
+	return problems
+}
+
+// wellFormedID reports whether id is tb_<RFC3339 time>_<suffix> or
+// tb_<ULID>.
+func wellFormedID(id string) bool {
+	if _, ok := IDTime(id); !ok {
+		return false
+	}
+	if _, ok := ulidTime(strings.TrimPrefix(id, idPrefix)); ok {
+		return true
+	}
+	_, suffix, _ := strings.Cut(strings.TrimPrefix(id, idPrefix), "_")
+	return suffix != ""
+}
+
+// quoteID quotes id for a message, marking an empty one.
+func quoteID(id string) string {
+	if id == "" {
+		return "(empty)"
+	}
+	return `"` + id + `"`
+}
+
+// CheckRelations reports relations of entries that name an entry not in
+// known. paths maps entry IDs to their files, for the report.
+func CheckRelations(entries []*Entry, known map[string]bool, paths func(id string) string) []Problem {
+	var problems []Problem
+	for _, entry := range entries {
+		for _, rel := range entry.Relations {
+			if !known[rel.ID] {
+				problems = append(problems, Problem{
+					Kind: ProblemRelation, Path: paths(entry.ID), ID: entry.ID,
+					Message: rel.Type + " relation names " + quoteID(rel.ID) + ", which is not in the ledger",
+				})
+			}
+		}
+	}
+	return problems
+}
+
+// CheckAnchors reports entries whose anchor commit is not among existing,
+// the full SHAs of the anchors git found. Anchors may be abbreviated.
+func CheckAnchors(entries []*Entry, existing []string, paths func(id string) string) []Problem {
+	found := make(map[string]bool, len(existing))
+	for _, sha := range existing {
+		found[sha] = true
+	}
+	var problems []Problem
+	for _, entry := range entries {
+		anchor := entry.Workset.AnchorCommit
+		if anchor == "" || found[anchor] ||
+			slices.ContainsFunc(existing, func(sha string) bool { return strings.HasPrefix(sha, anchor) }) {
+			continue
+		}
+		problems = append(problems, Problem{
+			Kind: ProblemAnchor, Path: paths(entry.ID), ID: entry.ID,
+			Message: "anchor commit " + shortCommit(anchor) + " is not in the repository",
+		})
+	}
+	return problems
+}
+
+// CheckFiles reports problems with the entry files; see
+// FileStorage.CheckFiles. Returns nil if file storage is not configured.
+func (s *Storage) CheckFiles() ([]Problem, error) {
+	if s.files == nil {
+		return nil, nil
+	}
+	return s.files.CheckFiles()
+}
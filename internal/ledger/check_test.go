@@ -0,0 +1,71 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWellFormedID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"tb_2026-01-15T10:00:00Z_abc123", true},
+		{"tb_2026-01-15T10:00:00Z_abc123-0a1b2c3d", true},
+		{"tb_2026-01-15T10:00:00Z_", false},
+		{"tb_2026-01-15_abc123", false},
+		{"tb_bogus", false},
+		{"2026-01-15T10:00:00Z_abc123", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := wellFormedID(tt.id); got != tt.want {
+			t.Errorf("wellFormedID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestCheckFilesReportsMisnamedFile(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStorage(dir, noopGitAdd, noopGitCommit)
+	entry := makeTestEntry("abc1234", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	if err := store.WriteEntry(entry, false); err != nil {
+		t.Fatal(err)
+	}
+	data, err := entry.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := GenerateID("fff9999", entry.CreatedAt)
+	if err = os.WriteFile(filepath.Join(dir, EntryDateDir(other), IDToFilename(other)+".json"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := store.CheckFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || problems[0].Kind != ProblemID || problems[0].ID != entry.ID {
+		t.Errorf("problems = %+v, want one id problem for the copy", problems)
+	}
+}
+
+func TestCheckRelationsAndAnchors(t *testing.T) {
+	entry := makeTestEntry("abc1234", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	entry.Relations = []Relation{{Type: RelationFixes, ID: "tb_known"}, {Type: RelationRelatesTo, ID: "tb_gone"}}
+	pathOf := func(id string) string { return id + ".json" }
+
+	problems := CheckRelations([]*Entry{entry}, map[string]bool{"tb_known": true, entry.ID: true}, pathOf)
+	if len(problems) != 1 || problems[0].Kind != ProblemRelation || problems[0].Path != entry.ID+".json" {
+		t.Errorf("CheckRelations = %+v, want the relation to tb_gone", problems)
+	}
+
+	if problems = CheckAnchors([]*Entry{entry}, []string{"abc1234ffffffff"}, pathOf); len(problems) != 0 {
+		t.Errorf("CheckAnchors with the anchor present = %+v", problems)
+	}
+	if problems = CheckAnchors([]*Entry{entry}, nil, pathOf); len(problems) != 1 || problems[0].Kind != ProblemAnchor {
+		t.Errorf("CheckAnchors with the anchor missing = %+v", problems)
+	}
+}
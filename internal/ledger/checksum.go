@@ -0,0 +1,260 @@
+// Package ledger — entry checksums for detecting edits made outside timbers.
+package ledger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// ChecksumFile is where entry checksums live, relative to the git directory.
+// Like the indexes it is local and never committed: it records what this
+// clone's timbers wrote, so fsck --verify can tell those writes from edits
+// made by hand or by a merge. Files matching what HEAD commits are trusted
+// too, so amends and removals pulled from other clones pass.
+const ChecksumFile = "timbers/checksums.json"
+
+// checksumVersion changes whenever the hashed form does; a manifest with
+// another version is treated as empty.
+const checksumVersion = 1
+
+// checksumManifest maps entry IDs to the checksum of the file timbers last
+// wrote for them.
+type checksumManifest struct {
+	Version int               `json:"version"`
+	Entries map[string]string `json:"entries"`
+}
+
+// ChecksumReport is what VerifyChecksums found.
+type ChecksumReport struct {
+	Problems []Problem // entries whose file changed or vanished since timbers wrote it
+	Recorded []string  // entries seen for the first time, now recorded, sorted
+}
+
+// SetChecksums sets where entry checksums are kept (see ChecksumFile); ""
+// disables them. Only working-directory storage records them.
+func (fs *FileStorage) SetChecksums(path string) {
+	fs.checksumPath = path
+}
+
+// SetCommitted makes VerifyChecksums trust entry files whose contents match
+// those committed at HEAD in the repository at root, and entries HEAD no
+// longer has. "" trusts only what this clone's timbers wrote.
+func (fs *FileStorage) SetCommitted(root string) {
+	fs.repoRoot = root
+}
+
+// committedChecksums returns the checksum of every file under the storage
+// directory at HEAD, keyed by its path as walked. A repository without
+// commits, or any git failure, yields none: verification then falls back to
+// the recorded checksums alone.
+func (fs *FileStorage) committedChecksums() map[string]string {
+	if fs.repoRoot == "" {
+		return nil
+	}
+	rel, err := filepath.Rel(fs.repoRoot, fs.dir)
+	if err != nil {
+		return nil
+	}
+	files, err := git.TreeFiles("HEAD", filepath.ToSlash(rel))
+	if err != nil {
+		return nil
+	}
+	sums := make(map[string]string, len(files))
+	for path, data := range files {
+		sums[filepath.Join(fs.repoRoot, filepath.FromSlash(path))] = fileChecksum(data)
+	}
+	return sums
+}
+
+// fileChecksum returns the checksum recorded for an entry file's bytes.
+func fileChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// loadChecksums reads the manifest, returning an empty one when it is
+// missing, unreadable, or from another version.
+func (fs *FileStorage) loadChecksums() *checksumManifest {
+	manifest := &checksumManifest{Version: checksumVersion, Entries: make(map[string]string)}
+	data, err := os.ReadFile(fs.checksumPath)
+	if err != nil {
+		return manifest
+	}
+	var loaded checksumManifest
+	if json.Unmarshal(data, &loaded) != nil || loaded.Version != checksumVersion || loaded.Entries == nil {
+		return manifest
+	}
+	return &loaded
+}
+
+// saveChecksums writes the manifest atomically.
+func (fs *FileStorage) saveChecksums(manifest *checksumManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err //nolint:wrapcheck // callers wrap or ignore
+	}
+	if err := os.MkdirAll(filepath.Dir(fs.checksumPath), 0o755); err != nil {
+		return err //nolint:wrapcheck // callers wrap or ignore
+	}
+	return atomicWrite(fs.checksumPath, data)
+}
+
+// recordChecksums stores the checksum of every entry file among paths, which
+// timbers has just written. Acks, tombstones, and files that cannot be read
+// are skipped. Failures are ignored: fsck --verify reports the entry as
+// changed, and fsck --reindex records it again.
+func (fs *FileStorage) recordChecksums(paths []string) {
+	if fs.checksumPath == "" || fs.tree != nil {
+		return
+	}
+	manifest := fs.loadChecksums()
+	for _, path := range paths {
+		id, ok := entryFileID(path)
+		if !ok {
+			continue
+		}
+		if data, err := os.ReadFile(path); err == nil { //nolint:gosec // path is a ledger file timbers wrote
+			manifest.Entries[id] = fileChecksum(data)
+		}
+	}
+	_ = fs.saveChecksums(manifest)
+}
+
+// entryFileID returns the entry ID an entry file path carries, and false for
+// acks, tombstones, and other files.
+func entryFileID(path string) (string, bool) {
+	base, ok := strings.CutSuffix(filepath.Base(path), ".json")
+	if !ok || !strings.HasPrefix(base, idPrefix) {
+		return "", false
+	}
+	return FilenameToID(base), true
+}
+
+// VerifyChecksums compares every entry file with the checksum recorded when
+// timbers last wrote it, and reports each one that differs or whose file is
+// gone, unless HEAD commits that change (see SetCommitted); committed changes
+// are recorded. Entries with no checksum, such as those pulled from another
+// clone, are recorded as they are and listed in Recorded. Returns nil when
+// checksums are disabled.
+func (fs *FileStorage) VerifyChecksums() (*ChecksumReport, error) {
+	if fs.checksumPath == "" || fs.tree != nil {
+		return nil, nil //nolint:nilnil // nil report means checksums are disabled
+	}
+	// Archived entries keep their checksums; look for them too.
+	includeArchived := fs.includeArchived
+	fs.includeArchived = true
+	files, err := fs.entryFiles()
+	fs.includeArchived = includeArchived
+	if err != nil {
+		return nil, err
+	}
+	manifest := fs.loadChecksums()
+	committed := fs.committedChecksums()
+	report := &ChecksumReport{}
+	seen := make(map[string]bool, len(files))
+	changed := false
+	for _, file := range files {
+		data, readErr := fs.readFile(file.path)
+		if readErr != nil {
+			return nil, output.NewSystemErrorWithCause("failed to read "+file.path, readErr)
+		}
+		seen[file.id] = true
+		sum := fileChecksum(data)
+		switch recorded, ok := manifest.Entries[file.id]; {
+		case !ok:
+			manifest.Entries[file.id] = sum
+			report.Recorded = append(report.Recorded, file.id)
+		case recorded == sum:
+		case committed[file.path] == sum:
+			manifest.Entries[file.id] = sum
+			changed = true
+		default:
+			report.Problems = append(report.Problems, Problem{
+				Kind: ProblemChecksum, Path: filepath.ToSlash(file.path), ID: file.id,
+				Message: "file changed outside timbers since it was last written",
+			})
+		}
+	}
+	problems, dropped := fs.removedEntries(manifest, seen, committed)
+	report.Problems = append(report.Problems, problems...)
+	slices.Sort(report.Recorded)
+	if len(report.Recorded) > 0 || changed || dropped {
+		if err := fs.saveChecksums(manifest); err != nil {
+			return nil, output.NewSystemErrorWithCause("failed to record entry checksums", err)
+		}
+	}
+	return report, nil
+}
+
+// removedEntries reports the entries with a recorded checksum whose file was
+// not seen, at the path timbers would have written them to. Entries HEAD no
+// longer has were removed by a commit: they are dropped from manifest
+// instead, and the second result reports whether any were.
+func (fs *FileStorage) removedEntries(
+	manifest *checksumManifest, seen map[string]bool, committed map[string]string,
+) ([]Problem, bool) {
+	committedIDs := make(map[string]bool, len(committed))
+	for path := range committed {
+		if id, ok := entryFileID(path); ok {
+			committedIDs[id] = true
+		}
+	}
+	var problems []Problem
+	dropped := false
+	for id := range manifest.Entries {
+		switch {
+		case seen[id]:
+		case committed != nil && !committedIDs[id]:
+			delete(manifest.Entries, id)
+			dropped = true
+		default:
+			problems = append(problems, Problem{
+				Kind: ProblemChecksum, Path: filepath.ToSlash(fs.entryPath(id)), ID: id,
+				Message: "entry file was removed outside timbers",
+			})
+		}
+	}
+	return problems, dropped
+}
+
+// ResetChecksums deletes the recorded checksums and records every entry
+// file as it is now, accepting changes VerifyChecksums reported. Reports
+// whether checksums are enabled.
+func (fs *FileStorage) ResetChecksums() (bool, error) {
+	if fs.checksumPath == "" || fs.tree != nil {
+		return false, nil
+	}
+	if err := os.Remove(fs.checksumPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return true, output.NewSystemErrorWithCause("failed to remove entry checksums", err)
+	}
+	_, err := fs.VerifyChecksums()
+	return true, err
+}
+
+// VerifyChecksums checks entry files against their recorded checksums; see
+// FileStorage.VerifyChecksums. Returns nil if file storage is not
+// configured.
+func (s *Storage) VerifyChecksums() (*ChecksumReport, error) {
+	if s.files == nil {
+		return nil, nil //nolint:nilnil // nil report means checksums are disabled
+	}
+	return s.files.VerifyChecksums()
+}
+
+// ResetChecksums re-records every entry's checksum; see
+// FileStorage.ResetChecksums.
+func (s *Storage) ResetChecksums() (bool, error) {
+	if s.files == nil {
+		return false, nil
+	}
+	return s.files.ResetChecksums()
+}
@@ -0,0 +1,164 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+// newChecksumStorage returns file storage in a temp dir with checksums
+// enabled.
+func newChecksumStorage(t *testing.T) *FileStorage {
+	t.Helper()
+	store := NewFileStorage(t.TempDir(), noopGitAdd, noopGitCommit)
+	store.SetChecksums(filepath.Join(t.TempDir(), "timbers", "checksums.json"))
+	return store
+}
+
+func TestVerifyChecksums_WritesAreTrusted(t *testing.T) {
+	store := newChecksumStorage(t)
+	base := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	single := makeTestEntry("aaa111aaa111", base)
+	if err := store.WriteEntry(single, false); err != nil {
+		t.Fatal(err)
+	}
+	batch := []*Entry{makeTestEntry("bbb222bbb222", base.Add(time.Hour)), makeTestEntry("ccc333ccc333", base.Add(2*time.Hour))}
+	if err := store.WriteEntries(batch); err != nil {
+		t.Fatal(err)
+	}
+	single.Summary.Why = "amended through timbers"
+	if err := store.WriteEntry(single, true); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := store.VerifyChecksums()
+	if err != nil {
+		t.Fatalf("VerifyChecksums: %v", err)
+	}
+	if len(report.Problems) != 0 || len(report.Recorded) != 0 {
+		t.Errorf("VerifyChecksums = %+v, want nothing to report after timbers' own writes", report)
+	}
+}
+
+func TestVerifyChecksums_ReportsOutsideChanges(t *testing.T) {
+	store := newChecksumStorage(t)
+	base := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	edited := makeTestEntry("aaa111aaa111", base)
+	removed := makeTestEntry("bbb222bbb222", base.Add(time.Hour))
+	if err := store.WriteEntries([]*Entry{edited, removed}); err != nil {
+		t.Fatal(err)
+	}
+	editedPath := store.entryPath(edited.ID)
+	if err := os.WriteFile(editedPath, []byte(`{"schema":"timbers.devlog/v2","id":"`+edited.ID+`"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(store.entryPath(removed.ID)); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := store.VerifyChecksums()
+	if err != nil {
+		t.Fatalf("VerifyChecksums: %v", err)
+	}
+	got := make(map[string]string)
+	for _, problem := range report.Problems {
+		if problem.Kind != ProblemChecksum {
+			t.Errorf("problem kind = %q, want %q", problem.Kind, ProblemChecksum)
+		}
+		got[problem.ID] = problem.Message
+	}
+	if len(got) != 2 || got[edited.ID] == "" || got[removed.ID] == "" {
+		t.Errorf("VerifyChecksums problems = %+v, want the edited and the removed entry", report.Problems)
+	}
+
+	if enabled, resetErr := store.ResetChecksums(); !enabled || resetErr != nil {
+		t.Fatalf("ResetChecksums = %v, %v", enabled, resetErr)
+	}
+	if report, err = store.VerifyChecksums(); err != nil || len(report.Problems) != 0 {
+		t.Errorf("after ResetChecksums: %+v, %v; want no problems", report, err)
+	}
+}
+
+func TestVerifyChecksums_DisabledWithoutPath(t *testing.T) {
+	store := NewFileStorage(t.TempDir(), noopGitAdd, noopGitCommit)
+	if report, err := store.VerifyChecksums(); report != nil || err != nil {
+		t.Errorf("VerifyChecksums without a path = %+v, %v; want nil, nil", report, err)
+	}
+}
+
+// gitClone returns file storage for the ledger of the repository at dir,
+// staging and committing there.
+func gitClone(t *testing.T, dir string) *FileStorage {
+	t.Helper()
+	for _, args := range [][]string{{"config", "user.email", "test@test.com"}, {"config", "user.name", "Test"}} {
+		if _, err := git.RunInDir(dir, nil, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	return NewFileStorage(filepath.Join(dir, ".timbers"),
+		func(path string) error {
+			_, err := git.RunInDir(dir, nil, "add", "--", path)
+			return err //nolint:wrapcheck // test helper
+		},
+		func(path, message string) error {
+			_, err := git.RunInDir(dir, nil, "commit", "-m", message, "--", path)
+			return err //nolint:wrapcheck // test helper
+		})
+}
+
+// TestVerifyChecksums_TrustsCommitsPulledFromAnotherClone runs against real
+// repositories: an amend and an rm made in one clone and pulled into another
+// pass verification there, while an uncommitted edit is still reported.
+func TestVerifyChecksums_TrustsCommitsPulledFromAnotherClone(t *testing.T) {
+	local, remote := t.TempDir(), t.TempDir()
+	if _, err := git.RunInDir(local, nil, "init"); err != nil {
+		t.Fatal(err)
+	}
+	store := gitClone(t, local)
+	store.SetChecksums(filepath.Join(local, ".git", filepath.FromSlash(ChecksumFile)))
+	store.SetCommitted(local)
+	base := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	amended := makeTestEntry("aaa111aaa111", base)
+	removed := makeTestEntry("bbb222bbb222", base.Add(time.Hour))
+	edited := makeTestEntry("ccc333ccc333", base.Add(2*time.Hour))
+	if err := store.WriteEntries([]*Entry{amended, removed, edited}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := git.RunInDir(remote, nil, "clone", local, "."); err != nil {
+		t.Fatal(err)
+	}
+	teammate := gitClone(t, remote)
+	amended.Summary.Why = "amended in another clone"
+	if err := teammate.WriteEntry(amended, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := teammate.WriteTombstone(makeTestTombstone(removed)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := git.RunInDir(local, nil, "pull", "--ff-only", remote); err != nil {
+		t.Fatalf("git pull: %v", err)
+	}
+
+	editedPath := store.entryPath(edited.ID)
+	data, err := os.ReadFile(editedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(editedPath, []byte(strings.Replace(string(data), "test why", "edited by hand", 1)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(local)
+	report, err := store.VerifyChecksums()
+	if err != nil {
+		t.Fatalf("VerifyChecksums: %v", err)
+	}
+	if len(report.Problems) != 1 || report.Problems[0].ID != edited.ID {
+		t.Errorf("VerifyChecksums problems = %+v, want only the uncommitted edit to %s", report.Problems, edited.ID)
+	}
+}
@@ -0,0 +1,83 @@
+package ledger
+
+import (
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+// CommitMeta records per-commit facts captured at log time. They cannot be
+// recovered reliably later: SHAs are rewritten by squash and rebase, pruned
+// history takes authorship with it, and signature verification depends on
+// the keyring of whoever runs it.
+type CommitMeta struct {
+	SHA         string           `json:"sha"`
+	Author      string           `json:"author,omitempty"`
+	AuthorEmail string           `json:"author_email,omitempty"`
+	AuthoredAt  *time.Time       `json:"authored_at,omitempty"`
+	CommittedAt *time.Time       `json:"committed_at,omitempty"`
+	Signature   *CommitSignature `json:"signature,omitempty"`
+}
+
+// CommitSignature is the GPG/SSH signature status of a commit as verified
+// when the entry was written.
+type CommitSignature struct {
+	Status string `json:"status"`
+	Key    string `json:"key,omitempty"`
+	Signer string `json:"signer,omitempty"`
+}
+
+// Valid reports whether the signature verified as good (trusted or not).
+func (s *CommitSignature) Valid() bool {
+	return s != nil && (s.Status == "good" || s.Status == "good_untrusted")
+}
+
+// SignedCount returns how many commits in the workset carry a valid
+// signature, and how many have signature data at all. Both are zero for
+// entries written before signatures were captured.
+func (w *Workset) SignedCount() (int, int) {
+	signed, known := 0, 0
+	for _, meta := range w.CommitMeta {
+		if meta.Signature == nil {
+			continue
+		}
+		known++
+		if meta.Signature.Valid() {
+			signed++
+		}
+	}
+	return signed, known
+}
+
+// NewCommitMeta captures the workset's per-commit metadata (author,
+// timestamps, and signature when sigs has it), in workset commit order.
+// Zero timestamps are omitted rather than stored as year 1.
+func NewCommitMeta(commits []git.Commit, sigs map[string]git.Signature) []CommitMeta {
+	if len(commits) == 0 {
+		return nil
+	}
+	meta := make([]CommitMeta, 0, len(commits))
+	for _, commit := range commits {
+		entry := CommitMeta{
+			SHA:         commit.SHA,
+			Author:      commit.Author,
+			AuthorEmail: commit.AuthorEmail,
+			AuthoredAt:  utcTimePtr(commit.Date),
+			CommittedAt: utcTimePtr(commit.CommitDate),
+		}
+		if sig, ok := sigs[commit.SHA]; ok {
+			entry.Signature = &CommitSignature{Status: sig.Status, Key: sig.Key, Signer: sig.Signer}
+		}
+		meta = append(meta, entry)
+	}
+	return meta
+}
+
+// utcTimePtr returns t in UTC, or nil for the zero time.
+func utcTimePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
@@ -20,16 +20,9 @@ const (
 // otherwise it derives identities from commit authors and co-author trailers.
 func ResolveContributors(commits []git.Commit, who []string) ([]Contributor, error) {
 	if len(who) > 0 {
-		contributors := make([]Contributor, 0, len(who))
-		for _, value := range who {
-			name, email, valid := parseExplicitContributor(value)
-			if !valid {
-				return nil, fmt.Errorf("invalid contributor %q: expected Name <email>", value)
-			}
-			contributors = append(contributors, Contributor{
-				Name: name, Email: email,
-				Sources: []string{ContributorSourceExplicit},
-			})
+		contributors, err := parseExplicitContributors(who)
+		if err != nil {
+			return nil, err
 		}
 		return dedupeContributors(contributors), nil
 	}
@@ -54,6 +47,34 @@ func ResolveContributors(commits []git.Commit, who []string) ([]Contributor, err
 	return dedupeContributors(contributors), nil
 }
 
+// AddCoAuthors merges explicit Name <email> identities into contributors.
+// Unlike who in ResolveContributors, coAuthors keep the automatic set.
+func AddCoAuthors(contributors []Contributor, coAuthors []string) ([]Contributor, error) {
+	if len(coAuthors) == 0 {
+		return contributors, nil
+	}
+	explicit, err := parseExplicitContributors(coAuthors)
+	if err != nil {
+		return nil, err
+	}
+	return dedupeContributors(append(slices.Clone(contributors), explicit...)), nil
+}
+
+func parseExplicitContributors(values []string) ([]Contributor, error) {
+	contributors := make([]Contributor, 0, len(values))
+	for _, value := range values {
+		name, email, valid := parseExplicitContributor(value)
+		if !valid {
+			return nil, fmt.Errorf("invalid contributor %q: expected Name <email>", value)
+		}
+		contributors = append(contributors, Contributor{
+			Name: name, Email: email,
+			Sources: []string{ContributorSourceExplicit},
+		})
+	}
+	return contributors, nil
+}
+
 func validIdentity(name, email string) bool {
 	return strings.TrimSpace(name) != "" && validEmail(email)
 }
@@ -61,3 +61,26 @@ func TestResolveContributorsRejectsIncompleteExplicitIdentity(t *testing.T) {
 		}
 	}
 }
+
+func TestAddCoAuthorsKeepsAutomatic(t *testing.T) {
+	commits := []git.Commit{{Author: "Git Author", AuthorEmail: "git@example.com"}}
+	automatic, err := ResolveContributors(commits, nil)
+	if err != nil {
+		t.Fatalf("ResolveContributors: %v", err)
+	}
+
+	got, err := AddCoAuthors(automatic, []string{"Pair Bot <pair-bot@example.com>", "Git Author <git@example.com>"})
+	if err != nil {
+		t.Fatalf("AddCoAuthors: %v", err)
+	}
+	want := []Contributor{
+		{Name: "Git Author", Email: "git@example.com", Sources: []string{ContributorSourceGitAuthor, ContributorSourceExplicit}},
+		{Name: "Pair Bot", Email: "pair-bot@example.com", Sources: []string{ContributorSourceExplicit}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("contributors = %#v, want %#v", got, want)
+	}
+	if _, err := AddCoAuthors(automatic, []string{"no email"}); err == nil {
+		t.Error("AddCoAuthors should reject an identity without an email")
+	}
+}
@@ -0,0 +1,46 @@
+// Package ledger — when the work an entry describes began, and cycle time.
+package ledger
+
+import (
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+// EarliestAuthored returns the earliest author date among commits, in UTC,
+// or nil when none has one. It is the default started_at for a new entry.
+func EarliestAuthored(commits []git.Commit) *time.Time {
+	var earliest time.Time
+	for _, commit := range commits {
+		if !commit.Date.IsZero() && (earliest.IsZero() || commit.Date.Before(earliest)) {
+			earliest = commit.Date
+		}
+	}
+	return utcTimePtr(earliest)
+}
+
+// Started returns when the work began: started_at when set, otherwise the
+// earliest authored_at in the commit metadata, so entries logged before
+// started_at existed still have one. The zero time means unknown.
+func (e *Entry) Started() time.Time {
+	if e.StartedAt != nil {
+		return *e.StartedAt
+	}
+	var earliest time.Time
+	for _, meta := range e.Workset.CommitMeta {
+		if meta.AuthoredAt != nil && (earliest.IsZero() || meta.AuthoredAt.Before(earliest)) {
+			earliest = *meta.AuthoredAt
+		}
+	}
+	return earliest
+}
+
+// CycleTime returns the time from when the work began to when the entry was
+// logged. ok is false when the start is unknown or falls after created_at.
+func (e *Entry) CycleTime() (time.Duration, bool) {
+	started := e.Started()
+	if started.IsZero() || started.After(e.CreatedAt) {
+		return 0, false
+	}
+	return e.CreatedAt.Sub(started), true
+}
@@ -0,0 +1,56 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+func TestEarliestAuthored(t *testing.T) {
+	first := time.Date(2026, 1, 10, 9, 0, 0, 0, time.FixedZone("PST", -8*3600))
+	commits := []git.Commit{
+		{SHA: "ccc", Date: first.Add(48 * time.Hour)},
+		{SHA: "bbb"},
+		{SHA: "aaa", Date: first},
+	}
+
+	got := EarliestAuthored(commits)
+	if got == nil || !got.Equal(first) || got.Location() != time.UTC {
+		t.Errorf("EarliestAuthored() = %v, want %v in UTC", got, first)
+	}
+	if got := EarliestAuthored([]git.Commit{{SHA: "bbb"}}); got != nil {
+		t.Errorf("EarliestAuthored() without dates = %v, want nil", got)
+	}
+}
+
+func TestEntryCycleTime(t *testing.T) {
+	created := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	authored := created.Add(-24 * time.Hour)
+	started := created.Add(-72 * time.Hour)
+	late := created.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		entry  Entry
+		want   time.Duration
+		wantOK bool
+	}{
+		{"unknown start", Entry{CreatedAt: created}, 0, false},
+		{"started_at", Entry{CreatedAt: created, StartedAt: &started}, 72 * time.Hour, true},
+		{
+			"falls back to commit metadata",
+			Entry{CreatedAt: created, Workset: Workset{CommitMeta: []CommitMeta{{SHA: "aaa", AuthoredAt: &authored}}}},
+			24 * time.Hour, true,
+		},
+		{"start after created_at", Entry{CreatedAt: created, StartedAt: &late}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.entry.CycleTime()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("CycleTime() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package ledger
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ADR statuses a decision can carry.
+const (
+	DecisionProposed   = "proposed"
+	DecisionAccepted   = "accepted"
+	DecisionDeprecated = "deprecated"
+	DecisionSuperseded = "superseded"
+)
+
+// DecisionStatuses lists every ADR status, in lifecycle order.
+var DecisionStatuses = []string{DecisionProposed, DecisionAccepted, DecisionDeprecated, DecisionSuperseded}
+
+// Decision holds the ADR-style fields of a KindDecision entry. The ADR's
+// context and consequences live in Summary.Why and Summary.How so every
+// reader of the ledger sees them without knowing about decisions.
+type Decision struct {
+	Status       string   `json:"status,omitempty"`
+	Alternatives []string `json:"alternatives,omitempty"`
+	// Supersedes is the ID of the decision entry this one replaces. Only
+	// timbers.devlog/v1 entries carry it; v2 records a supersedes relation
+	// instead. Read it through Entry.SupersedesID.
+	Supersedes string `json:"supersedes,omitempty"`
+}
+
+// SupersedesID returns the ID of the entry this one supersedes: its first
+// supersedes relation, or for v1 decisions the Decision.Supersedes field.
+// Empty when it supersedes nothing.
+func (e *Entry) SupersedesID() string {
+	if ids := e.RelationIDs(RelationSupersedes); len(ids) > 0 {
+		return ids[0]
+	}
+	if e.Decision != nil {
+		return e.Decision.Supersedes
+	}
+	return ""
+}
+
+// ParseDecisionStatus validates a status name from a flag. Empty means
+// DecisionAccepted.
+func ParseDecisionStatus(name string) (string, error) {
+	if name == "" {
+		return DecisionAccepted, nil
+	}
+	if !slices.Contains(DecisionStatuses, name) {
+		return "", fmt.Errorf("unknown decision status %q (supported: %s)", name, strings.Join(DecisionStatuses, ", "))
+	}
+	return name, nil
+}
+
+// Decisions returns the KindDecision entries in entries, oldest first. This
+// is the order ADR numbers follow.
+func Decisions(entries []*Entry) []*Entry {
+	decisions := FilterEntriesByKinds(entries, []string{KindDecision})
+	sorted := slices.Clone(decisions)
+	slices.SortStableFunc(sorted, func(a, b *Entry) int {
+		if cmp := a.CreatedAt.Compare(b.CreatedAt); cmp != 0 {
+			return cmp
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return sorted
+}
@@ -26,16 +26,16 @@ func (s *Storage) LatestAnchorOffFirstParent() (bool, *Entry, error) {
 	if latest == nil || latest.Workset.AnchorCommit == "" {
 		return false, latest, nil
 	}
-	head, headErr := s.git.HEAD()
+	head, headErr := s.git.HEAD(s.context())
 	if headErr != nil {
 		//nolint:nilerr // diagnostic is best-effort; never propagate as error
 		return false, latest, nil
 	}
 	// Stale anchor (not reachable at all) is a separate signal — surfaced
 	// elsewhere — and would muddy this one if conflated.
-	if !s.git.IsAncestorOf(latest.Workset.AnchorCommit, head) {
+	if !s.git.IsAncestorOf(s.context(), latest.Workset.AnchorCommit, head) {
 		return false, latest, nil
 	}
-	onLine := s.git.IsOnFirstParentLine(latest.Workset.AnchorCommit, head)
+	onLine := s.git.IsOnFirstParentLine(s.context(), latest.Workset.AnchorCommit, head)
 	return !onLine, latest, nil
 }
@@ -0,0 +1,25 @@
+package ledger
+
+import "github.com/gorewood/timbers/internal/git"
+
+// NewDiffstat converts git change statistics into the stored workset form.
+// The per-file breakdown is kept only when perFile is set (log --numstat);
+// by default an entry records the totals alone.
+func NewDiffstat(stat git.Diffstat, perFile bool) *Diffstat {
+	diffstat := &Diffstat{
+		Files:          stat.Files,
+		Insertions:     stat.Insertions,
+		Deletions:      stat.Deletions,
+		BinaryFiles:    stat.BinaryFiles,
+		GeneratedFiles: stat.GeneratedFiles,
+	}
+	for _, rename := range stat.Renames {
+		diffstat.Renames = append(diffstat.Renames, Rename{From: rename.From, To: rename.To})
+	}
+	if perFile {
+		for _, file := range stat.PerFile {
+			diffstat.PerFile = append(diffstat.PerFile, FileStat(file))
+		}
+	}
+	return diffstat
+}
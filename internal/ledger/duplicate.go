@@ -0,0 +1,73 @@
+package ledger
+
+import "time"
+
+// duplicateSummaryWindow bounds how far back FindDuplicates compares
+// summaries. Commit overlap is checked against the whole ledger.
+const duplicateSummaryWindow = 7 * 24 * time.Hour
+
+// minSimilarSummary is the share of what-terms two entries must have in
+// common (Jaccard index) to count as the same piece of work.
+const minSimilarSummary = 0.7
+
+// Duplicate is an existing entry that appears to document the same work as
+// a candidate entry.
+type Duplicate struct {
+	Entry *Entry
+	// SharedCommits lists candidate commits the existing entry already covers.
+	SharedCommits []string
+	// SimilarSummary is set when the two what lines are near-identical.
+	SimilarSummary bool
+}
+
+// FindDuplicates compares candidate against existing entries of the same
+// kind, returning those that cover any of its commits or, within the last
+// week, describe near-identical work. Different kinds are expected to
+// overlap: a decision or incident often shares commits with the work entry.
+func FindDuplicates(candidate *Entry, existing []*Entry) []Duplicate {
+	candidateCommits := make(map[string]bool, len(candidate.Workset.Commits))
+	for _, sha := range candidate.Workset.Commits {
+		candidateCommits[sha] = true
+	}
+	candidateTerms := QueryTerms(candidate.Summary.What)
+	kind := candidate.KindOrDefault()
+
+	var duplicates []Duplicate
+	for _, entry := range existing {
+		if entry.KindOrDefault() != kind {
+			continue
+		}
+		dup := Duplicate{Entry: entry}
+		for _, sha := range entry.Workset.Commits {
+			if candidateCommits[sha] {
+				dup.SharedCommits = append(dup.SharedCommits, sha)
+			}
+		}
+		if candidate.CreatedAt.Sub(entry.CreatedAt) <= duplicateSummaryWindow {
+			dup.SimilarSummary = jaccard(candidateTerms, QueryTerms(entry.Summary.What)) >= minSimilarSummary
+		}
+		if len(dup.SharedCommits) > 0 || dup.SimilarSummary {
+			duplicates = append(duplicates, dup)
+		}
+	}
+	return duplicates
+}
+
+// jaccard returns |left ∩ right| / |left ∪ right| for two deduplicated term
+// lists, or 0 when either has fewer than two terms (too little to judge).
+func jaccard(left, right []string) float64 {
+	if len(left) < 2 || len(right) < 2 {
+		return 0
+	}
+	inLeft := make(map[string]bool, len(left))
+	for _, term := range left {
+		inLeft[term] = true
+	}
+	shared := 0
+	for _, term := range right {
+		if inLeft[term] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(left)+len(right)-shared)
+}
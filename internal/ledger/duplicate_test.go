@@ -0,0 +1,37 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	candidate := makeTestEntry("ccc333", now)
+	candidate.Workset.Commits = []string{"ccc333", "bbb222"}
+	candidate.Summary.What = "Cache pending counts per run"
+
+	overlapping := makeTestEntry("bbb222", now.Add(-time.Hour))
+	overlapping.Summary.What = "Something else entirely"
+
+	similar := makeTestEntry("aaa111", now.Add(-2*time.Hour))
+	similar.Summary.What = "Cache pending counts per run."
+
+	staleSimilar := makeTestEntry("999999", now.Add(-30*24*time.Hour))
+	staleSimilar.Summary.What = "Cache pending counts per run"
+
+	decision := makeTestEntry("bbb222", now.Add(-time.Minute))
+	decision.ID = "tb_decision"
+	decision.Kind = KindDecision
+
+	dups := FindDuplicates(candidate, []*Entry{overlapping, similar, staleSimilar, decision})
+	if len(dups) != 2 {
+		t.Fatalf("FindDuplicates() returned %d duplicates, want 2: %+v", len(dups), dups)
+	}
+	if dups[0].Entry != overlapping || len(dups[0].SharedCommits) != 1 || dups[0].SharedCommits[0] != "bbb222" {
+		t.Errorf("first duplicate = %+v, want the entry sharing commit bbb222", dups[0])
+	}
+	if dups[1].Entry != similar || !dups[1].SimilarSummary || len(dups[1].SharedCommits) != 0 {
+		t.Errorf("second duplicate = %+v, want the recent entry with a similar summary", dups[1])
+	}
+}
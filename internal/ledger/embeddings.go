@@ -0,0 +1,180 @@
+package ledger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// embedBatchSize caps how many entries are sent per embedding request.
+const embedBatchSize = 64
+
+// Embedder turns texts into embedding vectors, one per text, in order.
+// llm.Client satisfies it.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// EmbeddingIndex caches one embedding vector per entry for semantic search.
+// It is a local, rebuildable cache (see Refresh), not part of the ledger:
+// callers keep it outside .timbers/ so it is never committed.
+type EmbeddingIndex struct {
+	Model   string                   `json:"model"`
+	Entries map[string]embeddedEntry `json:"entries"`
+}
+
+// embeddedEntry is one cached vector and the hash of the text it embeds, so
+// an amended entry is re-embedded.
+type embeddedEntry struct {
+	Hash   string    `json:"hash"`
+	Vector []float64 `json:"vector"`
+}
+
+// LoadEmbeddingIndex reads the index at path. A missing file, or an index
+// built with a different model, yields an empty index for model: vectors
+// from different models are not comparable.
+func LoadEmbeddingIndex(path, model string) (*EmbeddingIndex, error) {
+	empty := &EmbeddingIndex{Model: model, Entries: make(map[string]embeddedEntry)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return empty, nil
+		}
+		return nil, fmt.Errorf("reading embedding index: %w", err)
+	}
+
+	var idx EmbeddingIndex
+	if err := json.Unmarshal(data, &idx); err != nil || idx.Model != model || idx.Entries == nil {
+		return empty, nil //nolint:nilerr // a corrupt or stale cache is rebuilt, not reported
+	}
+	return &idx, nil
+}
+
+// Save writes the index to path atomically, creating its directory.
+func (idx *EmbeddingIndex) Save(path string) error {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("encoding embedding index: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating embedding index directory: %w", err)
+	}
+	if err := atomicWrite(path, data); err != nil {
+		return fmt.Errorf("writing embedding index: %w", err)
+	}
+	return nil
+}
+
+// Refresh brings the index up to date with entries: new and amended entries
+// are embedded in batches, and vectors for entries no longer in the ledger
+// are dropped. Encrypted entries are never embedded: their text would leave
+// the machine in the clear and their vectors would be cached on disk.
+// Returns the number of entries embedded.
+func (idx *EmbeddingIndex) Refresh(ctx context.Context, entries []*Entry, embedder Embedder) (int, error) {
+	live := make(map[string]bool, len(entries))
+	var stale []*Entry
+	for _, entry := range entries {
+		if entry.IsEncrypted() {
+			continue
+		}
+		live[entry.ID] = true
+		if cached, ok := idx.Entries[entry.ID]; !ok || cached.Hash != textHash(EmbeddingText(entry)) {
+			stale = append(stale, entry)
+		}
+	}
+	for id := range idx.Entries {
+		if !live[id] {
+			delete(idx.Entries, id)
+		}
+	}
+
+	for start := 0; start < len(stale); start += embedBatchSize {
+		batch := stale[start:min(start+embedBatchSize, len(stale))]
+		texts := make([]string, len(batch))
+		for i, entry := range batch {
+			texts[i] = EmbeddingText(entry)
+		}
+		vectors, err := embedder.Embed(ctx, texts)
+		if err != nil {
+			return start, err
+		}
+		for i, entry := range batch {
+			idx.Entries[entry.ID] = embeddedEntry{Hash: textHash(texts[i]), Vector: vectors[i]}
+		}
+	}
+	return len(stale), nil
+}
+
+// Rank scores entries by cosine similarity between their cached vectors and
+// query, returning up to limit entries with a positive score, most similar
+// first. Entries missing from the index, encrypted ones included, are
+// skipped. A limit of 0 or less
+// returns every match.
+func (idx *EmbeddingIndex) Rank(entries []*Entry, query []float64, limit int) []ScoredEntry {
+	var scored []ScoredEntry
+	for _, entry := range entries {
+		cached, ok := idx.Entries[entry.ID]
+		if !ok {
+			continue
+		}
+		if score := cosine(cached.Vector, query); score > 0 {
+			scored = append(scored, ScoredEntry{Entry: entry, Score: score})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].Entry.CreatedAt.After(scored[j].Entry.CreatedAt)
+	})
+	if limit > 0 && len(scored) > limit {
+		scored = scored[:limit]
+	}
+	return scored
+}
+
+// EmbeddingText is the text embedded for an entry: its summary, tags, and
+// notes, the fields that carry rationale.
+func EmbeddingText(entry *Entry) string {
+	parts := []string{entry.Summary.What, entry.Summary.Why, entry.Summary.How}
+	if len(entry.Tags) > 0 {
+		parts = append(parts, "Tags: "+strings.Join(entry.Tags, ", "))
+	}
+	if entry.Notes != "" {
+		parts = append(parts, entry.Notes)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// textHash fingerprints embedded text.
+func textHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cosine returns the cosine similarity of left and right, or 0 when their
+// lengths differ or either is zero.
+func cosine(left, right []float64) float64 {
+	if len(left) != len(right) || len(left) == 0 {
+		return 0
+	}
+	var dot, normLeft, normRight float64
+	for i := range left {
+		dot += left[i] * right[i]
+		normLeft += left[i] * left[i]
+		normRight += right[i] * right[i]
+	}
+	if normLeft == 0 || normRight == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normLeft) * math.Sqrt(normRight))
+}
@@ -0,0 +1,134 @@
+package ledger
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// topicEmbedder embeds text as counts of a few topic words, and records how
+// many texts it was asked to embed.
+type topicEmbedder struct {
+	embedded int
+}
+
+func (te *topicEmbedder) Embed(_ context.Context, texts []string) ([][]float64, error) {
+	te.embedded += len(texts)
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		lower := strings.ToLower(text)
+		vectors[i] = []float64{
+			float64(strings.Count(lower, "login")),
+			float64(strings.Count(lower, "cache")),
+		}
+	}
+	return vectors, nil
+}
+
+func TestEmbeddingIndex_RefreshIsIncremental(t *testing.T) {
+	base := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	auth := makeTestEntry("aaa111", base)
+	auth.Summary.What = "Harden login flow"
+	cache := makeTestEntry("bbb222", base.Add(time.Hour))
+	cache.Summary.What = "Add cache for pending"
+
+	idx, err := LoadEmbeddingIndex(filepath.Join(t.TempDir(), "missing.json"), "test-model")
+	if err != nil {
+		t.Fatalf("LoadEmbeddingIndex() error = %v", err)
+	}
+	embedder := &topicEmbedder{}
+	ctx := context.Background()
+
+	if n, err := idx.Refresh(ctx, []*Entry{auth, cache}, embedder); err != nil || n != 2 {
+		t.Fatalf("first Refresh() = %d, %v; want 2, nil", n, err)
+	}
+	if n, _ := idx.Refresh(ctx, []*Entry{auth, cache}, embedder); n != 0 {
+		t.Errorf("unchanged Refresh() embedded %d entries, want 0", n)
+	}
+
+	cache.Summary.Why = "login page was slow"
+	if n, _ := idx.Refresh(ctx, []*Entry{auth, cache}, embedder); n != 1 {
+		t.Errorf("Refresh() after amend embedded %d entries, want 1", n)
+	}
+
+	if _, err := idx.Refresh(ctx, []*Entry{cache}, embedder); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := idx.Entries[auth.ID]; ok {
+		t.Error("Refresh() kept a vector for a removed entry")
+	}
+	if embedder.embedded != 3 {
+		t.Errorf("embedded %d texts in total, want 3", embedder.embedded)
+	}
+}
+
+func TestEmbeddingIndex_Rank(t *testing.T) {
+	base := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	auth := makeTestEntry("aaa111", base)
+	auth.Summary.What = "Harden login flow"
+	cache := makeTestEntry("bbb222", base.Add(time.Hour))
+	cache.Summary.What = "Add cache for pending"
+	entries := []*Entry{auth, cache}
+
+	idx, _ := LoadEmbeddingIndex("", "test-model")
+	if _, err := idx.Refresh(context.Background(), entries, &topicEmbedder{}); err != nil {
+		t.Fatal(err)
+	}
+
+	ranked := idx.Rank(entries, []float64{1, 0}, 0)
+	if len(ranked) != 1 || ranked[0].Entry != auth {
+		t.Errorf("Rank(login) = %v, want only the login entry", ranked)
+	}
+}
+
+func TestEmbeddingIndex_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timbers", "embeddings.json")
+	entry := makeTestEntry("aaa111", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+
+	idx, _ := LoadEmbeddingIndex(path, "model-a")
+	if _, err := idx.Refresh(context.Background(), []*Entry{entry}, &topicEmbedder{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadEmbeddingIndex(path, "model-a")
+	if err != nil || len(loaded.Entries) != 1 {
+		t.Errorf("LoadEmbeddingIndex(same model) = %d entries, %v; want 1, nil", len(loaded.Entries), err)
+	}
+	other, err := LoadEmbeddingIndex(path, "model-b")
+	if err != nil || len(other.Entries) != 0 {
+		t.Errorf("LoadEmbeddingIndex(other model) = %d entries, %v; want empty index", len(other.Entries), err)
+	}
+}
+
+func TestEmbeddingIndex_SkipsEncryptedEntries(t *testing.T) {
+	base := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	auth := makeTestEntry("aaa111", base)
+	auth.Summary.What = "Harden login flow"
+	cache := makeTestEntry("bbb222", base.Add(time.Hour))
+	cache.Summary.What = "Add cache for pending"
+	entries := []*Entry{auth, cache}
+
+	idx, _ := LoadEmbeddingIndex("", "test-model")
+	embedder := &topicEmbedder{}
+	if _, err := idx.Refresh(context.Background(), entries, embedder); err != nil {
+		t.Fatal(err)
+	}
+	auth.Encrypt()
+	if _, err := idx.Refresh(context.Background(), entries, embedder); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := idx.Entries[auth.ID]; ok {
+		t.Error("Refresh() kept a vector for an encrypted entry")
+	}
+	if embedder.embedded != 2 {
+		t.Errorf("embedded %d texts in total, want 2 (no re-embedding of the encrypted entry)", embedder.embedded)
+	}
+	if ranked := idx.Rank(entries, []float64{1, 0}, 0); len(ranked) != 0 {
+		t.Errorf("Rank(login) = %v, want no match for the encrypted entry", ranked)
+	}
+}
@@ -13,9 +13,10 @@ import (
 var ErrNotTimbersNote = errors.New("not a timbers note")
 
 // SchemaVersion is the current schema version for timbers entries.
-const SchemaVersion = "timbers.devlog/v1"
+const SchemaVersion = "timbers.devlog/v2"
 
-// KindEntry is the kind identifier for ledger entries.
+// KindEntry is the kind identifier for ledger entries. The other kinds an
+// Entry may carry are listed in EntryKinds.
 const KindEntry = "entry"
 
 // idPrefix is the prefix for all entry IDs.
@@ -26,17 +27,26 @@ const shortSHALength = 6
 
 // Entry represents a development ledger entry.
 type Entry struct {
-	Schema       string        `json:"schema"`
-	Kind         string        `json:"kind"`
-	ID           string        `json:"id"`
-	CreatedAt    time.Time     `json:"created_at"`
-	UpdatedAt    time.Time     `json:"updated_at"`
-	Workset      Workset       `json:"workset"`
-	Summary      Summary       `json:"summary"`
-	Notes        string        `json:"notes,omitempty"`
-	Tags         []string      `json:"tags,omitempty"`
-	WorkItems    []WorkItem    `json:"work_items,omitempty"`
-	Contributors []Contributor `json:"contributors,omitempty"`
+	Schema       string            `json:"schema"`
+	Kind         string            `json:"kind"`
+	ID           string            `json:"id"`
+	CreatedAt    time.Time         `json:"created_at"`
+	StartedAt    *time.Time        `json:"started_at,omitempty"` // when the work began; see Started
+	UpdatedAt    time.Time         `json:"updated_at"`
+	Workset      Workset           `json:"workset"`
+	Summary      Summary           `json:"summary"`
+	Notes        string            `json:"notes,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	WorkItems    []WorkItem        `json:"work_items,omitempty"`
+	Contributors []Contributor     `json:"contributors,omitempty"`
+	Decision     *Decision         `json:"decision,omitempty"`
+	Relations    []Relation        `json:"relations,omitempty"`
+	Meta         map[string]string `json:"meta,omitempty"`      // custom team fields (service, risk, ...); see ParseMeta
+	Revisions    []Revision        `json:"revisions,omitempty"` // prior changes, oldest first; see RecordRevision
+	Encrypted    string            `json:"encrypted,omitempty"` // armored summary and notes; see SealEntry
+
+	encrypt bool // write summary and notes encrypted
+	sealed  bool // read encrypted and could not be decrypted
 }
 
 // Contributor is an identity credited with work described by an entry.
@@ -48,10 +58,13 @@ type Contributor struct {
 
 // Workset represents the set of commits documented by an entry.
 type Workset struct {
-	AnchorCommit string    `json:"anchor_commit"`
-	Commits      []string  `json:"commits"`
-	Range        string    `json:"range,omitempty"`
-	Diffstat     *Diffstat `json:"diffstat,omitempty"`
+	AnchorCommit string       `json:"anchor_commit"`
+	Commits      []string     `json:"commits"`
+	Range        string       `json:"range,omitempty"`
+	Diffstat     *Diffstat    `json:"diffstat,omitempty"`
+	CommitMeta   []CommitMeta `json:"commit_meta,omitempty"`
+	Files        []string     `json:"files,omitempty"`
+	Branch       string       `json:"branch,omitempty"` // branch checked out when the entry was logged
 }
 
 // Summary represents the what/why/how summary of an entry.
@@ -69,9 +82,30 @@ type WorkItem struct {
 
 // Diffstat represents file change statistics.
 type Diffstat struct {
-	Files      int `json:"files"`
-	Insertions int `json:"insertions"`
-	Deletions  int `json:"deletions"`
+	Files          int        `json:"files"`
+	Insertions     int        `json:"insertions"`
+	Deletions      int        `json:"deletions"`
+	BinaryFiles    int        `json:"binary_files,omitempty"`
+	GeneratedFiles int        `json:"generated_files,omitempty"`
+	Renames        []Rename   `json:"renames,omitempty"`
+	PerFile        []FileStat `json:"per_file,omitempty"`
+}
+
+// FileStat records the change to one file of the workset. It is stored only
+// when the entry was logged with --numstat.
+type FileStat struct {
+	Path       string `json:"path"`
+	From       string `json:"from,omitempty"`
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+	Binary     bool   `json:"binary,omitempty"`
+	Generated  bool   `json:"generated,omitempty"`
+}
+
+// Rename records a file moved from one path to another within the workset.
+type Rename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
 }
 
 // ValidationError is returned when entry validation fails.
@@ -146,7 +180,12 @@ func (e *Entry) Validate() error {
 	var missing []string
 	missing = e.validateTopLevel(missing)
 	missing = e.Workset.validate(missing)
-	missing = e.Summary.validate(missing)
+	if e.Encrypted == "" {
+		missing = e.Summary.validate(e.KindOrDefault(), missing)
+	}
+	if RequiresAlternatives(e.KindOrDefault()) && (e.Decision == nil || len(e.Decision.Alternatives) == 0) {
+		missing = append(missing, "decision.alternatives")
+	}
 
 	if len(missing) > 0 {
 		return &ValidationError{
@@ -189,15 +228,15 @@ func (w *Workset) validate(missing []string) []string {
 	return missing
 }
 
-// validate checks required fields in Summary.
-func (s *Summary) validate(missing []string) []string {
+// validate checks the Summary fields kind requires.
+func (s *Summary) validate(kind string, missing []string) []string {
 	if s.What == "" {
 		missing = append(missing, "summary.what")
 	}
-	if s.Why == "" {
+	if s.Why == "" && RequiresWhy(kind) {
 		missing = append(missing, "summary.why")
 	}
-	if s.How == "" {
+	if s.How == "" && RequiresHow(kind) {
 		missing = append(missing, "summary.how")
 	}
 	return missing
@@ -214,8 +253,8 @@ func (e *Entry) ToJSON() ([]byte, error) {
 
 // FromJSON deserializes an entry from JSON.
 // Returns ErrNotTimbersNote if the JSON is valid but doesn't have the timbers schema,
-// or if the kind field is set to something other than "entry" (e.g., "ack" — those
-// share the schema family and should be loaded via FromJSONAck instead).
+// or if the kind field is not one of EntryKinds (e.g., "ack" — those share the
+// schema family and should be loaded via FromJSONAck instead).
 func FromJSON(data []byte) (*Entry, error) {
 	if len(data) == 0 {
 		return nil, errors.New("empty JSON data")
@@ -235,7 +274,7 @@ func FromJSON(data []byte) (*Entry, error) {
 	// direct GetEntryByID("ack_...") would deserialize into a half-populated
 	// Entry struct instead of failing cleanly. The walkEntryFile prefix guard
 	// already keeps ListEntries safe; this catches direct-read paths.
-	if entry.Kind != "" && entry.Kind != KindEntry {
+	if entry.Kind != "" && !IsEntryKind(entry.Kind) {
 		return nil, ErrNotTimbersNote
 	}
 
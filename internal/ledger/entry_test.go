@@ -284,7 +284,7 @@ func TestFromJSONAcceptsOlderEntryWithoutContributors(t *testing.T) {
 
 func TestEntry_ToJSON(t *testing.T) {
 	entry := &Entry{
-		Schema:    SchemaVersion,
+		Schema:    "timbers.devlog/v1",
 		Kind:      KindEntry,
 		ID:        "tb_2026-01-15T15:04:05Z_8f2c1a",
 		CreatedAt: time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC),
@@ -366,8 +366,8 @@ func TestEntry_FromJSON(t *testing.T) {
 		t.Fatalf("FromJSON() error = %v", err)
 	}
 
-	if entry.Schema != SchemaVersion {
-		t.Errorf("Schema = %q, want %q", entry.Schema, SchemaVersion)
+	if entry.Schema != "timbers.devlog/v1" {
+		t.Errorf("Schema = %q, want %q", entry.Schema, "timbers.devlog/v1")
 	}
 	if entry.Kind != KindEntry {
 		t.Errorf("Kind = %q, want %q", entry.Kind, KindEntry)
@@ -407,6 +407,46 @@ func TestEntry_FromJSON(t *testing.T) {
 	}
 }
 
+// TestEntry_FromJSONMigratesV1 loads a timbers.devlog/v1 decision and checks
+// it migrates to the current schema with its content intact.
+func TestEntry_FromJSONMigratesV1(t *testing.T) {
+	v1 := []byte(`{
+		"schema": "timbers.devlog/v1",
+		"kind": "decision",
+		"id": "tb_2026-01-15T15:04:05Z_8f2c1a",
+		"created_at": "2026-01-15T15:04:05Z",
+		"updated_at": "2026-01-15T15:04:05Z",
+		"workset": {
+			"anchor_commit": "8f2c1a9d7b0c3e4f5a6b7c8d9e0f1a2b3c4d5e6f",
+			"commits": ["8f2c1a9d7b0c3e4f5a6b7c8d9e0f1a2b3c4d5e6f"]
+		},
+		"summary": {"what": "Use Postgres", "why": "Need transactions", "how": ""},
+		"decision": {"status": "accepted", "alternatives": ["SQLite"], "supersedes": "tb_2026-01-01T10:00:00Z_aaaaaa"}
+	}`)
+
+	migrated, changed, err := MigrateRecord(v1, "v2")
+	if err != nil || !changed {
+		t.Fatalf("MigrateRecord() = changed %v, %v", changed, err)
+	}
+	entry, err := FromJSON(migrated)
+	if err != nil {
+		t.Fatalf("FromJSON() error = %v", err)
+	}
+
+	if entry.Schema != SchemaVersion {
+		t.Errorf("Schema = %q, want %q", entry.Schema, SchemaVersion)
+	}
+	if err := entry.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want a valid migrated entry", err)
+	}
+	if entry.Summary.What != "Use Postgres" || entry.Summary.Why != "Need transactions" {
+		t.Errorf("Summary = %+v, want the v1 summary", entry.Summary)
+	}
+	if entry.Decision.Supersedes != "" || entry.SupersedesID() != "tb_2026-01-01T10:00:00Z_aaaaaa" {
+		t.Errorf("decision = %+v, relations = %+v, want supersedes moved to a relation", entry.Decision, entry.Relations)
+	}
+}
+
 func TestEntry_FromJSON_NotesOmitted(t *testing.T) {
 	jsonData := []byte(`{
 		"schema": "timbers.devlog/v1",
@@ -2,7 +2,6 @@ package ledger
 
 import (
 	"errors"
-	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -46,25 +45,44 @@ func DefaultGitCommit(path string, message string) error {
 	return err
 }
 
-// FileStorage provides file-based storage for ledger entries in YYYY/MM/DD subdirectories.
-// Each entry is stored as a JSON file at YYYY/MM/DD/<entry-id>.json.
+// FileStorage provides file-based storage for ledger entries.
+// Each entry is stored as a JSON file at <layout dir>/<entry-id>.json; the
+// default layout is YYYY/MM/DD (see Layout).
 type FileStorage struct {
-	dir       string
-	gitAdd    GitAddFunc
-	gitCommit GitCommitFunc
+	dir          string
+	layout       Layout
+	strict       bool
+	idScheme     IDScheme
+	gitAdd       GitAddFunc
+	gitCommit    GitCommitFunc
+	commitPaths  GitCommitPathsFunc
+	tree         *treeSource // set by NewTreeFileStorage; nil reads the working directory
+	indexPath    string      // entry index file; "" disables it (see SetIndex)
+	searchPath   string      // search index file; "" keeps it in memory (see SetSearchIndex)
+	checksumPath string      // entry checksum manifest; "" disables it (see SetChecksums)
+	repoRoot     string      // repository whose HEAD checksums trust; "" trusts none (see SetCommitted)
+	lockPath     string      // ledger write lock; "" disables it (see SetLock)
+	sealer       Sealer      // encrypts and decrypts entry summaries (see SetSealer)
+
+	includeArchived bool // reads see the archive directory (see SetIncludeArchived)
 }
 
 // NewFileStorage creates a FileStorage for the given directory.
 // If gitAdd is nil, uses DefaultGitAdd.
-// If gitCommit is nil, uses DefaultGitCommit.
+// If gitCommit is nil, uses DefaultGitCommit, and multi-entry writes commit
+// all their files in one commit via DefaultGitCommitPaths. A custom gitCommit
+// is called once per file for multi-entry writes.
 func NewFileStorage(dir string, gitAdd GitAddFunc, gitCommit GitCommitFunc) *FileStorage {
 	if gitAdd == nil {
 		gitAdd = DefaultGitAdd
 	}
+	commitPaths := DefaultGitCommitPaths
 	if gitCommit == nil {
 		gitCommit = DefaultGitCommit
+	} else {
+		commitPaths = commitEachPath(gitCommit)
 	}
-	return &FileStorage{dir: dir, gitAdd: gitAdd, gitCommit: gitCommit}
+	return &FileStorage{dir: dir, gitAdd: gitAdd, gitCommit: gitCommit, commitPaths: commitPaths}
 }
 
 // Dir returns the storage directory path.
@@ -74,13 +92,17 @@ func (fs *FileStorage) Dir() string {
 
 // DirExists returns true if the storage directory exists.
 func (fs *FileStorage) DirExists() bool {
+	if fs.tree != nil {
+		files, err := fs.tree.load(fs.dir)
+		return err == nil && len(files) > 0
+	}
 	info, err := os.Stat(fs.dir)
 	return err == nil && info.IsDir()
 }
 
 // EntryDateDir extracts the YYYY/MM/DD relative path from an entry ID.
-// Entry IDs have the format: tb_YYYY-MM-DDT...
-// Returns empty string if the ID format is unexpected.
+// Entry IDs have the format tb_YYYY-MM-DDT... or tb_<ULID>, whose UTC date
+// is used. Returns empty string if the ID format is unexpected.
 func EntryDateDir(id string) string {
 	if len(id) >= 13 && id[:3] == "tb_" {
 		datePart := id[3:13] // "2026-01-19"
@@ -89,16 +111,18 @@ func EntryDateDir(id string) string {
 			return filepath.Join(parts[0], parts[1], parts[2])
 		}
 	}
+	if rest, ok := strings.CutPrefix(id, idPrefix); ok {
+		if stamp, isULID := ulidTime(rest); isULID {
+			return filepath.FromSlash(stamp.Format("2006/01/02"))
+		}
+	}
 	return ""
 }
 
-// entryDir returns the directory for an entry ID (root/YYYY/MM/DD).
-// Falls back to the root storage directory if the ID format is unexpected.
+// entryDir returns the directory new writes use for an entry ID, per the
+// configured layout.
 func (fs *FileStorage) entryDir(id string) string {
-	if sub := EntryDateDir(id); sub != "" {
-		return filepath.Join(fs.dir, sub)
-	}
-	return fs.dir
+	return filepath.Join(fs.dir, fs.Layout().Dir(id))
 }
 
 // entryPath returns the file path for an entry ID using the safe (dashed)
@@ -107,29 +131,19 @@ func (fs *FileStorage) entryPath(id string) string {
 	return filepath.Join(fs.entryDir(id), IDToFilename(id)+".json")
 }
 
-// legacyEntryPath returns the pre-v0.18 colon-encoded file path for an entry ID.
-// Used as a read-side fallback so existing ledgers (before the colon-to-dash
-// migration) keep working without forcing a rewrite.
-func (fs *FileStorage) legacyEntryPath(id string) string {
-	return filepath.Join(fs.entryDir(id), id+".json")
-}
-
-// existingEntryPath returns the path to the entry file on disk, preferring the
-// canonical (dashed) filename and falling back to the legacy (colon-encoded)
-// filename. Returns the canonical path if neither exists, so callers get a
-// sensible target for error messages.
-func (fs *FileStorage) existingEntryPath(id string) string {
-	canonical := fs.entryPath(id)
-	if _, err := os.Stat(canonical); err == nil {
-		return canonical
-	}
-	legacy := fs.legacyEntryPath(id)
-	if legacy != canonical {
-		if _, err := os.Stat(legacy); err == nil {
-			return legacy
+// existingEntryPath returns the path to the entry file on disk. It checks the
+// configured layout first, then the other layouts, each in canonical (dashed)
+// and legacy (colon-encoded) filename form, so ledgers written before a
+// layout change or the v0.18 filename migration stay readable. Returns the
+// canonical path and false if no file exists, so callers get a sensible
+// target for error messages.
+func (fs *FileStorage) existingEntryPath(id string) (string, bool) {
+	for _, path := range fs.candidateEntryPaths(id) {
+		if fs.fileExists(path) {
+			return path, true
 		}
 	}
-	return canonical
+	return fs.entryPath(id), false
 }
 
 // ReadEntry reads the entry with the given ID from the storage directory.
@@ -138,22 +152,27 @@ func (fs *FileStorage) existingEntryPath(id string) string {
 // Reads accept both the canonical (dashed) filename and the legacy (colon)
 // filename so pre-v0.18 ledgers remain readable.
 func (fs *FileStorage) ReadEntry(id string) (*Entry, error) {
-	path := fs.existingEntryPath(id)
-	data, err := os.ReadFile(path)
+	path, _ := fs.existingEntryPath(id)
+	data, err := fs.readFile(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return nil, output.NewUserError("entry not found: " + id)
+			return nil, output.NewUserError("entry not found: " + id).WithID(output.ErrCodeEntryNotFound)
 		}
 		return nil, output.NewSystemErrorWithCause("failed to read entry file: "+path, err)
 	}
 
-	entry, err := FromJSON(data)
+	parse := FromJSON
+	if fs.strict {
+		parse = FromJSONStrict
+	}
+	entry, err := parse(data)
 	if err != nil {
 		if errors.Is(err, ErrNotTimbersNote) {
 			return nil, err
 		}
 		return nil, output.NewUserError("failed to parse entry: " + err.Error())
 	}
+	fs.openEntry(entry)
 
 	return entry, nil
 }
@@ -173,8 +192,10 @@ func (fs *FileStorage) ListEntriesWithStats() ([]*Entry, *ListStats, error) {
 	stats := &ListStats{}
 	var entries []*Entry
 
-	err := filepath.WalkDir(fs.dir, func(path string, d os.DirEntry, err error) error {
-		return fs.walkEntryFile(path, d, err, &entries, stats)
+	idx := fs.loadIndex()
+	err := fs.walkFiles(func(path, name string) error {
+		fs.walkEntryFile(idx, path, name, &entries, stats)
+		return nil
 	})
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
@@ -182,53 +203,37 @@ func (fs *FileStorage) ListEntriesWithStats() ([]*Entry, *ListStats, error) {
 		}
 		return nil, nil, output.NewSystemErrorWithCause("failed to walk storage directory", err)
 	}
+	idx.save()
 
 	return entries, stats, nil
 }
 
 // walkEntryFile is the per-file callback used by ListEntriesWithStats.
 // Extracted so the outer function stays under the cognitive-complexity
-// limit. Mutates the entries slice and stats counters in place; returns
-// non-nil only for fatal walk errors (read failures of individual files
-// are recorded as stats and swallowed).
-func (fs *FileStorage) walkEntryFile(
-	path string, d os.DirEntry, walkErr error,
-	entries *[]*Entry, stats *ListStats,
-) error {
-	if walkErr != nil {
-		return walkErr
-	}
-	if d.IsDir() || !strings.HasSuffix(d.Name(), ".json") {
-		return nil
+// limit. Mutates the entries slice and stats counters in place; read
+// failures of individual files are recorded as stats and skipped. Unchanged
+// files come from idx when the entry index is enabled.
+func (fs *FileStorage) walkEntryFile(idx *entryIndex, path, fileName string, entries *[]*Entry, stats *ListStats) {
+	if !strings.HasSuffix(fileName, ".json") {
+		return
 	}
 
-	// Ack files (ack_*.json) live in the same date layout as entries
-	// but are not entries — skip them silently so they don't show up
-	// in parse-error stats.
-	name := strings.TrimSuffix(d.Name(), ".json")
-	if strings.HasPrefix(name, ackIDPrefix) {
-		return nil
+	// Ack (ack_*.json) and tombstone (rm_*.json) files live in the same
+	// date layout as entries but are not entries — skip them silently so
+	// they don't show up in parse-error stats.
+	name := strings.TrimSuffix(fileName, ".json")
+	if strings.HasPrefix(name, ackIDPrefix) || strings.HasPrefix(name, tombstoneIDPrefix) {
+		return
 	}
 
-	stats.Total++
 	// Filenames may be in either format (canonical dashed, post-v0.18; or
 	// legacy colon-encoded). Convert to the canonical ID for ReadEntry.
 	id := FilenameToID(name)
-	entry, readErr := fs.ReadEntry(id)
-	if readErr != nil {
-		stats.Skipped++
-		if errors.Is(readErr, ErrNotTimbersNote) {
-			stats.NotTimbers++
-		} else {
-			stats.ParseErrors++
-			stats.CorruptFiles = append(stats.CorruptFiles, filepath.ToSlash(path))
-		}
-		//nolint:nilerr // per-file parse errors are recorded in stats and skipped; the walk continues
-		return nil
+	entry, readErr := fs.readIndexedEntry(idx, path, id)
+	stats.recordRead(path, readErr)
+	if readErr == nil {
+		*entries = append(*entries, entry)
 	}
-	*entries = append(*entries, entry)
-	stats.Parsed++
-	return nil
 }
 
 // WriteEntry writes an entry to the storage directory and stages it with git add.
@@ -236,25 +241,38 @@ func (fs *FileStorage) walkEntryFile(
 // If force is false and the entry file already exists, returns a conflict error.
 // If force is true, overwrites any existing file.
 func (fs *FileStorage) WriteEntry(entry *Entry, force bool) error {
+	if err := fs.checkWritable("write entry " + entry.ID); err != nil {
+		return err
+	}
 	if err := entry.Validate(); err != nil {
 		return output.NewUserError(err.Error())
 	}
+	unlock, err := fs.lock("write entry " + entry.ID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return fs.writeEntry(entry, force)
+}
 
+// writeEntry is WriteEntry once the entry is validated and the ledger lock
+// is held.
+func (fs *FileStorage) writeEntry(entry *Entry, force bool) error {
 	path := fs.entryPath(entry.ID)
 
-	// Check for existing entry if not forcing — consider both canonical and
-	// legacy filename forms so we don't silently create a duplicate alongside
-	// a pre-v0.18 file.
+	// Check for existing entry if not forcing — consider every layout and
+	// filename form so we don't silently create a duplicate alongside an
+	// older file.
 	if !force && fs.EntryExists(entry.ID) {
-		return output.NewConflictError("entry already exists: " + entry.ID)
+		return output.NewConflictError("entry already exists: " + entry.ID).WithID(output.ErrCodeEntryExists)
 	}
 
-	data, err := entry.ToJSON()
+	data, err := fs.marshalEntry(entry)
 	if err != nil {
-		return output.NewSystemError("failed to serialize entry: " + err.Error())
+		return err
 	}
 
-	// Ensure the date directory exists
+	// Ensure the layout directory exists
 	if err = os.MkdirAll(fs.entryDir(entry.ID), 0o755); err != nil {
 		return output.NewSystemErrorWithCause("failed to create entry directory", err)
 	}
@@ -267,11 +285,14 @@ func (fs *FileStorage) WriteEntry(entry *Entry, force bool) error {
 		return output.NewSystemErrorWithCause("failed to stage entry file", err)
 	}
 
-	// Transparent migration: if a legacy (colon-encoded) sibling exists for the
-	// same ID, remove it so the canonical (dashed) file is the single source of
-	// truth. WriteEntry is the one-way upgrade boundary. Done after the
-	// canonical is staged so a failure here cannot leave the new entry unstaged.
-	fs.removeLegacySibling(entry.ID, path)
+	// Transparent migration: if a legacy (colon-encoded) or other-layout
+	// sibling exists for the same ID, remove it so the canonical file is the
+	// single source of truth. WriteEntry is the one-way upgrade boundary. Done
+	// after the canonical is staged so a failure here cannot leave the new
+	// entry unstaged.
+	fs.removeStaleSiblings(entry.ID, path)
+	fs.indexWrittenEntry(path, data)
+	fs.recordChecksums([]string{path})
 
 	if err = fs.gitCommit(path, "timbers: document "+entry.ID); err != nil {
 		return output.NewSystemErrorWithCause("failed to commit entry file", err)
@@ -280,58 +301,28 @@ func (fs *FileStorage) WriteEntry(entry *Entry, force bool) error {
 	return nil
 }
 
-// atomicWrite writes data to path using write-to-temp-then-rename.
-// The temp file is created in the same directory as path.
-func atomicWrite(path string, data []byte) error {
-	dir := filepath.Dir(path)
-	tmpFile, err := os.CreateTemp(dir, ".tmp-*.json")
-	if err != nil {
-		return fmt.Errorf("create temp file: %w", err)
-	}
-	tmpPath := tmpFile.Name()
-	defer func() { _ = os.Remove(tmpPath) }()
-
-	if _, err := tmpFile.Write(data); err != nil {
-		_ = tmpFile.Close()
-		return fmt.Errorf("write data: %w", err)
-	}
-	if err := tmpFile.Close(); err != nil {
-		return fmt.Errorf("close temp file: %w", err)
-	}
-	if err := os.Rename(tmpPath, path); err != nil {
-		return fmt.Errorf("rename temp file: %w", err)
-	}
-	return nil
-}
-
-// removeLegacySibling deletes the pre-v0.18 colon-encoded file for an ID
-// after the canonical file has been written. Best-effort: errors are
-// ignored so a write that succeeded otherwise is not failed by a stale-file
-// cleanup. The canonical write has already happened.
-func (fs *FileStorage) removeLegacySibling(id, canonical string) {
-	legacy := fs.legacyEntryPath(id)
-	if legacy == canonical {
-		return
-	}
-	if _, err := os.Stat(legacy); err != nil {
-		return
-	}
-	if err := os.Remove(legacy); err != nil {
-		return
+// removeStaleSiblings deletes every other file for an ID (legacy
+// colon-encoded names, other layouts) after the canonical file has been
+// written. Best-effort: errors are ignored so a write that succeeded
+// otherwise is not failed by a stale-file cleanup.
+func (fs *FileStorage) removeStaleSiblings(id, canonical string) {
+	for _, path := range fs.candidateEntryPaths(id) {
+		if path == canonical {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			continue
+		}
+		_ = fs.gitAdd(path)
 	}
-	_ = fs.gitAdd(legacy)
 }
 
 // EntryExists returns true if an entry file exists for the given ID,
-// in either the canonical or legacy filename format.
+// in any layout or filename format.
 func (fs *FileStorage) EntryExists(id string) bool {
-	if _, err := os.Stat(fs.entryPath(id)); err == nil {
-		return true
-	}
-	legacy := fs.legacyEntryPath(id)
-	if legacy == fs.entryPath(id) {
-		return false
-	}
-	_, err := os.Stat(legacy)
-	return err == nil
+	_, ok := fs.existingEntryPath(id)
+	return ok
 }
@@ -0,0 +1,151 @@
+package ledger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// GitCommitPathsFunc commits the files at the given paths in a single commit.
+type GitCommitPathsFunc func(paths []string, message string) error
+
+// DefaultGitCommitPaths commits the given files in one commit, pathspec-scoped
+// like DefaultGitCommit and self-exempt from the cross-agent-debt gate for the
+// same reason.
+func DefaultGitCommitPaths(paths []string, message string) error {
+	args := append([]string{"commit", "-m", message, "--"}, paths...)
+	_, err := git.RunWithEnv([]string{SkipCrossAgentDebtEnv + "=1"}, args...)
+	return err
+}
+
+// commitEachPath adapts a single-file GitCommitFunc to GitCommitPathsFunc.
+func commitEachPath(commit GitCommitFunc) GitCommitPathsFunc {
+	return func(paths []string, message string) error {
+		for _, path := range paths {
+			if err := commit(path, message); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// pendingWrite is one entry of a WriteEntries batch on its way to disk.
+type pendingWrite struct {
+	path    string // final entry path
+	tmpPath string // temp file awaiting rename; "" once renamed
+}
+
+// WriteEntries writes a batch of entries atomically. Every entry is validated
+// and conflict-checked first; then all temp files are written, renamed into
+// place, staged, and committed in one commit. A failure at any step removes
+// every file the batch created and unstages it, so the ledger is never left
+// half-written or half-staged. Unlike WriteEntry, existing entries are never
+// overwritten.
+func (fs *FileStorage) WriteEntries(entries []*Entry) error {
+	if err := fs.checkWritable("write entries"); err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	unlock, err := fs.lock("write " + strconv.Itoa(len(entries)) + " entries")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return fs.writeBatch(entries)
+}
+
+// writeBatch is WriteEntries once the ledger lock is held.
+func (fs *FileStorage) writeBatch(entries []*Entry) error {
+	writes, err := fs.prepareBatch(entries)
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, len(writes))
+	for i := range writes {
+		paths[i] = writes[i].path
+		if err := os.Rename(writes[i].tmpPath, writes[i].path); err != nil {
+			fs.rollbackBatch(writes, false)
+			return output.NewSystemErrorWithCause("failed to write entry", fmt.Errorf("rename temp file: %w", err))
+		}
+		writes[i].tmpPath = ""
+	}
+
+	for _, path := range paths {
+		if err := fs.gitAdd(path); err != nil {
+			fs.rollbackBatch(writes, true)
+			return output.NewSystemErrorWithCause("failed to stage entry file", err)
+		}
+	}
+
+	message := fmt.Sprintf("timbers: document %d entries", len(entries))
+	if len(entries) == 1 {
+		message = "timbers: document " + entries[0].ID
+	}
+	if err := fs.commitPaths(paths, message); err != nil {
+		fs.rollbackBatch(writes, true)
+		return output.NewSystemErrorWithCause("failed to commit entry files", err)
+	}
+	fs.recordChecksums(paths)
+	return nil
+}
+
+// prepareBatch validates and conflict-checks every entry, then writes each
+// to a temp file beside its final path. On failure no temp files remain.
+func (fs *FileStorage) prepareBatch(entries []*Entry) ([]pendingWrite, error) {
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if err := entry.Validate(); err != nil {
+			return nil, output.NewUserError(err.Error())
+		}
+		if seen[entry.ID] || fs.EntryExists(entry.ID) {
+			return nil, output.NewConflictError("entry already exists: " + entry.ID).WithID(output.ErrCodeEntryExists)
+		}
+		seen[entry.ID] = true
+	}
+
+	writes := make([]pendingWrite, 0, len(entries))
+	for _, entry := range entries {
+		data, err := fs.marshalEntry(entry)
+		if err != nil {
+			fs.rollbackBatch(writes, false)
+			return nil, err
+		}
+		path := fs.entryPath(entry.ID)
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			fs.rollbackBatch(writes, false)
+			return nil, output.NewSystemErrorWithCause("failed to create entry directory", err)
+		}
+		tmpPath, err := writeTemp(filepath.Dir(path), data)
+		if err != nil {
+			fs.rollbackBatch(writes, false)
+			return nil, output.NewSystemErrorWithCause("failed to write entry", err)
+		}
+		writes = append(writes, pendingWrite{path: path, tmpPath: tmpPath})
+	}
+	return writes, nil
+}
+
+// rollbackBatch removes every temp file and renamed entry file of a failed
+// batch. When staged is true the removals are staged too, which drops the
+// new files from the index again. Best-effort: cleanup errors are ignored so
+// the original failure is what the caller reports.
+func (fs *FileStorage) rollbackBatch(writes []pendingWrite, staged bool) {
+	for _, write := range writes {
+		if write.tmpPath != "" {
+			_ = os.Remove(write.tmpPath)
+			continue
+		}
+		_ = os.Remove(write.path)
+		if staged {
+			_ = fs.gitAdd(write.path)
+		}
+	}
+}
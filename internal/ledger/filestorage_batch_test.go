@@ -0,0 +1,135 @@
+package ledger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+func batchTestEntries() []*Entry {
+	day := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	return []*Entry{
+		makeTestEntry("batch001", day),
+		makeTestEntry("batch002", day.Add(time.Hour)),
+		makeTestEntry("batch003", day.Add(2*time.Hour)),
+	}
+}
+
+func TestFileStorage_WriteEntries(t *testing.T) {
+	dir := t.TempDir()
+	adds := &gitAddRecorder{}
+	commits := &gitCommitRecorder{}
+	store := NewFileStorage(dir, adds.add, commits.commit)
+
+	if err := store.WriteEntries(batchTestEntries()); err != nil {
+		t.Fatalf("WriteEntries: %v", err)
+	}
+	entries, err := store.ListEntries()
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("ListEntries() = %d entries, %v; want 3", len(entries), err)
+	}
+	if len(adds.paths) != 3 || len(commits.paths) != 3 {
+		t.Errorf("staged %d and committed %d paths, want 3 each", len(adds.paths), len(commits.paths))
+	}
+}
+
+// TestFileStorage_WriteEntries_RollsBackOnStageFailure verifies a failure
+// midway through staging removes every file the batch wrote and re-stages
+// the removals, leaving neither a half-written nor a half-staged ledger.
+func TestFileStorage_WriteEntries_RollsBackOnStageFailure(t *testing.T) {
+	dir := t.TempDir()
+	var staged []string
+	calls := 0
+	failSecond := func(path string) error {
+		calls++
+		if calls == 2 {
+			return errors.New("index.lock exists")
+		}
+		staged = append(staged, path)
+		return nil
+	}
+	store := NewFileStorage(dir, failSecond, noopGitCommit)
+
+	if err := store.WriteEntries(batchTestEntries()); err == nil {
+		t.Fatal("WriteEntries should fail when staging fails")
+	}
+	if n := countFiles(t, dir); n != 0 {
+		t.Errorf("%d files left behind after rollback, want 0", n)
+	}
+	// First add succeeded, then rollback re-adds all three removed paths.
+	if len(staged) != 4 {
+		t.Errorf("staged %d paths (%v), want 1 write + 3 rollback removals", len(staged), staged)
+	}
+}
+
+func TestFileStorage_WriteEntries_ConflictWritesNothing(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStorage(dir, noopGitAdd, noopGitCommit)
+	entries := batchTestEntries()
+	if err := store.WriteEntry(entries[2], false); err != nil {
+		t.Fatalf("WriteEntry: %v", err)
+	}
+
+	err := store.WriteEntries(entries)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("WriteEntries error = %v, want conflict", err)
+	}
+	if n := countFiles(t, dir); n != 1 {
+		t.Errorf("%d files on disk, want only the pre-existing entry", n)
+	}
+}
+
+// TestFileStorage_WriteEntries_SingleCommit runs against a real repository
+// and verifies the default storage commits the whole batch as one commit.
+func TestFileStorage_WriteEntries_SingleCommit(t *testing.T) {
+	repo := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test"},
+		{"commit", "--allow-empty", "-m", "init"},
+	} {
+		if _, err := git.RunInDir(repo, nil, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	t.Chdir(repo)
+
+	store := NewFileStorage(filepath.Join(repo, ".timbers"), nil, nil)
+	if err := store.WriteEntries(batchTestEntries()); err != nil {
+		t.Fatalf("WriteEntries: %v", err)
+	}
+
+	out, err := git.RunInDir(repo, nil, "show", "--name-only", "--format=%s", "HEAD")
+	if err != nil {
+		t.Fatalf("git show: %v", err)
+	}
+	lines := strings.Split(out, "\n")
+	if lines[0] != "timbers: document 3 entries" {
+		t.Errorf("HEAD subject = %q, want one batch commit", lines[0])
+	}
+	if got := strings.Count(out, ".json"); got != 3 {
+		t.Errorf("HEAD touches %d entry files, want 3:\n%s", got, out)
+	}
+}
+
+// countFiles returns the number of regular files under dir.
+func countFiles(t *testing.T, dir string) int {
+	t.Helper()
+	count := 0
+	err := filepath.WalkDir(dir, func(_ string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			count++
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("walk %s: %v", dir, err)
+	}
+	return count
+}
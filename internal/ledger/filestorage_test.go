@@ -802,3 +802,31 @@ func TestFileStorage_WriteEntry_NoTempFilesLeftBehind(t *testing.T) {
 		t.Fatalf("failed to walk dir: %v", walkErr)
 	}
 }
+
+func TestFileStorage_ReadOnly(t *testing.T) {
+	SetReadOnly(true)
+	t.Cleanup(func() { SetReadOnly(false) })
+
+	dir := t.TempDir()
+	storage := NewFileStorage(dir, noopGitAdd, noopGitCommit)
+	entry := &Entry{
+		Schema:    SchemaVersion,
+		Kind:      "entry",
+		ID:        "tb_2026-01-15T15:04:05Z_abc123",
+		CreatedAt: time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC),
+		UpdatedAt: time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC),
+		Workset:   Workset{AnchorCommit: "abc1234567890"},
+		Summary:   Summary{What: "w", Why: "y", How: "h"},
+	}
+
+	err := storage.WriteEntry(entry, false)
+	if err == nil || !strings.Contains(err.Error(), "read-only") {
+		t.Fatalf("WriteEntry() error = %v, want read-only refusal", err)
+	}
+	if output.GetExitCode(err) != output.ExitUserError {
+		t.Errorf("exit code = %d, want %d", output.GetExitCode(err), output.ExitUserError)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("read-only write left %d files behind", len(entries))
+	}
+}
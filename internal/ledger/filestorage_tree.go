@@ -0,0 +1,159 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// treeSource serves the ledger from a git tree-ish instead of the working
+// directory. The files are read once, on first use, and never change: a
+// read-only FileStorage has nothing to invalidate them.
+type treeSource struct {
+	treeish string
+
+	once  sync.Once
+	files map[string][]byte // slash-separated repo-relative path -> contents
+	err   error
+}
+
+// load reads every file under dir in the tree-ish.
+func (src *treeSource) load(dir string) (map[string][]byte, error) {
+	src.once.Do(func() {
+		src.files, src.err = git.TreeFiles(src.treeish, filepath.ToSlash(dir))
+	})
+	return src.files, src.err
+}
+
+// NewTreeFileStorage returns a read-only FileStorage over the .timbers/
+// directory of treeish ("HEAD", a branch, a commit). It reads from the object
+// database, so it works in bare repositories, where there is no working
+// directory to read. Every write returns a read-only error.
+func NewTreeFileStorage(treeish string) *FileStorage {
+	files := NewFileStorage(".timbers", nil, nil)
+	files.tree = &treeSource{treeish: treeish}
+	return files
+}
+
+// NewTreeStorage creates a read-only Storage whose entries come from the
+// .timbers/ directory of treeish rather than the working directory, for
+// reports on bare mirrors. Skip rules are the built-in defaults and the
+// provenance classifier is off: both come from working-tree files and
+// local git config that a mirror does not have.
+func NewTreeStorage(treeish string) *Storage {
+	return NewStorage(nil, NewTreeFileStorage(treeish))
+}
+
+// Treeish returns the tree-ish a storage from NewTreeFileStorage reads, or ""
+// for a storage backed by the working directory.
+func (fs *FileStorage) Treeish() string {
+	if fs.tree == nil {
+		return ""
+	}
+	return fs.tree.treeish
+}
+
+// Treeish returns the tree-ish a storage from NewTreeStorage reads, or ""
+// for a storage backed by the working directory.
+func (s *Storage) Treeish() string {
+	if s.files == nil {
+		return ""
+	}
+	return s.files.Treeish()
+}
+
+// DirExists reports whether the ledger directory exists.
+func (s *Storage) DirExists() bool {
+	return s.files != nil && s.files.DirExists()
+}
+
+// checkWritable refuses writes to a tree-backed storage, then applies the
+// process-wide read-only mode.
+func (fs *FileStorage) checkWritable(operation string) error {
+	if fs.tree != nil {
+		return output.NewUserError("read-only: the ledger is read from " + fs.tree.treeish +
+			" (bare repository); refusing to " + operation).WithID(output.ErrCodeReadOnly)
+	}
+	return checkWritable(operation)
+}
+
+// readFile reads path from the working directory, or from the tree.
+// A path missing from the tree reports os.ErrNotExist, as os.ReadFile does.
+func (fs *FileStorage) readFile(path string) ([]byte, error) {
+	if fs.tree == nil {
+		return os.ReadFile(path) //nolint:gosec,wrapcheck // paths are built under fs.dir; callers test os.ErrNotExist
+	}
+	files, err := fs.tree.load(fs.dir)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := files[filepath.ToSlash(path)]
+	if !ok {
+		return nil, &os.PathError{Op: "read", Path: path, Err: os.ErrNotExist}
+	}
+	return data, nil
+}
+
+// fileExists reports whether path exists in the working directory, or in
+// the tree.
+func (fs *FileStorage) fileExists(path string) bool {
+	if fs.tree == nil {
+		_, err := os.Stat(path)
+		return err == nil
+	}
+	files, err := fs.tree.load(fs.dir)
+	if err != nil {
+		return false
+	}
+	_, ok := files[filepath.ToSlash(path)]
+	return ok
+}
+
+// walkFiles calls visit for every regular file under the storage directory, in
+// lexical order. A missing directory reports os.ErrNotExist. The archive
+// directory is skipped unless archived entries are included.
+func (fs *FileStorage) walkFiles(visit func(path, name string) error) error {
+	if fs.tree == nil {
+		//nolint:wrapcheck // callers test os.ErrNotExist and their own visit errors
+		return filepath.WalkDir(fs.dir, func(path string, entry os.DirEntry, err error) error {
+			if err == nil && entry.IsDir() && path == fs.archiveRoot() && !fs.includeArchived {
+				return filepath.SkipDir
+			}
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			return visit(path, entry.Name())
+		})
+	}
+	return fs.walkTree(visit)
+}
+
+// walkTree is walkFiles for a storage that reads a git tree.
+func (fs *FileStorage) walkTree(visit func(path, name string) error) error {
+	files, err := fs.tree.load(fs.dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return os.ErrNotExist
+	}
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if fs.inArchive(path) && !fs.includeArchived {
+			continue
+		}
+		if err := visit(filepath.FromSlash(path), path[strings.LastIndex(path, "/")+1:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -45,6 +45,36 @@ func FilterEntriesByTags(entries []*Entry, tags []string) []*Entry {
 	return result
 }
 
+// FilterEntriesByBranch keeps entries logged on branch. An empty branch
+// keeps all.
+func FilterEntriesByBranch(entries []*Entry, branch string) []*Entry {
+	if branch == "" {
+		return entries
+	}
+	var result []*Entry
+	for _, entry := range entries {
+		if entry.Workset.Branch == branch {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// FilterEntriesByAnchors keeps entries whose anchor commit is in anchors. A
+// nil set keeps all.
+func FilterEntriesByAnchors(entries []*Entry, anchors map[string]bool) []*Entry {
+	if anchors == nil {
+		return entries
+	}
+	var result []*Entry
+	for _, entry := range entries {
+		if anchors[entry.Workset.AnchorCommit] {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
 // EntryHasAnyTag checks if the entry has any of the specified tags.
 func EntryHasAnyTag(entry *Entry, tags []string) bool {
 	for _, entryTag := range entry.Tags {
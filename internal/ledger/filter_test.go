@@ -202,6 +202,29 @@ func TestSortEntriesByCreatedAt(t *testing.T) {
 	}
 }
 
+func TestFilterEntriesByBranchAndAnchors(t *testing.T) {
+	now := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	onMain := createFilterTestEntry("aaa111", "Main work", now, nil)
+	onMain.Workset.Branch = "main"
+	onFeature := createFilterTestEntry("bbb222", "Feature work", now, nil)
+	onFeature.Workset.Branch = "feat/oauth"
+	unknown := createFilterTestEntry("ccc333", "Unrecorded branch", now, nil)
+	entries := []*Entry{onMain, onFeature, unknown}
+
+	if got := FilterEntriesByBranch(entries, ""); len(got) != 3 {
+		t.Errorf("empty branch kept %d entries, want all 3", len(got))
+	}
+	if got := FilterEntriesByBranch(entries, "feat/oauth"); len(got) != 1 || got[0] != onFeature {
+		t.Errorf("FilterEntriesByBranch = %v, want only the feature entry", got)
+	}
+	if got := FilterEntriesByAnchors(entries, nil); len(got) != 3 {
+		t.Errorf("nil anchor set kept %d entries, want all 3", len(got))
+	}
+	if got := FilterEntriesByAnchors(entries, map[string]bool{"aaa111": true, "ccc333": true}); len(got) != 2 {
+		t.Errorf("FilterEntriesByAnchors kept %d entries, want 2", len(got))
+	}
+}
+
 // createFilterTestEntry creates a minimal valid entry for testing filters.
 func createFilterTestEntry(anchor, what string, created time.Time, tags []string) *Entry {
 	return &Entry{
@@ -1,50 +1,85 @@
 package ledger
 
-import "github.com/gorewood/timbers/internal/git"
+import (
+	"context"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+// WithContext returns a shallow copy of s whose git operations run under
+// ctx, so a caller with its own deadline or cancellation — an MCP request,
+// say — bounds the git work done on its behalf. The copy shares entry
+// storage and skip rules with s.
+func (s *Storage) WithContext(ctx context.Context) *Storage {
+	clone := *s
+	clone.ctx = ctx
+	return &clone
+}
+
+// context returns the context git operations run under: the one given to
+// WithContext, or the package-wide git context set per CLI command.
+func (s *Storage) context() context.Context {
+	if s.ctx != nil {
+		return s.ctx
+	}
+	return git.Context()
+}
 
 // realGitOps implements GitOps using the actual git package functions.
 type realGitOps struct{}
 
-func (realGitOps) HEAD() (string, error) {
-	return git.HEAD()
+func (realGitOps) HEAD(ctx context.Context) (string, error) {
+	return git.HEADContext(ctx)
+}
+
+func (realGitOps) Log(ctx context.Context, fromRef, toRef string) ([]git.Commit, error) {
+	return git.LogContext(ctx, fromRef, toRef)
+}
+
+func (realGitOps) LogFirstParent(ctx context.Context, fromRef, toRef string) ([]git.Commit, error) {
+	return git.LogFirstParentContext(ctx, fromRef, toRef)
+}
+
+func (realGitOps) ResolveCommit(ctx context.Context, ref string) (string, error) {
+	return git.ResolveCommitContext(ctx, ref)
 }
 
-func (realGitOps) Log(fromRef, toRef string) ([]git.Commit, error) {
-	return git.Log(fromRef, toRef)
+func (realGitOps) CommitsReachableFrom(ctx context.Context, sha string) ([]git.Commit, error) {
+	return git.CommitsReachableFromContext(ctx, sha)
 }
 
-func (realGitOps) LogFirstParent(fromRef, toRef string) ([]git.Commit, error) {
-	return git.LogFirstParent(fromRef, toRef)
+func (realGitOps) CommitsReachableFromFirstParent(ctx context.Context, sha string) ([]git.Commit, error) {
+	return git.CommitsReachableFromFirstParentContext(ctx, sha)
 }
 
-func (realGitOps) ResolveCommit(ref string) (string, error) {
-	return git.ResolveCommit(ref)
+func (realGitOps) IsAncestorOf(ctx context.Context, ancestor, descendant string) bool {
+	return git.IsAncestorOfContext(ctx, ancestor, descendant)
 }
 
-func (realGitOps) CommitsReachableFrom(sha string) ([]git.Commit, error) {
-	return git.CommitsReachableFrom(sha)
+func (realGitOps) IsOnFirstParentLine(ctx context.Context, sha, head string) bool {
+	return git.IsOnFirstParentLineContext(ctx, sha, head)
 }
 
-func (realGitOps) IsAncestorOf(ancestor, descendant string) bool {
-	return git.IsAncestorOf(ancestor, descendant)
+func (realGitOps) IsShallow(ctx context.Context) bool {
+	return git.IsShallowContext(ctx)
 }
 
-func (realGitOps) IsOnFirstParentLine(sha, head string) bool {
-	return git.IsOnFirstParentLine(sha, head)
+func (realGitOps) GetDiffstat(ctx context.Context, fromRef, toRef string) (git.Diffstat, error) {
+	return git.GetDiffstatContext(ctx, fromRef, toRef)
 }
 
-func (realGitOps) GetDiffstat(fromRef, toRef string) (git.Diffstat, error) {
-	return git.GetDiffstat(fromRef, toRef)
+func (realGitOps) CommitFiles(ctx context.Context, sha string) ([]string, error) {
+	return git.CommitFilesContext(ctx, sha)
 }
 
-func (realGitOps) CommitFiles(sha string) ([]string, error) {
-	return git.CommitFiles(sha)
+func (realGitOps) CommitFilesMulti(ctx context.Context, shas []string) (map[string][]string, error) {
+	return git.CommitFilesMultiContext(ctx, shas)
 }
 
-func (realGitOps) CommitFilesMulti(shas []string) (map[string][]string, error) {
-	return git.CommitFilesMulti(shas)
+func (realGitOps) CommitFilesMultiFirstParent(ctx context.Context, shas []string) (map[string][]string, error) {
+	return git.CommitFilesMultiFirstParentContext(ctx, shas)
 }
 
-func (realGitOps) DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
-	return git.DiffNameOnly(fromRef, toRef, pathPrefix)
+func (realGitOps) DiffNameOnly(ctx context.Context, fromRef, toRef, pathPrefix string) ([]string, error) {
+	return git.DiffNameOnlyContext(ctx, fromRef, toRef, pathPrefix)
 }
@@ -0,0 +1,83 @@
+package ledger
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// IDScheme selects how new entry IDs are generated. The anchor and random
+// schemes share the tb_<RFC3339>_<short-sha> prefix; ULID IDs carry their
+// time in the ULID instead. IDTime and EntryDateDir decode every scheme, so
+// a ledger may mix them and switching never strands existing entries.
+type IDScheme string
+
+// Supported ID schemes.
+const (
+	// IDSchemeAnchor is tb_<timestamp>_<short-sha> (the default). Two entries
+	// anchored to the same commit in the same second collide.
+	IDSchemeAnchor IDScheme = "anchor"
+	// IDSchemeRandom appends a random suffix: tb_<timestamp>_<short-sha>-<8 hex>.
+	IDSchemeRandom IDScheme = "random"
+	// IDSchemeULID is tb_<ULID>: millisecond time plus 80 random bits, sorted
+	// by time and unique without reference to the anchor.
+	IDSchemeULID IDScheme = "ulid"
+)
+
+// randomSuffixBytes is the number of random bytes in an IDSchemeRandom
+// suffix (8 hex characters, ~4 billion values per anchor-second).
+const randomSuffixBytes = 4
+
+// ParseIDScheme validates an ID scheme name from config. Empty means
+// IDSchemeAnchor.
+func ParseIDScheme(name string) (IDScheme, error) {
+	switch IDScheme(name) {
+	case "", IDSchemeAnchor:
+		return IDSchemeAnchor, nil
+	case IDSchemeRandom, IDSchemeULID:
+		return IDScheme(name), nil
+	default:
+		return IDSchemeAnchor, fmt.Errorf("unknown id scheme %q (supported: anchor, random, ulid)", name)
+	}
+}
+
+// Generate creates an entry ID for anchor at timestamp under this scheme.
+func (s IDScheme) Generate(anchor string, timestamp time.Time) string {
+	if s == IDSchemeULID {
+		return idPrefix + newULID(timestamp)
+	}
+	id := GenerateID(anchor, timestamp)
+	if s != IDSchemeRandom {
+		return id
+	}
+	return id + "-" + hex.EncodeToString(randomBytes(randomSuffixBytes))
+}
+
+// randomBytes returns n bytes from crypto/rand. rand.Read never returns an
+// error; it crashes the program when the system source fails.
+func randomBytes(n int) []byte {
+	buf := make([]byte, n)
+	_, _ = rand.Read(buf)
+	return buf
+}
+
+// SetIDScheme selects the scheme NewID uses.
+func (fs *FileStorage) SetIDScheme(scheme IDScheme) {
+	fs.idScheme = scheme
+}
+
+// NewID generates an ID for a new entry anchored at anchor, using the
+// configured scheme.
+func (fs *FileStorage) NewID(anchor string, timestamp time.Time) string {
+	return fs.idScheme.Generate(anchor, timestamp)
+}
+
+// NewID generates an ID for a new entry anchored at anchor, using the
+// configured scheme (IDSchemeAnchor when file storage is not configured).
+func (s *Storage) NewID(anchor string, timestamp time.Time) string {
+	if s.files == nil {
+		return GenerateID(anchor, timestamp)
+	}
+	return s.files.NewID(anchor, timestamp)
+}
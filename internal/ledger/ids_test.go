@@ -0,0 +1,143 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseIDScheme(t *testing.T) {
+	for name, want := range map[string]IDScheme{
+		"": IDSchemeAnchor, "anchor": IDSchemeAnchor, "random": IDSchemeRandom, "ulid": IDSchemeULID,
+	} {
+		if got, err := ParseIDScheme(name); err != nil || got != want {
+			t.Errorf("ParseIDScheme(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseIDScheme("uuid"); err == nil {
+		t.Error("ParseIDScheme(\"uuid\") expected error")
+	}
+}
+
+// TestIDSchemeRandom_NoCollisions verifies same-anchor, same-second IDs are
+// distinct under the random scheme while staying compatible with the tb_
+// format: the date directory and filename round-trip still work.
+func TestIDSchemeRandom_NoCollisions(t *testing.T) {
+	stamp := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	anchor := "abc123def456"
+	pattern := regexp.MustCompile(`^tb_2026-01-15T10:00:00Z_abc123-[0-9a-f]{8}$`)
+
+	seen := make(map[string]bool)
+	for range 50 {
+		id := IDSchemeRandom.Generate(anchor, stamp)
+		if !pattern.MatchString(id) {
+			t.Fatalf("Generate() = %q, want match for %s", id, pattern)
+		}
+		if seen[id] {
+			t.Fatalf("Generate() produced duplicate %q", id)
+		}
+		seen[id] = true
+
+		if got := FilenameToID(IDToFilename(id)); got != id {
+			t.Errorf("filename round-trip = %q, want %q", got, id)
+		}
+		if EntryDateDir(id) == "" {
+			t.Errorf("EntryDateDir(%q) is empty", id)
+		}
+	}
+
+	if got := IDSchemeAnchor.Generate(anchor, stamp); got != GenerateID(anchor, stamp) {
+		t.Errorf("anchor scheme = %q, want GenerateID %q", got, GenerateID(anchor, stamp))
+	}
+}
+
+func TestFileStorage_RandomIDSchemeAvoidsConflict(t *testing.T) {
+	store := NewFileStorage(t.TempDir(), noopGitAdd, noopGitCommit)
+	store.SetIDScheme(IDSchemeRandom)
+	ts := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	first := makeTestEntry("abc123def456", ts)
+	second := makeTestEntry("abc123def456", ts)
+	first.ID = store.NewID("abc123def456", ts)
+	second.ID = store.NewID("abc123def456", ts)
+	if err := store.WriteEntries([]*Entry{first, second}); err != nil {
+		t.Fatalf("WriteEntries with random IDs: %v", err)
+	}
+	for _, entry := range []*Entry{first, second} {
+		if _, err := store.ReadEntry(entry.ID); err != nil {
+			t.Errorf("ReadEntry(%s): %v", entry.ID, err)
+		}
+	}
+}
+
+// TestIDSchemeULID verifies ULID IDs are distinct, sort by time, and decode
+// wherever tb_<RFC3339> IDs do: IDTime, the date directory, the filename
+// round-trip, and fsck's well-formedness check.
+func TestIDSchemeULID(t *testing.T) {
+	stamp := time.Date(2026, 1, 15, 23, 59, 59, 123e6, time.UTC)
+	pattern := regexp.MustCompile(`^tb_[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+
+	seen := make(map[string]bool)
+	for range 50 {
+		id := IDSchemeULID.Generate("abc123def456", stamp)
+		if !pattern.MatchString(id) {
+			t.Fatalf("Generate() = %q, want match for %s", id, pattern)
+		}
+		if seen[id] {
+			t.Fatalf("Generate() produced duplicate %q", id)
+		}
+		seen[id] = true
+
+		if got, ok := IDTime(id); !ok || !got.Equal(stamp) {
+			t.Errorf("IDTime(%q) = %v, %v; want %v", id, got, ok, stamp)
+		}
+		if got := EntryDateDir(id); got != filepath.Join("2026", "01", "15") {
+			t.Errorf("EntryDateDir(%q) = %q, want 2026/01/15", id, got)
+		}
+		if got := FilenameToID(IDToFilename(id)); got != id {
+			t.Errorf("filename round-trip = %q, want %q", got, id)
+		}
+		if !wellFormedID(id) || !wellFormedID(strings.ToLower(id)) {
+			t.Errorf("wellFormedID(%q) = false", id)
+		}
+	}
+
+	earlier := IDSchemeULID.Generate("abc123def456", stamp.Add(-time.Millisecond))
+	later := IDSchemeULID.Generate("abc123def456", stamp.Add(time.Millisecond))
+	if earlier >= later {
+		t.Errorf("ULID IDs do not sort by time: %q >= %q", earlier, later)
+	}
+	if _, ok := IDTime("tb_01ARZ3NDEKTSV4RRFFQ69G5FAU"); ok {
+		t.Error("IDTime accepted a ULID containing U")
+	}
+}
+
+func TestFileStorage_ULIDSchemeMixesWithAnchorIDs(t *testing.T) {
+	store := NewFileStorage(t.TempDir(), noopGitAdd, noopGitCommit)
+	older := makeTestEntry("abc123def456", time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC))
+	if err := store.WriteEntry(older, false); err != nil {
+		t.Fatalf("WriteEntry(anchor ID): %v", err)
+	}
+
+	store.SetIDScheme(IDSchemeULID)
+	stamp := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	newer := makeTestEntry("abc123def456", stamp)
+	newer.ID = store.NewID("abc123def456", stamp)
+	if err := store.WriteEntry(newer, false); err != nil {
+		t.Fatalf("WriteEntry(ULID): %v", err)
+	}
+
+	entries, err := store.List(ListOptions{Since: stamp.Add(-time.Hour)})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != newer.ID {
+		t.Errorf("List(Since) = %d entries, want only %s", len(entries), newer.ID)
+	}
+	if _, err := os.Stat(filepath.Join(store.dir, "2026", "01", "15", newer.ID+".json")); err != nil {
+		t.Errorf("ULID entry not in its date directory: %v", err)
+	}
+}
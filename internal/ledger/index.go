@@ -0,0 +1,186 @@
+// Package ledger — local entry index.
+package ledger
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// IndexFile is where the entry index lives, relative to the git directory.
+// Like the embedding index it is a local, rebuildable cache, kept out of
+// .timbers/ so it is never committed.
+const IndexFile = "timbers/index.db"
+
+// indexVersion changes whenever the encoded form does; an index with another
+// version is rebuilt.
+const indexVersion = 1
+
+// entryIndex caches parsed entries by file path, so listing a large ledger
+// costs a stat per file instead of a read and a JSON parse. A record is used
+// only while its file's size and modification time are unchanged, so edits
+// by hand, git checkouts, and merges are picked up without invalidation.
+type entryIndex struct {
+	Version int
+	Strict  bool // entries parsed under strict mode are not valid without it, and vice versa
+	Files   map[string]indexedFile
+
+	path  string
+	seen  map[string]bool
+	dirty bool
+}
+
+// indexedFile is one parsed entry file and the stat it was parsed at.
+type indexedFile struct {
+	Size    int64
+	ModTime int64
+	Entry   *Entry
+}
+
+// SetIndex enables the entry index at path (see IndexFile); "" disables it.
+// Only working-directory storage uses it.
+func (fs *FileStorage) SetIndex(path string) {
+	fs.indexPath = path
+}
+
+// loadIndex reads the index for one pass over the ledger. It returns nil
+// when the index is disabled, and an empty index when the file is missing,
+// unreadable, or was built by another version or strictness.
+func (fs *FileStorage) loadIndex() *entryIndex {
+	if fs.indexPath == "" || fs.tree != nil {
+		return nil
+	}
+	idx := &entryIndex{
+		Version: indexVersion, Strict: fs.strict, Files: make(map[string]indexedFile),
+		path: fs.indexPath, seen: make(map[string]bool),
+	}
+	data, err := os.ReadFile(fs.indexPath)
+	if err != nil {
+		return idx
+	}
+	var loaded entryIndex
+	if gob.NewDecoder(bytes.NewReader(data)).Decode(&loaded) != nil ||
+		loaded.Version != indexVersion || loaded.Strict != fs.strict || loaded.Files == nil {
+		idx.dirty = true
+		return idx
+	}
+	idx.Files = loaded.Files
+	return idx
+}
+
+// readIndexedEntry returns the entry in the ledger file at path, from idx
+// when the file is unchanged since it was indexed. Files that fail to parse
+// are never indexed, so they are reported on every pass. With a nil idx it
+// is ReadEntry.
+func (fs *FileStorage) readIndexedEntry(idx *entryIndex, path, id string) (*Entry, error) {
+	if idx == nil {
+		return fs.ReadEntry(id)
+	}
+	key := filepath.ToSlash(path)
+	idx.seen[key] = true
+	info, err := os.Stat(path)
+	if err != nil {
+		return fs.ReadEntry(id)
+	}
+	if cached, ok := idx.Files[key]; ok && cached.Size == info.Size() && cached.ModTime == info.ModTime().UnixNano() {
+		return cached.Entry, nil
+	}
+
+	entry, err := fs.ReadEntry(id)
+	if err != nil {
+		return nil, err
+	}
+	// ReadEntry prefers the canonical file for an ID; only index the entry
+	// under the path it was actually read from. Encrypted entries are never
+	// cached, so decrypted text does not land on disk.
+	if existing, _ := fs.existingEntryPath(id); existing == path && !entry.IsEncrypted() {
+		idx.record(key, info, entry)
+	}
+	return entry, nil
+}
+
+// record stores entry as the parsed form of the file described by info.
+func (idx *entryIndex) record(key string, info os.FileInfo, entry *Entry) {
+	idx.Files[key] = indexedFile{Size: info.Size(), ModTime: info.ModTime().UnixNano(), Entry: entry}
+	idx.dirty = true
+}
+
+// save drops records for files the pass did not see and writes the index if
+// it changed. Failures are ignored: the next pass reads the files instead.
+func (idx *entryIndex) save() {
+	if idx == nil {
+		return
+	}
+	for key := range idx.Files {
+		if !idx.seen[key] {
+			delete(idx.Files, key)
+			idx.dirty = true
+		}
+	}
+	idx.write()
+}
+
+// write encodes the index to its file atomically when it changed.
+func (idx *entryIndex) write() {
+	if !idx.dirty {
+		return
+	}
+	var buf bytes.Buffer
+	if gob.NewEncoder(&buf).Encode(idx) != nil {
+		return
+	}
+	if os.MkdirAll(filepath.Dir(idx.path), 0o755) != nil {
+		return
+	}
+	_ = atomicWrite(idx.path, buf.Bytes())
+}
+
+// indexWrittenEntry updates an existing index after WriteEntry wrote data to
+// path, so the next listing does not re-read it.
+func (fs *FileStorage) indexWrittenEntry(path string, data []byte) {
+	if fs.indexPath == "" || fs.tree != nil {
+		return
+	}
+	if _, err := os.Stat(fs.indexPath); err != nil {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	parse := FromJSON
+	if fs.strict {
+		parse = FromJSONStrict
+	}
+	entry, err := parse(data)
+	if err != nil || entry.Encrypted != "" {
+		return
+	}
+	idx := fs.loadIndex()
+	idx.record(filepath.ToSlash(path), info, entry)
+	idx.write()
+}
+
+// ResetIndex deletes the entry index, so the next listing rebuilds it from
+// the ledger files. Reports whether the index is enabled.
+func (fs *FileStorage) ResetIndex() (bool, error) {
+	if fs.indexPath == "" || fs.tree != nil {
+		return false, nil
+	}
+	if err := os.Remove(fs.indexPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return true, output.NewSystemErrorWithCause("failed to remove entry index", err)
+	}
+	return true, nil
+}
+
+// ResetIndex deletes the entry index; see FileStorage.ResetIndex.
+func (s *Storage) ResetIndex() (bool, error) {
+	if s.files == nil {
+		return false, nil
+	}
+	return s.files.ResetIndex()
+}
@@ -0,0 +1,101 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newIndexedStorage returns file storage with the entry index enabled, and
+// the index path.
+func newIndexedStorage(t *testing.T) (*FileStorage, string) {
+	t.Helper()
+	store := NewFileStorage(t.TempDir(), noopGitAdd, noopGitCommit)
+	indexPath := filepath.Join(t.TempDir(), "timbers", "index.db")
+	store.SetIndex(indexPath)
+	return store, indexPath
+}
+
+func TestEntryIndexServesUnchangedFiles(t *testing.T) {
+	store, indexPath := newIndexedStorage(t)
+	for _, entry := range batchTestEntries() {
+		if err := store.WriteEntry(entry, false); err != nil {
+			t.Fatalf("WriteEntry: %v", err)
+		}
+	}
+	if _, err := os.Stat(indexPath); !os.IsNotExist(err) {
+		t.Fatalf("index exists before the first listing (stat err %v)", err)
+	}
+
+	entries, err := store.ListEntries()
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("ListEntries() = %d entries, %v; want 3", len(entries), err)
+	}
+	if idx := store.loadIndex(); len(idx.Files) != 3 {
+		t.Fatalf("index holds %d files after listing, want 3", len(idx.Files))
+	}
+
+	extra := makeTestEntry("batch004", time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC))
+	if err := store.WriteEntry(extra, false); err != nil {
+		t.Fatalf("WriteEntry: %v", err)
+	}
+	if idx := store.loadIndex(); len(idx.Files) != 4 {
+		t.Errorf("index holds %d files after WriteEntry, want 4", len(idx.Files))
+	}
+}
+
+func TestEntryIndexRereadsChangedFiles(t *testing.T) {
+	store, _ := newIndexedStorage(t)
+	entry := makeTestEntry("edit001", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	if err := store.WriteEntry(entry, false); err != nil {
+		t.Fatalf("WriteEntry: %v", err)
+	}
+	if _, err := store.ListEntries(); err != nil {
+		t.Fatal(err)
+	}
+
+	// An edit outside timbers (by hand, a checkout, a merge) changes the
+	// file's size and mtime, so the index record is not used.
+	entry.Summary.What = "edited by hand after indexing"
+	data, err := entry.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(store.entryPath(entry.ID), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := store.ListEntries()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("ListEntries() = %d entries, %v; want 1", len(entries), err)
+	}
+	if entries[0].Summary.What != entry.Summary.What {
+		t.Errorf("what = %q, want the edited file's %q", entries[0].Summary.What, entry.Summary.What)
+	}
+
+	if err := os.RemoveAll(filepath.Join(store.Dir(), "2026")); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := store.ListEntries(); len(entries) != 0 {
+		t.Errorf("ListEntries() = %d entries after deleting the file, want 0", len(entries))
+	}
+}
+
+func TestEntryIndexFallsBackFromCorruptIndex(t *testing.T) {
+	store, indexPath := newIndexedStorage(t)
+	for _, entry := range batchTestEntries() {
+		writeTestEntryFile(t, store.Dir(), entry)
+	}
+	if err := os.MkdirAll(filepath.Dir(indexPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeRawFile(t, filepath.Dir(indexPath), filepath.Base(indexPath), []byte("not an index"))
+
+	entries, err := store.ListEntries()
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("ListEntries() = %d entries, %v; want 3 from the files", len(entries), err)
+	}
+	if idx := store.loadIndex(); len(idx.Files) != 3 {
+		t.Errorf("index holds %d files after rebuilding, want 3", len(idx.Files))
+	}
+}
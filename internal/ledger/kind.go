@@ -0,0 +1,81 @@
+package ledger
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Record kinds the ledger stores alongside KindEntry. All share the entry
+// schema and are anchored to commits the same way; they differ in which
+// summary fields are required.
+const (
+	KindDecision  = "decision"  // a choice made: what was decided and why
+	KindIncident  = "incident"  // something that went wrong: what, impact/cause, resolution
+	KindNote      = "note"      // free-form context worth keeping next to the code
+	KindMilestone = "milestone" // a goal reached: what shipped and why it matters
+)
+
+// EntryKinds lists every kind Entry can carry, KindEntry first.
+var EntryKinds = []string{KindEntry, KindDecision, KindIncident, KindMilestone, KindNote}
+
+// IsEntryKind reports whether kind is one of EntryKinds.
+func IsEntryKind(kind string) bool {
+	return slices.Contains(EntryKinds, kind)
+}
+
+// ParseKind validates a kind name from a flag. Empty means KindEntry.
+func ParseKind(name string) (string, error) {
+	if name == "" {
+		return KindEntry, nil
+	}
+	if !IsEntryKind(name) {
+		return "", fmt.Errorf("unknown kind %q (supported: %s)", name, strings.Join(EntryKinds, ", "))
+	}
+	return name, nil
+}
+
+// RequiresWhy reports whether entries of kind must carry summary.why.
+// Notes are the only kind that may omit it.
+func RequiresWhy(kind string) bool {
+	return kind != KindNote
+}
+
+// RequiresHow reports whether entries of kind must carry summary.how.
+// Decisions record the choice and its rationale, and milestones what was
+// reached and why it matters; how either plays out is optional. Notes need
+// only what.
+func RequiresHow(kind string) bool {
+	return kind != KindDecision && kind != KindMilestone && kind != KindNote
+}
+
+// RequiresAlternatives reports whether entries of kind must carry
+// decision.alternatives: a decision names the options it was weighed
+// against.
+func RequiresAlternatives(kind string) bool {
+	return kind == KindDecision
+}
+
+// FilterEntriesByKinds filters entries to those whose kind is in kinds.
+// Entries with no kind count as KindEntry. An empty kinds list keeps all.
+func FilterEntriesByKinds(entries []*Entry, kinds []string) []*Entry {
+	if len(kinds) == 0 {
+		return entries
+	}
+
+	var result []*Entry
+	for _, entry := range entries {
+		if slices.Contains(kinds, entry.KindOrDefault()) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// KindOrDefault returns the entry's kind, treating empty as KindEntry.
+func (e *Entry) KindOrDefault() string {
+	if e.Kind == "" {
+		return KindEntry
+	}
+	return e.Kind
+}
@@ -0,0 +1,114 @@
+package ledger
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseKind(t *testing.T) {
+	for name, want := range map[string]string{
+		"": KindEntry, "entry": KindEntry, "decision": KindDecision, "milestone": KindMilestone, "note": KindNote,
+	} {
+		if got, err := ParseKind(name); err != nil || got != want {
+			t.Errorf("ParseKind(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseKind("ack"); err == nil {
+		t.Error("ParseKind(\"ack\") expected error: acks are not entries")
+	}
+}
+
+// TestEntryKinds_RequiredFields checks Validate and the JSON Schema agree on
+// which summary fields each kind requires, and that decisions require
+// alternatives.
+func TestEntryKinds_RequiredFields(t *testing.T) {
+	alternatives := []string{"SQLite"}
+	tests := []struct {
+		kind         string
+		why          string
+		how          string
+		alternatives []string
+		wantErr      bool
+	}{
+		{kind: KindEntry, why: "w", how: "", wantErr: true},
+		{kind: KindDecision, why: "w", how: "", alternatives: alternatives, wantErr: false},
+		{kind: KindDecision, why: "", how: "h", alternatives: alternatives, wantErr: true},
+		{kind: KindDecision, why: "w", how: "h", wantErr: true},
+		{kind: KindIncident, why: "w", how: "", wantErr: true},
+		{kind: KindMilestone, why: "w", how: "", wantErr: false},
+		{kind: KindMilestone, why: "", how: "h", wantErr: true},
+		{kind: KindNote, why: "", how: "", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		name := tt.kind + "/why=" + tt.why + "/how=" + tt.how + "/alternatives=" + strings.Join(tt.alternatives, ",")
+		t.Run(name, func(t *testing.T) {
+			entry := makeTestEntry("abc123def456", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+			entry.Kind = tt.kind
+			entry.Summary.Why = tt.why
+			entry.Summary.How = tt.how
+			if tt.alternatives != nil {
+				entry.Decision = &Decision{Status: DecisionAccepted, Alternatives: tt.alternatives}
+			}
+
+			if err := entry.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+			data, err := entry.ToJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ValidateSchema(data); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSchema() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_DecisionWithoutAlternatives(t *testing.T) {
+	entry := makeTestEntry("abc123def456", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	entry.Kind = KindDecision
+	entry.Decision = &Decision{Status: DecisionAccepted}
+
+	var validationErr *ValidationError
+	if err := entry.Validate(); !errors.As(err, &validationErr) ||
+		!slices.Equal(validationErr.Fields, []string{"decision.alternatives"}) {
+		t.Fatalf("Validate() = %v, want missing decision.alternatives", err)
+	}
+}
+
+func TestFromJSON_AcceptsEntryKinds(t *testing.T) {
+	for _, kind := range EntryKinds {
+		data := []byte(`{"schema":"timbers.devlog/v1","kind":"` + kind + `","id":"tb_x"}`)
+		if _, err := FromJSON(data); err != nil {
+			t.Errorf("FromJSON(kind=%s) = %v, want nil", kind, err)
+		}
+	}
+	_, err := FromJSON([]byte(`{"schema":"timbers.devlog/v1","kind":"ack","id":"ack_x"}`))
+	if !errors.Is(err, ErrNotTimbersNote) {
+		t.Errorf("FromJSON(kind=ack) = %v, want ErrNotTimbersNote", err)
+	}
+}
+
+func TestFilterEntriesByKinds(t *testing.T) {
+	ts := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	plain := makeTestEntry("aaa111", ts)
+	legacy := makeTestEntry("bbb222", ts)
+	legacy.Kind = ""
+	decision := makeTestEntry("ccc333", ts)
+	decision.Kind = KindDecision
+	all := []*Entry{plain, legacy, decision}
+
+	if got := FilterEntriesByKinds(all, nil); len(got) != 3 {
+		t.Errorf("no kinds kept %d entries, want 3", len(got))
+	}
+	if got := FilterEntriesByKinds(all, []string{KindEntry}); len(got) != 2 {
+		t.Errorf("kind entry kept %d entries, want 2 (empty kind counts as entry)", len(got))
+	}
+	if got := FilterEntriesByKinds(all, []string{KindDecision}); len(got) != 1 || got[0] != decision {
+		t.Errorf("kind decision kept %v, want only the decision", got)
+	}
+}
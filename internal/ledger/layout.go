@@ -0,0 +1,272 @@
+package ledger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/age"
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// Layout is how entry files are arranged under .timbers/.
+type Layout string
+
+// Supported layouts. LayoutDay is the default and matches every ledger
+// written before layouts were configurable.
+const (
+	LayoutFlat  Layout = "flat"  // .timbers/<id>.json
+	LayoutMonth Layout = "month" // .timbers/YYYY/MM/<id>.json
+	LayoutDay   Layout = "day"   // .timbers/YYYY/MM/DD/<id>.json
+	LayoutHash  Layout = "hash"  // .timbers/<2 hex chars of sha256(id)>/<id>.json
+)
+
+// Layouts lists every supported layout.
+var Layouts = []Layout{LayoutFlat, LayoutMonth, LayoutDay, LayoutHash}
+
+// ParseLayout validates a layout name from config. Empty means LayoutDay.
+func ParseLayout(name string) (Layout, error) {
+	if name == "" {
+		return LayoutDay, nil
+	}
+	for _, layout := range Layouts {
+		if string(layout) == name {
+			return layout, nil
+		}
+	}
+	return LayoutDay, fmt.Errorf("unknown storage layout %q (supported: flat, month, day, hash)", name)
+}
+
+// applyStorageConfig applies the [storage] settings for the repository at
+// root to files. A missing, unreadable, or invalid setting falls back to the
+// default (day layout, non-strict, anchor IDs) so a config mistake never makes the ledger
+// unwritable; doctor reports it instead. The local search index, checksums,
+// and write lock, and the entry index when enabled, are placed in the git
+// directory. Encrypted
+// entries are sealed to the [encryption] recipients and opened with the
+// identity in TIMBERS_AGE_IDENTITY.
+func applyStorageConfig(files *FileStorage, root string) {
+	gitDir, gitErr := git.Dir()
+	if gitErr == nil {
+		files.SetSearchIndex(filepath.Join(gitDir, filepath.FromSlash(SearchIndexFile)))
+		files.SetChecksums(filepath.Join(gitDir, filepath.FromSlash(ChecksumFile)))
+		files.SetCommitted(root)
+		files.SetLock(filepath.Join(gitDir, filepath.FromSlash(LockFile)))
+	}
+	cfg, err := config.LoadProject(root)
+	if err != nil {
+		return
+	}
+	if layout, err := ParseLayout(cfg.Storage.Layout); err == nil {
+		files.SetLayout(layout)
+	}
+	if scheme, err := ParseIDScheme(cfg.Storage.IDScheme); err == nil {
+		files.SetIDScheme(scheme)
+	}
+	files.SetStrict(cfg.Storage.Strict)
+	if cfg.Storage.Index && gitErr == nil {
+		files.SetIndex(filepath.Join(gitDir, filepath.FromSlash(IndexFile)))
+	}
+	if len(cfg.Encryption.Recipients) > 0 || os.Getenv(age.IdentityEnv) != "" {
+		files.SetSealer(age.New(cfg.Encryption.Recipients))
+	}
+}
+
+// Dir returns the directory for an entry ID relative to the storage root.
+// Date layouts fall back to the root when the ID carries no date.
+func (l Layout) Dir(id string) string {
+	switch l {
+	case LayoutFlat:
+		return ""
+	case LayoutMonth:
+		if day := EntryDateDir(id); day != "" {
+			return filepath.Dir(day)
+		}
+		return ""
+	case LayoutHash:
+		sum := sha256.Sum256([]byte(id))
+		return hex.EncodeToString(sum[:1])
+	case LayoutDay:
+		return EntryDateDir(id)
+	default:
+		return EntryDateDir(id)
+	}
+}
+
+// SetLayout selects the layout new writes use. Reads find entries in any
+// layout, so changing it never hides existing entries; MigrateLayout moves
+// them.
+func (fs *FileStorage) SetLayout(layout Layout) {
+	fs.layout = layout
+}
+
+// Layout returns the layout new writes use.
+func (fs *FileStorage) Layout() Layout {
+	if fs.layout == "" {
+		return LayoutDay
+	}
+	return fs.layout
+}
+
+// candidateEntryPaths returns every path an entry may live at: the
+// configured layout first, then the other layouts, each in canonical and
+// legacy (colon-encoded) filename form, then the same paths in the archive
+// when archived entries are included.
+func (fs *FileStorage) candidateEntryPaths(id string) []string {
+	names := []string{IDToFilename(id) + ".json"}
+	if legacy := id + ".json"; legacy != names[0] {
+		names = append(names, legacy)
+	}
+	layouts := []Layout{fs.Layout()}
+	for _, layout := range Layouts {
+		if layout != fs.Layout() {
+			layouts = append(layouts, layout)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var paths []string
+	for _, layout := range layouts {
+		for _, name := range names {
+			path := filepath.Join(fs.dir, layout.Dir(id), name)
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	if fs.includeArchived {
+		paths = append(paths, fs.archivePaths(paths)...)
+	}
+	return paths
+}
+
+// layoutMoves returns [from, to] pairs for every entry file, and every
+// tombstone, that is not where the configured layout (and canonical filename
+// form) puts it. Ack files are not entries and stay put, as do archived
+// entries.
+func (fs *FileStorage) layoutMoves() ([][2]string, error) {
+	var moves [][2]string
+	walkErr := filepath.WalkDir(fs.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return fs.skipArchiveDir(path)
+		}
+		if !strings.HasSuffix(d.Name(), ".json") || strings.HasPrefix(d.Name(), ackIDPrefix) {
+			return nil
+		}
+		name := strings.TrimSuffix(d.Name(), ".json")
+		var target string
+		switch {
+		case strings.HasPrefix(name, idPrefix):
+			target = fs.entryPath(FilenameToID(name))
+		case strings.HasPrefix(name, tombstoneIDPrefix):
+			targetID := FilenameToID(tombstoneTargetID(name))
+			target = filepath.Join(fs.dir, fs.Layout().Dir(targetID), d.Name())
+		default:
+			return nil
+		}
+		if target != path {
+			moves = append(moves, [2]string{path, target})
+		}
+		return nil
+	})
+	if walkErr != nil {
+		if errors.Is(walkErr, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, output.NewSystemErrorWithCause("failed to walk storage directory", walkErr)
+	}
+	return moves, nil
+}
+
+// MisplacedEntries returns how many entry and tombstone files are not where
+// the configured layout puts them.
+func (fs *FileStorage) MisplacedEntries() (int, error) {
+	moves, err := fs.layoutMoves()
+	return len(moves), err
+}
+
+// MigrateLayout moves entry files, and the tombstones next to them, into the
+// configured layout, renaming legacy filenames to canonical form along the
+// way, and prunes directories left empty. Returns the IDs that moved; the
+// caller stages and commits the result.
+func (fs *FileStorage) MigrateLayout() ([]string, error) {
+	if err := fs.checkWritable("migrate the ledger layout"); err != nil {
+		return nil, err
+	}
+	moves, err := fs.layoutMoves()
+	if err != nil {
+		return nil, err
+	}
+
+	var migrated []string
+	for _, move := range moves {
+		if err := moveEntryFile(move[0], move[1]); err != nil {
+			return migrated, output.NewSystemErrorWithCause("layout migration failed", err)
+		}
+		pruneEmptyDirs(filepath.Dir(move[0]), fs.dir)
+		name := strings.TrimSuffix(filepath.Base(move[1]), ".json")
+		if strings.HasPrefix(name, tombstoneIDPrefix) {
+			migrated = append(migrated, TombstoneID(FilenameToID(tombstoneTargetID(name))))
+		} else {
+			migrated = append(migrated, FilenameToID(name))
+		}
+	}
+	return migrated, nil
+}
+
+// moveEntryFile renames src to dst, creating dst's directory. When dst
+// already exists the src copy is a duplicate and is removed instead.
+func moveEntryFile(src, dst string) error {
+	if _, err := os.Stat(dst); err == nil {
+		if rmErr := os.Remove(src); rmErr != nil {
+			return fmt.Errorf("remove duplicate %s: %w", src, rmErr)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(dst), err)
+	}
+	if err := os.Rename(src, dst); err != nil {
+		return fmt.Errorf("rename %s: %w", src, err)
+	}
+	return nil
+}
+
+// pruneEmptyDirs removes dir and its parents while they are empty, stopping
+// at root (which is never removed).
+func pruneEmptyDirs(dir, root string) {
+	for dir != root && strings.HasPrefix(dir, root) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// MigrateLayout moves entry files and tombstones into the configured layout.
+// Returns the IDs that moved, or nil if file storage is not configured.
+func (s *Storage) MigrateLayout() ([]string, error) {
+	if s.files == nil {
+		return nil, nil
+	}
+	return s.files.MigrateLayout()
+}
+
+// MisplacedEntries returns how many entry and tombstone files are not where
+// the configured layout puts them, or 0 if file storage is not configured.
+func (s *Storage) MisplacedEntries() (int, error) {
+	if s.files == nil {
+		return 0, nil
+	}
+	return s.files.MisplacedEntries()
+}
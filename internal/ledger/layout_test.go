@@ -0,0 +1,104 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLayoutDir(t *testing.T) {
+	id := "tb_2026-01-15T10:00:00Z_abc123"
+	tests := []struct {
+		layout Layout
+		want   string
+	}{
+		{LayoutFlat, ""},
+		{LayoutMonth, filepath.Join("2026", "01")},
+		{LayoutDay, filepath.Join("2026", "01", "15")},
+	}
+	for _, tt := range tests {
+		if got := tt.layout.Dir(id); got != tt.want {
+			t.Errorf("%s.Dir() = %q, want %q", tt.layout, got, tt.want)
+		}
+	}
+
+	shard := LayoutHash.Dir(id)
+	if len(shard) != 2 || shard != LayoutHash.Dir(id) {
+		t.Errorf("hash.Dir() = %q, want a stable 2-character shard", shard)
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	if got, err := ParseLayout(""); err != nil || got != LayoutDay {
+		t.Errorf("ParseLayout(\"\") = %q, %v; want day", got, err)
+	}
+	if got, err := ParseLayout("hash"); err != nil || got != LayoutHash {
+		t.Errorf("ParseLayout(\"hash\") = %q, %v; want hash", got, err)
+	}
+	if _, err := ParseLayout("weekly"); err == nil {
+		t.Error("ParseLayout(\"weekly\") expected error")
+	}
+}
+
+// TestFileStorage_LayoutChangeAndMigrate writes under the day layout, switches
+// to flat, and verifies the old entry stays readable, new writes go flat, and
+// MigrateLayout moves the old entry and its tombstone and prunes the empty
+// date directories.
+func TestFileStorage_LayoutChangeAndMigrate(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStorage(dir, noopGitAdd, noopGitCommit)
+	old := makeTestEntry("old001", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	if err := store.WriteEntry(old, false); err != nil {
+		t.Fatalf("WriteEntry(day): %v", err)
+	}
+	tombstone := makeTestTombstone(old)
+	if err := store.WriteTombstone(tombstone); err != nil {
+		t.Fatalf("WriteTombstone(day): %v", err)
+	}
+
+	store.SetLayout(LayoutFlat)
+	if _, err := store.ReadEntry(old.ID); err != nil {
+		t.Fatalf("ReadEntry after layout change: %v", err)
+	}
+	if err := store.WriteEntry(old, false); err == nil {
+		t.Error("WriteEntry should detect the existing entry in another layout")
+	}
+
+	fresh := makeTestEntry("new001", time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC))
+	if err := store.WriteEntry(fresh, false); err != nil {
+		t.Fatalf("WriteEntry(flat): %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, IDToFilename(fresh.ID)+".json")); err != nil {
+		t.Errorf("new entry not written flat: %v", err)
+	}
+
+	if err := store.WriteTombstone(tombstone); err == nil {
+		t.Error("WriteTombstone should detect the existing tombstone in another layout")
+	}
+
+	if n, _ := store.MisplacedEntries(); n != 2 {
+		t.Errorf("MisplacedEntries() = %d, want 2", n)
+	}
+	moved, err := store.MigrateLayout()
+	if err != nil {
+		t.Fatalf("MigrateLayout: %v", err)
+	}
+	if !slices.Equal(moved, []string{tombstone.ID, old.ID}) {
+		t.Errorf("MigrateLayout() = %v, want [%s %s]", moved, tombstone.ID, old.ID)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "2026")); !os.IsNotExist(err) {
+		t.Errorf("old date directories should end up empty and pruned, stat err = %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, IDToFilename(tombstone.ID)+".json")); err != nil {
+		t.Errorf("tombstone not moved next to its entry: %v", err)
+	}
+	if n, _ := store.MisplacedEntries(); n != 0 {
+		t.Errorf("MisplacedEntries() after migration = %d, want 0", n)
+	}
+	entries, err := store.ListEntries()
+	if err != nil || len(entries) != 2 {
+		t.Errorf("ListEntries() = %d entries, %v; want 2", len(entries), err)
+	}
+}
@@ -0,0 +1,163 @@
+package ledger
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// AutoPlaceholder is the summary text `timbers log --auto` writes when a
+// commit message has nothing to extract. It is meant to be amended.
+const AutoPlaceholder = "Auto-documented"
+
+// Lint severities, most severe first.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+	LintInfo    = "info"
+)
+
+// LintSeverities lists every severity, most severe first.
+var LintSeverities = []string{LintError, LintWarning, LintInfo}
+
+// Lint rule names, stable for CI configuration and JSON consumers.
+const (
+	RuleWhyRestatesWhat = "why-restates-what"
+	RulePlaceholder     = "placeholder"
+	RuleNoTags          = "no-tags"
+	RuleLargeWorkset    = "large-workset"
+	RuleTodoMarker      = "todo-marker"
+)
+
+// LintFinding is one problem found in an entry.
+type LintFinding struct {
+	EntryID  string `json:"entry_id"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// LintOptions tunes the heuristics. Zero values disable the matching check.
+type LintOptions struct {
+	// PlaceholderAge is how long an AutoPlaceholder may stand before it is
+	// an error; younger placeholders are reported as info.
+	PlaceholderAge time.Duration
+	// MaxCommits and MaxFiles flag worksets too large to explain in one entry.
+	MaxCommits int
+	MaxFiles   int
+	// Now is the reference time for placeholder age (default time.Now).
+	Now time.Time
+}
+
+// DefaultLintOptions returns the thresholds `timbers lint` uses by default.
+func DefaultLintOptions() LintOptions {
+	return LintOptions{PlaceholderAge: 7 * 24 * time.Hour, MaxCommits: 50, MaxFiles: 100}
+}
+
+// todoRegex matches leftover drafting markers as whole words.
+var todoRegex = regexp.MustCompile(`\b(TODO|FIXME|TBD|XXX)\b`)
+
+// LintEntries runs the heuristic checks over entries. They need no LLM, so
+// they are cheap enough to gate CI on.
+func LintEntries(entries []*Entry, opts LintOptions) []LintFinding {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	findings := make([]LintFinding, 0, len(entries))
+	for _, entry := range entries {
+		findings = append(findings, lintEntry(entry, opts)...)
+	}
+	return findings
+}
+
+// lintEntry returns the findings for one entry, in rule order.
+func lintEntry(entry *Entry, opts LintOptions) []LintFinding {
+	var findings []LintFinding
+	add := func(rule, severity, message string) {
+		findings = append(findings, LintFinding{EntryID: entry.ID, Rule: rule, Severity: severity, Message: message})
+	}
+
+	if field := placeholderField(entry.Summary); field != "" {
+		severity := LintInfo
+		if opts.PlaceholderAge > 0 && opts.Now.Sub(entry.CreatedAt) > opts.PlaceholderAge {
+			severity = LintError
+		}
+		add(RulePlaceholder, severity, fmt.Sprintf("%s is still %q; amend it with the real rationale", field, AutoPlaceholder))
+	}
+	if entry.Summary.Why != AutoPlaceholder && restates(entry.Summary.What, entry.Summary.Why) {
+		add(RuleWhyRestatesWhat, LintWarning, "why repeats what; say what problem or constraint led to the change")
+	}
+	if marker := todoRegex.FindString(summaryText(entry)); marker != "" {
+		add(RuleTodoMarker, LintWarning, fmt.Sprintf("contains %s marker", marker))
+	}
+	if message := largeWorkset(entry.Workset, opts); message != "" {
+		add(RuleLargeWorkset, LintWarning, message)
+	}
+	if len(entry.Tags) == 0 {
+		add(RuleNoTags, LintInfo, "has no tags")
+	}
+	return findings
+}
+
+// largeWorkset describes how the workset exceeds the commit or file limit,
+// or returns "" when it is within both.
+func largeWorkset(workset Workset, opts LintOptions) string {
+	if commits := len(workset.Commits); opts.MaxCommits > 0 && commits > opts.MaxCommits {
+		return fmt.Sprintf("covers %d commits (limit %d); consider splitting it", commits, opts.MaxCommits)
+	}
+	if diffstat := workset.Diffstat; opts.MaxFiles > 0 && diffstat != nil && diffstat.Files > opts.MaxFiles {
+		return fmt.Sprintf("touches %d files (limit %d); consider splitting it", diffstat.Files, opts.MaxFiles)
+	}
+	return ""
+}
+
+// placeholderField names the first summary field still holding
+// AutoPlaceholder, or "" if none does.
+func placeholderField(summary Summary) string {
+	switch {
+	case summary.What == AutoPlaceholder:
+		return "what"
+	case summary.Why == AutoPlaceholder:
+		return "why"
+	case summary.How == AutoPlaceholder:
+		return "how"
+	default:
+		return ""
+	}
+}
+
+// restates reports whether why adds nothing to what: every meaningful word
+// of why already appears in what.
+func restates(what, why string) bool {
+	whyTerms := QueryTerms(why)
+	if len(whyTerms) == 0 {
+		return false
+	}
+	whatTerms := make(map[string]bool)
+	for _, term := range QueryTerms(what) {
+		whatTerms[term] = true
+	}
+	for _, term := range whyTerms {
+		if !whatTerms[term] {
+			return false
+		}
+	}
+	return true
+}
+
+// summaryText joins the free-text fields checked for drafting markers.
+func summaryText(entry *Entry) string {
+	return strings.Join([]string{entry.Summary.What, entry.Summary.Why, entry.Summary.How, entry.Notes}, "\n")
+}
+
+// LintSeverityRank orders severities: 0 for error, rising for less severe,
+// and len(LintSeverities) for unknown names.
+func LintSeverityRank(severity string) int {
+	for i, name := range LintSeverities {
+		if name == severity {
+			return i
+		}
+	}
+	return len(LintSeverities)
+}
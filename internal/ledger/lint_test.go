@@ -0,0 +1,93 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLintEntries(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		modify    func(e *Entry)
+		createdAt time.Time
+		wantRule  string
+		wantSev   string
+	}{
+		{
+			name:     "clean entry",
+			modify:   func(_ *Entry) {},
+			wantRule: "",
+		},
+		{
+			name:     "why restates what",
+			modify:   func(e *Entry) { e.Summary.What = "Fix login redirect"; e.Summary.Why = "Fix the login redirect" },
+			wantRule: RuleWhyRestatesWhat,
+			wantSev:  LintWarning,
+		},
+		{
+			name:      "old placeholder",
+			modify:    func(e *Entry) { e.Summary.Why = AutoPlaceholder },
+			createdAt: now.Add(-30 * 24 * time.Hour),
+			wantRule:  RulePlaceholder,
+			wantSev:   LintError,
+		},
+		{
+			name:      "fresh placeholder",
+			modify:    func(e *Entry) { e.Summary.How = AutoPlaceholder },
+			createdAt: now.Add(-time.Hour),
+			wantRule:  RulePlaceholder,
+			wantSev:   LintInfo,
+		},
+		{
+			name:     "no tags",
+			modify:   func(e *Entry) { e.Tags = nil },
+			wantRule: RuleNoTags,
+			wantSev:  LintInfo,
+		},
+		{
+			name:     "large workset",
+			modify:   func(e *Entry) { e.Workset.Diffstat = &Diffstat{Files: 500} },
+			wantRule: RuleLargeWorkset,
+			wantSev:  LintWarning,
+		},
+		{
+			name:     "todo marker",
+			modify:   func(e *Entry) { e.Notes = "TODO: explain the retry budget" },
+			wantRule: RuleTodoMarker,
+			wantSev:  LintWarning,
+		},
+		{
+			name:     "todo inside a word is fine",
+			modify:   func(e *Entry) { e.Notes = "Renamed TODOS table" },
+			wantRule: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createdAt := tt.createdAt
+			if createdAt.IsZero() {
+				createdAt = now.Add(-time.Hour)
+			}
+			entry := makeTestEntry("abc123", createdAt)
+			entry.Summary = Summary{What: "Cache pending counts", Why: "Status took seconds on large repos", How: "Memoize per run"}
+			entry.Tags = []string{"perf"}
+			tt.modify(entry)
+
+			opts := DefaultLintOptions()
+			opts.Now = now
+			findings := LintEntries([]*Entry{entry}, opts)
+
+			if tt.wantRule == "" {
+				if len(findings) != 0 {
+					t.Errorf("LintEntries() = %+v, want no findings", findings)
+				}
+				return
+			}
+			if len(findings) != 1 || findings[0].Rule != tt.wantRule || findings[0].Severity != tt.wantSev {
+				t.Errorf("LintEntries() = %+v, want one %s %s", findings, tt.wantSev, tt.wantRule)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// Package ledger — paged, time-bounded entry listing.
+package ledger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// ListOptions narrows and pages a List call. Entries come newest first:
+// created_at descending, then ID descending for entries created together.
+type ListOptions struct {
+	Since   time.Time // only entries created at or after Since; zero for no bound
+	Until   time.Time // only entries created at or before Until; zero for no bound
+	AfterID string    // only entries that come after this one: the last ID of the previous page
+	Offset  int       // skip this many matching entries
+	Limit   int       // return at most this many; 0 for no limit
+}
+
+// idStampSlack bounds how far an entry's created_at may run ahead of the
+// timestamp in its ID, which timbers writes from created_at truncated to the
+// second.
+const idStampSlack = time.Second
+
+// entryFile is an entry file found by name, before it is read.
+type entryFile struct {
+	path    string
+	id      string
+	stamp   time.Time // the creation time in the ID
+	stamped bool      // false when the ID carries no parseable time
+}
+
+// List returns the entries matching opts, newest first. Rather than parsing
+// the whole ledger it orders files by the timestamp in their IDs and reads
+// only as many as the bounds and page need, so `--last 3` costs a directory
+// walk and a handful of reads. Files whose IDs carry no timestamp are always
+// read. Unreadable files are skipped, as in ListEntries. An AfterID that
+// names no entry is a not-found error.
+func (fs *FileStorage) List(opts ListOptions) ([]*Entry, error) {
+	entries, _, err := fs.ListWithStats(opts)
+	return entries, err
+}
+
+// ListWithStats is List plus statistics about the files it read; files it
+// never had to open are not counted.
+func (fs *FileStorage) ListWithStats(opts ListOptions) ([]*Entry, *ListStats, error) {
+	cursor, err := fs.listCursor(opts.AfterID)
+	if err != nil {
+		return nil, nil, err
+	}
+	files, err := fs.entryFiles()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stats := &ListStats{}
+	idx := fs.loadIndex()
+	want := opts.Offset + opts.Limit
+	var kept []*Entry
+	for _, file := range files {
+		if file.stamped && (opts.pastOldest(file.stamp) || pageFull(kept, want, file.stamp, opts.Limit)) {
+			break
+		}
+		if !opts.mayHold(file, cursor) {
+			continue
+		}
+		entry, readErr := fs.readIndexedEntry(idx, file.path, file.id)
+		stats.recordRead(file.path, readErr)
+		if readErr != nil || !opts.matches(entry, cursor) {
+			continue
+		}
+		kept = keepNewest(append(kept, entry), want, opts.Limit)
+	}
+	idx.save()
+
+	sortNewestFirst(kept)
+	return page(kept, opts.Offset, opts.Limit), stats, nil
+}
+
+// recordRead counts one attempt to read the entry file at path.
+func (stats *ListStats) recordRead(path string, err error) {
+	stats.Total++
+	switch {
+	case err == nil:
+		stats.Parsed++
+	case errors.Is(err, ErrNotTimbersNote):
+		stats.Skipped++
+		stats.NotTimbers++
+	default:
+		stats.Skipped++
+		stats.ParseErrors++
+		stats.CorruptFiles = append(stats.CorruptFiles, filepath.ToSlash(path))
+	}
+}
+
+// listCursor reads the entry a page continues after, or returns nil when
+// afterID is empty.
+func (fs *FileStorage) listCursor(afterID string) (*Entry, error) {
+	if afterID == "" {
+		return nil, nil //nolint:nilnil // no cursor means the first page
+	}
+	return fs.ReadEntry(afterID)
+}
+
+// keepNewest trims kept to the want newest entries once a limit applies.
+func keepNewest(kept []*Entry, want, limit int) []*Entry {
+	if limit <= 0 || len(kept) <= want {
+		return kept
+	}
+	sortNewestFirst(kept)
+	return kept[:want]
+}
+
+// entryFiles lists the entry files in the ledger without reading them:
+// unstamped files first, then newest stamp first.
+func (fs *FileStorage) entryFiles() ([]entryFile, error) {
+	var files []entryFile
+	err := fs.walkFiles(func(path, name string) error {
+		base, ok := strings.CutSuffix(name, ".json")
+		if !ok || strings.HasPrefix(base, ackIDPrefix) || strings.HasPrefix(base, tombstoneIDPrefix) {
+			return nil
+		}
+		id := FilenameToID(base)
+		stamp, stamped := IDTime(id)
+		files = append(files, entryFile{path: path, id: id, stamp: stamp, stamped: stamped})
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, output.NewSystemErrorWithCause("failed to walk storage directory", err)
+	}
+	slices.SortStableFunc(files, func(left, right entryFile) int {
+		if left.stamped != right.stamped {
+			if left.stamped {
+				return 1
+			}
+			return -1
+		}
+		return right.stamp.Compare(left.stamp)
+	})
+	return files, nil
+}
+
+// IDTime returns the creation time embedded in an entry ID
+// (tb_<RFC3339>_<suffix> or tb_<ULID>), and false when the ID carries none.
+func IDTime(id string) (time.Time, bool) {
+	rest, ok := strings.CutPrefix(id, idPrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	if stamp, isULID := ulidTime(rest); isULID {
+		return stamp, true
+	}
+	stamp, _, ok := strings.Cut(rest, "_")
+	if !ok {
+		return time.Time{}, false
+	}
+	parsed, err := time.Parse(time.RFC3339, stamp)
+	return parsed, err == nil
+}
+
+// pastOldest reports whether every entry stamped at or before stamp was
+// created before Since, so no later file in newest-first order can match.
+func (opts ListOptions) pastOldest(stamp time.Time) bool {
+	return !opts.Since.IsZero() && !stamp.Add(idStampSlack).After(opts.Since)
+}
+
+// mayHold reports whether file can hold a match: it is unstamped, or its
+// stamp is not after Until nor before the cursor in listing order.
+func (opts ListOptions) mayHold(file entryFile, cursor *Entry) bool {
+	if !file.stamped {
+		return true
+	}
+	if !opts.Until.IsZero() && file.stamp.After(opts.Until) {
+		return false
+	}
+	return cursor == nil || !file.stamp.After(cursor.CreatedAt)
+}
+
+// matches reports whether entry is within the time bounds and after cursor.
+func (opts ListOptions) matches(entry *Entry, cursor *Entry) bool {
+	if !opts.Since.IsZero() && entry.CreatedAt.Before(opts.Since) {
+		return false
+	}
+	if !opts.Until.IsZero() && entry.CreatedAt.After(opts.Until) {
+		return false
+	}
+	return cursor == nil || newerFirst(cursor, entry) < 0
+}
+
+// pageFull reports whether kept already holds the want newest entries, so
+// files stamped at or before stamp, all strictly older, cannot change the
+// page.
+func pageFull(kept []*Entry, want int, stamp time.Time, limit int) bool {
+	if limit <= 0 || len(kept) < want {
+		return false
+	}
+	sortNewestFirst(kept)
+	return !stamp.Add(idStampSlack).After(kept[want-1].CreatedAt)
+}
+
+// newerFirst orders entries newest first, breaking created_at ties by ID
+// descending.
+func newerFirst(a, b *Entry) int {
+	if cmp := b.CreatedAt.Compare(a.CreatedAt); cmp != 0 {
+		return cmp
+	}
+	return strings.Compare(b.ID, a.ID)
+}
+
+// sortNewestFirst sorts entries in List order.
+func sortNewestFirst(entries []*Entry) {
+	slices.SortFunc(entries, newerFirst)
+}
+
+// page applies offset and limit to sorted entries. Always returns a non-nil
+// slice.
+func page(entries []*Entry, offset, limit int) []*Entry {
+	if offset >= len(entries) {
+		return []*Entry{}
+	}
+	entries = entries[offset:]
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
+
+// CountEntries returns the number of entry files (tb_*.json) in the ledger,
+// without reading them.
+func (fs *FileStorage) CountEntries() (int, error) {
+	files, err := fs.entryFiles()
+	count := 0
+	for _, file := range files {
+		if strings.HasPrefix(file.id, idPrefix) {
+			count++
+		}
+	}
+	return count, err
+}
+
+// List returns the entries matching opts, newest first; see
+// FileStorage.List. Returns an empty slice if file storage is not
+// configured.
+func (s *Storage) List(opts ListOptions) ([]*Entry, error) {
+	if s.files == nil {
+		return []*Entry{}, nil
+	}
+	return s.files.List(opts)
+}
+
+// ListWithStats is List plus statistics about the files it read; see
+// FileStorage.ListWithStats.
+func (s *Storage) ListWithStats(opts ListOptions) ([]*Entry, *ListStats, error) {
+	if s.files == nil {
+		return []*Entry{}, &ListStats{}, nil
+	}
+	return s.files.ListWithStats(opts)
+}
+
+// CountEntries returns the number of entry files in the ledger; see
+// FileStorage.CountEntries. Returns 0 if file storage is not configured.
+func (s *Storage) CountEntries() (int, error) {
+	if s.files == nil {
+		return 0, nil
+	}
+	return s.files.CountEntries()
+}
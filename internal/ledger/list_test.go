@@ -0,0 +1,119 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newListTestStorage writes one entry per day from 2026-01-01, oldest first,
+// and returns the storage with the entry IDs newest first.
+func newListTestStorage(t *testing.T, days int) (*FileStorage, []string) {
+	t.Helper()
+	store := NewFileStorage(t.TempDir(), noopGitAdd, noopGitCommit)
+	ids := make([]string, 0, days)
+	for day := range days {
+		entry := makeTestEntry("list00"+string(rune('a'+day)), time.Date(2026, 1, 1+day, 10, 0, 0, 0, time.UTC))
+		if err := store.WriteEntry(entry, false); err != nil {
+			t.Fatalf("WriteEntry: %v", err)
+		}
+		ids = append([]string{entry.ID}, ids...)
+	}
+	return store, ids
+}
+
+func listIDs(t *testing.T, store *FileStorage, opts ListOptions) []string {
+	t.Helper()
+	entries, err := store.List(opts)
+	if err != nil {
+		t.Fatalf("List(%+v): %v", opts, err)
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
+
+func TestList(t *testing.T) {
+	store, ids := newListTestStorage(t, 5)
+
+	tests := []struct {
+		name string
+		opts ListOptions
+		want []string
+	}{
+		{"everything", ListOptions{}, ids},
+		{"limit", ListOptions{Limit: 2}, ids[:2]},
+		{"offset", ListOptions{Offset: 1, Limit: 2}, ids[1:3]},
+		{"offset past the end", ListOptions{Offset: 9}, []string{}},
+		{"after ID", ListOptions{AfterID: ids[1], Limit: 2}, ids[2:4]},
+		{"since", ListOptions{Since: time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)}, ids[:2]},
+		{"until", ListOptions{Until: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)}, ids[3:]},
+		{"since and until with a limit", ListOptions{
+			Since: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+			Until: time.Date(2026, 1, 4, 23, 0, 0, 0, time.UTC),
+			Limit: 2,
+		}, ids[1:3]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listIDs(t, store, tt.opts); !slices.Equal(got, tt.want) {
+				t.Errorf("List = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := store.List(ListOptions{AfterID: "tb_2026-02-01T10:00:00Z_nosuch"}); err == nil {
+		t.Error("List after an unknown ID expected an error")
+	}
+}
+
+func TestListReadsOnlyWhatThePageNeeds(t *testing.T) {
+	store, ids := newListTestStorage(t, 3)
+	// A corrupt file older than every entry is never opened for a short
+	// page, so it cannot show up in the stats.
+	oldID := "tb_2020-01-01T00:00:00Z_zzzzzz"
+	if err := os.MkdirAll(filepath.Dir(store.entryPath(oldID)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(store.entryPath(oldID), []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, stats, err := store.ListWithStats(ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].ID != ids[0] || stats.Total != 2 || stats.ParseErrors != 0 {
+		t.Errorf("limit 2 = %d entries, stats %+v; want 2 entries from 2 reads", len(entries), stats)
+	}
+
+	if _, stats, err = store.ListWithStats(ListOptions{}); err != nil || stats.ParseErrors != 1 {
+		t.Errorf("unbounded list stats = %+v, %v; want the corrupt file counted", stats, err)
+	}
+	if count, countErr := store.CountEntries(); count != 4 || countErr != nil {
+		t.Errorf("CountEntries = %d, %v; want 4 entry files", count, countErr)
+	}
+}
+
+func TestListReadsUnstampedFiles(t *testing.T) {
+	store, ids := newListTestStorage(t, 2)
+	// An entry filed under a name without a timestamp is still listed in
+	// created_at order.
+	newest := makeTestEntry("list00z", time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	data, err := newest.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(store.dir, "imported.json"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{newest.ID, ids[0]}
+	if got := listIDs(t, store, ListOptions{Limit: 2}); !slices.Equal(got, want) {
+		t.Errorf("List = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+// Package ledger — cross-process write lock.
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// LockFile is the advisory lock every ledger write holds, relative to the
+// git directory. Two timbers processes (an agent and a hook, say) would
+// otherwise race on the same date directory and on the git index. Like
+// ChecksumFile it stays out of the working tree, so a held or stale lock
+// never shows up in git status.
+const LockFile = "timbers/ledger.lock"
+
+// staleLockAge is how old a lock must be before it is assumed to be left by
+// a crashed process and taken over. Ledger writes take well under a second.
+const staleLockAge = 10 * time.Minute
+
+// lockHolder is what a lock file records about the process holding it.
+type lockHolder struct {
+	PID        int       `json:"pid"`
+	Operation  string    `json:"operation"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// SetLock sets the ledger write lock file (see LockFile); "" disables
+// locking.
+func (fs *FileStorage) SetLock(path string) {
+	fs.lockPath = path
+}
+
+// lock takes the ledger write lock for operation and returns the function
+// that releases it. When another process holds the lock, lock fails at once
+// with a conflict error (LEDGER_LOCKED) rather than waiting. A lock older
+// than staleLockAge is taken over.
+func (fs *FileStorage) lock(operation string) (func(), error) {
+	path := fs.lockPath
+	if path == "" {
+		return func() {}, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to create ledger lock directory", err)
+	}
+	holder, err := json.Marshal(lockHolder{PID: os.Getpid(), Operation: operation, AcquiredAt: time.Now().UTC()})
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to encode ledger lock", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := createLockFile(path, holder)
+		if err == nil {
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, output.NewSystemErrorWithCause("failed to take the ledger lock", err)
+		}
+		if attempt > 0 || !removeStaleLock(path) {
+			return nil, lockedError(path)
+		}
+	}
+}
+
+// createLockFile creates the lock file at path holding data, failing with
+// os.ErrExist when it already exists.
+func createLockFile(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644) //nolint:gosec // path is under the git directory
+	if err != nil {
+		return err //nolint:wrapcheck // callers test os.ErrExist
+	}
+	_, writeErr := file.Write(data)
+	if err := errors.Join(writeErr, file.Close()); err != nil {
+		_ = os.Remove(path)
+		return fmt.Errorf("writing lock file: %w", err)
+	}
+	return nil
+}
+
+// removeStaleLock removes the lock at path if it is older than staleLockAge.
+// Reports whether it did.
+func removeStaleLock(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) < staleLockAge {
+		return false
+	}
+	return os.Remove(path) == nil
+}
+
+// lockedError describes who holds the lock at path.
+func lockedError(path string) error {
+	holder := "another timbers process"
+	if data, err := os.ReadFile(path); err == nil { //nolint:gosec // path is under the git directory
+		var held lockHolder
+		if json.Unmarshal(data, &held) == nil && held.PID > 0 {
+			holder += " (pid " + strconv.Itoa(held.PID) + ", " + held.Operation + ", since " +
+				held.AcquiredAt.Local().Format(time.TimeOnly) + ")"
+		}
+	}
+	return output.NewConflictError("the ledger is locked by " + holder + "; retry when it finishes, or delete " +
+		path + " if no timbers process is running").WithID(output.ErrCodeLedgerLocked)
+}
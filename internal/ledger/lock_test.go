@@ -0,0 +1,109 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+func TestWriteEntryRefusesWhileLocked(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStorage(dir, noopGitAdd, noopGitCommit)
+	lockPath := filepath.Join(t.TempDir(), filepath.FromSlash(LockFile))
+	store.SetLock(lockPath)
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	held := `{"pid":4242,"operation":"write entry tb_other","acquired_at":"2026-01-15T10:00:00Z"}`
+	if err := os.WriteFile(lockPath, []byte(held), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := makeTestEntry("lock001", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	err := store.WriteEntry(entry, false)
+	if output.GetExitCode(err) != output.ExitConflict || output.ErrorID(err) != output.ErrCodeLedgerLocked {
+		t.Fatalf("WriteEntry while locked = %v, want a LEDGER_LOCKED conflict", err)
+	}
+	if !strings.Contains(err.Error(), "pid 4242") {
+		t.Errorf("error %q does not name the holder", err)
+	}
+	if store.EntryExists(entry.ID) {
+		t.Error("entry written despite the lock")
+	}
+	if err := store.WriteEntries([]*Entry{entry}); output.ErrorID(err) != output.ErrCodeLedgerLocked {
+		t.Errorf("WriteEntries while locked = %v, want LEDGER_LOCKED", err)
+	}
+
+	if err := os.Remove(lockPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WriteEntry(entry, false); err != nil {
+		t.Fatalf("WriteEntry after unlock: %v", err)
+	}
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind after write: %v", err)
+	}
+}
+
+func TestStaleLockIsTakenOver(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStorage(dir, noopGitAdd, noopGitCommit)
+	lockPath := filepath.Join(t.TempDir(), filepath.FromSlash(LockFile))
+	store.SetLock(lockPath)
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(lockPath, []byte(`{"pid":1}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * staleLockAge)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := makeTestEntry("lock002", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	if err := store.WriteEntry(entry, false); err != nil {
+		t.Fatalf("WriteEntry with a stale lock: %v", err)
+	}
+}
+
+// TestLockStaysOutOfGitStatus runs against a real repository and verifies
+// the lock is kept in the git directory, where git status never lists it.
+func TestLockStaysOutOfGitStatus(t *testing.T) {
+	repo := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test"},
+		{"commit", "--allow-empty", "-m", "init"},
+	} {
+		if _, err := git.RunInDir(repo, nil, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	t.Chdir(repo)
+
+	store := NewFileStorage(filepath.Join(repo, ".timbers"), nil, nil)
+	applyStorageConfig(store, repo)
+	unlock, err := store.lock("test")
+	if err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+	defer unlock()
+
+	if _, err := os.Stat(filepath.Join(repo, ".git", filepath.FromSlash(LockFile))); err != nil {
+		t.Errorf("lock not held in the git directory: %v", err)
+	}
+	status, err := git.RunInDir(repo, nil, "status", "--porcelain", "--untracked-files=all", "--ignored")
+	if err != nil {
+		t.Fatalf("git status: %v", err)
+	}
+	if status != "" {
+		t.Errorf("git status lists files while the ledger is locked:\n%s", status)
+	}
+}
@@ -0,0 +1,187 @@
+// Package ledger — combining entries that document the same work.
+package ledger
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CombineEntries folds entries into one new entry created at createdAt:
+// their commits, files, tags, work items, contributors, meta, and relations
+// are unioned, newest entry first, and their summaries and notes are joined
+// (see JoinSummaries). The anchor, decision, and meta values that conflict come
+// from the newest entry. Relations between the combined entries are
+// dropped. The result has no ID yet: callers assign one from its anchor.
+//
+// Entries must share a kind, and none may be sealed, since their summaries
+// would be lost; the result is encrypted if any of them is.
+func CombineEntries(entries []*Entry, createdAt time.Time) (*Entry, error) {
+	if len(entries) < 2 {
+		return nil, errors.New("at least two entries are needed to merge")
+	}
+	entries = slices.Clone(entries)
+	sortNewestFirst(entries)
+	newest := entries[0]
+	for _, entry := range entries {
+		if entry.IsSealed() {
+			return nil, errors.New(entry.ID + " is encrypted and could not be decrypted")
+		}
+		if entry.KindOrDefault() != newest.KindOrDefault() {
+			return nil, errors.New("cannot merge a " + entry.KindOrDefault() + " with a " + newest.KindOrDefault())
+		}
+	}
+
+	merged := &Entry{
+		Schema:    SchemaVersion,
+		Kind:      newest.Kind,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		Workset:   combineWorksets(entries),
+		Summary:   JoinSummaries(entries),
+		Notes:     joinDistinct(entries, "\n\n", func(e *Entry) string { return e.Notes }),
+		Decision:  newest.Decision,
+	}
+	merged.WorkItems = unionBy(entries, func(e *Entry) []WorkItem { return e.WorkItems })
+	merged.Tags = unionBy(entries, func(e *Entry) []string { return e.Tags })
+	merged.Contributors = combineContributors(entries)
+	merged.Relations = combineRelations(entries)
+	for _, entry := range slices.Backward(entries) {
+		if len(entry.Meta) > 0 && merged.Meta == nil {
+			merged.Meta = make(map[string]string)
+		}
+		maps.Copy(merged.Meta, entry.Meta)
+	}
+	if slices.ContainsFunc(entries, (*Entry).IsEncrypted) {
+		merged.Encrypt()
+	}
+	return merged, nil
+}
+
+// JoinSummaries joins the what, why, and how of entries, newest first.
+// Repeated values appear once; distinct whats are joined with "; " to keep
+// a one-line what, the rest with a blank line.
+func JoinSummaries(entries []*Entry) Summary {
+	return Summary{
+		What: joinDistinct(entries, "; ", func(e *Entry) string { return e.Summary.What }),
+		Why:  joinDistinct(entries, "\n\n", func(e *Entry) string { return e.Summary.Why }),
+		How:  joinDistinct(entries, "\n\n", func(e *Entry) string { return e.Summary.How }),
+	}
+}
+
+// joinDistinct joins the non-empty, distinct values field returns for
+// entries with sep.
+func joinDistinct(entries []*Entry, sep string, field func(*Entry) string) string {
+	var values []string
+	for _, entry := range entries {
+		if value := strings.TrimSpace(field(entry)); value != "" && !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return strings.Join(values, sep)
+}
+
+// unionBy returns the distinct items field returns for entries, in order.
+func unionBy[T comparable](entries []*Entry, field func(*Entry) []T) []T {
+	var union []T
+	for _, entry := range entries {
+		for _, item := range field(entry) {
+			if !slices.Contains(union, item) {
+				union = append(union, item)
+			}
+		}
+	}
+	return union
+}
+
+// combineWorksets unions the worksets of entries, newest first. The
+// diffstat is left out: summing would double-count files the entries share,
+// so callers recompute it from git when they can.
+func combineWorksets(entries []*Entry) Workset {
+	workset := Workset{
+		AnchorCommit: entries[0].Workset.AnchorCommit,
+		Commits:      unionBy(entries, func(e *Entry) []string { return e.Workset.Commits }),
+		Branch:       entries[0].Workset.Branch,
+	}
+	if count := len(workset.Commits); count > 1 {
+		workset.Range = shortCommit(workset.Commits[count-1]) + ".." + shortCommit(workset.Commits[0])
+	}
+	for _, entry := range entries {
+		for _, meta := range entry.Workset.CommitMeta {
+			if !slices.ContainsFunc(workset.CommitMeta, func(seen CommitMeta) bool { return seen.SHA == meta.SHA }) {
+				workset.CommitMeta = append(workset.CommitMeta, meta)
+			}
+		}
+	}
+	workset.Files = unionBy(entries, func(e *Entry) []string { return e.Workset.ChangedFiles() })
+	slices.Sort(workset.Files)
+	return workset
+}
+
+// shortCommit abbreviates a commit SHA the way git log --format=%h does by
+// default.
+func shortCommit(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// combineContributors unions contributors by email, merging the sources of
+// the same person credited on several entries.
+func combineContributors(entries []*Entry) []Contributor {
+	var contributors []Contributor
+	for _, entry := range entries {
+		for _, contributor := range entry.Contributors {
+			i := slices.IndexFunc(contributors, func(seen Contributor) bool {
+				return strings.EqualFold(seen.Email, contributor.Email)
+			})
+			if i < 0 {
+				contributors = append(contributors, Contributor{
+					Name: contributor.Name, Email: contributor.Email, Sources: slices.Clone(contributor.Sources),
+				})
+				continue
+			}
+			for _, source := range contributor.Sources {
+				if !slices.Contains(contributors[i].Sources, source) {
+					contributors[i].Sources = append(contributors[i].Sources, source)
+				}
+			}
+		}
+	}
+	return contributors
+}
+
+// combineRelations unions the relations of entries, leaving out those
+// between the entries being combined.
+func combineRelations(entries []*Entry) []Relation {
+	var relations []Relation
+	for _, rel := range unionBy(entries, func(e *Entry) []Relation { return e.Relations }) {
+		if !slices.ContainsFunc(entries, func(e *Entry) bool { return e.ID == rel.ID }) {
+			relations = append(relations, rel)
+		}
+	}
+	return relations
+}
+
+// RetargetRelations points e's relations at any of fromIDs to toID instead,
+// dropping one that would then repeat. Reports whether anything changed.
+func (e *Entry) RetargetRelations(fromIDs []string, toID string) bool {
+	changed := false
+	relations := make([]Relation, 0, len(e.Relations))
+	for _, rel := range e.Relations {
+		if slices.Contains(fromIDs, rel.ID) {
+			rel.ID = toID
+			changed = true
+		}
+		if !slices.Contains(relations, rel) {
+			relations = append(relations, rel)
+		}
+	}
+	if changed {
+		e.Relations = relations
+	}
+	return changed
+}
@@ -0,0 +1,144 @@
+package ledger
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCombineEntries(t *testing.T) {
+	older := makeTestEntry("aaa1111111", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	older.Workset.Commits = []string{"aaa1111111", "bbb2222222"}
+	older.Tags = []string{"auth"}
+	older.Meta = map[string]string{"ticket": "OLD-1", "team": "core"}
+	older.Contributors = []Contributor{{Name: "Dev", Email: "dev@example.com", Sources: []string{"author"}}}
+	newer := makeTestEntry("ccc3333333", time.Date(2026, 1, 15, 11, 0, 0, 0, time.UTC))
+	newer.Workset.Commits = []string{"ccc3333333", "aaa1111111"}
+	newer.Summary = Summary{What: "Add OAuth login", Why: "test why", How: "Callback handler"}
+	newer.Tags = []string{"auth", "security"}
+	newer.Meta = map[string]string{"ticket": "NEW-2"}
+	newer.Contributors = []Contributor{{Name: "Dev", Email: "DEV@example.com", Sources: []string{"co-author"}}}
+	newer.Relations = []Relation{{Type: RelationRelatesTo, ID: older.ID}, {Type: RelationFixes, ID: "tb_other"}}
+
+	mergedAt := time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC)
+	merged, err := CombineEntries([]*Entry{older, newer}, mergedAt)
+	if err != nil {
+		t.Fatalf("CombineEntries: %v", err)
+	}
+
+	if merged.ID != "" || !merged.CreatedAt.Equal(mergedAt) {
+		t.Errorf("ID = %q, CreatedAt = %v; want no ID, created at %v", merged.ID, merged.CreatedAt, mergedAt)
+	}
+	if merged.Workset.AnchorCommit != "ccc3333333" {
+		t.Errorf("anchor = %q, want the newest entry's", merged.Workset.AnchorCommit)
+	}
+	if want := []string{"ccc3333333", "aaa1111111", "bbb2222222"}; !slices.Equal(merged.Workset.Commits, want) {
+		t.Errorf("commits = %v, want %v", merged.Workset.Commits, want)
+	}
+	if merged.Workset.Range != "bbb2222..ccc3333" {
+		t.Errorf("range = %q", merged.Workset.Range)
+	}
+	want := Summary{What: "Add OAuth login; test what", Why: "test why", How: "Callback handler\n\ntest how"}
+	if merged.Summary != want {
+		t.Errorf("summary = %+v, want %+v", merged.Summary, want)
+	}
+	if !slices.Equal(merged.Tags, []string{"auth", "security"}) {
+		t.Errorf("tags = %v", merged.Tags)
+	}
+	if merged.Meta["ticket"] != "NEW-2" || merged.Meta["team"] != "core" {
+		t.Errorf("meta = %v, want newest value to win and others kept", merged.Meta)
+	}
+	if len(merged.Contributors) != 1 || !slices.Equal(merged.Contributors[0].Sources, []string{"co-author", "author"}) {
+		t.Errorf("contributors = %+v, want one with both sources", merged.Contributors)
+	}
+	if len(merged.Relations) != 1 || merged.Relations[0].ID != "tb_other" {
+		t.Errorf("relations = %+v, want only the one outside the merge", merged.Relations)
+	}
+}
+
+func TestCombineEntriesRejects(t *testing.T) {
+	first := makeTestEntry("aaa1111111", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	second := makeTestEntry("bbb2222222", time.Date(2026, 1, 15, 11, 0, 0, 0, time.UTC))
+
+	if _, err := CombineEntries([]*Entry{first}, time.Now()); err == nil {
+		t.Error("a single entry expected an error")
+	}
+	second.Kind = KindDecision
+	if _, err := CombineEntries([]*Entry{first, second}, time.Now()); err == nil {
+		t.Error("mixed kinds expected an error")
+	}
+}
+
+func TestRetargetRelations(t *testing.T) {
+	entry := makeTestEntry("aaa1111111", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	entry.Relations = []Relation{
+		{Type: RelationSupersedes, ID: "tb_one"},
+		{Type: RelationSupersedes, ID: "tb_two"},
+		{Type: RelationFixes, ID: "tb_three"},
+	}
+
+	if !entry.RetargetRelations([]string{"tb_one", "tb_two"}, "tb_merged") {
+		t.Fatal("RetargetRelations reported no change")
+	}
+	want := []Relation{{Type: RelationSupersedes, ID: "tb_merged"}, {Type: RelationFixes, ID: "tb_three"}}
+	if !slices.Equal(entry.Relations, want) {
+		t.Errorf("relations = %+v, want %+v", entry.Relations, want)
+	}
+	if entry.RetargetRelations([]string{"tb_one"}, "tb_merged") {
+		t.Error("retargeting again reported a change")
+	}
+}
+
+func TestWriteMerge(t *testing.T) {
+	store := NewFileStorage(t.TempDir(), noopGitAdd, noopGitCommit)
+	first := makeTestEntry("aaa1111111", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	second := makeTestEntry("bbb2222222", time.Date(2026, 1, 15, 11, 0, 0, 0, time.UTC))
+	other := makeTestEntry("ccc3333333", time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC))
+	other.Relations = []Relation{{Type: RelationFixes, ID: first.ID}}
+	for _, entry := range []*Entry{first, second, other} {
+		if err := store.WriteEntry(entry, false); err != nil {
+			t.Fatalf("WriteEntry: %v", err)
+		}
+	}
+
+	mergedAt := time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC)
+	merged, err := CombineEntries([]*Entry{first, second}, mergedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged.ID = GenerateID(merged.Workset.AnchorCommit, mergedAt)
+	relinked := *other
+	relinked.Relations = slices.Clone(other.Relations)
+	relinked.RetargetRelations([]string{first.ID, second.ID}, merged.ID)
+	tombstones := []*Tombstone{makeTestTombstone(first), makeTestTombstone(second)}
+
+	if err = store.WriteMerge(merged, []*Entry{&relinked}, tombstones); err != nil {
+		t.Fatalf("WriteMerge: %v", err)
+	}
+
+	storage := NewStorage(nil, store)
+	entries, err := store.ListEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	remaining := FilterDeleted(entries, storage.DeletedSet())
+	ids := make([]string, len(remaining))
+	for i, entry := range remaining {
+		ids[i] = entry.ID
+	}
+	slices.Sort(ids)
+	if want := []string{other.ID, merged.ID}; !slices.Equal(ids, want) {
+		t.Errorf("entries after merge = %v, want %v", ids, want)
+	}
+	stored, err := store.ReadEntry(other.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Relations) != 1 || stored.Relations[0].ID != merged.ID {
+		t.Errorf("relations = %+v, want retargeted at %s", stored.Relations, merged.ID)
+	}
+
+	if err := store.WriteMerge(merged, nil, tombstones); err == nil {
+		t.Error("writing the same merge twice expected a conflict")
+	}
+}
@@ -0,0 +1,121 @@
+// Package ledger — custom metadata fields.
+package ledger
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// metaKeyPattern is what a meta key may look like: lowercase, starting with
+// a letter, so keys stay stable identifiers across teams and tools. Keep in
+// sync with propertyNames in entry.schema.json.
+var metaKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_.-]{0,63}$`)
+
+// ValidateMetaKey reports whether key is usable as a meta key.
+func ValidateMetaKey(key string) error {
+	if !metaKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid meta key %q (lowercase letters, digits, '.', '_', '-'; must start with a letter)", key)
+	}
+	return nil
+}
+
+// ParseMeta parses key=value pairs from --meta flags. Every pair needs a
+// value unless allowEmpty, in which case key= yields an empty value (amend
+// uses it to remove the key). A key given twice is an error. No pairs
+// yields a nil map.
+func ParseMeta(pairs []string, allowEmpty bool) (map[string]string, error) {
+	var meta map[string]string
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok {
+			return nil, fmt.Errorf("meta must be in format key=value, got %q", pair)
+		}
+		if err := ValidateMetaKey(key); err != nil {
+			return nil, err
+		}
+		if value == "" && !allowEmpty {
+			return nil, fmt.Errorf("meta %q has an empty value", key)
+		}
+		if meta == nil {
+			meta = make(map[string]string, len(pairs))
+		}
+		if _, dup := meta[key]; dup {
+			return nil, fmt.Errorf("meta %q given more than once", key)
+		}
+		meta[key] = value
+	}
+	return meta, nil
+}
+
+// ParseMetaFilter parses --meta filters: key=value matches entries whose
+// key has that value, and a bare key matches entries that set it at all.
+func ParseMetaFilter(filters []string) (map[string]string, error) {
+	bare := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		if !strings.Contains(filter, "=") {
+			filter += "="
+		}
+		bare = append(bare, filter)
+	}
+	return ParseMeta(bare, true)
+}
+
+// MergeMeta returns meta with updates applied: an empty value removes the
+// key. Returns nil when nothing is left, so the field is omitted.
+func MergeMeta(meta, updates map[string]string) map[string]string {
+	merged := maps.Clone(meta)
+	if merged == nil {
+		merged = make(map[string]string, len(updates))
+	}
+	for key, value := range updates {
+		if value == "" {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+// EntryMatchesMeta reports whether entry satisfies every filter (see
+// ParseMetaFilter).
+func EntryMatchesMeta(entry *Entry, filters map[string]string) bool {
+	for key, want := range filters {
+		got, ok := entry.Meta[key]
+		if !ok || (want != "" && got != want) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterEntriesByMeta keeps entries matching every filter. An empty filter
+// set keeps all.
+func FilterEntriesByMeta(entries []*Entry, filters map[string]string) []*Entry {
+	if len(filters) == 0 {
+		return entries
+	}
+	var result []*Entry
+	for _, entry := range entries {
+		if EntryMatchesMeta(entry, filters) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// FormatMeta renders meta as "key=value, key=value", sorted by key.
+func FormatMeta(meta map[string]string) string {
+	parts := make([]string, 0, len(meta))
+	for _, key := range slices.Sorted(maps.Keys(meta)) {
+		parts = append(parts, key+"="+meta[key])
+	}
+	return strings.Join(parts, ", ")
+}
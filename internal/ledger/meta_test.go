@@ -0,0 +1,99 @@
+package ledger
+
+import (
+	"maps"
+	"testing"
+	"time"
+)
+
+func TestParseMeta(t *testing.T) {
+	meta, err := ParseMeta([]string{"service=api", " risk = high "}, false)
+	if err != nil {
+		t.Fatalf("ParseMeta: %v", err)
+	}
+	if want := map[string]string{"service": "api", "risk": "high"}; !maps.Equal(meta, want) {
+		t.Errorf("ParseMeta = %v, want %v", meta, want)
+	}
+
+	for _, bad := range [][]string{
+		{"service"},                  // no value
+		{"service="},                 // empty value
+		{"Service=api"},              // uppercase key
+		{"1st=api"},                  // starts with a digit
+		{"service=api", "service=x"}, // duplicate
+	} {
+		if _, err := ParseMeta(bad, false); err == nil {
+			t.Errorf("ParseMeta(%q) expected an error", bad)
+		}
+	}
+	if meta, err := ParseMeta([]string{"reviewer="}, true); err != nil || meta["reviewer"] != "" {
+		t.Errorf("ParseMeta(reviewer=, allowEmpty) = %v, %v; want an empty value", meta, err)
+	}
+}
+
+func TestMergeMeta(t *testing.T) {
+	merged := MergeMeta(map[string]string{"service": "api", "risk": "low"}, map[string]string{"risk": "high", "service": ""})
+	if want := map[string]string{"risk": "high"}; !maps.Equal(merged, want) {
+		t.Errorf("MergeMeta = %v, want %v", merged, want)
+	}
+	if merged := MergeMeta(map[string]string{"risk": "low"}, map[string]string{"risk": ""}); merged != nil {
+		t.Errorf("MergeMeta removing the last key = %v, want nil", merged)
+	}
+}
+
+func TestFilterEntriesByMeta(t *testing.T) {
+	base := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	api := makeTestEntry("meta001", base)
+	api.Meta = map[string]string{"service": "api", "risk": "high"}
+	web := makeTestEntry("meta002", base.Add(time.Hour))
+	web.Meta = map[string]string{"service": "web"}
+	plain := makeTestEntry("meta003", base.Add(2*time.Hour))
+	entries := []*Entry{api, web, plain}
+
+	tests := []struct {
+		filters []string
+		want    []*Entry
+	}{
+		{nil, entries},
+		{[]string{"service=api"}, []*Entry{api}},
+		{[]string{"service"}, []*Entry{api, web}},
+		{[]string{"service", "risk=high"}, []*Entry{api}},
+		{[]string{"risk=low"}, nil},
+	}
+	for _, tt := range tests {
+		filters, err := ParseMetaFilter(tt.filters)
+		if err != nil {
+			t.Fatalf("ParseMetaFilter(%q): %v", tt.filters, err)
+		}
+		got := FilterEntriesByMeta(entries, filters)
+		if len(got) != len(tt.want) {
+			t.Errorf("filter %q kept %d entries, want %d", tt.filters, len(got), len(tt.want))
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("filter %q [%d] = %s, want %s", tt.filters, i, got[i].ID, tt.want[i].ID)
+			}
+		}
+	}
+}
+
+func TestMetaSchema(t *testing.T) {
+	entry := makeTestEntry("meta004", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	entry.Meta = map[string]string{"service": "api"}
+	data, err := entry.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ValidateSchema(data); err != nil {
+		t.Errorf("ValidateSchema(valid meta) = %v", err)
+	}
+
+	entry.Meta = map[string]string{"Service": "api"}
+	if data, err = entry.ToJSON(); err != nil {
+		t.Fatal(err)
+	}
+	if err = ValidateSchema(data); err == nil {
+		t.Error("ValidateSchema expected an error for an uppercase meta key")
+	}
+}
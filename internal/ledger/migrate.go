@@ -26,6 +26,9 @@ func (s *Storage) MigrateLegacyFilenames() ([]string, error) {
 // already canonical, and tolerates a canonical sibling already existing
 // (the legacy file is removed in that case).
 func (fs *FileStorage) MigrateLegacyFilenames() ([]string, error) {
+	if err := fs.checkWritable("migrate legacy filenames"); err != nil {
+		return nil, err
+	}
 	var migrated []string
 	walkErr := filepath.WalkDir(fs.dir, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
@@ -38,15 +41,8 @@ func (fs *FileStorage) MigrateLegacyFilenames() ([]string, error) {
 		if !strings.Contains(base, ":") {
 			return nil
 		}
-		canonicalName := IDToFilename(base) + ".json"
-		canonicalPath := filepath.Join(filepath.Dir(path), canonicalName)
-		if _, statErr := os.Stat(canonicalPath); statErr == nil {
-			// Canonical exists already — drop the legacy duplicate.
-			if rmErr := os.Remove(path); rmErr != nil {
-				return fmt.Errorf("remove duplicate legacy %s: %w", path, rmErr)
-			}
-		} else if rnErr := os.Rename(path, canonicalPath); rnErr != nil {
-			return fmt.Errorf("rename %s: %w", path, rnErr)
+		if err := migrateLegacyFile(path, base); err != nil {
+			return err
 		}
 		migrated = append(migrated, FilenameToID(base))
 		return nil
@@ -56,3 +52,20 @@ func (fs *FileStorage) MigrateLegacyFilenames() ([]string, error) {
 	}
 	return migrated, nil
 }
+
+// migrateLegacyFile renames the colon-encoded entry file at path to its
+// canonical name, or removes it when the canonical file already exists.
+func migrateLegacyFile(path, base string) error {
+	canonicalPath := filepath.Join(filepath.Dir(path), IDToFilename(base)+".json")
+	if _, statErr := os.Stat(canonicalPath); statErr == nil {
+		// Canonical exists already — drop the legacy duplicate.
+		if rmErr := os.Remove(path); rmErr != nil {
+			return fmt.Errorf("remove duplicate legacy %s: %w", path, rmErr)
+		}
+		return nil
+	}
+	if rnErr := os.Rename(path, canonicalPath); rnErr != nil {
+		return fmt.Errorf("rename %s: %w", path, rnErr)
+	}
+	return nil
+}
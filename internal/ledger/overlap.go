@@ -0,0 +1,53 @@
+// Package ledger — entries that document the same commits.
+package ledger
+
+import (
+	"slices"
+	"strings"
+)
+
+// Overlap is a set of entries that all list the same commits in their
+// worksets: a commit logged twice, typically after a cherry-pick or rebase
+// left its entry's range pending again.
+type Overlap struct {
+	IDs     []string `json:"ids"`     // the overlapping entries, sorted
+	Commits []string `json:"commits"` // the commits they share, sorted
+}
+
+// FindOverlaps reports entries whose worksets share commits. Entries sharing
+// the same commits are grouped into one Overlap; an entry can appear in
+// several when it shares different commits with different entries. Sorted
+// by IDs.
+func FindOverlaps(entries []*Entry) []Overlap {
+	byCommit := make(map[string][]string)
+	for _, entry := range entries {
+		for _, sha := range entry.Workset.Commits {
+			if ids := byCommit[sha]; !slices.Contains(ids, entry.ID) {
+				byCommit[sha] = append(ids, entry.ID)
+			}
+		}
+	}
+
+	groups := make(map[string]*Overlap)
+	for sha, ids := range byCommit {
+		if len(ids) < 2 {
+			continue
+		}
+		slices.Sort(ids)
+		key := strings.Join(ids, " ")
+		if groups[key] == nil {
+			groups[key] = &Overlap{IDs: ids}
+		}
+		groups[key].Commits = append(groups[key].Commits, sha)
+	}
+
+	overlaps := make([]Overlap, 0, len(groups))
+	for _, group := range groups {
+		slices.Sort(group.Commits)
+		overlaps = append(overlaps, *group)
+	}
+	slices.SortFunc(overlaps, func(left, right Overlap) int {
+		return slices.Compare(left.IDs, right.IDs)
+	})
+	return overlaps
+}
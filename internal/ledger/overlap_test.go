@@ -0,0 +1,30 @@
+package ledger
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFindOverlaps(t *testing.T) {
+	created := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	first := makeTestEntry("aaa1111", created)
+	first.Workset.Commits = []string{"aaa1111", "bbb2222", "ccc3333"}
+	second := makeTestEntry("bbb2222", created.Add(time.Hour))
+	second.Workset.Commits = []string{"bbb2222", "ccc3333"}
+	third := makeTestEntry("ddd4444", created.Add(2*time.Hour))
+	third.Workset.Commits = []string{"ddd4444", "aaa1111"}
+	clean := makeTestEntry("eee5555", created.Add(3*time.Hour))
+
+	got := FindOverlaps([]*Entry{third, second, first, clean})
+	want := []Overlap{
+		{IDs: []string{first.ID, second.ID}, Commits: []string{"bbb2222", "ccc3333"}},
+		{IDs: []string{first.ID, third.ID}, Commits: []string{"aaa1111"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindOverlaps = %+v, want %+v", got, want)
+	}
+	if got := FindOverlaps([]*Entry{first, clean}); len(got) != 0 {
+		t.Errorf("FindOverlaps without shared commits = %+v, want none", got)
+	}
+}
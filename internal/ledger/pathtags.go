@@ -0,0 +1,100 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// PathTagRule tags entries whose workset touches a file matching Pattern.
+type PathTagRule struct {
+	Pattern string
+	Tag     string
+}
+
+// ParsePathTagRules turns the [tags] paths config, glob → tag, into rules
+// sorted by pattern. Patterns and tags are validated so a typo fails the
+// log instead of silently tagging nothing.
+func ParsePathTagRules(paths map[string]string) ([]PathTagRule, error) {
+	rules := make([]PathTagRule, 0, len(paths))
+	for pattern, tag := range paths {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return nil, fmt.Errorf("path tag rule %q has an empty tag", pattern)
+		}
+		if err := validatePathGlob(pattern); err != nil {
+			return nil, err
+		}
+		rules = append(rules, PathTagRule{Pattern: pattern, Tag: tag})
+	}
+	slices.SortFunc(rules, func(a, b PathTagRule) int { return strings.Compare(a.Pattern, b.Pattern) })
+	return rules, nil
+}
+
+// InferPathTags returns the tags of the rules matching any of files, in
+// rule order and without duplicates.
+func InferPathTags(rules []PathTagRule, files []string) []string {
+	var tags []string
+	for _, rule := range rules {
+		if slices.Contains(tags, rule.Tag) {
+			continue
+		}
+		if slices.ContainsFunc(files, func(file string) bool { return MatchPathGlob(rule.Pattern, file) }) {
+			tags = append(tags, rule.Tag)
+		}
+	}
+	return tags
+}
+
+// MatchPathGlob reports whether the slash-separated file matches pattern.
+// "*", "?", and "[...]" match within one path segment, "**" matches any
+// number of segments, and a trailing "/" matches everything under a
+// directory. A pattern without a "/" matches the file's base name at any
+// depth, as in .gitignore.
+func MatchPathGlob(pattern, file string) bool {
+	if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+		pattern = dir + "/**"
+	}
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(file))
+		return matched
+	}
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(file, "/"))
+}
+
+// matchGlobSegments matches pattern segments against file segments.
+func matchGlobSegments(pattern, file []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for skip := 0; skip <= len(file); skip++ {
+				if matchGlobSegments(pattern[1:], file[skip:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(file) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], file[0]); !matched {
+			return false
+		}
+		pattern, file = pattern[1:], file[1:]
+	}
+	return len(file) == 0
+}
+
+// validatePathGlob reports a malformed pattern.
+func validatePathGlob(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return errors.New("path tag rule has an empty pattern")
+	}
+	for segment := range strings.SplitSeq(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
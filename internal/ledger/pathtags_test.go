@@ -0,0 +1,58 @@
+package ledger
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMatchPathGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		file    string
+		want    bool
+	}{
+		{"internal/llm/**", "internal/llm/anthropic.go", true},
+		{"internal/llm/**", "internal/llm/sub/dir/x.go", true},
+		{"internal/llm/**", "internal/ledger/x.go", false},
+		{"docs/", "docs/guide/intro.md", true},
+		{"**/*_test.go", "internal/git/git_test.go", true},
+		{"**/*_test.go", "main_test.go", true},
+		{"cmd/*/main.go", "cmd/timbers/main.go", true},
+		{"cmd/*/main.go", "cmd/timbers/sub/main.go", false},
+		{"*.md", "docs/guide/intro.md", true},
+		{"*.md", "docs/intro.txt", false},
+		{"go.mod", "go.mod", true},
+	}
+	for _, tt := range tests {
+		if got := MatchPathGlob(tt.pattern, tt.file); got != tt.want {
+			t.Errorf("MatchPathGlob(%q, %q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestInferPathTags(t *testing.T) {
+	rules, err := ParsePathTagRules(map[string]string{
+		"internal/llm/**": "llm",
+		"docs/**":         "docs",
+		"*.md":            "docs",
+		"cmd/**":          "cli",
+	})
+	if err != nil {
+		t.Fatalf("ParsePathTagRules: %v", err)
+	}
+	got := InferPathTags(rules, []string{"docs/a.md", "README.md", "internal/llm/openai.go"})
+	if want := []string{"docs", "llm"}; !slices.Equal(got, want) {
+		t.Errorf("InferPathTags = %v, want %v", got, want)
+	}
+}
+
+func TestParsePathTagRulesRejectsBadRules(t *testing.T) {
+	for _, paths := range []map[string]string{
+		{"docs/**": " "},
+		{"docs/[": "docs"},
+	} {
+		if _, err := ParsePathTagRules(paths); err == nil {
+			t.Errorf("ParsePathTagRules(%v) should fail", paths)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package ledger
 import (
 	"fmt"
 
+	"github.com/gorewood/timbers/internal/config"
 	"github.com/gorewood/timbers/internal/git"
 )
 
@@ -20,11 +21,11 @@ type ClassifiedCommit struct {
 // callers can see *why* each is or isn't pending — e.g. verifying that a new
 // .timbersignore author:/msg: rule actually exempts a commit.
 func (s *Storage) ExplainPending() ([]ClassifiedCommit, *Entry, error) {
-	commits, latest, docSet, ackedSet, err := s.pendingRange(false)
+	commits, latest, docSet, ackedSet, err := s.pendingRange(s.firstParent)
 	if commits == nil {
 		return nil, latest, err
 	}
-	fileMap, ferr := s.git.CommitFilesMulti(commitSHAs(commits))
+	fileMap, ferr := s.commitFilesMulti(commits)
 	if ferr != nil {
 		fileMap = map[string][]string{} // degrade: classify without file data
 	}
@@ -42,14 +43,16 @@ func (s *Storage) ExplainPending() ([]ClassifiedCommit, *Entry, error) {
 // pending detection, plus the documented/acked sets used to classify or
 // filter it. Shared by getPendingCommits (which filters) and ExplainPending
 // (which classifies). Returns the wrapped ErrStaleAnchor when the anchor was
-// GC'd (commits is then the all-reachable fallback). On a hard git failure,
-// commits is nil and err is the underlying error.
+// GC'd (commits is then the all-reachable fallback). In a shallow clone a
+// missing anchor is more likely beyond the fetch depth than rewritten, so
+// the range degrades to the fetched history without ErrStaleAnchor. On a
+// hard git failure, commits is nil and err is the underlying error.
 //
 // One disk scan per call: ListEntries feeds both `latest` and the documented-
 // SHA set; AckedSet is a parallel scan. Both are built once and returned so a
 // single pending check sees one consistent snapshot.
 func (s *Storage) pendingRange(firstParent bool) (commits []git.Commit, latest *Entry, docSet, ackedSet map[string]bool, err error) {
-	head, headErr := s.git.HEAD()
+	head, headErr := s.git.HEAD(s.context())
 	if headErr != nil {
 		return nil, nil, nil, nil, headErr
 	}
@@ -68,19 +71,19 @@ func (s *Storage) pendingRange(firstParent bool) (commits []git.Commit, latest *
 	}
 
 	anchor := latest.Workset.AnchorCommit
-	staleErr := fmt.Errorf("%w: %s", ErrStaleAnchor, anchor)
 
-	// Short-circuit 1 — stale anchor (squash/rebase GC'd the SHA): fall back
-	// to all-reachable and wrap ErrStaleAnchor so display callers surface it.
-	if !s.git.IsAncestorOf(anchor, head) {
-		return s.reachableFallback(head, latest, docSet, ackedSet, staleErr)
+	// Short-circuit 1 — stale anchor (squash/rebase GC'd the SHA, or a
+	// shallow clone never fetched it): fall back to all-reachable and wrap
+	// ErrStaleAnchor so display callers surface it.
+	if !s.git.IsAncestorOf(s.context(), anchor, head) {
+		return s.reachableFallback(head, latest, docSet, ackedSet, s.staleAnchorErr(anchor))
 	}
 
 	// Short-circuit 2 — off-first-parent anchor in gate path: anchor is
 	// reachable but not on HEAD's first-parent line (latest entry authored on
 	// a merged-in side branch). LogFirstParent(anchor, head) would walk a
 	// structurally weird range; fall back to all-reachable (no ErrStaleAnchor).
-	if firstParent && !s.git.IsOnFirstParentLine(anchor, head) {
+	if firstParent && !s.git.IsOnFirstParentLine(s.context(), anchor, head) {
 		return s.reachableFallback(head, latest, docSet, ackedSet, nil)
 	}
 
@@ -90,9 +93,9 @@ func (s *Storage) pendingRange(firstParent bool) (commits []git.Commit, latest *
 	if firstParent {
 		logFn = s.git.LogFirstParent
 	}
-	rangeCommits, logErr := logFn(anchor, head)
+	rangeCommits, logErr := logFn(s.context(), anchor, head)
 	if logErr != nil {
-		return s.reachableFallback(head, latest, docSet, ackedSet, staleErr)
+		return s.reachableFallback(head, latest, docSet, ackedSet, s.staleAnchorErr(anchor))
 	}
 	return rangeCommits, latest, docSet, ackedSet, nil
 }
@@ -104,9 +107,70 @@ func (s *Storage) pendingRange(firstParent bool) (commits []git.Commit, latest *
 func (s *Storage) reachableFallback(
 	head string, latest *Entry, docSet, ackedSet map[string]bool, wrapErr error,
 ) (commits []git.Commit, _ *Entry, _, _ map[string]bool, err error) {
-	fallback, reachErr := s.git.CommitsReachableFrom(head)
+	reachable := s.git.CommitsReachableFrom
+	if s.firstParent {
+		reachable = s.git.CommitsReachableFromFirstParent
+	}
+	fallback, reachErr := reachable(s.context(), head)
 	if reachErr != nil {
 		return nil, latest, docSet, ackedSet, reachErr
 	}
 	return fallback, latest, docSet, ackedSet, wrapErr
 }
+
+// staleAnchorErr wraps ErrStaleAnchor for an anchor missing from history,
+// or returns nil in a shallow clone, where the anchor most likely lies
+// beyond the fetch depth and the fetched history is the best answer.
+func (s *Storage) staleAnchorErr(anchor string) error {
+	if s.git.IsShallow(s.context()) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrStaleAnchor, anchor)
+}
+
+// SetFirstParent switches pending detection and LogRange to first-parent
+// traversal, so a merge-heavy repository is documented at the merge level:
+// commits brought in by a merge are not pending on their own, and the merge
+// counts as the work it brought onto the first-parent line. NewDefaultStorage
+// sets it from the [pending] first_parent config.
+func (s *Storage) SetFirstParent(enabled bool) {
+	s.firstParent = enabled
+}
+
+// FirstParent reports whether first-parent traversal is on.
+func (s *Storage) FirstParent() bool {
+	return s.firstParent
+}
+
+// LogRange returns commits in the given range (fromRef..toRef).
+// The 'fromRef' ref is exclusive, 'toRef' is inclusive. In first-parent
+// mode, commits brought in by merges are left out.
+func (s *Storage) LogRange(fromRef, toRef string) ([]git.Commit, error) {
+	if s.firstParent {
+		return s.git.LogFirstParent(s.context(), fromRef, toRef)
+	}
+	return s.git.Log(s.context(), fromRef, toRef)
+}
+
+// commitFilesMulti lists the files each commit changed. In first-parent mode
+// merges list their changes against the first parent, so a merge bringing
+// in real work is not dropped as a clean merge.
+func (s *Storage) commitFilesMulti(commits []git.Commit) (map[string][]string, error) {
+	if s.firstParent {
+		return s.git.CommitFilesMultiFirstParent(s.context(), commitSHAs(commits))
+	}
+	return s.git.CommitFilesMulti(s.context(), commitSHAs(commits))
+}
+
+// loadFirstParent reads the [pending] first_parent setting for the
+// repository at root. A missing or unreadable config means false.
+func loadFirstParent(root string) bool {
+	cfg, err := config.LoadProject(root)
+	return err == nil && cfg.Pending.FirstParent
+}
+
+// IsShallow reports whether the repository is a shallow clone, where pending
+// covers only the fetched history.
+func (s *Storage) IsShallow() bool {
+	return s.git.IsShallow(s.context())
+}
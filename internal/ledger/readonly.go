@@ -0,0 +1,31 @@
+package ledger
+
+import (
+	"sync/atomic"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// readOnly disables every ledger write in the process. The CLI sets it once
+// per command from --read-only, so writes are refused even on paths that do
+// not go through a command's flags (MCP tools, git hooks).
+var readOnly atomic.Bool
+
+// SetReadOnly turns process-wide read-only mode on or off.
+func SetReadOnly(enabled bool) {
+	readOnly.Store(enabled)
+}
+
+// ReadOnly reports whether read-only mode is on.
+func ReadOnly() bool {
+	return readOnly.Load()
+}
+
+// checkWritable returns a user error naming the refused operation when
+// read-only mode is on.
+func checkWritable(operation string) error {
+	if readOnly.Load() {
+		return output.NewUserError("read-only mode: refusing to " + operation).WithID(output.ErrCodeReadOnly)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+// Package ledger — moving an entry onto a new anchor commit.
+package ledger
+
+import "slices"
+
+// ReanchorWorkset returns workset moved onto anchor, as when a squash merge
+// has replaced the commits it covered. Commits that inHistory reports are
+// still in anchor's history are kept; the rest are dropped, and anchor leads
+// the list. The range and commit metadata follow the commits. File lists and
+// diffstats are copied unchanged, for callers to recompute from git.
+func ReanchorWorkset(workset Workset, anchor string, inHistory func(sha string) bool) Workset {
+	moved := Workset{
+		AnchorCommit: anchor,
+		Commits:      []string{anchor},
+		Files:        slices.Clone(workset.Files),
+		Diffstat:     workset.Diffstat,
+		Branch:       workset.Branch,
+	}
+	for _, sha := range workset.Commits {
+		if sha != anchor && inHistory(sha) {
+			moved.Commits = append(moved.Commits, sha)
+		}
+	}
+	if count := len(moved.Commits); count > 1 {
+		moved.Range = shortCommit(moved.Commits[count-1]) + ".." + shortCommit(anchor)
+	}
+	for _, meta := range workset.CommitMeta {
+		if slices.Contains(moved.Commits, meta.SHA) {
+			moved.CommitMeta = append(moved.CommitMeta, meta)
+		}
+	}
+	return moved
+}
@@ -0,0 +1,39 @@
+package ledger
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReanchorWorkset(t *testing.T) {
+	workset := Workset{
+		AnchorCommit: "ccc3333333",
+		Commits:      []string{"ccc3333333", "bbb2222222", "aaa1111111"},
+		Range:        "aaa1111..ccc3333",
+		Files:        []string{"auth.go"},
+		CommitMeta:   []CommitMeta{{SHA: "ccc3333333"}, {SHA: "aaa1111111"}},
+	}
+	inHistory := func(sha string) bool { return sha == "aaa1111111" }
+
+	moved := ReanchorWorkset(workset, "ddd4444444", inHistory)
+	if moved.AnchorCommit != "ddd4444444" {
+		t.Errorf("anchor = %q", moved.AnchorCommit)
+	}
+	if want := []string{"ddd4444444", "aaa1111111"}; !slices.Equal(moved.Commits, want) {
+		t.Errorf("commits = %v, want %v", moved.Commits, want)
+	}
+	if moved.Range != "aaa1111..ddd4444" {
+		t.Errorf("range = %q", moved.Range)
+	}
+	if len(moved.CommitMeta) != 1 || moved.CommitMeta[0].SHA != "aaa1111111" {
+		t.Errorf("commit meta = %+v, want only the kept commit's", moved.CommitMeta)
+	}
+	if !slices.Equal(moved.Files, workset.Files) {
+		t.Errorf("files = %v, want them copied", moved.Files)
+	}
+
+	squashed := ReanchorWorkset(workset, "ddd4444444", func(string) bool { return false })
+	if !slices.Equal(squashed.Commits, []string{"ddd4444444"}) || squashed.Range != "" {
+		t.Errorf("squashed workset = %+v, want only the new anchor and no range", squashed)
+	}
+}
@@ -0,0 +1,43 @@
+// Package ledger — relations between entries.
+package ledger
+
+import "slices"
+
+// Relation types an entry can carry.
+const (
+	RelationSupersedes = "supersedes"
+	RelationRelatesTo  = "relates-to"
+	RelationFixes      = "fixes"
+)
+
+// RelationTypes lists every relation type, in display order.
+var RelationTypes = []string{RelationSupersedes, RelationFixes, RelationRelatesTo}
+
+// Relation links an entry to an earlier one: a correction that supersedes
+// it, a follow-up that fixes it, or related work.
+type Relation struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// AddRelation adds rel to the entry unless it is already there. Reports
+// whether the entry changed.
+func (e *Entry) AddRelation(rel Relation) bool {
+	if slices.Contains(e.Relations, rel) {
+		return false
+	}
+	e.Relations = append(e.Relations, rel)
+	return true
+}
+
+// RelationIDs returns the IDs the entry relates to with relation type
+// relType, in the order they were added.
+func (e *Entry) RelationIDs(relType string) []string {
+	var ids []string
+	for _, rel := range e.Relations {
+		if rel.Type == relType {
+			ids = append(ids, rel.ID)
+		}
+	}
+	return ids
+}
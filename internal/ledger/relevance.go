@@ -0,0 +1,139 @@
+package ledger
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ScoredEntry pairs an entry with its relevance to a query.
+type ScoredEntry struct {
+	Entry *Entry
+	Score float64
+}
+
+// fieldWeights sets how much a query term counts in each entry field. The
+// summary line and tags say what an entry is about; how and notes mention
+// many things in passing.
+var fieldWeights = []struct {
+	weight float64
+	text   func(e *Entry) string
+}{
+	{3, func(e *Entry) string { return e.Summary.What }},
+	{3, func(e *Entry) string { return strings.Join(e.Tags, " ") }},
+	{2, func(e *Entry) string { return e.Summary.Why }},
+	{2, func(e *Entry) string { return workItemText(e.WorkItems) }},
+	{1, func(e *Entry) string { return e.Summary.How }},
+	{1, func(e *Entry) string { return e.Notes }},
+}
+
+// stopwords are dropped from queries: they match nearly every entry.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"did": true, "do": true, "does": true, "for": true, "from": true, "how": true, "in": true,
+	"is": true, "it": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "was": true, "we": true, "what": true, "when": true, "where": true,
+	"which": true, "who": true, "why": true, "with": true,
+}
+
+// RankEntries scores entries against a free-text query by keyword overlap
+// and returns up to limit entries with a positive score, most relevant first.
+// Each query term contributes its field-weighted frequency scaled by inverse
+// document frequency, so rare terms outweigh ones every entry mentions. Ties
+// go to the newer entry. A limit of 0 or less returns every match.
+func RankEntries(entries []*Entry, query string, limit int) []ScoredEntry {
+	terms := QueryTerms(query)
+	if len(terms) == 0 || len(entries) == 0 {
+		return nil
+	}
+
+	docs := make([]map[string]float64, len(entries))
+	docFreq := make(map[string]int)
+	for i, entry := range entries {
+		docs[i] = weightedTerms(entry)
+		for _, term := range terms {
+			if docs[i][term] > 0 {
+				docFreq[term]++
+			}
+		}
+	}
+
+	var scored []ScoredEntry
+	for i, entry := range entries {
+		if score := tfidf(docs[i], terms, docFreq, len(entries)); score > 0 {
+			scored = append(scored, ScoredEntry{Entry: entry, Score: score})
+		}
+	}
+
+	return topScored(scored, limit)
+}
+
+// topScored sorts scored by score, newest first on ties, and keeps up to
+// limit of them.
+func topScored(scored []ScoredEntry, limit int) []ScoredEntry {
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].Entry.CreatedAt.After(scored[j].Entry.CreatedAt)
+	})
+	if limit > 0 && len(scored) > limit {
+		scored = scored[:limit]
+	}
+	return scored
+}
+
+// tfidf scores one document's weighted term counts against terms.
+func tfidf(doc map[string]float64, terms []string, docFreq map[string]int, total int) float64 {
+	score := 0.0
+	for _, term := range terms {
+		if tf := doc[term]; tf > 0 {
+			score += tf * math.Log(1+float64(total)/float64(docFreq[term]))
+		}
+	}
+	return score
+}
+
+// QueryTerms splits query into lowercase search terms, dropping stopwords
+// and single characters. Duplicates are removed.
+func QueryTerms(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, token := range tokenize(query) {
+		if len(token) < 2 || stopwords[token] || seen[token] {
+			continue
+		}
+		seen[token] = true
+		terms = append(terms, token)
+	}
+	return terms
+}
+
+// weightedTerms returns each token in entry with its field-weighted count.
+func weightedTerms(entry *Entry) map[string]float64 {
+	counts := make(map[string]float64)
+	for _, field := range fieldWeights {
+		for _, token := range tokenize(field.text(entry)) {
+			counts[token] += field.weight
+		}
+	}
+	return counts
+}
+
+// tokenize lowercases text and splits it on anything that is not a letter
+// or digit.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// workItemText renders work items as "system id" pairs for matching.
+func workItemText(items []WorkItem) string {
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, item.System+" "+item.ID)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,50 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRankEntries(t *testing.T) {
+	stamp := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	auth := makeTestEntry("aaa111", stamp)
+	auth.Summary.What = "Rotate JWT refresh tokens"
+	auth.Summary.Why = "Stolen refresh tokens stayed valid forever"
+	cache := makeTestEntry("bbb222", stamp.Add(time.Hour))
+	cache.Summary.What = "Cache pending results"
+	cache.Notes = "Considered caching tokens too"
+	unrelated := makeTestEntry("ccc333", stamp.Add(2*time.Hour))
+	unrelated.Summary.What = "Bump dependencies"
+	entries := []*Entry{auth, cache, unrelated}
+
+	ranked := RankEntries(entries, "Why do refresh tokens rotate?", 0)
+	if len(ranked) != 2 {
+		t.Fatalf("RankEntries() matched %d entries, want 2: %+v", len(ranked), ranked)
+	}
+	if ranked[0].Entry != auth {
+		t.Errorf("top entry = %q, want the token rotation entry", ranked[0].Entry.Summary.What)
+	}
+	if ranked[0].Score <= ranked[1].Score {
+		t.Errorf("scores not descending: %v, %v", ranked[0].Score, ranked[1].Score)
+	}
+
+	if got := RankEntries(entries, "refresh tokens", 1); len(got) != 1 {
+		t.Errorf("limit 1 returned %d entries", len(got))
+	}
+	if got := RankEntries(entries, "why is it", 0); got != nil {
+		t.Errorf("stopword-only query matched %d entries, want none", len(got))
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	got := QueryTerms("Why did the JWT-refresh change? JWT!")
+	want := []string{"jwt", "refresh", "change"}
+	if len(got) != len(want) {
+		t.Fatalf("QueryTerms() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("QueryTerms()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package ledger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// abbrevSHALength is git's default abbreviation, which ledger files use for
+// short SHAs (in ranges, for example). Entry IDs use the shorter
+// shortSHALength and are deliberately not relinked: an ID is permanent.
+const abbrevSHALength = 7
+
+// RelinkCommits rewrites commit SHAs in every entry and ack file after a
+// rebase or amend. rewrites maps each old full SHA to its replacement, as
+// git's post-rewrite hook reports them; both the full SHAs and their 7-char
+// abbreviations are replaced, so ranges and anchors follow the rewrite.
+// Files are edited byte-for-byte (line endings and field order survive).
+// Every rewritten entry and ack is validated before any file is written, so
+// a rewrite that would break one record changes none. Returns the paths that
+// changed, sorted; they are left unstaged for the caller to commit.
+func (fs *FileStorage) RelinkCommits(rewrites map[string]string) ([]string, error) {
+	if err := fs.checkWritable("relink ledger files"); err != nil {
+		return nil, err
+	}
+	unlock, err := fs.lock("relink ledger files")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	files, err := fs.relink(rewrites)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeLedgerFiles(files); err != nil {
+		return nil, err
+	}
+	fs.recordChecksums(relinkedPaths(files))
+	return relinkedPaths(files), nil
+}
+
+// ReanchorCommits is RelinkCommits followed by staging every changed file,
+// so the relinked ledger is ready to commit.
+func (fs *FileStorage) ReanchorCommits(rewrites map[string]string) ([]string, error) {
+	changed, err := fs.RelinkCommits(rewrites)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range changed {
+		if err := fs.gitAdd(path); err != nil {
+			return changed, output.NewSystemErrorWithCause("failed to stage relinked ledger file", err)
+		}
+	}
+	return changed, nil
+}
+
+// PlanRelink reports the files RelinkCommits would change, without writing
+// them. It validates like RelinkCommits, so a dry run surfaces the same
+// errors.
+func (fs *FileStorage) PlanRelink(rewrites map[string]string) ([]string, error) {
+	files, err := fs.relink(rewrites)
+	if err != nil {
+		return nil, err
+	}
+	return relinkedPaths(files), nil
+}
+
+// relinkedFile is a ledger file with rewrites applied, not yet written.
+type relinkedFile struct {
+	path string
+	data []byte
+}
+
+// relink walks the ledger directory applying rewrites in memory, and
+// returns the files that changed, sorted by path.
+func (fs *FileStorage) relink(rewrites map[string]string) ([]relinkedFile, error) {
+	replacer := shaReplacer(rewrites)
+	if replacer == nil {
+		return nil, nil
+	}
+
+	var changed []relinkedFile
+	walkErr := filepath.WalkDir(fs.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".json") || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		data, relinked, relinkErr := relinkFile(path, replacer)
+		if relinkErr != nil || !relinked {
+			return relinkErr
+		}
+		changed = append(changed, relinkedFile{path: path, data: data})
+		return nil
+	})
+	if err := relinkWalkError(walkErr); err != nil {
+		return nil, err
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].path < changed[j].path })
+	return changed, nil
+}
+
+// relinkWalkError maps an error from walking the ledger: validation errors
+// pass through, a missing ledger directory is not an error, and anything
+// else is a system error.
+func relinkWalkError(err error) error {
+	var exitErr *output.ExitError
+	switch {
+	case err == nil || os.IsNotExist(err):
+		return nil
+	case errors.As(err, &exitErr):
+		return exitErr
+	default:
+		return output.NewSystemErrorWithCause("failed to relink ledger files", err)
+	}
+}
+
+// relinkFile applies replacer to the file at path and validates the result.
+// Reports whether the file changed.
+func relinkFile(path string, replacer *strings.Replacer) ([]byte, bool, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path comes from walking the ledger directory
+	if err != nil {
+		return nil, false, fmt.Errorf("reading %s: %w", path, err)
+	}
+	relinked := []byte(replacer.Replace(string(data)))
+	if bytes.Equal(relinked, data) {
+		return nil, false, nil
+	}
+	if err := validateRelink(data, relinked); err != nil {
+		return nil, false, output.NewUserError("relinking " + filepath.Base(path) + " would corrupt it: " + err.Error())
+	}
+	return relinked, true, nil
+}
+
+// validateRelink checks that relinking kept a valid entry or ack valid,
+// under the same ID. Files that were not valid records to begin with are
+// left for doctor and lint to report.
+func validateRelink(before, after []byte) error {
+	oldID, ok := ledgerRecordID(before)
+	if !ok {
+		return nil
+	}
+	newID, ok := ledgerRecordID(after)
+	if !ok {
+		return errors.New("the result is not a valid ledger record")
+	}
+	if newID != oldID {
+		return fmt.Errorf("its id would change from %s to %s", oldID, newID)
+	}
+	return nil
+}
+
+// ledgerRecordID returns the ID of data when it is a valid entry or ack.
+func ledgerRecordID(data []byte) (string, bool) {
+	if entry, err := FromJSON(data); err == nil {
+		return entry.ID, entry.Validate() == nil
+	}
+	if ack, err := FromJSONAck(data); err == nil {
+		return ack.ID, ack.Validate() == nil
+	}
+	return "", false
+}
+
+// writeLedgerFiles writes every file to a temp file first and only then
+// renames them into place, so a failed write leaves the ledger untouched.
+func writeLedgerFiles(files []relinkedFile) error {
+	tmpPaths := make([]string, 0, len(files))
+	for _, file := range files {
+		tmpPath, err := writeTemp(filepath.Dir(file.path), file.data)
+		if err != nil {
+			removeAll(tmpPaths)
+			return output.NewSystemErrorWithCause("failed to write ledger file", err)
+		}
+		tmpPaths = append(tmpPaths, tmpPath)
+	}
+	for i, file := range files {
+		if err := os.Rename(tmpPaths[i], file.path); err != nil {
+			removeAll(tmpPaths[i:])
+			return output.NewSystemErrorWithCause("failed to write ledger file", err)
+		}
+	}
+	return nil
+}
+
+// removeAll removes paths, ignoring errors.
+func removeAll(paths []string) {
+	for _, path := range paths {
+		_ = os.Remove(path)
+	}
+}
+
+// relinkedPaths returns the paths of files.
+func relinkedPaths(files []relinkedFile) []string {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.path)
+	}
+	return paths
+}
+
+// shaReplacer builds a replacer for rewrites, full SHAs before short ones so
+// a full match is never half-replaced by its own abbreviation. Returns nil
+// when there is nothing to replace.
+func shaReplacer(rewrites map[string]string) *strings.Replacer {
+	olds := make([]string, 0, len(rewrites))
+	for old, replacement := range rewrites {
+		if old != replacement && len(old) > abbrevSHALength && len(replacement) > abbrevSHALength {
+			olds = append(olds, old)
+		}
+	}
+	if len(olds) == 0 {
+		return nil
+	}
+	sort.Strings(olds)
+
+	pairs := make([]string, 0, 4*len(olds))
+	for _, old := range olds {
+		pairs = append(pairs, old, rewrites[old])
+	}
+	for _, old := range olds {
+		pairs = append(pairs, old[:abbrevSHALength], rewrites[old][:abbrevSHALength])
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// RelinkCommits rewrites commit SHAs in the ledger files; see
+// FileStorage.RelinkCommits. Returns nil if file storage is not configured.
+func (s *Storage) RelinkCommits(rewrites map[string]string) ([]string, error) {
+	if s.files == nil {
+		return nil, nil
+	}
+	return s.files.RelinkCommits(rewrites)
+}
+
+// PlanRelink reports the ledger files RelinkCommits would change; see
+// FileStorage.PlanRelink. Returns nil if file storage is not configured.
+func (s *Storage) PlanRelink(rewrites map[string]string) ([]string, error) {
+	if s.files == nil {
+		return nil, nil
+	}
+	return s.files.PlanRelink(rewrites)
+}
+
+// ReanchorCommits relinks and stages the ledger files; see
+// FileStorage.ReanchorCommits. Returns nil if file storage is not
+// configured.
+func (s *Storage) ReanchorCommits(rewrites map[string]string) ([]string, error) {
+	if s.files == nil {
+		return nil, nil
+	}
+	return s.files.ReanchorCommits(rewrites)
+}
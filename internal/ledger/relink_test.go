@@ -0,0 +1,143 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRelinkCommits(t *testing.T) {
+	const (
+		oldSHA = "abc1234def5678901234567890abcdef12345678"
+		newSHA = "fff9999eee8888777766665555444433332222aa"
+		id     = "tb_2026-01-15T15:04:05Z_abc123"
+	)
+	entryJSON := "{\r\n  \"id\": \"" + id + "\",\r\n" +
+		"  \"workset\": {\"anchor_commit\": \"" + oldSHA + "\", \"range\": \"0000000..abc1234\"}\r\n}\r\n"
+
+	t.Run("replaces full and abbreviated SHAs", func(t *testing.T) {
+		dir := t.TempDir()
+		writeRawEntryFile(t, dir, id, []byte(entryJSON))
+		writeRawFile(t, dir, "unrelated.json", []byte(`{"anchor_commit": "1111111"}`))
+
+		changed, err := NewFileStorage(dir, noopGitAdd, noopGitCommit).
+			RelinkCommits(map[string]string{oldSHA: newSHA})
+		if err != nil {
+			t.Fatalf("RelinkCommits() error: %v", err)
+		}
+		if len(changed) != 1 {
+			t.Fatalf("changed = %v, want one file", changed)
+		}
+
+		data, err := os.ReadFile(changed[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := string(data)
+		if strings.Contains(got, oldSHA) || strings.Contains(got, "..abc1234") {
+			t.Errorf("old SHA survived relink: %s", got)
+		}
+		if !strings.Contains(got, newSHA) || !strings.Contains(got, "..fff9999") {
+			t.Errorf("new SHA missing after relink: %s", got)
+		}
+		if !strings.Contains(got, `"id": "`+id+`"`) {
+			t.Errorf("entry ID should not be relinked: %s", got)
+		}
+		if strings.Count(got, "\r\n") != 4 {
+			t.Errorf("CRLF line endings should be preserved: %q", got)
+		}
+	})
+
+	t.Run("no match leaves files untouched", func(t *testing.T) {
+		dir := t.TempDir()
+		writeRawEntryFile(t, dir, id, []byte(entryJSON))
+
+		changed, err := NewFileStorage(dir, noopGitAdd, noopGitCommit).
+			RelinkCommits(map[string]string{strings.Repeat("9", 40): newSHA})
+		if err != nil {
+			t.Fatalf("RelinkCommits() error: %v", err)
+		}
+		if len(changed) != 0 {
+			t.Errorf("changed = %v, want none", changed)
+		}
+	})
+
+	t.Run("plan reports files without writing", func(t *testing.T) {
+		dir := t.TempDir()
+		writeRawEntryFile(t, dir, id, []byte(entryJSON))
+
+		planned, err := NewFileStorage(dir, noopGitAdd, noopGitCommit).
+			PlanRelink(map[string]string{oldSHA: newSHA})
+		if err != nil {
+			t.Fatalf("PlanRelink() error: %v", err)
+		}
+		if len(planned) != 1 {
+			t.Fatalf("planned = %v, want one file", planned)
+		}
+		data, err := os.ReadFile(planned[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != entryJSON {
+			t.Errorf("PlanRelink() modified the file: %s", data)
+		}
+	})
+
+	t.Run("missing ledger directory is not an error", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "missing")
+		changed, err := NewFileStorage(dir, noopGitAdd, noopGitCommit).
+			RelinkCommits(map[string]string{oldSHA: newSHA})
+		if err != nil || len(changed) != 0 {
+			t.Errorf("RelinkCommits() = %v, %v; want nil, nil", changed, err)
+		}
+	})
+
+	t.Run("a rewrite that corrupts one record writes nothing", func(t *testing.T) {
+		dir := t.TempDir()
+		created := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+		plain := makeTestEntry(oldSHA, created)
+		// A custom ID that happens to contain the old abbreviation.
+		clashing := makeTestEntry(oldSHA, created.Add(time.Hour))
+		clashing.ID = "tb_2026-01-15T16:04:05Z_" + oldSHA[:abbrevSHALength]
+		for _, entry := range []*Entry{plain, clashing} {
+			data, err := entry.ToJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeRawEntryFile(t, dir, entry.ID, data)
+		}
+
+		_, err := NewFileStorage(dir, noopGitAdd, noopGitCommit).
+			RelinkCommits(map[string]string{oldSHA: newSHA})
+		if err == nil || !strings.Contains(err.Error(), "id would change") {
+			t.Fatalf("RelinkCommits() error = %v, want an id-change error", err)
+		}
+		entries, err := NewFileStorage(dir, noopGitAdd, noopGitCommit).ListEntries()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range entries {
+			if entry.Workset.AnchorCommit != oldSHA {
+				t.Errorf("%s was relinked despite the failed validation", entry.ID)
+			}
+		}
+	})
+
+	t.Run("reanchor stages every changed file", func(t *testing.T) {
+		dir := t.TempDir()
+		writeRawEntryFile(t, dir, id, []byte(entryJSON))
+		recorder := &gitAddRecorder{}
+
+		changed, err := NewFileStorage(dir, recorder.add, noopGitCommit).
+			ReanchorCommits(map[string]string{oldSHA: newSHA})
+		if err != nil {
+			t.Fatalf("ReanchorCommits() error: %v", err)
+		}
+		if len(changed) != 1 || !reflect.DeepEqual(recorder.paths, changed) {
+			t.Errorf("staged %v, changed %v; want the one changed file staged", recorder.paths, changed)
+		}
+	})
+}
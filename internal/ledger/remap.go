@@ -0,0 +1,109 @@
+package ledger
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/git"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// ParseRewriteMap reads old-to-new commit pairs, one per line, as
+// "<old> <new>" or "<old>=<new>". Blank lines and # comments are skipped,
+// as are lines that are not SHA pairs, so git filter-repo's commit-map
+// (with its "old new" header) and a post-rewrite list can be fed in as-is.
+// Commits mapped to the all-zero SHA were dropped by the rewrite and are
+// left alone.
+func ParseRewriteMap(r io.Reader) (map[string]string, error) {
+	rewrites := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(strings.Replace(line, "=", " ", 1))
+		if len(fields) < 2 || !isHexSHA(fields[0]) || !isHexSHA(fields[1]) {
+			if lineNo == 1 {
+				continue // a header line
+			}
+			return nil, output.NewUserError("line " + strconv.Itoa(lineNo) + ": expected <old-sha> <new-sha>, got " + line)
+		}
+		if strings.Trim(fields[1], "0") == "" {
+			continue
+		}
+		rewrites[fields[0]] = fields[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to read commit map", err)
+	}
+	return rewrites, nil
+}
+
+// isHexSHA reports whether s looks like a full or abbreviated commit SHA.
+func isHexSHA(s string) bool {
+	if len(s) < abbrevSHALength || len(s) > 64 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// ReferencedCommits returns the distinct anchor and workset SHAs of
+// entries, sorted.
+func ReferencedCommits(entries []*Entry) []string {
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.Workset.AnchorCommit != "" {
+			seen[entry.Workset.AnchorCommit] = true
+		}
+		for _, sha := range entry.Workset.Commits {
+			seen[sha] = true
+		}
+	}
+	shas := make([]string, 0, len(seen))
+	for sha := range seen {
+		shas = append(shas, sha)
+	}
+	sort.Strings(shas)
+	return shas
+}
+
+// MatchRewrites pairs each orphaned commit with its rewritten counterpart
+// in current: the commit with the same author email, author date, and
+// subject, which rebase, cherry-pick, and filter-repo all preserve. An
+// orphan with no counterpart, or with several, is returned in unmatched
+// (sorted) rather than guessed at. Squashed commits never match.
+func MatchRewrites(orphans, current []git.Commit) (rewrites map[string]string, unmatched []string) {
+	candidates := make(map[string][]string, len(current))
+	for _, commit := range current {
+		key := rewriteKey(commit)
+		candidates[key] = append(candidates[key], commit.SHA)
+	}
+
+	rewrites = make(map[string]string)
+	for _, orphan := range orphans {
+		matches := candidates[rewriteKey(orphan)]
+		if len(matches) == 1 && matches[0] != orphan.SHA {
+			rewrites[orphan.SHA] = matches[0]
+			continue
+		}
+		unmatched = append(unmatched, orphan.SHA)
+	}
+	sort.Strings(unmatched)
+	return rewrites, unmatched
+}
+
+// rewriteKey identifies a commit across history rewrites.
+func rewriteKey(commit git.Commit) string {
+	return commit.AuthorEmail + "\x00" + strconv.FormatInt(commit.Date.Unix(), 10) + "\x00" + commit.Subject
+}
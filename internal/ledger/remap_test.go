@@ -0,0 +1,69 @@
+package ledger
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+func TestParseRewriteMap(t *testing.T) {
+	const (
+		oldSHA = "abc1234def5678901234567890abcdef12345678"
+		newSHA = "fff9999eee8888777766665555444433332222aa"
+		zero   = "0000000000000000000000000000000000000000"
+	)
+
+	t.Run("accepts filter-repo and old=new forms", func(t *testing.T) {
+		input := "old                                      new\n" +
+			oldSHA + " " + newSHA + "\n" +
+			"# a comment\n\n" +
+			"1111111=2222222\n" +
+			newSHA + " " + zero + "\n"
+		got, err := ParseRewriteMap(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ParseRewriteMap() error: %v", err)
+		}
+		want := map[string]string{oldSHA: newSHA, "1111111": "2222222"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseRewriteMap() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("rejects malformed lines after the first", func(t *testing.T) {
+		_, err := ParseRewriteMap(strings.NewReader(oldSHA + " " + newSHA + "\nnot a pair\n"))
+		if err == nil || !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("ParseRewriteMap() error = %v, want a line 2 error", err)
+		}
+	})
+}
+
+func TestReferencedCommits(t *testing.T) {
+	entries := []*Entry{
+		{Workset: Workset{AnchorCommit: "bbb", Commits: []string{"aaa", "bbb"}}},
+		{Workset: Workset{AnchorCommit: "ccc", Commits: []string{"ccc"}}},
+	}
+	if got, want := ReferencedCommits(entries), []string{"aaa", "bbb", "ccc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReferencedCommits() = %v, want %v", got, want)
+	}
+}
+
+func TestMatchRewrites(t *testing.T) {
+	date := time.Date(2026, 1, 15, 15, 4, 5, 0, time.UTC)
+	commit := func(sha, subject string) git.Commit {
+		return git.Commit{SHA: sha, Subject: subject, AuthorEmail: "dev@example.com", Date: date}
+	}
+
+	orphans := []git.Commit{commit("old1", "feat: one"), commit("old2", "feat: two"), commit("old3", "feat: squashed")}
+	current := []git.Commit{commit("new1", "feat: one"), commit("new2a", "feat: two"), commit("new2b", "feat: two")}
+
+	rewrites, unmatched := MatchRewrites(orphans, current)
+	if want := map[string]string{"old1": "new1"}; !reflect.DeepEqual(rewrites, want) {
+		t.Errorf("rewrites = %v, want %v", rewrites, want)
+	}
+	if want := []string{"old2", "old3"}; !reflect.DeepEqual(unmatched, want) {
+		t.Errorf("unmatched = %v, want %v (ambiguous and squashed)", unmatched, want)
+	}
+}
@@ -0,0 +1,89 @@
+// Package ledger — revision history for amended entries.
+package ledger
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Revision records one change to an entry after it was written: when, by
+// whom, and each field's value before and after.
+type Revision struct {
+	RevisedAt time.Time     `json:"revised_at"`
+	By        Acker         `json:"by"`
+	Changes   []FieldChange `json:"changes"`
+}
+
+// FieldChange is one field's value before and after a revision, rendered
+// as text. An empty side means the field was unset.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// revisionFields lists the fields a revision compares, in display order,
+// with how each renders as text.
+var revisionFields = []struct {
+	name   string
+	render func(*Entry) string
+}{
+	{"kind", func(e *Entry) string { return e.Kind }},
+	{"summary.what", func(e *Entry) string { return e.Summary.What }},
+	{"summary.why", func(e *Entry) string { return e.Summary.Why }},
+	{"summary.how", func(e *Entry) string { return e.Summary.How }},
+	{"notes", func(e *Entry) string { return e.Notes }},
+	{"tags", func(e *Entry) string { return strings.Join(e.Tags, ", ") }},
+	{"work_items", renderWorkItems},
+	{"contributors", renderContributors},
+	{"meta", func(e *Entry) string { return FormatMeta(e.Meta) }},
+	{"relations", renderRelations},
+	{"workset.anchor_commit", func(e *Entry) string { return e.Workset.AnchorCommit }},
+	{"workset.commits", func(e *Entry) string { return strings.Join(e.Workset.Commits, ", ") }},
+}
+
+// RecordRevision appends a revision to e listing every field that differs
+// from before, e's prior version. Reports whether anything changed; an
+// unchanged entry gets no revision.
+func (e *Entry) RecordRevision(before *Entry, reviser Acker, revisedAt time.Time) bool {
+	var changes []FieldChange
+	for _, field := range revisionFields {
+		if old, updated := field.render(before), field.render(e); old != updated {
+			changes = append(changes, FieldChange{Field: field.name, Before: old, After: updated})
+		}
+	}
+	if len(changes) == 0 {
+		return false
+	}
+	// Clone so a shallow copy of before never shares the appended element.
+	e.Revisions = append(slices.Clone(before.Revisions), Revision{RevisedAt: revisedAt, By: reviser, Changes: changes})
+	return true
+}
+
+// renderWorkItems renders work items as "system:id, system:id".
+func renderWorkItems(e *Entry) string {
+	parts := make([]string, len(e.WorkItems))
+	for i, item := range e.WorkItems {
+		parts[i] = item.System + ":" + item.ID
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderContributors renders contributors as "Name <email>, ...".
+func renderContributors(e *Entry) string {
+	parts := make([]string, len(e.Contributors))
+	for i, contributor := range e.Contributors {
+		parts[i] = contributor.Name + " <" + contributor.Email + ">"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderRelations renders relations as "type id, type id".
+func renderRelations(e *Entry) string {
+	parts := make([]string, len(e.Relations))
+	for i, rel := range e.Relations {
+		parts[i] = rel.Type + " " + rel.ID
+	}
+	return strings.Join(parts, ", ")
+}
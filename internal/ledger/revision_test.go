@@ -0,0 +1,49 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordRevision(t *testing.T) {
+	created := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	before := makeTestEntry("rev001", created)
+	before.Tags = []string{"auth"}
+
+	after := *before
+	after.Summary.Why = "clearer reasoning"
+	after.Tags = []string{"auth", "security"}
+	reviser := Acker{Name: "Dev", Email: "dev@example.com"}
+	if !after.RecordRevision(before, reviser, created.Add(time.Hour)) {
+		t.Fatal("RecordRevision reported no change")
+	}
+	if len(after.Revisions) != 1 || len(before.Revisions) != 0 {
+		t.Fatalf("revisions: after %d, before %d; want 1, 0", len(after.Revisions), len(before.Revisions))
+	}
+	rev := after.Revisions[0]
+	want := []FieldChange{
+		{Field: "summary.why", Before: "test why", After: "clearer reasoning"},
+		{Field: "tags", Before: "auth", After: "auth, security"},
+	}
+	if rev.By != reviser || len(rev.Changes) != len(want) {
+		t.Fatalf("revision = %+v", rev)
+	}
+	for i := range want {
+		if rev.Changes[i] != want[i] {
+			t.Errorf("changes[%d] = %+v, want %+v", i, rev.Changes[i], want[i])
+		}
+	}
+
+	// A second amend appends without touching the earlier version's slice.
+	second := after
+	second.Notes = "added context"
+	second.RecordRevision(&after, reviser, created.Add(2*time.Hour))
+	if len(second.Revisions) != 2 || len(after.Revisions) != 1 {
+		t.Errorf("revisions: second %d, after %d; want 2, 1", len(second.Revisions), len(after.Revisions))
+	}
+
+	unchanged := *before
+	if unchanged.RecordRevision(before, reviser, created) || unchanged.Revisions != nil {
+		t.Error("RecordRevision on an unchanged entry added a revision")
+	}
+}
@@ -0,0 +1,134 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// WriteMerge records a merge in one commit: the merged entry, the existing
+// entries whose relations were retargeted at it, and a tombstone for each
+// entry merged away.
+func (fs *FileStorage) WriteMerge(merged *Entry, retargeted []*Entry, tombstones []*Tombstone) error {
+	operation := "merge " + strconv.Itoa(len(tombstones)) + " entries into " + merged.ID
+	return fs.writeRewrite(operation, []*Entry{merged}, retargeted, tombstones)
+}
+
+// WriteSplit records a split in one commit: the entries split out of the
+// original, the existing entries whose relations were pointed at them, and
+// the original's tombstone.
+func (fs *FileStorage) WriteSplit(parts, retargeted []*Entry, tombstone *Tombstone) error {
+	operation := "split " + tombstone.TargetID + " into " + strconv.Itoa(len(parts)) + " entries"
+	return fs.writeRewrite(operation, parts, retargeted, []*Tombstone{tombstone})
+}
+
+// writeRewrite writes new entries, rewrites existing ones, and adds
+// tombstones as one commit. Everything is validated and serialized before
+// the first file is written.
+func (fs *FileStorage) writeRewrite(operation string, created, updated []*Entry, tombstones []*Tombstone) error {
+	if err := fs.checkWritable(operation); err != nil {
+		return err
+	}
+	unlock, err := fs.lock(operation)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	files, err := fs.rewriteFiles(created, updated, tombstones)
+	if err != nil {
+		return err
+	}
+	paths := relinkedPaths(files)
+	for _, path := range paths {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return output.NewSystemErrorWithCause("failed to create ledger directory", err)
+		}
+	}
+	if err := writeLedgerFiles(files); err != nil {
+		return err
+	}
+	fs.recordChecksums(paths)
+	for _, path := range paths {
+		if err := fs.gitAdd(path); err != nil {
+			return output.NewSystemErrorWithCause("failed to stage rewritten ledger file", err)
+		}
+	}
+	if err := fs.commitPaths(paths, "timbers: "+operation); err != nil {
+		return output.NewSystemErrorWithCause("failed to commit rewritten ledger files", err)
+	}
+	return nil
+}
+
+// rewriteFiles validates and serializes the files writeRewrite writes.
+func (fs *FileStorage) rewriteFiles(created, updated []*Entry, tombstones []*Tombstone) ([]relinkedFile, error) {
+	files := make([]relinkedFile, 0, len(created)+len(updated)+len(tombstones))
+	for _, entry := range created {
+		if err := entry.Validate(); err != nil {
+			return nil, output.NewUserError(err.Error())
+		}
+		if fs.EntryExists(entry.ID) {
+			return nil, output.NewConflictError("entry already exists: " + entry.ID).WithID(output.ErrCodeEntryExists)
+		}
+		data, err := fs.marshalEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, relinkedFile{path: fs.entryPath(entry.ID), data: data})
+	}
+
+	for _, entry := range updated {
+		path, ok := fs.existingEntryPath(entry.ID)
+		if !ok {
+			return nil, output.NewUserError("entry not found: " + entry.ID).WithID(output.ErrCodeEntryNotFound)
+		}
+		data, err := fs.marshalEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, relinkedFile{path: path, data: data})
+	}
+
+	for _, tombstone := range tombstones {
+		file, err := fs.tombstoneFile(tombstone)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// tombstoneFile validates and serializes one tombstone of a rewrite.
+func (fs *FileStorage) tombstoneFile(tombstone *Tombstone) (relinkedFile, error) {
+	if err := tombstone.Validate(); err != nil {
+		return relinkedFile{}, output.NewUserError(err.Error())
+	}
+	path := fs.tombstonePath(tombstone)
+	if fs.tombstoneExists(tombstone) {
+		return relinkedFile{}, output.NewConflictError("entry already deleted: " + tombstone.TargetID).WithID(output.ErrCodeEntryExists)
+	}
+	data, err := tombstone.ToJSON()
+	if err != nil {
+		return relinkedFile{}, output.NewSystemError("failed to serialize tombstone: " + err.Error())
+	}
+	return relinkedFile{path: path, data: data}, nil
+}
+
+// WriteMerge records a merge in one commit; see FileStorage.WriteMerge.
+func (s *Storage) WriteMerge(merged *Entry, retargeted []*Entry, tombstones []*Tombstone) error {
+	if s.files == nil {
+		return output.NewSystemError("storage not configured for writes")
+	}
+	return s.files.WriteMerge(merged, retargeted, tombstones)
+}
+
+// WriteSplit records a split in one commit; see FileStorage.WriteSplit.
+func (s *Storage) WriteSplit(parts, retargeted []*Entry, tombstone *Tombstone) error {
+	if s.files == nil {
+		return output.NewSystemError("storage not configured for writes")
+	}
+	return s.files.WriteSplit(parts, retargeted, tombstone)
+}
@@ -0,0 +1,141 @@
+package ledger
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// EntrySchema is the JSON Schema (draft 2020-12) for timbers.devlog
+// entries, v1 and v2 alike. Unlike struct-tag unmarshalling, which silently
+// drops unknown or mistyped fields, validating against it catches payloads
+// written by other tools that don't match what timbers reads.
+//
+//go:embed entry.schema.json
+var EntrySchema []byte
+
+// resolvedEntrySchema parses and resolves EntrySchema once.
+var resolvedEntrySchema = sync.OnceValues(func() (*jsonschema.Resolved, error) {
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(EntrySchema, &schema); err != nil {
+		return nil, fmt.Errorf("parsing embedded entry schema: %w", err)
+	}
+	return schema.Resolve(nil)
+})
+
+// SchemaError is a schema violation at a specific location in an entry.
+type SchemaError struct {
+	Path    string // instance location, e.g. "workset.diffstat.files"; "" for the top level
+	Message string // what the schema rejected
+}
+
+// Error implements the error interface.
+func (e *SchemaError) Error() string {
+	if e.Path == "" {
+		return "schema violation: " + e.Message
+	}
+	return "schema violation at " + e.Path + ": " + e.Message
+}
+
+// ValidateSchema checks raw entry JSON against EntrySchema. Returns a
+// *SchemaError naming the offending location on the first violation.
+func ValidateSchema(data []byte) error {
+	resolved, err := resolvedEntrySchema()
+	if err != nil {
+		return err
+	}
+	var instance any
+	if err := json.Unmarshal(data, &instance); err != nil {
+		return fmt.Errorf("parsing entry JSON: %w", err)
+	}
+	if err := resolved.Validate(instance); err != nil {
+		return newSchemaError(err.Error())
+	}
+	return nil
+}
+
+// FromJSONStrict is FromJSON plus schema validation: payloads with unknown,
+// mistyped, or missing fields are rejected instead of being partially loaded.
+// Non-timbers JSON still returns ErrNotTimbersNote.
+func FromJSONStrict(data []byte) (*Entry, error) {
+	entry, err := FromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateSchema(data); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// AsSchemaError checks if err is a SchemaError and extracts it.
+func AsSchemaError(err error, target **SchemaError) bool {
+	return errors.As(err, target)
+}
+
+// newSchemaError turns the validator's nested "validating <schema pointer>:"
+// chain into an instance path. Pointers into $defs restart the walk from the
+// referencing property, so only property names and array items contribute.
+func newSchemaError(msg string) *SchemaError {
+	var path []string
+	for strings.HasPrefix(msg, "validating ") {
+		pointer, rest, ok := strings.Cut(strings.TrimPrefix(msg, "validating "), ": ")
+		if !ok {
+			break
+		}
+		msg = rest
+		if step := lastSchemaStep(pointer); step != "" {
+			path = append(path, step)
+		}
+	}
+	return &SchemaError{Path: strings.ReplaceAll(strings.Join(path, "."), ".[]", "[]"), Message: msg}
+}
+
+// lastSchemaStep maps the final segment of a schema pointer to an instance
+// path step: "/properties/x" gives "x", "/items" gives "[]", anything else
+// (the root, $defs, $ref targets) gives "".
+func lastSchemaStep(pointer string) string {
+	segments := strings.Split(pointer, "/")
+	n := len(segments)
+	switch {
+	case n >= 2 && segments[n-2] == "properties":
+		return segments[n-1]
+	case n >= 1 && segments[n-1] == "items":
+		return "[]"
+	default:
+		return ""
+	}
+}
+
+// SetStrict turns schema validation on or off. In strict mode ReadEntry
+// rejects files that don't match EntrySchema (ListEntries counts them as
+// parse errors) and writes refuse entries that would not round-trip.
+func (fs *FileStorage) SetStrict(strict bool) {
+	fs.strict = strict
+}
+
+// marshalEntry serializes entry, sealing it first when it is encrypted and
+// validating the result against EntrySchema in strict mode.
+func (fs *FileStorage) marshalEntry(entry *Entry) ([]byte, error) {
+	entry, err := fs.sealForWrite(entry)
+	if err != nil {
+		return nil, err
+	}
+	data, err := entry.ToJSON()
+	if err != nil {
+		return nil, output.NewSystemError("failed to serialize entry: " + err.Error())
+	}
+	if fs.strict {
+		if err := ValidateSchema(data); err != nil {
+			return nil, output.NewUserError(err.Error())
+		}
+	}
+	return data, nil
+}
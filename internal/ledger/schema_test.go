@@ -0,0 +1,110 @@
+package ledger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestValidateSchema_FullEntry guards against the schema drifting from the
+// Entry struct: an entry with every optional field populated must validate.
+func TestValidateSchema_FullEntry(t *testing.T) {
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	entry := makeTestEntry("abc123def456", now)
+	entry.Workset.Range = "abc123..def456"
+	entry.Workset.Diffstat = &Diffstat{
+		Files: 3, Insertions: 10, Deletions: 2, BinaryFiles: 1, GeneratedFiles: 1,
+		Renames: []Rename{{From: "a.go", To: "b.go"}},
+	}
+	entry.Workset.CommitMeta = []CommitMeta{{
+		SHA: "abc123def456", Author: "Ada", AuthorEmail: "ada@example.com",
+		AuthoredAt: &now, CommittedAt: &now,
+		Signature: &CommitSignature{Status: "good", Key: "ABCD", Signer: "Ada"},
+	}}
+	entry.Notes = "notes"
+	entry.Tags = []string{"feature"}
+	entry.WorkItems = []WorkItem{{System: "jira", ID: "PROJ-1"}}
+	entry.Contributors = []Contributor{{Name: "Ada", Email: "ada@example.com", Sources: []string{"git-author"}}}
+
+	data, err := entry.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+	if err := ValidateSchema(data); err != nil {
+		t.Errorf("ValidateSchema(full entry) = %v, want nil", err)
+	}
+}
+
+func TestValidateSchema_PathLevelErrors(t *testing.T) {
+	base := `"schema":"timbers.devlog/v1","kind":"entry","id":"tb_x","created_at":"a","updated_at":"b"`
+	tests := []struct {
+		name     string
+		json     string
+		wantPath string
+		wantMsg  string
+	}{
+		{
+			name:     "missing summary field",
+			json:     `{` + base + `,"workset":{"anchor_commit":"a","commits":["a"]},"summary":{"what":"a","how":"c"}}`,
+			wantPath: "summary",
+			wantMsg:  `"why"`,
+		},
+		{
+			name: "mistyped diffstat",
+			json: `{` + base + `,"workset":{"anchor_commit":"a","commits":["a"],` +
+				`"diffstat":{"files":"3","insertions":0,"deletions":0}},"summary":{"what":"a","why":"b","how":"c"}}`,
+			wantPath: "workset.diffstat.files",
+			wantMsg:  "type",
+		},
+		{
+			name:     "non-string commit",
+			json:     `{` + base + `,"workset":{"anchor_commit":"a","commits":[3]},"summary":{"what":"a","why":"b","how":"c"}}`,
+			wantPath: "workset.commits[]",
+			wantMsg:  "type",
+		},
+		{
+			name:     "unknown field",
+			json:     `{` + base + `,"workset":{"anchor_commit":"a","commits":["a"]},"summary":{"what":"a","why":"b","how":"c"},"summary_typo":1}`,
+			wantPath: "",
+			wantMsg:  `"summary_typo"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSchema([]byte(tt.json))
+			var schemaErr *SchemaError
+			if !AsSchemaError(err, &schemaErr) {
+				t.Fatalf("ValidateSchema() = %v, want *SchemaError", err)
+			}
+			if schemaErr.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q (error: %v)", schemaErr.Path, tt.wantPath, err)
+			}
+			if !strings.Contains(schemaErr.Message, tt.wantMsg) {
+				t.Errorf("Message = %q, want it to mention %q", schemaErr.Message, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestFileStorage_StrictRejectsMalformedFiles(t *testing.T) {
+	dir := t.TempDir()
+	entry := makeTestEntry("strict01", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	data, _ := entry.ToJSON()
+	// A field another tool added (or misspelled) that FromJSON silently drops.
+	tampered := strings.Replace(string(data), `"summary"`, `"extra":true,"summary"`, 1)
+	writeRawEntryFile(t, dir, entry.ID, []byte(tampered))
+
+	store := NewFileStorage(dir, noopGitAdd, noopGitCommit)
+	if _, err := store.ReadEntry(entry.ID); err != nil {
+		t.Fatalf("non-strict ReadEntry: %v", err)
+	}
+
+	store.SetStrict(true)
+	if _, err := store.ReadEntry(entry.ID); err == nil || !strings.Contains(err.Error(), "extra") {
+		t.Errorf("strict ReadEntry error = %v, want schema violation naming the field", err)
+	}
+	_, stats, err := store.ListEntriesWithStats()
+	if err != nil || stats.ParseErrors != 1 {
+		t.Errorf("strict ListEntriesWithStats: parse errors = %v, err = %v; want 1", stats, err)
+	}
+}
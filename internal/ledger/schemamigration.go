@@ -0,0 +1,313 @@
+// Package ledger — schema version migrations.
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// schemaFamily prefixes the schema field of every timbers record.
+const schemaFamily = "timbers.devlog/"
+
+// Migration moves a ledger record from one schema version to the next.
+// Transform edits the decoded JSON in place, so a migration keeps working
+// after the Go types move on; the runner sets the schema field itself.
+type Migration struct {
+	From      string // schema version migrated from, e.g. "v1"
+	To        string // schema version migrated to, e.g. "v2"
+	Summary   string // one line describing the change, for migrate output
+	Transform func(record map[string]any) error
+}
+
+// Migrations lists every schema migration, oldest first; each one's To is
+// the next one's From, and the last one's To is SchemaVersion's version.
+var Migrations = []Migration{
+	{From: "v1", To: "v2", Summary: "decision.supersedes becomes a supersedes relation", Transform: migrateV1ToV2},
+}
+
+// SchemaVersions lists every schema version, oldest first.
+func SchemaVersions() []string {
+	versions := make([]string, 0, len(Migrations)+1)
+	versions = append(versions, Migrations[0].From)
+	for _, migration := range Migrations {
+		versions = append(versions, migration.To)
+	}
+	return versions
+}
+
+// SchemaVersionOf returns the version part of a schema field: "v1" for
+// "timbers.devlog/v1".
+func SchemaVersionOf(schema string) string {
+	return strings.TrimPrefix(schema, schemaFamily)
+}
+
+// ParseSchemaVersion validates a version name from a flag, either "v2" or
+// "timbers.devlog/v2". Empty means the current version.
+func ParseSchemaVersion(name string) (string, error) {
+	if name == "" {
+		return SchemaVersionOf(SchemaVersion), nil
+	}
+	version := SchemaVersionOf(name)
+	if !slices.Contains(SchemaVersions(), version) {
+		return "", fmt.Errorf("unknown schema version %q (supported: %s)", name, strings.Join(SchemaVersions(), ", "))
+	}
+	return version, nil
+}
+
+// migrationsBetween returns the migrations that take a record from version
+// from to version target, in order. Migrations only run forward.
+func migrationsBetween(from, target string) ([]Migration, error) {
+	if from == target {
+		return nil, nil
+	}
+	versions := SchemaVersions()
+	start, end := slices.Index(versions, from), slices.Index(versions, target)
+	switch {
+	case start < 0:
+		return nil, fmt.Errorf("unknown schema version %q (written by a newer timbers?)", from)
+	case start > end:
+		return nil, fmt.Errorf("cannot migrate from %s back to %s: migrations only run forward", from, target)
+	}
+	return Migrations[start:end], nil
+}
+
+// MigrateRecord migrates one ledger record (entry, ack, or tombstone) to
+// version target, keeping the layout of data (see recordFormat) so only the
+// migrated fields differ. Reports whether the record changed; a record
+// already at target is returned unchanged. Returns ErrNotTimbersNote for JSON that is
+// not a timbers record.
+func MigrateRecord(data []byte, target string) ([]byte, bool, error) {
+	var record map[string]any
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, false, fmt.Errorf("parsing record JSON: %w", err)
+	}
+	schema, _ := record["schema"].(string)
+	if !strings.HasPrefix(schema, schemaFamily) {
+		return nil, false, ErrNotTimbersNote
+	}
+	steps, err := migrationsBetween(SchemaVersionOf(schema), target)
+	if err != nil || len(steps) == 0 {
+		return nil, false, err
+	}
+	for _, step := range steps {
+		if transformErr := step.Transform(record); transformErr != nil {
+			return nil, false, fmt.Errorf("migrating %s to %s: %w", step.From, step.To, transformErr)
+		}
+		record["schema"] = schemaFamily + step.To
+	}
+	migrated, err := encodeMigrated(record, detectFormat(data))
+	if err != nil {
+		return nil, false, err
+	}
+	return migrated, !bytes.Equal(migrated, data), nil
+}
+
+// migrateV1ToV2 moves decision.supersedes, where 'timbers decide
+// --supersedes' recorded the link under v1, into relations as a supersedes
+// relation, first so SupersedesID still finds it, and drops a decision
+// object left empty.
+func migrateV1ToV2(record map[string]any) error {
+	decision, ok := record["decision"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	supersedes, ok := decision["supersedes"].(string)
+	if !ok {
+		return nil
+	}
+	delete(decision, "supersedes")
+	if len(decision) == 0 {
+		delete(record, "decision")
+	}
+	if supersedes == "" {
+		return nil
+	}
+	relations, _ := record["relations"].([]any)
+	rel := map[string]any{"type": RelationSupersedes, "id": supersedes}
+	if slices.ContainsFunc(relations, func(existing any) bool { return reflect.DeepEqual(existing, rel) }) {
+		return nil
+	}
+	record["relations"] = append([]any{rel}, relations...)
+	return nil
+}
+
+// recordFormat is the layout of a ledger file. Timbers writes compact JSON,
+// but ledgers also hold files an older timbers or an editor indented; a
+// migrated record is written back in its file's layout, so the change shows
+// only the migrated fields.
+type recordFormat struct {
+	indent     string // indentation per level; "" for compact JSON
+	newline    bool   // the file ends with a newline
+	escapeHTML bool   // <, >, and & are escaped, as encoding/json does by default
+	ascii      bool   // other characters are \u-escaped, as some JSON writers do
+}
+
+// detectFormat returns the layout of the JSON in data.
+func detectFormat(data []byte) recordFormat {
+	format := recordFormat{
+		newline:    bytes.HasSuffix(data, []byte("\n")),
+		escapeHTML: !bytes.ContainsAny(data, "<>&"),
+		ascii:      !bytes.ContainsFunc(data, func(r rune) bool { return r > unicode.MaxASCII }),
+	}
+	if body, ok := bytes.CutPrefix(bytes.TrimSpace(data), []byte("{\n")); ok {
+		format.indent = string(body[:len(body)-len(bytes.TrimLeft(body, " \t"))])
+	}
+	return format
+}
+
+// encode serializes value in the format.
+func (f recordFormat) encode(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(f.escapeHTML)
+	encoder.SetIndent("", f.indent)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err //nolint:wrapcheck // callers wrap or ignore
+	}
+	data := buf.Bytes()
+	if f.ascii {
+		data = escapeNonASCII(data)
+	}
+	if f.newline {
+		return data, nil
+	}
+	return bytes.TrimSuffix(data, []byte("\n")), nil
+}
+
+// escapeNonASCII replaces every non-ASCII character in encoded JSON, which
+// can only occur inside strings, with its \u escape.
+func escapeNonASCII(data []byte) []byte {
+	escaped := make([]byte, 0, len(data))
+	for _, r := range string(data) {
+		if r <= unicode.MaxASCII {
+			escaped = append(escaped, byte(r))
+			continue
+		}
+		units := []rune{r}
+		if high, low := utf16.EncodeRune(r); high != unicode.ReplacementChar {
+			units = []rune{high, low}
+		}
+		for _, unit := range units {
+			escaped = fmt.Appendf(escaped, `\u%04x`, unit)
+		}
+	}
+	return escaped
+}
+
+// encodeMigrated serializes a migrated record in format. A record that
+// round-trips through its Go type without losing anything is written in the
+// field order timbers itself writes; anything else (unknown fields, say)
+// keeps every key, in sorted order.
+func encodeMigrated(record map[string]any, format recordFormat) ([]byte, error) {
+	loose, err := format.encode(record)
+	if err != nil {
+		return nil, fmt.Errorf("encoding migrated record: %w", err)
+	}
+	if canonical, ok := canonicalRecord(loose, record["kind"], format); ok {
+		return canonical, nil
+	}
+	return loose, nil
+}
+
+// canonicalRecord re-encodes data through the Go type for kind, in format.
+// Reports false when the type is unknown or the round trip would change the
+// record.
+func canonicalRecord(data []byte, kind any, format recordFormat) ([]byte, bool) {
+	typed := recordType(kind)
+	if typed == nil || json.Unmarshal(data, typed) != nil {
+		return nil, false
+	}
+	canonical, err := format.encode(typed)
+	if err != nil {
+		return nil, false
+	}
+	var want, got map[string]any
+	if json.Unmarshal(data, &want) != nil || json.Unmarshal(canonical, &got) != nil || !reflect.DeepEqual(want, got) {
+		return nil, false
+	}
+	return canonical, true
+}
+
+// recordType returns a new value of the Go type for a record's kind, or nil
+// for a kind timbers does not know.
+func recordType(kind any) any {
+	switch kind, _ := kind.(string); {
+	case kind == KindAck:
+		return &Ack{}
+	case kind == KindTombstone:
+		return &Tombstone{}
+	case kind == "" || IsEntryKind(kind):
+		return &Entry{}
+	default:
+		return nil
+	}
+}
+
+// diffRecords lists the fields that differ between two versions of a
+// record, one line per leaf field, sorted by path: "~ path: old -> new" for
+// a changed value, "- path: old" for a removed one, "+ path: new" for an
+// added one.
+func diffRecords(before, after []byte) ([]string, error) {
+	var old, updated any
+	if err := errors.Join(json.Unmarshal(before, &old), json.Unmarshal(after, &updated)); err != nil {
+		return nil, fmt.Errorf("parsing record JSON: %w", err)
+	}
+	oldFields, newFields := map[string]string{}, map[string]string{}
+	flattenRecord("", old, oldFields)
+	flattenRecord("", updated, newFields)
+
+	paths := make([]string, 0, len(oldFields)+len(newFields))
+	for path := range oldFields {
+		paths = append(paths, path)
+	}
+	for path := range newFields {
+		if _, ok := oldFields[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	var lines []string
+	for _, path := range paths {
+		oldValue, hadOld := oldFields[path]
+		newValue, hasNew := newFields[path]
+		switch {
+		case !hasNew:
+			lines = append(lines, "- "+path+": "+oldValue)
+		case !hadOld:
+			lines = append(lines, "+ "+path+": "+newValue)
+		case oldValue != newValue:
+			lines = append(lines, "~ "+path+": "+oldValue+" -> "+newValue)
+		}
+	}
+	return lines, nil
+}
+
+// flattenRecord adds each leaf of value to fields, keyed by its path
+// ("decision.status", "relations[0].id") and rendered as JSON.
+func flattenRecord(path string, value any, fields map[string]string) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, child := range value {
+			if path != "" {
+				key = path + "." + key
+			}
+			flattenRecord(key, child, fields)
+		}
+	case []any:
+		for i, child := range value {
+			flattenRecord(path+"["+strconv.Itoa(i)+"]", child, fields)
+		}
+	default:
+		encoded, _ := json.Marshal(value)
+		fields[path] = string(encoded)
+	}
+}
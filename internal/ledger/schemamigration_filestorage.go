@@ -0,0 +1,192 @@
+// Package ledger — applying schema migrations to the ledger directory.
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// SchemaBackupDir is where MigrateSchema backs up the ledger directory before
+// rewriting it, relative to the git directory: one timestamped copy per run,
+// outside .timbers/ so it is never committed.
+const SchemaBackupDir = "timbers/backups"
+
+// SchemaBackupPath returns the backup directory for a migration run at at,
+// under gitDir.
+func SchemaBackupPath(gitDir string, at time.Time) string {
+	return filepath.Join(gitDir, filepath.FromSlash(SchemaBackupDir), at.UTC().Format("20060102T150405Z"))
+}
+
+// SchemaChange is one ledger file a schema migration rewrites.
+type SchemaChange struct {
+	Path string   // file path
+	ID   string   // record ID
+	From string   // schema version before the migration
+	Diff []string // changed fields; see diffRecords
+
+	data []byte
+}
+
+// PlanSchemaMigration migrates every entry, ack, and tombstone in memory to
+// version target and returns the files that would change, sorted by path, each
+// with its field-level diff. Each migrated record is validated (against
+// EntrySchema too in strict mode), so a dry run surfaces the same errors as
+// MigrateSchema. Nothing is written.
+func (fs *FileStorage) PlanSchemaMigration(target string) ([]SchemaChange, error) {
+	var changes []SchemaChange
+	walkErr := fs.walkFiles(func(path, name string) error {
+		if !strings.HasSuffix(name, ".json") || strings.HasPrefix(name, ".") {
+			return nil
+		}
+		change, changed, err := fs.planSchemaChange(path, target)
+		if err != nil {
+			return output.NewUserError("migrating " + name + ": " + err.Error())
+		}
+		if changed {
+			changes = append(changes, change)
+		}
+		return nil
+	})
+	if err := relinkWalkError(walkErr); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// planSchemaChange migrates the file at path in memory. Files that are not
+// timbers records are left alone.
+func (fs *FileStorage) planSchemaChange(path, target string) (SchemaChange, bool, error) {
+	data, err := fs.readFile(path)
+	if err != nil {
+		return SchemaChange{}, false, fmt.Errorf("reading file: %w", err)
+	}
+	migrated, changed, err := MigrateRecord(data, target)
+	if errors.Is(err, ErrNotTimbersNote) || (err == nil && !changed) {
+		return SchemaChange{}, false, nil
+	}
+	if err != nil {
+		return SchemaChange{}, false, err
+	}
+	if invalid := fs.validateMigrated(data, migrated); invalid != nil {
+		return SchemaChange{}, false, invalid
+	}
+	diff, err := diffRecords(data, migrated)
+	if err != nil {
+		return SchemaChange{}, false, err
+	}
+	var header struct {
+		Schema string `json:"schema"`
+		ID     string `json:"id"`
+	}
+	_ = json.Unmarshal(data, &header) // MigrateRecord already parsed it
+	return SchemaChange{Path: path, ID: header.ID, From: SchemaVersionOf(header.Schema), Diff: diff, data: migrated}, true, nil
+}
+
+// validateMigrated checks that a migrated entry or ack is still valid under
+// the same ID, and in strict mode that a migrated entry matches EntrySchema.
+func (fs *FileStorage) validateMigrated(before, after []byte) error {
+	if err := validateRelink(before, after); err != nil {
+		return err
+	}
+	if _, err := FromJSON(after); err != nil || !fs.strict {
+		return nil //nolint:nilerr // acks and tombstones have no schema to check
+	}
+	return ValidateSchema(after)
+}
+
+// MigrateSchema migrates the ledger to version target: it plans the migration,
+// copies the whole ledger directory to backupDir, rewrites every changed
+// file atomically, and stages and commits them in one commit. A ledger
+// already at target is left untouched and nothing is backed up. Returns the
+// files that changed.
+func (fs *FileStorage) MigrateSchema(target, backupDir string) ([]SchemaChange, error) {
+	if err := fs.checkWritable("migrate the ledger schema"); err != nil {
+		return nil, err
+	}
+	unlock, err := fs.lock("migrate the ledger schema")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return fs.migrateSchema(target, backupDir)
+}
+
+// migrateSchema is MigrateSchema once the ledger lock is held.
+func (fs *FileStorage) migrateSchema(target, backupDir string) ([]SchemaChange, error) {
+	changes, err := fs.PlanSchemaMigration(target)
+	if err != nil || len(changes) == 0 {
+		return changes, err
+	}
+	if err := copyTree(fs.dir, backupDir); err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to back up the ledger to "+backupDir, err)
+	}
+
+	files := make([]relinkedFile, len(changes))
+	paths := make([]string, len(changes))
+	for i, change := range changes {
+		files[i] = relinkedFile{path: change.Path, data: change.data}
+		paths[i] = change.Path
+	}
+	if err := writeLedgerFiles(files); err != nil {
+		return nil, err
+	}
+	fs.recordChecksums(relinkedPaths(files))
+	for _, path := range paths {
+		if err := fs.gitAdd(path); err != nil {
+			return changes, output.NewSystemErrorWithCause("failed to stage migrated ledger file", err)
+		}
+	}
+	if err := fs.commitPaths(paths, "timbers: migrate ledger to "+schemaFamily+target); err != nil {
+		return changes, output.NewSystemErrorWithCause("failed to commit migrated ledger files", err)
+	}
+	return changes, nil
+}
+
+// copyTree copies every file under src to the same relative path under dst.
+func copyTree(src, dst string) error {
+	//nolint:wrapcheck // the caller wraps the error
+	return filepath.WalkDir(src, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0o750)
+		}
+		data, err := os.ReadFile(path) //nolint:gosec // path comes from walking the ledger directory
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o600)
+	})
+}
+
+// PlanSchemaMigration reports the ledger files a migration to version
+// target would change; see FileStorage.PlanSchemaMigration. Returns nil if file
+// storage is not configured.
+func (s *Storage) PlanSchemaMigration(target string) ([]SchemaChange, error) {
+	if s.files == nil {
+		return nil, nil
+	}
+	return s.files.PlanSchemaMigration(target)
+}
+
+// MigrateSchema migrates the ledger to version target; see
+// FileStorage.MigrateSchema. Returns nil if file storage is not configured.
+func (s *Storage) MigrateSchema(target, backupDir string) ([]SchemaChange, error) {
+	if s.files == nil {
+		return nil, nil
+	}
+	return s.files.MigrateSchema(target, backupDir)
+}
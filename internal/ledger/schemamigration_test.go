@@ -0,0 +1,165 @@
+package ledger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// makeV1Decision returns a timbers.devlog/v1 decision that supersedes
+// supersededID through the old decision field, as 'timbers decide
+// --supersedes' wrote them before v2 moved the link into relations.
+func makeV1Decision(anchor, supersededID string) *Entry {
+	entry := makeTestEntry(anchor, time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	entry.Schema = "timbers.devlog/v1"
+	entry.Kind = KindDecision
+	entry.Decision = &Decision{Status: DecisionAccepted, Alternatives: []string{"SQLite"}, Supersedes: supersededID}
+	entry.Relations = []Relation{{Type: RelationRelatesTo, ID: "tb_2026-01-02T10:00:00Z_bbbbbb"}}
+	return entry
+}
+
+func TestMigrateRecordV1ToV2(t *testing.T) {
+	v1, err := makeV1Decision("dec0001", "tb_2026-01-01T10:00:00Z_aaaaaa").ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrated, changed, err := MigrateRecord(v1, "v2")
+	if err != nil || !changed {
+		t.Fatalf("MigrateRecord = changed %v, %v", changed, err)
+	}
+	entry, err := FromJSON(migrated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Schema != "timbers.devlog/v2" || entry.Decision.Supersedes != "" || entry.Decision.Status != DecisionAccepted {
+		t.Errorf("migrated entry = schema %s, decision %+v", entry.Schema, entry.Decision)
+	}
+	want := []Relation{
+		{Type: RelationSupersedes, ID: "tb_2026-01-01T10:00:00Z_aaaaaa"},
+		{Type: RelationRelatesTo, ID: "tb_2026-01-02T10:00:00Z_bbbbbb"},
+	}
+	if !slices.Equal(entry.Relations, want) || entry.SupersedesID() != want[0].ID {
+		t.Errorf("relations = %v, want %v", entry.Relations, want)
+	}
+	// Canonical field order survives: schema is still written first.
+	if !strings.HasPrefix(string(migrated), `{"schema":"timbers.devlog/v2","kind":"decision"`) {
+		t.Errorf("migrated JSON lost field order: %s", migrated)
+	}
+
+	if _, changed, err := MigrateRecord(migrated, "v2"); changed || err != nil {
+		t.Errorf("re-migrating = changed %v, %v; want a no-op", changed, err)
+	}
+	if _, _, err := MigrateRecord(migrated, "v1"); err == nil {
+		t.Error("migrating v2 back to v1 expected an error")
+	}
+}
+
+func TestMigrateRecordKeepsUnknownFields(t *testing.T) {
+	data := []byte(`{"schema":"timbers.devlog/v1","kind":"ack","id":"ack_x","custom":"kept"}`)
+	migrated, changed, err := MigrateRecord(data, "v2")
+	if err != nil || !changed {
+		t.Fatalf("MigrateRecord = changed %v, %v", changed, err)
+	}
+	var record map[string]any
+	if err := json.Unmarshal(migrated, &record); err != nil {
+		t.Fatal(err)
+	}
+	if record["custom"] != "kept" || record["schema"] != "timbers.devlog/v2" {
+		t.Errorf("migrated record = %v", record)
+	}
+}
+
+func TestMigrateRecordKeepsFileLayout(t *testing.T) {
+	entry := makeTestEntry("fmt0001", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	entry.Schema = "timbers.devlog/v1"
+	entry.Summary.Why = "Broad discovery \u2014 compose <AND> & <OR> later"
+	// An indented, ASCII-only file with raw <, >, and &, ending in a newline.
+	var buf strings.Builder
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(entry); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte(strings.Replace(buf.String(), "\u2014", `\u2014`, 1))
+
+	migrated, changed, err := MigrateRecord(data, "v2")
+	if err != nil || !changed {
+		t.Fatalf("MigrateRecord = changed %v, %v", changed, err)
+	}
+	want := strings.Replace(string(data), `"timbers.devlog/v1"`, `"timbers.devlog/v2"`, 1)
+	if string(migrated) != want {
+		t.Errorf("migrated file changed more than the schema:\n got %s\nwant %s", migrated, want)
+	}
+}
+
+func TestParseSchemaVersion(t *testing.T) {
+	for name, want := range map[string]string{"": "v2", "v1": "v1", "timbers.devlog/v2": "v2"} {
+		if got, err := ParseSchemaVersion(name); got != want || err != nil {
+			t.Errorf("ParseSchemaVersion(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseSchemaVersion("v9"); err == nil {
+		t.Error("ParseSchemaVersion(v9) expected an error")
+	}
+	if versions := SchemaVersions(); versions[len(versions)-1] != SchemaVersionOf(SchemaVersion) {
+		t.Errorf("latest migration ends at %s, SchemaVersion is %s", versions[len(versions)-1], SchemaVersion)
+	}
+}
+
+func TestMigrateSchema(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStorage(dir, noopGitAdd, noopGitCommit)
+	decision := makeV1Decision("dec0001", "tb_2026-01-01T10:00:00Z_aaaaaa")
+	current := makeTestEntry("cur0001", time.Date(2026, 1, 16, 10, 0, 0, 0, time.UTC))
+	for _, entry := range []*Entry{decision, current} {
+		if err := store.WriteEntry(entry, false); err != nil {
+			t.Fatalf("WriteEntry: %v", err)
+		}
+	}
+	path, _ := store.existingEntryPath(decision.ID)
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := store.PlanSchemaMigration("v2")
+	if err != nil {
+		t.Fatalf("PlanSchemaMigration: %v", err)
+	}
+	if len(plan) != 1 || plan[0].ID != decision.ID || plan[0].From != "v1" {
+		t.Fatalf("plan = %+v, want only the v1 decision", plan)
+	}
+	for _, line := range []string{
+		`~ schema: "timbers.devlog/v1" -> "timbers.devlog/v2"`,
+		`- decision.supersedes: "tb_2026-01-01T10:00:00Z_aaaaaa"`,
+		`~ relations[0].type: "relates-to" -> "supersedes"`,
+		`+ relations[1].id: "tb_2026-01-02T10:00:00Z_bbbbbb"`,
+	} {
+		if !slices.Contains(plan[0].Diff, line) {
+			t.Errorf("diff missing %q:\n%s", line, strings.Join(plan[0].Diff, "\n"))
+		}
+	}
+
+	backupDir := filepath.Join(t.TempDir(), "backup")
+	if _, migrateErr := store.MigrateSchema("v2", backupDir); migrateErr != nil {
+		t.Fatalf("MigrateSchema: %v", migrateErr)
+	}
+	migrated, err := store.ReadEntry(decision.ID)
+	if err != nil || migrated.Schema != SchemaVersion || migrated.SupersedesID() != "tb_2026-01-01T10:00:00Z_aaaaaa" {
+		t.Errorf("after migrate: %+v, %v", migrated, err)
+	}
+	rel, _ := filepath.Rel(dir, path)
+	if backup, readErr := os.ReadFile(filepath.Join(backupDir, rel)); readErr != nil || string(backup) != string(original) {
+		t.Errorf("backup = %q, %v; want the original file", backup, readErr)
+	}
+
+	if again, err := store.PlanSchemaMigration("v2"); len(again) != 0 || err != nil {
+		t.Errorf("second plan = %+v, %v; want nothing to migrate", again, err)
+	}
+}
@@ -0,0 +1,125 @@
+// Package ledger — encryption at rest for entry summaries.
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// EncryptedPlaceholder stands in for the summary of an entry that could not
+// be decrypted.
+const EncryptedPlaceholder = "(encrypted)"
+
+// Sealer encrypts and decrypts entry payloads. internal/age implements it
+// with the age CLI.
+type Sealer interface {
+	Seal(plaintext []byte) (string, error)
+	Open(ciphertext string) ([]byte, error)
+}
+
+// sealedPayload is the part of an entry that is encrypted. Everything else
+// (ID, workset, tags, relations) stays in cleartext so listing, pending
+// detection, and indexing work without keys. Revisions are sealed too, since
+// they carry earlier summaries.
+type sealedPayload struct {
+	Summary   Summary    `json:"summary"`
+	Notes     string     `json:"notes,omitempty"`
+	Revisions []Revision `json:"revisions,omitempty"`
+}
+
+// SetSealer sets how encrypted entries are sealed on write and opened on
+// read. Without one, encrypted entries read as EncryptedPlaceholder and
+// cannot be written.
+func (fs *FileStorage) SetSealer(sealer Sealer) {
+	fs.sealer = sealer
+}
+
+// Encrypt marks the entry to be written with its summary and notes sealed.
+func (e *Entry) Encrypt() {
+	e.encrypt = true
+}
+
+// IsEncrypted reports whether the entry is, or will be, stored encrypted.
+func (e *Entry) IsEncrypted() bool {
+	return e.encrypt || e.Encrypted != ""
+}
+
+// IsSealed reports whether the entry is stored encrypted and could not be
+// decrypted, so its summary and notes are unavailable.
+func (e *Entry) IsSealed() bool {
+	return e.sealed
+}
+
+// SealEntry returns a copy of entry with its summary, notes, and revisions
+// encrypted into Encrypted and blanked in cleartext.
+func SealEntry(entry *Entry, sealer Sealer) (*Entry, error) {
+	plaintext, err := json.Marshal(sealedPayload{Summary: entry.Summary, Notes: entry.Notes, Revisions: entry.Revisions})
+	if err != nil {
+		return nil, fmt.Errorf("encoding payload: %w", err)
+	}
+	ciphertext, err := sealer.Seal(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	sealed := *entry
+	sealed.Summary = Summary{}
+	sealed.Notes = ""
+	sealed.Revisions = nil
+	sealed.Encrypted = ciphertext
+	return &sealed, nil
+}
+
+// openEntry decrypts an entry read from disk in place. On success the entry
+// looks like any other but stays marked for encryption, so rewriting it
+// (amend, link) seals it again. Without a sealer, or when decryption fails,
+// the entry is marked sealed and its summary shows EncryptedPlaceholder.
+func (fs *FileStorage) openEntry(entry *Entry) {
+	if entry.Encrypted == "" {
+		return
+	}
+	if fs.sealer != nil {
+		if plaintext, err := fs.sealer.Open(entry.Encrypted); err == nil {
+			var payload sealedPayload
+			if json.Unmarshal(plaintext, &payload) == nil {
+				entry.Summary = payload.Summary
+				entry.Notes = payload.Notes
+				// Revisions recorded while the entry could not be opened
+				// are in cleartext; they follow the sealed ones.
+				entry.Revisions = append(payload.Revisions, entry.Revisions...)
+				entry.Encrypted = ""
+				entry.encrypt = true
+				return
+			}
+		}
+	}
+	entry.sealed = true
+	entry.Summary = Summary{What: EncryptedPlaceholder}
+}
+
+// sealForWrite returns the form of entry to serialize. Entries that could
+// not be opened keep their original ciphertext, plus any revisions made
+// since in cleartext (amend refuses summary edits on them, so those never
+// hold sealed text); entries marked for encryption are sealed with the
+// configured sealer.
+func (fs *FileStorage) sealForWrite(entry *Entry) (*Entry, error) {
+	if entry.sealed {
+		kept := *entry
+		kept.Summary = Summary{}
+		kept.Notes = ""
+		return &kept, nil
+	}
+	if !entry.encrypt {
+		return entry, nil
+	}
+	if fs.sealer == nil {
+		return nil, output.NewUserError("entry " + entry.ID + " is encrypted but no recipients are configured; set [encryption] recipients in " +
+			".timbers/config.toml")
+	}
+	sealed, err := SealEntry(entry, fs.sealer)
+	if err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to encrypt entry "+entry.ID, err)
+	}
+	return sealed, nil
+}
@@ -0,0 +1,125 @@
+package ledger
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSealer "encrypts" by hex-encoding, and fails to open without its key.
+type fakeSealer struct{ canOpen bool }
+
+func (s fakeSealer) Seal(plaintext []byte) (string, error) {
+	return "SEALED:" + hex.EncodeToString(plaintext), nil
+}
+
+func (s fakeSealer) Open(ciphertext string) ([]byte, error) {
+	if !s.canOpen {
+		return nil, errors.New("no identity")
+	}
+	plaintext, err := hex.DecodeString(strings.TrimPrefix(ciphertext, "SEALED:"))
+	if err != nil {
+		return nil, fmt.Errorf("decoding: %w", err)
+	}
+	return plaintext, nil
+}
+
+// writeEncryptedEntry writes an encrypted entry and returns the storage and
+// the file's contents.
+func writeEncryptedEntry(t *testing.T) (*FileStorage, *Entry, string) {
+	t.Helper()
+	store := NewFileStorage(t.TempDir(), noopGitAdd, noopGitCommit)
+	store.SetStrict(true)
+	store.SetSealer(fakeSealer{canOpen: true})
+	entry := makeTestEntry("seal001", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	entry.Notes = "private notes"
+	entry.Tags = []string{"security"}
+	entry.Revisions = []Revision{{
+		RevisedAt: entry.CreatedAt,
+		Changes:   []FieldChange{{Field: "summary.why", Before: "earlier reasoning", After: "test why"}},
+	}}
+	entry.Encrypt()
+	if err := store.WriteEntry(entry, false); err != nil {
+		t.Fatalf("WriteEntry: %v", err)
+	}
+	data, err := os.ReadFile(store.entryPath(entry.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store, entry, string(data)
+}
+
+func TestEncryptedEntryRoundTrip(t *testing.T) {
+	store, entry, raw := writeEncryptedEntry(t)
+
+	if strings.Contains(raw, "test what") || strings.Contains(raw, "private notes") || strings.Contains(raw, "earlier reasoning") {
+		t.Errorf("summary, notes, or revisions written in cleartext:\n%s", raw)
+	}
+	for _, want := range []string{`"encrypted":"SEALED:`, entry.ID, `"security"`} {
+		if !strings.Contains(raw, want) {
+			t.Errorf("file missing %s:\n%s", want, raw)
+		}
+	}
+
+	got, err := store.ReadEntry(entry.ID)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if got.Summary != entry.Summary || got.Notes != "private notes" || got.Encrypted != "" || len(got.Revisions) != 1 {
+		t.Errorf("decrypted entry = %+v", got)
+	}
+	if !got.IsEncrypted() || got.IsSealed() {
+		t.Errorf("IsEncrypted = %v, IsSealed = %v; want true, false", got.IsEncrypted(), got.IsSealed())
+	}
+
+	// Rewriting a decrypted entry seals it again.
+	if err = store.WriteEntry(got, true); err != nil {
+		t.Fatalf("rewrite: %v", err)
+	}
+	data, _ := os.ReadFile(store.entryPath(entry.ID))
+	if strings.Contains(string(data), "test what") {
+		t.Errorf("rewrite stored the summary in cleartext:\n%s", data)
+	}
+}
+
+func TestEncryptedEntryWithoutKey(t *testing.T) {
+	store, entry, raw := writeEncryptedEntry(t)
+	store.SetSealer(fakeSealer{})
+
+	got, err := store.ReadEntry(entry.ID)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if !got.IsSealed() || got.Summary.What != EncryptedPlaceholder {
+		t.Errorf("entry without key: sealed = %v, what = %q", got.IsSealed(), got.Summary.What)
+	}
+
+	// Rewriting keeps the original ciphertext.
+	got.Tags = append(got.Tags, "reviewed")
+	if err = store.WriteEntry(got, true); err != nil {
+		t.Fatalf("rewrite: %v", err)
+	}
+	data, _ := os.ReadFile(store.entryPath(entry.ID))
+	before, _ := FromJSON([]byte(raw))
+	after, err := FromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Encrypted != before.Encrypted || len(after.Tags) != 2 {
+		t.Errorf("rewrite lost the ciphertext or the tag:\n%s", data)
+	}
+}
+
+func TestEncryptRequiresRecipients(t *testing.T) {
+	store := NewFileStorage(t.TempDir(), noopGitAdd, noopGitCommit)
+	entry := makeTestEntry("seal002", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	entry.Encrypt()
+	err := store.WriteEntry(entry, false)
+	if err == nil || !strings.Contains(err.Error(), "recipients") {
+		t.Errorf("WriteEntry without a sealer = %v, want a recipients error", err)
+	}
+}
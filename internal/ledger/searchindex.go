@@ -0,0 +1,226 @@
+// Package ledger — inverted index for keyword search.
+package ledger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// SearchIndexFile is where the search index lives, relative to the git
+// directory. It is a local, rebuildable cache (see Refresh and timbers fsck
+// --reindex), never committed.
+const SearchIndexFile = "timbers/search.db"
+
+// searchIndexVersion changes whenever tokenizing or weighting does; an index
+// with another version is rebuilt.
+const searchIndexVersion = 1
+
+// SearchIndex is an inverted index over the fields RankEntries scores: each
+// term maps to the entries containing it and its field-weighted count there.
+// Ranking with it gives the same scores as RankEntries without tokenizing
+// every entry on every search.
+type SearchIndex struct {
+	Version  int
+	Docs     map[string]indexedDoc
+	Postings map[string]map[string]float64
+}
+
+// indexedDoc records the hash of the text an entry was indexed from, so an
+// amended entry is re-indexed, and its terms, so its postings can be removed.
+type indexedDoc struct {
+	Hash  string
+	Terms []string
+}
+
+// SetSearchIndex sets where the search index is cached (see SearchIndexFile);
+// "" leaves search to build it in memory.
+func (fs *FileStorage) SetSearchIndex(path string) {
+	fs.searchPath = path
+}
+
+// SearchIndexPath returns where the search index is cached, or "" when the
+// storage has no cache location (injected or tree storage).
+func (s *Storage) SearchIndexPath() string {
+	if s.files == nil {
+		return ""
+	}
+	return s.files.searchPath
+}
+
+// NewSearchIndex returns an empty search index.
+func NewSearchIndex() *SearchIndex {
+	return &SearchIndex{
+		Version:  searchIndexVersion,
+		Docs:     make(map[string]indexedDoc),
+		Postings: make(map[string]map[string]float64),
+	}
+}
+
+// LoadSearchIndex reads the index at path. A missing, corrupt, or outdated
+// file yields an empty index, to be filled by Refresh.
+func LoadSearchIndex(path string) (*SearchIndex, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return NewSearchIndex(), nil
+		}
+		return nil, fmt.Errorf("reading search index: %w", err)
+	}
+	var idx SearchIndex
+	if gob.NewDecoder(bytes.NewReader(data)).Decode(&idx) != nil ||
+		idx.Version != searchIndexVersion || idx.Docs == nil || idx.Postings == nil {
+		return NewSearchIndex(), nil //nolint:nilerr // a corrupt or stale cache is rebuilt, not reported
+	}
+	return &idx, nil
+}
+
+// Save writes the index to path atomically, creating its directory.
+func (idx *SearchIndex) Save(path string) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(idx); err != nil {
+		return fmt.Errorf("encoding search index: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating search index directory: %w", err)
+	}
+	if err := atomicWrite(path, buf.Bytes()); err != nil {
+		return fmt.Errorf("writing search index: %w", err)
+	}
+	return nil
+}
+
+// Refresh brings the index up to date with entries: new and amended entries
+// are (re)indexed and entries no longer in the ledger are dropped. Encrypted
+// entries are never indexed, so their text is not written to disk in the
+// clear; Rank scores them in memory instead. Returns how many entries
+// changed, so callers can skip saving an unchanged index.
+func (idx *SearchIndex) Refresh(entries []*Entry) int {
+	changed := 0
+	live := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if entry.IsEncrypted() {
+			continue
+		}
+		live[entry.ID] = true
+		hash := searchTextHash(entry)
+		if doc, ok := idx.Docs[entry.ID]; ok && doc.Hash == hash {
+			continue
+		}
+		idx.remove(entry.ID)
+		idx.add(entry, hash)
+		changed++
+	}
+	for id := range idx.Docs {
+		if !live[id] {
+			idx.remove(id)
+			changed++
+		}
+	}
+	return changed
+}
+
+// Rank scores entries against query from the index, exactly as RankEntries
+// would, and returns up to limit matches, most relevant first. Only entries
+// in the slice are considered, and document frequencies count only them, so
+// callers can filter (by kind, say) before ranking. Encrypted entries are
+// tokenized here, since Refresh leaves them out; other entries missing from
+// the index never match: Refresh first.
+func (idx *SearchIndex) Rank(entries []*Entry, query string, limit int) []ScoredEntry {
+	terms := QueryTerms(query)
+	if len(terms) == 0 || len(entries) == 0 {
+		return nil
+	}
+	byID := make(map[string]*Entry, len(entries))
+	unindexed := make(map[string]map[string]float64)
+	for _, entry := range entries {
+		byID[entry.ID] = entry
+		if entry.IsEncrypted() {
+			unindexed[entry.ID] = weightedTerms(entry)
+		}
+	}
+
+	scores := make(map[string]float64)
+	for _, term := range terms {
+		idx.addTermScores(scores, term, byID, unindexed, len(entries))
+	}
+
+	scored := make([]ScoredEntry, 0, len(scores))
+	for _, entry := range entries {
+		if score := scores[entry.ID]; score > 0 {
+			scored = append(scored, ScoredEntry{Entry: entry, Score: score})
+		}
+	}
+	return topScored(scored, limit)
+}
+
+// addTermScores adds term's tf-idf contribution for each entry in byID to
+// scores, counting document frequency over byID only; unindexed holds the
+// term counts of ranked entries kept out of the index, and total is the
+// number of entries being ranked.
+func (idx *SearchIndex) addTermScores(
+	scores map[string]float64, term string, byID map[string]*Entry, unindexed map[string]map[string]float64, total int,
+) {
+	matches := make(map[string]float64)
+	for id, tf := range idx.Postings[term] {
+		if byID[id] != nil {
+			matches[id] = tf
+		}
+	}
+	for id, counts := range unindexed {
+		if tf := counts[term]; tf > 0 {
+			matches[id] = tf
+		}
+	}
+	for id, tf := range matches {
+		scores[id] += tf * math.Log(1+float64(total)/float64(len(matches)))
+	}
+}
+
+// add indexes entry, whose searchable text hashes to hash.
+func (idx *SearchIndex) add(entry *Entry, hash string) {
+	counts := weightedTerms(entry)
+	terms := make([]string, 0, len(counts))
+	for term, count := range counts {
+		postings := idx.Postings[term]
+		if postings == nil {
+			postings = make(map[string]float64)
+			idx.Postings[term] = postings
+		}
+		postings[entry.ID] = count
+		terms = append(terms, term)
+	}
+	idx.Docs[entry.ID] = indexedDoc{Hash: hash, Terms: terms}
+}
+
+// remove drops every posting for the entry id.
+func (idx *SearchIndex) remove(id string) {
+	doc, ok := idx.Docs[id]
+	if !ok {
+		return
+	}
+	for _, term := range doc.Terms {
+		delete(idx.Postings[term], id)
+		if len(idx.Postings[term]) == 0 {
+			delete(idx.Postings, term)
+		}
+	}
+	delete(idx.Docs, id)
+}
+
+// searchTextHash hashes the fields search scores, so edits elsewhere in an
+// entry (its workset, say) do not force re-indexing.
+func searchTextHash(entry *Entry) string {
+	sum := sha256.New()
+	for _, field := range fieldWeights {
+		sum.Write([]byte(field.text(entry)))
+		sum.Write([]byte{0})
+	}
+	return hex.EncodeToString(sum.Sum(nil))
+}
@@ -0,0 +1,105 @@
+package ledger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func searchIndexTestEntries() []*Entry {
+	stamp := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	auth := makeTestEntry("aaa111", stamp)
+	auth.Summary.What = "Rotate JWT refresh tokens"
+	auth.Summary.Why = "Stolen refresh tokens stayed valid forever"
+	auth.Tags = []string{"auth", "security"}
+	cache := makeTestEntry("bbb222", stamp.Add(time.Hour))
+	cache.Summary.What = "Cache pending results"
+	cache.Notes = "Considered caching tokens too"
+	unrelated := makeTestEntry("ccc333", stamp.Add(2*time.Hour))
+	unrelated.Summary.What = "Bump dependencies"
+	return []*Entry{auth, cache, unrelated}
+}
+
+func TestSearchIndexRanksLikeRankEntries(t *testing.T) {
+	entries := searchIndexTestEntries()
+	idx := NewSearchIndex()
+	if changed := idx.Refresh(entries); changed != 3 {
+		t.Fatalf("Refresh() = %d, want 3 new entries", changed)
+	}
+
+	for _, query := range []string{"refresh tokens", "security cache", "dependencies", "why is it"} {
+		if got, want := idx.Rank(entries, query, 0), RankEntries(entries, query, 0); !reflect.DeepEqual(got, want) {
+			t.Errorf("Rank(%q) = %+v, want RankEntries' %+v", query, got, want)
+		}
+	}
+	subset := entries[1:]
+	if got, want := idx.Rank(subset, "tokens", 0), RankEntries(subset, "tokens", 0); !reflect.DeepEqual(got, want) {
+		t.Errorf("Rank over a subset = %+v, want %+v", got, want)
+	}
+}
+
+func TestSearchIndexRefreshTracksLedgerChanges(t *testing.T) {
+	entries := searchIndexTestEntries()
+	path := filepath.Join(t.TempDir(), "timbers", "search.db")
+	idx := NewSearchIndex()
+	idx.Refresh(entries)
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadSearchIndex(path)
+	if err != nil {
+		t.Fatalf("LoadSearchIndex: %v", err)
+	}
+	if changed := loaded.Refresh(entries); changed != 0 {
+		t.Errorf("Refresh() after reload = %d, want 0", changed)
+	}
+
+	entries[0].Summary.What = "Expire sessions on logout"
+	entries[0].Summary.Why = "Sessions outlived logout"
+	entries[0].Tags = nil
+	if changed := loaded.Refresh(entries[:2]); changed != 2 {
+		t.Errorf("Refresh() after an amend and a removal = %d, want 2", changed)
+	}
+	ranked := loaded.Rank(entries[:2], "refresh", 0)
+	if len(ranked) != 0 {
+		t.Errorf("Rank(refresh) after amending it away = %+v, want no matches", ranked)
+	}
+	if _, ok := loaded.Postings["dependencies"]; ok {
+		t.Error("postings for the removed entry are still indexed")
+	}
+}
+
+func TestSearchIndexKeepsEncryptedEntriesOffDisk(t *testing.T) {
+	entries := searchIndexTestEntries()
+	path := filepath.Join(t.TempDir(), "timbers", "search.db")
+	idx := NewSearchIndex()
+	idx.Refresh(entries)
+
+	// Encrypting an indexed entry drops it on the next refresh.
+	entries[0].Encrypt()
+	if changed := idx.Refresh(entries); changed != 1 {
+		t.Errorf("Refresh() after encrypting an entry = %d, want 1", changed)
+	}
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, term := range []string{"rotate", "jwt", "stolen", "forever", "security"} {
+		if bytes.Contains(data, []byte(term)) {
+			t.Errorf("saved index contains %q from the encrypted entry", term)
+		}
+	}
+
+	for _, query := range []string{"refresh tokens", "security"} {
+		if got, want := idx.Rank(entries, query, 0), RankEntries(entries, query, 0); !reflect.DeepEqual(got, want) {
+			t.Errorf("Rank(%q) = %+v, want RankEntries' %+v", query, got, want)
+		}
+	}
+}
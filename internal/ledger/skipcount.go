@@ -60,17 +60,17 @@ func (s *Storage) commitsSinceLatest() ([]git.Commit, bool, error) {
 		return nil, false, err
 	}
 
-	head, err := s.git.HEAD()
+	head, err := s.git.HEAD(s.context())
 	if err != nil {
 		return nil, false, err
 	}
 
 	anchor := latest.Workset.AnchorCommit
-	if !s.git.IsAncestorOf(anchor, head) {
+	if !s.git.IsAncestorOf(s.context(), anchor, head) {
 		return nil, false, nil
 	}
 
-	commits, err := s.git.Log(anchor, head)
+	commits, err := s.git.Log(s.context(), anchor, head)
 	if err != nil {
 		return nil, false, nil //nolint:nilerr // stale-anchor counts are best-effort
 	}
@@ -87,7 +87,7 @@ func (s *Storage) countAutoSkipped(commits []git.Commit) int {
 	if len(commits) == 0 {
 		return 0
 	}
-	fileMap, err := s.git.CommitFilesMulti(commitSHAs(commits))
+	fileMap, err := s.commitFilesMulti(commits)
 	if err != nil {
 		return 0
 	}
@@ -321,7 +321,7 @@ func (s *Storage) filterCommits(commits []git.Commit, docSet, ackedSet map[strin
 	if len(commits) == 0 {
 		return commits
 	}
-	fileMap, err := s.git.CommitFilesMulti(commitSHAs(commits))
+	fileMap, err := s.commitFilesMulti(commits)
 	if err != nil {
 		return commits
 	}
@@ -0,0 +1,135 @@
+// Package ledger — splitting an entry into several.
+package ledger
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"time"
+)
+
+// SplitPart is one entry to split out of another: the commits it covers and
+// its own summary.
+type SplitPart struct {
+	Commits []string
+	Summary Summary
+	Notes   string
+}
+
+// SplitEntry divides entry into one new entry per part, created at
+// createdAt. Every commit of entry's workset must be in exactly one part.
+// Each part keeps its commits in the workset's order and is anchored at the
+// first of them; the kind, tags, work items, contributors, meta, relations,
+// and decision are copied from entry. File lists and diffstats are left for
+// callers to recompute from git, and the parts have no IDs yet.
+//
+// A sealed entry cannot be split, since its summary would be lost; the parts
+// of an encrypted entry are encrypted.
+func SplitEntry(entry *Entry, parts []SplitPart, createdAt time.Time) ([]*Entry, error) {
+	if entry.IsSealed() {
+		return nil, errors.New(entry.ID + " is encrypted and could not be decrypted")
+	}
+	if len(parts) < 2 {
+		return nil, errors.New("at least two parts are needed to split")
+	}
+	if err := checkSplitCommits(entry.Workset.Commits, parts); err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, len(parts))
+	for i, part := range parts {
+		split := &Entry{
+			Schema:       SchemaVersion,
+			Kind:         entry.Kind,
+			CreatedAt:    createdAt,
+			UpdatedAt:    createdAt,
+			Workset:      splitWorkset(entry.Workset, part.Commits),
+			Summary:      part.Summary,
+			Notes:        part.Notes,
+			WorkItems:    slices.Clone(entry.WorkItems),
+			Tags:         slices.Clone(entry.Tags),
+			Contributors: slices.Clone(entry.Contributors),
+			Relations:    slices.Clone(entry.Relations),
+			Decision:     entry.Decision,
+		}
+		if len(entry.Meta) > 0 {
+			split.Meta = maps.Clone(entry.Meta)
+		}
+		if entry.IsEncrypted() {
+			split.Encrypt()
+		}
+		entries[i] = split
+	}
+	return entries, nil
+}
+
+// checkSplitCommits reports an error unless every one of commits is in
+// exactly one part, and every part has at least one.
+func checkSplitCommits(commits []string, parts []SplitPart) error {
+	seen := make(map[string]bool, len(commits))
+	for _, part := range parts {
+		if len(part.Commits) == 0 {
+			return errors.New("every part needs at least one commit")
+		}
+		for _, sha := range part.Commits {
+			if !slices.Contains(commits, sha) {
+				return errors.New("commit " + shortCommit(sha) + " is not in the entry")
+			}
+			if seen[sha] {
+				return errors.New("commit " + shortCommit(sha) + " is in more than one part")
+			}
+			seen[sha] = true
+		}
+	}
+	for _, sha := range commits {
+		if !seen[sha] {
+			return errors.New("commit " + shortCommit(sha) + " is in no part")
+		}
+	}
+	return nil
+}
+
+// splitWorkset returns the part of workset covering commits, in workset
+// order.
+func splitWorkset(workset Workset, commits []string) Workset {
+	part := Workset{Branch: workset.Branch}
+	for _, sha := range workset.Commits {
+		if slices.Contains(commits, sha) {
+			part.Commits = append(part.Commits, sha)
+		}
+	}
+	part.AnchorCommit = part.Commits[0]
+	if count := len(part.Commits); count > 1 {
+		part.Range = shortCommit(part.Commits[count-1]) + ".." + shortCommit(part.Commits[0])
+	}
+	for _, meta := range workset.CommitMeta {
+		if slices.Contains(commits, meta.SHA) {
+			part.CommitMeta = append(part.CommitMeta, meta)
+		}
+	}
+	return part
+}
+
+// FanOutRelations replaces e's relations to fromID with one of the same type
+// to each of toIDs, dropping any that would then repeat. Reports whether
+// anything changed.
+func (e *Entry) FanOutRelations(fromID string, toIDs []string) bool {
+	changed := false
+	relations := make([]Relation, 0, len(e.Relations))
+	for _, rel := range e.Relations {
+		targets := []string{rel.ID}
+		if rel.ID == fromID {
+			targets = toIDs
+			changed = true
+		}
+		for _, id := range targets {
+			if fanned := (Relation{Type: rel.Type, ID: id}); !slices.Contains(relations, fanned) {
+				relations = append(relations, fanned)
+			}
+		}
+	}
+	if changed {
+		e.Relations = relations
+	}
+	return changed
+}
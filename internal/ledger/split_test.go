@@ -0,0 +1,79 @@
+package ledger
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSplitEntry(t *testing.T) {
+	entry := makeTestEntry("ccc3333333", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	entry.Workset.Commits = []string{"ccc3333333", "bbb2222222", "aaa1111111"}
+	entry.Workset.CommitMeta = []CommitMeta{{SHA: "bbb2222222", Author: "Dev"}}
+	entry.Tags = []string{"backend"}
+	entry.Meta = map[string]string{"ticket": "T-1"}
+
+	splitAt := time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC)
+	parts, err := SplitEntry(entry, []SplitPart{
+		{Commits: []string{"aaa1111111", "ccc3333333"}, Summary: Summary{What: "Billing", Why: "y", How: "h"}},
+		{Commits: []string{"bbb2222222"}, Summary: Summary{What: "Auth", Why: "y", How: "h"}},
+	}, splitAt)
+	if err != nil {
+		t.Fatalf("SplitEntry: %v", err)
+	}
+
+	first, second := parts[0].Workset, parts[1].Workset
+	if first.AnchorCommit != "ccc3333333" || !slices.Equal(first.Commits, []string{"ccc3333333", "aaa1111111"}) ||
+		first.Range != "aaa1111..ccc3333" || len(first.CommitMeta) != 0 {
+		t.Errorf("first workset = %+v", first)
+	}
+	if second.AnchorCommit != "bbb2222222" || second.Range != "" || len(second.CommitMeta) != 1 {
+		t.Errorf("second workset = %+v", second)
+	}
+	if parts[1].Summary.What != "Auth" || !slices.Equal(parts[1].Tags, []string{"backend"}) || parts[1].Meta["ticket"] != "T-1" {
+		t.Errorf("second entry = %+v", parts[1])
+	}
+	parts[0].Meta["ticket"] = "changed"
+	if entry.Meta["ticket"] != "T-1" {
+		t.Error("parts share meta with the original")
+	}
+}
+
+func TestSplitEntryRejects(t *testing.T) {
+	entry := makeTestEntry("bbb2222222", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	entry.Workset.Commits = []string{"bbb2222222", "aaa1111111"}
+	tests := []struct {
+		name  string
+		parts []SplitPart
+	}{
+		{"one part", []SplitPart{{Commits: []string{"bbb2222222", "aaa1111111"}}}},
+		{"commit left out", []SplitPart{{Commits: []string{"bbb2222222"}}, {Commits: []string{}}}},
+		{"commit twice", []SplitPart{{Commits: []string{"bbb2222222", "aaa1111111"}}, {Commits: []string{"aaa1111111"}}}},
+		{"unknown commit", []SplitPart{{Commits: []string{"bbb2222222", "aaa1111111"}}, {Commits: []string{"fff9999999"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := SplitEntry(entry, tt.parts, time.Now()); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestFanOutRelations(t *testing.T) {
+	entry := makeTestEntry("aaa1111111", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	entry.Relations = []Relation{{Type: RelationFixes, ID: "tb_wide"}, {Type: RelationRelatesTo, ID: "tb_other"}}
+
+	if !entry.FanOutRelations("tb_wide", []string{"tb_one", "tb_two"}) {
+		t.Fatal("FanOutRelations reported no change")
+	}
+	want := []Relation{
+		{Type: RelationFixes, ID: "tb_one"}, {Type: RelationFixes, ID: "tb_two"}, {Type: RelationRelatesTo, ID: "tb_other"},
+	}
+	if !slices.Equal(entry.Relations, want) {
+		t.Errorf("relations = %+v, want %+v", entry.Relations, want)
+	}
+	if entry.FanOutRelations("tb_wide", []string{"tb_one"}) {
+		t.Error("fanning out again reported a change")
+	}
+}
@@ -2,9 +2,9 @@
 package ledger
 
 import (
+	"context"
 	"errors"
 	"path/filepath"
-	"sort"
 	"time"
 
 	"github.com/gorewood/timbers/internal/git"
@@ -31,23 +31,28 @@ type ListStats struct {
 
 // GitOps defines the git operations required by Storage.
 // Entry storage is handled by FileStorage; this interface covers
-// commit history and diff operations only. It is a cohesive git-operations
+// commit history and diff operations only. Every method takes the context
+// the Storage was given (see WithContext), so cancellation and --timeout
+// reach each git invocation. It is a cohesive git-operations
 // facade; the interfacebloat 10-method threshold is arbitrary here and
 // splitting one coherent seam would only fragment the mock surface.
 //
 //nolint:interfacebloat // cohesive git facade; see doc comment above
 type GitOps interface {
-	HEAD() (string, error)
-	Log(fromRef, toRef string) ([]git.Commit, error)
-	LogFirstParent(fromRef, toRef string) ([]git.Commit, error)
-	ResolveCommit(ref string) (string, error)
-	CommitsReachableFrom(sha string) ([]git.Commit, error)
-	IsAncestorOf(ancestor, descendant string) bool
-	IsOnFirstParentLine(sha, head string) bool
-	GetDiffstat(fromRef, toRef string) (git.Diffstat, error)
-	CommitFiles(sha string) ([]string, error)
-	CommitFilesMulti(shas []string) (map[string][]string, error)
-	DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error)
+	HEAD(ctx context.Context) (string, error)
+	Log(ctx context.Context, fromRef, toRef string) ([]git.Commit, error)
+	LogFirstParent(ctx context.Context, fromRef, toRef string) ([]git.Commit, error)
+	ResolveCommit(ctx context.Context, ref string) (string, error)
+	CommitsReachableFrom(ctx context.Context, sha string) ([]git.Commit, error)
+	CommitsReachableFromFirstParent(ctx context.Context, sha string) ([]git.Commit, error)
+	IsAncestorOf(ctx context.Context, ancestor, descendant string) bool
+	IsOnFirstParentLine(ctx context.Context, sha, head string) bool
+	IsShallow(ctx context.Context) bool
+	GetDiffstat(ctx context.Context, fromRef, toRef string) (git.Diffstat, error)
+	CommitFiles(ctx context.Context, sha string) ([]string, error)
+	CommitFilesMulti(ctx context.Context, shas []string) (map[string][]string, error)
+	CommitFilesMultiFirstParent(ctx context.Context, shas []string) (map[string][]string, error)
+	DiffNameOnly(ctx context.Context, fromRef, toRef, pathPrefix string) ([]string, error)
 }
 
 // Storage provides read/write access to ledger entries stored as files in .timbers/.
@@ -58,6 +63,8 @@ type Storage struct {
 	skipAuthors  []string
 	skipMessages []string
 	provenance   ProvenanceConfig // cross-agent debt classifier; zero-value = disabled
+	firstParent  bool             // pending and ranges follow first parents only
+	ctx          context.Context  // nil means git.Context()
 }
 
 // NewStorage creates a Storage with the given git operations and file storage.
@@ -80,7 +87,7 @@ func NewStorage(ops GitOps, files *FileStorage) *Storage {
 	}
 	rules := compiledDefaultSkipRules
 	var authors, messages []string
-	if files != nil {
+	if files != nil && files.tree == nil {
 		// One file parse yields path rules, author globs, and message
 		// globs. A malformed or unreadable .timbersignore must not break
 		// pending detection, so loader errors fall through to the defaults.
@@ -102,16 +109,24 @@ func NewStorage(ops GitOps, files *FileStorage) *Storage {
 // DefaultSessionWindow otherwise). Empty user.email and a malformed
 // session-window value both degrade safely — see LoadProvenanceConfig
 // and LoadSessionWindow.
+//
+// In a bare repository, which has no working directory, the ledger is read
+// from HEAD's tree instead (see NewTreeStorage) and writes are refused.
 func NewDefaultStorage() (*Storage, error) {
+	if git.IsBare() {
+		return NewTreeStorage("HEAD"), nil
+	}
 	root, err := git.RepoRoot()
 	if err != nil {
 		return nil, err
 	}
-	files := NewFileStorage(filepath.Join(root, ".timbers"), DefaultGitAdd, DefaultGitCommit)
+	files := NewFileStorage(filepath.Join(root, ".timbers"), nil, nil)
+	applyStorageConfig(files, root)
 	store := NewStorage(nil, files)
 	cfg := LoadProvenanceConfig(time.Now())
 	cfg.StaleWindow = LoadSessionWindow(root).Window
 	store.SetProvenance(cfg)
+	store.SetFirstParent(loadFirstParent(root))
 	return store, nil
 }
 
@@ -135,6 +150,12 @@ func (s *Storage) ListEntriesWithStats() ([]*Entry, *ListStats, error) {
 	return s.files.ListEntriesWithStats()
 }
 
+// WriteEntries writes a batch of entries atomically: all are written, staged,
+// and committed together, or none are. See FileStorage.WriteEntries.
+func (s *Storage) WriteEntries(entries []*Entry) error {
+	return s.files.WriteEntries(entries)
+}
+
 // WriteEntry writes an entry to the .timbers/ directory and stages it.
 // Validates the entry before writing.
 // If force is false and the entry file already exists, returns a conflict error.
@@ -147,56 +168,39 @@ func (s *Storage) WriteEntry(entry *Entry, force bool) error {
 // Returns a user error (exit code 1) if the entry is not found.
 func (s *Storage) GetEntryByID(id string) (*Entry, error) {
 	if s.files == nil {
-		return nil, output.NewUserError("entry not found: " + id)
+		return nil, output.NewUserError("entry not found: " + id).WithID(output.ErrCodeEntryNotFound)
 	}
 	return s.files.ReadEntry(id)
 }
 
+// EntryPath returns the path of the entry file for id, and false when there
+// is no such file.
+func (s *Storage) EntryPath(id string) (string, bool) {
+	if s.files == nil {
+		return "", false
+	}
+	return s.files.existingEntryPath(id)
+}
+
 // GetLatestEntry returns the entry with the most recent created_at timestamp.
 // Returns ErrNoEntries if no entries exist.
 func (s *Storage) GetLatestEntry() (*Entry, error) {
-	entries, err := s.ListEntries()
+	entries, err := s.List(ListOptions{Limit: 1})
 	if err != nil {
 		return nil, err
 	}
-
 	if len(entries) == 0 {
 		return nil, ErrNoEntries
 	}
-
-	latest := entries[0]
-	for _, entry := range entries[1:] {
-		if entry.CreatedAt.After(latest.CreatedAt) {
-			latest = entry
-		}
-	}
-
-	return latest, nil
+	return entries[0], nil
 }
 
 // GetLastNEntries returns the last N entries sorted by created_at descending.
 // Returns entries up to N; if fewer than N exist, returns all entries.
-// Returns an empty slice if no entries exist.
+// Returns an empty slice if no entries exist. Only the files needed are
+// read; see FileStorage.List.
 func (s *Storage) GetLastNEntries(count int) ([]*Entry, error) {
-	entries, err := s.ListEntries()
-	if err != nil {
-		return nil, err
-	}
-
-	if len(entries) == 0 {
-		return []*Entry{}, nil
-	}
-
-	// Sort entries by CreatedAt descending (most recent first)
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[j].CreatedAt.Before(entries[i].CreatedAt)
-	})
-
-	// Return last N entries
-	if count >= len(entries) {
-		return entries, nil
-	}
-	return entries[:count], nil
+	return s.List(ListOptions{Limit: count})
 }
 
 // --- Git operations ---
@@ -241,15 +245,18 @@ func (s *Storage) GetGatePendingCommits() ([]git.Commit, *Entry, error) {
 // uses --first-parent so merged-in commits are excluded; in addition,
 // commits with no first-parent file changes (clean merges or empty commits)
 // are dropped, since they add no new work to this branch's line.
-func (s *Storage) getPendingCommits(firstParent bool) ([]git.Commit, *Entry, error) {
-	commits, latest, docSet, ackedSet, err := s.pendingRange(firstParent)
+//
+// In first-parent mode (SetFirstParent) the display path walks first
+// parents too, but keeps its display filtering.
+func (s *Storage) getPendingCommits(gate bool) ([]git.Commit, *Entry, error) {
+	commits, latest, docSet, ackedSet, err := s.pendingRange(gate || s.firstParent)
 	if commits == nil {
 		// Hard error (HEAD/reach failure) — nothing to filter.
 		return nil, latest, err
 	}
 	// On stale anchor, commits is the all-reachable fallback; still filter it
 	// (callers that care distinguish via errors.Is(err, ErrStaleAnchor)).
-	return s.filterCommits(commits, docSet, ackedSet, firstParent), latest, err
+	return s.filterCommits(commits, docSet, ackedSet, gate), latest, err
 }
 
 // latestEntry returns the entry with the most recent CreatedAt, or nil
@@ -294,26 +301,39 @@ func (s *Storage) HasPendingCommits() (bool, error) {
 	return len(commits) > 0, nil
 }
 
-// LogRange returns commits in the given range (fromRef..toRef).
-// The 'fromRef' ref is exclusive, 'toRef' is inclusive.
-func (s *Storage) LogRange(fromRef, toRef string) ([]git.Commit, error) {
-	return s.git.Log(fromRef, toRef)
-}
-
 // ResolveCommit resolves a commit-ish ref to its full SHA via the underlying
 // git operations. Used to normalize a user-supplied --anchor before it becomes
 // a stored anchor, so a symbolic ref like "HEAD" is never persisted.
 func (s *Storage) ResolveCommit(ref string) (string, error) {
-	return s.git.ResolveCommit(ref)
+	return s.git.ResolveCommit(s.context(), ref)
+}
+
+// ReachableCommits returns the SHAs of every commit in the history of ref,
+// ref included.
+func (s *Storage) ReachableCommits(ref string) (map[string]bool, error) {
+	commits, err := s.git.CommitsReachableFrom(s.context(), ref)
+	if err != nil {
+		return nil, err
+	}
+	reachable := make(map[string]bool, len(commits))
+	for _, commit := range commits {
+		reachable[commit.SHA] = true
+	}
+	return reachable, nil
+}
+
+// IsAncestorOf reports whether ancestor is in the history of descendant.
+func (s *Storage) IsAncestorOf(ancestor, descendant string) bool {
+	return s.git.IsAncestorOf(s.context(), ancestor, descendant)
 }
 
 // GetDiffstat returns the change statistics for the given commit range.
 func (s *Storage) GetDiffstat(fromRef, toRef string) (git.Diffstat, error) {
-	return s.git.GetDiffstat(fromRef, toRef)
+	return s.git.GetDiffstat(s.context(), fromRef, toRef)
 }
 
 // DiffNameOnly returns file paths changed between fromRef and toRef,
 // optionally filtered to a path prefix.
 func (s *Storage) DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
-	return s.git.DiffNameOnly(fromRef, toRef, pathPrefix)
+	return s.git.DiffNameOnly(s.context(), fromRef, toRef, pathPrefix)
 }
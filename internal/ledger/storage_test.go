@@ -2,6 +2,7 @@ package ledger
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"strings"
@@ -28,20 +29,21 @@ type mockGitOps struct {
 	isAncestor           bool
 	anchorOffFirstParent bool                // opt-in: when true, IsOnFirstParentLine returns false
 	commitFiles          map[string][]string // SHA -> files; nil map = unknown (no filtering)
+	shallow              bool                // IsShallow result
 }
 
 func newMockGitOps() *mockGitOps {
 	return &mockGitOps{isAncestor: true}
 }
 
-func (m *mockGitOps) HEAD() (string, error) {
+func (m *mockGitOps) HEAD(_ context.Context) (string, error) {
 	if m.headErr != nil {
 		return "", m.headErr
 	}
 	return m.headSHA, nil
 }
 
-func (m *mockGitOps) Log(fromRef, toRef string) ([]git.Commit, error) {
+func (m *mockGitOps) Log(_ context.Context, fromRef, toRef string) ([]git.Commit, error) {
 	if m.logErr != nil {
 		return nil, m.logErr
 	}
@@ -52,7 +54,7 @@ func (m *mockGitOps) Log(fromRef, toRef string) ([]git.Commit, error) {
 // Log (handy for tests that don't care about the merge case); tests that
 // need to distinguish the two paths set firstParentCommits/firstParentErr
 // explicitly.
-func (m *mockGitOps) LogFirstParent(fromRef, toRef string) ([]git.Commit, error) {
+func (m *mockGitOps) LogFirstParent(_ context.Context, fromRef, toRef string) ([]git.Commit, error) {
 	m.firstParentCalled = true
 	if m.firstParentErr != nil {
 		return nil, m.firstParentErr
@@ -70,22 +72,26 @@ func (m *mockGitOps) LogFirstParent(fromRef, toRef string) ([]git.Commit, error)
 
 // ResolveCommit returns the ref unchanged — the mock models a git that
 // resolves any ref to itself, which is all pending detection needs.
-func (m *mockGitOps) ResolveCommit(ref string) (string, error) {
+func (m *mockGitOps) ResolveCommit(_ context.Context, ref string) (string, error) {
 	return ref, nil
 }
 
-func (m *mockGitOps) CommitsReachableFrom(sha string) ([]git.Commit, error) {
+func (m *mockGitOps) CommitsReachableFrom(_ context.Context, sha string) ([]git.Commit, error) {
 	if m.reachableErr != nil {
 		return nil, m.reachableErr
 	}
 	return m.reachableFrom, nil
 }
 
-func (m *mockGitOps) IsAncestorOf(ancestor, descendant string) bool {
+func (m *mockGitOps) CommitsReachableFromFirstParent(ctx context.Context, sha string) ([]git.Commit, error) {
+	return m.CommitsReachableFrom(ctx, sha)
+}
+
+func (m *mockGitOps) IsAncestorOf(_ context.Context, ancestor, descendant string) bool {
 	return m.isAncestor
 }
 
-func (m *mockGitOps) IsOnFirstParentLine(sha, head string) bool {
+func (m *mockGitOps) IsOnFirstParentLine(_ context.Context, sha, head string) bool {
 	// Default true unless the test opts into the "off the line" case
 	// — keeps existing tests unchanged while letting new tests exercise
 	// the Laura pathology directly.
@@ -95,11 +101,15 @@ func (m *mockGitOps) IsOnFirstParentLine(sha, head string) bool {
 	return true
 }
 
-func (m *mockGitOps) GetDiffstat(fromRef, toRef string) (git.Diffstat, error) {
+func (m *mockGitOps) IsShallow(_ context.Context) bool {
+	return m.shallow
+}
+
+func (m *mockGitOps) GetDiffstat(_ context.Context, fromRef, toRef string) (git.Diffstat, error) {
 	return git.Diffstat{}, nil
 }
 
-func (m *mockGitOps) CommitFiles(sha string) ([]string, error) {
+func (m *mockGitOps) CommitFiles(_ context.Context, sha string) ([]string, error) {
 	if m.commitFiles == nil {
 		return nil, nil
 	}
@@ -110,10 +120,10 @@ func (m *mockGitOps) CommitFiles(sha string) ([]string, error) {
 	return files, nil
 }
 
-func (m *mockGitOps) CommitFilesMulti(shas []string) (map[string][]string, error) {
+func (m *mockGitOps) CommitFilesMulti(ctx context.Context, shas []string) (map[string][]string, error) {
 	result := make(map[string][]string, len(shas))
 	for _, sha := range shas {
-		files, err := m.CommitFiles(sha)
+		files, err := m.CommitFiles(ctx, sha)
 		if err != nil {
 			return nil, err
 		}
@@ -122,7 +132,11 @@ func (m *mockGitOps) CommitFilesMulti(shas []string) (map[string][]string, error
 	return result, nil
 }
 
-func (m *mockGitOps) DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
+func (m *mockGitOps) CommitFilesMultiFirstParent(ctx context.Context, shas []string) (map[string][]string, error) {
+	return m.CommitFilesMulti(ctx, shas)
+}
+
+func (m *mockGitOps) DiffNameOnly(_ context.Context, fromRef, toRef, pathPrefix string) ([]string, error) {
 	return nil, nil
 }
 
@@ -408,6 +422,20 @@ func TestGetPendingCommits(t *testing.T) {
 			wantLatestNil:   false,
 			wantStaleAnchor: true,
 		},
+		{
+			name: "anchor beyond a shallow clone degrades to the fetched history",
+			entries: []*Entry{
+				makeTestEntry("unfetchedsh", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)),
+			},
+			setupMock: func(mock *mockGitOps) {
+				mock.headSHA = "headsha1234"
+				mock.shallow = true
+				mock.isAncestor = false
+				mock.reachableFrom = []git.Commit{{SHA: "commit1abc", Short: "commit1"}}
+			},
+			wantCommitCount: 1,
+			wantLatestNil:   false,
+		},
 		{
 			name: "stale anchor with reachable error returns error",
 			entries: []*Entry{
@@ -0,0 +1,141 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// KindTombstone is the kind identifier for tombstone records, which mark an
+// entry as deleted without removing its file. Like acks they share the
+// timbers.devlog schema family but have their own shape.
+const KindTombstone = "tombstone"
+
+// tombstoneIDPrefix is the prefix for all tombstone IDs.
+const tombstoneIDPrefix = "rm_"
+
+// Tombstone records that an entry was deleted, by whom, and why. The entry
+// file stays in the ledger, so history is append-only in spirit: query and
+// export hide the entry unless asked to include deleted entries.
+type Tombstone struct {
+	Schema    string    `json:"schema"`
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+	Who       Acker     `json:"who"`
+	TargetID  string    `json:"target_id"`
+	Reason    string    `json:"reason"`
+}
+
+// TombstoneID returns the tombstone ID for an entry ID. There is one
+// tombstone per entry, so deleting an entry twice is a conflict.
+func TombstoneID(entryID string) string {
+	return tombstoneIDPrefix + strings.TrimPrefix(entryID, idPrefix)
+}
+
+// tombstoneTargetID returns the entry ID a tombstone ID deletes: the
+// inverse of TombstoneID.
+func tombstoneTargetID(id string) string {
+	return idPrefix + strings.TrimPrefix(id, tombstoneIDPrefix)
+}
+
+// Validate checks that all required fields are present.
+func (t *Tombstone) Validate() error {
+	var missing []string
+	if t.Schema == "" {
+		missing = append(missing, "schema")
+	}
+	if t.Kind == "" {
+		missing = append(missing, "kind")
+	}
+	if t.ID == "" {
+		missing = append(missing, "id")
+	}
+	if t.DeletedAt.IsZero() {
+		missing = append(missing, "deleted_at")
+	}
+	if t.TargetID == "" {
+		missing = append(missing, "target_id")
+	}
+	if t.Reason == "" {
+		missing = append(missing, "reason")
+	}
+	if len(missing) > 0 {
+		return &ValidationError{Fields: missing, Message: "missing required fields"}
+	}
+	return nil
+}
+
+// ToJSON serializes the tombstone to JSON.
+func (t *Tombstone) ToJSON() ([]byte, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, fmt.Errorf("serializing tombstone to JSON: %w", err)
+	}
+	return data, nil
+}
+
+// FromJSONTombstone deserializes a tombstone record. Returns
+// ErrNotTimbersNote when the JSON is valid but is not a timbers tombstone.
+func FromJSONTombstone(data []byte) (*Tombstone, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty JSON data")
+	}
+	var tombstone Tombstone
+	if err := json.Unmarshal(data, &tombstone); err != nil {
+		return nil, fmt.Errorf("parsing tombstone JSON: %w", err)
+	}
+	if !strings.HasPrefix(tombstone.Schema, "timbers.devlog/") || tombstone.Kind != KindTombstone {
+		return nil, ErrNotTimbersNote
+	}
+	return &tombstone, nil
+}
+
+// WriteTombstone records an entry as deleted.
+func (s *Storage) WriteTombstone(tombstone *Tombstone) error {
+	if s.files == nil {
+		return output.NewSystemError("storage not configured for writes")
+	}
+	return s.files.WriteTombstone(tombstone)
+}
+
+// ListTombstones returns every tombstone record under the storage directory.
+func (s *Storage) ListTombstones() ([]*Tombstone, error) {
+	if s.files == nil {
+		return nil, nil
+	}
+	return s.files.ListTombstones()
+}
+
+// DeletedSet returns the IDs of entries that have a tombstone. Returns an
+// empty (non-nil) map on any error, so listings degrade to showing
+// everything rather than failing.
+func (s *Storage) DeletedSet() map[string]bool {
+	deleted := make(map[string]bool)
+	tombstones, err := s.ListTombstones()
+	if err != nil {
+		return deleted
+	}
+	for _, tombstone := range tombstones {
+		deleted[tombstone.TargetID] = true
+	}
+	return deleted
+}
+
+// FilterDeleted drops entries whose IDs are in deleted.
+func FilterDeleted(entries []*Entry, deleted map[string]bool) []*Entry {
+	if len(deleted) == 0 {
+		return entries
+	}
+	var result []*Entry
+	for _, entry := range entries {
+		if !deleted[entry.ID] {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
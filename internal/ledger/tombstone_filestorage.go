@@ -0,0 +1,97 @@
+package ledger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// tombstonePath returns the file path for a tombstone: next to the entry it
+// deletes, in the configured layout, so MigrateLayout moves the two together.
+func (fs *FileStorage) tombstonePath(tombstone *Tombstone) string {
+	return filepath.Join(fs.dir, fs.Layout().Dir(tombstone.TargetID), IDToFilename(tombstone.ID)+".json")
+}
+
+// tombstoneExists reports whether the entry tombstone deletes already has a
+// tombstone, in any layout.
+func (fs *FileStorage) tombstoneExists(tombstone *Tombstone) bool {
+	name := IDToFilename(tombstone.ID) + ".json"
+	for _, layout := range Layouts {
+		if _, err := os.Stat(filepath.Join(fs.dir, layout.Dir(tombstone.TargetID), name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteTombstone writes a tombstone record and stages + commits it. A
+// second tombstone for the same entry is a conflict.
+func (fs *FileStorage) WriteTombstone(tombstone *Tombstone) error {
+	if err := fs.checkWritable("write tombstone " + tombstone.ID); err != nil {
+		return err
+	}
+	if err := tombstone.Validate(); err != nil {
+		return output.NewUserError(err.Error())
+	}
+	unlock, err := fs.lock("write tombstone " + tombstone.ID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	path := fs.tombstonePath(tombstone)
+	if fs.tombstoneExists(tombstone) {
+		return output.NewConflictError("entry already deleted: " + tombstone.TargetID).WithID(output.ErrCodeEntryExists)
+	}
+
+	data, err := tombstone.ToJSON()
+	if err != nil {
+		return output.NewSystemError("failed to serialize tombstone: " + err.Error())
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return output.NewSystemErrorWithCause("failed to create tombstone directory", err)
+	}
+	if err = atomicWrite(path, data); err != nil {
+		return output.NewSystemErrorWithCause("failed to write tombstone", err)
+	}
+	if err = fs.gitAdd(path); err != nil {
+		return output.NewSystemErrorWithCause("failed to stage tombstone file", err)
+	}
+	if err = fs.gitCommit(path, "timbers: delete "+tombstone.TargetID); err != nil {
+		return output.NewSystemErrorWithCause("failed to commit tombstone file", err)
+	}
+	return nil
+}
+
+// ListTombstones returns every tombstone record under the storage
+// directory. Like ListAcks it is best-effort: files that don't start with
+// "rm_" or don't parse are skipped.
+func (fs *FileStorage) ListTombstones() ([]*Tombstone, error) {
+	var tombstones []*Tombstone
+	walkErr := fs.walkFiles(func(path, fileName string) error {
+		name, ok := strings.CutSuffix(fileName, ".json")
+		if !ok || !strings.HasPrefix(name, tombstoneIDPrefix) {
+			return nil
+		}
+		data, readErr := fs.readFile(path)
+		if readErr != nil {
+			return nil //nolint:nilerr // best-effort, like ListAcks
+		}
+		tombstone, parseErr := FromJSONTombstone(data)
+		if parseErr != nil {
+			return nil //nolint:nilerr // not a tombstone record — silently skip
+		}
+		tombstones = append(tombstones, tombstone)
+		return nil
+	})
+	if walkErr != nil {
+		if errors.Is(walkErr, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, output.NewSystemErrorWithCause("failed to walk tombstone directory", walkErr)
+	}
+	return tombstones, nil
+}
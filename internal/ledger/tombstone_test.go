@@ -0,0 +1,77 @@
+package ledger
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func makeTestTombstone(entry *Entry) *Tombstone {
+	return &Tombstone{
+		Schema:    SchemaVersion,
+		Kind:      KindTombstone,
+		ID:        TombstoneID(entry.ID),
+		DeletedAt: entry.CreatedAt.Add(time.Hour),
+		Who:       Acker{Name: "Dev", Email: "dev@example.com"},
+		TargetID:  entry.ID,
+		Reason:    "logged against the wrong repo",
+	}
+}
+
+func TestTombstoneID(t *testing.T) {
+	if got := TombstoneID("tb_2026-01-15T10:00:00Z_abc123"); got != "rm_2026-01-15T10:00:00Z_abc123" {
+		t.Errorf("TombstoneID = %q", got)
+	}
+}
+
+func TestWriteTombstone(t *testing.T) {
+	store := NewFileStorage(t.TempDir(), noopGitAdd, noopGitCommit)
+	kept := makeTestEntry("keep001", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	deleted := makeTestEntry("gone001", time.Date(2026, 1, 15, 11, 0, 0, 0, time.UTC))
+	for _, entry := range []*Entry{kept, deleted} {
+		if err := store.WriteEntry(entry, false); err != nil {
+			t.Fatalf("WriteEntry: %v", err)
+		}
+	}
+	if err := store.WriteTombstone(makeTestTombstone(deleted)); err != nil {
+		t.Fatalf("WriteTombstone: %v", err)
+	}
+
+	// The entry file stays; the tombstone is neither an entry nor a parse error.
+	entries, stats, err := store.ListEntriesWithStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || stats.Skipped != 0 {
+		t.Errorf("ListEntries = %d entries, %d skipped; want 2, 0", len(entries), stats.Skipped)
+	}
+
+	tombstones, err := store.ListTombstones()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tombstones) != 1 || tombstones[0].TargetID != deleted.ID || tombstones[0].Who.Name != "Dev" {
+		t.Fatalf("ListTombstones = %+v", tombstones)
+	}
+
+	storage := NewStorage(nil, store)
+	remaining := FilterDeleted(entries, storage.DeletedSet())
+	if len(remaining) != 1 || remaining[0].ID != kept.ID {
+		t.Errorf("FilterDeleted kept %d entries, want only %s", len(remaining), kept.ID)
+	}
+
+	if err := store.WriteTombstone(makeTestTombstone(deleted)); err == nil {
+		t.Error("deleting an entry twice expected a conflict")
+	}
+}
+
+func TestTombstoneValidate(t *testing.T) {
+	tombstone := makeTestTombstone(makeTestEntry("val001", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)))
+	tombstone.Reason = ""
+	if err := tombstone.Validate(); err == nil {
+		t.Error("Validate without a reason expected an error")
+	}
+	if _, err := FromJSONTombstone([]byte(`{"schema":"timbers.devlog/v1","kind":"entry","id":"tb_x"}`)); !errors.Is(err, ErrNotTimbersNote) {
+		t.Errorf("FromJSONTombstone(entry) = %v, want ErrNotTimbersNote", err)
+	}
+}
@@ -0,0 +1,64 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"strings"
+	"time"
+)
+
+// ulidAlphabet is Crockford's base32, the ULID alphabet: no I, L, O, or U.
+const ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidLength is the length of a ULID: 10 characters of millisecond
+// timestamp followed by 16 of randomness.
+const ulidLength = 26
+
+// ulidTimeLength is the number of leading ULID characters that encode the
+// timestamp.
+const ulidTimeLength = 10
+
+// newULID returns a ULID for timestamp: its Unix milliseconds in the first
+// 48 bits and 80 random bits after, so ULIDs sort by time.
+func newULID(timestamp time.Time) string {
+	var raw [16]byte
+	binary.BigEndian.PutUint64(raw[:8], uint64(timestamp.UnixMilli())<<16) //nolint:gosec // pre-1970 times are not IDs
+	copy(raw[6:], randomBytes(len(raw)-6))
+
+	// 128 bits encode as 26 five-bit characters; the first carries 3 bits.
+	high := binary.BigEndian.Uint64(raw[:8])
+	low := binary.BigEndian.Uint64(raw[8:])
+	var out [ulidLength]byte
+	for i := ulidLength - 1; i >= 0; i-- {
+		out[i] = ulidAlphabet[low&0x1f]
+		low = low>>5 | high<<59
+		high >>= 5
+	}
+	return string(out[:])
+}
+
+// ulidTime returns the time encoded in a ULID, and false when value is not
+// one. Lowercase is accepted, as Crockford's base32 is case-insensitive.
+func ulidTime(value string) (time.Time, bool) {
+	if len(value) != ulidLength || !strings.ContainsRune("01234567", rune(value[0])) {
+		return time.Time{}, false
+	}
+	var millis int64
+	for i := range ulidLength {
+		digit := strings.IndexByte(ulidAlphabet, upperASCII(value[i]))
+		if digit < 0 {
+			return time.Time{}, false
+		}
+		if i < ulidTimeLength {
+			millis = millis<<5 | int64(digit)
+		}
+	}
+	return time.UnixMilli(millis).UTC(), true
+}
+
+// upperASCII upper-cases an ASCII letter and leaves other bytes unchanged.
+func upperASCII(char byte) byte {
+	if char >= 'a' && char <= 'z' {
+		return char - 'a' + 'A'
+	}
+	return char
+}
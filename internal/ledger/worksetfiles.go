@@ -0,0 +1,88 @@
+// Package ledger — changed file paths of a workset.
+package ledger
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+// WorksetFiles returns the paths changed by commits, sorted and without
+// duplicates, for Workset.Files. In first-parent mode a merge contributes
+// its changes against the first parent.
+func (s *Storage) WorksetFiles(commits []git.Commit) ([]string, error) {
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	byCommit, err := s.commitFilesMulti(commits)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, changed := range byCommit {
+		files = append(files, changed...)
+	}
+	slices.Sort(files)
+	return slices.Compact(files), nil
+}
+
+// ChangedFiles returns the paths the workset touched: Files when the entry
+// recorded them, otherwise the paths in a per-file diffstat (log --numstat).
+// Entries logged before workset.files existed may have neither.
+func (w Workset) ChangedFiles() []string {
+	if len(w.Files) > 0 || w.Diffstat == nil {
+		return w.Files
+	}
+	files := make([]string, 0, len(w.Diffstat.PerFile))
+	for _, file := range w.Diffstat.PerFile {
+		files = append(files, file.Path)
+	}
+	return files
+}
+
+// MatchPath reports whether file matches a path filter. A pattern ending in
+// "/..." matches everything under that directory, a pattern with glob
+// characters is matched with path.Match, and any other pattern matches the
+// file itself or everything under it as a directory.
+func MatchPath(pattern, file string) bool {
+	pattern = strings.TrimPrefix(pattern, "./")
+	if dir, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return strings.HasPrefix(file, dir+"/")
+	}
+	if strings.ContainsAny(pattern, "*?[") {
+		matched, err := path.Match(pattern, file)
+		return err == nil && matched
+	}
+	pattern = strings.TrimSuffix(pattern, "/")
+	return file == pattern || strings.HasPrefix(file, pattern+"/")
+}
+
+// FilterEntriesByPaths keeps entries that touched a file matching any of
+// patterns (see MatchPath). An empty pattern list keeps all.
+func FilterEntriesByPaths(entries []*Entry, patterns []string) []*Entry {
+	if len(patterns) == 0 {
+		return entries
+	}
+	var result []*Entry
+	for _, entry := range entries {
+		if entryTouchesAny(entry, patterns) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// entryTouchesAny reports whether any changed file of entry matches any of
+// patterns.
+func entryTouchesAny(entry *Entry, patterns []string) bool {
+	for _, file := range entry.Workset.ChangedFiles() {
+		for _, pattern := range patterns {
+			if MatchPath(pattern, file) {
+				return true
+			}
+		}
+	}
+	return false
+}
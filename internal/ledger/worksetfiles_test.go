@@ -0,0 +1,75 @@
+package ledger
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gorewood/timbers/internal/git"
+)
+
+func TestWorksetFiles(t *testing.T) {
+	mock := newMockGitOps()
+	mock.commitFiles = map[string][]string{
+		"aaa": {"src/auth/login.go", "README.md"},
+		"bbb": {"src/auth/login.go", "src/auth/token.go"},
+	}
+	store := NewStorage(mock, nil)
+
+	files, err := store.WorksetFiles([]git.Commit{{SHA: "bbb"}, {SHA: "aaa"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"README.md", "src/auth/login.go", "src/auth/token.go"}
+	if !slices.Equal(files, want) {
+		t.Errorf("WorksetFiles = %v, want %v", files, want)
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		file    string
+		want    bool
+	}{
+		{"src/auth/...", "src/auth/login.go", true},
+		{"src/auth/...", "src/auth/oauth/client.go", true},
+		{"src/auth/...", "src/authz/policy.go", false},
+		{"src/auth", "src/auth/login.go", true},
+		{"src/auth/", "src/auth/login.go", true},
+		{"src/auth", "src/authz/policy.go", false},
+		{"README.md", "README.md", true},
+		{"./README.md", "README.md", true},
+		{"src/*/login.go", "src/auth/login.go", true},
+		{"*.go", "src/auth/login.go", false},
+	}
+	for _, tt := range tests {
+		if got := MatchPath(tt.pattern, tt.file); got != tt.want {
+			t.Errorf("MatchPath(%q, %q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestFilterEntriesByPaths(t *testing.T) {
+	created := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	auth := makeTestEntry("path001", created)
+	auth.Workset.Files = []string{"src/auth/login.go"}
+	docs := makeTestEntry("path002", created)
+	docs.Workset.Files = []string{"docs/spec.md"}
+	// Older entries without workset.files fall back to a per-file diffstat.
+	numstat := makeTestEntry("path003", created)
+	numstat.Workset.Diffstat = &Diffstat{Files: 1, PerFile: []FileStat{{Path: "src/auth/token.go"}}}
+	bare := makeTestEntry("path004", created)
+
+	entries := []*Entry{auth, docs, numstat, bare}
+	got := FilterEntriesByPaths(entries, []string{"src/auth/..."})
+	if len(got) != 2 || got[0] != auth || got[1] != numstat {
+		t.Errorf("FilterEntriesByPaths(src/auth/...) kept %d entries, want auth and numstat", len(got))
+	}
+	if got := FilterEntriesByPaths(entries, []string{"docs", "nowhere"}); len(got) != 1 || got[0] != docs {
+		t.Errorf("FilterEntriesByPaths(docs, nowhere) kept %d entries, want docs", len(got))
+	}
+	if got := FilterEntriesByPaths(entries, nil); len(got) != len(entries) {
+		t.Errorf("no patterns kept %d entries, want all", len(got))
+	}
+}
@@ -0,0 +1,130 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// Embedding API types. OpenAI and OpenAI-compatible local servers share one
+// format; Gemini batches one request per text.
+type openaiEmbedRequest struct {
+	Model string   `json:"model,omitempty"`
+	Input []string `json:"input"`
+}
+
+type openaiEmbedResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type googleEmbedRequest struct {
+	Requests []googleEmbedContent `json:"requests"`
+}
+
+type googleEmbedContent struct {
+	Model   string        `json:"model"`
+	Content googleContent `json:"content"`
+}
+
+type googleEmbedResponse struct {
+	Embeddings []struct {
+		Values []float64 `json:"values"`
+	} `json:"embeddings"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Embed returns one embedding vector per text, in input order. Anthropic has
+// no embeddings API; use an OpenAI, Google, or local embedding model.
+func (c *Client) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	switch c.provider {
+	case ProviderOpenAI:
+		return c.embedOpenAI(ctx, "https://api.openai.com/v1/embeddings", c.model, texts,
+			map[string]string{"Authorization": "Bearer " + c.apiKey})
+	case ProviderLocal:
+		model := c.model
+		if model == "default" || model == "local" {
+			model = ""
+		}
+		return c.embedOpenAI(ctx, LocalServerURL()+"/embeddings", model, texts, nil)
+	case ProviderGoogle:
+		return c.embedGoogle(ctx, texts)
+	case ProviderAnthropic:
+		return nil, output.NewUserError("anthropic has no embeddings API; set an openai, google, or local embedding model")
+	default:
+		return nil, output.NewUserError(fmt.Sprintf("unsupported provider: %s", c.provider))
+	}
+}
+
+func (c *Client) embedOpenAI(ctx context.Context, url, model string, texts []string, headers map[string]string) ([][]float64, error) {
+	respBody, err := c.doRequest(ctx, url, openaiEmbedRequest{Model: model, Input: texts}, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var result openaiEmbedResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to parse response", err)
+	}
+	if result.Error != nil {
+		return nil, output.NewSystemError("API error: " + result.Error.Message)
+	}
+	if len(result.Data) != len(texts) {
+		return nil, output.NewSystemError(fmt.Sprintf("API returned %d embeddings for %d inputs", len(result.Data), len(texts)))
+	}
+
+	vectors := make([][]float64, len(texts))
+	for _, item := range result.Data {
+		if item.Index < 0 || item.Index >= len(texts) {
+			return nil, output.NewSystemError(fmt.Sprintf("API returned embedding index %d out of range", item.Index))
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	return vectors, nil
+}
+
+func (c *Client) embedGoogle(ctx context.Context, texts []string) ([][]float64, error) {
+	modelPath := "models/" + c.model
+	body := googleEmbedRequest{Requests: make([]googleEmbedContent, len(texts))}
+	for i, text := range texts {
+		body.Requests[i] = googleEmbedContent{
+			Model:   modelPath,
+			Content: googleContent{Parts: []googlePart{{Text: text}}},
+		}
+	}
+	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/%s:batchEmbedContents", modelPath)
+
+	respBody, err := c.doRequest(ctx, url, body, map[string]string{"x-goog-api-key": c.apiKey})
+	if err != nil {
+		return nil, err
+	}
+
+	var result googleEmbedResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, output.NewSystemErrorWithCause("failed to parse response", err)
+	}
+	if result.Error != nil {
+		return nil, output.NewSystemError("API error: " + result.Error.Message)
+	}
+	if len(result.Embeddings) != len(texts) {
+		return nil, output.NewSystemError(fmt.Sprintf("API returned %d embeddings for %d inputs", len(result.Embeddings), len(texts)))
+	}
+
+	vectors := make([][]float64, len(texts))
+	for i, embedding := range result.Embeddings {
+		vectors[i] = embedding.Values
+	}
+	return vectors, nil
+}
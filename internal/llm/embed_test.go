@@ -0,0 +1,74 @@
+//nolint:bodyclose // Test file uses mock responses with NopCloser bodies
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEmbedOpenAI_OrdersByIndex(t *testing.T) {
+	responseJSON := `{"data": [
+		{"index": 1, "embedding": [0, 1]},
+		{"index": 0, "embedding": [1, 0]}
+	]}`
+	client := &Client{
+		provider:   ProviderOpenAI,
+		model:      "text-embedding-3-small",
+		apiKey:     "test-key",
+		httpClient: &mockHTTPDoer{response: mockResponse(200, responseJSON)},
+	}
+
+	vectors, err := client.Embed(context.Background(), []string{"first", "second"})
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if len(vectors) != 2 || vectors[0][0] != 1 || vectors[1][1] != 1 {
+		t.Errorf("Embed() = %v, want vectors in input order", vectors)
+	}
+}
+
+func TestEmbedOpenAI_CountMismatch(t *testing.T) {
+	client := &Client{
+		provider:   ProviderOpenAI,
+		model:      "text-embedding-3-small",
+		apiKey:     "test-key",
+		httpClient: &mockHTTPDoer{response: mockResponse(200, `{"data": [{"index": 0, "embedding": [1]}]}`)},
+	}
+
+	if _, err := client.Embed(context.Background(), []string{"a", "b"}); err == nil {
+		t.Error("Embed() expected error when the API returns fewer embeddings than inputs")
+	}
+}
+
+func TestEmbedGoogle_Success(t *testing.T) {
+	client := &Client{
+		provider:   ProviderGoogle,
+		model:      "gemini-embedding-001",
+		apiKey:     "test-key",
+		httpClient: &mockHTTPDoer{response: mockResponse(200, `{"embeddings": [{"values": [0.5, 0.5]}]}`)},
+	}
+
+	vectors, err := client.Embed(context.Background(), []string{"text"})
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if len(vectors) != 1 || len(vectors[0]) != 2 {
+		t.Errorf("Embed() = %v, want one 2-dimensional vector", vectors)
+	}
+}
+
+func TestEmbedAnthropic_Unsupported(t *testing.T) {
+	client := &Client{provider: ProviderAnthropic, model: "claude-haiku-4-5-20251001", apiKey: "test-key"}
+
+	_, err := client.Embed(context.Background(), []string{"text"})
+	if err == nil || !strings.Contains(err.Error(), "no embeddings API") {
+		t.Errorf("Embed() error = %v, want unsupported-provider error", err)
+	}
+}
+
+func TestInferProvider_EmbeddingModel(t *testing.T) {
+	if got := inferProvider("text-embedding-3-small"); got != ProviderOpenAI {
+		t.Errorf("inferProvider(text-embedding-3-small) = %q, want %q", got, ProviderOpenAI)
+	}
+}
@@ -85,6 +85,11 @@ func New(model string, provider Provider) (*Client, error) {
 	}, nil
 }
 
+// Model returns the resolved model name requests are sent to.
+func (c *Client) Model() string {
+	return c.model
+}
+
 // Complete generates a completion for the given request.
 func (c *Client) Complete(ctx context.Context, req Request) (*Response, error) {
 	switch c.provider {
@@ -131,6 +136,7 @@ type providerPattern struct {
 
 // providerPatterns checked in order; first match wins.
 var providerPatterns = []providerPattern{
+	{"text-embedding", ProviderOpenAI},
 	{"claude", ProviderAnthropic},
 	{"haiku", ProviderAnthropic},
 	{"sonnet", ProviderAnthropic},
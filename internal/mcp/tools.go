@@ -44,8 +44,8 @@ type PendingOutput struct {
 }
 
 func handlePending(storage *ledger.Storage) mcp.ToolHandlerFor[PendingInput, PendingOutput] {
-	return func(_ context.Context, _ *mcp.CallToolRequest, _ PendingInput) (*mcp.CallToolResult, PendingOutput, error) {
-		commits, latest, err := storage.GetPendingCommits()
+	return func(ctx context.Context, _ *mcp.CallToolRequest, _ PendingInput) (*mcp.CallToolResult, PendingOutput, error) {
+		commits, latest, err := storage.WithContext(ctx).GetPendingCommits()
 		warning := ""
 		if err != nil && !errors.Is(err, ledger.ErrStaleAnchor) {
 			return nil, PendingOutput{}, fmt.Errorf("getting pending commits: %w", err)
@@ -109,7 +109,7 @@ type PrimeOutput struct {
 }
 
 func handlePrime(storage *ledger.Storage) mcp.ToolHandlerFor[PrimeInput, PrimeOutput] {
-	return func(_ context.Context, _ *mcp.CallToolRequest, input PrimeInput) (*mcp.CallToolResult, PrimeOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input PrimeInput) (*mcp.CallToolResult, PrimeOutput, error) {
 		lastN := input.Last
 		if lastN <= 0 {
 			lastN = 3
@@ -125,17 +125,17 @@ func handlePrime(storage *ledger.Storage) mcp.ToolHandlerFor[PrimeInput, PrimeOu
 			return nil, PrimeOutput{}, fmt.Errorf("getting current branch: %w", err)
 		}
 
-		head, err := git.HEAD()
+		head, err := git.HEADContext(ctx)
 		if err != nil {
 			return nil, PrimeOutput{}, fmt.Errorf("getting HEAD: %w", err)
 		}
 
-		allEntries, err := storage.ListEntries()
+		entryCount, err := storage.CountEntries()
 		if err != nil {
-			return nil, PrimeOutput{}, fmt.Errorf("listing entries: %w", err)
+			return nil, PrimeOutput{}, fmt.Errorf("counting entries: %w", err)
 		}
 
-		pendingCommits, _, pendingErr := storage.GetPendingCommits()
+		pendingCommits, _, pendingErr := storage.WithContext(ctx).GetPendingCommits()
 		if pendingErr != nil && !errors.Is(pendingErr, ledger.ErrStaleAnchor) {
 			return nil, PrimeOutput{}, fmt.Errorf("getting pending commits: %w", pendingErr)
 		}
@@ -151,7 +151,7 @@ func handlePrime(storage *ledger.Storage) mcp.ToolHandlerFor[PrimeInput, PrimeOu
 			Repo:          filepath.Base(root),
 			Branch:        branch,
 			Head:          head,
-			EntryCount:    len(allEntries),
+			EntryCount:    entryCount,
 			Pending:       buildPrimePending(pendingCommits),
 			RecentEntries: buildPrimeEntries(recentEntries, input.Verbose),
 			Workflow:      workflow,
@@ -219,7 +219,7 @@ type StatusOutput struct {
 }
 
 func handleStatus(storage *ledger.Storage) mcp.ToolHandlerFor[StatusInput, StatusOutput] {
-	return func(_ context.Context, _ *mcp.CallToolRequest, _ StatusInput) (*mcp.CallToolResult, StatusOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, _ StatusInput) (*mcp.CallToolResult, StatusOutput, error) {
 		root, err := git.RepoRoot()
 		if err != nil {
 			return nil, StatusOutput{}, fmt.Errorf("getting repo root: %w", err)
@@ -230,7 +230,7 @@ func handleStatus(storage *ledger.Storage) mcp.ToolHandlerFor[StatusInput, Statu
 			return nil, StatusOutput{}, fmt.Errorf("getting current branch: %w", err)
 		}
 
-		head, err := git.HEAD()
+		head, err := git.HEADContext(ctx)
 		if err != nil {
 			return nil, StatusOutput{}, fmt.Errorf("getting HEAD: %w", err)
 		}
@@ -239,9 +239,9 @@ func handleStatus(storage *ledger.Storage) mcp.ToolHandlerFor[StatusInput, Statu
 		dirInfo, statErr := os.Stat(timbersDir)
 		dirExists := statErr == nil && dirInfo.IsDir()
 
-		entries, err := storage.ListEntries()
+		entryCount, err := storage.CountEntries()
 		if err != nil {
-			return nil, StatusOutput{}, fmt.Errorf("listing entries: %w", err)
+			return nil, StatusOutput{}, fmt.Errorf("counting entries: %w", err)
 		}
 
 		out := StatusOutput{
@@ -250,7 +250,7 @@ func handleStatus(storage *ledger.Storage) mcp.ToolHandlerFor[StatusInput, Statu
 			Head:       head,
 			TimbersDir: timbersDir,
 			DirExists:  dirExists,
-			EntryCount: len(entries),
+			EntryCount: entryCount,
 		}
 
 		return nil, out, nil
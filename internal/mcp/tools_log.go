@@ -22,6 +22,9 @@ type LogInput struct {
 	Tags     []string `json:"tags,omitempty"      jsonschema:"tags for categorization"`
 	WorkItem string   `json:"work_item,omitempty" jsonschema:"work item reference in system:id format"`
 	Who      []string `json:"who,omitempty"       jsonschema:"public Name <email>; omit to derive from Git; values replace automatic set"`
+	Numstat  bool     `json:"numstat,omitempty"   jsonschema:"record per-file insertions/deletions in the workset diffstat"`
+
+	RequireSigned bool `json:"require_signed,omitempty" jsonschema:"refuse unless every commit has a valid GPG/SSH signature"`
 }
 
 // LogOutput is the output for the log tool.
@@ -30,12 +33,13 @@ type LogOutput struct {
 }
 
 func handleLog(storage *ledger.Storage) mcp.ToolHandlerFor[LogInput, LogOutput] {
-	return func(_ context.Context, _ *mcp.CallToolRequest, input LogInput) (*mcp.CallToolResult, LogOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input LogInput) (*mcp.CallToolResult, LogOutput, error) {
 		if err := validateLogInput(input); err != nil {
 			return nil, LogOutput{}, err
 		}
 
-		commits, _, pendingErr := storage.GetPendingCommits()
+		store := storage.WithContext(ctx)
+		commits, _, pendingErr := store.GetPendingCommits()
 		if pendingErr != nil && !errors.Is(pendingErr, ledger.ErrStaleAnchor) {
 			return nil, LogOutput{}, fmt.Errorf("getting pending commits: %w", pendingErr)
 		}
@@ -43,12 +47,17 @@ func handleLog(storage *ledger.Storage) mcp.ToolHandlerFor[LogInput, LogOutput]
 			return nil, LogOutput{}, errors.New("no pending commits to document")
 		}
 
-		entry, err := buildLogEntry(storage, commits, input)
+		sigs, err := logSignatures(ctx, commits, input.RequireSigned)
+		if err != nil {
+			return nil, LogOutput{}, err
+		}
+
+		entry, err := buildLogEntry(store, commits, sigs, input)
 		if err != nil {
 			return nil, LogOutput{}, err
 		}
 
-		if err := storage.WriteEntry(entry, false); err != nil {
+		if err := store.WriteEntry(entry, false); err != nil {
 			return nil, LogOutput{}, fmt.Errorf("writing entry: %w", err)
 		}
 
@@ -68,10 +77,43 @@ func validateLogInput(input LogInput) error {
 	return nil
 }
 
+// logSignatures reads the signature status of commits, as `timbers log`
+// does. A failed lookup leaves the entry without signature data unless
+// requireSigned is set, which also refuses any commit without a valid
+// signature.
+func logSignatures(ctx context.Context, commits []git.Commit, requireSigned bool) (map[string]git.Signature, error) {
+	shas := make([]string, len(commits))
+	for idx, commit := range commits {
+		shas[idx] = commit.SHA
+	}
+	sigs, err := git.CommitSignaturesContext(ctx, shas)
+	if err != nil {
+		if requireSigned {
+			return nil, fmt.Errorf("reading commit signatures: %w", err)
+		}
+		return nil, nil //nolint:nilnil // no signature data is a valid, recordable outcome
+	}
+	if !requireSigned {
+		return sigs, nil
+	}
+	var unsigned []string
+	for _, commit := range commits {
+		if !sigs[commit.SHA].Valid() {
+			unsigned = append(unsigned, commit.Short)
+		}
+	}
+	if len(unsigned) > 0 {
+		return nil, errors.New("require_signed: commits without a valid signature: " + strings.Join(unsigned, ", "))
+	}
+	return sigs, nil
+}
+
 // buildLogEntry creates a ledger entry from pending commits and user input.
+// sigs, when non-nil, supplies the signature status recorded per commit.
 func buildLogEntry(
 	storage *ledger.Storage,
 	commits []git.Commit,
+	sigs map[string]git.Signature,
 	input LogInput,
 ) (*ledger.Entry, error) {
 	what := input.What
@@ -94,6 +136,7 @@ func buildLogEntry(
 
 	fromRef := commits[len(commits)-1].SHA + "^"
 	diffstat, _ := storage.GetDiffstat(fromRef, anchor)
+	files, _ := storage.WorksetFiles(commits)
 	now := time.Now().UTC()
 
 	workItems, contributors, err := resolveLogMetadata(commits, input)
@@ -104,18 +147,18 @@ func buildLogEntry(
 	return &ledger.Entry{
 		Schema:    ledger.SchemaVersion,
 		Kind:      ledger.KindEntry,
-		ID:        ledger.GenerateID(anchor, now),
+		ID:        storage.NewID(anchor, now),
 		CreatedAt: now,
+		StartedAt: ledger.EarliestAuthored(commits),
 		UpdatedAt: now,
 		Workset: ledger.Workset{
 			AnchorCommit: anchor,
 			Commits:      commitSHAs,
 			Range:        rangeStr,
-			Diffstat: &ledger.Diffstat{
-				Files:      diffstat.Files,
-				Insertions: diffstat.Insertions,
-				Deletions:  diffstat.Deletions,
-			},
+			Diffstat:     ledger.NewDiffstat(diffstat, input.Numstat),
+			CommitMeta:   ledger.NewCommitMeta(commits, sigs),
+			Files:        files,
+			Branch:       git.AttachedBranch(),
 		},
 		Summary: ledger.Summary{
 			What: what,
@@ -23,55 +23,67 @@ type mockGitOps struct {
 	commitFiles   map[string][]string
 }
 
-func (m *mockGitOps) HEAD() (string, error) {
+func (m *mockGitOps) HEAD(_ context.Context) (string, error) {
 	return m.headSHA, nil
 }
 
-func (m *mockGitOps) Log(_, _ string) ([]git.Commit, error) {
+func (m *mockGitOps) Log(_ context.Context, _, _ string) ([]git.Commit, error) {
 	return m.logCommits, m.logErr
 }
 
-func (m *mockGitOps) LogFirstParent(_, _ string) ([]git.Commit, error) {
+func (m *mockGitOps) LogFirstParent(_ context.Context, _, _ string) ([]git.Commit, error) {
 	return m.logCommits, m.logErr
 }
 
-func (m *mockGitOps) ResolveCommit(ref string) (string, error) {
+func (m *mockGitOps) ResolveCommit(_ context.Context, ref string) (string, error) {
 	return ref, nil
 }
 
-func (m *mockGitOps) CommitsReachableFrom(_ string) ([]git.Commit, error) {
+func (m *mockGitOps) CommitsReachableFrom(_ context.Context, _ string) ([]git.Commit, error) {
 	return m.reachableFrom, nil
 }
 
-func (m *mockGitOps) IsAncestorOf(ancestor, descendant string) bool {
+func (m *mockGitOps) CommitsReachableFromFirstParent(ctx context.Context, sha string) ([]git.Commit, error) {
+	return m.CommitsReachableFrom(ctx, sha)
+}
+
+func (m *mockGitOps) IsAncestorOf(_ context.Context, ancestor, descendant string) bool {
 	return true
 }
 
-func (m *mockGitOps) IsOnFirstParentLine(sha, head string) bool {
+func (m *mockGitOps) IsOnFirstParentLine(_ context.Context, sha, head string) bool {
 	return true
 }
 
-func (m *mockGitOps) GetDiffstat(_, _ string) (git.Diffstat, error) {
+func (m *mockGitOps) IsShallow(_ context.Context) bool {
+	return false
+}
+
+func (m *mockGitOps) GetDiffstat(_ context.Context, _, _ string) (git.Diffstat, error) {
 	return m.diffstat, nil
 }
 
-func (m *mockGitOps) CommitFiles(sha string) ([]string, error) {
+func (m *mockGitOps) CommitFiles(_ context.Context, sha string) ([]string, error) {
 	if m.commitFiles == nil {
 		return nil, nil
 	}
 	return m.commitFiles[sha], nil
 }
 
-func (m *mockGitOps) CommitFilesMulti(shas []string) (map[string][]string, error) {
+func (m *mockGitOps) CommitFilesMulti(ctx context.Context, shas []string) (map[string][]string, error) {
 	result := make(map[string][]string, len(shas))
 	for _, sha := range shas {
-		files, _ := m.CommitFiles(sha)
+		files, _ := m.CommitFiles(ctx, sha)
 		result[sha] = files
 	}
 	return result, nil
 }
 
-func (m *mockGitOps) DiffNameOnly(fromRef, toRef, pathPrefix string) ([]string, error) {
+func (m *mockGitOps) CommitFilesMultiFirstParent(ctx context.Context, shas []string) (map[string][]string, error) {
+	return m.CommitFilesMulti(ctx, shas)
+}
+
+func (m *mockGitOps) DiffNameOnly(_ context.Context, fromRef, toRef, pathPrefix string) ([]string, error) {
 	return nil, nil
 }
 
@@ -308,6 +320,31 @@ func TestHandleLog_Success(t *testing.T) {
 	}
 }
 
+func TestHandleLog_RecordsCommitMeta(t *testing.T) {
+	gitOps := &mockGitOps{headSHA: "abc123", reachableFrom: []git.Commit{{
+		SHA: "abc123", Short: "abc123", Subject: "test", Author: "Git Author", AuthorEmail: "git@example.com",
+	}}}
+	storage := makeTestStorage(t, gitOps, nil)
+
+	_, out, err := handleLog(storage)(context.Background(), &mcp.CallToolRequest{}, LogInput{
+		What: "feature", Why: "reason", How: "method",
+	})
+	if err != nil {
+		t.Fatalf("handleLog: %v", err)
+	}
+	if meta := out.Entry.Workset.CommitMeta; len(meta) != 1 || meta[0].SHA != "abc123" || meta[0].Author != "Git Author" {
+		t.Errorf("CommitMeta = %+v, want one record for abc123", meta)
+	}
+
+	// abc123 is not a real commit, so its signature cannot be verified.
+	_, _, err = handleLog(storage)(context.Background(), &mcp.CallToolRequest{}, LogInput{
+		What: "feature", Why: "reason", How: "method", RequireSigned: true,
+	})
+	if err == nil {
+		t.Error("require_signed with unverifiable commits should fail")
+	}
+}
+
 func TestHandleLog_WhoReplacesAutomaticContributors(t *testing.T) {
 	gitOps := &mockGitOps{headSHA: "abc123", reachableFrom: []git.Commit{{
 		SHA: "abc123", Short: "abc123", Subject: "test", Author: "Git Author", AuthorEmail: "git@example.com",
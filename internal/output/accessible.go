@@ -0,0 +1,38 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WithAccessible switches human output to linear text a screen reader can
+// follow: no color, box drawing, rules under headings, hyperlinks, or
+// spinner. Commands check IsAccessible to put words in place of symbols
+// (status icons, arrows). JSON output is unaffected. Returns the printer
+// for chaining.
+func (p *Printer) WithAccessible() *Printer {
+	p.accessible = true
+	p.isTTY = false
+	p.color = false
+	p.styles = newStyles(p.theme, false)
+	return p
+}
+
+// IsAccessible returns true if human output is in accessible mode.
+func (p *Printer) IsAccessible() bool {
+	return p.accessible
+}
+
+// Heading writes title with a rule of the given character beneath it
+// ("=" for a page title, "-" for a section). Accessible mode writes the
+// title alone: a screen reader would read the rule out character by
+// character.
+func (p *Printer) Heading(title, rule string) {
+	mustWrite(fmt.Fprintln(p.w, title))
+	if p.accessible {
+		return
+	}
+	mustWrite(fmt.Fprintln(p.w, strings.Repeat(rule, lipgloss.Width(title))))
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrinter_Accessible(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewPrinter(&buf, false, true).WithAccessible()
+
+	if printer.IsTTY() || printer.ColorEnabled() {
+		t.Errorf("IsTTY() = %v, ColorEnabled() = %v; want both false", printer.IsTTY(), printer.ColorEnabled())
+	}
+	printer.Heading("Ledger Status", "-")
+	printer.Section("Repository")
+	printer.Box("Title", "body")
+	if got := printer.Link("https://example.com", "abc1234"); got != "abc1234" {
+		t.Errorf("Link() = %q, want plain text", got)
+	}
+
+	out := buf.String()
+	for _, symbol := range []string{"---", "─", "╭", "\x1b"} {
+		if strings.Contains(out, symbol) {
+			t.Errorf("output contains %q:\n%s", symbol, out)
+		}
+	}
+	for _, text := range []string{"Ledger Status", "Repository", "Title", "body"} {
+		if !strings.Contains(out, text) {
+			t.Errorf("output lacks %q:\n%s", text, out)
+		}
+	}
+}
+
+func TestPrinter_Heading(t *testing.T) {
+	var buf bytes.Buffer
+	NewPrinter(&buf, false, false).Heading("Recent Work", "-")
+	if got, want := buf.String(), "Recent Work\n-----------\n"; got != want {
+		t.Errorf("Heading() = %q, want %q", got, want)
+	}
+}
@@ -21,12 +21,26 @@
 //	printer.Println("Some text")
 //	printer.Print("Formatted: %s\n", value)
 //
+// Helpers that only report outcomes can take a Reporter — the structured
+// subset of Printer — so tests pass a RecordingPrinter and assert on the
+// results, errors, and warnings it captured:
+//
+//	recorder := output.NewRecordingPrinter()
+//	err := checkCleanTree(isDirty, false, recorder)
+//	recorder.Err()      // the error reported
+//	recorder.Warnings() // formatted warnings, in order
+//
 // # JSON Mode
 //
 // When JSON mode is enabled (via --json flag), all output is structured:
 //
 //	// Success: {"message": "...", "id": "...", ...}
-//	// Error: {"error": "message", "code": N}
+//	// Error: {"error": "message", "code": N, "error_code": "USER_ERROR"}
+//
+// Warning and Info messages are collected in JSON mode and attached to the
+// next object written as a "warnings" array, rather than printed separately.
+// A result that is not an object (an entry array) leaves them for stderr,
+// as a {"warnings": [...]} object.
 //
 // # Styling
 //
@@ -47,6 +61,8 @@
 //	output.ExitUserError   // 1: User error (bad args, missing fields)
 //	output.ExitSystemError // 2: System error (git failed, I/O error)
 //	output.ExitConflict    // 3: Conflict (entry exists, state mismatch)
+//	output.ExitPartial     // 4: Partial (some items of a batch failed)
+//	output.ExitCanceled    // 130: Canceled (SIGINT/SIGTERM; any error wrapping context.Canceled)
 //
 // # Error Types
 //
@@ -57,5 +73,8 @@
 //	output.NewConflictError("entry already exists")
 //
 // These errors carry exit codes that are used for both JSON error output
-// and process exit codes.
+// and process exit codes. WithID adds a stable identifier (ErrCode*) that
+// JSON errors report as "error_code":
+//
+//	output.NewUserError("entry not found: " + id).WithID(output.ErrCodeEntryNotFound)
 package output
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"context"
+	"errors"
+)
+
+// Error identifiers. JSON errors carry one as "error_code" next to the
+// numeric exit "code", so agents can branch on the kind of failure without
+// parsing the English message. Identifiers are stable: new ones may be
+// added, existing ones are never renamed or reused.
+const (
+	// Class defaults, used when no more specific identifier applies.
+	ErrCodeUser     = "USER_ERROR"
+	ErrCodeSystem   = "SYSTEM_ERROR"
+	ErrCodeConflict = "CONFLICT"
+	ErrCodePartial  = "PARTIAL"
+	ErrCodeCanceled = "CANCELED"
+
+	ErrCodeTimeout       = "TIMEOUT"         // --timeout expired
+	ErrCodeNotARepo      = "NOT_A_REPO"      // not inside a git repository
+	ErrCodeGitFailed     = "GIT_FAILED"      // a git command failed
+	ErrCodeEntryExists   = "ENTRY_EXISTS"    // an entry or ack with this ID already exists
+	ErrCodeEntryNotFound = "ENTRY_NOT_FOUND" // no entry with this ID
+	ErrCodeDuplicate     = "DUPLICATE_ENTRY" // log refused a near-duplicate entry
+	ErrCodeMissingWhat   = "MISSING_WHAT"    // log has no what
+	ErrCodeMissingWhy    = "MISSING_WHY"     // log needs --why
+	ErrCodeMissingHow    = "MISSING_HOW"     // log needs --how
+	ErrCodeReadOnly      = "READ_ONLY"       // refused under --read-only
+	ErrCodeInvalidQuery  = "INVALID_QUERY"   // --query does not compile or fails
+	ErrCodeLedgerLocked  = "LEDGER_LOCKED"   // another timbers process holds the ledger write lock
+)
+
+// WithID sets the error's identifier. Returns the error for chaining:
+//
+//	output.NewUserError("entry not found: " + id).WithID(output.ErrCodeEntryNotFound)
+func (e *ExitError) WithID(id string) *ExitError {
+	e.ID = id
+	return e
+}
+
+// ErrorID returns the identifier for err: the first ID set on an ExitError
+// in its chain (so a generic wrapper keeps its cause's ID), else the
+// default for its exit code. Errors wrapping context.DeadlineExceeded are
+// TIMEOUT and context.Canceled CANCELED, whatever their exit code.
+func ErrorID(err error) string {
+	for next := err; next != nil; {
+		var exitErr *ExitError
+		if !errors.As(next, &exitErr) {
+			break
+		}
+		if exitErr.ID != "" {
+			return exitErr.ID
+		}
+		next = exitErr.Cause
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrCodeTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrCodeCanceled
+	}
+	switch GetExitCode(err) {
+	case ExitSystemError:
+		return ErrCodeSystem
+	case ExitConflict:
+		return ErrCodeConflict
+	case ExitPartial:
+		return ErrCodePartial
+	default:
+		return ErrCodeUser
+	}
+}
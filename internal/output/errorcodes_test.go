@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorID(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "user default", err: NewUserError("bad"), want: ErrCodeUser},
+		{name: "system default", err: NewSystemError("bad"), want: ErrCodeSystem},
+		{name: "conflict default", err: NewConflictError("bad"), want: ErrCodeConflict},
+		{name: "untyped error", err: errors.New("bad"), want: ErrCodeUser},
+		{name: "explicit ID", err: NewUserError("bad").WithID(ErrCodeMissingWhy), want: ErrCodeMissingWhy},
+		{
+			name: "ID survives fmt wrapping",
+			err:  fmt.Errorf("logging: %w", NewConflictError("exists").WithID(ErrCodeEntryExists)),
+			want: ErrCodeEntryExists,
+		},
+		{
+			name: "generic wrapper keeps its cause's ID",
+			err:  NewSystemErrorWithCause("failed", NewSystemError("no repo").WithID(ErrCodeNotARepo)),
+			want: ErrCodeNotARepo,
+		},
+		{name: "timeout", err: NewSystemErrorWithCause("git timed out", context.DeadlineExceeded), want: ErrCodeTimeout},
+		{name: "canceled", err: fmt.Errorf("stopped: %w", context.Canceled), want: ErrCodeCanceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorID(tt.err); got != tt.want {
+				t.Errorf("ErrorID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrinter_Error_ErrorCode(t *testing.T) {
+	var buf bytes.Buffer
+	NewPrinter(&buf, true, false).Error(NewUserError("entry not found: x").WithID(ErrCodeEntryNotFound))
+
+	var result struct {
+		Code      int    `json:"code"`
+		ErrorCode string `json:"error_code"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("parse %q: %v", buf.String(), err)
+	}
+	if result.Code != ExitUserError || result.ErrorCode != ErrCodeEntryNotFound {
+		t.Errorf("got code %d, error_code %q; want %d, %q", result.Code, result.ErrorCode, ExitUserError, ErrCodeEntryNotFound)
+	}
+}
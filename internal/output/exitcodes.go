@@ -1,18 +1,26 @@
 // Package output provides structured output and error handling for the timbers CLI.
 package output
 
-import "errors"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
 
 // Exit codes following the specification:
 // 0 = Success
 // 1 = User error (bad args, missing fields, not found)
 // 2 = System error (git failed, I/O error)
 // 3 = Conflict (entry exists, state mismatch)
+// 4 = Partial (a batch operation where some items failed; see NewPartialError)
+// 130 = Canceled (interrupted by SIGINT/SIGTERM; 128 + SIGINT, as shells report)
 const (
 	ExitSuccess     = 0
 	ExitUserError   = 1
 	ExitSystemError = 2
 	ExitConflict    = 3
+	ExitPartial     = 4
+	ExitCanceled    = 130
 )
 
 // ExitError is an error that carries an exit code for the CLI.
@@ -20,6 +28,9 @@ type ExitError struct {
 	Code    int
 	Message string
 	Cause   error
+	// ID is the stable error identifier (ErrCode*) reported in JSON as
+	// "error_code". Empty means the default for Code; see ErrorID.
+	ID string
 }
 
 // Error implements the error interface.
@@ -69,11 +80,15 @@ func NewConflictError(message string) *ExitError {
 }
 
 // GetExitCode extracts the exit code from an error.
-// Returns ExitSuccess for nil, ExitUserError for non-ExitError errors.
+// Returns ExitSuccess for nil, ExitCanceled for anything wrapping
+// context.Canceled, and ExitUserError for non-ExitError errors.
 func GetExitCode(err error) int {
 	if err == nil {
 		return ExitSuccess
 	}
+	if errors.Is(err, context.Canceled) {
+		return ExitCanceled
+	}
 
 	var exitErr *ExitError
 	if errors.As(err, &exitErr) {
@@ -83,3 +98,38 @@ func GetExitCode(err error) int {
 	// Default to user error for untyped errors
 	return ExitUserError
 }
+
+// ErrorDetailer is implemented by errors that carry structured context about
+// what failed — for example the git command, exit status, and stderr behind
+// a "git command failed" message. Printer.Error looks for one anywhere in the
+// error chain: JSON errors gain a "details" object and human errors gain a
+// one-line context hint.
+type ErrorDetailer interface {
+	error
+	// ErrorDetails returns the machine-readable context for JSON output.
+	ErrorDetails() map[string]any
+	// DetailLine returns a short human-readable context line.
+	DetailLine() string
+}
+
+// ErrorJSON returns JSON-formatted error bytes.
+// Format: {"error": "message", "code": N, "error_code": "USER_ERROR"}, with
+// the default identifier for the exit code.
+func ErrorJSON(message string, code int) []byte {
+	return errorJSON(message, code, ErrorID(&ExitError{Code: code}), nil)
+}
+
+// errorJSON encodes an error object, with a "details" object from an
+// ErrorDetailer when details is non-nil.
+func errorJSON(message string, code int, id string, details map[string]any) []byte {
+	data := map[string]any{
+		"error":      message,
+		"code":       code,
+		"error_code": id,
+	}
+	if details != nil {
+		data["details"] = details
+	}
+	result, _ := json.Marshal(data)
+	return result
+}
@@ -2,7 +2,9 @@
 package output
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -16,6 +18,8 @@ func TestExitCodeConstants(t *testing.T) {
 		{"ExitUserError", ExitUserError, 1},
 		{"ExitSystemError", ExitSystemError, 2},
 		{"ExitConflict", ExitConflict, 3},
+		{"ExitPartial", ExitPartial, 4},
+		{"ExitCanceled", ExitCanceled, 130},
 	}
 
 	for _, tt := range tests {
@@ -118,6 +122,16 @@ func TestGetExitCode(t *testing.T) {
 			err:      NewConflictError("duplicate"),
 			expected: ExitConflict,
 		},
+		{
+			name:     "system error caused by cancellation",
+			err:      NewSystemErrorWithCause("git push canceled", fmt.Errorf("push: %w", context.Canceled)),
+			expected: ExitCanceled,
+		},
+		{
+			name:     "deadline is not a cancellation",
+			err:      NewSystemErrorWithCause("git push timed out", context.DeadlineExceeded),
+			expected: ExitSystemError,
+		},
 		{
 			name:     "regular error defaults to user error",
 			err:      errors.New("some error"),
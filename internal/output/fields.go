@@ -33,16 +33,22 @@ func Separator() Field { return Field{} }
 // measures raw bytes, so embedded ANSI escapes (or wide runes) would throw off
 // the wrap points and column alignment. Styling is applied here, after wrapping.
 func (p *Printer) FieldsBox(title string, fields []Field) {
-	p.Box(title, p.renderFields(fields, p.boxContentWidth()))
+	p.Box(title, p.renderFields(fields, p.boxContentWidth(), "  "))
+}
+
+// panelWidth returns the terminal width set by WithWidth, or
+// defaultPanelWidth when none is known.
+func (p *Printer) panelWidth() int {
+	if p.width <= 0 {
+		return defaultPanelWidth
+	}
+	return p.width
 }
 
 // boxContentWidth returns the wrap width available for field values,
 // accounting for the box border and horizontal padding when at a TTY.
 func (p *Printer) boxContentWidth() int {
-	width := p.width
-	if width <= 0 {
-		width = defaultPanelWidth
-	}
+	width := p.panelWidth()
 	if p.isTTY {
 		// rounded border (2 cols) + Padding(0, 1) (2 cols)
 		width -= 4
@@ -50,10 +56,10 @@ func (p *Printer) boxContentWidth() int {
 	return max(width, 1)
 }
 
-// renderFields builds the aligned, wrapped panel body as a single string.
-func (p *Printer) renderFields(fields []Field, width int) string {
+// renderFields builds the aligned, wrapped panel body as a single string,
+// with gap between the key column and the values.
+func (p *Printer) renderFields(fields []Field, width int, gap string) string {
 	keyWidth := maxKeyWidth(fields)
-	const gap = "  "
 	indent := keyWidth + len(gap)
 	valueWidth := max(width-indent, 1)
 
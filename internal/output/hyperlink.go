@@ -0,0 +1,24 @@
+package output
+
+// Hyperlink wraps text in an OSC 8 terminal hyperlink to url. Terminals
+// that support OSC 8 make text clickable; others ignore the escapes and
+// show text alone.
+func Hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// Link returns text as a hyperlink to url when the printer writes styled
+// output to a terminal, and text unchanged otherwise or when url is empty,
+// so piped output and JSON never carry escapes.
+func (p *Printer) Link(url, text string) string {
+	if !p.LinksEnabled() || url == "" {
+		return text
+	}
+	return Hyperlink(url, text)
+}
+
+// LinksEnabled reports whether Link renders hyperlinks. Callers can check
+// it before doing work (a git call, say) to build link targets.
+func (p *Printer) LinksEnabled() bool {
+	return p.isTTY && !p.json
+}
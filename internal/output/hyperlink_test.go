@@ -0,0 +1,33 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPrinter_Link(t *testing.T) {
+	const url = "https://github.com/o/r/commit/abc1234"
+
+	tty := NewPrinter(&bytes.Buffer{}, false, true)
+	got := tty.Link(url, "abc1234")
+	if want := "\x1b]8;;" + url + "\x1b\\abc1234\x1b]8;;\x1b\\"; got != want {
+		t.Errorf("Link() = %q, want %q", got, want)
+	}
+	if w := lipgloss.Width(got); w != len("abc1234") {
+		t.Errorf("display width = %d, want the text's width", w)
+	}
+	if got := tty.Link("", "abc1234"); got != "abc1234" {
+		t.Errorf("Link() with no url = %q, want plain text", got)
+	}
+
+	for name, printer := range map[string]*Printer{
+		"piped": NewPrinter(&bytes.Buffer{}, false, false),
+		"json":  NewPrinter(&bytes.Buffer{}, true, true),
+	} {
+		if got := printer.Link(url, "abc1234"); got != "abc1234" {
+			t.Errorf("%s: Link() = %q, want plain text", name, got)
+		}
+	}
+}
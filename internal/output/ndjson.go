@@ -0,0 +1,57 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// WithNDJSON switches a structured-output printer to newline-delimited
+// JSON: a result that is an array is written one compact element per line,
+// each as soon as it is encoded, and any other result as a single line.
+// Implies JSON mode. Returns the printer for chaining.
+func (p *Printer) WithNDJSON() *Printer {
+	p.json = true
+	p.ndjson = true
+	return p
+}
+
+// IsNDJSON returns true if results are written as newline-delimited JSON.
+func (p *Printer) IsNDJSON() bool {
+	return p.ndjson
+}
+
+// writeNDJSON writes each value as one line of compact JSON. When expand is
+// set, array values are written element by element instead; filtered
+// results are not expanded, matching jq -c.
+func writeNDJSON(w io.Writer, values []any, expand bool) error {
+	enc := json.NewEncoder(w)
+	for _, value := range values {
+		items := []any{value}
+		if expand {
+			items = arrayElements(value)
+		}
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				return fmt.Errorf("encoding JSON: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// arrayElements returns the elements of value if it is a slice or array
+// (other than raw bytes, which encode as a string or pre-encoded JSON), or
+// value alone otherwise.
+func arrayElements(value any) []any {
+	reflected := reflect.ValueOf(value)
+	if (reflected.Kind() != reflect.Slice && reflected.Kind() != reflect.Array) || reflected.Type().Elem().Kind() == reflect.Uint8 {
+		return []any{value}
+	}
+	items := make([]any, reflected.Len())
+	for i := range items {
+		items[i] = reflected.Index(i).Interface()
+	}
+	return items
+}
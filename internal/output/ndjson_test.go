@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrinter_NDJSON(t *testing.T) {
+	type item struct {
+		ID string `json:"id"`
+	}
+
+	tests := []struct {
+		name   string
+		data   any
+		filter JSONFilter
+		want   string
+	}{
+		{
+			name: "array is one element per line",
+			data: []item{{ID: "a"}, {ID: "b"}},
+			want: "{\"id\":\"a\"}\n{\"id\":\"b\"}\n",
+		},
+		{
+			name: "empty array writes nothing",
+			data: []item{},
+			want: "",
+		},
+		{
+			name: "object is a single line",
+			data: map[string]any{"status": "ok"},
+			want: "{\"status\":\"ok\"}\n",
+		},
+		{
+			name:   "filtered results are not expanded",
+			data:   []item{{ID: "a"}},
+			filter: func(any) ([]any, error) { return []any{[]any{"a", "b"}}, nil },
+			want:   "[\"a\",\"b\"]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			printer := NewPrinter(&buf, false, false).WithNDJSON()
+			if tt.filter != nil {
+				printer = printer.WithFilter(tt.filter)
+			}
+			if !printer.IsJSON() || !printer.IsNDJSON() {
+				t.Fatal("WithNDJSON should imply JSON mode")
+			}
+			if err := printer.WriteJSON(tt.data); err != nil {
+				t.Fatalf("WriteJSON() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,9 +16,41 @@ type Printer struct {
 	w      io.Writer
 	errW   io.Writer
 	json   bool
+	yaml   bool
+	ndjson bool
 	isTTY  bool
-	width  int
-	styles *Styles
+	color  bool
+	// accessible renders linear, symbol-free text; see WithAccessible.
+	accessible bool
+	width      int
+	theme      Theme
+	styles     *Styles
+
+	// warnings collects Warning and Info messages in JSON mode until the
+	// next JSON object is written.
+	warnings  []string
+	streams   StreamPolicy
+	verbosity Verbosity
+
+	// filter, when set, transforms each JSON result into the values that
+	// are written instead; see WithFilter.
+	filter JSONFilter
+}
+
+// JSONFilter maps a JSON result to the values written in its place, one
+// JSON document each. It receives the result with warnings attached.
+type JSONFilter func(data any) ([]any, error)
+
+// StreamPolicy decides which writer errors and warnings use when that is a
+// matter of taste rather than protocol. The zero value is the default:
+// JSON errors on the main writer, human warnings on the error writer.
+type StreamPolicy struct {
+	// JSONErrorsToStderr writes JSON-mode errors to the error writer, so
+	// stdout carries only successful results.
+	JSONErrorsToStderr bool
+	// WarningsToStdout writes human-mode warnings and info to the main
+	// writer, interleaved with the output they refer to.
+	WarningsToStdout bool
 }
 
 // Styles holds lipgloss styles for human-readable output.
@@ -38,43 +70,17 @@ type Styles struct {
 
 // NewPrinter creates a new Printer.
 // If json is true, output will be JSON formatted.
-// If isTTY is true, colors will be enabled for human output.
+// If isTTY is true, output is styled for a terminal: boxes, links, and
+// color, unless the environment opts out of color (NoColorEnv).
 func NewPrinter(writer io.Writer, jsonMode bool, isTTY bool) *Printer {
-	styles := &Styles{
-		Error:   lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "9", Dark: "9"}).Bold(true), // Red
-		Success: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "10", Dark: "10"}),          // Green
-		Warning: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "11", Dark: "11"}),          // Yellow
-		Bold:    lipgloss.NewStyle().Bold(true),
-		Dim:     lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "8", Dark: "7"}),
-		Title:   lipgloss.NewStyle().Bold(true).Foreground(lipgloss.AdaptiveColor{Light: "12", Dark: "12"}), // Blue
-		Muted:   lipgloss.NewStyle().Faint(true),
-		Key:     lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "14", Dark: "14"}), // Cyan
-		Value:   lipgloss.NewStyle(),
-		Border:  lipgloss.AdaptiveColor{Light: "8", Dark: "7"},                                   // Gray
-		Accent:  lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "13", Dark: "13"}), // Magenta
-	}
-
-	// Disable colors if not a TTY
-	if !isTTY {
-		styles.Error = lipgloss.NewStyle()
-		styles.Success = lipgloss.NewStyle()
-		styles.Warning = lipgloss.NewStyle()
-		styles.Bold = lipgloss.NewStyle()
-		styles.Dim = lipgloss.NewStyle()
-		styles.Title = lipgloss.NewStyle()
-		styles.Muted = lipgloss.NewStyle()
-		styles.Key = lipgloss.NewStyle()
-		styles.Value = lipgloss.NewStyle()
-		styles.Border = lipgloss.NoColor{}
-		styles.Accent = lipgloss.NewStyle()
-	}
-
+	color := isTTY && !NoColorEnv()
 	return &Printer{
 		w:      writer,
 		errW:   writer,
 		json:   jsonMode,
 		isTTY:  isTTY,
-		styles: styles,
+		color:  color,
+		styles: newStyles(Theme{}, color),
 	}
 }
 
@@ -86,6 +92,13 @@ func (p *Printer) WithStderr(w io.Writer) *Printer {
 	return p
 }
 
+// WithStreams sets the stream policy for errors and warnings.
+// Returns the printer for chaining.
+func (p *Printer) WithStreams(policy StreamPolicy) *Printer {
+	p.streams = policy
+	return p
+}
+
 // WithWidth sets the terminal width used to wrap panel values (FieldsBox).
 // A non-positive width falls back to a default (defaultPanelWidth).
 // Returns the printer for chaining.
@@ -94,7 +107,8 @@ func (p *Printer) WithWidth(w int) *Printer {
 	return p
 }
 
-// IsJSON returns true if the printer is in JSON mode.
+// IsJSON returns true if the printer is in JSON mode (or YAML mode, which
+// shares the structured schema).
 func (p *Printer) IsJSON() bool {
 	return p.json
 }
@@ -126,7 +140,9 @@ func (p *Printer) Success(data map[string]any) error {
 }
 
 // Error outputs an error.
-// For JSON mode, outputs {"error": "...", "code": N} to stdout.
+// For JSON mode, outputs {"error": "...", "code": N, "error_code": "..."}
+// (see ErrorID), plus any collected
+// warnings, to stdout (or stderr under StreamPolicy.JSONErrorsToStderr).
 // For human mode, outputs a styled error message to stderr (if set).
 func (p *Printer) Error(err error) {
 	exitErr := &ExitError{}
@@ -137,34 +153,46 @@ func (p *Printer) Error(err error) {
 			Message: err.Error(),
 		}
 	}
+	if code := GetExitCode(err); code != exitErr.Code {
+		exitErr = &ExitError{Code: code, Message: exitErr.Message}
+	}
+
+	var detailer ErrorDetailer
+	hasDetails := errors.As(err, &detailer)
 
 	if p.json {
-		mustWrite(p.w.Write(ErrorJSON(exitErr.Message, exitErr.Code)))
-		mustWrite(fmt.Fprintln(p.w))
+		var details map[string]any
+		if hasDetails {
+			details = detailer.ErrorDetails()
+		}
+		encoded := errorJSON(exitErr.Message, exitErr.Code, ErrorID(err), details)
+		encoded, _ = p.spliceWarnings(encoded)
+		errW := p.w
+		if p.streams.JSONErrorsToStderr {
+			errW = p.errW
+		}
+		if p.yaml {
+			mustWrite(0, writeYAML(errW, []any{json.RawMessage(encoded)}))
+			return
+		}
+		mustWrite(errW.Write(encoded))
+		mustWrite(fmt.Fprintln(errW))
 		return
 	}
 
 	// Human-readable error goes to errW (stderr when set)
 	mustWrite(fmt.Fprintf(p.errW, "%s: %s\n", p.styles.Error.Render("Error"), exitErr.Message))
-}
-
-// Warn outputs a warning message.
-// For JSON mode, outputs {"warning": "..."} to stdout.
-// For human mode, outputs a styled warning to stderr (if set).
-func (p *Printer) Warn(format string, args ...any) {
-	msg := fmt.Sprintf(format, args...)
-	if p.json {
-		data := map[string]any{"warning": msg}
-		_ = p.writeJSON(data)
-		return
+	if hasDetails {
+		if line := detailer.DetailLine(); line != "" {
+			mustWrite(fmt.Fprintf(p.errW, "  %s\n", p.styles.Dim.Render(line)))
+		}
 	}
-	mustWrite(fmt.Fprintf(p.errW, "%s: %s\n", p.styles.Warning.Render("Warning"), msg))
 }
 
 // Stderr writes a message to the error writer (for status hints when piped).
-// No-op in JSON mode (structured protocol handles metadata).
+// No-op in JSON mode (structured protocol handles metadata) and when quiet.
 func (p *Printer) Stderr(format string, args ...any) {
-	if p.json {
+	if p.json || p.verbosity == VerbosityQuiet {
 		return
 	}
 	mustWrite(fmt.Fprintf(p.errW, format, args...))
@@ -180,33 +208,50 @@ func (p *Printer) Println(args ...any) {
 	mustWrite(fmt.Fprintln(p.w, args...))
 }
 
-// writeJSON encodes data as JSON and writes it.
+// writeJSON encodes data as JSON (or YAML; see WithYAML), with any
+// collected warnings, and writes it.
 func (p *Printer) writeJSON(data any) error {
+	data = p.attachWarnings(data)
+	defer p.flushWarnings()
+	results := []any{data}
+	if p.filter != nil {
+		filtered, err := p.filter(data)
+		if err != nil {
+			return NewUserError(err.Error()).WithID(ErrCodeInvalidQuery)
+		}
+		results = filtered
+	}
+	switch {
+	case p.yaml:
+		return writeYAML(p.w, results)
+	case p.ndjson:
+		return writeNDJSON(p.w, results, p.filter == nil)
+	}
+
 	enc := json.NewEncoder(p.w)
 	enc.SetIndent("", "  ")
-	if err := enc.Encode(data); err != nil {
-		return fmt.Errorf("encoding JSON: %w", err)
+	for _, result := range results {
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("encoding JSON: %w", err)
+		}
 	}
 	return nil
 }
 
+// WithFilter returns the printer with results passed through filter before
+// they are written (a --query expression, say). Errors are never filtered:
+// a consumer must always be able to see them.
+func (p *Printer) WithFilter(filter JSONFilter) *Printer {
+	p.filter = filter
+	return p
+}
+
 // WriteJSON encodes any data as JSON and writes it.
 // Use this for outputting structs or other types that aren't maps.
 func (p *Printer) WriteJSON(data any) error {
 	return p.writeJSON(data)
 }
 
-// ErrorJSON returns JSON-formatted error bytes.
-// Format: {"error": "message", "code": N}
-func ErrorJSON(message string, code int) []byte {
-	data := map[string]any{
-		"error": message,
-		"code":  code,
-	}
-	result, _ := json.Marshal(data)
-	return result
-}
-
 // mustWrite panics if a write operation fails.
 // Use this to wrap write operations that should never fail
 // (e.g., writing to stdout/stderr or buffers).
@@ -216,68 +261,6 @@ func mustWrite(_ int, err error) {
 	}
 }
 
-// Table renders a simple table with column alignment.
-// Headers are rendered in Bold style. Column widths are auto-calculated.
-// For non-TTY output, renders plain text with space padding.
-func (p *Printer) Table(headers []string, rows [][]string) {
-	if len(headers) == 0 {
-		return
-	}
-
-	widths := calcColumnWidths(headers, rows)
-	p.printTableHeaders(headers, widths)
-	p.printTableRows(rows, widths)
-}
-
-// calcColumnWidths computes the max width for each column.
-func calcColumnWidths(headers []string, rows [][]string) []int {
-	widths := make([]int, len(headers))
-	for i, h := range headers {
-		widths[i] = len(h)
-	}
-	for _, row := range rows {
-		for i, cell := range row {
-			if i < len(widths) && len(cell) > widths[i] {
-				widths[i] = len(cell)
-			}
-		}
-	}
-	return widths
-}
-
-// printTableHeaders renders the table header row.
-func (p *Printer) printTableHeaders(headers []string, widths []int) {
-	for i, h := range headers {
-		padded := padRight(h, widths[i])
-		if i > 0 {
-			mustWrite(fmt.Fprint(p.w, "  "))
-		}
-		mustWrite(fmt.Fprint(p.w, p.styles.Bold.Render(padded)))
-	}
-	mustWrite(fmt.Fprintln(p.w))
-}
-
-// printTableRows renders all data rows.
-func (p *Printer) printTableRows(rows [][]string, widths []int) {
-	for _, row := range rows {
-		p.printTableRow(row, widths)
-	}
-}
-
-// printTableRow renders a single data row.
-func (p *Printer) printTableRow(row []string, widths []int) {
-	for i, cell := range row {
-		if i >= len(widths) {
-			break
-		}
-		if i > 0 {
-			mustWrite(fmt.Fprint(p.w, "  "))
-		}
-		mustWrite(fmt.Fprint(p.w, padRight(cell, widths[i])))
-	}
-	mustWrite(fmt.Fprintln(p.w))
-}
-
 // Box renders content in a bordered box with an optional title.
 // For TTY output, uses lipgloss.RoundedBorder.
 // For non-TTY output, renders plain text without borders.
@@ -306,28 +289,15 @@ func (p *Printer) Box(title string, content string) {
 	mustWrite(fmt.Fprintln(p.w, style.Render(boxContent)))
 }
 
-// Section renders a section header with underline.
-// Adds a blank line before the header.
+// Section renders a section header with underline (omitted in accessible
+// mode). Adds a blank line before the header.
 func (p *Printer) Section(title string) {
 	mustWrite(fmt.Fprintln(p.w))
 	mustWrite(fmt.Fprintln(p.w, p.styles.Title.Render(title)))
-	// Create underline matching title length
-	underline := strings.Repeat("─", len(title))
-	mustWrite(fmt.Fprintln(p.w, p.styles.Muted.Render(underline)))
-}
-
-// KeyValue renders a key-value pair with styles applied.
-// Format: "Key: Value"
-func (p *Printer) KeyValue(key string, value string) {
-	styledKey := p.styles.Key.Render(key + ":")
-	styledValue := p.styles.Value.Render(value)
-	mustWrite(fmt.Fprintf(p.w, "%s %s\n", styledKey, styledValue))
-}
-
-// padRight pads a string with spaces to reach the target width.
-func padRight(s string, width int) string {
-	if len(s) >= width {
-		return s
+	if p.accessible {
+		return
 	}
-	return s + strings.Repeat(" ", width-len(s))
+	// Create underline matching the title's display width (it may be a link)
+	underline := strings.Repeat("─", lipgloss.Width(title))
+	mustWrite(fmt.Fprintln(p.w, p.styles.Muted.Render(underline)))
 }
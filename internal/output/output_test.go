@@ -2,7 +2,9 @@ package output
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 )
@@ -125,11 +127,11 @@ func TestPrinter_IsJSON(t *testing.T) {
 	}
 }
 
-func TestPrinter_Warn_Human(t *testing.T) {
+func TestPrinter_Warning_Human(t *testing.T) {
 	var buf bytes.Buffer
 	printer := NewPrinter(&buf, false, false)
 
-	printer.Warn("working tree has %s", "uncommitted changes")
+	printer.Warning("working tree has %s", "uncommitted changes")
 
 	output := buf.String()
 	if !strings.Contains(output, "Warning") {
@@ -140,21 +142,6 @@ func TestPrinter_Warn_Human(t *testing.T) {
 	}
 }
 
-func TestPrinter_Warn_JSON(t *testing.T) {
-	var buf bytes.Buffer
-	printer := NewPrinter(&buf, true, false)
-
-	printer.Warn("dirty tree")
-
-	var result map[string]any
-	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
-		t.Fatalf("Failed to parse JSON: %v\nOutput: %s", err, buf.String())
-	}
-	if result["warning"] != "dirty tree" {
-		t.Errorf("warning = %v, want %q", result["warning"], "dirty tree")
-	}
-}
-
 func TestPrinter_WithStderr_Error(t *testing.T) {
 	var stdout, stderr bytes.Buffer
 	printer := NewPrinter(&stdout, false, false).WithStderr(&stderr)
@@ -169,11 +156,11 @@ func TestPrinter_WithStderr_Error(t *testing.T) {
 	}
 }
 
-func TestPrinter_WithStderr_Warn(t *testing.T) {
+func TestPrinter_WithStderr_Warning(t *testing.T) {
 	var stdout, stderr bytes.Buffer
 	printer := NewPrinter(&stdout, false, false).WithStderr(&stderr)
 
-	printer.Warn("check your config")
+	printer.Warning("check your config")
 
 	if stdout.Len() > 0 {
 		t.Errorf("warning should not go to stdout, got: %q", stdout.String())
@@ -197,6 +184,38 @@ func TestPrinter_WithStderr_JSON_Error_StaysOnStdout(t *testing.T) {
 	}
 }
 
+func TestPrinter_WithStreams(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	policy := StreamPolicy{JSONErrorsToStderr: true, WarningsToStdout: true}
+
+	NewPrinter(&stdout, true, false).WithStderr(&stderr).WithStreams(policy).Error(NewUserError("json error"))
+	if stdout.Len() > 0 || !strings.Contains(stderr.String(), `"error":"json error"`) {
+		t.Errorf("JSON error should go to stderr: stdout=%q stderr=%q", stdout.String(), stderr.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	NewPrinter(&stdout, false, false).WithStderr(&stderr).WithStreams(policy).Warning("check your config")
+	if stderr.Len() > 0 || !strings.Contains(stdout.String(), "check your config") {
+		t.Errorf("warning should go to stdout: stdout=%q stderr=%q", stdout.String(), stderr.String())
+	}
+}
+
+func TestPrinter_JSON_Error_Canceled(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewPrinter(&buf, true, false)
+
+	printer.Error(NewSystemErrorWithCause("LLM request failed", context.Canceled))
+
+	var result map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to parse JSON: %v\nOutput: %s", err, buf.String())
+	}
+	if result["code"] != float64(ExitCanceled) {
+		t.Errorf("code = %v, want %d", result["code"], ExitCanceled)
+	}
+}
+
 func TestPrinter_Stderr(t *testing.T) {
 	var stdout, stderr bytes.Buffer
 	printer := NewPrinter(&stdout, false, false).WithStderr(&stderr)
@@ -241,3 +260,83 @@ func TestErrorJSON_Format(t *testing.T) {
 		t.Errorf("code = %d, want %d", parsed.Code, ExitUserError)
 	}
 }
+
+// detailedError is a test ErrorDetailer.
+type detailedError struct{}
+
+func (detailedError) Error() string { return "boom" }
+func (detailedError) ErrorDetails() map[string]any {
+	return map[string]any{"command": "git", "exit_code": 128}
+}
+func (detailedError) DetailLine() string { return "while running: git fetch (exit 128)" }
+
+func TestPrinter_Error_Details(t *testing.T) {
+	err := NewSystemErrorWithCause("git command failed: boom", detailedError{})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewPrinter(&buf, true, false).Error(err)
+
+		var result struct {
+			Error   string         `json:"error"`
+			Details map[string]any `json:"details"`
+		}
+		if jsonErr := json.Unmarshal(buf.Bytes(), &result); jsonErr != nil {
+			t.Fatalf("Failed to parse JSON: %v\nOutput: %s", jsonErr, buf.String())
+		}
+		if result.Details["command"] != "git" {
+			t.Errorf("details = %v, want command=git", result.Details)
+		}
+	})
+
+	t.Run("human", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewPrinter(&buf, false, false).Error(err)
+		if !strings.Contains(buf.String(), "while running: git fetch (exit 128)") {
+			t.Errorf("output missing detail line: %q", buf.String())
+		}
+	})
+
+	t.Run("no details without detailer", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewPrinter(&buf, true, false).Error(NewUserError("plain"))
+		if strings.Contains(buf.String(), "details") {
+			t.Errorf("plain error should not carry details: %s", buf.String())
+		}
+	})
+}
+
+func TestPrinter_WithFilter(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewPrinter(&buf, true, false).WithFilter(func(data any) ([]any, error) {
+		fields, _ := data.(map[string]any)
+		return []any{fields["id"], fields["status"]}, nil
+	})
+
+	if err := printer.WriteJSON(map[string]any{"id": "tb_1", "status": "ok"}); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	if got, want := buf.String(), "\"tb_1\"\n\"ok\"\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestPrinter_WithFilter_Error(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewPrinter(&buf, true, false).WithFilter(func(any) ([]any, error) {
+		return nil, errors.New("bad query")
+	})
+
+	err := printer.WriteJSON(map[string]any{"id": "tb_1"})
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitUserError {
+		t.Fatalf("WriteJSON() error = %v, want user error", err)
+	}
+
+	// Errors themselves are never filtered.
+	buf.Reset()
+	printer.Error(err)
+	if !strings.Contains(buf.String(), `"error":"bad query"`) {
+		t.Errorf("error output = %q", buf.String())
+	}
+}
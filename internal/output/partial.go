@@ -0,0 +1,100 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Item statuses in an ItemResult.
+const (
+	ItemOK     = "ok"
+	ItemFailed = "failed"
+)
+
+// ItemResult is the outcome of one item in a batch operation. Item names
+// what was processed (an entry ID, a commit group); ID is what it produced,
+// when that differs.
+type ItemResult struct {
+	Item      string `json:"item"`
+	ID        string `json:"id,omitempty"`
+	Status    string `json:"status"` // ItemOK or ItemFailed
+	Error     string `json:"error,omitempty"`
+	ErrorCode string `json:"error_code,omitempty"`
+}
+
+// ItemSucceeded returns the result for an item that succeeded.
+func ItemSucceeded(item, id string) ItemResult {
+	return ItemResult{Item: item, ID: id, Status: ItemOK}
+}
+
+// ItemFailedWith returns the result for an item that failed with err.
+func ItemFailedWith(item string, err error) ItemResult {
+	return ItemResult{Item: item, Status: ItemFailed, Error: err.Error(), ErrorCode: ErrorID(err)}
+}
+
+// PartialError is the per-item outcome behind a partial-success error. It
+// is the ErrorDetailer in the chain of an error from NewPartialError, so
+// JSON errors carry the items under "details".
+type PartialError struct {
+	Items []ItemResult
+}
+
+// Error summarizes the failures.
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%d of %d items failed", e.Failed(), len(e.Items))
+}
+
+// Failed returns the number of failed items.
+func (e *PartialError) Failed() int {
+	failed := 0
+	for _, item := range e.Items {
+		if item.Status == ItemFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// ErrorDetails implements ErrorDetailer.
+func (e *PartialError) ErrorDetails() map[string]any {
+	return map[string]any{
+		"succeeded": len(e.Items) - e.Failed(),
+		"failed":    e.Failed(),
+		"items":     e.Items,
+	}
+}
+
+// DetailLine implements ErrorDetailer: each failed item and its error.
+func (e *PartialError) DetailLine() string {
+	failures := make([]string, 0, e.Failed())
+	for _, item := range e.Items {
+		if item.Status == ItemFailed {
+			failures = append(failures, item.Item+": "+item.Error)
+		}
+	}
+	return "failed: " + strings.Join(failures, "; ")
+}
+
+// NewPartialError creates an error for a batch operation where some items
+// succeeded and some failed (exit code 4). Callers report it after the work
+// that did succeed is done, so agents can tell "some items failed" from a
+// total failure and retry only what is marked failed. When every item
+// failed, return the underlying error instead, with its own exit code.
+func NewPartialError(message string, items []ItemResult) *ExitError {
+	return &ExitError{
+		Code:    ExitPartial,
+		Message: message,
+		Cause:   &PartialError{Items: items},
+	}
+}
+
+// AsPartial returns the PartialError in err's chain, or nil when err is not
+// a partial-success error.
+func AsPartial(err error) *PartialError {
+	var partial *PartialError
+	if errors.As(err, &partial) {
+		return partial
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNewPartialError(t *testing.T) {
+	items := []ItemResult{
+		ItemSucceeded("2026-01-15", "tb_1"),
+		ItemFailedWith("2026-01-16", NewConflictError("exists")),
+	}
+	err := fmt.Errorf("batch: %w", NewPartialError("built 1 of 2 entries", items))
+
+	if code := GetExitCode(err); code != ExitPartial {
+		t.Errorf("GetExitCode() = %d, want %d", code, ExitPartial)
+	}
+	if id := ErrorID(err); id != ErrCodePartial {
+		t.Errorf("ErrorID() = %q, want %q", id, ErrCodePartial)
+	}
+	partial := AsPartial(err)
+	if partial == nil || partial.Failed() != 1 || len(partial.Items) != 2 {
+		t.Fatalf("AsPartial() = %+v, want 1 of 2 failed", partial)
+	}
+	if partial.Items[1].ErrorCode != ErrCodeConflict {
+		t.Errorf("failed item error_code = %q, want %q", partial.Items[1].ErrorCode, ErrCodeConflict)
+	}
+	if AsPartial(errors.New("plain")) != nil {
+		t.Error("AsPartial() of a plain error should be nil")
+	}
+}
+
+func TestPrinter_Error_PartialDetails(t *testing.T) {
+	items := []ItemResult{ItemSucceeded("a", ""), ItemFailedWith("b", errors.New("disk full"))}
+	err := NewPartialError("exported 1 of 2 entries", items)
+
+	var buf bytes.Buffer
+	NewPrinter(&buf, true, false).Error(err)
+	var result map[string]any
+	if jsonErr := json.Unmarshal(buf.Bytes(), &result); jsonErr != nil {
+		t.Fatalf("output is not JSON: %v\n%s", jsonErr, buf.String())
+	}
+	details, ok := result["details"].(map[string]any)
+	if !ok || details["succeeded"] != float64(1) || details["failed"] != float64(1) {
+		t.Errorf("details = %v, want succeeded 1, failed 1", result["details"])
+	}
+	if result["code"] != float64(ExitPartial) {
+		t.Errorf("code = %v, want %d", result["code"], ExitPartial)
+	}
+
+	var human bytes.Buffer
+	NewPrinter(&human, false, false).Error(err)
+	if !strings.Contains(human.String(), "failed: b: disk full") {
+		t.Errorf("human error = %q, want the failed item listed", human.String())
+	}
+}
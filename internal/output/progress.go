@@ -0,0 +1,174 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Progress intervals: how often a TTY spinner redraws, and how often JSON
+// mode reports an operation that is still running. Variables so tests can
+// shorten them.
+var (
+	spinnerInterval       = 100 * time.Millisecond
+	progressEventInterval = time.Second
+)
+
+// spinnerFrames are the braille frames the TTY spinner cycles through.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// ProgressEvent is one JSON-mode progress report, written to the error
+// writer as a line of NDJSON. Total is 0 when the amount of work is unknown
+// (waiting on an LLM, say).
+type ProgressEvent struct {
+	Event     string `json:"event"` // always "progress"
+	Label     string `json:"label"`
+	Current   int    `json:"current"`
+	Total     int    `json:"total"`
+	ElapsedMS int64  `json:"elapsed_ms"`
+	Done      bool   `json:"done"`
+}
+
+// Progress reports a long-running operation while it runs. On a TTY error
+// writer it draws a spinner line that Done clears; in JSON mode it writes
+// ProgressEvent lines to the error writer periodically and once more at the
+// end, so stdout keeps its single result. Nothing is drawn until the first
+// interval passes: an operation that finishes quickly reports nothing. It
+// is silent for human output that is not a terminal, in accessible mode,
+// and at VerbosityQuiet.
+//
+// Update and Increment are safe to call from any goroutine. Avoid other
+// error-writer output while a spinner is drawing.
+type Progress struct {
+	w       io.Writer
+	json    bool
+	styles  *Styles
+	label   string
+	total   int
+	started time.Time
+
+	mu      sync.Mutex
+	current int
+	frame   int
+	drawn   bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Progress starts reporting an operation named label over total steps
+// (0 when unknown). Call Done when the operation finishes, even on error:
+//
+//	progress := printer.Progress("Waiting for claude-haiku", 0)
+//	resp, err := client.Complete(ctx, req)
+//	progress.Done()
+func (p *Printer) Progress(label string, total int) *Progress {
+	progress := &Progress{
+		w:       p.errW,
+		json:    p.json,
+		styles:  p.styles,
+		label:   label,
+		total:   total,
+		started: time.Now(),
+	}
+	if p.verbosity == VerbosityQuiet || (!p.json && (p.accessible || !IsTTY(p.errW))) {
+		return progress
+	}
+
+	interval := spinnerInterval
+	if p.json {
+		interval = progressEventInterval
+	}
+	progress.stop = make(chan struct{})
+	progress.done = make(chan struct{})
+	go progress.run(interval)
+	return progress
+}
+
+// Update sets the number of steps completed.
+func (pr *Progress) Update(current int) {
+	pr.mu.Lock()
+	pr.current = current
+	pr.mu.Unlock()
+}
+
+// Increment marks one more step completed.
+func (pr *Progress) Increment() {
+	pr.mu.Lock()
+	pr.current++
+	pr.mu.Unlock()
+}
+
+// Done stops reporting: the spinner line is cleared, or a final event with
+// done set is written, if anything was drawn. Calling it more than once is
+// harmless.
+func (pr *Progress) Done() {
+	if pr.stop == nil {
+		return
+	}
+	select {
+	case <-pr.done:
+		return
+	default:
+	}
+	close(pr.stop)
+	<-pr.done
+	pr.draw(true)
+}
+
+// run redraws every interval until Done.
+func (pr *Progress) run(interval time.Duration) {
+	defer close(pr.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-pr.stop:
+			return
+		case <-ticker.C:
+			pr.draw(false)
+		}
+	}
+}
+
+// draw writes one JSON event or spinner frame. A finished spinner clears
+// its line instead; a finish with nothing drawn writes nothing.
+func (pr *Progress) draw(finished bool) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if finished && !pr.drawn {
+		return
+	}
+	pr.drawn = true
+	if pr.json {
+		encoded, err := json.Marshal(ProgressEvent{
+			Event:     "progress",
+			Label:     pr.label,
+			Current:   pr.current,
+			Total:     pr.total,
+			ElapsedMS: time.Since(pr.started).Milliseconds(),
+			Done:      finished,
+		})
+		if err == nil {
+			_, _ = pr.w.Write(append(encoded, '\n'))
+		}
+		return
+	}
+	if finished {
+		_, _ = fmt.Fprint(pr.w, "\r\x1b[K")
+		return
+	}
+	_, _ = fmt.Fprintf(pr.w, "\r\x1b[K%s", pr.spinnerLine())
+	pr.frame++
+}
+
+// spinnerLine renders the current spinner frame, label, and count.
+func (pr *Progress) spinnerLine() string {
+	line := pr.styles.Accent.Render(spinnerFrames[pr.frame%len(spinnerFrames)]) + " " + pr.label
+	if pr.total > 0 {
+		line += pr.styles.Dim.Render(fmt.Sprintf(" (%d/%d)", pr.current, pr.total))
+	}
+	return line
+}
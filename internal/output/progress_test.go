@@ -0,0 +1,98 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgress_JSON_WritesEvents(t *testing.T) {
+	defer func(prev time.Duration) { progressEventInterval = prev }(progressEventInterval)
+	progressEventInterval = 5 * time.Millisecond
+
+	var out, errBuf bytes.Buffer
+	printer := NewPrinter(&out, true, false).WithStderr(&errBuf)
+
+	progress := printer.Progress("batch", 3)
+	progress.Increment()
+	progress.Update(3)
+	time.Sleep(25 * time.Millisecond)
+	progress.Done()
+	progress.Done()
+
+	if out.Len() != 0 {
+		t.Errorf("stdout = %q, want no progress on stdout", out.String())
+	}
+	if done := strings.Count(errBuf.String(), `"done":true`); done != 1 {
+		t.Errorf("Done twice wrote %d done events, want 1", done)
+	}
+	var events []ProgressEvent
+	scanner := bufio.NewScanner(&errBuf)
+	for scanner.Scan() {
+		var event ProgressEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("line %q is not a progress event: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+	if len(events) < 3 {
+		t.Fatalf("events = %+v, want periodic and done", events)
+	}
+	first, last := events[0], events[len(events)-1]
+	if first.Event != "progress" || first.Label != "batch" || first.Total != 3 || first.Current != 3 || first.Done {
+		t.Errorf("first event = %+v", first)
+	}
+	if !last.Done || last.Current != 3 {
+		t.Errorf("last event = %+v, want done at 3", last)
+	}
+}
+
+func TestProgress_JSON_QuickOperationWritesNothing(t *testing.T) {
+	var out, errBuf bytes.Buffer
+	printer := NewPrinter(&out, true, false).WithStderr(&errBuf)
+
+	printer.Progress("quick", 0).Done()
+	if errBuf.Len() != 0 {
+		t.Errorf("stderr = %q, want nothing before the first interval", errBuf.String())
+	}
+}
+
+func TestProgress_SilentWhenNotTTYOrQuiet(t *testing.T) {
+	tests := []struct {
+		name    string
+		printer func(out, errW *bytes.Buffer) *Printer
+	}{
+		{"human non-TTY", func(out, errW *bytes.Buffer) *Printer {
+			return NewPrinter(out, false, true).WithStderr(errW)
+		}},
+		{"json quiet", func(out, errW *bytes.Buffer) *Printer {
+			return NewPrinter(out, true, false).WithStderr(errW).WithVerbosity(VerbosityQuiet)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errBuf bytes.Buffer
+			progress := tt.printer(&out, &errBuf).Progress("waiting", 0)
+			progress.Increment()
+			progress.Done()
+			if out.Len() != 0 || errBuf.Len() != 0 {
+				t.Errorf("wrote stdout %q, stderr %q; want nothing", out.String(), errBuf.String())
+			}
+		})
+	}
+}
+
+func TestProgress_SpinnerLine(t *testing.T) {
+	progress := &Progress{styles: NewPrinter(&bytes.Buffer{}, false, false).styles, label: "grouping", total: 4}
+	progress.current = 2
+	if got, want := progress.spinnerLine(), spinnerFrames[0]+" grouping (2/4)"; got != want {
+		t.Errorf("spinnerLine() = %q, want %q", got, want)
+	}
+	progress.total = 0
+	if got := progress.spinnerLine(); strings.Contains(got, "(") {
+		t.Errorf("spinnerLine() = %q, want no count without a total", got)
+	}
+}
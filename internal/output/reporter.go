@@ -0,0 +1,138 @@
+package output
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Reporter is the structured subset of Printer: results, errors, warnings,
+// and info, with no layout. Helpers that only report outcomes take a
+// Reporter so tests can pass a RecordingPrinter and assert on what was
+// reported rather than on rendered text. Rendering (tables, boxes, raw
+// prints) stays on the concrete *Printer.
+type Reporter interface {
+	// IsJSON reports whether results are structured (JSON or YAML).
+	IsJSON() bool
+	// Success reports a result object.
+	Success(data map[string]any) error
+	// WriteJSON reports a structured result of any shape.
+	WriteJSON(data any) error
+	// Error reports a failure; the caller still returns err.
+	Error(err error)
+	// Warning reports something the user should know about.
+	Warning(format string, args ...any)
+	// Info reports an informational note.
+	Info(format string, args ...any)
+}
+
+var (
+	_ Reporter = (*Printer)(nil)
+	_ Reporter = (*RecordingPrinter)(nil)
+)
+
+// RecordingPrinter is a Reporter that records each call instead of writing
+// it. JSON sets what IsJSON reports, for code that branches on the mode.
+// It is safe for concurrent use.
+type RecordingPrinter struct {
+	JSON bool
+
+	mu       sync.Mutex
+	results  []any
+	errors   []error
+	warnings []string
+	infos    []string
+}
+
+// NewRecordingPrinter returns an empty recorder in human mode.
+func NewRecordingPrinter() *RecordingPrinter {
+	return &RecordingPrinter{}
+}
+
+// IsJSON reports the recorder's JSON field.
+func (r *RecordingPrinter) IsJSON() bool {
+	return r.JSON
+}
+
+// Success records data as a result.
+func (r *RecordingPrinter) Success(data map[string]any) error {
+	return r.WriteJSON(data)
+}
+
+// WriteJSON records data as a result.
+func (r *RecordingPrinter) WriteJSON(data any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, data)
+	return nil
+}
+
+// Error records err.
+func (r *RecordingPrinter) Error(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, err)
+}
+
+// Warning records the formatted warning.
+func (r *RecordingPrinter) Warning(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.warnings = append(r.warnings, fmt.Sprintf(format, args...))
+}
+
+// Info records the formatted note.
+func (r *RecordingPrinter) Info(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.infos = append(r.infos, fmt.Sprintf(format, args...))
+}
+
+// Results returns the recorded results, in order.
+func (r *RecordingPrinter) Results() []any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]any(nil), r.results...)
+}
+
+// Errors returns the recorded errors, in order.
+func (r *RecordingPrinter) Errors() []error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]error(nil), r.errors...)
+}
+
+// Warnings returns the recorded warnings, in order.
+func (r *RecordingPrinter) Warnings() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.warnings...)
+}
+
+// Infos returns the recorded info notes, in order.
+func (r *RecordingPrinter) Infos() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.infos...)
+}
+
+// LastResult returns the most recent result as an object, or nil when
+// there is none or it is not a map[string]any.
+func (r *RecordingPrinter) LastResult() map[string]any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.results) == 0 {
+		return nil
+	}
+	last, _ := r.results[len(r.results)-1].(map[string]any)
+	return last
+}
+
+// Err returns the most recent error, or nil.
+func (r *RecordingPrinter) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.errors) == 0 {
+		return nil
+	}
+	return r.errors[len(r.errors)-1]
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRecordingPrinter_RecordsCalls(t *testing.T) {
+	recorder := NewRecordingPrinter()
+	if recorder.LastResult() != nil || recorder.Err() != nil {
+		t.Fatal("empty recorder should have no result or error")
+	}
+
+	recorder.Warning("dirty %s", "tree")
+	recorder.Info("anchor self-heals")
+	if err := recorder.Success(map[string]any{"status": "ok"}); err != nil {
+		t.Fatalf("Success() error = %v", err)
+	}
+	if err := recorder.WriteJSON([]string{"a"}); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	conflict := NewConflictError("exists")
+	recorder.Error(conflict)
+
+	if got := recorder.Warnings(); len(got) != 1 || got[0] != "dirty tree" {
+		t.Errorf("Warnings() = %v, want [dirty tree]", got)
+	}
+	if got := recorder.Infos(); len(got) != 1 || got[0] != "anchor self-heals" {
+		t.Errorf("Infos() = %v, want [anchor self-heals]", got)
+	}
+	if got := recorder.Results(); len(got) != 2 {
+		t.Errorf("Results() = %v, want 2 results", got)
+	}
+	if recorder.LastResult() != nil {
+		t.Errorf("LastResult() = %v, want nil for a non-object result", recorder.LastResult())
+	}
+	if !errors.Is(recorder.Err(), conflict) || GetExitCode(recorder.Err()) != ExitConflict {
+		t.Errorf("Err() = %v, want the conflict error", recorder.Err())
+	}
+}
+
+func TestRecordingPrinter_IsJSON(t *testing.T) {
+	recorder := &RecordingPrinter{JSON: true}
+	if !recorder.IsJSON() {
+		t.Error("IsJSON() = false, want true")
+	}
+	if NewRecordingPrinter().IsJSON() {
+		t.Error("new recorder should be in human mode")
+	}
+}
@@ -0,0 +1,179 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// minFitWidth is the narrowest Fit will squeeze the last column to.
+const minFitWidth = 10
+
+// TableOptions tunes TableWith. The zero value is Table: a header row and
+// every cell at full width.
+type TableOptions struct {
+	// NoHeader omits the header row. Headers still define the columns.
+	NoHeader bool
+	// MaxWidths caps the display width of each column; longer cells are
+	// cut with "…". Zero, or a missing entry, means no cap.
+	MaxWidths []int
+	// Fit cuts the last column so rows fit the printer width (WithWidth).
+	Fit bool
+}
+
+// Table renders a simple table with column alignment.
+// Headers are rendered in Bold style. Column widths are auto-calculated from
+// display width, so cells may hold wide runes or pre-styled text.
+// For non-TTY output, renders plain text with space padding.
+func (p *Printer) Table(headers []string, rows [][]string) {
+	p.TableWith(headers, rows, TableOptions{})
+}
+
+// TableWith renders a table like Table, tuned by opts. Truncation only
+// applies at a TTY: piped output degrades to plain aligned text with every
+// cell whole, so scripts never see a cut value.
+func (p *Printer) TableWith(headers []string, rows [][]string, opts TableOptions) {
+	if len(headers) == 0 {
+		return
+	}
+
+	labels := headers
+	if opts.NoHeader {
+		labels = make([]string, len(headers))
+	}
+	if p.isTTY {
+		rows = truncateColumns(rows, opts.MaxWidths)
+		if opts.Fit {
+			rows = fitLastColumn(labels, rows, p.panelWidth())
+		}
+	}
+
+	widths := calcColumnWidths(labels, rows)
+	if !opts.NoHeader {
+		p.printTableHeaders(headers, widths)
+	}
+	p.printTableRows(rows, widths)
+}
+
+// truncateColumns returns rows with each cell cut to its column's cap.
+func truncateColumns(rows [][]string, caps []int) [][]string {
+	if len(caps) == 0 {
+		return rows
+	}
+	cut := make([][]string, len(rows))
+	for r, row := range rows {
+		cut[r] = make([]string, len(row))
+		for i, cell := range row {
+			if i < len(caps) && caps[i] > 0 {
+				cell = ansi.Truncate(cell, caps[i], "…")
+			}
+			cut[r][i] = cell
+		}
+	}
+	return cut
+}
+
+// fitLastColumn cuts the last column so every row fits within width.
+func fitLastColumn(labels []string, rows [][]string, width int) [][]string {
+	last := len(labels) - 1
+	widths := calcColumnWidths(labels, rows)
+	used := 2 * last // column gaps
+	for _, w := range widths[:last] {
+		used += w
+	}
+	caps := make([]int, len(labels))
+	caps[last] = max(width-used, minFitWidth)
+	return truncateColumns(rows, caps)
+}
+
+// calcColumnWidths computes the max display width for each column.
+func calcColumnWidths(headers []string, rows [][]string) []int {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = lipgloss.Width(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], lipgloss.Width(cell))
+			}
+		}
+	}
+	return widths
+}
+
+// printTableHeaders renders the table header row. Like data rows, the last
+// header is not padded.
+func (p *Printer) printTableHeaders(headers []string, widths []int) {
+	for i, h := range headers {
+		padded := h
+		if i < len(headers)-1 {
+			padded = padRight(h, widths[i])
+		}
+		if i > 0 {
+			mustWrite(fmt.Fprint(p.w, "  "))
+		}
+		mustWrite(fmt.Fprint(p.w, p.styles.Bold.Render(padded)))
+	}
+	mustWrite(fmt.Fprintln(p.w))
+}
+
+// printTableRows renders all data rows.
+func (p *Printer) printTableRows(rows [][]string, widths []int) {
+	for _, row := range rows {
+		p.printTableRow(row, widths)
+	}
+}
+
+// printTableRow renders a single data row. The last cell is not padded, so
+// rows carry no trailing whitespace.
+func (p *Printer) printTableRow(row []string, widths []int) {
+	last := min(len(row), len(widths)) - 1
+	for i := 0; i <= last; i++ {
+		if i > 0 {
+			mustWrite(fmt.Fprint(p.w, "  "))
+		}
+		cell := row[i]
+		if i < last {
+			cell = padRight(cell, widths[i])
+		}
+		mustWrite(fmt.Fprint(p.w, cell))
+	}
+	mustWrite(fmt.Fprintln(p.w))
+}
+
+// KeyValue renders a key-value pair with styles applied.
+// Format: "Key: Value"
+func (p *Printer) KeyValue(key string, value string) {
+	styledKey := p.styles.Key.Render(key + ":")
+	styledValue := p.styles.Value.Render(value)
+	mustWrite(fmt.Fprintf(p.w, "%s %s\n", styledKey, styledValue))
+}
+
+// KV renders a block of "Key: Value" lines with the values aligned in one
+// column, like KeyValue for each pair. Long values wrap under the value
+// column; a Separator renders as a blank line. As with FieldsBox, keys and
+// values must be plain text.
+func (p *Printer) KV(pairs []Field) {
+	if len(pairs) == 0 {
+		return
+	}
+	labeled := make([]Field, len(pairs))
+	for i, pair := range pairs {
+		if pair.Key != "" {
+			pair.Key += ":"
+		}
+		labeled[i] = pair
+	}
+	mustWrite(fmt.Fprintln(p.w, p.renderFields(labeled, p.panelWidth(), " ")))
+}
+
+// padRight pads a string with spaces to reach the target display width.
+func padRight(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
@@ -0,0 +1,98 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTableAlignsColumnsByDisplayWidth(t *testing.T) {
+	var buf bytes.Buffer
+	NewPrinter(&buf, false, false).Table([]string{"SHA", "Subject"}, [][]string{
+		{"abc1234", "Fix naïve parser"},
+		{"日本", "Add docs"},
+	})
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	want := []string{
+		"SHA      Subject",
+		"abc1234  Fix naïve parser",
+		"日本     Add docs",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestKVAlignsValues(t *testing.T) {
+	var buf bytes.Buffer
+	NewPrinter(&buf, false, false).KV([]Field{
+		{Key: "Count", Value: "2"},
+		{Key: "Directory", Value: ".timbers"},
+	})
+
+	want := "Count:     2\nDirectory: .timbers\n"
+	if buf.String() != want {
+		t.Errorf("KV output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestKVEmptyWritesNothing(t *testing.T) {
+	var buf bytes.Buffer
+	NewPrinter(&buf, false, false).KV(nil)
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %q", buf.String())
+	}
+}
+
+func TestTableWith(t *testing.T) {
+	headers := []string{"ID", "What"}
+	rows := [][]string{{"c1", "a rather long description"}, {"bb", "short"}}
+
+	tests := []struct {
+		name  string
+		isTTY bool
+		width int
+		opts  TableOptions
+		want  string
+	}{
+		{
+			name: "no header",
+			opts: TableOptions{NoHeader: true},
+			want: "c1  a rather long description\nbb  short\n",
+		},
+		{
+			name:  "max widths truncate at a TTY",
+			isTTY: true,
+			opts:  TableOptions{NoHeader: true, MaxWidths: []int{0, 8}},
+			want:  "c1  a rathe…\nbb  short\n",
+		},
+		{
+			name: "piped output keeps cells whole",
+			opts: TableOptions{NoHeader: true, MaxWidths: []int{0, 8}},
+			want: "c1  a rather long description\nbb  short\n",
+		},
+		{
+			name:  "fit cuts the last column to the width",
+			isTTY: true,
+			width: 16,
+			opts:  TableOptions{NoHeader: true, Fit: true},
+			want:  "c1  a rather lo…\nbb  short\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			NewPrinter(&buf, false, tt.isTTY).WithWidth(tt.width).TableWith(headers, rows, tt.opts)
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme backgrounds.
+const (
+	ThemeAuto  = "auto"  // adapt to the terminal's background
+	ThemeDark  = "dark"  // palette for dark backgrounds
+	ThemeLight = "light" // palette for light backgrounds
+)
+
+// Theme selects the palette for human output. The zero value is the
+// default: the built-in palette, adapted to the terminal's background.
+type Theme struct {
+	// Background is ThemeAuto (or ""), ThemeDark, or ThemeLight.
+	Background string
+	// Colors overrides palette roles (see ThemeRoles) with an ANSI color
+	// code ("0"-"255") or a hex color ("#5f87ff").
+	Colors map[string]string
+}
+
+// roleColor is a role's default color on light and dark backgrounds.
+type roleColor struct{ light, dark string }
+
+// defaultPalette is the built-in color for each theme role.
+var defaultPalette = map[string]roleColor{
+	"error":   {"9", "9"},   // Red
+	"success": {"10", "10"}, // Green
+	"warning": {"11", "11"}, // Yellow
+	"dim":     {"8", "7"},   // Gray
+	"title":   {"12", "12"}, // Blue
+	"key":     {"14", "14"}, // Cyan
+	"accent":  {"13", "13"}, // Magenta
+	"border":  {"8", "7"},   // Gray
+}
+
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ThemeRoles returns the palette roles a Theme can override, sorted.
+func ThemeRoles() []string {
+	roles := make([]string, 0, len(defaultPalette))
+	for role := range defaultPalette {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// Validate reports an unknown background, role, or color.
+func (t Theme) Validate() error {
+	switch t.Background {
+	case "", ThemeAuto, ThemeDark, ThemeLight:
+	default:
+		return fmt.Errorf("theme background %q: want auto, dark, or light", t.Background)
+	}
+	for role, color := range t.Colors {
+		if _, ok := defaultPalette[role]; !ok {
+			return fmt.Errorf("theme color %q: unknown role (known: %v)", role, ThemeRoles())
+		}
+		if code, err := strconv.Atoi(color); err == nil && code >= 0 && code <= 255 {
+			continue
+		}
+		if !hexColor.MatchString(color) {
+			return fmt.Errorf("theme color %s = %q: want an ANSI code 0-255 or #rrggbb", role, color)
+		}
+	}
+	return nil
+}
+
+// color returns the theme's color for role.
+func (t Theme) color(role string) lipgloss.TerminalColor {
+	if custom := t.Colors[role]; custom != "" {
+		return lipgloss.Color(custom)
+	}
+	def := defaultPalette[role]
+	switch t.Background {
+	case ThemeDark:
+		return lipgloss.Color(def.dark)
+	case ThemeLight:
+		return lipgloss.Color(def.light)
+	default:
+		return lipgloss.AdaptiveColor{Light: def.light, Dark: def.dark}
+	}
+}
+
+// newStyles builds the styles for theme, or plain styles without color.
+func newStyles(theme Theme, color bool) *Styles {
+	if !color {
+		return &Styles{
+			Error:   lipgloss.NewStyle(),
+			Success: lipgloss.NewStyle(),
+			Warning: lipgloss.NewStyle(),
+			Bold:    lipgloss.NewStyle(),
+			Dim:     lipgloss.NewStyle(),
+			Title:   lipgloss.NewStyle(),
+			Muted:   lipgloss.NewStyle(),
+			Key:     lipgloss.NewStyle(),
+			Value:   lipgloss.NewStyle(),
+			Border:  lipgloss.NoColor{},
+			Accent:  lipgloss.NewStyle(),
+		}
+	}
+	return &Styles{
+		Error:   lipgloss.NewStyle().Foreground(theme.color("error")).Bold(true),
+		Success: lipgloss.NewStyle().Foreground(theme.color("success")),
+		Warning: lipgloss.NewStyle().Foreground(theme.color("warning")),
+		Bold:    lipgloss.NewStyle().Bold(true),
+		Dim:     lipgloss.NewStyle().Foreground(theme.color("dim")),
+		Title:   lipgloss.NewStyle().Bold(true).Foreground(theme.color("title")),
+		Muted:   lipgloss.NewStyle().Faint(true),
+		Key:     lipgloss.NewStyle().Foreground(theme.color("key")),
+		Value:   lipgloss.NewStyle(),
+		Border:  theme.color("border"),
+		Accent:  lipgloss.NewStyle().Foreground(theme.color("accent")),
+	}
+}
+
+// WithTheme sets the palette for human output. Returns the printer for
+// chaining.
+func (p *Printer) WithTheme(theme Theme) *Printer {
+	p.theme = theme
+	p.styles = newStyles(p.theme, p.color)
+	return p
+}
+
+// WithColor turns color on or off regardless of what NewPrinter decided
+// (for --color always, or CLICOLOR_FORCE). Layout — boxes, links — still
+// follows the TTY setting. Returns the printer for chaining.
+func (p *Printer) WithColor(enabled bool) *Printer {
+	p.color = enabled
+	p.styles = newStyles(p.theme, p.color)
+	return p
+}
+
+// ColorEnabled reports whether styles carry color.
+func (p *Printer) ColorEnabled() bool {
+	return p.color
+}
+
+// Styles returns the printer's styles, for commands that render their own
+// layouts in the shared palette.
+func (p *Printer) Styles() *Styles {
+	return p.styles
+}
+
+// NoColorEnv reports whether the environment asks for no color: NO_COLOR
+// set to anything (https://no-color.org), or CLICOLOR=0.
+func NoColorEnv() bool {
+	return os.Getenv("NO_COLOR") != "" || os.Getenv("CLICOLOR") == "0"
+}
+
+// ForceColorEnv reports whether CLICOLOR_FORCE asks for color even when
+// output is not a terminal.
+func ForceColorEnv() bool {
+	force := os.Getenv("CLICOLOR_FORCE")
+	return force != "" && force != "0"
+}
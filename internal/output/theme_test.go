@@ -0,0 +1,91 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestThemeValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		theme   Theme
+		wantErr string
+	}{
+		{name: "zero value", theme: Theme{}},
+		{name: "dark with overrides", theme: Theme{Background: ThemeDark, Colors: map[string]string{"title": "33", "dim": "#8a8a8a"}}},
+		{name: "unknown background", theme: Theme{Background: "solarized"}, wantErr: "background"},
+		{name: "unknown role", theme: Theme{Colors: map[string]string{"heading": "1"}}, wantErr: "unknown role"},
+		{name: "out of range code", theme: Theme{Colors: map[string]string{"error": "256"}}, wantErr: "ANSI code"},
+		{name: "color name", theme: Theme{Colors: map[string]string{"error": "red"}}, wantErr: "ANSI code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.theme.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrinter_ThemeColors(t *testing.T) {
+	defer lipgloss.SetColorProfile(lipgloss.ColorProfile())
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR", "")
+
+	printer := NewPrinter(&bytes.Buffer{}, false, true).
+		WithTheme(Theme{Background: ThemeDark, Colors: map[string]string{"title": "33"}})
+	if got := printer.Styles().Title.Render("x"); !strings.Contains(got, "38;5;33") {
+		t.Errorf("title = %q, want the custom color 33", got)
+	}
+	if got := printer.Styles().Dim.Render("x"); !strings.Contains(got, "37") {
+		t.Errorf("dim = %q, want the dark palette's 7", got)
+	}
+}
+
+func TestNewPrinter_NoColorEnv(t *testing.T) {
+	defer lipgloss.SetColorProfile(lipgloss.ColorProfile())
+	lipgloss.SetColorProfile(termenv.ANSI256)
+
+	for _, env := range []struct{ key, value string }{{"NO_COLOR", "1"}, {"CLICOLOR", "0"}} {
+		t.Run(env.key, func(t *testing.T) {
+			t.Setenv("NO_COLOR", "")
+			t.Setenv("CLICOLOR", "")
+			t.Setenv(env.key, env.value)
+
+			printer := NewPrinter(&bytes.Buffer{}, false, true)
+			if printer.ColorEnabled() {
+				t.Error("ColorEnabled() = true, want false")
+			}
+			if !printer.IsTTY() {
+				t.Error("IsTTY() = false; NO_COLOR should only drop color, not layout")
+			}
+			if got := printer.Styles().Error.Render("x"); got != "x" {
+				t.Errorf("error style = %q, want plain", got)
+			}
+			if !printer.WithColor(true).ColorEnabled() {
+				t.Error("WithColor(true) should override the environment")
+			}
+		})
+	}
+}
+
+func TestForceColorEnv(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "0": false, "1": true, "yes": true} {
+		t.Setenv("CLICOLOR_FORCE", value)
+		if got := ForceColorEnv(); got != want {
+			t.Errorf("ForceColorEnv() with %q = %v, want %v", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package output
+
+import "fmt"
+
+// Verbosity controls how much incidental output a Printer writes. Results
+// and errors are never affected.
+type Verbosity int
+
+// Verbosity levels.
+const (
+	// VerbosityQuiet drops warnings, info, and status hints (--quiet).
+	VerbosityQuiet Verbosity = iota - 1
+	// VerbosityNormal is the default.
+	VerbosityNormal
+	// VerbosityVerbose adds debug detail, such as the git commands run
+	// (--verbose).
+	VerbosityVerbose
+)
+
+// WithVerbosity sets the verbosity level. Returns the printer for chaining.
+func (p *Printer) WithVerbosity(level Verbosity) *Printer {
+	p.verbosity = level
+	return p
+}
+
+// Verbosity returns the printer's verbosity level.
+func (p *Printer) Verbosity() Verbosity {
+	return p.verbosity
+}
+
+// Debug writes a dimmed diagnostic line to the error writer when verbose.
+// It goes to stderr in JSON mode too, so it never corrupts structured
+// output.
+func (p *Printer) Debug(format string, args ...any) {
+	if p.verbosity < VerbosityVerbose {
+		return
+	}
+	mustWrite(fmt.Fprintln(p.errW, p.styles.Dim.Render("debug: "+fmt.Sprintf(format, args...))))
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrinter_Quiet(t *testing.T) {
+	var out, errOut bytes.Buffer
+	printer := NewPrinter(&out, false, false).WithStderr(&errOut).WithVerbosity(VerbosityQuiet)
+	printer.Warning("careful")
+	printer.Info("fyi")
+	printer.Stderr("hint\n")
+	printer.Println("result")
+
+	if out.String() != "result\n" {
+		t.Errorf("stdout = %q, want only the result", out.String())
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("stderr = %q, want nothing when quiet", errOut.String())
+	}
+
+	out.Reset()
+	printer = NewPrinter(&out, true, false).WithVerbosity(VerbosityQuiet)
+	printer.Warning("careful")
+	if err := printer.Success(map[string]any{"status": "ok"}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "warnings") {
+		t.Errorf("quiet JSON output carries warnings: %s", out.String())
+	}
+}
+
+func TestPrinter_Debug(t *testing.T) {
+	var out, errOut bytes.Buffer
+	printer := NewPrinter(&out, true, false).WithStderr(&errOut)
+	printer.Debug("hidden")
+	if errOut.Len() != 0 {
+		t.Errorf("Debug wrote %q at normal verbosity", errOut.String())
+	}
+
+	printer.WithVerbosity(VerbosityVerbose).Debug("git %s", "status")
+	if got := errOut.String(); got != "debug: git status\n" {
+		t.Errorf("stderr = %q, want the debug line", got)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Debug wrote to stdout: %q", out.String())
+	}
+}
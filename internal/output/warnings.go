@@ -0,0 +1,133 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+)
+
+// Warning reports a problem that does not stop the command.
+// For human mode, outputs a styled warning to stderr (if set), or to
+// stdout under StreamPolicy.WarningsToStdout.
+// For JSON mode, the message is collected and attached as a "warnings" array
+// to the next JSON object the printer writes (the result or the error), so
+// JSON consumers see it without a second object on stdout. A result with
+// no object to carry them sends them to stderr instead (see flushWarnings).
+// Dropped entirely at VerbosityQuiet.
+func (p *Printer) Warning(format string, args ...any) {
+	if p.verbosity == VerbosityQuiet {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if p.json {
+		p.warnings = append(p.warnings, msg)
+		return
+	}
+	mustWrite(fmt.Fprintf(p.warnWriter(), "%s: %s\n", p.styles.Warning.Render("Warning"), msg))
+}
+
+// Info reports a note worth surfacing, like a hint or a self-healing
+// condition, that is not a problem.
+// For human mode, outputs a dimmed line to stderr (if set).
+// For JSON mode, it is collected into the "warnings" array like Warning.
+// Dropped entirely at VerbosityQuiet.
+func (p *Printer) Info(format string, args ...any) {
+	if p.verbosity == VerbosityQuiet {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if p.json {
+		p.warnings = append(p.warnings, msg)
+		return
+	}
+	mustWrite(fmt.Fprintln(p.warnWriter(), p.styles.Dim.Render(msg)))
+}
+
+// warnWriter returns the writer for human-mode warnings and info under the
+// stream policy.
+func (p *Printer) warnWriter() io.Writer {
+	if p.streams.WarningsToStdout {
+		return p.w
+	}
+	return p.errW
+}
+
+// Warnings returns the JSON-mode warnings not yet attached to output.
+func (p *Printer) Warnings() []string {
+	return p.warnings
+}
+
+// attachWarnings returns data with the pending warnings added under a
+// "warnings" key, and clears them. Data that does not encode as a JSON
+// object (an entry array, say) is returned unchanged with the warnings
+// still pending for flushWarnings: there is no key to put them under.
+func (p *Printer) attachWarnings(data any) any {
+	if len(p.warnings) == 0 {
+		return data
+	}
+	if fields, ok := data.(map[string]any); ok {
+		merged := make(map[string]any, len(fields)+1)
+		maps.Copy(merged, fields)
+		merged["warnings"] = p.warnings
+		p.warnings = nil
+		return merged
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+	spliced, ok := p.spliceWarnings(encoded)
+	if !ok {
+		return data
+	}
+	return json.RawMessage(spliced)
+}
+
+// flushWarnings writes warnings still pending after a result was written
+// (one that is not a JSON object, like an entry array) to the error writer
+// as a {"warnings": [...]} object, and clears them. Stdout stays a single
+// parseable result and the warnings still reach the consumer.
+func (p *Printer) flushWarnings() {
+	if len(p.warnings) == 0 {
+		return
+	}
+	flushed := map[string]any{"warnings": p.warnings}
+	p.warnings = nil
+	if p.yaml {
+		mustWrite(0, writeYAML(p.errW, []any{flushed}))
+		return
+	}
+	encoded, err := json.Marshal(flushed)
+	if err != nil {
+		return
+	}
+	mustWrite(p.errW.Write(append(encoded, '\n')))
+}
+
+// spliceWarnings appends the pending warnings as a final "warnings" member
+// of the encoded JSON object, keeping the existing field order, and clears
+// them. It reports false if encoded is not an object.
+func (p *Printer) spliceWarnings(encoded []byte) ([]byte, bool) {
+	trimmed := bytes.TrimSpace(encoded)
+	if len(p.warnings) == 0 || len(trimmed) < 2 || trimmed[0] != '{' || trimmed[len(trimmed)-1] != '}' {
+		return encoded, false
+	}
+	list, err := json.Marshal(p.warnings)
+	if err != nil {
+		return encoded, false
+	}
+
+	var buf bytes.Buffer
+	buf.Write(trimmed[:len(trimmed)-1])
+	if len(bytes.TrimSpace(trimmed[1:len(trimmed)-1])) > 0 {
+		buf.WriteByte(',')
+	}
+	buf.WriteString(`"warnings":`)
+	buf.Write(list)
+	buf.WriteByte('}')
+	p.warnings = nil
+	return buf.Bytes(), true
+}
@@ -0,0 +1,124 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPrinter_Warning_JSON_AttachesToResult(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewPrinter(&buf, true, false)
+
+	printer.Warning("dirty tree")
+	printer.Info("anchor self-heals")
+	if err := printer.Success(map[string]any{"status": "ok"}); err != nil {
+		t.Fatalf("Success() error = %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("output is not a single JSON object: %v\nOutput: %s", err, buf.String())
+	}
+	warnings, ok := result["warnings"].([]any)
+	if !ok || len(warnings) != 2 || warnings[0] != "dirty tree" || warnings[1] != "anchor self-heals" {
+		t.Errorf("warnings = %v, want [dirty tree anchor self-heals]", result["warnings"])
+	}
+	if len(printer.Warnings()) != 0 {
+		t.Errorf("warnings should be cleared once written, got %v", printer.Warnings())
+	}
+}
+
+func TestPrinter_Warning_JSON_NoWarningsKeyWhenEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewPrinter(&buf, true, false)
+
+	if err := printer.Success(map[string]any{"status": "ok"}); err != nil {
+		t.Fatalf("Success() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "warnings") {
+		t.Errorf("output should not mention warnings: %s", buf.String())
+	}
+}
+
+func TestPrinter_Warning_JSON_StructKeepsFieldOrder(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewPrinter(&buf, true, false)
+
+	printer.Warning("stale anchor")
+	result := struct {
+		Zulu  string `json:"zulu"`
+		Alpha string `json:"alpha"`
+	}{"z", "a"}
+	if err := printer.WriteJSON(result); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	out := buf.String()
+	zulu, alpha, warn := strings.Index(out, "zulu"), strings.Index(out, "alpha"), strings.Index(out, "warnings")
+	if zulu < 0 || alpha < zulu || warn < alpha {
+		t.Errorf("want zulu, alpha, warnings in order: %s", out)
+	}
+	if !strings.Contains(out, "\n  \"warnings\"") {
+		t.Errorf("output should stay indented: %s", out)
+	}
+}
+
+func TestPrinter_Warning_JSON_ArrayFlushesWarningsToStderr(t *testing.T) {
+	var buf, errBuf bytes.Buffer
+	printer := NewPrinter(&buf, true, false).WithStderr(&errBuf)
+
+	printer.Warning("corrupt entry skipped")
+	if err := printer.WriteJSON([]string{"a", "b"}); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	var result []string
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("array output changed shape: %v\nOutput: %s", err, buf.String())
+	}
+	var flushed map[string][]string
+	if err := json.Unmarshal(errBuf.Bytes(), &flushed); err != nil {
+		t.Fatalf("stderr is not a warnings object: %v\nOutput: %s", err, errBuf.String())
+	}
+	if got := flushed["warnings"]; len(got) != 1 || got[0] != "corrupt entry skipped" {
+		t.Errorf("warnings = %v, want the one warning", got)
+	}
+	if len(printer.Warnings()) != 0 {
+		t.Errorf("warnings still pending after flush: %v", printer.Warnings())
+	}
+}
+
+func TestPrinter_Warning_JSON_AttachesToError(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewPrinter(&buf, true, false)
+
+	printer.Warning("dirty tree")
+	printer.Error(NewConflictError("entry already exists"))
+
+	var result map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to parse JSON: %v\nOutput: %s", err, buf.String())
+	}
+	if result["error"] != "entry already exists" {
+		t.Errorf("error = %v, want %q", result["error"], "entry already exists")
+	}
+	if warnings, ok := result["warnings"].([]any); !ok || len(warnings) != 1 {
+		t.Errorf("warnings = %v, want one", result["warnings"])
+	}
+}
+
+func TestPrinter_Info_Human(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	printer := NewPrinter(&stdout, false, false).WithStderr(&stderr)
+
+	printer.Info("anchor self-heals on the next entry")
+
+	if stdout.Len() > 0 {
+		t.Errorf("info should not go to stdout, got: %q", stdout.String())
+	}
+	if stderr.String() != "anchor self-heals on the next entry\n" {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WithYAML switches a structured-output printer to YAML. Results and errors
+// keep the JSON schema — same keys, same nesting — rendered as YAML
+// documents, so YAML-native tooling can consume any command. Implies JSON
+// mode: IsJSON reports true and commands take their structured path.
+// Returns the printer for chaining.
+func (p *Printer) WithYAML() *Printer {
+	p.json = true
+	p.yaml = true
+	return p
+}
+
+// IsYAML returns true if structured output is rendered as YAML.
+func (p *Printer) IsYAML() bool {
+	return p.yaml
+}
+
+// writeYAML writes each value as its own YAML document.
+func writeYAML(w io.Writer, values []any) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	for _, value := range values {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("encoding JSON: %w", err)
+		}
+		node, err := jsonToYAMLNode(encoded)
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(node); err != nil {
+			return fmt.Errorf("encoding YAML: %w", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encoding YAML: %w", err)
+	}
+	return nil
+}
+
+// jsonToYAMLNode parses encoded JSON (which is valid YAML) into a node
+// tree, keeping the JSON key order, and clears the flow and quoting styles
+// so it renders as block YAML. Scalars the encoder cannot write plainly
+// (a string "true", say) are still quoted.
+func jsonToYAMLNode(encoded []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(encoded, &doc); err != nil {
+		return nil, fmt.Errorf("converting JSON to YAML: %w", err)
+	}
+	clearYAMLStyle(&doc)
+	return &doc, nil
+}
+
+// clearYAMLStyle resets the style of node and its descendants.
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
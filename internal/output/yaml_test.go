@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPrinter_YAML_WriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewPrinter(&buf, false, false).WithYAML()
+	if !printer.IsJSON() || !printer.IsYAML() {
+		t.Fatal("WithYAML should imply structured output")
+	}
+
+	data := struct {
+		ID    string   `json:"id"`
+		Flag  string   `json:"flag"`
+		Tags  []string `json:"tags"`
+		Empty []string `json:"empty"`
+		Notes string   `json:"notes"`
+	}{ID: "tb_1", Flag: "true", Tags: []string{"a", "b"}, Empty: []string{}, Notes: "line one\nline two"}
+	if err := printer.WriteJSON(data); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	want := `id: tb_1
+flag: "true"
+tags:
+  - a
+  - b
+empty: []
+notes: |-
+  line one
+  line two
+`
+	if got := buf.String(); got != want {
+		t.Errorf("YAML output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPrinter_YAML_Error(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewPrinter(&buf, false, false).WithYAML()
+	printer.Warning("heads up")
+	printer.Error(NewSystemError("disk full"))
+
+	want := "code: 2\nerror: disk full\nerror_code: SYSTEM_ERROR\nwarnings:\n  - heads up\n"
+	if got := buf.String(); got != want {
+		t.Errorf("YAML error:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPrinter_YAML_FilteredDocuments(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewPrinter(&buf, false, false).WithYAML().WithFilter(func(any) ([]any, error) {
+		return []any{"one", map[string]any{"n": 2}}, nil
+	})
+	if err := printer.WriteJSON(map[string]any{}); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	if got, want := buf.String(), "one\n---\nn: 2\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	printer = NewPrinter(&buf, false, false).WithYAML().WithFilter(func(any) ([]any, error) {
+		return nil, errors.New("bad")
+	})
+	if err := printer.WriteJSON(map[string]any{}); err == nil || !strings.Contains(err.Error(), "bad") {
+		t.Errorf("WriteJSON() error = %v, want filter error", err)
+	}
+}
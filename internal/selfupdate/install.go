@@ -0,0 +1,134 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// binaryName is the executable inside each release archive.
+const binaryName = "timbers"
+
+// AssetName returns the release archive name for a platform, matching the
+// goreleaser name template: timbers_<os>_<arch>.tar.gz, or .zip on Windows.
+func AssetName(goos, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return binaryName + "_" + goos + "_" + goarch + ext
+}
+
+// ExtractBinary returns the timbers executable from a release archive named
+// asset (which decides the format).
+func ExtractBinary(archive []byte, asset string) ([]byte, error) {
+	if strings.HasSuffix(asset, ".zip") {
+		return extractZip(archive)
+	}
+	return extractTarGz(archive)
+}
+
+// isBinary reports whether an archive member is the timbers executable.
+func isBinary(name string) bool {
+	base := path.Base(name)
+	return base == binaryName || base == binaryName+".exe"
+}
+
+func extractTarGz(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("archive contains no timbers binary")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeReg && isBinary(header.Name) {
+			return readBinary(reader)
+		}
+	}
+}
+
+func extractZip(archive []byte) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() || !isBinary(file.Name) {
+			continue
+		}
+		member, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %w", err)
+		}
+		defer member.Close() //nolint:errcheck
+		return readBinary(member)
+	}
+	return nil, errors.New("archive contains no timbers binary")
+}
+
+// readBinary reads an archive member, up to maxAssetSize bytes.
+func readBinary(member io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(member, maxAssetSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+	return data, nil
+}
+
+// ReplaceExecutable installs binary at exe, the running executable's path.
+// The new binary is written beside exe first, so a failure leaves the old
+// one in place. The old binary is moved aside rather than overwritten,
+// which Windows requires for a running executable; the leftover ".old" file
+// is removed when possible and is harmless otherwise.
+func ReplaceExecutable(exe string, binary []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".timbers-upgrade-*")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	cleanup := func() { _ = os.Remove(tmpName) }
+
+	if _, err := tmp.Write(binary); err != nil {
+		_ = tmp.Close()
+		cleanup()
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		cleanup()
+		return fmt.Errorf("closing temp file: %w", err)
+	}
+	// #nosec G302 -- the binary must be executable
+	if err := os.Chmod(tmpName, 0o755); err != nil {
+		cleanup()
+		return fmt.Errorf("setting permissions: %w", err)
+	}
+
+	old := exe + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		cleanup()
+		return fmt.Errorf("moving current binary aside: %w", err)
+	}
+	if err := os.Rename(tmpName, exe); err != nil {
+		_ = os.Rename(old, exe)
+		cleanup()
+		return fmt.Errorf("installing new binary: %w", err)
+	}
+	_ = os.Remove(old)
+	return nil
+}
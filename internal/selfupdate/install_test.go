@@ -0,0 +1,124 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func makeTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzWriter := gzip.NewWriter(&buf)
+	writer := tar.NewWriter(gzWriter)
+	for name, body := range files {
+		header := &tar.Header{Name: name, Mode: 0o755, Size: int64(len(body)), Typeflag: tar.TypeReg}
+		if err := writer.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func makeZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, body := range files {
+		member, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := member.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestAssetName(t *testing.T) {
+	if got := AssetName("linux", "amd64"); got != "timbers_linux_amd64.tar.gz" {
+		t.Errorf("AssetName(linux) = %q", got)
+	}
+	if got := AssetName("windows", "amd64"); got != "timbers_windows_amd64.zip" {
+		t.Errorf("AssetName(windows) = %q", got)
+	}
+}
+
+func TestExtractBinary(t *testing.T) {
+	files := map[string]string{"README.md": "readme", "LICENSE": "license"}
+
+	t.Run("tar.gz", func(t *testing.T) {
+		files["timbers"] = "unix binary"
+		got, err := ExtractBinary(makeTarGz(t, files), "timbers_linux_amd64.tar.gz")
+		if err != nil || string(got) != "unix binary" {
+			t.Errorf("ExtractBinary() = %q, %v", got, err)
+		}
+		delete(files, "timbers")
+	})
+
+	t.Run("zip", func(t *testing.T) {
+		files["timbers.exe"] = "windows binary"
+		got, err := ExtractBinary(makeZip(t, files), "timbers_windows_amd64.zip")
+		if err != nil || string(got) != "windows binary" {
+			t.Errorf("ExtractBinary() = %q, %v", got, err)
+		}
+		delete(files, "timbers.exe")
+	})
+
+	t.Run("missing binary", func(t *testing.T) {
+		if _, err := ExtractBinary(makeTarGz(t, files), "timbers_linux_amd64.tar.gz"); err == nil {
+			t.Error("ExtractBinary() should fail without a timbers binary")
+		}
+	})
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "timbers")
+	if err := os.WriteFile(exe, []byte("old"), 0o755); err != nil { //nolint:gosec // test binary
+		t.Fatal(err)
+	}
+
+	if err := ReplaceExecutable(exe, []byte("new")); err != nil {
+		t.Fatalf("ReplaceExecutable() error: %v", err)
+	}
+
+	data, err := os.ReadFile(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "new" {
+		t.Errorf("binary = %q, want new", data)
+	}
+	info, err := os.Stat(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o111 == 0 {
+		t.Errorf("binary mode = %v, want executable", info.Mode())
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("leftover files after replace: %v", entries)
+	}
+}
@@ -0,0 +1,125 @@
+// Package selfupdate finds, verifies, and installs timbers releases
+// published on GitHub, so a binary installed by curl can replace itself.
+package selfupdate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Default release endpoints for gorewood/timbers.
+const (
+	DefaultAPIURL      = "https://api.github.com/repos/gorewood/timbers"
+	DefaultDownloadURL = "https://github.com/gorewood/timbers/releases/download"
+)
+
+// checksumsAsset is the checksum file goreleaser publishes with each release.
+const checksumsAsset = "checksums.txt"
+
+// maxAssetSize bounds a download, well above any release archive.
+const maxAssetSize = 200 << 20
+
+// ErrChecksumMismatch is returned when a downloaded archive does not match
+// the release's published checksum.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// Client talks to the release endpoints. The zero value is not usable;
+// call NewClient.
+type Client struct {
+	APIURL      string
+	DownloadURL string
+	HTTP        *http.Client
+}
+
+// NewClient returns a client for the public gorewood/timbers releases.
+func NewClient() *Client {
+	return &Client{APIURL: DefaultAPIURL, DownloadURL: DefaultDownloadURL, HTTP: http.DefaultClient}
+}
+
+// Latest returns the tag of the latest published release, e.g. "v0.9.0".
+func (c *Client) Latest(ctx context.Context) (string, error) {
+	body, err := c.get(ctx, c.APIURL+"/releases/latest", "application/vnd.github+json")
+	if err != nil {
+		return "", err
+	}
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.Unmarshal(body, &release); err != nil {
+		return "", fmt.Errorf("parsing release: %w", err)
+	}
+	if release.TagName == "" {
+		return "", errors.New("release has no tag")
+	}
+	return release.TagName, nil
+}
+
+// Download fetches asset from the release tagged tag and verifies it
+// against the release's checksums.txt. A release without checksums is
+// refused rather than installed unverified.
+func (c *Client) Download(ctx context.Context, tag, asset string) ([]byte, error) {
+	base := c.DownloadURL + "/" + tag + "/"
+	sums, err := c.get(ctx, base+checksumsAsset, "")
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", checksumsAsset, err)
+	}
+	want, err := findChecksum(sums, asset)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.get(ctx, base+asset, "")
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", asset, err)
+	}
+	if got := sha256.Sum256(data); hex.EncodeToString(got[:]) != want {
+		return nil, fmt.Errorf("%w for %s", ErrChecksumMismatch, asset)
+	}
+	return data, nil
+}
+
+// findChecksum returns the hex SHA-256 listed for asset in a sha256sum-style
+// checksum file.
+func findChecksum(sums []byte, asset string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == asset {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s lists no checksum for %s", checksumsAsset, asset)
+}
+
+// get fetches url and returns the body, failing on any non-200 status.
+func (c *Client) get(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("network error: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned %d", url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxAssetSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	return body, nil
+}
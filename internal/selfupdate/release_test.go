@@ -0,0 +1,91 @@
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestServer serves a fake release API and download host. sums is the
+// checksums.txt body; an empty sums serves a 404 for it.
+func newTestServer(t *testing.T, asset string, archive []byte, sums string) *Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/releases/latest", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name": "v0.9.0"}`))
+	})
+	mux.HandleFunc("/download/v0.9.0/checksums.txt", func(w http.ResponseWriter, _ *http.Request) {
+		if sums == "" {
+			http.NotFound(w, nil)
+			return
+		}
+		_, _ = w.Write([]byte(sums))
+	})
+	mux.HandleFunc("/download/v0.9.0/"+asset, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return &Client{APIURL: server.URL + "/api", DownloadURL: server.URL + "/download", HTTP: server.Client()}
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestClientLatest(t *testing.T) {
+	client := newTestServer(t, "unused", nil, "")
+	tag, err := client.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Latest() error: %v", err)
+	}
+	if tag != "v0.9.0" {
+		t.Errorf("Latest() = %q, want v0.9.0", tag)
+	}
+}
+
+func TestClientDownload(t *testing.T) {
+	const asset = "timbers_linux_amd64.tar.gz"
+	archive := []byte("archive bytes")
+
+	t.Run("verifies checksum", func(t *testing.T) {
+		sums := sha256Hex([]byte("other")) + "  timbers_darwin_arm64.tar.gz\n" + sha256Hex(archive) + "  " + asset + "\n"
+		client := newTestServer(t, asset, archive, sums)
+		data, err := client.Download(context.Background(), "v0.9.0", asset)
+		if err != nil {
+			t.Fatalf("Download() error: %v", err)
+		}
+		if string(data) != string(archive) {
+			t.Errorf("Download() = %q, want %q", data, archive)
+		}
+	})
+
+	t.Run("rejects mismatch", func(t *testing.T) {
+		client := newTestServer(t, asset, archive, sha256Hex([]byte("tampered"))+"  "+asset+"\n")
+		_, err := client.Download(context.Background(), "v0.9.0", asset)
+		if !errors.Is(err, ErrChecksumMismatch) {
+			t.Errorf("Download() error = %v, want ErrChecksumMismatch", err)
+		}
+	})
+
+	t.Run("refuses release without checksums", func(t *testing.T) {
+		client := newTestServer(t, asset, archive, "")
+		if _, err := client.Download(context.Background(), "v0.9.0", asset); err == nil {
+			t.Error("Download() should fail without checksums.txt")
+		}
+	})
+
+	t.Run("refuses asset missing from checksums", func(t *testing.T) {
+		client := newTestServer(t, asset, archive, sha256Hex(archive)+"  other.tar.gz\n")
+		_, err := client.Download(context.Background(), "v0.9.0", asset)
+		if err == nil || !strings.Contains(err.Error(), "no checksum") {
+			t.Errorf("Download() error = %v, want missing checksum", err)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package selfupdate
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IsNewer reports whether release version latest is newer than installed.
+// Versions are compared as dotted numbers with an optional "v" prefix and
+// any pre-release or build suffix ignored; a version that does not parse
+// is never newer, so a garbled tag cannot trigger an upgrade.
+func IsNewer(latest, installed string) bool {
+	latestParts, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	installedParts, ok := parseVersion(installed)
+	if !ok {
+		return true
+	}
+	for i := range latestParts {
+		if latestParts[i] != installedParts[i] {
+			return latestParts[i] > installedParts[i]
+		}
+	}
+	return false
+}
+
+// parseVersion splits "v1.2.3-rc1" into [1 2 3]. Missing minor or patch
+// components count as zero.
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if cut := strings.IndexAny(version, "-+"); cut >= 0 {
+		version = version[:cut]
+	}
+	fields := strings.Split(version, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		num, err := strconv.Atoi(field)
+		if err != nil || num < 0 {
+			return parts, false
+		}
+		parts[i] = num //nolint:gosec // len(fields) <= 3 is checked above
+	}
+	return parts, true
+}
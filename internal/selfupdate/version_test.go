@@ -0,0 +1,24 @@
+package selfupdate
+
+import "testing"
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		latest, installed string
+		want              bool
+	}{
+		{"v0.9.0", "0.8.3", true},
+		{"v0.9.0", "v0.9.0", false},
+		{"v0.9.0", "v0.10.0", false},
+		{"v1.0.0", "v0.99.99", true},
+		{"v0.9.1-rc1", "v0.9.0", true},
+		{"v1.2", "v1.2.0", false},
+		{"garbage", "v0.1.0", false},
+		{"v0.1.0", "dev", true},
+	}
+	for _, tt := range tests {
+		if got := IsNewer(tt.latest, tt.installed); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.latest, tt.installed, got, tt.want)
+		}
+	}
+}
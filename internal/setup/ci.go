@@ -0,0 +1,141 @@
+package setup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// ciWorkflow describes a scaffolded CI workflow for one provider.
+type ciWorkflow struct {
+	path    string // slash-separated, relative to the repo root
+	content string
+}
+
+// ciWorkflows maps a --ci provider name to its workflow file.
+//
+// Both workflows gate pull requests on `timbers pending` (fail when commits
+// in the PR are undocumented) and publish the PR's ledger entries via
+// `timbers export --range` so reviewers see the what/why/how next to the diff.
+// GitLab gets an includable file rather than .gitlab-ci.yml itself, which
+// almost always exists already and must not be clobbered.
+var ciWorkflows = map[string]ciWorkflow{
+	"github": {path: ".github/workflows/timbers.yml", content: githubWorkflow},
+	"gitlab": {path: ".gitlab/timbers.yml", content: gitlabWorkflow},
+}
+
+// CIProviders returns the supported --ci provider names, sorted.
+func CIProviders() []string {
+	names := make([]string, 0, len(ciWorkflows))
+	for name := range ciWorkflows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CIWorkflowPath returns the repo-relative path of the workflow file for
+// provider, or a user error for an unknown provider.
+func CIWorkflowPath(provider string) (string, error) {
+	wf, ok := ciWorkflows[provider]
+	if !ok {
+		return "", output.NewUserError(fmt.Sprintf("unknown CI provider %q (supported: %v)", provider, CIProviders()))
+	}
+	return wf.path, nil
+}
+
+// InstallCIWorkflow writes the workflow file for provider under repoRoot.
+// An existing file is left untouched (the team may have customized it).
+// Returns the repo-relative path and whether the file was written.
+func InstallCIWorkflow(repoRoot, provider string) (string, bool, error) {
+	relPath, err := CIWorkflowPath(provider)
+	if err != nil {
+		return "", false, err
+	}
+
+	path := filepath.Join(repoRoot, filepath.FromSlash(relPath))
+	if _, statErr := os.Stat(path); statErr == nil {
+		return relPath, false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return relPath, false, output.NewSystemErrorWithCause("failed to create workflow directory", err)
+	}
+	// #nosec G306 -- workflow is a tracked file, needs standard perms
+	if err := os.WriteFile(path, []byte(ciWorkflows[provider].content), 0o644); err != nil {
+		return relPath, false, output.NewSystemErrorWithCause("failed to write workflow", err)
+	}
+	return relPath, true, nil
+}
+
+const githubWorkflow = `# Generated by 'timbers init --ci github'.
+# Fails the PR when commits are undocumented and publishes the PR's
+# ledger entries to the job summary.
+name: timbers
+
+on:
+  pull_request:
+
+jobs:
+  ledger:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0 # pending detection needs full history
+
+      - name: Install timbers
+        run: |
+          curl -fsSL https://raw.githubusercontent.com/gorewood/timbers/main/install.sh | bash
+          echo "$HOME/.local/bin" >> "$GITHUB_PATH"
+
+      - name: Check for undocumented commits
+        run: |
+          timbers pending
+          count="$(timbers pending --json | grep -o '"count": *[0-9]*' | grep -o '[0-9]*$')"
+          if [ "${count:-0}" -gt 0 ]; then
+            echo "::error::$count undocumented commit(s). Run 'timbers log' and push the entry."
+            exit 1
+          fi
+
+      - name: PR ledger summary
+        run: |
+          timbers export --range "origin/${{ github.base_ref }}..HEAD" --format md >> "$GITHUB_STEP_SUMMARY"
+`
+
+const gitlabWorkflow = `# Generated by 'timbers init --ci gitlab'.
+# Include it from .gitlab-ci.yml:
+#
+#   include:
+#     - local: .gitlab/timbers.yml
+#
+# Fails the merge request when commits are undocumented and saves the MR's
+# ledger entries as a markdown artifact.
+timbers:
+  stage: test
+  image: alpine:latest
+  rules:
+    - if: $CI_PIPELINE_SOURCE == "merge_request_event"
+  variables:
+    GIT_DEPTH: 0 # pending detection needs full history
+  before_script:
+    - apk add --no-cache bash curl git
+    - curl -fsSL https://raw.githubusercontent.com/gorewood/timbers/main/install.sh | bash
+    - export PATH="$HOME/.local/bin:$PATH"
+  script:
+    - |
+      timbers pending
+      count="$(timbers pending --json | grep -o '"count": *[0-9]*' | grep -o '[0-9]*$')"
+      if [ "${count:-0}" -gt 0 ]; then
+        echo "$count undocumented commit(s). Run 'timbers log' and push the entry."
+        exit 1
+      fi
+    - git fetch origin "$CI_MERGE_REQUEST_TARGET_BRANCH_NAME"
+    - timbers export --range "origin/$CI_MERGE_REQUEST_TARGET_BRANCH_NAME..HEAD" --format md > timbers-summary.md
+  artifacts:
+    when: always
+    paths:
+      - timbers-summary.md
+`
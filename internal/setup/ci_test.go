@@ -0,0 +1,72 @@
+package setup
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestInstallCIWorkflow(t *testing.T) {
+	for _, provider := range CIProviders() {
+		t.Run(provider, func(t *testing.T) {
+			root := t.TempDir()
+			rel, written, err := InstallCIWorkflow(root, provider)
+			if err != nil {
+				t.Fatalf("InstallCIWorkflow() error = %v", err)
+			}
+			if !written {
+				t.Fatal("InstallCIWorkflow() written = false on fresh repo")
+			}
+
+			data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+			if err != nil {
+				t.Fatalf("workflow not written: %v", err)
+			}
+			var doc map[string]any
+			if err := yaml.Unmarshal(data, &doc); err != nil {
+				t.Fatalf("workflow is not valid YAML: %v", err)
+			}
+			for _, want := range []string{"timbers pending", "timbers export --range"} {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("workflow missing %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestInstallCIWorkflow_KeepsExistingFile(t *testing.T) {
+	root := t.TempDir()
+	rel, err := CIWorkflowPath("github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(path, []byte("custom: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, written, err := InstallCIWorkflow(root, "github")
+	if err != nil {
+		t.Fatalf("InstallCIWorkflow() error = %v", err)
+	}
+	if written {
+		t.Error("InstallCIWorkflow() overwrote an existing workflow")
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "custom: true\n" {
+		t.Errorf("existing workflow modified: %q", data)
+	}
+}
+
+func TestInstallCIWorkflow_UnknownProvider(t *testing.T) {
+	if _, _, err := InstallCIWorkflow(t.TempDir(), "jenkins"); err == nil {
+		t.Fatal("InstallCIWorkflow() expected error for unknown provider")
+	}
+}
@@ -30,7 +30,7 @@ func AppendTimbersSection(hookPath string, sectionContent string) error {
 		// File doesn't exist — start with shebang.
 		content = "#!/bin/sh\n"
 	} else {
-		content = string(existing)
+		content = normalizeLineEndings(string(existing))
 		// Idempotent: if section already present, do nothing.
 		if hasSectionDelimiters(content) {
 			return nil
@@ -67,7 +67,7 @@ func RemoveTimbersSection(hookPath string) error {
 		return fmt.Errorf("reading hook file: %w", err)
 	}
 
-	content := string(existing)
+	content := normalizeLineEndings(string(existing))
 
 	// New format: remove delimited section.
 	if hasSectionDelimiters(content) {
@@ -199,7 +199,7 @@ func SectionUpToDate(hookPath string, sectionContent string) bool {
 	if err != nil {
 		return false
 	}
-	installed, found := extractSectionContent(string(data))
+	installed, found := extractSectionContent(normalizeLineEndings(string(data)))
 	if !found {
 		return false
 	}
@@ -230,6 +230,15 @@ func hasOldFormatTimbers(content string) bool {
 	return strings.Contains(content, "timbers hook run") && !hasSectionDelimiters(content)
 }
 
+// normalizeLineEndings converts CRLF line endings to LF. Hooks run under sh
+// (Git for Windows bundles one), which reads a trailing \r as part of each
+// command, so a hook checked out with CRLF — core.autocrlf on a tracked
+// core.hooksPath directory, say — fails in confusing ways. Any hook timbers
+// rewrites comes out LF-only.
+func normalizeLineEndings(content string) string {
+	return strings.ReplaceAll(content, "\r\n", "\n")
+}
+
 // cleanupTempFile is a best-effort removal of temporary files during error paths.
 func cleanupTempFile(name string) {
 	_ = os.Remove(name)
@@ -31,6 +31,7 @@ type HookEnvInfo struct {
 	Owner      string // "beads", "husky", "" if unknown or N/A
 	HasHook    bool   // Pre-commit hook file exists
 	HasTimbers bool   // Timbers integration present (old or new format)
+	JJ         bool   // Jujutsu colocated repository; jj never runs git hooks
 }
 
 // knownOwner maps a path pattern to a tool name.
@@ -79,6 +80,7 @@ func ClassifyHookEnv() (HookEnvInfo, error) {
 	}
 
 	info := classifyHookEnvFrom(coreHooksPath, hooksDir, hookExists, hookContent)
+	info.JJ = git.IsJJColocated()
 	return info, nil
 }
 
@@ -267,7 +269,7 @@ func InstallPostCommitHook(hookPath string) error {
 		if err != nil {
 			return fmt.Errorf("reading post-commit hook: %w", err)
 		}
-		content := string(existing)
+		content := normalizeLineEndings(string(existing))
 		if strings.Contains(content, "timbers hook run post-commit") {
 			return nil // already installed
 		}
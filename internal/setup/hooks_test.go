@@ -635,6 +635,27 @@ fi
 			t.Error("section delimiter should be on its own line")
 		}
 	})
+
+	t.Run("normalizes CRLF line endings", func(t *testing.T) {
+		dir := t.TempDir()
+		hookPath := filepath.Join(dir, "pre-commit")
+		writeTestFile(t, hookPath, "#!/bin/sh\r\necho hello\r\n")
+
+		if err := AppendTimbersSection(hookPath, sectionContent); err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := os.ReadFile(hookPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(content), "\r") {
+			t.Errorf("hook should be LF-only, got %q", content)
+		}
+		if !strings.Contains(string(content), "echo hello\n") {
+			t.Error("existing content should be preserved")
+		}
+	})
 }
 
 func TestRemoveTimbersSection(t *testing.T) {
@@ -884,6 +905,25 @@ echo current
 		}
 	})
 
+	t.Run("true when installed section has CRLF line endings", func(t *testing.T) {
+		dir := t.TempDir()
+		hookPath := filepath.Join(dir, "post-rewrite")
+		if err := AppendTimbersSection(hookPath, current); err != nil {
+			t.Fatalf("AppendTimbersSection() error: %v", err)
+		}
+		data, err := os.ReadFile(hookPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		crlf := strings.ReplaceAll(string(data), "\n", "\r\n")
+		if err := os.WriteFile(hookPath, []byte(crlf), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if !SectionUpToDate(hookPath, current) {
+			t.Error("expected up to date despite CRLF checkout")
+		}
+	})
+
 	t.Run("false when file missing", func(t *testing.T) {
 		dir := t.TempDir()
 		if SectionUpToDate(filepath.Join(dir, "post-rewrite"), current) {
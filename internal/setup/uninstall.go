@@ -36,7 +36,22 @@ type UninstallInfo struct {
 	HooksInstalled      bool
 	HooksHasBackup      bool
 
-	// Agent environment integrations detected during gather.
+	// Post-commit and post-rewrite hooks carrying timbers content.
+	ExtraHooks []GitHookState
+
+	GitattributesPath     string
+	GitattributesHasEntry bool
+	GitattributesRemoved  bool
+	ConfigPath            string
+	ConfigExists          bool
+	ConfigRemoved         bool
+
+	// CIWorkflows lists repo-relative workflow files scaffolded by init --ci.
+	// They are tracked project files, so uninstall reports but keeps them.
+	CIWorkflows []string
+
+	// Agent environment integrations detected during gather, one per
+	// installed scope.
 	AgentEnvs []AgentEnvState
 }
 
@@ -49,13 +64,15 @@ func GatherBinaryPath() (string, error) {
 	return execPath, nil
 }
 
-// GatherRepoInfo collects repository-level state: name, .timbers dir, entry count.
+// GatherRepoInfo collects repository-level state: name, .timbers dir, entry
+// count, config file, .gitattributes entry, and scaffolded CI workflows.
 func GatherRepoInfo(info *UninstallInfo) {
 	root, err := git.RepoRoot()
 	if err != nil {
 		return
 	}
 	info.RepoName = filepath.Base(root)
+	gatherRepoFiles(info, root)
 	timbersDir := filepath.Join(root, ".timbers")
 	info.TimbersDirPath = timbersDir
 
@@ -73,7 +90,7 @@ func GatherRepoInfo(info *UninstallInfo) {
 	})
 }
 
-// GatherHookInfo collects pre-commit hook state.
+// GatherHookInfo collects state for every git hook timbers installs.
 func GatherHookInfo(info *UninstallInfo) {
 	hooksDir, err := GetHooksDir()
 	if err != nil {
@@ -84,13 +101,27 @@ func GatherHookInfo(info *UninstallInfo) {
 	info.HooksHasBackup = HookExists(p + ".backup")
 	info.PreCommitHookPath = p
 	info.PreCommitBackupPath = p + ".backup"
+
+	for _, name := range []string{"post-commit", "post-rewrite"} {
+		path := filepath.Join(hooksDir, name)
+		if HasTimbersHook(path, name) {
+			info.ExtraHooks = append(info.ExtraHooks, GitHookState{Name: name, Path: path})
+		}
+	}
 }
 
 // GatherAgentEnvInfo detects all registered agent environment integrations.
+// Both scopes are checked: an env installed globally and per-project yields
+// two states, so uninstall removes both rather than only the first found.
 func GatherAgentEnvInfo(info *UninstallInfo) {
 	for _, env := range AllAgentEnvs() {
-		path, scope, installed := env.Detect()
-		if installed {
+		seen := make(map[string]bool)
+		for _, project := range []bool{true, false} {
+			path, scope, installed, err := env.Check(project)
+			if err != nil || !installed || seen[path] {
+				continue
+			}
+			seen[path] = true
 			info.AgentEnvs = append(info.AgentEnvs, AgentEnvState{
 				Name:    env.Name(),
 				Display: env.DisplayName(),
@@ -115,7 +146,7 @@ func RemoveAgentEnvs(info *UninstallInfo) []string {
 			errs = append(errs, info.AgentEnvs[i].Name+": unknown agent environment")
 			continue
 		}
-		// Detect which scope is installed and remove from that scope.
+		// Each state records one installed scope; remove from that scope.
 		project := info.AgentEnvs[i].Scope == "project"
 		if err := env.Remove(project); err != nil {
 			errs = append(errs, info.AgentEnvs[i].Name+": "+err.Error())
@@ -0,0 +1,165 @@
+package setup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gorewood/timbers/internal/config"
+	"github.com/gorewood/timbers/internal/output"
+)
+
+// GitattributesLine is the .gitattributes rule timbers init adds to collapse
+// ledger files in diffs.
+const GitattributesLine = "/.timbers/** linguist-generated"
+
+// GitHookState captures a git hook that carries timbers content.
+type GitHookState struct {
+	Name    string // hook name (e.g. "post-rewrite")
+	Path    string // hook file path
+	Removed bool   // set after successful removal
+}
+
+// UninstallItem is one line of the uninstall report: a component, where it
+// lives, and (for items left behind) why it was kept.
+type UninstallItem struct {
+	Component string `json:"component"`
+	Path      string `json:"path,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// gatherRepoFiles records the config file, .gitattributes entry, and CI
+// workflows under root.
+func gatherRepoFiles(info *UninstallInfo, root string) {
+	info.ConfigPath = config.ProjectPath(root)
+	info.ConfigExists = HookExists(info.ConfigPath)
+
+	info.GitattributesPath = filepath.Join(root, ".gitattributes")
+	if data, err := os.ReadFile(info.GitattributesPath); err == nil {
+		info.GitattributesHasEntry = hasGitattributesLine(string(data))
+	}
+
+	for _, provider := range CIProviders() {
+		rel, _ := CIWorkflowPath(provider)
+		if HookExists(filepath.Join(root, filepath.FromSlash(rel))) {
+			info.CIWorkflows = append(info.CIWorkflows, rel)
+		}
+	}
+}
+
+// hasGitattributesLine reports whether content contains GitattributesLine.
+func hasGitattributesLine(content string) bool {
+	for line := range strings.SplitSeq(content, "\n") {
+		if strings.TrimRight(line, "\r") == GitattributesLine {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveGitattributesLine drops GitattributesLine from the file at path,
+// deleting the file when nothing else remains. Missing files are a no-op.
+func RemoveGitattributesLine(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return output.NewSystemErrorWithCause("failed to read .gitattributes", err)
+	}
+
+	var kept []string
+	for line := range strings.SplitSeq(string(data), "\n") {
+		if strings.TrimRight(line, "\r") != GitattributesLine {
+			kept = append(kept, line)
+		}
+	}
+	remaining := strings.Join(kept, "\n")
+	if strings.TrimSpace(remaining) == "" {
+		if err := os.Remove(path); err != nil {
+			return output.NewSystemErrorWithCause("failed to remove .gitattributes", err)
+		}
+		return nil
+	}
+	// #nosec G306 -- .gitattributes is a tracked file, needs standard perms
+	if err := os.WriteFile(path, []byte(remaining), 0o644); err != nil {
+		return output.NewSystemErrorWithCause("failed to write .gitattributes", err)
+	}
+	return nil
+}
+
+// hookMarker returns the comment line init writes ahead of the timbers part
+// of a hook it appended without section delimiters.
+func hookMarker(name string) string {
+	return "# timbers " + name + " hook"
+}
+
+// HasTimbersHook reports whether the named hook at hookPath contains timbers
+// content in any format init or hooks install has written: a delimited
+// section, an old-format whole-file hook, or an undelimited appended block.
+func HasTimbersHook(hookPath, name string) bool {
+	if HasTimbersSection(hookPath) {
+		return true
+	}
+	data, err := os.ReadFile(hookPath)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), hookMarker(name))
+}
+
+// RemoveTimbersHook strips timbers content from the named hook at hookPath.
+// Delimited sections are removed precisely. Undelimited blocks appended by
+// init run from their marker comment to the end of the file, so the file is
+// truncated at the marker. The file is deleted when only a shebang remains.
+func RemoveTimbersHook(hookPath, name string) error {
+	data, err := os.ReadFile(hookPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading hook file: %w", err)
+	}
+	content := string(data)
+	if hasSectionDelimiters(content) {
+		return RemoveTimbersSection(hookPath)
+	}
+
+	idx := strings.Index(content, hookMarker(name))
+	if idx < 0 {
+		return RemoveTimbersSection(hookPath)
+	}
+	remaining := strings.TrimRight(content[:idx], " \t\n") + "\n"
+	stripped := strings.TrimSpace(remaining)
+	if stripped == "" || stripped == "#!/bin/sh" {
+		if removeErr := os.Remove(hookPath); removeErr != nil {
+			return fmt.Errorf("removing hook file: %w", removeErr)
+		}
+		return nil
+	}
+	return atomicWrite(hookPath, remaining)
+}
+
+// RemoveExtraHooks removes timbers content from every detected post-commit
+// and post-rewrite hook.
+func RemoveExtraHooks(info *UninstallInfo) []string {
+	var errs []string
+	for i := range info.ExtraHooks {
+		hook := &info.ExtraHooks[i]
+		if err := RemoveTimbersHook(hook.Path, hook.Name); err != nil {
+			errs = append(errs, hook.Name+": "+err.Error())
+			continue
+		}
+		hook.Removed = true
+	}
+	return errs
+}
+
+// RemoveConfigFile removes the per-repository config file.
+func RemoveConfigFile(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return output.NewSystemErrorWithCause("failed to remove "+config.ProjectFile, err)
+	}
+	return nil
+}
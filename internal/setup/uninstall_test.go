@@ -142,3 +142,114 @@ func TestGatherBinaryPath(t *testing.T) {
 		t.Error("expected non-empty path")
 	}
 }
+
+func TestRemoveTimbersHook(t *testing.T) {
+	tests := []struct {
+		name    string
+		hook    string
+		content string
+		want    string // "" means the file should be deleted
+	}{
+		{
+			name:    "whole-file old format",
+			hook:    "post-commit",
+			content: "#!/bin/sh\n# timbers post-commit hook\ntimbers hook run post-commit \"$@\"\n",
+		},
+		{
+			name:    "undelimited block appended by init",
+			hook:    "post-rewrite",
+			content: "#!/bin/sh\necho mine\n\n# timbers post-rewrite hook\nfind .timbers -name '*.json'\n",
+			want:    "#!/bin/sh\necho mine\n",
+		},
+		{
+			name:    "delimited section",
+			hook:    "pre-commit",
+			content: "#!/bin/sh\necho mine\n" + sectionStart + "\ntimbers hook run pre-commit\n" + sectionEnd + "\n",
+			want:    "#!/bin/sh\necho mine\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hookPath := filepath.Join(t.TempDir(), tt.hook)
+			writeTestFile(t, hookPath, tt.content)
+			if !HasTimbersHook(hookPath, tt.hook) {
+				t.Fatal("HasTimbersHook() = false before removal")
+			}
+
+			if err := RemoveTimbersHook(hookPath, tt.hook); err != nil {
+				t.Fatalf("RemoveTimbersHook() error: %v", err)
+			}
+
+			data, err := os.ReadFile(hookPath)
+			if tt.want == "" {
+				if !os.IsNotExist(err) {
+					t.Errorf("hook should be deleted, got %q", string(data))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("remaining hook = %q, want %q", string(data), tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveGitattributesLine(t *testing.T) {
+	t.Run("keeps other rules", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".gitattributes")
+		writeTestFile(t, path, "*.png binary\n"+GitattributesLine+"\n")
+		if err := RemoveGitattributesLine(path); err != nil {
+			t.Fatalf("RemoveGitattributesLine() error: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "*.png binary\n" {
+			t.Errorf("content = %q, want only the other rule", string(data))
+		}
+	})
+
+	t.Run("deletes file left empty", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".gitattributes")
+		writeTestFile(t, path, GitattributesLine+"\n")
+		if err := RemoveGitattributesLine(path); err != nil {
+			t.Fatalf("RemoveGitattributesLine() error: %v", err)
+		}
+		if HookExists(path) {
+			t.Error(".gitattributes should be deleted when empty")
+		}
+	})
+}
+
+func TestGatherAgentEnvInfo_BothScopes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	project := t.TempDir()
+	t.Chdir(project)
+
+	env := &ClaudeEnv{}
+	for _, scope := range []bool{true, false} {
+		if _, err := env.Install(scope); err != nil {
+			t.Fatalf("Install(%v) error: %v", scope, err)
+		}
+	}
+
+	info := &UninstallInfo{}
+	GatherAgentEnvInfo(info)
+	if len(info.AgentEnvs) != 2 {
+		t.Fatalf("AgentEnvs = %+v, want project and global", info.AgentEnvs)
+	}
+
+	if errs := RemoveAgentEnvs(info); len(errs) > 0 {
+		t.Fatalf("RemoveAgentEnvs() errors: %v", errs)
+	}
+	for _, scope := range []bool{true, false} {
+		if _, _, installed, _ := env.Check(scope); installed {
+			t.Errorf("Check(%v) still installed after removal", scope)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Package telemetry records strictly opt-in, anonymous usage events: which
+// command ran, how long it took, how it exited, and the timbers version.
+// Nothing about the repository, its files, or its ledger is collected.
+// Events are appended to a local spool and sent in batches by Flush.
+package telemetry
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// settingsFile holds the opt-in decision inside the config directory.
+const settingsFile = "telemetry.json"
+
+// Settings is the persisted opt-in state.
+type Settings struct {
+	Enabled bool `json:"enabled"`
+	// InstallID is a random identifier generated at opt-in, so events from
+	// one installation can be grouped without identifying the user.
+	InstallID string    `json:"install_id,omitempty"`
+	ChangedAt time.Time `json:"changed_at,omitzero"`
+}
+
+// LoadSettings reads the opt-in state from dir. A missing file means
+// telemetry was never enabled.
+func LoadSettings(dir string) (Settings, error) {
+	var settings Settings
+	data, err := os.ReadFile(filepath.Join(dir, settingsFile)) //nolint:gosec // config directory path
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, fmt.Errorf("reading telemetry settings: %w", err)
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return settings, fmt.Errorf("parsing telemetry settings: %w", err)
+	}
+	return settings, nil
+}
+
+// SetEnabled records the opt-in decision in dir and returns the new state.
+// Enabling generates an install ID if there is none; disabling discards the
+// ID and any spooled events, so opting out leaves nothing behind.
+func SetEnabled(dir string, enabled bool, now time.Time) (Settings, error) {
+	settings, err := LoadSettings(dir)
+	if err != nil {
+		return settings, err
+	}
+	settings.Enabled = enabled
+	settings.ChangedAt = now.UTC()
+	if enabled && settings.InstallID == "" {
+		settings.InstallID = newInstallID()
+	}
+	if !enabled {
+		settings.InstallID = ""
+		if err = os.Remove(filepath.Join(dir, spoolFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return settings, fmt.Errorf("removing telemetry spool: %w", err)
+		}
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return settings, fmt.Errorf("encoding telemetry settings: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return settings, fmt.Errorf("creating config directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, settingsFile), append(data, '\n'), 0o600); err != nil {
+		return settings, fmt.Errorf("writing telemetry settings: %w", err)
+	}
+	return settings, nil
+}
+
+// EnvDisabled reports whether the environment vetoes telemetry regardless
+// of the opt-in: DO_NOT_TRACK set to anything but 0, or TIMBERS_TELEMETRY
+// set to off, 0, or false.
+func EnvDisabled() bool {
+	if value := os.Getenv("DO_NOT_TRACK"); value != "" && value != "0" {
+		return true
+	}
+	switch strings.ToLower(os.Getenv("TIMBERS_TELEMETRY")) {
+	case "off", "0", "false":
+		return true
+	}
+	return false
+}
+
+// Active reports whether events should be recorded: opted in and not
+// vetoed by the environment.
+func Active(dir string) (Settings, bool) {
+	if dir == "" || EnvDisabled() {
+		return Settings{}, false
+	}
+	settings, err := LoadSettings(dir)
+	if err != nil {
+		return settings, false
+	}
+	return settings, settings.Enabled
+}
+
+// newInstallID returns 16 random bytes as hex.
+func newInstallID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,126 @@
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// spoolFile holds recorded events awaiting Flush, one JSON object per line.
+const spoolFile = "telemetry-spool.jsonl"
+
+// Spool limits. The spool is trimmed to MaxSpooled events, oldest first, so
+// it stays small when nothing is ever sent; a flush is due once FlushBatch
+// events are waiting.
+const (
+	MaxSpooled = 500
+	FlushBatch = 25
+)
+
+// Event is one command invocation. These fields are everything telemetry
+// collects.
+type Event struct {
+	Command    string    `json:"command"`
+	DurationMS int64     `json:"duration_ms"`
+	ExitCode   int       `json:"exit_code"`
+	Version    string    `json:"version"`
+	OS         string    `json:"os"`
+	Arch       string    `json:"arch"`
+	Time       time.Time `json:"time"`
+}
+
+// Record appends event to the spool in dir, trimming the oldest events
+// beyond MaxSpooled.
+func Record(dir string, event Event) error {
+	events, err := Pending(dir)
+	if err != nil {
+		return err
+	}
+	events = append(events, event)
+	if len(events) > MaxSpooled {
+		events = events[len(events)-MaxSpooled:]
+	}
+	return writeSpool(dir, events)
+}
+
+// Pending returns the spooled events in dir, oldest first. Lines that do
+// not parse are dropped.
+func Pending(dir string) ([]Event, error) {
+	data, err := os.ReadFile(filepath.Join(dir, spoolFile)) //nolint:gosec // config directory path
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading telemetry spool: %w", err)
+	}
+	var events []Event
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var event Event
+		if json.Unmarshal(scanner.Bytes(), &event) == nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// Due reports whether enough events are spooled in dir to send a batch.
+func Due(dir string) bool {
+	events, err := Pending(dir)
+	return err == nil && len(events) >= FlushBatch
+}
+
+// Flush sends the spooled events in dir to endpoint as one JSON batch and
+// empties the spool on success. Returns how many events were sent; with no
+// endpoint, nothing is sent and the spool is kept.
+func Flush(ctx context.Context, dir, endpoint, installID string, client *http.Client) (int, error) {
+	events, err := Pending(dir)
+	if err != nil || len(events) == 0 || endpoint == "" {
+		return 0, err
+	}
+	body, err := json.Marshal(map[string]any{"install_id": installID, "events": events})
+	if err != nil {
+		return 0, fmt.Errorf("encoding telemetry batch: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("network error: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return 0, fmt.Errorf("telemetry endpoint returned %d", resp.StatusCode)
+	}
+	return len(events), writeSpool(dir, nil)
+}
+
+// writeSpool replaces the spool in dir with events.
+func writeSpool(dir string, events []Event) error {
+	var buf bytes.Buffer
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("encoding telemetry event: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, spoolFile), buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("writing telemetry spool: %w", err)
+	}
+	return nil
+}
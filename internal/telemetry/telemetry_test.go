@@ -0,0 +1,140 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSetEnabled(t *testing.T) {
+	t.Setenv("DO_NOT_TRACK", "")
+	t.Setenv("TIMBERS_TELEMETRY", "")
+	dir := t.TempDir()
+
+	if _, active := Active(dir); active {
+		t.Fatal("telemetry must be off until opted in")
+	}
+
+	settings, err := SetEnabled(dir, true, time.Now())
+	if err != nil {
+		t.Fatalf("SetEnabled(true) error: %v", err)
+	}
+	if settings.InstallID == "" {
+		t.Error("opting in should generate an install ID")
+	}
+	if _, active := Active(dir); !active {
+		t.Error("expected active after opting in")
+	}
+	if err = Record(dir, Event{Command: "timbers log"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = SetEnabled(dir, false, time.Now()); err != nil {
+		t.Fatalf("SetEnabled(false) error: %v", err)
+	}
+	loaded, err := LoadSettings(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Enabled || loaded.InstallID != "" {
+		t.Errorf("opting out should clear state, got %+v", loaded)
+	}
+	if events, _ := Pending(dir); len(events) != 0 {
+		t.Errorf("opting out should delete the spool, got %d events", len(events))
+	}
+}
+
+func TestEnvDisabled(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := SetEnabled(dir, true, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		doNotTrack, timbers string
+		want                bool
+	}{
+		{"", "", false},
+		{"1", "", true},
+		{"0", "", false},
+		{"", "off", true},
+		{"", "on", false},
+	}
+	for _, tt := range tests {
+		t.Setenv("DO_NOT_TRACK", tt.doNotTrack)
+		t.Setenv("TIMBERS_TELEMETRY", tt.timbers)
+		if got := EnvDisabled(); got != tt.want {
+			t.Errorf("EnvDisabled(DO_NOT_TRACK=%q, TIMBERS_TELEMETRY=%q) = %v, want %v", tt.doNotTrack, tt.timbers, got, tt.want)
+		}
+		if _, active := Active(dir); active == tt.want {
+			t.Errorf("Active() = %v with env veto %v", active, tt.want)
+		}
+	}
+}
+
+func TestRecordTrimsSpool(t *testing.T) {
+	dir := t.TempDir()
+	for i := range MaxSpooled + 10 {
+		if err := Record(dir, Event{Command: "timbers status", ExitCode: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	events, err := Pending(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != MaxSpooled {
+		t.Fatalf("spooled %d events, want %d", len(events), MaxSpooled)
+	}
+	if events[0].ExitCode != 10 {
+		t.Errorf("oldest kept event = %d, want 10 (oldest dropped first)", events[0].ExitCode)
+	}
+	if !Due(dir) {
+		t.Error("expected a flush to be due")
+	}
+}
+
+func TestFlush(t *testing.T) {
+	var received struct {
+		InstallID string  `json:"install_id"`
+		Events    []Event `json:"events"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	if err := Record(dir, Event{Command: "timbers log", DurationMS: 42}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("no endpoint keeps spool", func(t *testing.T) {
+		sent, err := Flush(context.Background(), dir, "", "id", server.Client())
+		if err != nil || sent != 0 {
+			t.Errorf("Flush() = %d, %v; want 0, nil", sent, err)
+		}
+		if events, _ := Pending(dir); len(events) != 1 {
+			t.Errorf("spool should be kept, got %d events", len(events))
+		}
+	})
+
+	t.Run("sends and empties spool", func(t *testing.T) {
+		sent, err := Flush(context.Background(), dir, server.URL, "id", server.Client())
+		if err != nil || sent != 1 {
+			t.Fatalf("Flush() = %d, %v; want 1, nil", sent, err)
+		}
+		if received.InstallID != "id" || len(received.Events) != 1 || received.Events[0].Command != "timbers log" {
+			t.Errorf("server received %+v", received)
+		}
+		if events, _ := Pending(dir); len(events) != 0 {
+			t.Errorf("spool should be empty after flush, got %d events", len(events))
+		}
+	})
+}